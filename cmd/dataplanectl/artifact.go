@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/review"
+	"orchestrator/internal/dataplane/stack"
+	"orchestrator/internal/dataplane/store"
+)
+
+// runArtifact drives one review transition as the human -user names.
+//
+// Each verb takes the artifact it acts on by -artifact, and the verbs that
+// act on the strength of a review name it by -review: the seam accepts on
+// one specific review, never on "whichever acceptance exists", and the
+// command line does not get to be vaguer than the seam.
+func runArtifact(ctx context.Context, cfg *stack.Config, verb string, opts *runOptions) error {
+	switch {
+	case opts.org == "":
+		return fmt.Errorf("artifact %s needs -org <slug>", verb)
+	case opts.user == "":
+		return fmt.Errorf("artifact %s needs -user <handle>: the human the action is recorded as", verb)
+	case opts.artifact == "":
+		return fmt.Errorf("artifact %s needs -artifact <id>", verb)
+	}
+	artifactID, err := uuid.Parse(opts.artifact)
+	if err != nil {
+		return fmt.Errorf("-artifact %q is not a UUID", opts.artifact)
+	}
+
+	seam, err := openSeam(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer seam.Close()
+	desk, err := openDesk(ctx, seam, opts)
+	if err != nil {
+		return err
+	}
+
+	switch verb {
+	case "review":
+		err = runArtifactReview(ctx, seam, desk, artifactID, opts)
+	case "accept", "supersede":
+		err = runArtifactAccept(ctx, desk, verb, artifactID, opts)
+	case "invalidate":
+		if err = desk.Invalidate(ctx, artifactID); err == nil {
+			fmt.Printf("invalidated %s\n", artifactID)
+		}
+	}
+	return withAdvice(err)
+}
+
+// openDesk resolves -org and -user into the desk that acts as that user.
+// Both are resolved, never created: bootstrap is the only thing that makes
+// either.
+func openDesk(ctx context.Context, seam store.Store, opts *runOptions) (*review.Desk, error) {
+	organization, err := seam.GetOrganizationBySlug(ctx, opts.org)
+	if err != nil {
+		return nil, fmt.Errorf("resolve organization %q: %w", opts.org, err)
+	}
+	user, err := seam.GetUserByHandle(ctx, organization.OrganizationID, opts.user)
+	if err != nil {
+		return nil, fmt.Errorf("resolve user %q in %s: %w", opts.user, opts.org, err)
+	}
+	return review.NewDesk(seam, *user)
+}
+
+func runArtifactReview(ctx context.Context, seam store.Store, desk *review.Desk, artifactID uuid.UUID, opts *runOptions) error {
+	// The digest is required rather than looked up: a review binds what
+	// its reviewer READ, and filling it in here would bind it to whatever
+	// the artifact says at the moment the command runs.
+	if opts.digest == "" {
+		return errors.New("artifact review needs -digest <review digest>: the review_digest of the content you read, " +
+			"as the artifact browser shows it")
+	}
+	submission := review.Submission{
+		ArtifactID: artifactID,
+		Seen:       opts.digest,
+		Decision:   store.Decision(opts.decision),
+		Rationale:  opts.rationale,
+	}
+	if opts.base != "" {
+		digest, sequence, err := parseBase(opts.base)
+		if err != nil {
+			return err
+		}
+		submission.BaseDigest, submission.BaseSequence = &digest, &sequence
+	}
+	recorded, err := desk.Record(ctx, submission)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("recorded review %s of %s: %s, as %s\n", recorded.ReviewID, artifactID, recorded.Decision, opts.user)
+
+	// Recorded either way, and said when it cannot accept: a review of
+	// earlier content is a true record that is no use for acceptance, and
+	// the operator should find that out now rather than at `accept`.
+	current, err := seam.GetManagementArtifact(ctx, desk.Reviewer().OrganizationID, artifactID)
+	if err != nil {
+		return fmt.Errorf("re-read artifact %s: %w", artifactID, err)
+	}
+	if current.ReviewDigest != recorded.ReviewDigest {
+		fmt.Printf("  note: the artifact's current review digest is %s; this review is of other content "+
+			"and cannot accept it\n", current.ReviewDigest)
+	}
+	return nil
+}
+
+func runArtifactAccept(ctx context.Context, desk *review.Desk, verb string, artifactID uuid.UUID, opts *runOptions) error {
+	if opts.review == "" {
+		return fmt.Errorf("artifact %s needs -review <id>: the accepted review to act on", verb)
+	}
+	reviewID, err := uuid.Parse(opts.review)
+	if err != nil {
+		return fmt.Errorf("-review %q is not a UUID", opts.review)
+	}
+	if verb == "accept" {
+		if err := desk.Accept(ctx, artifactID, reviewID); err != nil {
+			return err
+		}
+		fmt.Printf("accepted %s with review %s\n", artifactID, reviewID)
+		return nil
+	}
+	target, err := desk.Supersede(ctx, artifactID, reviewID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("accepted %s with review %s; %s is superseded\n", artifactID, reviewID, target)
+	return nil
+}
+
+// parseBase reads -base, which is digest@sequence as the browser shows it.
+func parseBase(base string) (string, int, error) {
+	digest, sequenceText, found := strings.Cut(base, "@")
+	if !found || digest == "" {
+		return "", 0, fmt.Errorf("-base %q is not digest@sequence", base)
+	}
+	sequence, err := strconv.Atoi(sequenceText)
+	if err != nil {
+		return "", 0, fmt.Errorf("-base %q: the sequence is not a number", base)
+	}
+	return digest, sequence, nil
+}
+
+// withAdvice appends what to do next to a refusal that has an answer to
+// that, so the operator reading a failed command reads it too.
+func withAdvice(err error) error {
+	if err == nil {
+		return nil
+	}
+	if advice := review.Advice(err); advice != "" {
+		return fmt.Errorf("%w\n\n%s", err, advice)
+	}
+	return err
+}
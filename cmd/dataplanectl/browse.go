@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"orchestrator/internal/dataplane/browse"
+	"orchestrator/internal/dataplane/stack"
+)
+
+// DefaultBrowseAddress is where `browse` listens unless told otherwise.
+const DefaultBrowseAddress = "127.0.0.1:7780"
+
+// browseShutdownGrace bounds how long an interrupted browser waits for the
+// requests it is serving. They are reads; none of them is worth waiting
+// longer for than an operator will wait for the prompt back.
+const browseShutdownGrace = 5 * time.Second
+
+// runBrowse serves the artifact browser until interrupted.
+//
+// The listen address is checked BEFORE the plane is opened: a browser with
+// no authentication on a non-loopback address is refused outright, and
+// refusing after connecting would be refusing late for no reason.
+func runBrowse(ctx context.Context, cfg *stack.Config, opts *runOptions) error {
+	if opts.org == "" {
+		return errors.New("browse needs -org <slug>")
+	}
+	address := opts.listen
+	if address == "" {
+		address = DefaultBrowseAddress
+	}
+	if err := browse.CheckListenAddress(address); err != nil {
+		return err
+	}
+
+	seam, err := openSeam(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer seam.Close()
+	organization, err := seam.GetOrganizationBySlug(ctx, opts.org)
+	if err != nil {
+		return fmt.Errorf("resolve organization %q: %w", opts.org, err)
+	}
+	handler, err := browse.New(seam, *organization)
+	if err != nil {
+		return err
+	}
+	// Actions only when a human is named: the browser records reviews as
+	// someone, and that someone is decided here, by the person starting it.
+	mode := "read-only"
+	if opts.user != "" {
+		desk, err := openDesk(ctx, seam, opts)
+		if err != nil {
+			return err
+		}
+		if err := handler.EnableActions(desk); err != nil {
+			return err
+		}
+		mode = "reviewing as " + opts.user
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", address)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", address, err)
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	fmt.Printf("browsing %s at http://%s/, %s (Ctrl-C to stop)\n", organization.Slug, listener.Addr(), mode)
+
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+	select {
+	case err := <-served:
+		return fmt.Errorf("serve the artifact browser: %w", err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), browseShutdownGrace)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("stop the artifact browser: %w", err)
+	}
+	return nil
+}
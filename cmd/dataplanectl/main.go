@@ -14,8 +14,10 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"orchestrator/internal/dataplane/paths"
 	"orchestrator/internal/dataplane/stack"
@@ -25,23 +27,34 @@ func main() {
 	composeFile := flag.String("compose", stack.DefaultComposeFile, "path to the data-plane compose file")
 	force := flag.Bool("force", false, "for reset, restore and force-version: proceed without the interactive confirmation")
 	forceVersion := flag.Int("version", -1, "for force-version: the schema version to record")
-	destination := flag.String("to", "", "for backup: the archive directory to create (must not exist)")
+	destination := flag.String("to", "", "for backup: the archive directory to create (must not exist); "+
+		"for backup-online: the directory scheduled archives are kept under")
+	keepDaily := flag.Int("keep-daily", 0, "for backup-online: keep the newest archive of this many days (0 with -keep-weekly 0 keeps all)")
+	keepWeekly := flag.Int("keep-weekly", 0, "for backup-online: keep the newest archive of this many ISO weeks")
+	every := flag.Duration("every", 0, "for backup-online: repeat at this interval until interrupted (0 runs once)")
 	source := flag.String("from", "", "for restore: the archive directory to restore from")
-	org := flag.String("org", "", "for bootstrap and benchmark: the organization slug")
+	org := flag.String("org", "", "for bootstrap, benchmark, browse and artifact: the organization slug")
 	orgName := flag.String("org-name", "", "for bootstrap: the organization's display name (defaults to the slug)")
-	user := flag.String("user", "", "for bootstrap: the user handle")
+	user := flag.String("user", "", "for bootstrap: the user handle; for artifact and browse: the human who acts")
 	userName := flag.String("user-name", "", "for bootstrap: the user's display name (defaults to the handle)")
 	operator := flag.String("operator", "", "for benchmark import: the handle of the operator the report is authored by")
 	results := flag.String("results", "", "for benchmark import: the results store (default "+DefaultResultsDir+")")
 	fileCap := flag.Int64("file-cap", 0, "for benchmark import: the per-file evidence cap in bytes (0 is the default)")
 	attemptCap := flag.Int64("attempt-cap", 0, "for benchmark import: the per-attempt evidence cap in bytes (0 is the default)")
+	listen := flag.String("listen", "", "for browse: the loopback address to serve on (default "+DefaultBrowseAddress+")")
+	artifact := flag.String("artifact", "", "for artifact: the artifact to act on")
+	reviewID := flag.String("review", "", "for artifact accept and supersede: the accepted review to act on")
+	decision := flag.String("decision", "", "for artifact review: accepted, rejected or changes_requested")
+	rationale := flag.String("rationale", "", "for artifact review: why")
+	digest := flag.String("digest", "", "for artifact review: the review digest of the content that was read")
+	base := flag.String("base", "", "for artifact review of an amendment: the base it was read against, as digest@sequence")
 	var suites suiteList
 	flag.Var(&suites, "suite", "for benchmark: a suite run id; repeatable, and for import may be omitted to mean every suite in the store")
 	flag.Usage = usage
 	flag.Parse()
 
-	// One or two words: the lifecycle verbs are single, and `benchmark` is
-	// a group with verbs of its own.
+	// One or two words: the lifecycle verbs are single, and `benchmark` and
+	// `artifact` are groups with verbs of their own.
 	if flag.NArg() < 1 || flag.NArg() > 2 {
 		usage()
 		os.Exit(2)
@@ -56,6 +69,8 @@ func main() {
 		force:        *force,
 		forceVersion: *forceVersion,
 		destination:  *destination,
+		retention:    stack.RetentionPolicy{Daily: *keepDaily, Weekly: *keepWeekly},
+		every:        *every,
 		source:       *source,
 		org:          *org,
 		orgName:      *orgName,
@@ -66,6 +81,13 @@ func main() {
 		suites:       suites,
 		fileCap:      *fileCap,
 		attemptCap:   *attemptCap,
+		listen:       *listen,
+		artifact:     *artifact,
+		review:       *reviewID,
+		decision:     *decision,
+		rationale:    *rationale,
+		digest:       *digest,
+		base:         *base,
 	})
 	stopSignals()
 	if err != nil {
@@ -75,8 +97,9 @@ func main() {
 }
 
 func usage() {
-	fmt.Fprint(os.Stderr, `usage: dataplanectl [flags] <up|down|reset|migrate|force-version|backup|restore|verify|recover-key|
-                                  bootstrap|benchmark import|benchmark show>
+	fmt.Fprint(os.Stderr, `usage: dataplanectl [flags] <up|down|reset|migrate|force-version|backup|backup-online|restore|verify|
+                                  recover-key|rotate-key|retire-key|bootstrap|benchmark import|benchmark show|
+                                  browse|artifact review|artifact accept|artifact invalidate|artifact supersede>
 
   up       start Postgres and MinIO, wait until usable, apply migrations (idempotent)
   down     stop the containers, leaving all data in place
@@ -92,6 +115,14 @@ func usage() {
   backup   stop the plane, copy the data root to -to, restart what was running.
            The archive excludes the root-of-trust key by design, so restoring
            it elsewhere needs the key file too (or new-key recovery).
+  backup-online
+           take an archive of the RUNNING plane under the directory -to, without
+           stopping it: a pg_basebackup of Postgres plus a copy of the object
+           store that hard-links files an earlier archive already holds. Each
+           archive is verified as restore would check it, then older ones are
+           pruned by -keep-daily / -keep-weekly. With -every it repeats until
+           interrupted:
+               dataplanectl -to /backups -keep-daily 7 -keep-weekly 4 -every 24h backup-online
   restore  replace the data root from the archive at -from. Requires -force
            when the data root already holds a plane.
   verify   recompute every stored digest and read every attachment, which is
@@ -102,6 +133,16 @@ func usage() {
            which must then be re-entered. Requires -force to skip the prompt.
            This is the second of ADR 0022's two restore branches; the first
            is simply restoring the original key file.
+  rotate-key
+           replace a WORKING root key: mint a new one, re-seal every stored
+           secret under it, and move the database and object-store
+           credentials to it. Nothing is lost, and an interrupted rotation
+           resumes by running it again. The old key is kept beside the new
+           one, and still opens secrets, until retire-key.
+  retire-key
+           end a rotation: re-seal anything still under the old key, then
+           DESTROY the old key. Run it against the running plane once you
+           are satisfied with the new key.
   bootstrap
            provision an organization and a user. Nothing else creates
            either, and the importer resolves them and never creates them.
@@ -119,6 +160,31 @@ func usage() {
            read one suite back out of the plane: its attempts, their
            verdicts, what its report holds, and what the import left out.
            Requires -org and exactly one -suite.
+  browse   serve a web view of one organization's artifacts on a
+           loopback address until interrupted: listings by scope and story,
+           raw and effective payloads with their amendment history, the
+           review chain with each reviewer's lineage, and pinned evidence
+           down to the attachment bytes. Every page is also JSON under /api.
+           With -user, the pages also offer the review actions below, as
+           that human; without it they only read.
+               dataplanectl -org acme -user dr browse
+  artifact review
+           record a review of -artifact as the human -user names. -decision,
+           -rationale and -digest are required; -digest is the review digest
+           of the content you read, and an amendment also needs -base, the
+           digest@sequence of the base you read it against. Both are on the
+           artifact's page in the browser. Refused if -user authored it.
+               dataplanectl -org acme -user dr -artifact <id> -decision accepted \
+                   -rationale "matches the story" -digest <hex> artifact review
+  artifact accept
+           accept a draft -artifact with the accepted -review named. An
+           amendment is checked against the base its review recorded; if
+           another amendment was accepted since, it must be reviewed again.
+  artifact supersede
+           accept a draft that supersedes another, with -review, retiring
+           the artifact it names in the same step.
+  artifact invalidate
+           withdraw a draft -artifact that was never accepted.
 
 flags:
 `)
@@ -130,6 +196,8 @@ flags:
 type runOptions struct {
 	composeFile  string
 	destination  string
+	retention    stack.RetentionPolicy
+	every        time.Duration
 	source       string
 	org          string
 	orgName      string
@@ -137,6 +205,13 @@ type runOptions struct {
 	userName     string
 	operator     string
 	results      string
+	listen       string
+	artifact     string
+	review       string
+	decision     string
+	rationale    string
+	digest       string
+	base         string
 	suites       suiteList
 	fileCap      int64
 	attemptCap   int64
@@ -173,6 +248,9 @@ func run(ctx context.Context, command string, opts *runOptions) error {
 	case "backup":
 		return runBackup(ctx, cfg, opts)
 
+	case "backup-online":
+		return runOnlineBackup(ctx, cfg, opts)
+
 	case "restore":
 		return runRestore(ctx, cfg, opts)
 
@@ -182,6 +260,12 @@ func run(ctx context.Context, command string, opts *runOptions) error {
 	case "recover-key":
 		return runRecoverKey(ctx, cfg, opts)
 
+	case "rotate-key":
+		return runRotateKey(ctx, cfg, opts)
+
+	case "retire-key":
+		return runRetireKey(ctx, cfg, opts)
+
 	default:
 		return runPlaneCommand(ctx, cfg, command, opts)
 	}
@@ -204,6 +288,12 @@ func runPlaneCommand(ctx context.Context, cfg *stack.Config, command string, opt
 	case "benchmark show":
 		return runBenchmarkShow(ctx, cfg, opts)
 
+	case "browse":
+		return runBrowse(ctx, cfg, opts)
+
+	case "artifact review", "artifact accept", "artifact invalidate", "artifact supersede":
+		return runArtifact(ctx, cfg, strings.TrimPrefix(command, "artifact "), opts)
+
 	default:
 		usage()
 		return fmt.Errorf("unknown command %q", command)
@@ -344,6 +434,35 @@ func confirmRecoverKey(cfg *stack.Config) bool {
 	return answer == confirmationWord
 }
 
+// runRotateKey moves a working plane onto a new root key.
+//
+// No prompt: unlike recover-key nothing is deleted, and the one step that is
+// irreversible — destroying the old key — is retire-key's.
+func runRotateKey(ctx context.Context, cfg *stack.Config, opts *runOptions) error {
+	report, err := stack.RotateKey(ctx, cfg, opts.composeFile)
+	if err != nil {
+		return fmt.Errorf("rotate the data plane's root key: %w", err)
+	}
+	fmt.Printf("the data plane now opens with a new key at %s\n"+
+		"  %d secret(s) re-sealed, %d already under the new key\n"+
+		"  the old key is held at %s and still opens secrets;\n"+
+		"  run retire-key to destroy it once you are satisfied with the new one\n",
+		cfg.Roots.KeyPath(), report.Resealed, report.Current,
+		filepath.Join(cfg.Roots.Config, stack.RetiringKeyFile))
+	return nil
+}
+
+// runRetireKey ends a rotation's dual-key period.
+func runRetireKey(ctx context.Context, cfg *stack.Config, _ *runOptions) error {
+	report, err := stack.RetireKey(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("retire the data plane's old root key: %w", err)
+	}
+	fmt.Printf("checked %d secret(s): %d were still under the old key and were re-sealed\n"+
+		"the old key has been destroyed\n", report.Secrets, report.Resealed)
+	return nil
+}
+
 // runBackup copies the data root to a new archive directory.
 func runBackup(ctx context.Context, cfg *stack.Config, opts *runOptions) error {
 	if opts.destination == "" {
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"orchestrator/internal/dataplane/stack"
+)
+
+// runOnlineBackup takes one scheduled archive, or keeps taking them every
+// opts.every until the context is cancelled.
+//
+// The loop lives here rather than in the stack package because it is a
+// policy about WHEN, and every stack entry point is an operation that takes
+// the lifecycle lock for its own duration. A loop inside one would hold the
+// lock between runs and shut `restore` and `reset` out for as long as the
+// schedule ran.
+func runOnlineBackup(ctx context.Context, cfg *stack.Config, opts *runOptions) error {
+	if opts.destination == "" {
+		return errors.New("backup-online needs -to <directory> to keep its archives under")
+	}
+	if opts.every < 0 {
+		return fmt.Errorf("-every must not be negative, got %s", opts.every)
+	}
+	if opts.every == 0 {
+		return onlineBackupOnce(ctx, cfg, opts)
+	}
+
+	ticker := time.NewTicker(opts.every)
+	defer ticker.Stop()
+	for {
+		// A failed run is reported and the schedule carries on. Stopping on
+		// the first failure would turn one transient outage into no backups
+		// at all until somebody noticed; the failure is on stderr, and the
+		// archives it did not replace are still there because retention
+		// never runs after a failed one.
+		if err := onlineBackupOnce(ctx, cfg, opts); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "dataplanectl: scheduled backup failed: %v\n", err)
+		}
+		fmt.Printf("next backup at %s\n", time.Now().Add(opts.every).Format(time.RFC3339))
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// onlineBackupOnce runs one archive and prints what it did.
+func onlineBackupOnce(ctx context.Context, cfg *stack.Config, opts *runOptions) error {
+	report, err := stack.OnlineBackup(ctx, cfg, opts.composeFile, opts.destination, opts.retention)
+	if report.Archive != "" {
+		fmt.Printf("archive written and verified at %s\n"+
+			"  %d object-store files: %d copied (%d bytes), %d reused from earlier archives (%d bytes)\n",
+			report.Archive, report.Objects, report.Objects-report.Reused, report.CopiedBytes,
+			report.Reused, report.ReusedBytes)
+		if report.Vanished > 0 {
+			fmt.Printf("  %d files were removed by the object store while the copy ran\n", report.Vanished)
+		}
+		for _, pruned := range report.Pruned {
+			fmt.Printf("  pruned %s\n", pruned)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("take an online backup: %w", err)
+	}
+	return nil
+}
@@ -157,6 +157,11 @@ func (f *OrchestratorFlow) Run(ctx context.Context, k *kernel.Kernel) error {
 			if _, mirrorErr := mirrorMgr.EnsureMirror(ctx); mirrorErr != nil {
 				return fmt.Errorf("mirror creation required before spec injection: %w", mirrorErr)
 			}
+			for _, repo := range config.GetRepos()[1:] {
+				if _, mirrorErr := mirror.NewRepoManager(k.ProjectDir(), repo.Name).EnsureMirror(ctx); mirrorErr != nil {
+					return fmt.Errorf("mirror creation for %s required before spec injection: %w", repo.Name, mirrorErr)
+				}
+			}
 			k.Logger.Info("✅ Mirror ready before spec injection")
 		}
 
@@ -37,6 +37,7 @@ func main() {
 		airplaneMode  = flag.Bool("airplane", false, "Run in airplane mode (offline with local Gitea + Ollama)")
 		syncMode      = flag.Bool("sync", false, "Sync offline changes from Gitea to GitHub and exit")
 		syncDryRun    = flag.Bool("sync-dry-run", false, "Preview sync without making changes (use with --sync)")
+		syncStrategy  = flag.String("sync-strategy", "merge", "How to integrate offline work when GitHub moved: merge or rebase (use with --sync)")
 		runMode       = flag.Bool("run", false, "Run app with dependencies only (no orchestrator)")
 		telemetryFlag = flag.String("telemetry", "", "Enable or disable failure telemetry reporting (true/false)")
 	)
@@ -66,7 +67,7 @@ func main() {
 
 	// Handle sync mode (runs and exits before full orchestrator startup)
 	if *syncMode {
-		exitCode := runSyncMode(*projectDir, *syncDryRun)
+		exitCode := runSyncMode(*projectDir, *syncDryRun, *syncStrategy)
 		os.Exit(exitCode)
 	}
 
@@ -532,10 +533,16 @@ func initializeKernel(projectDir string) (*kernel.Kernel, context.Context, error
 
 // runSyncMode handles the --sync flag to sync offline changes to GitHub.
 // This runs independently and exits without starting the full orchestrator.
-func runSyncMode(projectDir string, dryRun bool) int {
+func runSyncMode(projectDir string, dryRun bool, strategyName string) int {
 	fmt.Println("🔄 Maestro Sync")
 	fmt.Println()
 
+	strategy, err := sync.ParseStrategy(strategyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return 1
+	}
+
 	// Load configuration
 	if err := config.LoadConfig(projectDir); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
@@ -576,7 +583,7 @@ func runSyncMode(projectDir string, dryRun bool) int {
 	}
 
 	// Run sync
-	result, err := syncer.SyncToGitHub(ctx)
+	result, err := syncer.WithStrategy(strategy).SyncToGitHub(ctx)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Sync failed: %v\n", err)
 		return 1
@@ -709,10 +716,14 @@ func printSyncResult(result *sync.Result, dryRun bool) {
 		fmt.Println("╔════════════════════════════════════════════════════════════════════╗")
 		fmt.Println("║                    📋 Sync Preview (Dry Run)                       ║")
 		fmt.Println("╚════════════════════════════════════════════════════════════════════╝")
-	} else {
+	} else if result.Success {
 		fmt.Println("╔════════════════════════════════════════════════════════════════════╗")
 		fmt.Println("║                    ✅ Sync Complete                                 ║")
 		fmt.Println("╚════════════════════════════════════════════════════════════════════╝")
+	} else {
+		fmt.Println("╔════════════════════════════════════════════════════════════════════╗")
+		fmt.Println("║                    ⚠️  Sync Incomplete                              ║")
+		fmt.Println("╚════════════════════════════════════════════════════════════════════╝")
 	}
 	fmt.Println()
 
@@ -724,13 +735,61 @@ func printSyncResult(result *sync.Result, dryRun bool) {
 		fmt.Println()
 	}
 
+	if len(result.BranchesUnchanged) > 0 {
+		fmt.Printf("✔️  Branches already on GitHub: %d\n", len(result.BranchesUnchanged))
+		fmt.Println()
+	}
+
+	if len(result.BranchesSkipped) > 0 {
+		fmt.Println("⏭️  Branches changed on GitHub (not pushed):")
+		for _, branch := range result.BranchesSkipped {
+			fmt.Printf("   • %s\n", branch)
+		}
+		fmt.Println()
+	}
+
+	if target := result.Target; target != nil {
+		fmt.Printf("🔀 Target branch: %s", target.Divergence)
+		if target.Divergence == sync.DivergenceDiverged {
+			fmt.Printf(" (%d offline, %d upstream commits)", target.Ahead, target.Behind)
+		}
+		fmt.Println()
+		for _, commit := range target.OfflineCommits {
+			fmt.Printf("   • %s\n", commit)
+		}
+		if len(target.Conflicts) > 0 {
+			fmt.Printf("❌ The %s stopped on conflicts in:\n", target.Strategy)
+			for _, file := range target.Conflicts {
+				fmt.Printf("   • %s\n", file)
+			}
+			fmt.Println("   Resolve them on Gitea or GitHub, then run --sync again.")
+		} else if target.IntegratedSHA != "" {
+			fmt.Printf("%s   Offline work integrated by %s\n", prefix, target.Strategy)
+		}
+	}
+
 	if result.MainPushed {
 		fmt.Printf("%s🎯 Main branch: pushed\n", prefix)
 	} else if result.MainUpToDate {
 		fmt.Printf("%s🎯 Main branch: already up-to-date\n", prefix)
 	}
+	if result.GiteaUpdated {
+		fmt.Printf("%s📥 Gitea: updated with upstream changes\n", prefix)
+	}
 	fmt.Println()
 
+	if len(result.PRsMigrated) > 0 {
+		fmt.Printf("%s🔁 Pull requests migrated:\n", prefix)
+		for _, pr := range result.PRsMigrated {
+			if pr.GitHubURL != "" {
+				fmt.Printf("   • Gitea #%d %s → %s\n", pr.GiteaNumber, pr.Title, pr.GitHubURL)
+			} else {
+				fmt.Printf("   • Gitea #%d %s (%s → %s)\n", pr.GiteaNumber, pr.Title, pr.Head, pr.Base)
+			}
+		}
+		fmt.Println()
+	}
+
 	if result.MirrorUpdated {
 		fmt.Printf("%s📥 Mirror: updated from GitHub\n", prefix)
 	}
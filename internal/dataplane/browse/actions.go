@@ -0,0 +1,170 @@
+package browse
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/review"
+	"orchestrator/internal/dataplane/store"
+)
+
+// Actions is the write side a browser may be given. review.Desk satisfies
+// it; the interface keeps this package from constructing one, so the human
+// it acts as is decided by whoever started the browser and by nothing a
+// request says.
+type Actions interface {
+	Reviewer() store.User
+	Record(ctx context.Context, submission review.Submission) (*store.Review, error)
+	Accept(ctx context.Context, artifactID, reviewID uuid.UUID) error
+	Invalidate(ctx context.Context, artifactID uuid.UUID) error
+	Supersede(ctx context.Context, supersedingID, reviewID uuid.UUID) (uuid.UUID, error)
+}
+
+var _ Actions = (*review.Desk)(nil)
+
+// maxFormBytes bounds an action's body. A rationale is prose, and nothing a
+// person types into a form needs more.
+const maxFormBytes = 64 << 10
+
+// EnableActions lets the browser record reviews and drive the transitions
+// that follow them, as the human actions acts for.
+//
+// A page that writes has to be defended against other pages, which a
+// read-only one did not: a site the operator visits can submit a form to
+// 127.0.0.1 with the operator's browser, and the loopback check in
+// ServeHTTP cannot tell, because the Host is genuinely loopback. Two
+// defences, each sufficient against the browsers that honour it:
+//
+//   - http.CrossOriginProtection refuses a request the browser marks as
+//     cross-site (Sec-Fetch-Site, or an Origin that is not this host).
+//   - Every form carries a token minted here, per server. A page elsewhere
+//     cannot read it — ServeHTTP's Host check keeps a rebinding page from
+//     loading ours — so it cannot forge a form that carries it.
+func (s *Server) EnableActions(actions Actions) error {
+	if actions == nil {
+		return errors.New("artifact browser actions need a review desk")
+	}
+	if actions.Reviewer().OrganizationID != s.organization.OrganizationID {
+		return errors.New("the reviewer belongs to a different organization than the browser serves")
+	}
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("mint the form token: %w", err)
+	}
+	s.actions, s.token = actions, hex.EncodeToString(token)
+	s.crossOrigin = http.NewCrossOriginProtection()
+
+	s.mux.HandleFunc("POST /artifacts/{id}/reviews", s.act(s.recordReview))
+	s.mux.HandleFunc("POST /artifacts/{id}/accept", s.act(s.accept))
+	s.mux.HandleFunc("POST /artifacts/{id}/supersede", s.act(s.supersede))
+	s.mux.HandleFunc("POST /artifacts/{id}/invalidate", s.act(s.invalidate))
+	return nil
+}
+
+// act wraps one action: refuse anything that is not this browser's own
+// form, run it, and send the browser back to the artifact it acted on.
+// Post/redirect/get, so a reload re-reads the artifact rather than
+// resubmitting the decision.
+func (s *Server) act(action func(*http.Request, uuid.UUID) (uuid.UUID, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := s.crossOrigin.Check(r); err != nil {
+			s.fail(w, r, false, fmt.Errorf("%w: %w", errForbidden, err))
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxFormBytes)
+		if err := r.ParseForm(); err != nil {
+			s.fail(w, r, false, fmt.Errorf("%w: %w", errBadRequest, err))
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(r.PostForm.Get("token")), []byte(s.token)) != 1 {
+			s.fail(w, r, false, fmt.Errorf("%w: the form is not one this browser served; reload the page", errForbidden))
+			return
+		}
+		id, err := pathID(r, "id")
+		if err != nil {
+			s.fail(w, r, false, err)
+			return
+		}
+		next, err := action(r, id)
+		if err != nil {
+			s.fail(w, r, false, err)
+			return
+		}
+		http.Redirect(w, r, "/artifacts/"+next.String(), http.StatusSeeOther)
+	}
+}
+
+func (s *Server) recordReview(r *http.Request, id uuid.UUID) (uuid.UUID, error) {
+	submission := review.Submission{
+		ArtifactID: id,
+		Seen:       r.PostForm.Get("seen"),
+		Decision:   store.Decision(r.PostForm.Get("decision")),
+		Rationale:  r.PostForm.Get("rationale"),
+	}
+	if digest := r.PostForm.Get("base_digest"); digest != "" {
+		sequence, err := strconv.Atoi(r.PostForm.Get("base_sequence"))
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%w: base sequence %q is not a number", errBadRequest, r.PostForm.Get("base_sequence"))
+		}
+		submission.BaseDigest, submission.BaseSequence = &digest, &sequence
+	}
+	if _, err := s.actions.Record(r.Context(), submission); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *Server) accept(r *http.Request, id uuid.UUID) (uuid.UUID, error) {
+	reviewID, err := formID(r, "review_id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, s.actions.Accept(r.Context(), id, reviewID)
+}
+
+func (s *Server) supersede(r *http.Request, id uuid.UUID) (uuid.UUID, error) {
+	reviewID, err := formID(r, "review_id")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if _, err := s.actions.Supersede(r.Context(), id, reviewID); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (s *Server) invalidate(r *http.Request, id uuid.UUID) (uuid.UUID, error) {
+	return id, s.actions.Invalidate(r.Context(), id)
+}
+
+// formID parses a form field as a UUID.
+func formID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(r.PostForm.Get(name))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s %q is not a UUID", errBadRequest, name, r.PostForm.Get(name))
+	}
+	return id, nil
+}
+
+// actionForms is what a page needs to offer actions: the token its forms
+// carry, and who they act as.
+type actionForms struct {
+	Token    string
+	Reviewer store.User
+}
+
+// Authored reports whether principal is this browser's human, so a page
+// can say why it offers no review form rather than offering one the desk
+// will refuse.
+func (a *actionForms) Authored(principal *Principal) bool {
+	return principal != nil && principal.Kind == store.PrincipalHuman &&
+		principal.UserID != nil && *principal.UserID == a.Reviewer.UserID
+}
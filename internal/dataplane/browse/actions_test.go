@@ -0,0 +1,258 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/review"
+	"orchestrator/internal/dataplane/store"
+)
+
+// writablePlane is fakeSource with the transitions a review desk drives. It
+// applies them without the seam's rules; the rules under test here are the
+// browser's and the desk's.
+type writablePlane struct {
+	*fakeSource
+	acceptErr error
+	stopped   map[uuid.UUID]string
+}
+
+//nolint:gocritic // hugeParam: matches the seam's signature
+func (w *writablePlane) CreatePrincipalInstance(_ context.Context, input store.CreatePrincipalInstanceInput) (*store.PrincipalInstance, error) {
+	instance := store.PrincipalInstance{
+		PrincipalInstanceID: uuid.New(), Kind: input.Kind, UserID: input.UserID, Model: input.Model, StartTime: time.Now(),
+	}
+	w.principals[instance.PrincipalInstanceID] = instance
+	return &instance, nil
+}
+
+func (w *writablePlane) StopPrincipalInstance(_ context.Context, _, id uuid.UUID, reason string) (store.StopOutcome, error) {
+	w.stopped[id] = reason
+	return store.StopOutcome{Recorded: true, Reason: reason}, nil
+}
+
+//nolint:gocritic // hugeParam: matches the seam's signature
+func (w *writablePlane) CreateReview(_ context.Context, input store.CreateReviewInput) (*store.Review, error) {
+	recorded := store.Review{
+		ReviewID: uuid.New(), ArtifactID: input.ArtifactID, ReviewerInstanceID: input.ReviewerInstanceID,
+		Decision: input.Decision, ReviewDigest: input.ReviewDigest, Rationale: input.Rationale,
+		BaseDigest: input.BaseDigest, BaseSequence: input.BaseSequence, DecidedAt: time.Now(),
+	}
+	w.reviews[input.ArtifactID] = append(w.reviews[input.ArtifactID], recorded)
+	return &recorded, nil
+}
+
+func (w *writablePlane) transition(id uuid.UUID, status store.Status) {
+	artifact := w.management[id]
+	artifact.Status = status
+	w.management[id] = artifact
+}
+
+func (w *writablePlane) AcceptArtifact(_ context.Context, _, id, _ uuid.UUID) error {
+	if w.acceptErr != nil {
+		return w.acceptErr
+	}
+	w.transition(id, store.StatusAccepted)
+	return nil
+}
+
+func (w *writablePlane) AcceptAmendment(ctx context.Context, org, id, reviewID uuid.UUID) error {
+	return w.AcceptArtifact(ctx, org, id, reviewID)
+}
+
+func (w *writablePlane) InvalidateArtifact(_ context.Context, _, id uuid.UUID) error {
+	w.transition(id, store.StatusInvalidated)
+	return nil
+}
+
+func (w *writablePlane) SupersedeArtifact(_ context.Context, _, target, superseding, _ uuid.UUID) error {
+	w.transition(superseding, store.StatusAccepted)
+	w.transition(target, store.StatusSuperseded)
+	return nil
+}
+
+// acting serves p with actions enabled, as a human who authored nothing in
+// it.
+func (p *plane) acting(t *testing.T) (*httptest.Server, *writablePlane, store.User) {
+	t.Helper()
+	organization := store.Organization{OrganizationID: uuid.New(), Slug: "acme", DisplayName: "Acme"}
+	writable := &writablePlane{fakeSource: p.source, stopped: map[uuid.UUID]string{}}
+	user := store.User{UserID: uuid.New(), OrganizationID: organization.OrganizationID, Handle: "dr"}
+	desk, err := review.NewDesk(writable, user)
+	if err != nil {
+		t.Fatalf("NewDesk: %v", err)
+	}
+	server, err := New(writable, organization)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := server.EnableActions(desk); err != nil {
+		t.Fatalf("EnableActions: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer, writable, user
+}
+
+var tokenField = regexp.MustCompile(`name="token" value="([0-9a-f]+)"`)
+
+// formToken reads the token a page's forms carry, as a browser would.
+func formToken(t *testing.T, page string) string {
+	t.Helper()
+	match := tokenField.FindStringSubmatch(page)
+	if match == nil {
+		t.Fatal("the page offers no action form")
+	}
+	return match[1]
+}
+
+// post submits a form without following the redirect, so the test sees
+// where the action sent the browser.
+func post(t *testing.T, target string, form url.Values, header http.Header) (*http.Response, string) {
+	t.Helper()
+	request, _ := http.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode())) //nolint:noctx // test
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("POST %s: %v", target, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, _ := io.ReadAll(response.Body)
+	return response, string(body)
+}
+
+// TestReviewThenAcceptFromTheBrowser walks the path a person takes: read
+// the draft amendment against its base, record a review bound to both, and
+// accept with it.
+func TestReviewThenAcceptFromTheBrowser(t *testing.T) {
+	p := newPlane()
+	server, writable, user := p.acting(t)
+	page := server.URL + "/artifacts/" + p.draft.String()
+
+	_, body := get(t, page)
+	if !strings.Contains(body, "reviewing as <strong>dr</strong>") {
+		t.Fatal("a browser that writes does not say who it writes as")
+	}
+	base := "base-of-" + p.original.String()[:8]
+	if !strings.Contains(body, base+"@1") {
+		t.Fatal("the amendment's page does not show the base its review will record")
+	}
+	token := formToken(t, body)
+
+	response, _ := post(t, page+"/reviews", url.Values{
+		"token": {token}, "seen": {p.source.management[p.draft].ReviewDigest},
+		"base_digest": {base}, "base_sequence": {"1"},
+		"decision": {"accepted"}, "rationale": {"the size is right"},
+	}, nil)
+	if response.StatusCode != http.StatusSeeOther || response.Header.Get("Location") != "/artifacts/"+p.draft.String() {
+		t.Fatalf("record review: status %d, location %q", response.StatusCode, response.Header.Get("Location"))
+	}
+	recorded := p.source.reviews[p.draft]
+	if len(recorded) != 1 || recorded[0].BaseDigest == nil || *recorded[0].BaseDigest != base {
+		t.Fatalf("reviews = %+v, want one bound to the base the page showed", recorded)
+	}
+	reviewer := p.source.principals[recorded[0].ReviewerInstanceID]
+	if reviewer.Kind != store.PrincipalHuman || *reviewer.UserID != user.UserID {
+		t.Fatalf("the review was given by %+v, not the browser's human", reviewer)
+	}
+	if writable.stopped[reviewer.PrincipalInstanceID] == "" {
+		t.Fatal("the reviewer's instance was left open")
+	}
+
+	_, body = get(t, page)
+	if !strings.Contains(body, `action="/artifacts/`+p.draft.String()+`/accept"`) {
+		t.Fatal("the page does not offer acceptance with the current accepted review")
+	}
+	response, _ = post(t, page+"/accept", url.Values{
+		"token": {token}, "review_id": {recorded[0].ReviewID.String()},
+	}, nil)
+	if response.StatusCode != http.StatusSeeOther || p.source.management[p.draft].Status != store.StatusAccepted {
+		t.Fatalf("accept: status %d, artifact %s", response.StatusCode, p.source.management[p.draft].Status)
+	}
+}
+
+// A form is accepted only from this browser's own pages.
+func TestActionsRefuseForeignForms(t *testing.T) {
+	p := newPlane()
+	server, _, _ := p.acting(t)
+	page := server.URL + "/artifacts/" + p.draft.String()
+	_, body := get(t, page)
+	token := formToken(t, body)
+
+	cases := map[string]struct {
+		form   url.Values
+		header http.Header
+	}{
+		"no token":    {url.Values{}, nil},
+		"wrong token": {url.Values{"token": {strings.Repeat("0", len(token))}}, nil},
+		"cross-site":  {url.Values{"token": {token}}, http.Header{"Sec-Fetch-Site": {"cross-site"}}},
+		"other origin": {url.Values{"token": {token}},
+			http.Header{"Origin": {"https://attacker.example"}}},
+	}
+	for name, c := range cases {
+		if response, _ := post(t, page+"/invalidate", c.form, c.header); response.StatusCode != http.StatusForbidden {
+			t.Errorf("%s: status %d, want 403", name, response.StatusCode)
+		}
+	}
+	if p.source.management[p.draft].Status != store.StatusDraft {
+		t.Fatal("a refused form changed the artifact")
+	}
+
+	// A browser started without a reviewer has no action routes at all.
+	readOnly := newPlane().serve(t)
+	response, _ := post(t, readOnly.URL+"/artifacts/"+p.draft.String()+"/invalidate", url.Values{"token": {token}}, nil)
+	if response.StatusCode != http.StatusNotFound {
+		t.Fatalf("a read-only browser answered an action with %d", response.StatusCode)
+	}
+}
+
+// TestRefusedActionsSayWhatToDoNext: a moved base is a conflict whose
+// advice says retrying cannot work, and self-review is refused, with the
+// page explaining why it offered no form.
+func TestRefusedActionsSayWhatToDoNext(t *testing.T) {
+	p := newPlane()
+	server, writable, user := p.acting(t)
+	page := server.URL + "/artifacts/" + p.draft.String()
+	_, body := get(t, page)
+	token := formToken(t, body)
+
+	writable.acceptErr = fmt.Errorf("%w: reviewed at sequence 1, now 2", store.ErrBaseMoved)
+	response, body := post(t, page+"/accept", url.Values{"token": {token}, "review_id": {uuid.NewString()}}, nil)
+	if response.StatusCode != http.StatusConflict || !strings.Contains(body, "Retrying cannot succeed") {
+		t.Fatalf("base moved: status %d; body %s", response.StatusCode, body)
+	}
+
+	// Make the operator the draft's author.
+	self := store.PrincipalInstance{PrincipalInstanceID: uuid.New(), Kind: store.PrincipalHuman, UserID: &user.UserID}
+	p.source.principals[self.PrincipalInstanceID] = self
+	draft := p.source.management[p.draft]
+	draft.AuthorInstanceID = self.PrincipalInstanceID
+	p.source.management[p.draft] = draft
+
+	_, body = get(t, page)
+	if !strings.Contains(body, "You authored this artifact") || strings.Contains(body, `/reviews"`) {
+		t.Fatal("the page offers its author a review form")
+	}
+	response, _ = post(t, page+"/reviews", url.Values{
+		"token": {token}, "seen": {draft.ReviewDigest}, "base_digest": {"x"}, "base_sequence": {strconv.Itoa(1)},
+		"decision": {"accepted"}, "rationale": {"mine, and fine"},
+	}, nil)
+	if response.StatusCode != http.StatusForbidden || len(p.source.reviews[p.draft]) != 0 {
+		t.Fatalf("self-review: status %d, %d reviews recorded", response.StatusCode, len(p.source.reviews[p.draft]))
+	}
+}
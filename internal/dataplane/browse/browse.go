@@ -0,0 +1,316 @@
+// Package browse is a read-only web view of one organization's artifacts.
+//
+// Until now the only way to look at what the plane holds was SQL, which
+// answers the question asked and nothing beside it: a row does not say
+// which amendments apply to it, who reviewed it, or what its pins keep
+// alive. This package assembles those answers from the seam's own reads and
+// serves each one twice — as an HTML page for a person and as JSON at the
+// same path under /api for a script — from the SAME view value, so the two
+// cannot disagree about what an artifact is.
+//
+// It reads through the seam and never around it. Every read goes through
+// store.Reader or the object store, so the registry's readability rule, the
+// organization scoping and attachment verification all apply here exactly
+// as they apply to any other caller; a browser with its own queries would
+// be a second, unreviewed account of the plane.
+//
+// It has no authentication, because the plane it serves has none: local
+// mode is one operator's machine. What it does have is a refusal to answer
+// anything but loopback (see Server.ServeHTTP), so that "local" stays true
+// of the listener and of every page a browser loads from it.
+//
+// It is read-only unless given Actions (see Server.EnableActions), and even
+// then the only writes are the review transitions a human drives, through
+// the same desk the command line uses.
+package browse
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/review"
+	"orchestrator/internal/dataplane/store"
+)
+
+// Source is what the browser reads. store.Store satisfies it; the interface
+// is narrower so a test can stand one up without implementing the whole
+// seam, and so a reader can see at a glance that nothing here writes.
+type Source interface {
+	GetManagementArtifact(ctx context.Context, organizationID, artifactID uuid.UUID) (*store.ManagementArtifact, error)
+	GetAuditArtifact(ctx context.Context, organizationID, artifactID uuid.UUID) (*store.AuditArtifact, error)
+	EffectiveView(ctx context.Context, organizationID, artifactID uuid.UUID) (json.RawMessage, error)
+	AmendmentBase(ctx context.Context, organizationID, originalID uuid.UUID) (store.AmendmentBase, error)
+
+	ListManagementArtifactsByScope(ctx context.Context, organizationID uuid.UUID, scope store.Scope) ([]store.ManagementArtifact, error)
+	ListManagementArtifactsByStory(ctx context.Context, organizationID, storyID uuid.UUID) ([]store.ManagementArtifact, error)
+	ListAuditArtifactsByScope(ctx context.Context, organizationID uuid.UUID, scope store.Scope) ([]store.AuditArtifact, error)
+
+	ListReviews(ctx context.Context, organizationID, artifactID uuid.UUID) ([]store.Review, error)
+	GetPrincipalInstance(ctx context.Context, organizationID, instanceID uuid.UUID) (*store.PrincipalInstance, error)
+	ListSeededInputs(ctx context.Context, organizationID, instanceID uuid.UUID) ([]store.SeededInput, error)
+
+	ListPins(ctx context.Context, organizationID, artifactID uuid.UUID) ([]store.Pin, error)
+	GetAttachment(ctx context.Context, organizationID, attachmentID uuid.UUID) (io.ReadCloser, *store.Attachment, error)
+}
+
+var _ Source = store.Store(nil)
+
+// ErrNotLoopback reports a listen address that would expose the browser
+// beyond this machine.
+var ErrNotLoopback = errors.New("the artifact browser listens on loopback only")
+
+// errBadRequest marks a request this package refuses before reading
+// anything: a malformed identifier or an unknown scope type.
+var errBadRequest = errors.New("bad request")
+
+// errForbidden marks a write this package refuses to attempt: one from
+// another origin, or without the form token.
+var errForbidden = errors.New("forbidden")
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// apiPrefix is where every page's JSON twin lives.
+const apiPrefix = "/api"
+
+// Server serves one organization's artifacts.
+//
+// One organization per server, fixed at construction, because every seam
+// read is organization-scoped and a request parameter choosing it would be
+// a tenant switch with no authentication in front of it.
+type Server struct {
+	source      Source
+	pages       *template.Template
+	mux         *http.ServeMux
+	actions     Actions
+	crossOrigin *http.CrossOriginProtection
+	// token is the form token every action must carry; see EnableActions.
+	token        string
+	organization store.Organization
+}
+
+// New builds a server over source for organization.
+func New(source Source, organization store.Organization) (*Server, error) {
+	if source == nil {
+		return nil, errors.New("artifact browser needs a source")
+	}
+	if organization.OrganizationID == uuid.Nil {
+		return nil, errors.New("artifact browser needs a resolved organization")
+	}
+	pages, err := template.New("").Funcs(template.FuncMap{"pretty": prettyJSON, "when": formatTime}).
+		ParseFS(templateFS, "templates/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("parse artifact browser templates: %w", err)
+	}
+	s := &Server{source: source, pages: pages, mux: http.NewServeMux(), organization: organization}
+	s.routes()
+	return s, nil
+}
+
+// routes registers every view at its page path and its /api twin.
+func (s *Server) routes() {
+	s.view("/{$}", "index", s.index)
+	s.view("/scopes/{type}/{id}", "listing", s.scopeListing)
+	s.view("/stories/{id}", "listing", s.storyListing)
+	s.view("/artifacts/{id}", "artifact", s.artifact)
+	s.view("/audit/{id}", "audit", s.audit)
+	s.view("/principals/{id}", "principal", s.principal)
+
+	s.mux.HandleFunc("GET /find", s.find)
+	s.mux.HandleFunc("GET /attachments/{id}", s.attachment)
+	s.mux.HandleFunc("GET "+apiPrefix+"/attachments/{id}", s.attachment)
+}
+
+// view registers one page: build assembles the value, and it is rendered
+// as the named template at path and as JSON at apiPrefix+path.
+func (s *Server) view(path, page string, build func(*http.Request) (any, error)) {
+	s.mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+		value, err := build(r)
+		if err != nil {
+			s.fail(w, r, false, err)
+			return
+		}
+		s.render(w, page, value)
+	})
+	s.mux.HandleFunc("GET "+apiPrefix+strings.TrimSuffix(path, "{$}"), func(w http.ResponseWriter, r *http.Request) {
+		value, err := build(r)
+		if err != nil {
+			s.fail(w, r, true, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, value)
+	})
+}
+
+// ServeHTTP refuses any request whose Host is not a loopback name.
+//
+// Binding to 127.0.0.1 keeps other machines from connecting, but not other
+// ORIGINS: a page on the internet can resolve its own hostname to
+// 127.0.0.1 and have the operator's browser read these pages as same-origin
+// content. Checking Host closes that, and it is the only thing that does
+// for a server with no credential to check instead.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !loopbackHost(r.Host) {
+		http.Error(w, "the artifact browser answers loopback requests only", http.StatusForbidden)
+		return
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'self' 'unsafe-inline'; form-action 'self'")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	s.mux.ServeHTTP(w, r)
+}
+
+// CheckListenAddress refuses an address that is not loopback, including
+// the empty host, which listens on every interface.
+func CheckListenAddress(address string) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("listen address %q: %w", address, err)
+	}
+	if !loopbackHost(host) {
+		return fmt.Errorf("%w: %q is not a loopback address", ErrNotLoopback, address)
+	}
+	return nil
+}
+
+func loopbackHost(hostport string) bool {
+	host := hostport
+	if h, _, err := net.SplitHostPort(hostport); err == nil {
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// render executes a page template into a buffer first, so a template that
+// fails halfway is a clean 500 rather than half a page with a 200 on it.
+func (s *Server) render(w http.ResponseWriter, page string, value any) {
+	var body bytes.Buffer
+	if err := s.pages.ExecuteTemplate(&body, page, s.pageData(value)); err != nil {
+		http.Error(w, fmt.Sprintf("render %s: %v", page, err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = body.WriteTo(w)
+}
+
+// pageData is what every template receives: the view, the organization it
+// belongs to for the page header, and the action forms when actions are
+// enabled.
+type pageData struct {
+	View         any
+	Actions      *actionForms
+	Organization store.Organization
+}
+
+func (s *Server) pageData(value any) pageData {
+	data := pageData{Organization: s.organization, View: value}
+	if s.actions != nil {
+		data.Actions = &actionForms{Token: s.token, Reviewer: s.actions.Reviewer()}
+	}
+	return data
+}
+
+// heading is what the "head" template receives.
+type heading struct {
+	Title    string
+	Reviewer string
+}
+
+// Titled is the heading for a page titled title, naming the reviewer when
+// the page can act, so nobody mistakes a browser that writes for one that
+// only reads.
+func (p pageData) Titled(title string) heading {
+	h := heading{Title: title}
+	if p.Actions != nil {
+		h.Reviewer = p.Actions.Reviewer.Handle
+	}
+	return h
+}
+
+// fail reports err with the status its kind deserves.
+func (s *Server) fail(w http.ResponseWriter, _ *http.Request, api bool, err error) {
+	status := http.StatusInternalServerError
+	var rejected *store.TransitionRejected
+	switch {
+	case errors.Is(err, errBadRequest), review.IsInvalid(err):
+		status = http.StatusBadRequest
+	case errors.Is(err, errForbidden), errors.Is(err, review.ErrSelfReview):
+		status = http.StatusForbidden
+	case errors.Is(err, store.ErrNotFound):
+		status = http.StatusNotFound
+	// A refused transition is a conflict with the artifact's state, not a
+	// malformed request: the same request could succeed against a
+	// different state, and ErrBaseMoved is exactly that.
+	case errors.Is(err, store.ErrBaseMoved), errors.Is(err, review.ErrUseSupersede), errors.As(err, &rejected):
+		status = http.StatusConflict
+	}
+	advice := review.Advice(err)
+	if api {
+		body := map[string]string{"error": err.Error()}
+		if advice != "" {
+			body["advice"] = advice
+		}
+		writeJSON(w, status, body)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	if renderErr := s.pages.ExecuteTemplate(w, "error",
+		s.pageData(errorView{Status: status, Message: err.Error(), Advice: advice})); renderErr != nil {
+		_, _ = io.WriteString(w, template.HTMLEscapeString(err.Error()))
+	}
+}
+
+type errorView struct {
+	Message string
+	Advice  string
+	Status  int
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(value)
+}
+
+// prettyJSON indents a payload for display. A payload that is not JSON is
+// shown as stored rather than hidden: the page exists to show what is there.
+func prettyJSON(raw json.RawMessage) string {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return indented.String()
+}
+
+// formatTime renders an instant for a page, in UTC so two pages never
+// disagree about the order of events because they were read in two zones.
+func formatTime(at time.Time) string { return at.UTC().Format("2006-01-02 15:04:05Z") }
+
+// pathID parses a path segment as a UUID.
+func pathID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(r.PathValue(name))
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %s %q is not a UUID", errBadRequest, name, r.PathValue(name))
+	}
+	return id, nil
+}
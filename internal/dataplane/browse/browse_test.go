@@ -0,0 +1,430 @@
+package browse
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/store"
+)
+
+// fakeSource is an in-memory plane: enough of the seam's reads to assemble
+// every view, answering ErrNotFound for anything it was not given.
+type fakeSource struct {
+	management  map[uuid.UUID]store.ManagementArtifact
+	audit       map[uuid.UUID]store.AuditArtifact
+	effective   map[uuid.UUID]json.RawMessage
+	reviews     map[uuid.UUID][]store.Review
+	principals  map[uuid.UUID]store.PrincipalInstance
+	pins        map[uuid.UUID][]store.Pin
+	attachments map[uuid.UUID]fakeAttachment
+}
+
+type fakeAttachment struct {
+	err  error
+	body string
+	row  store.Attachment
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		management:  map[uuid.UUID]store.ManagementArtifact{},
+		audit:       map[uuid.UUID]store.AuditArtifact{},
+		effective:   map[uuid.UUID]json.RawMessage{},
+		reviews:     map[uuid.UUID][]store.Review{},
+		principals:  map[uuid.UUID]store.PrincipalInstance{},
+		pins:        map[uuid.UUID][]store.Pin{},
+		attachments: map[uuid.UUID]fakeAttachment{},
+	}
+}
+
+func (f *fakeSource) GetManagementArtifact(_ context.Context, _, id uuid.UUID) (*store.ManagementArtifact, error) {
+	if a, ok := f.management[id]; ok {
+		return &a, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeSource) GetAuditArtifact(_ context.Context, _, id uuid.UUID) (*store.AuditArtifact, error) {
+	if a, ok := f.audit[id]; ok {
+		return &a, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeSource) EffectiveView(_ context.Context, _, id uuid.UUID) (json.RawMessage, error) {
+	return f.effective[id], nil
+}
+
+func (f *fakeSource) AmendmentBase(_ context.Context, _, id uuid.UUID) (store.AmendmentBase, error) {
+	return store.AmendmentBase{Digest: "base-of-" + id.String()[:8], View: f.effective[id], Sequence: 1}, nil
+}
+
+func (f *fakeSource) ListManagementArtifactsByScope(_ context.Context, _ uuid.UUID, scope store.Scope) ([]store.ManagementArtifact, error) {
+	var found []store.ManagementArtifact
+	for _, a := range f.management {
+		if a.Scope == scope {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSource) ListManagementArtifactsByStory(_ context.Context, _, story uuid.UUID) ([]store.ManagementArtifact, error) {
+	var found []store.ManagementArtifact
+	for _, a := range f.management {
+		if a.Lineage.StoryID != nil && *a.Lineage.StoryID == story {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSource) ListAuditArtifactsByScope(_ context.Context, _ uuid.UUID, scope store.Scope) ([]store.AuditArtifact, error) {
+	var found []store.AuditArtifact
+	for _, a := range f.audit {
+		if a.Scope == scope {
+			found = append(found, a)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeSource) ListReviews(_ context.Context, _, id uuid.UUID) ([]store.Review, error) {
+	return f.reviews[id], nil
+}
+
+func (f *fakeSource) GetPrincipalInstance(_ context.Context, _, id uuid.UUID) (*store.PrincipalInstance, error) {
+	if p, ok := f.principals[id]; ok {
+		return &p, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeSource) ListSeededInputs(context.Context, uuid.UUID, uuid.UUID) ([]store.SeededInput, error) {
+	return nil, nil
+}
+
+func (f *fakeSource) ListPins(_ context.Context, _, id uuid.UUID) ([]store.Pin, error) {
+	return f.pins[id], nil
+}
+
+func (f *fakeSource) GetAttachment(_ context.Context, _, id uuid.UUID) (io.ReadCloser, *store.Attachment, error) {
+	a, ok := f.attachments[id]
+	if !ok {
+		return nil, nil, store.ErrNotFound
+	}
+	return io.NopCloser(io.MultiReader(strings.NewReader(a.body), errReader{a.err})), &a.row, nil
+}
+
+// errReader ends a stream with err, the way the seam reports a digest
+// mismatch: at EOF, after every byte.
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
+
+// plane is one original with an accepted and a draft amendment, a
+// successor, two reviews and two pins.
+type plane struct {
+	source     *fakeSource
+	original   uuid.UUID
+	accepted   uuid.UUID
+	draft      uuid.UUID
+	successor  uuid.UUID
+	evidence   uuid.UUID
+	attachment uuid.UUID
+	author     uuid.UUID
+	reviewer   uuid.UUID
+	story      uuid.UUID
+}
+
+func newPlane() *plane {
+	p := &plane{
+		source: newFakeSource(), original: uuid.New(), accepted: uuid.New(), draft: uuid.New(),
+		successor: uuid.New(), evidence: uuid.New(), attachment: uuid.New(),
+		author: uuid.New(), reviewer: uuid.New(), story: uuid.New(),
+	}
+	scope := store.Scope{Type: store.ScopeStory, ID: p.story}
+	lineage := store.Lineage{StoryID: &p.story}
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	one := 1
+	agent := "architect"
+
+	p.source.principals[p.author] = store.PrincipalInstance{
+		PrincipalInstanceID: p.author, Kind: store.PrincipalAgent, Model: "model-a", AgentType: &agent, StartTime: at,
+	}
+	p.source.principals[p.reviewer] = store.PrincipalInstance{
+		PrincipalInstanceID: p.reviewer, Kind: store.PrincipalHuman, Model: "human", StartTime: at,
+	}
+	artifact := func(id uuid.UUID, status store.Status, payload string, created time.Time) store.ManagementArtifact {
+		return store.ManagementArtifact{
+			ArtifactID: id, Status: status, Payload: json.RawMessage(payload), Scope: scope, Lineage: lineage,
+			Type: "spec.story", Category: "management", Summary: "summary of " + id.String()[:8],
+			AuthorInstanceID: p.author, CreatedAt: created, ReviewDigest: "sha256:" + id.String(),
+		}
+	}
+
+	original := artifact(p.original, store.StatusSuperseded, `{"title":"first","size":1}`, at)
+	p.source.management[p.original] = original
+
+	accepted := artifact(p.accepted, store.StatusAccepted, `{"size":2}`, at.Add(time.Hour))
+	accepted.IsAmendment, accepted.AmendsArtifactID, accepted.AmendmentSequence = true, &p.original, &one
+	p.source.management[p.accepted] = accepted
+
+	draft := artifact(p.draft, store.StatusDraft, `{"size":3}`, at.Add(2*time.Hour))
+	draft.IsAmendment, draft.AmendsArtifactID = true, &p.original
+	p.source.management[p.draft] = draft
+
+	successor := artifact(p.successor, store.StatusAccepted, `{"title":"second"}`, at.Add(3*time.Hour))
+	successor.SupersedesArtifactID = &p.original
+	p.source.management[p.successor] = successor
+
+	p.source.effective[p.original] = json.RawMessage(`{"title":"first","size":2}`)
+	p.source.reviews[p.original] = []store.Review{
+		{ReviewID: uuid.New(), ReviewerInstanceID: p.reviewer, Decision: store.DecisionAccepted,
+			ReviewDigest: original.ReviewDigest, DecidedAt: at.Add(30 * time.Minute), Rationale: "reads well"},
+		{ReviewID: uuid.New(), ReviewerInstanceID: p.reviewer, Decision: store.DecisionChangesRequested,
+			ReviewDigest: "sha256:earlier", DecidedAt: at.Add(10 * time.Minute), Rationale: "too short"},
+	}
+
+	p.source.audit[p.evidence] = store.AuditArtifact{
+		ArtifactID: p.evidence, Scope: scope, Type: "benchmark.run_record", Category: "audit",
+		Payload: json.RawMessage(`{"record":{}}`), AuthorInstanceID: p.author, CreatedAt: at,
+	}
+	p.source.pins[p.original] = []store.Pin{
+		{PinID: uuid.New(), AuditArtifactID: &p.evidence, Digest: "sha256:audit", CreatedAt: at},
+		{PinID: uuid.New(), AttachmentID: &p.attachment, Digest: "sha256:file", CreatedAt: at},
+	}
+	p.source.attachments[p.attachment] = fakeAttachment{
+		body: "<script>alert(1)</script>",
+		row: store.Attachment{AttachmentID: p.attachment, MediaType: "text/html", SizeBytes: 25,
+			Digest: "sha256:file"},
+	}
+	return p
+}
+
+func (p *plane) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	server, err := New(p.source, store.Organization{OrganizationID: uuid.New(), Slug: "acme", DisplayName: "Acme"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func get(t *testing.T, url string) (*http.Response, string) {
+	t.Helper()
+	response, err := http.Get(url) //nolint:gosec,noctx // a test server's URL
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	body, _ := io.ReadAll(response.Body)
+	return response, string(body)
+}
+
+// TestArtifactDetailAssemblesWhatTheRowCannotSay is the reason the package
+// exists: the raw payload beside the effective view, the amendments in the
+// order they apply, who superseded it, and the review chain with each
+// reviewer resolved — none of which one row holds.
+func TestArtifactDetailAssemblesWhatTheRowCannotSay(t *testing.T) {
+	p := newPlane()
+	server := p.serve(t)
+
+	response, body := get(t, server.URL+"/api/artifacts/"+p.original.String())
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", response.StatusCode, body)
+	}
+	var detail ArtifactDetail
+	if err := json.Unmarshal([]byte(body), &detail); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if compact(t, detail.Artifact.Payload) != `{"title":"first","size":1}` ||
+		compact(t, detail.Effective) != `{"title":"first","size":2}` {
+		t.Fatalf("payload %s / effective %s: the two must be shown as stored and as applied",
+			detail.Artifact.Payload, detail.Effective)
+	}
+	if len(detail.Amendments) != 2 || detail.Amendments[0].ArtifactID != p.accepted ||
+		!detail.Amendments[0].Applied || detail.Amendments[1].Applied {
+		t.Fatalf("amendments = %+v, want the sequenced one applied and first, the draft after it", detail.Amendments)
+	}
+	if len(detail.SupersededBy) != 1 || detail.SupersededBy[0] != p.successor {
+		t.Fatalf("superseded_by = %v, want %s", detail.SupersededBy, p.successor)
+	}
+	if len(detail.Reviews) != 2 || detail.Reviews[0].Rationale != "too short" {
+		t.Fatalf("reviews are not in decision order: %+v", detail.Reviews)
+	}
+	if detail.Reviews[0].Current || !detail.Reviews[1].Current {
+		t.Fatal("a review of earlier content was reported as bound to the current content, or the reverse")
+	}
+	if detail.Reviews[1].Reviewer == nil || detail.Reviews[1].Reviewer.Kind != store.PrincipalHuman {
+		t.Fatalf("reviewer lineage not resolved: %+v", detail.Reviews[1].Reviewer)
+	}
+	if detail.Author == nil || detail.Author.Model != "model-a" {
+		t.Fatalf("author not resolved: %+v", detail.Author)
+	}
+	if len(detail.Evidence) != 2 {
+		t.Fatalf("evidence = %+v, want both pins", detail.Evidence)
+	}
+
+	// And the page renders the same view, linking onward into the evidence.
+	response, page := get(t, server.URL+"/artifacts/"+p.original.String())
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("page status %d: %s", response.StatusCode, page)
+	}
+	for _, want := range []string{"/audit/" + p.evidence.String(), "/attachments/" + p.attachment.String(),
+		"/artifacts/" + p.successor.String(), "too short"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("the page does not show %q", want)
+		}
+	}
+}
+
+func TestListingsRenderAsPagesAndJSON(t *testing.T) {
+	p := newPlane()
+	server := p.serve(t)
+
+	for _, path := range []string{"/scopes/story/" + p.story.String(), "/stories/" + p.story.String()} {
+		response, body := get(t, server.URL+apiPrefix+path)
+		if response.StatusCode != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", path, response.StatusCode, body)
+		}
+		var listing Listing
+		if err := json.Unmarshal([]byte(body), &listing); err != nil {
+			t.Fatalf("%s: decode: %v", path, err)
+		}
+		if len(listing.Management) != 4 || len(listing.Audit) != 1 {
+			t.Fatalf("%s: %d management, %d audit; want 4 and 1", path, len(listing.Management), len(listing.Audit))
+		}
+		if response, page := get(t, server.URL+path); response.StatusCode != http.StatusOK ||
+			!strings.Contains(page, p.original.String()) {
+			t.Fatalf("%s: page status %d, or it does not list the original", path, response.StatusCode)
+		}
+	}
+	for _, path := range []string{"/", "/audit/" + p.evidence.String(), "/principals/" + p.reviewer.String()} {
+		if response, body := get(t, server.URL+path); response.StatusCode != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", path, response.StatusCode, body)
+		}
+	}
+}
+
+func TestRefusalsCarryTheirStatus(t *testing.T) {
+	server := newPlane().serve(t)
+	cases := map[string]int{
+		"/api/artifacts/not-a-uuid":                 http.StatusBadRequest,
+		"/api/scopes/galaxy/" + uuid.NewString():    http.StatusBadRequest,
+		"/api/artifacts/" + uuid.NewString():        http.StatusNotFound,
+		"/artifacts/" + uuid.NewString():            http.StatusNotFound,
+		"/api/attachments/" + uuid.NewString():      http.StatusNotFound,
+		"/find?scope_type=story&id=" + "not-a-uuid": http.StatusBadRequest,
+	}
+	for path, want := range cases {
+		if response, _ := get(t, server.URL+path); response.StatusCode != want {
+			t.Errorf("%s: status %d, want %d", path, response.StatusCode, want)
+		}
+	}
+	response, err := http.Post(server.URL+"/api/artifacts/"+uuid.NewString(), "application/json", nil) //nolint:noctx // test
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST status %d: the browser must not accept writes", response.StatusCode)
+	}
+}
+
+// An attachment is always a download, and a stream the seam could not
+// verify is cut short rather than delivered looking complete.
+func TestAttachmentsAreVerifiedDownloads(t *testing.T) {
+	p := newPlane()
+	server := p.serve(t)
+
+	response, body := get(t, server.URL+"/attachments/"+p.attachment.String())
+	if response.StatusCode != http.StatusOK || body != "<script>alert(1)</script>" {
+		t.Fatalf("status %d, body %q", response.StatusCode, body)
+	}
+	if !strings.HasPrefix(response.Header.Get("Content-Disposition"), "attachment") ||
+		response.Header.Get("Content-Security-Policy") != "sandbox" {
+		t.Fatalf("an HTML attachment would render as this origin: %v", response.Header)
+	}
+
+	broken := p.source.attachments[p.attachment]
+	broken.err = errors.New("digest mismatch")
+	p.source.attachments[p.attachment] = broken
+	// Either failure is right: a small body is still buffered when the
+	// abort lands, so the client may see the connection die before the
+	// status line rather than partway through the body.
+	response, err := http.Get(server.URL + "/attachments/" + p.attachment.String()) //nolint:noctx // test
+	if err == nil {
+		defer func() { _ = response.Body.Close() }()
+		_, err = io.ReadAll(response.Body)
+	}
+	if err == nil {
+		t.Fatal("an attachment that failed verification arrived as a complete download")
+	}
+}
+
+func compact(t *testing.T, raw json.RawMessage) string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := json.Compact(&out, raw); err != nil {
+		t.Fatalf("compact %s: %v", raw, err)
+	}
+	return out.String()
+}
+
+// Loopback is checked on the way in as well as on the way up: a rebinding
+// page reaches a loopback listener with its own name in Host.
+func TestOnlyLoopbackIsServed(t *testing.T) {
+	server := newPlane().serve(t)
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/", nil) //nolint:noctx // test
+	request.Host = "attacker.example:80"
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusForbidden {
+		t.Fatalf("a request for another host was served (status %d)", response.StatusCode)
+	}
+
+	for address, ok := range map[string]bool{
+		"127.0.0.1:7780": true, "[::1]:7780": true, "localhost:7780": true,
+		":7780": false, "0.0.0.0:7780": false, "192.168.1.5:7780": false,
+	} {
+		if err := CheckListenAddress(address); (err == nil) != ok {
+			t.Errorf("CheckListenAddress(%q) = %v", address, err)
+		}
+	}
+}
+
+// Payloads are data, so the page escapes them like any other text.
+func TestPayloadsAreEscaped(t *testing.T) {
+	p := newPlane()
+	hostile := p.source.management[p.original]
+	hostile.Payload = json.RawMessage(`{"title":"<img src=x onerror=alert(1)>"}`)
+	p.source.management[p.original] = hostile
+	server := p.serve(t)
+
+	_, page := get(t, server.URL+"/artifacts/"+p.original.String())
+	if strings.Contains(page, "<img src=x") || !bytes.Contains([]byte(page), []byte("&lt;img")) {
+		t.Fatal("a payload was rendered as markup")
+	}
+}
@@ -0,0 +1,614 @@
+package browse
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/registry"
+	"orchestrator/internal/dataplane/store"
+)
+
+// scopeTypes is the schema's scope vocabulary, in hierarchy order, for the
+// index page's picker and for refusing a type the schema would not admit.
+var scopeTypes = []store.ScopeType{
+	store.ScopeOrganization, store.ScopeProduct, store.ScopeFeature,
+	store.ScopeEpic, store.ScopeStory, store.ScopeBenchmark,
+}
+
+// Index is the landing page: what can be browsed, and how to name it.
+type Index struct {
+	Organization string            `json:"organization"`
+	ScopeTypes   []store.ScopeType `json:"scope_types"`
+}
+
+// Listing is every artifact in one scope, or every Management artifact
+// along one story's lineage.
+type Listing struct {
+	Scope      *Scope            `json:"scope,omitempty"`
+	StoryID    *uuid.UUID        `json:"story_id,omitempty"`
+	Title      string            `json:"title"`
+	Management []ArtifactSummary `json:"management"`
+	Audit      []ArtifactSummary `json:"audit"`
+}
+
+// ArtifactSummary is one row of a listing.
+type ArtifactSummary struct {
+	CreatedAt         time.Time         `json:"created_at"`
+	AmendsArtifactID  *uuid.UUID        `json:"amends_artifact_id,omitempty"`
+	AmendmentSequence *int              `json:"amendment_sequence,omitempty"`
+	Type              registry.Type     `json:"type"`
+	Category          registry.Category `json:"category"`
+	Status            store.Status      `json:"status,omitempty"`
+	Summary           string            `json:"summary"`
+	ArtifactID        uuid.UUID         `json:"artifact_id"`
+}
+
+// ArtifactDetail is one Management artifact with everything that decides
+// what it currently says and who stands behind it.
+//
+// Payload is what was STORED. For an original, Effective is what it says
+// now: the payload with every accepted amendment applied in sequence. The
+// two are shown side by side because the difference is the point — an
+// accepted artifact's payload is never rewritten, so reading the payload
+// alone reads the artifact as it was on the day it was first accepted.
+//
+// Base is set on a draft amendment only: the original's effective view as
+// of now, which is what a reviewer must read the patch against and what
+// the review records.
+type ArtifactDetail struct {
+	Author       *Principal        `json:"author"`
+	Base         *Base             `json:"base,omitempty"`
+	Artifact     ManagementView    `json:"artifact"`
+	Effective    json.RawMessage   `json:"effective,omitempty"`
+	Amendments   []Amendment       `json:"amendments"`
+	SupersededBy []uuid.UUID       `json:"superseded_by"`
+	Reviews      []ReviewWithActor `json:"reviews"`
+	Evidence     []Evidence        `json:"evidence"`
+}
+
+// ManagementView is store.ManagementArtifact as the API spells it.
+type ManagementView struct {
+	AcceptedAt           *time.Time        `json:"accepted_at,omitempty"`
+	ReviewerInstanceID   *uuid.UUID        `json:"reviewer_instance_id,omitempty"`
+	ProducedByToolCallID *uuid.UUID        `json:"produced_by_tool_call_id,omitempty"`
+	AmendsArtifactID     *uuid.UUID        `json:"amends_artifact_id,omitempty"`
+	SupersedesArtifactID *uuid.UUID        `json:"supersedes_artifact_id,omitempty"`
+	ReplacesArtifactID   *uuid.UUID        `json:"replaces_artifact_id,omitempty"`
+	AmendmentSequence    *int              `json:"amendment_sequence,omitempty"`
+	Lineage              Lineage           `json:"lineage"`
+	CreatedAt            time.Time         `json:"created_at"`
+	Type                 registry.Type     `json:"type"`
+	Category             registry.Category `json:"category"`
+	Status               store.Status      `json:"status"`
+	Summary              string            `json:"summary"`
+	PayloadDigest        string            `json:"payload_digest"`
+	ReviewDigest         string            `json:"review_digest"`
+	Payload              json.RawMessage   `json:"payload"`
+	Scope                Scope             `json:"scope"`
+	ArtifactID           uuid.UUID         `json:"artifact_id"`
+	UserID               uuid.UUID         `json:"user_id"`
+	AuthorInstanceID     uuid.UUID         `json:"author_instance_id"`
+	SchemaVersion        int               `json:"schema_version"`
+	IsAmendment          bool              `json:"is_amendment"`
+}
+
+// Base is store.AmendmentBase as the API spells it. Digest and Sequence are
+// what a review of the amendment records; `dataplanectl artifact review`
+// takes them as -base digest@sequence.
+type Base struct {
+	Digest   string          `json:"digest"`
+	View     json.RawMessage `json:"view"`
+	Sequence int             `json:"sequence"`
+}
+
+// Amendment is one entry in an original's amendment history. Patch is the
+// amendment's payload, which ADR 0028 makes a JSON merge patch over the
+// original; only accepted ones are part of the effective view.
+type Amendment struct {
+	CreatedAt  time.Time       `json:"created_at"`
+	Sequence   *int            `json:"sequence,omitempty"`
+	Status     store.Status    `json:"status"`
+	Summary    string          `json:"summary"`
+	Patch      json.RawMessage `json:"patch"`
+	ArtifactID uuid.UUID       `json:"artifact_id"`
+	Applied    bool            `json:"applied"`
+}
+
+// ReviewWithActor is a review and the principal instance that made it.
+type ReviewWithActor struct {
+	Reviewer     *Principal `json:"reviewer"`
+	BaseDigest   *string    `json:"base_digest,omitempty"`
+	BaseSequence *int       `json:"base_sequence,omitempty"`
+	DecidedAt    time.Time  `json:"decided_at"`
+	ReviewDigest string     `json:"review_digest"`
+	Decision     string     `json:"decision"`
+	Rationale    string     `json:"rationale"`
+	ReviewID     uuid.UUID  `json:"review_id"`
+	// Current is whether the review is bound to the artifact's present
+	// content. A review of earlier content is history, not a verdict on
+	// what the artifact says now.
+	Current bool `json:"current"`
+}
+
+// Evidence is one pin: what this artifact keeps alive.
+type Evidence struct {
+	AuditArtifactID *uuid.UUID `json:"audit_artifact_id,omitempty"`
+	AttachmentID    *uuid.UUID `json:"attachment_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Digest          string     `json:"digest"`
+	PinID           uuid.UUID  `json:"pin_id"`
+}
+
+// AuditDetail is one Audit artifact.
+type AuditDetail struct {
+	Author   *Principal `json:"author"`
+	Artifact AuditView  `json:"artifact"`
+}
+
+// AuditView is store.AuditArtifact as the API spells it.
+type AuditView struct {
+	UserID               *uuid.UUID        `json:"user_id,omitempty"`
+	ProducedByToolCallID *uuid.UUID        `json:"produced_by_tool_call_id,omitempty"`
+	Lineage              Lineage           `json:"lineage"`
+	CreatedAt            time.Time         `json:"created_at"`
+	Type                 registry.Type     `json:"type"`
+	Category             registry.Category `json:"category"`
+	Summary              string            `json:"summary"`
+	PayloadDigest        string            `json:"payload_digest"`
+	Payload              json.RawMessage   `json:"payload"`
+	Scope                Scope             `json:"scope"`
+	ArtifactID           uuid.UUID         `json:"artifact_id"`
+	AuthorInstanceID     uuid.UUID         `json:"author_instance_id"`
+	SchemaVersion        int               `json:"schema_version"`
+}
+
+// Principal is one principal instance: who acted, and under which model,
+// prompt and harness — the lineage a review's weight depends on.
+type Principal struct {
+	AgentType           *string             `json:"agent_type,omitempty"`
+	PromptPackID        *string             `json:"prompt_pack_id,omitempty"`
+	PromptHash          *string             `json:"prompt_hash,omitempty"`
+	HarnessConfigHash   *string             `json:"harness_config_hash,omitempty"`
+	MaestroVersion      *string             `json:"maestro_version,omitempty"`
+	UserID              *uuid.UUID          `json:"user_id,omitempty"`
+	StopTime            *time.Time          `json:"stop_time,omitempty"`
+	StopReason          *string             `json:"stop_reason,omitempty"`
+	Lineage             Lineage             `json:"lineage"`
+	StartTime           time.Time           `json:"start_time"`
+	Kind                store.PrincipalKind `json:"kind"`
+	Model               string              `json:"model"`
+	Seeds               []Seed              `json:"seeds,omitempty"`
+	PrincipalInstanceID uuid.UUID           `json:"principal_instance_id"`
+}
+
+// Seed is one artifact a principal instance started from, with the digest
+// it had then. An artifact whose digest has since moved is one the instance
+// never saw in its current form.
+type Seed struct {
+	SeededAt     time.Time `json:"seeded_at"`
+	SeededDigest string    `json:"seeded_digest"`
+	ArtifactID   uuid.UUID `json:"artifact_id"`
+}
+
+// Scope is store.Scope as the API spells it.
+type Scope struct {
+	Type store.ScopeType `json:"type"`
+	ID   uuid.UUID       `json:"id"`
+}
+
+// Lineage is store.Lineage as the API spells it.
+type Lineage struct {
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	FeatureID *uuid.UUID `json:"feature_id,omitempty"`
+	EpicID    *uuid.UUID `json:"epic_id,omitempty"`
+	StoryID   *uuid.UUID `json:"story_id,omitempty"`
+}
+
+func (s *Server) index(*http.Request) (any, error) {
+	return Index{Organization: s.organization.Slug, ScopeTypes: scopeTypes}, nil
+}
+
+// find turns the index page's form into a listing URL. It is a redirect
+// rather than a view so every listing has one address, and the address is
+// the one a person can bookmark.
+func (s *Server) find(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	id, err := uuid.Parse(query.Get("id"))
+	if err != nil {
+		s.fail(w, r, false, fmt.Errorf("%w: %q is not a UUID", errBadRequest, query.Get("id")))
+		return
+	}
+	target := "/stories/" + id.String()
+	if scopeType := query.Get("scope_type"); scopeType != "" {
+		target = "/scopes/" + url.PathEscape(scopeType) + "/" + id.String()
+	}
+	http.Redirect(w, r, target, http.StatusSeeOther)
+}
+
+func (s *Server) scopeListing(r *http.Request) (any, error) {
+	scopeType := store.ScopeType(r.PathValue("type"))
+	if !slices.Contains(scopeTypes, scopeType) {
+		return nil, fmt.Errorf("%w: %q is not a scope type", errBadRequest, scopeType)
+	}
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	scope := store.Scope{Type: scopeType, ID: id}
+	ctx, org := r.Context(), s.organization.OrganizationID
+
+	management, err := s.source.ListManagementArtifactsByScope(ctx, org, scope)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := s.source.ListAuditArtifactsByScope(ctx, org, scope)
+	if err != nil {
+		return nil, err
+	}
+	return Listing{
+		Scope:      &Scope{Type: scope.Type, ID: scope.ID},
+		Title:      fmt.Sprintf("%s %s", scopeType, id),
+		Management: managementSummaries(management),
+		Audit:      auditSummaries(audit),
+	}, nil
+}
+
+// storyListing is every Management artifact whose lineage reaches the
+// story, whatever it is scoped to, plus the Audit artifacts scoped to the
+// story itself. The seam has no by-lineage read for Audit exhaust, and
+// inventing one here would be a query with no seam behind it.
+func (s *Server) storyListing(r *http.Request) (any, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	ctx, org := r.Context(), s.organization.OrganizationID
+
+	management, err := s.source.ListManagementArtifactsByStory(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	audit, err := s.source.ListAuditArtifactsByScope(ctx, org, store.Scope{Type: store.ScopeStory, ID: id})
+	if err != nil {
+		return nil, err
+	}
+	return Listing{
+		StoryID:    &id,
+		Title:      "story " + id.String(),
+		Management: managementSummaries(management),
+		Audit:      auditSummaries(audit),
+	}, nil
+}
+
+func (s *Server) artifact(r *http.Request) (any, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	ctx, org := r.Context(), s.organization.OrganizationID
+
+	artifact, err := s.source.GetManagementArtifact(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	principals := principalCache{source: s.source, org: org}
+	detail := ArtifactDetail{Artifact: managementView(artifact)}
+	if detail.Author, err = principals.get(ctx, artifact.AuthorInstanceID); err != nil {
+		return nil, err
+	}
+
+	// Amendments and successors share the original's scope (an amendment
+	// inherits it), so one scope read answers both without a query of this
+	// package's own.
+	siblings, err := s.source.ListManagementArtifactsByScope(ctx, org, artifact.Scope)
+	if err != nil {
+		return nil, err
+	}
+	detail.Amendments, detail.SupersededBy = relatives(artifact, siblings)
+	switch {
+	case !artifact.IsAmendment:
+		if detail.Effective, err = s.source.EffectiveView(ctx, org, id); err != nil {
+			return nil, err
+		}
+	case artifact.Status == store.StatusDraft && artifact.AmendsArtifactID != nil:
+		base, err := s.source.AmendmentBase(ctx, org, *artifact.AmendsArtifactID)
+		if err != nil {
+			return nil, err
+		}
+		detail.Base = &Base{Digest: base.Digest, View: base.View, Sequence: base.Sequence}
+	}
+
+	if detail.Reviews, err = s.reviews(ctx, artifact, &principals); err != nil {
+		return nil, err
+	}
+	pins, err := s.source.ListPins(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	detail.Evidence = make([]Evidence, 0, len(pins))
+	for i := range pins {
+		detail.Evidence = append(detail.Evidence, Evidence{
+			AuditArtifactID: pins[i].AuditArtifactID,
+			AttachmentID:    pins[i].AttachmentID,
+			CreatedAt:       pins[i].CreatedAt,
+			Digest:          pins[i].Digest,
+			PinID:           pins[i].PinID,
+		})
+	}
+	return detail, nil
+}
+
+// relatives finds an original's amendments, in sequence order, and the
+// artifacts that supersede it.
+func relatives(artifact *store.ManagementArtifact, siblings []store.ManagementArtifact) ([]Amendment, []uuid.UUID) {
+	amendments := []Amendment{}
+	supersededBy := []uuid.UUID{}
+	for i := range siblings {
+		sibling := &siblings[i]
+		if sibling.SupersedesArtifactID != nil && *sibling.SupersedesArtifactID == artifact.ArtifactID {
+			supersededBy = append(supersededBy, sibling.ArtifactID)
+		}
+		if sibling.AmendsArtifactID == nil || *sibling.AmendsArtifactID != artifact.ArtifactID {
+			continue
+		}
+		amendments = append(amendments, Amendment{
+			CreatedAt:  sibling.CreatedAt,
+			Sequence:   sibling.AmendmentSequence,
+			Status:     sibling.Status,
+			Summary:    sibling.Summary,
+			Patch:      sibling.Payload,
+			ArtifactID: sibling.ArtifactID,
+			Applied:    sibling.Status == store.StatusAccepted,
+		})
+	}
+	// Sequenced amendments first, in the order they apply; drafts, which
+	// have no sequence yet, after them in the order they were written.
+	slices.SortStableFunc(amendments, func(a, b Amendment) int {
+		switch {
+		case a.Sequence != nil && b.Sequence != nil:
+			return cmp.Compare(*a.Sequence, *b.Sequence)
+		case a.Sequence != nil:
+			return -1
+		case b.Sequence != nil:
+			return 1
+		default:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+	})
+	return amendments, supersededBy
+}
+
+func (s *Server) reviews(
+	ctx context.Context, artifact *store.ManagementArtifact, principals *principalCache,
+) ([]ReviewWithActor, error) {
+	reviews, err := s.source.ListReviews(ctx, s.organization.OrganizationID, artifact.ArtifactID)
+	if err != nil {
+		return nil, err
+	}
+	chain := make([]ReviewWithActor, 0, len(reviews))
+	for i := range reviews {
+		review := &reviews[i]
+		reviewer, err := principals.get(ctx, review.ReviewerInstanceID)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, ReviewWithActor{
+			Reviewer:     reviewer,
+			BaseDigest:   review.BaseDigest,
+			BaseSequence: review.BaseSequence,
+			DecidedAt:    review.DecidedAt,
+			ReviewDigest: review.ReviewDigest,
+			Decision:     string(review.Decision),
+			Rationale:    review.Rationale,
+			ReviewID:     review.ReviewID,
+			Current:      review.ReviewDigest == artifact.ReviewDigest,
+		})
+	}
+	slices.SortStableFunc(chain, func(a, b ReviewWithActor) int { return a.DecidedAt.Compare(b.DecidedAt) })
+	return chain, nil
+}
+
+func (s *Server) audit(r *http.Request) (any, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	ctx, org := r.Context(), s.organization.OrganizationID
+	artifact, err := s.source.GetAuditArtifact(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	principals := principalCache{source: s.source, org: org}
+	author, err := principals.get(ctx, artifact.AuthorInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	return AuditDetail{Author: author, Artifact: auditView(artifact)}, nil
+}
+
+func (s *Server) principal(r *http.Request) (any, error) {
+	id, err := pathID(r, "id")
+	if err != nil {
+		return nil, err
+	}
+	ctx, org := r.Context(), s.organization.OrganizationID
+	principals := principalCache{source: s.source, org: org}
+	principal, err := principals.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := s.source.ListSeededInputs(ctx, org, id)
+	if err != nil {
+		return nil, err
+	}
+	// A copy: the cache's value is shared with nothing here, but a view
+	// that mutates a cached entry is one refactor from leaking into another.
+	view := *principal
+	for i := range seeds {
+		view.Seeds = append(view.Seeds, Seed{
+			SeededAt: seeds[i].SeededAt, SeededDigest: seeds[i].SeededDigest, ArtifactID: seeds[i].ArtifactID,
+		})
+	}
+	return view, nil
+}
+
+// attachment streams an attachment's bytes as a download.
+//
+// Always a download, never rendered: an attachment's media type is whatever
+// its writer claimed, and an HTML or SVG attachment rendered from this
+// origin would run as this origin. The sandbox policy repeats that for a
+// browser that ignores the disposition.
+//
+// The seam verifies as it streams and can only report a mismatch at the end.
+// By then the status line is gone, so a mismatch ABORTS the connection: the
+// download fails short of its stated length instead of arriving looking
+// complete, which is the property GetAttachment exists to keep.
+func (s *Server) attachment(w http.ResponseWriter, r *http.Request) {
+	api := strings.HasPrefix(r.URL.Path, apiPrefix+"/")
+	id, err := pathID(r, "id")
+	if err != nil {
+		s.fail(w, r, api, err)
+		return
+	}
+	body, attachment, err := s.source.GetAttachment(r.Context(), s.organization.OrganizationID, id)
+	if err != nil {
+		s.fail(w, r, api, err)
+		return
+	}
+	defer func() { _ = body.Close() }()
+
+	header := w.Header()
+	header.Set("Content-Type", attachment.MediaType)
+	header.Set("Content-Length", strconv.FormatInt(attachment.SizeBytes, 10))
+	header.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.AttachmentID.String()))
+	header.Set("Content-Security-Policy", "sandbox")
+	header.Set("X-Attachment-Digest", attachment.Digest)
+	if _, err := io.Copy(w, body); err != nil {
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// principalCache resolves each principal instance once per request: a
+// review chain usually names the same few reviewers many times over.
+type principalCache struct {
+	source Source
+	seen   map[uuid.UUID]*Principal
+	org    uuid.UUID
+}
+
+func (c *principalCache) get(ctx context.Context, id uuid.UUID) (*Principal, error) {
+	if principal, ok := c.seen[id]; ok {
+		return principal, nil
+	}
+	instance, err := c.source.GetPrincipalInstance(ctx, c.org, id)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("principal instance %s: %w", id, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	principal := &Principal{
+		AgentType:           instance.AgentType,
+		PromptPackID:        instance.PromptPackID,
+		PromptHash:          instance.PromptHash,
+		HarnessConfigHash:   instance.HarnessConfigHash,
+		MaestroVersion:      instance.MaestroVersion,
+		UserID:              instance.UserID,
+		StopTime:            instance.StopTime,
+		StopReason:          instance.StopReason,
+		Lineage:             lineageView(instance.Lineage),
+		StartTime:           instance.StartTime,
+		Kind:                instance.Kind,
+		Model:               instance.Model,
+		PrincipalInstanceID: instance.PrincipalInstanceID,
+	}
+	if c.seen == nil {
+		c.seen = map[uuid.UUID]*Principal{}
+	}
+	c.seen[id] = principal
+	return principal, nil
+}
+
+func managementSummaries(artifacts []store.ManagementArtifact) []ArtifactSummary {
+	summaries := make([]ArtifactSummary, 0, len(artifacts))
+	for i := range artifacts {
+		a := &artifacts[i]
+		summaries = append(summaries, ArtifactSummary{
+			CreatedAt: a.CreatedAt, AmendsArtifactID: a.AmendsArtifactID, AmendmentSequence: a.AmendmentSequence,
+			Type: a.Type, Category: a.Category, Status: a.Status, Summary: a.Summary, ArtifactID: a.ArtifactID,
+		})
+	}
+	return summaries
+}
+
+func auditSummaries(artifacts []store.AuditArtifact) []ArtifactSummary {
+	summaries := make([]ArtifactSummary, 0, len(artifacts))
+	for i := range artifacts {
+		a := &artifacts[i]
+		summaries = append(summaries, ArtifactSummary{
+			CreatedAt: a.CreatedAt, Type: a.Type, Category: a.Category, Summary: a.Summary, ArtifactID: a.ArtifactID,
+		})
+	}
+	return summaries
+}
+
+func managementView(a *store.ManagementArtifact) ManagementView {
+	return ManagementView{
+		AcceptedAt:           a.AcceptedAt,
+		ReviewerInstanceID:   a.ReviewerInstanceID,
+		ProducedByToolCallID: a.ProducedByToolCallID,
+		AmendsArtifactID:     a.AmendsArtifactID,
+		SupersedesArtifactID: a.SupersedesArtifactID,
+		ReplacesArtifactID:   a.ReplacesArtifactID,
+		AmendmentSequence:    a.AmendmentSequence,
+		Lineage:              lineageView(a.Lineage),
+		CreatedAt:            a.CreatedAt,
+		Type:                 a.Type,
+		Category:             a.Category,
+		Status:               a.Status,
+		Summary:              a.Summary,
+		PayloadDigest:        a.PayloadDigest,
+		ReviewDigest:         a.ReviewDigest,
+		Payload:              a.Payload,
+		Scope:                Scope{Type: a.Scope.Type, ID: a.Scope.ID},
+		ArtifactID:           a.ArtifactID,
+		UserID:               a.UserID,
+		AuthorInstanceID:     a.AuthorInstanceID,
+		SchemaVersion:        a.SchemaVersion,
+		IsAmendment:          a.IsAmendment,
+	}
+}
+
+func auditView(a *store.AuditArtifact) AuditView {
+	return AuditView{
+		UserID:               a.UserID,
+		ProducedByToolCallID: a.ProducedByToolCallID,
+		Lineage:              lineageView(a.Lineage),
+		CreatedAt:            a.CreatedAt,
+		Type:                 a.Type,
+		Category:             a.Category,
+		Summary:              a.Summary,
+		PayloadDigest:        a.PayloadDigest,
+		Payload:              a.Payload,
+		Scope:                Scope{Type: a.Scope.Type, ID: a.Scope.ID},
+		ArtifactID:           a.ArtifactID,
+		AuthorInstanceID:     a.AuthorInstanceID,
+		SchemaVersion:        a.SchemaVersion,
+	}
+}
+
+func lineageView(l store.Lineage) Lineage {
+	return Lineage{ProductID: l.ProductID, FeatureID: l.FeatureID, EpicID: l.EpicID, StoryID: l.StoryID}
+}
@@ -0,0 +1,514 @@
+// Package dbus is the smallest D-Bus client the data plane needs: enough of
+// the wire protocol to make method calls on the session bus and read their
+// replies.
+//
+// It exists for one caller, the Secret Service root-key backend, and it is
+// written here rather than imported because the general-purpose clients
+// carry signal routing, introspection and an object exporter that nothing
+// here would use, and a root-of-trust path is the last place to take on code
+// nobody reads. What is left is small enough to read in one sitting: the
+// marshalling rules (this file), the connection and its authentication
+// (conn.go), and a stand-in peer that lets tests speak the real protocol
+// without a bus daemon (standin.go).
+//
+// The type coverage is deliberately partial. Every type the Secret Service
+// API uses is supported; a signature naming anything else is refused when it
+// is encoded or decoded, never guessed at.
+package dbus
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrUnsupportedType reports a signature naming a type this codec does not
+// implement.
+var ErrUnsupportedType = errors.New("unsupported D-Bus type")
+
+// ErrMalformed reports wire data that does not decode under its signature.
+var ErrMalformed = errors.New("malformed D-Bus data")
+
+// ObjectPath is a D-Bus object path ('o').
+type ObjectPath string
+
+// Signature is a D-Bus type signature ('g').
+type Signature string
+
+// Variant is a self-describing value ('v').
+type Variant struct {
+	Value     any
+	Signature Signature
+}
+
+// DictEntry is one entry of a dictionary array ('a{...}').
+type DictEntry struct {
+	Key   any
+	Value any
+}
+
+// Struct is a D-Bus struct ('(...)'), its fields in order.
+type Struct []any
+
+// maxMessageSize is the protocol's own limit. Enforced on read so a peer
+// cannot make this process allocate whatever length it claims.
+const maxMessageSize = 1 << 27
+
+// alignment is the boundary a value of the given type code starts on.
+func alignment(code byte) int {
+	switch code {
+	case 'y', 'g', 'v':
+		return 1
+	case 'x', 't', '(', '{':
+		return 8
+	default:
+		return 4
+	}
+}
+
+// splitType returns the first complete type in a signature and the rest.
+func splitType(signature string) (first, rest string, err error) {
+	if signature == "" {
+		return "", "", fmt.Errorf("%w: empty signature", ErrMalformed)
+	}
+	switch signature[0] {
+	case 'a':
+		element, remainder, elemErr := splitType(signature[1:])
+		if elemErr != nil {
+			return "", "", elemErr
+		}
+		return "a" + element, remainder, nil
+	case '(', '{':
+		closing := byte(')')
+		if signature[0] == '{' {
+			closing = '}'
+		}
+		inner := signature[1:]
+		for inner != "" && inner[0] != closing {
+			_, remainder, innerErr := splitType(inner)
+			if innerErr != nil {
+				return "", "", innerErr
+			}
+			inner = remainder
+		}
+		if inner == "" {
+			return "", "", fmt.Errorf("%w: unterminated %q in signature %q", ErrMalformed, signature[0], signature)
+		}
+		length := len(signature) - len(inner) + 1
+		return signature[:length], signature[length:], nil
+	default:
+		return signature[:1], signature[1:], nil
+	}
+}
+
+// splitSignature breaks a signature into its complete types.
+func splitSignature(signature string) ([]string, error) {
+	var types []string
+	for signature != "" {
+		first, rest, err := splitType(signature)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, first)
+		signature = rest
+	}
+	return types, nil
+}
+
+// encoder appends values at offsets relative to the start of a message.
+//
+// The base matters: alignment is measured from the start of the MESSAGE,
+// and a body always starts on an 8-byte boundary, so a body encoded from
+// zero aligns identically once it is placed after its header.
+type encoder struct {
+	buf []byte
+}
+
+func (e *encoder) align(n int) {
+	for len(e.buf)%n != 0 {
+		e.buf = append(e.buf, 0)
+	}
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.align(4)
+	e.buf = binary.LittleEndian.AppendUint32(e.buf, v)
+}
+
+func (e *encoder) str(s string) {
+	e.uint32(uint32(len(s))) //nolint:gosec // bounded by maxMessageSize on the way out
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, 0)
+}
+
+func (e *encoder) signature(s string) error {
+	if len(s) > math.MaxUint8 {
+		return fmt.Errorf("%w: signature %q is longer than 255 bytes", ErrMalformed, s)
+	}
+	e.buf = append(e.buf, byte(len(s)))
+	e.buf = append(e.buf, s...)
+	e.buf = append(e.buf, 0)
+	return nil
+}
+
+// encodeAll encodes one value per complete type in the signature.
+func (e *encoder) encodeAll(signature string, values []any) error {
+	types, err := splitSignature(signature)
+	if err != nil {
+		return err
+	}
+	if len(types) != len(values) {
+		return fmt.Errorf("%w: signature %q describes %d values, got %d", ErrMalformed, signature, len(types), len(values))
+	}
+	for i, code := range types {
+		if err := e.encode(code, values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//nolint:gocyclo,cyclop // One case per type code is the readable form of a codec.
+func (e *encoder) encode(code string, value any) error {
+	mismatch := func() error {
+		return fmt.Errorf("%w: %T cannot be encoded as %q", ErrMalformed, value, code)
+	}
+	switch code[0] {
+	case 'y':
+		v, ok := value.(byte)
+		if !ok {
+			return mismatch()
+		}
+		e.buf = append(e.buf, v)
+	case 'b':
+		v, ok := value.(bool)
+		if !ok {
+			return mismatch()
+		}
+		var word uint32
+		if v {
+			word = 1
+		}
+		e.uint32(word)
+	case 'i':
+		v, ok := value.(int32)
+		if !ok {
+			return mismatch()
+		}
+		e.uint32(uint32(v)) //nolint:gosec // two's-complement reinterpretation is the wire format
+	case 'u':
+		v, ok := value.(uint32)
+		if !ok {
+			return mismatch()
+		}
+		e.uint32(v)
+	case 'x', 't':
+		var word uint64
+		switch v := value.(type) {
+		case int64:
+			word = uint64(v) //nolint:gosec // two's-complement reinterpretation is the wire format
+		case uint64:
+			word = v
+		default:
+			return mismatch()
+		}
+		e.align(8)
+		e.buf = binary.LittleEndian.AppendUint64(e.buf, word)
+	case 's':
+		v, ok := value.(string)
+		if !ok {
+			return mismatch()
+		}
+		e.str(v)
+	case 'o':
+		v, ok := value.(ObjectPath)
+		if !ok {
+			return mismatch()
+		}
+		e.str(string(v))
+	case 'g':
+		v, ok := value.(Signature)
+		if !ok {
+			return mismatch()
+		}
+		return e.signature(string(v))
+	case 'v':
+		v, ok := value.(Variant)
+		if !ok {
+			return mismatch()
+		}
+		if err := e.signature(string(v.Signature)); err != nil {
+			return err
+		}
+		return e.encodeAll(string(v.Signature), []any{v.Value})
+	case 'a':
+		return e.array(code, value)
+	case '(':
+		fields, ok := value.(Struct)
+		if !ok {
+			return mismatch()
+		}
+		e.align(8)
+		return e.encodeAll(code[1:len(code)-1], fields)
+	case '{':
+		entry, ok := value.(DictEntry)
+		if !ok {
+			return mismatch()
+		}
+		e.align(8)
+		return e.encodeAll(code[1:len(code)-1], []any{entry.Key, entry.Value})
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedType, code)
+	}
+	return nil
+}
+
+// array encodes an 'a' value. The length prefix counts the elements only,
+// not the padding between the prefix and the first element.
+func (e *encoder) array(code string, value any) error {
+	element := code[1:]
+	var elements []any
+	switch v := value.(type) {
+	case []byte:
+		if element != "y" {
+			return fmt.Errorf("%w: []byte cannot be encoded as %q", ErrMalformed, code)
+		}
+		e.uint32(uint32(len(v))) //nolint:gosec // bounded by maxMessageSize on the way out
+		e.buf = append(e.buf, v...)
+		return nil
+	case []any:
+		elements = v
+	case []DictEntry:
+		for _, entry := range v {
+			elements = append(elements, entry)
+		}
+	case []ObjectPath:
+		for _, path := range v {
+			elements = append(elements, path)
+		}
+	case []string:
+		for _, s := range v {
+			elements = append(elements, s)
+		}
+	default:
+		return fmt.Errorf("%w: %T cannot be encoded as %q", ErrMalformed, value, code)
+	}
+
+	e.uint32(0)
+	lengthAt := len(e.buf) - 4
+	e.align(alignment(element[0]))
+	start := len(e.buf)
+	for _, item := range elements {
+		if err := e.encode(element, item); err != nil {
+			return err
+		}
+	}
+	binary.LittleEndian.PutUint32(e.buf[lengthAt:], uint32(len(e.buf)-start)) //nolint:gosec // bounded above
+	return nil
+}
+
+// decoder reads values from a whole message, so alignment is measured from
+// its start exactly as the sender measured it.
+type decoder struct {
+	order binary.ByteOrder
+	buf   []byte
+	pos   int
+}
+
+func (d *decoder) align(n int) error {
+	for d.pos%n != 0 {
+		if d.pos >= len(d.buf) {
+			return fmt.Errorf("%w: truncated padding", ErrMalformed)
+		}
+		d.pos++
+	}
+	return nil
+}
+
+func (d *decoder) take(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.buf) {
+		return nil, fmt.Errorf("%w: %d bytes wanted at offset %d of %d", ErrMalformed, n, d.pos, len(d.buf))
+	}
+	out := d.buf[d.pos : d.pos+n]
+	d.pos += n
+	return out, nil
+}
+
+func (d *decoder) uint32() (uint32, error) {
+	if err := d.align(4); err != nil {
+		return 0, err
+	}
+	raw, err := d.take(4)
+	if err != nil {
+		return 0, err
+	}
+	return d.order.Uint32(raw), nil
+}
+
+func (d *decoder) str() (string, error) {
+	length, err := d.uint32()
+	if err != nil {
+		return "", err
+	}
+	raw, err := d.take(int(length) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(raw[:length]), nil
+}
+
+func (d *decoder) signature() (string, error) {
+	raw, err := d.take(1)
+	if err != nil {
+		return "", err
+	}
+	body, err := d.take(int(raw[0]) + 1)
+	if err != nil {
+		return "", err
+	}
+	return string(body[:raw[0]]), nil
+}
+
+func (d *decoder) decodeAll(signature string) ([]any, error) {
+	types, err := splitSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(types))
+	for _, code := range types {
+		value, decodeErr := d.decode(code)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+//nolint:gocyclo,cyclop // One case per type code is the readable form of a codec.
+func (d *decoder) decode(code string) (any, error) {
+	switch code[0] {
+	case 'y':
+		raw, err := d.take(1)
+		if err != nil {
+			return nil, err
+		}
+		return raw[0], nil
+	case 'b':
+		word, err := d.uint32()
+		if err != nil {
+			return nil, err
+		}
+		if word > 1 {
+			return nil, fmt.Errorf("%w: boolean %d", ErrMalformed, word)
+		}
+		return word == 1, nil
+	case 'i':
+		word, err := d.uint32()
+		return int32(word), err //nolint:gosec // two's-complement reinterpretation is the wire format
+	case 'u':
+		return d.uint32()
+	case 'x', 't':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		raw, err := d.take(8)
+		if err != nil {
+			return nil, err
+		}
+		if code[0] == 'x' {
+			return int64(d.order.Uint64(raw)), nil //nolint:gosec // two's-complement reinterpretation
+		}
+		return d.order.Uint64(raw), nil
+	case 's':
+		return d.str()
+	case 'o':
+		s, err := d.str()
+		return ObjectPath(s), err
+	case 'g':
+		s, err := d.signature()
+		return Signature(s), err
+	case 'v':
+		inner, err := d.signature()
+		if err != nil {
+			return nil, err
+		}
+		if first, rest, splitErr := splitType(inner); splitErr != nil || rest != "" || first == "" {
+			return nil, fmt.Errorf("%w: variant signature %q is not a single type", ErrMalformed, inner)
+		}
+		value, err := d.decode(inner)
+		if err != nil {
+			return nil, err
+		}
+		return Variant{Signature: Signature(inner), Value: value}, nil
+	case 'a':
+		return d.array(code)
+	case '(':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		fields, err := d.decodeAll(code[1 : len(code)-1])
+		return Struct(fields), err
+	case '{':
+		if err := d.align(8); err != nil {
+			return nil, err
+		}
+		pair, err := d.decodeAll(code[1 : len(code)-1])
+		if err != nil {
+			return nil, err
+		}
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("%w: dictionary entry %q is not a pair", ErrMalformed, code)
+		}
+		return DictEntry{Key: pair[0], Value: pair[1]}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedType, code)
+	}
+}
+
+// array decodes 'ay' to []byte, 'a{...}' to []DictEntry, and anything else
+// to []any.
+func (d *decoder) array(code string) (any, error) {
+	length, err := d.uint32()
+	if err != nil {
+		return nil, err
+	}
+	element := code[1:]
+	if err := d.align(alignment(element[0])); err != nil {
+		return nil, err
+	}
+	end := d.pos + int(length)
+	if end > len(d.buf) {
+		return nil, fmt.Errorf("%w: array of %d bytes overruns the message", ErrMalformed, length)
+	}
+	if element == "y" {
+		raw, takeErr := d.take(int(length))
+		if takeErr != nil {
+			return nil, takeErr
+		}
+		return bytes.Clone(raw), nil
+	}
+
+	var items []any
+	var entries []DictEntry
+	for d.pos < end {
+		item, decodeErr := d.decode(element)
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		if entry, isEntry := item.(DictEntry); isEntry {
+			entries = append(entries, entry)
+			continue
+		}
+		items = append(items, item)
+	}
+	if d.pos != end {
+		return nil, fmt.Errorf("%w: array elements overran their declared length", ErrMalformed)
+	}
+	if element[0] == '{' {
+		return entries, nil
+	}
+	return items, nil
+}
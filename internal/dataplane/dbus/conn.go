@@ -0,0 +1,376 @@
+package dbus
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnvSessionBusAddress is where the session bus is advertised.
+const EnvSessionBusAddress = "DBUS_SESSION_BUS_ADDRESS"
+
+// ErrNoSessionBus reports that no session bus address could be found.
+var ErrNoSessionBus = errors.New("no D-Bus session bus is available")
+
+// Message types.
+const (
+	typeMethodCall   byte = 1
+	typeMethodReturn byte = 2
+	typeError        byte = 3
+)
+
+// Header field codes.
+const (
+	fieldPath        byte = 1
+	fieldInterface   byte = 2
+	fieldMember      byte = 3
+	fieldErrorName   byte = 4
+	fieldReplySerial byte = 5
+	fieldDestination byte = 6
+	fieldSender      byte = 7
+	fieldSignature   byte = 8
+)
+
+const (
+	busName      = "org.freedesktop.DBus"
+	busPath      = ObjectPath("/org/freedesktop/DBus")
+	protocolByte = 1
+
+	// fixedHeaderLen is the part of a header before its field array's
+	// contents: endianness, type, flags, version, body length, serial and
+	// the field array's length.
+	fixedHeaderLen = 16
+)
+
+// Error is an error reply from the peer.
+type Error struct {
+	Name    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Name
+	}
+	return e.Name + ": " + e.Message
+}
+
+// message is one D-Bus message, decoded.
+type message struct {
+	fields    map[byte]any
+	body      []any
+	signature string
+	serial    uint32
+	kind      byte
+}
+
+func (m *message) field(code byte) string {
+	switch v := m.fields[code].(type) {
+	case string:
+		return v
+	case ObjectPath:
+		return string(v)
+	case Signature:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+// writeMessage encodes a message, always little-endian.
+func writeMessage(w io.Writer, m *message) error {
+	var body encoder
+	if err := body.encodeAll(m.signature, m.body); err != nil {
+		return err
+	}
+
+	var fields []any
+	for _, code := range []byte{
+		fieldPath, fieldInterface, fieldMember, fieldErrorName,
+		fieldReplySerial, fieldDestination, fieldSender,
+	} {
+		value, present := m.fields[code]
+		if !present {
+			continue
+		}
+		fields = append(fields, Struct{code, Variant{Signature: signatureOf(value), Value: value}})
+	}
+	if m.signature != "" {
+		fields = append(fields, Struct{fieldSignature, Variant{Signature: "g", Value: Signature(m.signature)}})
+	}
+
+	header := encoder{buf: []byte{'l', m.kind, 0, protocolByte}}
+	header.uint32(uint32(len(body.buf))) //nolint:gosec // bounded by maxMessageSize below
+	header.uint32(m.serial)
+	if err := header.encode("a(yv)", fields); err != nil {
+		return err
+	}
+	header.align(8)
+	if len(header.buf)+len(body.buf) > maxMessageSize {
+		return fmt.Errorf("%w: message of %d bytes exceeds the protocol limit", ErrMalformed, len(header.buf)+len(body.buf))
+	}
+	_, err := w.Write(append(header.buf, body.buf...))
+	return err
+}
+
+// signatureOf names the wire type of a header field value.
+func signatureOf(value any) Signature {
+	switch value.(type) {
+	case ObjectPath:
+		return "o"
+	case Signature:
+		return "g"
+	case uint32:
+		return "u"
+	default:
+		return "s"
+	}
+}
+
+// readMessage reads and decodes one message in either byte order.
+func readMessage(r io.Reader) (*message, error) {
+	fixed := make([]byte, fixedHeaderLen)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		return nil, err
+	}
+	var order binary.ByteOrder
+	switch fixed[0] {
+	case 'l':
+		order = binary.LittleEndian
+	case 'B':
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("%w: endianness marker %q", ErrMalformed, fixed[0])
+	}
+	if fixed[3] != protocolByte {
+		return nil, fmt.Errorf("%w: protocol version %d", ErrMalformed, fixed[3])
+	}
+	bodyLen := int(order.Uint32(fixed[4:8]))
+	fieldsLen := int(order.Uint32(fixed[12:16]))
+	headerLen := fixedHeaderLen + fieldsLen
+	if pad := headerLen % 8; pad != 0 {
+		headerLen += 8 - pad
+	}
+	if headerLen+bodyLen > maxMessageSize {
+		return nil, fmt.Errorf("%w: message of %d bytes exceeds the protocol limit", ErrMalformed, headerLen+bodyLen)
+	}
+
+	whole := make([]byte, headerLen+bodyLen)
+	copy(whole, fixed)
+	if _, err := io.ReadFull(r, whole[fixedHeaderLen:]); err != nil {
+		return nil, err
+	}
+
+	header := decoder{order: order, buf: whole[:fixedHeaderLen+fieldsLen], pos: 12}
+	raw, err := header.decode("a(yv)")
+	if err != nil {
+		return nil, err
+	}
+	m := &message{kind: fixed[1], serial: order.Uint32(fixed[8:12]), fields: map[byte]any{}}
+	for _, item := range raw.([]any) { //nolint:forcetypeassert // a(yv) decodes to []any by construction
+		field := item.(Struct)                    //nolint:forcetypeassert // (yv) decodes to Struct
+		code := field[0].(byte)                   //nolint:forcetypeassert // y decodes to byte
+		m.fields[code] = field[1].(Variant).Value //nolint:forcetypeassert // v decodes to Variant
+	}
+	m.signature = m.field(fieldSignature)
+
+	body := decoder{order: order, buf: whole, pos: headerLen}
+	if m.body, err = body.decodeAll(m.signature); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Conn is a connection to a message bus.
+//
+// Calls are serialized: one is in flight at a time, and anything the bus
+// sends in between — signals, mostly — is read and discarded. That is
+// enough for request/response use, and it is all this package offers.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	name   string
+	mu     sync.Mutex
+	serial uint32
+}
+
+// SessionBusAddress returns the session bus address from the environment,
+// falling back to the systemd user-bus socket when only XDG_RUNTIME_DIR is
+// set.
+func SessionBusAddress() (string, error) {
+	if address := os.Getenv(EnvSessionBusAddress); address != "" {
+		return address, nil
+	}
+	if runtime := os.Getenv("XDG_RUNTIME_DIR"); runtime != "" {
+		socket := filepath.Join(runtime, "bus")
+		if _, err := os.Stat(socket); err == nil {
+			return "unix:path=" + socket, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s is unset and there is no user-bus socket", ErrNoSessionBus, EnvSessionBusAddress)
+}
+
+// DialSession connects to the session bus.
+func DialSession(ctx context.Context) (*Conn, error) {
+	address, err := SessionBusAddress()
+	if err != nil {
+		return nil, err
+	}
+	return Dial(ctx, address)
+}
+
+// Dial connects to the bus at a D-Bus address, authenticates, and
+// registers with the bus.
+//
+// Only unix transports are supported, which is every session bus this
+// plane runs beside. An address listing several transports is tried in
+// order, as the specification requires.
+func Dial(ctx context.Context, address string) (*Conn, error) {
+	var failures []string
+	for _, entry := range strings.Split(address, ";") {
+		socket, ok := unixSocket(entry)
+		if !ok {
+			failures = append(failures, fmt.Sprintf("%q: unsupported transport", entry))
+			continue
+		}
+		var dialer net.Dialer
+		raw, err := dialer.DialContext(ctx, "unix", socket)
+		if err != nil {
+			failures = append(failures, err.Error())
+			continue
+		}
+		c := &Conn{conn: raw, reader: bufio.NewReader(raw)}
+		if err := c.handshake(ctx); err != nil {
+			_ = raw.Close()
+			return nil, err
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("%w: no usable transport in %q (%s)", ErrNoSessionBus, address, strings.Join(failures, "; "))
+}
+
+// unixSocket extracts the socket path from a unix transport entry. An
+// abstract socket is named with a leading NUL, as the kernel expects.
+func unixSocket(entry string) (string, bool) {
+	rest, isUnix := strings.CutPrefix(entry, "unix:")
+	if !isUnix {
+		return "", false
+	}
+	for _, option := range strings.Split(rest, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "path":
+			return value, true
+		case "abstract":
+			return "@" + value, true
+		}
+	}
+	return "", false
+}
+
+// handshake runs EXTERNAL authentication, which proves identity by the
+// socket's peer credentials rather than by anything sent, and then the Hello
+// call every bus client must make first.
+func (c *Conn) handshake(ctx context.Context) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+		defer func() { _ = c.conn.SetDeadline(time.Time{}) }()
+	}
+	uid := hex.EncodeToString([]byte(strconv.Itoa(os.Getuid())))
+	if _, err := io.WriteString(c.conn, "\x00AUTH EXTERNAL "+uid+"\r\n"); err != nil {
+		return fmt.Errorf("authenticate to the bus: %w", err)
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("read the bus's authentication reply: %w", err)
+	}
+	if !strings.HasPrefix(line, "OK ") {
+		return fmt.Errorf("the bus refused EXTERNAL authentication: %q", strings.TrimSpace(line))
+	}
+	if _, err := io.WriteString(c.conn, "BEGIN\r\n"); err != nil {
+		return fmt.Errorf("begin the bus session: %w", err)
+	}
+
+	reply, err := c.Call(ctx, busName, busPath, busName, "Hello", "")
+	if err != nil {
+		return fmt.Errorf("register with the bus: %w", err)
+	}
+	if len(reply) == 1 {
+		c.name, _ = reply[0].(string)
+	}
+	return nil
+}
+
+// Call invokes a method and returns its reply's values.
+//
+// The signature describes args; the reply describes itself. An error reply
+// is returned as *Error.
+func (c *Conn) Call(
+	ctx context.Context, destination string, path ObjectPath, iface, member string, signature Signature, args ...any,
+) ([]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = c.conn.SetDeadline(deadline)
+		defer func() { _ = c.conn.SetDeadline(time.Time{}) }()
+	}
+
+	c.serial++
+	serial := c.serial
+	call := &message{
+		kind:   typeMethodCall,
+		serial: serial,
+		fields: map[byte]any{
+			fieldPath:        path,
+			fieldInterface:   iface,
+			fieldMember:      member,
+			fieldDestination: destination,
+		},
+		signature: string(signature),
+		body:      args,
+	}
+	if err := writeMessage(c.conn, call); err != nil {
+		return nil, fmt.Errorf("send %s.%s: %w", iface, member, err)
+	}
+
+	for {
+		reply, err := readMessage(c.reader)
+		if err != nil {
+			return nil, fmt.Errorf("read the reply to %s.%s: %w", iface, member, err)
+		}
+		replyTo, _ := reply.fields[fieldReplySerial].(uint32)
+		if replyTo != serial {
+			continue
+		}
+		switch reply.kind {
+		case typeMethodReturn:
+			return reply.body, nil
+		case typeError:
+			failure := &Error{Name: reply.field(fieldErrorName)}
+			if len(reply.body) > 0 {
+				failure.Message, _ = reply.body[0].(string)
+			}
+			return nil, failure
+		}
+	}
+}
+
+// Name is the unique name the bus assigned this connection.
+func (c *Conn) Name() string { return c.name }
+
+// Close closes the connection.
+func (c *Conn) Close() error {
+	return c.conn.Close()
+}
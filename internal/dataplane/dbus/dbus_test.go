@@ -0,0 +1,129 @@
+package dbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEncodingMatchesTheSpecification pins the wire bytes for a value whose
+// layout exercises every alignment rule at once: a byte, then a string
+// aligned to 4, then an array whose length prefix is followed by padding to
+// its 8-aligned struct elements — padding the length must NOT count.
+func TestEncodingMatchesTheSpecification(t *testing.T) {
+	var e encoder
+	err := e.encodeAll("ysa(yu)", []any{
+		byte(7), "hi", []any{Struct{byte(1), uint32(2)}},
+	})
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	want := []byte{
+		7, 0, 0, 0, // y, padded to 4
+		2, 0, 0, 0, 'h', 'i', 0, // s
+		0,          // pad to 4
+		8, 0, 0, 0, // array length: one 8-byte struct, padding excluded
+		// offset 16 is already 8-aligned, so no padding here
+		1, 0, 0, 0, 2, 0, 0, 0, // (yu)
+	}
+	if !bytes.Equal(e.buf, want) {
+		t.Fatalf("encoded\n  %v\nwant\n  %v", e.buf, want)
+	}
+}
+
+// The Secret Service's own shapes survive a round trip, which is what the
+// backend built on this depends on.
+func TestSecretServiceShapesRoundTrip(t *testing.T) {
+	values := []any{
+		[]DictEntry{{Key: "service", Value: "maestro"}, {Key: "account", Value: "root"}},
+		Struct{ObjectPath("/session/1"), []byte{}, []byte("deadbeef"), "text/plain"},
+		Variant{Signature: "s", Value: ""},
+		true,
+		[]any{ObjectPath("/a"), ObjectPath("/b")},
+	}
+	signature := "a{ss}(oayays)vbao"
+
+	var e encoder
+	if err := e.encodeAll(signature, values); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	d := decoder{order: binary.LittleEndian, buf: e.buf}
+	got, err := d.decodeAll(signature)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Fatalf("round trip changed the values:\n  got  %#v\n  want %#v", got, values)
+	}
+	if d.pos != len(e.buf) {
+		t.Fatalf("decoding left %d bytes unread", len(e.buf)-d.pos)
+	}
+}
+
+// Types outside the supported set are refused in both directions, never
+// guessed at.
+func TestUnsupportedTypesAreRefused(t *testing.T) {
+	var e encoder
+	if err := e.encodeAll("d", []any{1.5}); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("encoding a double returned %v, want ErrUnsupportedType", err)
+	}
+	d := decoder{order: binary.LittleEndian, buf: make([]byte, 8)}
+	if _, err := d.decodeAll("h"); !errors.Is(err, ErrUnsupportedType) {
+		t.Fatalf("decoding a unix fd returned %v, want ErrUnsupportedType", err)
+	}
+}
+
+// A declared length past the end of the message is malformed, not an
+// allocation.
+func TestTruncatedArrayIsMalformed(t *testing.T) {
+	d := decoder{order: binary.LittleEndian, buf: []byte{0xff, 0xff, 0, 0, 1, 2}}
+	if _, err := d.decodeAll("ay"); !errors.Is(err, ErrMalformed) {
+		t.Fatalf("decoded an array longer than its message (err = %v)", err)
+	}
+}
+
+// TestCallOverTheStandIn is the whole client against a peer speaking the
+// real protocol: authentication, Hello, a call, and an error reply.
+func TestCallOverTheStandIn(t *testing.T) {
+	standIn, err := NewStandIn(t.TempDir(), func(call Call) (Signature, []any, error) {
+		switch call.Member {
+		case "Echo":
+			return "as", []any{[]any{call.Args[0], string(call.Path)}}, nil
+		default:
+			return "", nil, &Error{Name: "org.example.Error.NoSuchMethod", Message: call.Member}
+		}
+	})
+	if err != nil {
+		t.Fatalf("NewStandIn: %v", err)
+	}
+	t.Cleanup(func() { _ = standIn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := Dial(ctx, "tcp:host=nowhere;"+standIn.Address())
+	if err != nil {
+		t.Fatalf("Dial: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+	if conn.Name() == "" {
+		t.Fatal("Hello assigned no unique name")
+	}
+
+	reply, err := conn.Call(ctx, "org.example", "/obj", "org.example.Iface", "Echo", "s", "ping")
+	if err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if want := []any{[]any{"ping", "/obj"}}; !reflect.DeepEqual(reply, want) {
+		t.Fatalf("reply = %#v, want %#v", reply, want)
+	}
+
+	_, err = conn.Call(ctx, "org.example", "/obj", "org.example.Iface", "Missing", "")
+	var failure *Error
+	if !errors.As(err, &failure) || failure.Name != "org.example.Error.NoSuchMethod" {
+		t.Fatalf("error reply surfaced as %v, want the peer's named error", err)
+	}
+}
@@ -0,0 +1,170 @@
+package dbus
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Call is one method call, as the stand-in's handler sees it.
+type Call struct {
+	Path      ObjectPath
+	Interface string
+	Member    string
+	Args      []any
+}
+
+// Handler answers a method call with a reply signature and values, or an
+// error. An *Error is sent as that D-Bus error; anything else as
+// org.freedesktop.DBus.Error.Failed.
+type Handler func(call Call) (Signature, []any, error)
+
+// StandIn is a local peer that speaks the bus protocol to one client at a
+// time, for tests of code that would otherwise need a session bus daemon.
+//
+// It is a stand-in, not a bus: it routes nothing, ignores destinations, and
+// answers every call with its one handler. What it does do faithfully is
+// the wire — the authentication exchange, Hello, and every message encoded
+// and decoded by the same rules a real daemon applies — so a client tested
+// against it has spoken the actual protocol, not a mock of it.
+//
+// It lives in the package rather than in a test file because its users are
+// other packages' tests: a backend built on this client is tested by
+// standing its service up here.
+type StandIn struct {
+	listener net.Listener
+	handler  Handler
+	address  string
+	wg       sync.WaitGroup
+}
+
+// NewStandIn listens on a socket in dir and serves calls with handler until
+// Close.
+func NewStandIn(dir string, handler Handler) (*StandIn, error) {
+	socket := filepath.Join(dir, "bus")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("listen for the stand-in bus: %w", err)
+	}
+	s := &StandIn{listener: listener, handler: handler, address: "unix:path=" + socket}
+	s.wg.Add(1)
+	go s.accept()
+	return s, nil
+}
+
+// Address is the D-Bus address a client dials.
+func (s *StandIn) Address() string { return s.address }
+
+// Close stops accepting and waits for every connection to finish.
+func (s *StandIn) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *StandIn) accept() {
+	defer s.wg.Done()
+	for client := 1; ; client++ {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() { _ = conn.Close() }()
+			_ = s.serve(conn, ":1."+strconv.Itoa(client))
+		}()
+	}
+}
+
+// serve runs one connection: authentication, then calls until the client
+// goes away.
+func (s *StandIn) serve(conn net.Conn, uniqueName string) error {
+	reader := bufio.NewReader(conn)
+	if err := standInAuth(conn, reader); err != nil {
+		return err
+	}
+
+	var serial uint32
+	for {
+		call, err := readMessage(reader)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if call.kind != typeMethodCall {
+			continue
+		}
+		serial++
+		reply := &message{
+			kind:   typeMethodReturn,
+			serial: serial,
+			fields: map[byte]any{fieldReplySerial: call.serial, fieldDestination: uniqueName},
+		}
+
+		var signature Signature
+		var values []any
+		var handlerErr error
+		if call.field(fieldMember) == "Hello" && call.field(fieldInterface) == busName {
+			signature, values = "s", []any{uniqueName}
+		} else {
+			signature, values, handlerErr = s.handler(Call{
+				Path:      ObjectPath(call.field(fieldPath)),
+				Interface: call.field(fieldInterface),
+				Member:    call.field(fieldMember),
+				Args:      call.body,
+			})
+		}
+		if handlerErr != nil {
+			failure := &Error{Name: "org.freedesktop.DBus.Error.Failed", Message: handlerErr.Error()}
+			var named *Error
+			if errors.As(handlerErr, &named) {
+				failure = named
+			}
+			reply.kind = typeError
+			reply.fields[fieldErrorName] = failure.Name
+			signature, values = "s", []any{failure.Message}
+		}
+		reply.signature, reply.body = string(signature), values
+		if err := writeMessage(conn, reply); err != nil {
+			return err
+		}
+	}
+}
+
+// standInAuth accepts EXTERNAL authentication from any client: the stand-in
+// is for tests, and the socket is in the test's own directory.
+func standInAuth(conn net.Conn, reader *bufio.Reader) error {
+	nul, err := reader.ReadByte()
+	if err != nil || nul != 0 {
+		return fmt.Errorf("stand-in bus: expected the credentials byte: %w", err)
+	}
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "AUTH EXTERNAL") {
+		_, _ = io.WriteString(conn, "REJECTED EXTERNAL\r\n")
+		return fmt.Errorf("stand-in bus: unsupported authentication %q", strings.TrimSpace(line))
+	}
+	if _, err := io.WriteString(conn, "OK 0123456789abcdef0123456789abcdef\r\n"); err != nil {
+		return err
+	}
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(line) != "BEGIN" {
+		return fmt.Errorf("stand-in bus: expected BEGIN, got %q", strings.TrimSpace(line))
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.31.1
+// source: rekey.sql
+
+package gen
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const listSecretsForRekey = `-- name: ListSecretsForRekey :many
+
+SELECT secret_id, organization_id, name, owner_user_id, scope_type, scope_organization_id, scope_product_id, scope_repository_id, scope_id, scheme, nonce, ciphertext, version, created_at, updated_at FROM secrets
+WHERE organization_id = $1
+ORDER BY secret_id
+`
+
+// Root-key rotation (the vault's re-seal pass).
+//
+// These two statements serve `dataplane-rotate-key` and
+// `dataplane-retire-key`, which move every envelope from one root key to
+// another. They are the ONLY statements against the vault that carry no
+// acting user, and that is deliberate rather than an omission: a re-seal is
+// not an access by anybody. It opens nothing for a caller, returns no
+// plaintext across the seam, and leaves every field that decides who may
+// read a secret exactly as it was — the authenticated data binds them, and
+// the re-seal rebuilds that binding from the stored row.
+//
+// What they keep from their neighbours is TENANCY. Like verification, the
+// pass iterates organizations rather than reaching across them, so there is
+// still no statement here that a caller in the wrong tenant could serve.
+// ListSecretsForRekey returns every envelope in one organization.
+//
+// Every row, individual and shared alike: a rotation that skipped a row
+// would leave it sealed under a key the plane is about to stop holding, and
+// the secret would become unreadable the day that key is retired.
+func (q *Queries) ListSecretsForRekey(ctx context.Context, organizationID pgtype.UUID) ([]Secret, error) {
+	rows, err := q.db.Query(ctx, listSecretsForRekey, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Secret{}
+	for rows.Next() {
+		var i Secret
+		if err := rows.Scan(
+			&i.SecretID,
+			&i.OrganizationID,
+			&i.Name,
+			&i.OwnerUserID,
+			&i.ScopeType,
+			&i.ScopeOrganizationID,
+			&i.ScopeProductID,
+			&i.ScopeRepositoryID,
+			&i.ScopeID,
+			&i.Scheme,
+			&i.Nonce,
+			&i.Ciphertext,
+			&i.Version,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const resealSecret = `-- name: ResealSecret :execrows
+UPDATE secrets s
+SET scheme     = $1,
+    nonce      = $2,
+    ciphertext = $3
+WHERE s.organization_id = $4
+  AND s.secret_id       = $5
+  AND s.version         = $6
+`
+
+type ResealSecretParams struct {
+	Scheme          string
+	Nonce           []byte
+	Ciphertext      []byte
+	OrganizationID  pgtype.UUID
+	SecretID        pgtype.UUID
+	ExpectedVersion int32
+}
+
+// ResealSecret replaces one envelope under its expected version.
+//
+// The version is NOT bumped, and neither is updated_at: the secret's value
+// has not changed, and a caller holding version N for a replacement must
+// not be refused because the plane changed keys underneath it. Reusing the
+// version is safe because the root key is part of the derivation — the same
+// secret id and version under a new root key is a new per-version key, so
+// the new nonce is drawn from a space the old ciphertext never used.
+func (q *Queries) ResealSecret(ctx context.Context, arg ResealSecretParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resealSecret,
+		arg.Scheme,
+		arg.Nonce,
+		arg.Ciphertext,
+		arg.OrganizationID,
+		arg.SecretID,
+		arg.ExpectedVersion,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
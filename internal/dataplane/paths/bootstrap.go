@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -17,11 +18,23 @@ import (
 var ErrInvalidBootstrap = errors.New("invalid bootstrap pointer")
 
 const (
-	// RootOfTrustKeyFile is the shipped root-of-trust backend. Keychain and
-	// passphrase are the opt-in upgrades, added here when implemented so an
-	// unimplemented kind cannot validate.
+	// RootOfTrustKeyFile is the default root-of-trust backend. The other
+	// kinds below are opt-in, and each is added here only once it is
+	// implemented, so an unimplemented kind cannot validate.
 	RootOfTrustKeyFile = "key_file"
 
+	// RootOfTrustSecretService holds the key in the Linux Secret Service —
+	// the desktop keyring — found by its attributes.
+	RootOfTrustSecretService = "secret_service"
+
+	// RootOfTrustVaultTransit holds the key WRAPPED: the file at Path is
+	// ciphertext that only a Vault transit key can open.
+	RootOfTrustVaultTransit = "vault_transit"
+
+	// RootOfTrustCommand runs an operator-supplied helper and reads the key
+	// from its standard output.
+	RootOfTrustCommand = "command"
+
 	minPort = 1
 	maxPort = 65535
 
@@ -77,12 +90,34 @@ type ObjectStore struct {
 
 // RootOfTrust references the external unlock anchor — a reference, never
 // the key material itself.
+//
+// Every kind's settings are locators: where the key is, or what to ask for
+// it. None is a credential. The Vault token in particular is read from the
+// environment at the point of use, because a pointer that held one would be
+// a file that unlocks the plane, which is the one thing this file must
+// never be.
+//
+//nolint:govet // fieldalignment: readable order preferred over packing.
 type RootOfTrust struct {
-	// Kind is the auth-module backend: "key_file" today, with "keychain"
-	// and "passphrase" as the opt-in upgrades behind the same interface.
+	// Kind selects the backend: one of the RootOfTrust* constants.
 	Kind string `json:"kind"`
-	// Path is set for the key_file kind.
+	// Path is the key file for key_file, and the wrapped key for
+	// vault_transit.
 	Path string `json:"path,omitempty"`
+	// Attributes find the secret_service item. They are matched exactly and
+	// must match one item.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Address is the vault_transit server: a bare http(s) origin.
+	Address string `json:"address,omitempty"`
+	// Mount is the vault_transit secrets-engine mount. Empty means
+	// "transit", Vault's own default.
+	Mount string `json:"mount,omitempty"`
+	// KeyName is the vault_transit key that wraps the root key.
+	KeyName string `json:"key_name,omitempty"`
+	// Command is the helper's argv for the command kind. It is executed
+	// directly, never through a shell, and its program must be an absolute
+	// path so what runs does not depend on whoever set PATH.
+	Command []string `json:"command,omitempty"`
 }
 
 // WriteBootstrap writes the pointer atomically, replacing any existing one.
@@ -225,26 +260,32 @@ func (b *Bootstrap) validate() error {
 // than by reviewers noticing. The path is held to "" or "/" for the same
 // reason — a token in a path segment is still a token.
 func validateEndpoint(endpoint string) error {
-	if endpoint == "" {
-		return fmt.Errorf("%w: objects.endpoint is required", ErrInvalidBootstrap)
+	return validateOrigin("objects.endpoint", endpoint)
+}
+
+// validateOrigin is validateEndpoint's rule for any origin-valued field,
+// named in its errors.
+func validateOrigin(field, origin string) error {
+	if origin == "" {
+		return fmt.Errorf("%w: %s is required", ErrInvalidBootstrap, field)
 	}
-	u, err := url.Parse(endpoint)
+	u, err := url.Parse(origin)
 	if err != nil {
-		return fmt.Errorf("%w: objects.endpoint %q is not a URL: %w", ErrInvalidBootstrap, endpoint, err)
+		return fmt.Errorf("%w: %s %q is not a URL: %w", ErrInvalidBootstrap, field, origin, err)
 	}
 	switch {
 	case u.Scheme != "http" && u.Scheme != "https":
-		return fmt.Errorf("%w: objects.endpoint %q must be http or https", ErrInvalidBootstrap, endpoint)
+		return fmt.Errorf("%w: %s %q must be http or https", ErrInvalidBootstrap, field, origin)
 	case u.Host == "":
-		return fmt.Errorf("%w: objects.endpoint %q has no host", ErrInvalidBootstrap, endpoint)
+		return fmt.Errorf("%w: %s %q has no host", ErrInvalidBootstrap, field, origin)
 	case u.User != nil:
-		return fmt.Errorf("%w: objects.endpoint must not contain userinfo; credentials never live in the bootstrap pointer", ErrInvalidBootstrap)
+		return fmt.Errorf("%w: %s must not contain userinfo; credentials never live in the bootstrap pointer", ErrInvalidBootstrap, field)
 	case u.RawQuery != "":
-		return fmt.Errorf("%w: objects.endpoint must not contain a query string", ErrInvalidBootstrap)
+		return fmt.Errorf("%w: %s must not contain a query string", ErrInvalidBootstrap, field)
 	case u.Fragment != "":
-		return fmt.Errorf("%w: objects.endpoint must not contain a fragment", ErrInvalidBootstrap)
+		return fmt.Errorf("%w: %s must not contain a fragment", ErrInvalidBootstrap, field)
 	case u.Path != "" && u.Path != "/":
-		return fmt.Errorf("%w: objects.endpoint %q must be a bare origin, with no path", ErrInvalidBootstrap, endpoint)
+		return fmt.Errorf("%w: %s %q must be a bare origin, with no path", ErrInvalidBootstrap, field, origin)
 	}
 	return nil
 }
@@ -306,14 +347,48 @@ func isHostRune(r rune) bool {
 // refused rather than defaulted: silently falling back to the key file
 // when someone asked for a keychain would put the key somewhere they did
 // not intend.
+//
+// A setting that belongs to a DIFFERENT kind is refused too. The file is
+// hand-edited, and an operator who switches kind and leaves the old
+// settings behind — or who misremembers which kind takes which — would
+// otherwise have a field that reads as configuration and does nothing.
 func (r RootOfTrust) validate() error {
+	if err := r.refuseForeignSettings(); err != nil {
+		return err
+	}
 	switch r.Kind {
 	case RootOfTrustKeyFile:
-		if r.Path == "" {
-			return fmt.Errorf("%w: root_of_trust.path is required for kind %q", ErrInvalidBootstrap, RootOfTrustKeyFile)
+		return r.validatePath()
+	case RootOfTrustSecretService:
+		if len(r.Attributes) == 0 {
+			return fmt.Errorf("%w: root_of_trust.attributes is required for kind %q: with none, every "+
+				"item in the keyring matches", ErrInvalidBootstrap, r.Kind)
+		}
+		for name := range r.Attributes {
+			if name == "" {
+				return fmt.Errorf("%w: root_of_trust.attributes has an empty name", ErrInvalidBootstrap)
+			}
+		}
+		return nil
+	case RootOfTrustVaultTransit:
+		if err := r.validatePath(); err != nil {
+			return err
+		}
+		if err := validateOrigin("root_of_trust.address", r.Address); err != nil {
+			return err
 		}
-		if !filepath.IsAbs(r.Path) {
-			return fmt.Errorf("%w: root_of_trust.path %q must be absolute", ErrInvalidBootstrap, r.Path)
+		if r.Mount != "" {
+			for _, segment := range strings.Split(r.Mount, "/") {
+				if err := validateSegment("root_of_trust.mount", segment); err != nil {
+					return err
+				}
+			}
+		}
+		return validateSegment("root_of_trust.key_name", r.KeyName)
+	case RootOfTrustCommand:
+		if len(r.Command) == 0 || !filepath.IsAbs(r.Command[0]) {
+			return fmt.Errorf("%w: root_of_trust.command must name its program by absolute path, so "+
+				"what runs does not depend on PATH", ErrInvalidBootstrap)
 		}
 		return nil
 	case "":
@@ -322,3 +397,56 @@ func (r RootOfTrust) validate() error {
 		return fmt.Errorf("%w: unsupported root_of_trust.kind %q", ErrInvalidBootstrap, r.Kind)
 	}
 }
+
+func (r RootOfTrust) validatePath() error {
+	if r.Path == "" {
+		return fmt.Errorf("%w: root_of_trust.path is required for kind %q", ErrInvalidBootstrap, r.Kind)
+	}
+	if !filepath.IsAbs(r.Path) {
+		return fmt.Errorf("%w: root_of_trust.path %q must be absolute", ErrInvalidBootstrap, r.Path)
+	}
+	return nil
+}
+
+// refuseForeignSettings rejects a setting the selected kind does not read.
+func (r RootOfTrust) refuseForeignSettings() error {
+	set := map[string]bool{
+		"path":       r.Path != "",
+		"attributes": len(r.Attributes) > 0,
+		"address":    r.Address != "",
+		"mount":      r.Mount != "",
+		"key_name":   r.KeyName != "",
+		"command":    len(r.Command) > 0,
+	}
+	reads := map[string][]string{
+		RootOfTrustKeyFile:       {"path"},
+		RootOfTrustSecretService: {"attributes"},
+		RootOfTrustVaultTransit:  {"path", "address", "mount", "key_name"},
+		RootOfTrustCommand:       {"command"},
+	}
+	allowed, known := reads[r.Kind]
+	if !known {
+		return nil // the kind itself is refused by the caller
+	}
+	for _, field := range []string{"path", "attributes", "address", "mount", "key_name", "command"} {
+		if set[field] && !slices.Contains(allowed, field) {
+			return fmt.Errorf("%w: root_of_trust.%s is not read by kind %q", ErrInvalidBootstrap, field, r.Kind)
+		}
+	}
+	return nil
+}
+
+// validateSegment requires one URL path segment of plain characters, since
+// the value is placed into a request path.
+func validateSegment(field, segment string) error {
+	if segment == "" || segment == "." || segment == ".." {
+		return fmt.Errorf("%w: %s %q is not a usable name", ErrInvalidBootstrap, field, segment)
+	}
+	for _, r := range segment {
+		if !isHostRune(r) {
+			return fmt.Errorf("%w: %s %q may contain only letters, digits, '.', '-' and '_'",
+				ErrInvalidBootstrap, field, segment)
+		}
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -30,7 +31,7 @@ func TestBootstrapRoundTrip(t *testing.T) {
 	}
 
 	want.SchemaVersion = BootstrapSchemaVersion
-	if got != *want {
+	if !reflect.DeepEqual(got, *want) {
 		t.Errorf("round trip mismatch\n got: %+v\nwant: %+v", got, want)
 	}
 }
@@ -242,6 +243,28 @@ func TestBootstrapValidation(t *testing.T) {
 		{name: "unsupported root of trust kind", mutate: func(b *Bootstrap) { b.RootOfTrust.Kind = "keychain" }},
 		{name: "key file without path", mutate: func(b *Bootstrap) { b.RootOfTrust.Path = "" }},
 		{name: "key file with relative path", mutate: func(b *Bootstrap) { b.RootOfTrust.Path = "rel/key" }},
+		{name: "key file with a command", mutate: func(b *Bootstrap) { b.RootOfTrust.Command = []string{"/bin/true"} }},
+		{name: "secret service without attributes", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustSecretService}
+		}},
+		{name: "secret service with a path", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustSecretService, Path: "/cfg/root-of-trust.key",
+				Attributes: map[string]string{"service": "maestro"}}
+		}},
+		{name: "vault without address", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustVaultTransit, Path: "/cfg/root.wrapped", KeyName: "maestro"}
+		}},
+		{name: "vault address with a token", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustVaultTransit, Path: "/cfg/root.wrapped",
+				Address: "https://vault.example?token=hvs.x", KeyName: "maestro"}
+		}},
+		{name: "vault key name that escapes its path", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustVaultTransit, Path: "/cfg/root.wrapped",
+				Address: "https://vault.example", KeyName: "../sys"}
+		}},
+		{name: "command by relative program", mutate: func(b *Bootstrap) {
+			b.RootOfTrust = RootOfTrust{Kind: RootOfTrustCommand, Command: []string{"pass", "maestro"}}
+		}},
 	}
 
 	for _, tc := range tests {
@@ -456,3 +479,31 @@ func TestOwnershipErrorGuidance(t *testing.T) {
 		}
 	}
 }
+
+// Every opt-in kind round-trips with the settings it reads, so an operator
+// who selects one by hand-editing the pointer has it survive the rewrite
+// every `up` performs.
+func TestBootstrapAcceptsEveryRootOfTrustKind(t *testing.T) {
+	for _, reference := range []RootOfTrust{
+		{Kind: RootOfTrustSecretService, Attributes: map[string]string{"service": "maestro", "plane": "local"}},
+		{Kind: RootOfTrustVaultTransit, Path: "/cfg/root-of-trust.wrapped", Address: "https://vault.example:8200",
+			Mount: "team/transit", KeyName: "maestro-root"},
+		{Kind: RootOfTrustCommand, Command: []string{"/usr/bin/pass", "show", "maestro/root"}},
+	} {
+		t.Run(reference.Kind, func(t *testing.T) {
+			root := t.TempDir()
+			want := sampleBootstrap()
+			want.RootOfTrust = reference
+			if err := WriteBootstrap(root, want); err != nil {
+				t.Fatalf("WriteBootstrap: %v", err)
+			}
+			got, err := ReadBootstrap(root)
+			if err != nil {
+				t.Fatalf("ReadBootstrap: %v", err)
+			}
+			if !reflect.DeepEqual(got.RootOfTrust, reference) {
+				t.Fatalf("root of trust came back as %+v, want %+v", got.RootOfTrust, reference)
+			}
+		})
+	}
+}
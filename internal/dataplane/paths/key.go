@@ -437,12 +437,27 @@ func readKey(path string) ([]byte, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read root-of-trust key %s: %w", path, err)
 	}
+	key, err := DecodeKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("root-of-trust key %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// DecodeKey reads key material in the form EncodeKey writes, wherever it
+// was held.
+//
+// Exported for the root-key backends that hold the key somewhere other than
+// a file — a keyring item, a helper's output — so every encoded key is
+// parsed by the one rule, and a key moved between backends by copying its
+// text is read back as the same key.
+func DecodeKey(raw []byte) ([]byte, error) {
 	key, err := hex.DecodeString(strings.TrimSpace(string(raw)))
 	if err != nil {
-		return nil, fmt.Errorf("decode root-of-trust key %s: %w", path, err)
+		return nil, fmt.Errorf("decode root-of-trust key: %w", err)
 	}
 	if len(key) != keyLen {
-		return nil, fmt.Errorf("root-of-trust key %s is %d bytes, want %d", path, len(key), keyLen)
+		return nil, fmt.Errorf("root-of-trust key is %d bytes, want %d", len(key), keyLen)
 	}
 	return key, nil
 }
@@ -0,0 +1,44 @@
+-- Root-key rotation (the vault's re-seal pass).
+--
+-- These two statements serve `dataplane-rotate-key` and
+-- `dataplane-retire-key`, which move every envelope from one root key to
+-- another. They are the ONLY statements against the vault that carry no
+-- acting user, and that is deliberate rather than an omission: a re-seal is
+-- not an access by anybody. It opens nothing for a caller, returns no
+-- plaintext across the seam, and leaves every field that decides who may
+-- read a secret exactly as it was — the authenticated data binds them, and
+-- the re-seal rebuilds that binding from the stored row.
+--
+-- What they keep from their neighbours is TENANCY. Like verification, the
+-- pass iterates organizations rather than reaching across them, so there is
+-- still no statement here that a caller in the wrong tenant could serve.
+
+-- ListSecretsForRekey returns every envelope in one organization.
+--
+-- Every row, individual and shared alike: a rotation that skipped a row
+-- would leave it sealed under a key the plane is about to stop holding, and
+-- the secret would become unreadable the day that key is retired.
+--
+-- name: ListSecretsForRekey :many
+SELECT * FROM secrets
+WHERE organization_id = @organization_id
+ORDER BY secret_id;
+
+-- ResealSecret replaces one envelope under its expected version.
+--
+-- The version is NOT bumped, and neither is updated_at: the secret's value
+-- has not changed, and a caller holding version N for a replacement must
+-- not be refused because the plane changed keys underneath it. Reusing the
+-- version is safe because the root key is part of the derivation — the same
+-- secret id and version under a new root key is a new per-version key, so
+-- the new nonce is drawn from a space the old ciphertext never used.
+--
+-- name: ResealSecret :execrows
+UPDATE secrets s
+SET scheme     = @scheme,
+    nonce      = @nonce,
+    ciphertext = @ciphertext
+WHERE s.organization_id = @organization_id
+  AND s.secret_id       = @secret_id
+  AND s.version         = @expected_version
+;
@@ -312,6 +312,7 @@ var namedVersionedMutations = map[string]string{
 	"DeleteConfigurationRecord": "configuration_records",
 	"ReplaceSecret":             "secrets",
 	"DeleteSecret":              "secrets",
+	"ResealSecret":              "secrets",
 }
 
 // versionedSetColumns are the ONLY columns these updates may assign.
@@ -411,7 +412,10 @@ func TestVersionedTablesAreMutatedOnlyUnderTheirGuards(t *testing.T) {
 				"reporting success.", stmt.file, stmt.name, table)
 		}
 
-		if table == "secrets" {
+		// A maintenance re-seal has no acting user to guard by. Tenancy is
+		// what it keeps instead, and TestEverySecretStatementCarriesItsGuards
+		// requires it.
+		if table == "secrets" && !secretStatements[stmt.name].maintenance {
 			for _, branch := range ownershipBranches {
 				if !branch.pattern.MatchString(where) {
 					t.Errorf("%s: %q mutates secrets without %s — %s. Enforcing ownership on reads "+
@@ -460,6 +464,11 @@ func TestVersionedTablesAreMutatedOnlyUnderTheirGuards(t *testing.T) {
 var secretStatements = map[string]struct {
 	ownership  bool // filters rows by the acting user
 	membership bool // correlates that user with the organization
+	// maintenance marks a statement that re-seals envelopes for a root-key
+	// rotation. It has no acting user, so it owes neither guard above —
+	// and it is required to be ORGANIZATION-SCOPED instead, so the one
+	// family exempt from the access model is not also exempt from tenancy.
+	maintenance bool
 }{
 	// Creation has no ownership predicate to carry: the owner is a column it
 	// writes, not a row it selects. Membership is the whole guard, and the
@@ -471,8 +480,18 @@ var secretStatements = map[string]struct {
 	"GetSecret":                  {ownership: true, membership: true},
 	"ReplaceSecret":              {ownership: true, membership: true},
 	"DeleteSecret":               {ownership: true, membership: true},
+
+	// The re-seal pass (rekey.sql). Neither returns plaintext across the
+	// seam nor changes who may read a row; see that file for why an acting
+	// user would guard nothing here.
+	"ListSecretsForRekey": {maintenance: true},
+	"ResealSecret":        {maintenance: true},
 }
 
+// organizationScope is the tenancy predicate a maintenance statement owes in
+// place of the acting-user guards.
+var organizationScope = regexp.MustCompile(`(?i)organization_id\s*=\s*@organization_id`)
+
 // membershipCorrelations are both halves the EXISTS must carry. An
 // `EXISTS (SELECT 1 FROM users …)` that correlated only the user id would
 // pass a shape check while proving nothing about the tenant, and one that
@@ -508,6 +527,20 @@ func TestEverySecretStatementCarriesItsGuards(t *testing.T) {
 		}
 		seen[stmt.name] = true
 
+		if required.maintenance {
+			if required.ownership || required.membership {
+				t.Errorf("%s: %q is listed as maintenance AND as carrying acting-user guards; the "+
+					"table describes a statement that cannot exist", stmt.file, stmt.name)
+			}
+			if !organizationScope.MatchString(stmt.sql) {
+				t.Errorf("%s: %q re-seals without `organization_id = @organization_id`. It is exempt "+
+					"from the access model because it acts for nobody, not from tenancy: a pass that "+
+					"reached across organizations is a statement a caller in the wrong tenant could "+
+					"serve.", stmt.file, stmt.name)
+			}
+			continue
+		}
+
 		if required.ownership {
 			for _, branch := range ownershipBranches {
 				if !branch.pattern.MatchString(stmt.sql) {
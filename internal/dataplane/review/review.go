@@ -0,0 +1,326 @@
+// Package review is the human front-end to the seam's review transitions.
+//
+// ADR 0020 requires every Management artifact to be reviewed by a principal
+// that is not its author, and the seam has carried the transitions that
+// enforce it since the artifact tables existed — but nothing a person could
+// drive. This package is that driver, shared by `dataplanectl artifact` and
+// the artifact browser's forms so the two cannot disagree about what a
+// human review is.
+//
+// It decides nothing the seam decides. Acceptance, base checking and the
+// author/reviewer rules all stay in the seam, where they hold for every
+// caller; what this package adds is what only a FRONT-END can do: record
+// the human as a principal, refuse self-review before a review row exists
+// rather than at acceptance, and turn a refusal into the next step an
+// operator should take.
+package review
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/store"
+)
+
+// Seam is what a Desk writes through. store.Store satisfies it; the
+// interface is narrower so a test can stand one up, and so a reader can see
+// which transitions a human may drive.
+type Seam interface {
+	GetManagementArtifact(ctx context.Context, organizationID, artifactID uuid.UUID) (*store.ManagementArtifact, error)
+	GetPrincipalInstance(ctx context.Context, organizationID, instanceID uuid.UUID) (*store.PrincipalInstance, error)
+
+	CreatePrincipalInstance(ctx context.Context, input store.CreatePrincipalInstanceInput) (*store.PrincipalInstance, error)
+	StopPrincipalInstance(ctx context.Context, organizationID, instanceID uuid.UUID, reason string) (store.StopOutcome, error)
+
+	CreateReview(ctx context.Context, input store.CreateReviewInput) (*store.Review, error)
+	AcceptArtifact(ctx context.Context, organizationID, artifactID, reviewID uuid.UUID) error
+	AcceptAmendment(ctx context.Context, organizationID, amendmentID, reviewID uuid.UUID) error
+	InvalidateArtifact(ctx context.Context, organizationID, artifactID uuid.UUID) error
+	SupersedeArtifact(ctx context.Context, organizationID, targetID, supersedingID, reviewID uuid.UUID) error
+}
+
+var _ Seam = store.Store(nil)
+
+// ErrSelfReview reports a human reviewing an artifact they authored.
+//
+// The seam refuses the same thing at acceptance (ReasonReviewerIsAuthorUser),
+// which is where it must be enforced. It is refused here as well because a
+// review row is permanent: recorded and then refused at acceptance, it
+// would sit in the artifact's history as a verdict its author gave on their
+// own work.
+var ErrSelfReview = errors.New("a human may author an artifact or review it, never both")
+
+// ErrUseSupersede reports an accept aimed at an artifact that supersedes
+// another. Accepting it alone would leave the target authoritative beside
+// it; supersession accepts one and retires the other together.
+var ErrUseSupersede = errors.New("this artifact supersedes another; accept it by superseding")
+
+// errInput marks a submission refused before the seam is asked anything.
+var errInput = errors.New("invalid review")
+
+// stopTimeout bounds the write that closes a reviewer's instance. It runs
+// on a detached context, so it needs a deadline of its own.
+const stopTimeout = 10 * time.Second
+
+// Submission is one human decision about one artifact.
+//
+// Seen is the review digest of the content the reviewer READ, and the base
+// is the amendment base they read it against. Both are supplied rather than
+// looked up: a review binds what its reviewer saw (design D3a), and a front
+// end that filled them in at submission would bind the review to whatever
+// the artifact says by then.
+type Submission struct {
+	BaseDigest   *string
+	BaseSequence *int
+
+	Seen      string
+	Rationale string
+	Decision  store.Decision
+
+	ArtifactID uuid.UUID
+}
+
+// Desk drives review transitions as one human.
+type Desk struct {
+	seam Seam
+	user store.User
+}
+
+// NewDesk returns a desk acting as user, in the user's organization.
+//
+// The user is resolved by the caller and never created here, for the reason
+// the importer resolves its operator: a review desk that provisions the
+// people it records is one typo from a reviewer nobody is.
+//
+//nolint:gocritic // hugeParam: by value, so the desk holds its own copy
+func NewDesk(seam Seam, user store.User) (*Desk, error) {
+	if seam == nil {
+		return nil, errors.New("review desk needs a seam")
+	}
+	if user.UserID == uuid.Nil || user.OrganizationID == uuid.Nil {
+		return nil, errors.New("review desk needs a resolved user")
+	}
+	return &Desk{seam: seam, user: user}, nil
+}
+
+// Reviewer is the human this desk acts as.
+func (d *Desk) Reviewer() store.User { return d.user }
+
+// Authored reports whether the desk's human authored the artifact, which
+// is the question a front-end asks before offering a review at all.
+func (d *Desk) Authored(ctx context.Context, artifact *store.ManagementArtifact) (bool, error) {
+	author, err := d.seam.GetPrincipalInstance(ctx, d.user.OrganizationID, artifact.AuthorInstanceID)
+	if err != nil {
+		return false, fmt.Errorf("read the author of %s: %w", artifact.ArtifactID, err)
+	}
+	return author.Kind == store.PrincipalHuman && author.UserID != nil && *author.UserID == d.user.UserID, nil
+}
+
+// Record writes one review as a fresh human principal instance.
+//
+// One instance per review, opened and closed around it: an instance is one
+// acting lifetime, and a person's review is one act. The identity that
+// outlives it is the user, which the seam's self-review rule compares.
+//
+// A review of content other than the artifact's current content is
+// recorded, not refused — it is a true account of what was read — but it
+// can never be accepted, and the returned review says so by its digest.
+//
+//nolint:gocritic // hugeParam: by value, so a caller cannot change it mid-call
+func (d *Desk) Record(ctx context.Context, submission Submission) (_ *store.Review, err error) {
+	if err := checkSubmission(&submission); err != nil {
+		return nil, err
+	}
+	org := d.user.OrganizationID
+	artifact, err := d.seam.GetManagementArtifact(ctx, org, submission.ArtifactID)
+	if err != nil {
+		return nil, fmt.Errorf("read artifact %s: %w", submission.ArtifactID, err)
+	}
+	authored, err := d.Authored(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	if authored {
+		return nil, fmt.Errorf("%w: %s authored artifact %s", ErrSelfReview, d.user.Handle, artifact.ArtifactID)
+	}
+	if artifact.IsAmendment && submission.BaseDigest == nil {
+		return nil, fmt.Errorf("%w: artifact %s is an amendment, so the review must name the base it was read against",
+			errInput, artifact.ArtifactID)
+	}
+
+	instance, err := d.seam.CreatePrincipalInstance(ctx, store.CreatePrincipalInstanceInput{
+		Kind: store.PrincipalHuman,
+		// ADR 0020's identity for a human principal, as the importer
+		// spells it for its operator.
+		Model:          "human-" + d.user.UserID.String(),
+		UserID:         &d.user.UserID,
+		Lineage:        artifact.Lineage,
+		OrganizationID: org,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create reviewer principal: %w", err)
+	}
+	defer func() {
+		reason := "review recorded"
+		if err != nil {
+			reason = "review failed"
+		}
+		if stopErr := d.stop(ctx, instance.PrincipalInstanceID, reason); stopErr != nil && err == nil {
+			err = stopErr
+		}
+	}()
+
+	recorded, err := d.seam.CreateReview(ctx, store.CreateReviewInput{
+		BaseDigest:         submission.BaseDigest,
+		BaseSequence:       submission.BaseSequence,
+		ReviewDigest:       submission.Seen,
+		Rationale:          submission.Rationale,
+		Decision:           submission.Decision,
+		OrganizationID:     org,
+		ArtifactID:         artifact.ArtifactID,
+		ReviewerInstanceID: instance.PrincipalInstanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record review of %s: %w", artifact.ArtifactID, err)
+	}
+	return recorded, nil
+}
+
+// stop closes a reviewer's instance on a context detached from the
+// caller's: the write records how the act ended, and a cancelled act is
+// one of the ways it ends.
+func (d *Desk) stop(ctx context.Context, instance uuid.UUID, reason string) error {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), stopTimeout)
+	defer cancel()
+	if _, err := d.seam.StopPrincipalInstance(stopCtx, d.user.OrganizationID, instance, reason); err != nil {
+		return fmt.Errorf("stop reviewer principal %s: %w", instance, err)
+	}
+	return nil
+}
+
+func checkSubmission(submission *Submission) error {
+	switch submission.Decision {
+	case store.DecisionAccepted, store.DecisionRejected, store.DecisionChangesRequested:
+	default:
+		return fmt.Errorf("%w: decision %q is not one of %q, %q or %q", errInput, submission.Decision,
+			store.DecisionAccepted, store.DecisionRejected, store.DecisionChangesRequested)
+	}
+	// A rationale is required of every decision, acceptances included: a
+	// review's weight is the reason beside it, and an empty one is a
+	// rubber stamp with a timestamp.
+	if strings.TrimSpace(submission.Rationale) == "" {
+		return fmt.Errorf("%w: a review needs a rationale", errInput)
+	}
+	if submission.Seen == "" {
+		return fmt.Errorf("%w: a review must name the review digest of the content that was read", errInput)
+	}
+	if (submission.BaseDigest == nil) != (submission.BaseSequence == nil) {
+		return fmt.Errorf("%w: a base is a digest at a sequence; give both or neither", errInput)
+	}
+	return nil
+}
+
+// Accept makes an artifact authoritative on the strength of a named
+// review, choosing the transition the artifact needs.
+//
+// The acceptor need not be the reviewer, and is not recorded: acceptance
+// enacts the named review's decision, and it is that review's principal the
+// seam checks against the author and writes into the row.
+func (d *Desk) Accept(ctx context.Context, artifactID, reviewID uuid.UUID) error {
+	org := d.user.OrganizationID
+	artifact, err := d.seam.GetManagementArtifact(ctx, org, artifactID)
+	if err != nil {
+		return fmt.Errorf("read artifact %s: %w", artifactID, err)
+	}
+	switch {
+	case artifact.SupersedesArtifactID != nil:
+		return fmt.Errorf("%w: artifact %s supersedes %s", ErrUseSupersede, artifactID, *artifact.SupersedesArtifactID)
+	case artifact.IsAmendment:
+		err = d.seam.AcceptAmendment(ctx, org, artifactID, reviewID)
+	default:
+		err = d.seam.AcceptArtifact(ctx, org, artifactID, reviewID)
+	}
+	if err != nil {
+		return fmt.Errorf("accept artifact %s with review %s: %w", artifactID, reviewID, err)
+	}
+	return nil
+}
+
+// Invalidate withdraws a draft before it was ever authoritative.
+func (d *Desk) Invalidate(ctx context.Context, artifactID uuid.UUID) error {
+	if err := d.seam.InvalidateArtifact(ctx, d.user.OrganizationID, artifactID); err != nil {
+		return fmt.Errorf("invalidate artifact %s: %w", artifactID, err)
+	}
+	return nil
+}
+
+// Supersede accepts a superseding artifact and retires the artifact it
+// names, returning that target. The target is read from the superseding
+// artifact rather than supplied, because the artifact already says what it
+// replaces and a second account of it could only disagree.
+func (d *Desk) Supersede(ctx context.Context, supersedingID, reviewID uuid.UUID) (uuid.UUID, error) {
+	org := d.user.OrganizationID
+	superseding, err := d.seam.GetManagementArtifact(ctx, org, supersedingID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("read artifact %s: %w", supersedingID, err)
+	}
+	if superseding.SupersedesArtifactID == nil {
+		return uuid.Nil, fmt.Errorf("%w: artifact %s supersedes nothing; accept it instead", errInput, supersedingID)
+	}
+	target := *superseding.SupersedesArtifactID
+	if err := d.seam.SupersedeArtifact(ctx, org, target, supersedingID, reviewID); err != nil {
+		return uuid.Nil, fmt.Errorf("supersede %s with %s: %w", target, supersedingID, err)
+	}
+	return target, nil
+}
+
+// IsInvalid reports a submission this package refused before writing, which
+// a front-end answers as a malformed request rather than a conflict.
+func IsInvalid(err error) bool { return errors.Is(err, errInput) }
+
+// Advice is the next step for an operator whose action was refused, or ""
+// when the error speaks for itself.
+//
+// It exists for the refusals whose message is accurate and still leaves the
+// operator guessing. ErrBaseMoved above all: "re-review is required" is
+// true, but the operator needs to know that retrying cannot work and what
+// to read before reviewing again.
+func Advice(err error) string {
+	var rejected *store.TransitionRejected
+	switch {
+	case errors.Is(err, store.ErrBaseMoved):
+		return "Another amendment to the same original was accepted after this review was recorded, " +
+			"so the amendment now applies to a different effective view than the one reviewed. " +
+			"Retrying cannot succeed: read the amendment against the original's current effective view " +
+			"and record a new review, then accept with that one."
+	case errors.Is(err, ErrSelfReview):
+		return "ADR 0020 requires a reviewer who is not the author. Ask another person, or an agent " +
+			"reviewer, to review this artifact."
+	case errors.Is(err, ErrUseSupersede):
+		return "Use supersede with the same review; it accepts this artifact and retires the one it replaces together."
+	case errors.As(err, &rejected):
+		return adviceFor(rejected.Reason)
+	}
+	return ""
+}
+
+func adviceFor(reason store.RejectionReason) string {
+	switch reason {
+	case store.ReasonDigestMismatch:
+		return "The artifact changed after this review was recorded. Review its current content and accept with the new review."
+	case store.ReasonReviewerIsAuthor, store.ReasonReviewerIsAuthorUser:
+		return "The named review was given by the artifact's author, and can never accept it. " +
+			"Accept with a review from someone else."
+	case store.ReasonReviewNotAccept:
+		return "Only a review whose decision is \"accepted\" can accept an artifact."
+	case store.ReasonWrongStatus:
+		return "Only a draft can be accepted or invalidated; an accepted artifact changes by amendment or supersession."
+	default:
+		return ""
+	}
+}
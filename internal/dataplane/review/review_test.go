@@ -0,0 +1,265 @@
+package review
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/store"
+)
+
+// fakeSeam records what a desk asks of the seam. It enforces nothing: the
+// rules under test are the desk's own, and the seam's are tested against a
+// real database.
+type fakeSeam struct {
+	artifacts  map[uuid.UUID]*store.ManagementArtifact
+	principals map[uuid.UUID]*store.PrincipalInstance
+	created    []store.CreatePrincipalInstanceInput
+	stopped    map[uuid.UUID]string
+	reviews    []store.CreateReviewInput
+	calls      []string
+	acceptErr  error
+}
+
+func newFakeSeam() *fakeSeam {
+	return &fakeSeam{
+		artifacts:  map[uuid.UUID]*store.ManagementArtifact{},
+		principals: map[uuid.UUID]*store.PrincipalInstance{},
+		stopped:    map[uuid.UUID]string{},
+	}
+}
+
+func (f *fakeSeam) GetManagementArtifact(_ context.Context, _, id uuid.UUID) (*store.ManagementArtifact, error) {
+	if a, ok := f.artifacts[id]; ok {
+		return a, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+func (f *fakeSeam) GetPrincipalInstance(_ context.Context, _, id uuid.UUID) (*store.PrincipalInstance, error) {
+	if p, ok := f.principals[id]; ok {
+		return p, nil
+	}
+	return nil, store.ErrNotFound
+}
+
+//nolint:gocritic // hugeParam: matches the seam's signature
+func (f *fakeSeam) CreatePrincipalInstance(_ context.Context, input store.CreatePrincipalInstanceInput) (*store.PrincipalInstance, error) {
+	f.created = append(f.created, input)
+	instance := &store.PrincipalInstance{PrincipalInstanceID: uuid.New(), Kind: input.Kind, UserID: input.UserID, Model: input.Model}
+	f.principals[instance.PrincipalInstanceID] = instance
+	return instance, nil
+}
+
+func (f *fakeSeam) StopPrincipalInstance(_ context.Context, _, id uuid.UUID, reason string) (store.StopOutcome, error) {
+	f.stopped[id] = reason
+	return store.StopOutcome{Recorded: true, Reason: reason}, nil
+}
+
+//nolint:gocritic // hugeParam: matches the seam's signature
+func (f *fakeSeam) CreateReview(_ context.Context, input store.CreateReviewInput) (*store.Review, error) {
+	f.reviews = append(f.reviews, input)
+	return &store.Review{ReviewID: uuid.New(), ArtifactID: input.ArtifactID, ReviewerInstanceID: input.ReviewerInstanceID}, nil
+}
+
+func (f *fakeSeam) AcceptArtifact(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error {
+	f.calls = append(f.calls, "accept")
+	return f.acceptErr
+}
+
+func (f *fakeSeam) AcceptAmendment(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error {
+	f.calls = append(f.calls, "accept-amendment")
+	return f.acceptErr
+}
+
+func (f *fakeSeam) InvalidateArtifact(context.Context, uuid.UUID, uuid.UUID) error {
+	f.calls = append(f.calls, "invalidate")
+	return nil
+}
+
+func (f *fakeSeam) SupersedeArtifact(_ context.Context, _, target, _, _ uuid.UUID) error {
+	f.calls = append(f.calls, "supersede "+target.String())
+	return nil
+}
+
+// draft adds a draft artifact authored by a principal of the given user, or
+// by an agent when user is nil.
+func (f *fakeSeam) draft(user *uuid.UUID) *store.ManagementArtifact {
+	author := &store.PrincipalInstance{PrincipalInstanceID: uuid.New(), Kind: store.PrincipalAgent}
+	if user != nil {
+		author.Kind, author.UserID = store.PrincipalHuman, user
+	}
+	f.principals[author.PrincipalInstanceID] = author
+	artifact := &store.ManagementArtifact{
+		ArtifactID: uuid.New(), AuthorInstanceID: author.PrincipalInstanceID,
+		Status: store.StatusDraft, ReviewDigest: strings.Repeat("a", 64),
+	}
+	f.artifacts[artifact.ArtifactID] = artifact
+	return artifact
+}
+
+func newDesk(t *testing.T, seam *fakeSeam) *Desk {
+	t.Helper()
+	desk, err := NewDesk(seam, store.User{UserID: uuid.New(), OrganizationID: uuid.New(), Handle: "dr"})
+	if err != nil {
+		t.Fatalf("NewDesk: %v", err)
+	}
+	return desk
+}
+
+func submission(artifact *store.ManagementArtifact) Submission {
+	return Submission{
+		ArtifactID: artifact.ArtifactID, Seen: artifact.ReviewDigest,
+		Decision: store.DecisionAccepted, Rationale: "matches the story",
+	}
+}
+
+// TestRecordOpensAndClosesAHumanLifetime: the review is given by a fresh
+// human instance carrying the user, closed once the review exists.
+func TestRecordOpensAndClosesAHumanLifetime(t *testing.T) {
+	seam := newFakeSeam()
+	desk := newDesk(t, seam)
+	artifact := seam.draft(nil)
+
+	recorded, err := desk.Record(context.Background(), submission(artifact))
+	if err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+	if len(seam.created) != 1 {
+		t.Fatalf("created %d principals, want 1", len(seam.created))
+	}
+	created := seam.created[0]
+	if created.Kind != store.PrincipalHuman || created.UserID == nil || *created.UserID != desk.Reviewer().UserID {
+		t.Fatalf("reviewer principal = %+v, want the desk's human", created)
+	}
+	if created.Model != "human-"+desk.Reviewer().UserID.String() {
+		t.Fatalf("reviewer model = %q", created.Model)
+	}
+	if got := seam.stopped[recorded.ReviewerInstanceID]; got != "review recorded" {
+		t.Fatalf("reviewer instance stopped with %q, want %q", got, "review recorded")
+	}
+	if seam.reviews[0].ReviewDigest != artifact.ReviewDigest {
+		t.Fatal("the review was not bound to the digest the reviewer supplied")
+	}
+}
+
+// TestSelfReviewIsRefusedBeforeAnythingIsWritten: not at acceptance, where
+// the seam would refuse it too, but before a review row and the instance
+// that would have given it exist.
+func TestSelfReviewIsRefusedBeforeAnythingIsWritten(t *testing.T) {
+	seam := newFakeSeam()
+	desk := newDesk(t, seam)
+	userID := desk.Reviewer().UserID
+	artifact := seam.draft(&userID)
+
+	_, err := desk.Record(context.Background(), submission(artifact))
+	if !errors.Is(err, ErrSelfReview) {
+		t.Fatalf("Record = %v, want ErrSelfReview", err)
+	}
+	if len(seam.created) != 0 || len(seam.reviews) != 0 {
+		t.Fatalf("a refused self-review wrote %d principals and %d reviews", len(seam.created), len(seam.reviews))
+	}
+
+	// Another human's artifact is reviewable by this one.
+	other := uuid.New()
+	if _, err := desk.Record(context.Background(), submission(seam.draft(&other))); err != nil {
+		t.Fatalf("reviewing another human's artifact: %v", err)
+	}
+}
+
+func TestRecordRefusesAnIncompleteSubmission(t *testing.T) {
+	seam := newFakeSeam()
+	desk := newDesk(t, seam)
+	artifact := seam.draft(nil)
+	amendment := seam.draft(nil)
+	amendment.IsAmendment = true
+	digest, sequence := strings.Repeat("b", 64), 2
+
+	for name, change := range map[string]func(*Submission){
+		"no rationale":       func(s *Submission) { s.Rationale = "  " },
+		"unknown decision":   func(s *Submission) { s.Decision = "approved" },
+		"no digest":          func(s *Submission) { s.Seen = "" },
+		"half a base":        func(s *Submission) { s.BaseDigest = &digest },
+		"amendment, no base": func(s *Submission) { s.ArtifactID = amendment.ArtifactID },
+	} {
+		s := submission(artifact)
+		change(&s)
+		if _, err := desk.Record(context.Background(), s); !IsInvalid(err) {
+			t.Errorf("%s: Record = %v, want an invalid submission", name, err)
+		}
+	}
+	if len(seam.created) != 0 {
+		t.Fatalf("refused submissions created %d principals", len(seam.created))
+	}
+
+	s := submission(amendment)
+	s.BaseDigest, s.BaseSequence = &digest, &sequence
+	if _, err := desk.Record(context.Background(), s); err != nil {
+		t.Fatalf("an amendment review with its base: %v", err)
+	}
+}
+
+// TestAcceptChoosesTheTransition: an amendment needs the base check, and a
+// superseding artifact must retire its target in the same step.
+func TestAcceptChoosesTheTransition(t *testing.T) {
+	seam := newFakeSeam()
+	desk := newDesk(t, seam)
+	ctx := context.Background()
+
+	original := seam.draft(nil)
+	amendment := seam.draft(nil)
+	amendment.IsAmendment = true
+	successor := seam.draft(nil)
+	successor.SupersedesArtifactID = &original.ArtifactID
+
+	if err := desk.Accept(ctx, original.ArtifactID, uuid.New()); err != nil {
+		t.Fatalf("Accept original: %v", err)
+	}
+	if err := desk.Accept(ctx, amendment.ArtifactID, uuid.New()); err != nil {
+		t.Fatalf("Accept amendment: %v", err)
+	}
+	if err := desk.Accept(ctx, successor.ArtifactID, uuid.New()); !errors.Is(err, ErrUseSupersede) {
+		t.Fatalf("Accept successor = %v, want ErrUseSupersede", err)
+	}
+	target, err := desk.Supersede(ctx, successor.ArtifactID, uuid.New())
+	if err != nil || target != original.ArtifactID {
+		t.Fatalf("Supersede = %s, %v; want target %s", target, err, original.ArtifactID)
+	}
+	if _, err := desk.Supersede(ctx, original.ArtifactID, uuid.New()); !IsInvalid(err) {
+		t.Fatalf("Supersede with an artifact that supersedes nothing = %v", err)
+	}
+
+	want := []string{"accept", "accept-amendment", "supersede " + original.ArtifactID.String()}
+	if strings.Join(seam.calls, ",") != strings.Join(want, ",") {
+		t.Fatalf("transitions = %v, want %v", seam.calls, want)
+	}
+}
+
+// TestBaseMovedSurvivesWithAdvice: the sentinel stays matchable through
+// the desk's wrapping, and the advice says retrying cannot work.
+func TestBaseMovedSurvivesWithAdvice(t *testing.T) {
+	seam := newFakeSeam()
+	desk := newDesk(t, seam)
+	amendment := seam.draft(nil)
+	amendment.IsAmendment = true
+	seam.acceptErr = store.ErrBaseMoved
+
+	err := desk.Accept(context.Background(), amendment.ArtifactID, uuid.New())
+	if !errors.Is(err, store.ErrBaseMoved) {
+		t.Fatalf("Accept = %v, want ErrBaseMoved", err)
+	}
+	if advice := Advice(err); !strings.Contains(advice, "Retrying cannot succeed") {
+		t.Fatalf("Advice = %q", advice)
+	}
+
+	rejected := &store.TransitionRejected{Reason: store.ReasonDigestMismatch}
+	if Advice(rejected) == "" {
+		t.Fatal("a digest mismatch carries no advice")
+	}
+	if Advice(errors.New("connection refused")) != "" {
+		t.Fatal("an unclassified error was given advice")
+	}
+}
@@ -0,0 +1,111 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+const (
+	// commandTimeout bounds the helper. A helper may legitimately ask a
+	// hardware token or a password manager, which takes a human a moment;
+	// one that takes longer than this is not coming back.
+	commandTimeout = 2 * time.Minute
+
+	// maxCommandOutput bounds what the helper may make this process hold.
+	// A root key's text form is 65 bytes.
+	maxCommandOutput = 4096
+
+	// maxCommandDiagnostic bounds how much of the helper's stderr a
+	// refusal quotes.
+	maxCommandDiagnostic = 512
+)
+
+// Command builds the provider that runs an operator-supplied helper and
+// reads the root key from its standard output, in the text form EncodeKey
+// writes.
+//
+// It is the escape hatch for every store this package does not speak — a
+// password manager's CLI, a hardware token, a cloud KMS through its own
+// tooling — and it has no create: the helper owns its key's existence, so a
+// helper that prints nothing has failed, and that is reported as a failure
+// rather than as a missing key this process could mint in its place.
+//
+// The helper is run directly, never through a shell, with no standard
+// input. Its stdout is the key and is never quoted in an error; its stderr
+// is diagnostics and is, truncated.
+func Command(argv []string) (RootKeyProvider, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return nil, errors.New("command backend was given no helper to run")
+	}
+	if !filepath.IsAbs(argv[0]) {
+		return nil, fmt.Errorf("command backend helper %q must be an absolute path", argv[0])
+	}
+	return commandProvider{argv: slices.Clone(argv)}, nil
+}
+
+type commandProvider struct {
+	argv []string
+}
+
+func (p commandProvider) Backend() Backend { return BackendCommand }
+
+func (p commandProvider) RootKey() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+
+	//nolint:gosec // G204: running the operator's configured helper is this backend's purpose.
+	helper := exec.CommandContext(ctx, p.argv[0], p.argv[1:]...)
+	var stdout, stderr boundedBuffer
+	stdout.limit, stderr.limit = maxCommandOutput, maxCommandDiagnostic
+	helper.Stdout, helper.Stderr = &stdout, &stderr
+
+	if err := helper.Run(); err != nil {
+		return nil, fmt.Errorf("root-key helper %s failed: %w%s", p.argv[0], err, stderr.diagnostic())
+	}
+	if stdout.overflowed {
+		return nil, fmt.Errorf("root-key helper %s printed more than %d bytes; it must print only the key",
+			p.argv[0], maxCommandOutput)
+	}
+	key, err := paths.DecodeKey(stdout.Bytes())
+	if err != nil {
+		// The decode error is NOT wrapped: it quotes the byte it stopped
+		// at, and the output may be a key in the wrong form.
+		return nil, fmt.Errorf("root-key helper %s did not print a %d-byte key in hex", p.argv[0], paths.RootKeyLen)
+	}
+	return key, nil
+}
+
+// boundedBuffer keeps the first limit bytes written to it and notes
+// whether there was more.
+type boundedBuffer struct {
+	bytes.Buffer
+	limit      int
+	overflowed bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	room := b.limit - b.Len()
+	if len(p) > room {
+		b.overflowed = true
+		b.Buffer.Write(p[:max(room, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func (b *boundedBuffer) diagnostic() string {
+	text := strings.TrimSpace(b.String())
+	if text == "" {
+		return ""
+	}
+	return ": " + text
+}
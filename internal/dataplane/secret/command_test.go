@@ -0,0 +1,79 @@
+package secret
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// helperScript writes an executable shell script and returns its path.
+func helperScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "root-key-helper")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700); err != nil { //nolint:gosec // G306: it must be executable
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCommandReadsTheKeyFromStdout(t *testing.T) {
+	helper := helperScript(t, "printf '%s\\n' \""+strings.TrimSpace(string(paths.EncodeKey(validRootKey())))+"\"")
+	provider, err := Command([]string{helper})
+	if err != nil {
+		t.Fatalf("Command: %v", err)
+	}
+	key, err := provider.RootKey()
+	if err != nil {
+		t.Fatalf("RootKey: %v", err)
+	}
+	if !bytes.Equal(key, validRootKey()) {
+		t.Fatal("the provider returned a different key than the helper printed")
+	}
+	if provider.Backend() != BackendCommand {
+		t.Fatalf("Backend() = %s", provider.Backend())
+	}
+}
+
+// A failing helper's stderr is its diagnosis and is reported; its stdout
+// may be a key in the wrong form and never is.
+func TestCommandFailuresQuoteStderrNeverStdout(t *testing.T) {
+	t.Run("exit status", func(t *testing.T) {
+		helper := helperScript(t, "echo 'token not present' >&2; exit 3")
+		provider, _ := Command([]string{helper})
+		_, err := provider.RootKey()
+		if err == nil || !strings.Contains(err.Error(), "token not present") {
+			t.Fatalf("RootKey = %v, want the helper's stderr", err)
+		}
+	})
+	t.Run("not a key", func(t *testing.T) {
+		helper := helperScript(t, "echo 'hunter2-almost-a-key'")
+		provider, _ := Command([]string{helper})
+		_, err := provider.RootKey()
+		if err == nil {
+			t.Fatal("non-key output was accepted")
+		}
+		if strings.Contains(err.Error(), "hunter2") || strings.Contains(err.Error(), "'h'") {
+			t.Fatalf("the refusal quotes the helper's output: %v", err)
+		}
+	})
+	t.Run("empty output", func(t *testing.T) {
+		provider, _ := Command([]string{helperScript(t, "exit 0")})
+		if _, err := provider.RootKey(); err == nil {
+			t.Fatal("a helper that printed nothing produced a key")
+		}
+	})
+}
+
+// The helper is named by absolute path, or which program runs depends on
+// whatever PATH the verb happened to inherit.
+func TestCommandRefusesARelativeHelper(t *testing.T) {
+	for _, argv := range [][]string{nil, {""}, {"pass", "show", "maestro"}} {
+		if _, err := Command(argv); err == nil {
+			t.Fatalf("Command(%q) was accepted", argv)
+		}
+	}
+}
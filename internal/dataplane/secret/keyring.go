@@ -0,0 +1,106 @@
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// KeyRing is a provider that still OPENS envelopes sealed under keys it no
+// longer seals with.
+//
+// It exists for the one period a plane legitimately holds two root keys:
+// after a rotation has installed the new key and before the operator has
+// retired the old one. During that period every envelope is expected to be
+// under the new key — the rotation re-sealed them all before installing it —
+// and the old key is kept so that expectation can be WRONG without costing
+// a secret. Retiring it is a separate, explicit step, because destroying key
+// material is the one part of a rotation that cannot be undone.
+//
+// It extends RootKeyProvider rather than replacing it, so everything that
+// seals keeps asking the one question the interface answers. Only opening
+// consults the retiring keys, through OpenWith; nothing can seal under one.
+type KeyRing interface {
+	RootKeyProvider
+
+	// RetiringKeys returns the keys envelopes may still be sealed under,
+	// besides RootKey. Callers do not retain them.
+	RetiringKeys() ([][]byte, error)
+}
+
+// Rotating wraps a provider with the retiring keys it should still open
+// under.
+//
+// Every retiring key is held to the same length bar ResolvedKey applies,
+// for the same reason: material that arrives from a file nobody re-checks
+// here would otherwise be trusted to open secrets on its provenance alone.
+// With no retiring keys it returns the provider unchanged, so a plane that
+// is not mid-rotation is indistinguishable from one that never rotated.
+func Rotating(sealing RootKeyProvider, retiring ...[]byte) (RootKeyProvider, error) {
+	if sealing == nil {
+		return nil, fmt.Errorf("rotating key ring was given no sealing provider: %w", ErrNoRootKey)
+	}
+	if len(retiring) == 0 {
+		return sealing, nil
+	}
+	held := make([][]byte, 0, len(retiring))
+	for i, key := range retiring {
+		if len(key) != paths.RootKeyLen {
+			return nil, fmt.Errorf("retiring root key %d is %d bytes, want exactly %d: %w",
+				i, len(key), paths.RootKeyLen, ErrRootKeyLength)
+		}
+		held = append(held, bytes.Clone(key))
+	}
+	return rotatingProvider{RootKeyProvider: sealing, retiring: held}, nil
+}
+
+type rotatingProvider struct {
+	RootKeyProvider
+	retiring [][]byte
+}
+
+func (p rotatingProvider) RetiringKeys() ([][]byte, error) {
+	keys := make([][]byte, 0, len(p.retiring))
+	for _, key := range p.retiring {
+		keys = append(keys, bytes.Clone(key))
+	}
+	return keys, nil
+}
+
+// OpenWith opens an envelope under the provider's root key and, when the
+// provider is a KeyRing, under each of its retiring keys in turn.
+//
+// The sealing key is tried FIRST and is the only one tried for a plane that
+// is not mid-rotation, so the common path costs exactly what Open costs.
+// Only ErrDecrypt moves on to the next key: an unknown scheme or a malformed
+// binding is the same answer under every key, and retrying it would report
+// the last key's failure instead of the first one's.
+//
+//nolint:gocritic // hugeParam: by value, matching Open.
+func OpenWith(provider RootKeyProvider, binding Binding, envelope Envelope) (Value, error) {
+	rootKey, err := provider.RootKey()
+	if err != nil {
+		return Value{}, fmt.Errorf("read the root key: %w", err)
+	}
+	value, err := Open(rootKey, binding, envelope)
+	if err == nil || !errors.Is(err, ErrDecrypt) {
+		return value, err
+	}
+
+	ring, isRing := provider.(KeyRing)
+	if !isRing {
+		return Value{}, err
+	}
+	retiring, ringErr := ring.RetiringKeys()
+	if ringErr != nil {
+		return Value{}, fmt.Errorf("read the retiring root keys: %w", ringErr)
+	}
+	for _, key := range retiring {
+		if value, retryErr := Open(key, binding, envelope); retryErr == nil {
+			return value, nil
+		}
+	}
+	return Value{}, err
+}
@@ -0,0 +1,95 @@
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// rotationKeys mints the two keys a rotation holds at once.
+func rotationKeys(t *testing.T) (current, retiring []byte) {
+	t.Helper()
+	current = bytes.Repeat([]byte{0x5a}, 32)
+	retiring = bytes.Repeat([]byte{0xa5}, 32)
+	return current, retiring
+}
+
+// TestOpenWithFallsBackToARetiringKey is the dual-key period: an envelope
+// the rotation did not reach still opens, and one it did reach opens under
+// the key that sealed it.
+func TestOpenWithFallsBackToARetiringKey(t *testing.T) {
+	current, retiring := rotationKeys(t)
+	live, err := ResolvedKey(current, BackendKeyFile)
+	if err != nil {
+		t.Fatalf("ResolvedKey: %v", err)
+	}
+	ring, err := Rotating(live, retiring)
+	if err != nil {
+		t.Fatalf("Rotating: %v", err)
+	}
+
+	for name, sealedUnder := range map[string][]byte{"current": current, "retiring": retiring} {
+		envelope, sealErr := Seal(sealedUnder, testBinding(), []byte("token sealed under "+name))
+		if sealErr != nil {
+			t.Fatalf("Seal: %v", sealErr)
+		}
+		opened, openErr := OpenWith(ring, testBinding(), envelope)
+		if openErr != nil {
+			t.Fatalf("an envelope sealed under the %s key did not open: %v", name, openErr)
+		}
+		if got := string(opened.Reveal()); got != "token sealed under "+name {
+			t.Fatalf("opened %q", got)
+		}
+	}
+
+	// The ring seals under the CURRENT key only. A provider that handed a
+	// retiring key to a sealer would write new envelopes the retirement is
+	// about to make unreadable.
+	sealing, err := ring.RootKey()
+	if err != nil {
+		t.Fatalf("RootKey: %v", err)
+	}
+	if !bytes.Equal(sealing, current) {
+		t.Fatal("the key ring seals under a retiring key")
+	}
+}
+
+// TestOpenWithWithoutARingOpensUnderOneKey pins that a plane which is not
+// mid-rotation gains nothing from this: a foreign envelope is refused with
+// the same error Open gives.
+func TestOpenWithWithoutARingOpensUnderOneKey(t *testing.T) {
+	current, retiring := rotationKeys(t)
+	live, err := ResolvedKey(current, BackendKeyFile)
+	if err != nil {
+		t.Fatalf("ResolvedKey: %v", err)
+	}
+	envelope, err := Seal(retiring, testBinding(), []byte("sealed elsewhere"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+	if _, err := OpenWith(live, testBinding(), envelope); !errors.Is(err, ErrDecrypt) {
+		t.Fatalf("OpenWith without a key ring returned %v, want ErrDecrypt", err)
+	}
+
+	// And a ring with no retiring keys IS the provider it wraps.
+	unchanged, err := Rotating(live)
+	if err != nil {
+		t.Fatalf("Rotating: %v", err)
+	}
+	if _, isRing := unchanged.(KeyRing); isRing {
+		t.Fatal("a ring with nothing retiring still reports itself as a key ring")
+	}
+}
+
+// TestRotatingRefusesAShortRetiringKey holds retiring material to the bar
+// every root key meets.
+func TestRotatingRefusesAShortRetiringKey(t *testing.T) {
+	current, _ := rotationKeys(t)
+	live, err := ResolvedKey(current, BackendKeyFile)
+	if err != nil {
+		t.Fatalf("ResolvedKey: %v", err)
+	}
+	if _, err := Rotating(live, []byte("short")); !errors.Is(err, ErrRootKeyLength) {
+		t.Fatalf("Rotating accepted a five-byte retiring key (%v)", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"slices"
 
 	"orchestrator/internal/dataplane/paths"
@@ -12,8 +13,10 @@ import (
 // RootKeyProvider supplies the root-of-trust key material.
 //
 // It answers ONE question — give me the root key — and that narrowness is
-// the design decision (item 7, D3). It is a LOCAL seam: the three backends
-// below are three ways of holding a key on this machine.
+// the design decision (item 7, D3). It is a LOCAL seam: every backend below
+// is a way for THIS machine to obtain its own key, whether it holds it in a
+// file, in the desktop keyring, wrapped by a Vault transit key, or behind an
+// operator's helper.
 //
 // It is deliberately not the seam cloud mode replaces. Cloud mode does not
 // hand Maestro a root key from a provider secret manager; it replaces the
@@ -42,8 +45,22 @@ const (
 	BackendKeyFile Backend = "key-file"
 
 	// BackendKeychain is named but not implemented; see ErrBackendNotImplemented.
+	// It is the macOS keychain. Linux's equivalent is BackendSecretService,
+	// which is a different protocol and therefore a different backend.
 	BackendKeychain Backend = "os-keychain"
 
+	// BackendSecretService is the Linux Secret Service — GNOME Keyring,
+	// KWallet — reached over the session bus. See SecretService.
+	BackendSecretService Backend = "secret-service"
+
+	// BackendVaultTransit keeps only a WRAPPED key on disk, which a Vault
+	// transit key must unwrap. See VaultTransit.
+	BackendVaultTransit Backend = "vault-transit"
+
+	// BackendCommand reads the key from an operator-supplied helper. See
+	// Command.
+	BackendCommand Backend = "command"
+
 	// BackendPassphrase is named but not implemented. It carries a cost the
 	// default cannot: a plane that cannot start unattended.
 	BackendPassphrase Backend = "passphrase"
@@ -146,11 +163,71 @@ func ProviderFor(backend Backend, configRoot string, access Access) (RootKeyProv
 		return KeyFile(configRoot, access), nil
 	case BackendKeychain, BackendPassphrase:
 		return nil, fmt.Errorf("%w: %s", ErrBackendNotImplemented, backend)
+	case BackendSecretService, BackendVaultTransit, BackendCommand:
+		// A name is not enough to build these: each needs to know WHERE its
+		// key is, and that lives in the bootstrap pointer.
+		return nil, fmt.Errorf("root-key backend %s takes its settings from the bootstrap pointer's "+
+			"root_of_trust; build it with FromRootOfTrust", backend)
 	default:
 		return nil, fmt.Errorf("unknown root-key backend %q", backend)
 	}
 }
 
+// FromRootOfTrust builds the provider a bootstrap pointer selects.
+//
+// It is the pointer's counterpart to ProviderFor, and it refuses the same
+// way: at construction, with nothing returned beside the error. The
+// reference has already been validated by paths.ReadBootstrap, so what is
+// checked here is only what the pointer cannot know — that a key_file
+// reference names the key file this config root actually holds.
+func FromRootOfTrust(reference paths.RootOfTrust, configRoot string, access Access) (RootKeyProvider, error) {
+	switch reference.Kind {
+	case paths.RootOfTrustKeyFile:
+		// The key file's place is fixed by the config root; the pointer
+		// only records it. A pointer naming another file is one somebody
+		// edited expecting it to move the key, and loading the canonical
+		// file anyway would be the silent fall-through D3 forbids.
+		if canonical := filepath.Join(configRoot, paths.KeyFileName); reference.Path != canonical {
+			return nil, fmt.Errorf("bootstrap pointer names key file %s, but this config root's key file "+
+				"is %s. The key file cannot be relocated by editing the pointer; move the config root "+
+				"instead, or restore the pointer's path", reference.Path, canonical)
+		}
+		return KeyFile(configRoot, access), nil
+	case paths.RootOfTrustSecretService:
+		return SecretService(reference.Attributes, access)
+	case paths.RootOfTrustVaultTransit:
+		return VaultTransit(VaultTransitConfig{
+			Address:     reference.Address,
+			Mount:       reference.Mount,
+			KeyName:     reference.KeyName,
+			WrappedPath: reference.Path,
+		}, access)
+	case paths.RootOfTrustCommand:
+		return Command(reference.Command)
+	default:
+		return nil, fmt.Errorf("unknown root-of-trust kind %q", reference.Kind)
+	}
+}
+
+// BackendFor names the backend a root-of-trust reference selects, without
+// building it. Callers that already hold the key use it to say where the key
+// came from; building a provider for that would be a second chance to read
+// it.
+func BackendFor(reference paths.RootOfTrust) (Backend, error) {
+	switch reference.Kind {
+	case paths.RootOfTrustKeyFile:
+		return BackendKeyFile, nil
+	case paths.RootOfTrustSecretService:
+		return BackendSecretService, nil
+	case paths.RootOfTrustVaultTransit:
+		return BackendVaultTransit, nil
+	case paths.RootOfTrustCommand:
+		return BackendCommand, nil
+	default:
+		return "", fmt.Errorf("unknown root-of-trust kind %q", reference.Kind)
+	}
+}
+
 // ResolvedKey wraps key material that has ALREADY been obtained, for callers
 // that must hand a provider to something else without making a second
 // create-versus-load decision.
@@ -228,6 +305,9 @@ var knownBackends = []Backend{
 	BackendKeychain,
 	BackendPassphrase,
 	BackendOperatorProvided,
+	BackendSecretService,
+	BackendVaultTransit,
+	BackendCommand,
 }
 
 // known reports whether this is a backend the package defines.
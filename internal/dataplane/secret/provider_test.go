@@ -241,3 +241,39 @@ func TestResolvedKeyReportsTheSourceItWasGiven(t *testing.T) {
 		t.Fatalf("resolved key returned different material than it was given")
 	}
 }
+
+// TestFromRootOfTrustSelectsTheBackendThePointerNames, and refuses a key-file
+// pointer that names some other file: editing the pointer does not move the
+// key, and loading the canonical file anyway would be a silent fall-through.
+func TestFromRootOfTrustSelectsTheBackendThePointerNames(t *testing.T) {
+	root := t.TempDir()
+	cases := map[Backend]paths.RootOfTrust{
+		BackendKeyFile:       {Kind: paths.RootOfTrustKeyFile, Path: filepath.Join(root, paths.KeyFileName)},
+		BackendSecretService: {Kind: paths.RootOfTrustSecretService, Attributes: map[string]string{"plane": "local"}},
+		BackendVaultTransit: {
+			Kind: paths.RootOfTrustVaultTransit, Address: "https://vault.example:8200", KeyName: "plane",
+			Path: filepath.Join(root, "root.key.wrapped"),
+		},
+		BackendCommand: {Kind: paths.RootOfTrustCommand, Command: []string{"/usr/local/bin/fetch-key"}},
+	}
+	for want, reference := range cases {
+		provider, err := FromRootOfTrust(reference, root, LoadOnly)
+		if err != nil {
+			t.Fatalf("FromRootOfTrust(%s): %v", reference.Kind, err)
+		}
+		if provider.Backend() != want {
+			t.Fatalf("FromRootOfTrust(%s) built %s", reference.Kind, provider.Backend())
+		}
+		if named, _ := BackendFor(reference); named != want {
+			t.Fatalf("BackendFor(%s) = %s, but the pointer builds %s", reference.Kind, named, want)
+		}
+	}
+
+	moved := paths.RootOfTrust{Kind: paths.RootOfTrustKeyFile, Path: filepath.Join(t.TempDir(), paths.KeyFileName)}
+	if _, err := FromRootOfTrust(moved, root, LoadOnly); err == nil {
+		t.Fatal("a pointer naming another key file was accepted")
+	}
+	if _, err := ProviderFor(BackendCommand, root, LoadOnly); err == nil {
+		t.Fatal("ProviderFor built a backend whose settings only the pointer carries")
+	}
+}
@@ -0,0 +1,267 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"orchestrator/internal/dataplane/dbus"
+	"orchestrator/internal/dataplane/paths"
+)
+
+// ErrKeyringLocked reports a Secret Service item that exists but is locked.
+//
+// It is refused rather than unlocked because unlocking is a PROMPT — a
+// dialog the keyring daemon shows on the desktop — and a lifecycle verb
+// that blocked on a dialog nobody may be looking at would hang `up` with no
+// explanation. The operator unlocks the keyring, and the verb is re-run.
+var ErrKeyringLocked = errors.New("the keyring holding the root key is locked")
+
+// ErrAmbiguousKeyring reports attributes that match more than one item.
+// Choosing one would be choosing which key protects the vault, by accident.
+var ErrAmbiguousKeyring = errors.New("more than one keyring item matches the root key's attributes")
+
+const (
+	secretServiceName    = "org.freedesktop.secrets"
+	secretServicePath    = dbus.ObjectPath("/org/freedesktop/secrets")
+	secretServiceIface   = "org.freedesktop.Secret.Service"
+	secretCollectionIfc  = "org.freedesktop.Secret.Collection"
+	secretItemIface      = "org.freedesktop.Secret.Item"
+	secretSessionIface   = "org.freedesktop.Secret.Session"
+	secretItemLabel      = "org.freedesktop.Secret.Item.Label"
+	secretItemAttributes = "org.freedesktop.Secret.Item.Attributes"
+
+	// noObject is the Secret Service's "none": no prompt needed, no
+	// collection under that alias.
+	noObject = dbus.ObjectPath("/")
+
+	// secretServiceTimeout bounds one exchange with the keyring daemon. It
+	// is a local socket; anything slower is a daemon that is not answering.
+	secretServiceTimeout = 30 * time.Second
+
+	// keyringLabel is what the operator sees in their keyring manager.
+	keyringLabel = "Maestro data-plane root-of-trust key"
+)
+
+// SecretService builds the provider that holds the root key in the Linux
+// Secret Service, found by an exact match on its attributes.
+//
+// The key is stored in the text form EncodeKey writes, so an operator can
+// move a key between the key file and the keyring by copying it, and the
+// session is "plain": the transfer is over a local socket to a daemon
+// running as the same user, which is the same trust boundary the key file
+// has, and the encrypted session algorithms protect against nothing that
+// boundary does not.
+//
+// MayCreate stores a freshly minted key in the DEFAULT collection when no
+// item matches; LoadOnly reports paths.ErrNoKey, so a plane whose keyring
+// item was deleted is refused exactly as one whose key file was.
+func SecretService(attributes map[string]string, access Access) (RootKeyProvider, error) {
+	if len(attributes) == 0 {
+		return nil, errors.New("secret service backend was given no attributes: every item would match")
+	}
+	return secretServiceProvider{attributes: maps.Clone(attributes), access: access}, nil
+}
+
+type secretServiceProvider struct {
+	attributes map[string]string
+	access     Access
+}
+
+func (p secretServiceProvider) Backend() Backend { return BackendSecretService }
+
+func (p secretServiceProvider) RootKey() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretServiceTimeout)
+	defer cancel()
+
+	conn, err := dbus.DialSession(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reach the Secret Service: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+	keyring := secretServiceSession{conn: conn}
+
+	session, err := keyring.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer keyring.close(ctx, session)
+
+	unlocked, locked, err := keyring.search(ctx, p.attributes)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(unlocked) == 1 && len(locked) == 0:
+		return keyring.read(ctx, unlocked[0], session)
+	case len(unlocked)+len(locked) > 1:
+		return nil, fmt.Errorf("%w: %d items match %v. Give the pointer's attributes enough detail to "+
+			"name one", ErrAmbiguousKeyring, len(unlocked)+len(locked), p.attributes)
+	case len(locked) == 1:
+		return nil, fmt.Errorf("%w: unlock the keyring holding %v and run the command again", ErrKeyringLocked, p.attributes)
+	case p.access == MayCreate:
+		return keyring.create(ctx, p.attributes, session)
+	default:
+		return nil, fmt.Errorf("%w: no Secret Service item matches %v", paths.ErrNoKey, p.attributes)
+	}
+}
+
+// secretServiceSession is one conversation with the keyring daemon.
+type secretServiceSession struct {
+	conn *dbus.Conn
+}
+
+func (s secretServiceSession) call(
+	ctx context.Context, path dbus.ObjectPath, iface, member string, signature dbus.Signature, args ...any,
+) ([]any, error) {
+	reply, err := s.conn.Call(ctx, secretServiceName, path, iface, member, signature, args...)
+	if err != nil {
+		return nil, fmt.Errorf("secret service %s: %w", member, err)
+	}
+	return reply, nil
+}
+
+func (s secretServiceSession) open(ctx context.Context) (dbus.ObjectPath, error) {
+	reply, err := s.call(ctx, secretServicePath, secretServiceIface, "OpenSession", "sv",
+		"plain", dbus.Variant{Signature: "s", Value: ""})
+	if err != nil {
+		return "", err
+	}
+	if len(reply) != 2 {
+		return "", fmt.Errorf("secret service OpenSession returned %d values, want 2", len(reply))
+	}
+	session, ok := reply[1].(dbus.ObjectPath)
+	if !ok {
+		return "", fmt.Errorf("secret service OpenSession returned %T for the session", reply[1])
+	}
+	return session, nil
+}
+
+// close ends the session. Best effort: the daemon drops it with the
+// connection anyway.
+func (s secretServiceSession) close(ctx context.Context, session dbus.ObjectPath) {
+	_, _ = s.call(ctx, session, secretSessionIface, "Close", "")
+}
+
+func (s secretServiceSession) search(
+	ctx context.Context, attributes map[string]string,
+) (unlocked, locked []dbus.ObjectPath, err error) {
+	reply, err := s.call(ctx, secretServicePath, secretServiceIface, "SearchItems", "a{ss}", attributeEntries(attributes))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(reply) != 2 {
+		return nil, nil, fmt.Errorf("secret service SearchItems returned %d values, want 2", len(reply))
+	}
+	if unlocked, err = objectPaths(reply[0]); err != nil {
+		return nil, nil, err
+	}
+	if locked, err = objectPaths(reply[1]); err != nil {
+		return nil, nil, err
+	}
+	return unlocked, locked, nil
+}
+
+func (s secretServiceSession) read(ctx context.Context, item, session dbus.ObjectPath) ([]byte, error) {
+	reply, err := s.call(ctx, item, secretItemIface, "GetSecret", "o", session)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 1 {
+		return nil, fmt.Errorf("secret service GetSecret returned %d values, want 1", len(reply))
+	}
+	fields, ok := reply[0].(dbus.Struct)
+	if !ok || len(fields) != 4 {
+		return nil, fmt.Errorf("secret service GetSecret returned %T, want a (oayays) secret", reply[0])
+	}
+	value, ok := fields[2].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("secret service secret value is %T, want bytes", fields[2])
+	}
+	key, err := paths.DecodeKey(value)
+	if err != nil {
+		// Not wrapped, as for the command backend: the decode error quotes
+		// the byte it stopped at.
+		return nil, fmt.Errorf("keyring item %s does not hold a %d-byte key in hex", item, paths.RootKeyLen)
+	}
+	return key, nil
+}
+
+// create mints a key and stores it in the default collection.
+//
+// replace is FALSE: an item that appeared between the search and here is
+// somebody else's key, and overwriting it would be the silent replacement
+// of a root key this whole package exists to prevent.
+func (s secretServiceSession) create(
+	ctx context.Context, attributes map[string]string, session dbus.ObjectPath,
+) ([]byte, error) {
+	reply, err := s.call(ctx, secretServicePath, secretServiceIface, "ReadAlias", "s", "default")
+	if err != nil {
+		return nil, err
+	}
+	collection, ok := firstPath(reply)
+	if !ok || collection == noObject {
+		return nil, errors.New("the Secret Service has no default collection to store the root key in; " +
+			"create one in your keyring manager, or select another root-of-trust backend")
+	}
+
+	key, err := paths.NewKeyMaterial()
+	if err != nil {
+		return nil, err
+	}
+	properties := []dbus.DictEntry{
+		{Key: secretItemLabel, Value: dbus.Variant{Signature: "s", Value: keyringLabel}},
+		{Key: secretItemAttributes, Value: dbus.Variant{Signature: "a{ss}", Value: attributeEntries(attributes)}},
+	}
+	secretValue := dbus.Struct{session, []byte{}, paths.EncodeKey(key), "text/plain"}
+	reply, err = s.call(ctx, collection, secretCollectionIfc, "CreateItem", "a{sv}(oayays)b",
+		properties, secretValue, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply) != 2 {
+		return nil, fmt.Errorf("secret service CreateItem returned %d values, want 2", len(reply))
+	}
+	if prompt, _ := reply[1].(dbus.ObjectPath); prompt != noObject {
+		// The item was NOT created: a prompt is the daemon asking first.
+		return nil, fmt.Errorf("%w: storing the root key needs the default collection unlocked; unlock it "+
+			"and run the command again", ErrKeyringLocked)
+	}
+	return key, nil
+}
+
+// attributeEntries renders attributes as an a{ss}, in a stable order.
+func attributeEntries(attributes map[string]string) []dbus.DictEntry {
+	entries := make([]dbus.DictEntry, 0, len(attributes))
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		entries = append(entries, dbus.DictEntry{Key: name, Value: attributes[name]})
+	}
+	return entries
+}
+
+func objectPaths(value any) ([]dbus.ObjectPath, error) {
+	items, ok := value.([]any)
+	if !ok && value != nil {
+		return nil, fmt.Errorf("secret service returned %T, want an array of object paths", value)
+	}
+	found := make([]dbus.ObjectPath, 0, len(items))
+	for _, item := range items {
+		path, isPath := item.(dbus.ObjectPath)
+		if !isPath {
+			return nil, fmt.Errorf("secret service returned %T, want an object path", item)
+		}
+		found = append(found, path)
+	}
+	return found, nil
+}
+
+func firstPath(reply []any) (dbus.ObjectPath, bool) {
+	if len(reply) == 0 {
+		return "", false
+	}
+	path, ok := reply[0].(dbus.ObjectPath)
+	return path, ok
+}
@@ -0,0 +1,207 @@
+package secret
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+
+	"orchestrator/internal/dataplane/dbus"
+	"orchestrator/internal/dataplane/paths"
+)
+
+// keyringItem is one item in the stand-in keyring.
+type keyringItem struct {
+	attributes map[string]string
+	value      []byte
+	locked     bool
+}
+
+// standInKeyring is the part of the Secret Service API the backend uses,
+// served over the real wire protocol by dbus.StandIn.
+type standInKeyring struct {
+	items    map[dbus.ObjectPath]*keyringItem
+	mu       sync.Mutex
+	lockedDB bool
+	next     int
+}
+
+func newStandInKeyring(t *testing.T) *standInKeyring {
+	t.Helper()
+	keyring := &standInKeyring{items: map[dbus.ObjectPath]*keyringItem{}}
+	standIn, err := dbus.NewStandIn(t.TempDir(), keyring.handle)
+	if err != nil {
+		t.Fatalf("NewStandIn: %v", err)
+	}
+	t.Cleanup(func() { _ = standIn.Close() })
+	t.Setenv(dbus.EnvSessionBusAddress, standIn.Address())
+	return keyring
+}
+
+func (k *standInKeyring) put(attributes map[string]string, value []byte, locked bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.next++
+	k.items[dbus.ObjectPath(fmt.Sprintf("/org/freedesktop/secrets/collection/login/%d", k.next))] = &keyringItem{
+		attributes: attributes, value: value, locked: locked,
+	}
+}
+
+func (k *standInKeyring) handle(call dbus.Call) (dbus.Signature, []any, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	switch call.Member {
+	case "OpenSession":
+		if call.Args[0] != "plain" {
+			return "", nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.NotSupported"}
+		}
+		return "vo", []any{dbus.Variant{Signature: "s", Value: ""}, dbus.ObjectPath("/org/freedesktop/secrets/session/1")}, nil
+	case "Close":
+		return "", nil, nil
+	case "SearchItems":
+		wanted := map[string]string{}
+		for _, entry := range call.Args[0].([]dbus.DictEntry) { //nolint:forcetypeassert // the signature is a{ss}
+			wanted[entry.Key.(string)] = entry.Value.(string) //nolint:forcetypeassert // a{ss}
+		}
+		var unlocked, locked []any
+		for path, item := range k.items {
+			if !maps.Equal(item.attributes, wanted) {
+				continue
+			}
+			if item.locked {
+				locked = append(locked, path)
+			} else {
+				unlocked = append(unlocked, path)
+			}
+		}
+		return "aoao", []any{unlocked, locked}, nil
+	case "GetSecret":
+		item := k.items[call.Path]
+		return "(oayays)", []any{dbus.Struct{call.Args[0], []byte{}, item.value, "text/plain"}}, nil
+	case "ReadAlias":
+		return "o", []any{dbus.ObjectPath("/org/freedesktop/secrets/collection/login")}, nil
+	case "CreateItem":
+		if k.lockedDB {
+			return "oo", []any{noObject, dbus.ObjectPath("/org/freedesktop/secrets/prompt/1")}, nil
+		}
+		properties := call.Args[0].([]dbus.DictEntry) //nolint:forcetypeassert // a{sv}
+		attributes := map[string]string{}
+		for _, property := range properties {
+			if property.Key != secretItemAttributes {
+				continue
+			}
+			for _, entry := range property.Value.(dbus.Variant).Value.([]dbus.DictEntry) { //nolint:forcetypeassert // a{ss}
+				attributes[entry.Key.(string)] = entry.Value.(string) //nolint:forcetypeassert // a{ss}
+			}
+		}
+		secret := call.Args[1].(dbus.Struct) //nolint:forcetypeassert // (oayays)
+		k.next++
+		path := dbus.ObjectPath(fmt.Sprintf("/org/freedesktop/secrets/collection/login/%d", k.next))
+		k.items[path] = &keyringItem{attributes: attributes, value: secret[2].([]byte)} //nolint:forcetypeassert // ay
+		return "oo", []any{path, noObject}, nil
+	default:
+		return "", nil, &dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownMethod", Message: call.Member}
+	}
+}
+
+func testAttributes() map[string]string {
+	return map[string]string{"application": "maestro", "plane": "local"}
+}
+
+// TestSecretServiceLoadsTheMatchingItem reads a key stored in the keyring by
+// hand, in the key file's text form, which is how an operator moves a key
+// there.
+func TestSecretServiceLoadsTheMatchingItem(t *testing.T) {
+	keyring := newStandInKeyring(t)
+	keyring.put(map[string]string{"application": "something-else"}, paths.EncodeKey(bytes.Repeat([]byte{1}, 32)), false)
+	keyring.put(testAttributes(), paths.EncodeKey(validRootKey()), false)
+
+	provider, err := SecretService(testAttributes(), LoadOnly)
+	if err != nil {
+		t.Fatalf("SecretService: %v", err)
+	}
+	key, err := provider.RootKey()
+	if err != nil {
+		t.Fatalf("RootKey: %v", err)
+	}
+	if !bytes.Equal(key, validRootKey()) {
+		t.Fatal("the provider returned a different key than the item holds")
+	}
+	if provider.Backend() != BackendSecretService {
+		t.Fatalf("Backend() = %s", provider.Backend())
+	}
+}
+
+// A missing item is paths.ErrNoKey under LoadOnly, so the stack refuses a
+// plane whose keyring item was deleted exactly as it refuses one whose key
+// file was — and nothing is created on the way out.
+func TestSecretServiceLoadOnlyRefusesAMissingItem(t *testing.T) {
+	keyring := newStandInKeyring(t)
+	provider, err := SecretService(testAttributes(), LoadOnly)
+	if err != nil {
+		t.Fatalf("SecretService: %v", err)
+	}
+	if _, err := provider.RootKey(); !errors.Is(err, paths.ErrNoKey) {
+		t.Fatalf("RootKey = %v, want ErrNoKey", err)
+	}
+	if len(keyring.items) != 0 {
+		t.Fatal("a load-only provider created a keyring item")
+	}
+}
+
+// MayCreate stores a minted key once, and the next load returns it.
+func TestSecretServiceMayCreateStoresThenLoads(t *testing.T) {
+	keyring := newStandInKeyring(t)
+	creating, err := SecretService(testAttributes(), MayCreate)
+	if err != nil {
+		t.Fatalf("SecretService: %v", err)
+	}
+	created, err := creating.RootKey()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	again, err := creating.RootKey()
+	if err != nil {
+		t.Fatalf("second MayCreate: %v", err)
+	}
+	if !bytes.Equal(created, again) || len(keyring.items) != 1 {
+		t.Fatalf("MayCreate replaced or duplicated the key (%d items)", len(keyring.items))
+	}
+}
+
+// The refusals that must not become guesses: a locked item is not unlocked
+// behind the operator's back, two matching items are not chosen between,
+// and a collection that wants a prompt does not get an item half-created.
+func TestSecretServiceRefusesWhatItCannotDecide(t *testing.T) {
+	t.Run("locked", func(t *testing.T) {
+		keyring := newStandInKeyring(t)
+		keyring.put(testAttributes(), paths.EncodeKey(validRootKey()), true)
+		provider, _ := SecretService(testAttributes(), MayCreate)
+		if _, err := provider.RootKey(); !errors.Is(err, ErrKeyringLocked) {
+			t.Fatalf("RootKey = %v, want ErrKeyringLocked", err)
+		}
+		if len(keyring.items) != 1 {
+			t.Fatal("a locked item was treated as missing and a second key was created")
+		}
+	})
+	t.Run("ambiguous", func(t *testing.T) {
+		keyring := newStandInKeyring(t)
+		keyring.put(testAttributes(), paths.EncodeKey(validRootKey()), false)
+		keyring.put(testAttributes(), paths.EncodeKey(bytes.Repeat([]byte{2}, 32)), false)
+		provider, _ := SecretService(testAttributes(), LoadOnly)
+		if _, err := provider.RootKey(); !errors.Is(err, ErrAmbiguousKeyring) {
+			t.Fatalf("RootKey = %v, want ErrAmbiguousKeyring", err)
+		}
+	})
+	t.Run("prompting collection", func(t *testing.T) {
+		keyring := newStandInKeyring(t)
+		keyring.lockedDB = true
+		provider, _ := SecretService(testAttributes(), MayCreate)
+		if _, err := provider.RootKey(); !errors.Is(err, ErrKeyringLocked) {
+			t.Fatalf("RootKey = %v, want ErrKeyringLocked", err)
+		}
+	})
+}
@@ -0,0 +1,247 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// Environment variables the Vault transit backend reads at the point of use.
+//
+// The token is environment and never pointer, for the reason the pointer
+// carries no password: a file that can unwrap the root key is a file that
+// unlocks the plane. The names are Vault's own, so an operator whose shell
+// is already logged in to Vault needs nothing more.
+const (
+	EnvVaultToken     = "VAULT_TOKEN"
+	EnvVaultNamespace = "VAULT_NAMESPACE"
+)
+
+// ErrNoVaultToken reports a Vault transit backend with no token to present.
+var ErrNoVaultToken = errors.New("no Vault token is set")
+
+const (
+	// defaultTransitMount is Vault's own default mount for the engine.
+	defaultTransitMount = "transit"
+
+	// vaultTimeout bounds one request. Vault answering slowly is Vault
+	// unavailable, and a lifecycle verb should say so rather than wait.
+	vaultTimeout = 30 * time.Second
+
+	// maxVaultResponse bounds what a reply may make this process read.
+	maxVaultResponse = 1 << 20
+
+	// wrappedKeyPerm matches the key file's mode. The wrapped key is
+	// ciphertext, but it is one Vault token away from being the key.
+	wrappedKeyPerm = 0o600
+)
+
+// VaultTransitConfig locates a Vault transit key and the wrapped root key it
+// opens.
+type VaultTransitConfig struct {
+	// Address is the server origin.
+	Address string
+	// Mount is the transit engine's mount; empty means "transit".
+	Mount string
+	// KeyName is the transit key.
+	KeyName string
+	// WrappedPath is the file holding the root key as transit ciphertext.
+	WrappedPath string
+}
+
+// VaultTransit builds the provider that keeps the root key WRAPPED by a
+// Vault transit key.
+//
+// What sits on disk is transit ciphertext, and what unlocks it is a Vault
+// token with decrypt permission on one key — so the root of trust is Vault's
+// access policy, not this machine's filesystem. Revoking the token or the
+// key's policy locks the plane, which is the property the backend exists to
+// offer.
+//
+// "Transit-compatible" is the whole contract: two endpoints, encrypt and
+// decrypt, speaking Vault's request and response shapes. OpenBao and any
+// other server that honours them works the same way.
+//
+// MayCreate mints a key and wraps it when no wrapped key exists; LoadOnly
+// reports paths.ErrNoKey, as every other backend does for a missing key.
+func VaultTransit(config VaultTransitConfig, access Access) (RootKeyProvider, error) {
+	if config.Address == "" || config.KeyName == "" || config.WrappedPath == "" {
+		return nil, fmt.Errorf("vault transit backend needs an address, a key name and a wrapped-key path, got %+v", config)
+	}
+	if !filepath.IsAbs(config.WrappedPath) {
+		return nil, fmt.Errorf("vault transit wrapped-key path %q must be absolute", config.WrappedPath)
+	}
+	if config.Mount == "" {
+		config.Mount = defaultTransitMount
+	}
+	return vaultTransitProvider{config: config, access: access, client: &http.Client{Timeout: vaultTimeout}}, nil
+}
+
+type vaultTransitProvider struct {
+	client *http.Client
+	config VaultTransitConfig
+	access Access
+}
+
+func (p vaultTransitProvider) Backend() Backend { return BackendVaultTransit }
+
+func (p vaultTransitProvider) RootKey() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), vaultTimeout)
+	defer cancel()
+
+	wrapped, err := os.ReadFile(p.config.WrappedPath)
+	switch {
+	case err == nil:
+		return p.unwrap(ctx, strings.TrimSpace(string(wrapped)))
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("read the wrapped root key %s: %w", p.config.WrappedPath, err)
+	case p.access == MayCreate:
+		return p.mint(ctx)
+	default:
+		return nil, fmt.Errorf("%w: no wrapped root key at %s", paths.ErrNoKey, p.config.WrappedPath)
+	}
+}
+
+func (p vaultTransitProvider) unwrap(ctx context.Context, ciphertext string) ([]byte, error) {
+	var reply struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	if err := p.post(ctx, "decrypt", map[string]string{"ciphertext": ciphertext}, &reply); err != nil {
+		return nil, err
+	}
+	key, err := base64.StdEncoding.DecodeString(reply.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit returned a plaintext that is not base64: %w", err)
+	}
+	if len(key) != paths.RootKeyLen {
+		return nil, fmt.Errorf("vault transit unwrapped %d bytes, want exactly %d: %w",
+			len(key), paths.RootKeyLen, ErrRootKeyLength)
+	}
+	return key, nil
+}
+
+// mint wraps a new key and installs the ciphertext without replacing any
+// wrapped key that appeared meanwhile: the file is linked into place, and a
+// link fails where a rename would silently win.
+func (p vaultTransitProvider) mint(ctx context.Context) ([]byte, error) {
+	key, err := paths.NewKeyMaterial()
+	if err != nil {
+		return nil, err
+	}
+	var reply struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	plaintext := base64.StdEncoding.EncodeToString(key)
+	if err := p.post(ctx, "encrypt", map[string]string{"plaintext": plaintext}, &reply); err != nil {
+		return nil, err
+	}
+	if reply.Data.Ciphertext == "" {
+		return nil, errors.New("vault transit encrypt returned no ciphertext")
+	}
+	if err := installWrappedKey(p.config.WrappedPath, reply.Data.Ciphertext); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func installWrappedKey(path, ciphertext string) error {
+	dir := filepath.Dir(path)
+	temp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("stage the wrapped root key: %w", err)
+	}
+	tempName := temp.Name()
+	defer func() { _ = os.Remove(tempName) }()
+
+	_, writeErr := temp.WriteString(ciphertext + "\n")
+	if writeErr == nil {
+		writeErr = temp.Chmod(wrappedKeyPerm)
+	}
+	if writeErr == nil {
+		writeErr = temp.Sync()
+	}
+	if closeErr := temp.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		return fmt.Errorf("write the wrapped root key: %w", writeErr)
+	}
+	if err := os.Link(tempName, path); err != nil {
+		return fmt.Errorf("install the wrapped root key at %s: %w", path, err)
+	}
+	handle, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", dir, err)
+	}
+	defer func() { _ = handle.Close() }()
+	if err := handle.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", dir, err)
+	}
+	return nil
+}
+
+// post sends one transit request and decodes its data.
+//
+// A refusal names Vault's own errors, which say what is wrong with the
+// token or the policy, but never the request body: that carries either the
+// plaintext key or the ciphertext that unwraps to it.
+func (p vaultTransitProvider) post(ctx context.Context, operation string, body map[string]string, reply any) error {
+	token := os.Getenv(EnvVaultToken)
+	if token == "" {
+		return fmt.Errorf("%w: set %s to a token that may %s with transit key %q",
+			ErrNoVaultToken, EnvVaultToken, operation, p.config.KeyName)
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("encode the vault transit %s request: %w", operation, err)
+	}
+	endpoint := strings.TrimSuffix(p.config.Address, "/") + "/v1/" + p.config.Mount + "/" + operation + "/" +
+		url.PathEscape(p.config.KeyName)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(encoded))
+	if err != nil {
+		return fmt.Errorf("build the vault transit %s request: %w", operation, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv(EnvVaultNamespace); namespace != "" {
+		request.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("vault transit %s at %s: %w", operation, p.config.Address, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	raw, err := io.ReadAll(io.LimitReader(response.Body, maxVaultResponse))
+	if err != nil {
+		return fmt.Errorf("read the vault transit %s reply: %w", operation, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		var failure struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.Unmarshal(raw, &failure)
+		return fmt.Errorf("vault transit %s with key %q was refused (%s): %s",
+			operation, p.config.KeyName, response.Status, strings.Join(failure.Errors, "; "))
+	}
+	if err := json.Unmarshal(raw, reply); err != nil {
+		return fmt.Errorf("decode the vault transit %s reply: %w", operation, err)
+	}
+	return nil
+}
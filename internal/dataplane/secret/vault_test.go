@@ -0,0 +1,148 @@
+package secret
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// standInTransit is the two endpoints of Vault's transit engine the backend
+// uses. Its "encryption" is a reversible tag, which is all a test of the
+// wire contract needs.
+func standInTransit(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var data map[string]string
+		switch r.URL.Path {
+		case "/v1/transit/encrypt/plane":
+			data = map[string]string{"ciphertext": "vault:v1:" + body["plaintext"]}
+		case "/v1/transit/decrypt/plane":
+			data = map[string]string{"plaintext": strings.TrimPrefix(body["ciphertext"], "vault:v1:")}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":["no handler for route"]}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func transitConfig(address, wrapped string) VaultTransitConfig {
+	return VaultTransitConfig{Address: address, KeyName: "plane", WrappedPath: wrapped}
+}
+
+// TestVaultTransitMintsThenUnwraps is first-run setup and the run after it:
+// what lands on disk is ciphertext, and every later load unwraps the same key.
+func TestVaultTransitMintsThenUnwraps(t *testing.T) {
+	server := standInTransit(t, "s.token")
+	t.Setenv(EnvVaultToken, "s.token")
+	wrapped := filepath.Join(t.TempDir(), "root.key.wrapped")
+
+	creating, err := VaultTransit(transitConfig(server.URL, wrapped), MayCreate)
+	if err != nil {
+		t.Fatalf("VaultTransit: %v", err)
+	}
+	created, err := creating.RootKey()
+	if err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+
+	onDisk, err := os.ReadFile(wrapped)
+	if err != nil {
+		t.Fatalf("read wrapped key: %v", err)
+	}
+	if bytes.Contains(onDisk, paths.EncodeKey(created)) {
+		t.Fatal("the wrapped file holds the key in its key-file form")
+	}
+	if info, _ := os.Stat(wrapped); info.Mode().Perm() != wrappedKeyPerm {
+		t.Fatalf("wrapped key mode = %v, want %v", info.Mode().Perm(), os.FileMode(wrappedKeyPerm))
+	}
+
+	loading, _ := VaultTransit(transitConfig(server.URL, wrapped), LoadOnly)
+	loaded, err := loading.RootKey()
+	if err != nil {
+		t.Fatalf("unwrap: %v", err)
+	}
+	if !bytes.Equal(created, loaded) {
+		t.Fatal("the unwrapped key differs from the minted one")
+	}
+	if loading.Backend() != BackendVaultTransit {
+		t.Fatalf("Backend() = %s", loading.Backend())
+	}
+}
+
+// A missing wrapped key under LoadOnly is paths.ErrNoKey and asks Vault
+// nothing; MayCreate with no token is refused before anything is minted.
+func TestVaultTransitRefusals(t *testing.T) {
+	server := standInTransit(t, "s.token")
+
+	t.Run("load-only missing", func(t *testing.T) {
+		t.Setenv(EnvVaultToken, "s.token")
+		provider, _ := VaultTransit(transitConfig(server.URL, filepath.Join(t.TempDir(), "absent")), LoadOnly)
+		if _, err := provider.RootKey(); !errors.Is(err, paths.ErrNoKey) {
+			t.Fatalf("RootKey = %v, want ErrNoKey", err)
+		}
+	})
+	t.Run("no token", func(t *testing.T) {
+		t.Setenv(EnvVaultToken, "")
+		dir := t.TempDir()
+		provider, _ := VaultTransit(transitConfig(server.URL, filepath.Join(dir, "wrapped")), MayCreate)
+		if _, err := provider.RootKey(); !errors.Is(err, ErrNoVaultToken) {
+			t.Fatalf("RootKey = %v, want ErrNoVaultToken", err)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Fatalf("a refused mint left %d files behind", len(entries))
+		}
+	})
+	t.Run("relative wrapped path", func(t *testing.T) {
+		if _, err := VaultTransit(transitConfig(server.URL, "root.key.wrapped"), LoadOnly); err == nil {
+			t.Fatal("a relative wrapped-key path was accepted")
+		}
+	})
+}
+
+// TestVaultTransitRefusalNamesVaultsErrorNotTheRequest: an operator needs
+// Vault's reason, and must never find the ciphertext that unwraps to their
+// root key pasted into a log.
+func TestVaultTransitRefusalNamesVaultsErrorNotTheRequest(t *testing.T) {
+	server := standInTransit(t, "s.right")
+	t.Setenv(EnvVaultToken, "s.wrong")
+	wrapped := filepath.Join(t.TempDir(), "wrapped")
+	ciphertext := "vault:v1:" + base64.StdEncoding.EncodeToString(validRootKey())
+	if err := os.WriteFile(wrapped, []byte(ciphertext+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	provider, _ := VaultTransit(transitConfig(server.URL, wrapped), LoadOnly)
+	_, err := provider.RootKey()
+	if err == nil {
+		t.Fatal("a refused token unwrapped the key")
+	}
+	if !strings.Contains(err.Error(), "permission denied") {
+		t.Fatalf("the refusal does not carry Vault's reason: %v", err)
+	}
+	if strings.Contains(err.Error(), ciphertext) {
+		t.Fatalf("the refusal quotes the wrapped key: %v", err)
+	}
+}
@@ -61,6 +61,11 @@ type Manifest struct {
 	SourceRoot string          `json:"source_root"`
 	Entries    []ManifestEntry `json:"entries"`
 	Format     int             `json:"format"`
+	// Mode says how the copy was taken: empty for the cold stop-copy-start
+	// backup, ArchiveModeOnline for a scheduled one. Additive rather than a
+	// format bump, because both produce the same restorable layout and
+	// restore does not need to tell them apart.
+	Mode string `json:"mode,omitempty"`
 }
 
 // ManifestEntry inventories one top-level entry of the copied data root.
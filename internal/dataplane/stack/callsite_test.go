@@ -25,9 +25,12 @@ var guardedVerbs = map[string]string{
 	"Migrate":      "lifecycleMigrate",
 	"ForceVersion": "lifecycleForceVersion",
 	"Backup":       "lifecycleBackup",
+	"OnlineBackup": "lifecycleBackup",
 	"Restore":      "lifecycleRestore",
 	"Verify":       "lifecycleVerify",
 	"RecoverKey":   "lifecycleRecoverKey",
+	"RotateKey":    "lifecycleRotateKey",
+	"RetireKey":    "lifecycleRetireKey",
 	"OpenSeam":     "lifecycleUse",
 }
 
@@ -139,7 +139,7 @@ func openSeam(t *testing.T, cfg *Config) *postgres.Store {
 	if err != nil {
 		t.Fatalf("reach the object store: %v", err)
 	}
-	keyProvider, err := resolvedRootKey(rootKey)
+	keyProvider, err := resolvedRootKey(cfg, rootKey)
 	if err != nil {
 		t.Fatalf("wrap the root key: %v", err)
 	}
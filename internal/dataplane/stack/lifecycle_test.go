@@ -99,6 +99,16 @@ func TestLifecycleOperationsTakeTheLock(t *testing.T) {
 			destination := filepath.Join(t.TempDir(), "archive")
 			return func(ctx context.Context) error { return Backup(ctx, cfg, bogusComposeFile, destination) }
 		},
+		"OnlineBackup": func(t *testing.T, cfg *Config) func(context.Context) error {
+			t.Helper()
+			// An archive root outside the data root; it is created before
+			// the lock is taken, so it need not exist here.
+			root := filepath.Join(t.TempDir(), "scheduled")
+			return func(ctx context.Context) error {
+				_, err := OnlineBackup(ctx, cfg, bogusComposeFile, root, RetentionPolicy{})
+				return err
+			}
+		},
 		"RecoverKey": func(_ *testing.T, cfg *Config) func(context.Context) error {
 			// force: true, or it refuses before reaching for the lock and
 			// the case would pass for the wrong reason.
@@ -106,6 +116,18 @@ func TestLifecycleOperationsTakeTheLock(t *testing.T) {
 				return RecoverKey(ctx, cfg, bogusComposeFile, true)
 			}
 		},
+		"RotateKey": func(_ *testing.T, cfg *Config) func(context.Context) error {
+			return func(ctx context.Context) error {
+				_, err := RotateKey(ctx, cfg, bogusComposeFile)
+				return err
+			}
+		},
+		"RetireKey": func(_ *testing.T, cfg *Config) func(context.Context) error {
+			return func(ctx context.Context) error {
+				_, err := RetireKey(ctx, cfg)
+				return err
+			}
+		},
 		"Verify": func(_ *testing.T, cfg *Config) func(context.Context) error {
 			return func(ctx context.Context) error {
 				_, err := Verify(ctx, cfg)
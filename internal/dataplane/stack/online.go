@@ -0,0 +1,728 @@
+package stack
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// Online, incremental backups: the scheduled counterpart to Backup.
+//
+// The cold backup stops the plane, which is the right trade for an operator
+// taking one archive before a risky change and the wrong one for a schedule:
+// a nightly outage is a cost nobody agreed to. So this mode never stops
+// anything. Postgres is captured with pg_basebackup, which is the server's
+// own consistent online copy, and the object store is copied file by file
+// with every file's digest recorded, so the next run can hard-link what did
+// not change instead of copying it again.
+//
+// The result is the SAME archive layout the cold backup produces — a data
+// directory holding one directory per service, plus a manifest written last
+// — so Restore accepts it unchanged and ReadManifest/validateArchiveTree are
+// what verify it. An incremental archive is still a complete one: reuse is a
+// hard link, not a reference into a sibling, so pruning an older archive
+// can never take a newer one's objects with it.
+
+// ArchiveModeOnline marks a manifest written by OnlineBackup.
+const ArchiveModeOnline = "online"
+
+// ArchiveDigests is the per-file digest index beside an online archive's
+// manifest.
+//
+// Beside it and not under ArchiveDataDir: the data directory's inventory is
+// compared exactly against the manifest, and the index describes the tree
+// rather than belonging to it. Restore never reads it.
+const ArchiveDigests = "digests.json"
+
+// onlineArchivePrefix and onlineArchiveLayout name each scheduled archive.
+//
+// The name carries the time so the directory listing sorts chronologically,
+// but retention reads the MANIFEST's timestamp rather than parsing names: a
+// directory renamed by hand must not be able to move itself out of the
+// pruning window.
+const (
+	onlineArchivePrefix = "maestro-"
+	onlineArchiveLayout = "20060102T150405.000Z"
+)
+
+// ErrPlaneNotRunning reports an online backup of a plane that is not up.
+//
+// Refused rather than degraded to a cold copy: a schedule that silently
+// switched modes would take an archive of a stopped Postgres cluster on the
+// night it happened to be down, and nothing would say the guarantee had
+// changed.
+var ErrPlaneNotRunning = errors.New("online backup needs a running data plane")
+
+// RetentionPolicy is how many scheduled archives survive pruning.
+//
+// Daily keeps the newest archive of each of the last Daily distinct UTC
+// days that have one; Weekly does the same for ISO weeks. The two sets are
+// unioned. Both zero means "keep everything", not "keep nothing": a policy
+// nobody configured must not delete archives.
+type RetentionPolicy struct {
+	Daily  int
+	Weekly int
+}
+
+// OnlineBackupReport describes one scheduled run.
+type OnlineBackupReport struct {
+	// Archive is the published, verified archive directory.
+	Archive string
+	// Pruned are the archives retention removed after it was published.
+	Pruned []string
+	// Objects and Reused count the object-store files in the archive and
+	// how many of them were hard-linked from an earlier one.
+	Objects int
+	Reused  int
+	// CopiedBytes and ReusedBytes split the object store's size the same
+	// way, which is the number an operator sizing a disk wants.
+	CopiedBytes int64
+	ReusedBytes int64
+	// Vanished counts files the running object store removed between the
+	// walk seeing them and the copy opening them.
+	Vanished int
+}
+
+// OnlineBackup takes a consistent archive of a RUNNING plane into a new
+// timestamped directory under archiveRoot, verifies it, and prunes older
+// archives under policy.
+//
+// It holds the lifecycle lock for the whole run, as Backup does, and guards
+// as a backup: a torn, unverified or mid-recovery plane is refused, because
+// an archive of one would carry that state into every later restore.
+//
+// Like Backup it never reads the root-of-trust key. pg_basebackup runs
+// INSIDE the Postgres container against its loopback, which the image's
+// pg_hba trusts for replication, so no credential is rendered here.
+func OnlineBackup(
+	ctx context.Context, c *Config, composeFile, archiveRoot string, policy RetentionPolicy,
+) (_ OnlineBackupReport, err error) {
+	if policy.Daily < 0 || policy.Weekly < 0 {
+		return OnlineBackupReport{}, fmt.Errorf("retention counts must not be negative (daily %d, weekly %d)",
+			policy.Daily, policy.Weekly)
+	}
+	if overlapErr := refuseOverlap(c.Roots.Data, archiveRoot); overlapErr != nil {
+		return OnlineBackupReport{}, overlapErr
+	}
+	if mkErr := os.MkdirAll(archiveRoot, 0o700); mkErr != nil {
+		return OnlineBackupReport{}, fmt.Errorf("create %s: %w", archiveRoot, mkErr)
+	}
+
+	release, lockErr := lockLifecycle(c)
+	if lockErr != nil {
+		return OnlineBackupReport{}, lockErr
+	}
+	defer func() {
+		if relErr := release(); relErr != nil && err == nil {
+			err = relErr
+		}
+	}()
+
+	if guardErr := guardRestoreState(c, lifecycleBackup); guardErr != nil {
+		return OnlineBackupReport{}, guardErr
+	}
+
+	env, envErr := c.composeEnv(placeholderKey())
+	if envErr != nil {
+		return OnlineBackupReport{}, envErr
+	}
+	state, stateErr := readProjectState(ctx, c.ProjectName, composeFile, env)
+	if stateErr != nil {
+		return OnlineBackupReport{}, stateErr
+	}
+	for _, service := range allServiceNames() {
+		if !slices.Contains(state.running, service) {
+			return OnlineBackupReport{}, fmt.Errorf("%w: %s is not running. Start it with `dataplane-up`, "+
+				"or take a cold archive with `dataplane-backup`", ErrPlaneNotRunning, service)
+		}
+	}
+
+	prior, indexErr := loadDigestIndex(archiveRoot)
+	if indexErr != nil {
+		return OnlineBackupReport{}, indexErr
+	}
+
+	report := OnlineBackupReport{}
+	archive, publishErr := publishOnlineArchive(archiveRoot, c.Roots.Data, func(data string) error {
+		if dumpErr := basebackup(ctx, c, composeFile, env, data); dumpErr != nil {
+			return dumpErr
+		}
+		// The database FIRST, the objects second. Writers store an object
+		// before committing the row that references it, so every object
+		// the snapshot's rows name already exists when the walk starts. The
+		// reverse order would capture rows whose objects the walk had
+		// already passed.
+		return copyObjects(c.Roots, data, prior, &report)
+	})
+	if publishErr != nil {
+		return OnlineBackupReport{}, publishErr
+	}
+	report.Archive = archive
+
+	// Verification before retention, and retention only after a
+	// verified archive exists: pruning on the strength of a run that failed
+	// would shrink the set of good archives on exactly the night one was
+	// needed.
+	if verifyErr := verifyArchive(archive); verifyErr != nil {
+		return report, verifyErr
+	}
+	pruned, pruneErr := applyRetention(archiveRoot, policy, archive)
+	report.Pruned = pruned
+	if pruneErr != nil {
+		return report, pruneErr
+	}
+	return report, nil
+}
+
+// verifyArchive applies restore's own acceptance checks to an archive that
+// was just published.
+//
+// The same two functions, not a parallel check: an archive that passes
+// here is by construction one Restore will accept, and a change to what
+// Restore accepts changes what a schedule verifies without a second edit.
+func verifyArchive(archive string) error {
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		return fmt.Errorf("verify %s: %w", archive, err)
+	}
+	if err := validateArchiveTree(filepath.Join(archive, ArchiveDataDir), manifest); err != nil {
+		return fmt.Errorf("verify %s: %w", archive, err)
+	}
+	return nil
+}
+
+// publishOnlineArchive stages an archive beside its final name, lets fill
+// populate the data directory, writes the digest index and then the
+// manifest, and renames the result into place.
+//
+// The completion protocol is Backup's: the manifest is written last, so a
+// killed run leaves a staging directory nothing will mistake for an archive.
+func publishOnlineArchive(archiveRoot, sourceRoot string, fill func(data string) error) (string, error) {
+	staging, err := os.MkdirTemp(archiveRoot, ".maestro-backup-*")
+	if err != nil {
+		return "", fmt.Errorf("create staging directory in %s: %w", archiveRoot, err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = os.RemoveAll(staging)
+		}
+	}()
+	//nolint:gosec // G302 assumes a file; 0700 on a directory is the tight mode, not a loose one.
+	if chmodErr := os.Chmod(staging, 0o700); chmodErr != nil {
+		return "", fmt.Errorf("set mode on %s: %w", staging, chmodErr)
+	}
+
+	data := filepath.Join(staging, ArchiveDataDir)
+	if mkErr := os.Mkdir(data, 0o700); mkErr != nil {
+		return "", fmt.Errorf("create %s: %w", data, mkErr)
+	}
+	if fillErr := fill(data); fillErr != nil {
+		return "", fillErr
+	}
+
+	digests, digestErr := digestTree(data)
+	if digestErr != nil {
+		return "", digestErr
+	}
+	if writeErr := writeDigestIndex(staging, digests); writeErr != nil {
+		return "", writeErr
+	}
+
+	manifest, manifestErr := inventory(data, sourceRoot)
+	if manifestErr != nil {
+		return "", manifestErr
+	}
+	manifest.Mode = ArchiveModeOnline
+	if writeErr := writeManifest(staging, manifest); writeErr != nil {
+		return "", writeErr
+	}
+
+	destination := filepath.Join(archiveRoot, onlineArchivePrefix+manifest.CreatedAt.Format(onlineArchiveLayout))
+	if _, statErr := os.Lstat(destination); statErr == nil {
+		return "", fmt.Errorf("%w: %s", ErrDestinationExists, destination)
+	}
+	if renameErr := os.Rename(staging, destination); renameErr != nil {
+		return "", fmt.Errorf("publish archive to %s: %w", destination, renameErr)
+	}
+	committed = true
+	return destination, syncDir(archiveRoot)
+}
+
+// basebackup streams a pg_basebackup of the running cluster into the
+// archive's postgres directory, laid out exactly as the live bind mount is.
+//
+// Tar to stdout with WAL FETCHED into the same stream (-X fetch): that is
+// the one pg_basebackup mode which needs no second file, and it makes the
+// copy self-contained — the WAL needed to reach consistency travels with
+// it, so restoring the directory and starting the server is the whole
+// recovery. --checkpoint=fast, because a spread checkpoint can hold a
+// scheduled run for the full checkpoint interval for no benefit here.
+func basebackup(ctx context.Context, c *Config, composeFile string, env []string, data string) (err error) {
+	live, err := c.Roots.ServiceDataDir(paths.ServicePostgres)
+	if err != nil {
+		return fmt.Errorf("resolve the postgres data directory: %w", err)
+	}
+	target := filepath.Join(data, string(paths.ServicePostgres))
+	if mirrorErr := mirrorDirMode(live, target); mirrorErr != nil {
+		return mirrorErr
+	}
+	// The cluster lives in a subdirectory of the mount (compose.yaml's
+	// PGDATA), and the restored tree must put it in the same place.
+	cluster := filepath.Join(target, "pgdata")
+
+	cmd, err := composeCommand(ctx, c.ProjectName, composeFile, env, "exec", "-T", string(paths.ServicePostgres),
+		"sh", "-c", `pg_basebackup -h 127.0.0.1 -U "$POSTGRES_USER" -D - -F tar -X fetch --checkpoint=fast`)
+	if err != nil {
+		return err
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("attach to pg_basebackup: %w", err)
+	}
+	if startErr := cmd.Start(); startErr != nil {
+		return fmt.Errorf("start pg_basebackup: %w", startErr)
+	}
+
+	extractErr := extractTar(stdout, cluster)
+	if extractErr != nil {
+		// Drain so the child is not left blocked on a full pipe while Wait
+		// waits for it.
+		_, _ = io.Copy(io.Discard, stdout)
+	}
+	waitErr := cmd.Wait()
+	if waitErr != nil {
+		return fmt.Errorf("pg_basebackup: %w\n%s", waitErr, stderr.String())
+	}
+	if extractErr != nil {
+		return fmt.Errorf("unpack the base backup into %s: %w", cluster, extractErr)
+	}
+	return nil
+}
+
+// extractTar unpacks a pg_basebackup tar stream into dir.
+//
+// Narrow on purpose: pg_basebackup emits directories, regular files and
+// symlinks, and anything else — or any entry that would land outside dir —
+// is a stream this code did not expect and refuses rather than interprets.
+// Every file is fsynced, and every directory post-order, for the reason
+// copyTree gives.
+func extractTar(stream io.Reader, dir string) error {
+	if err := copyDir(dir, 0o700); err != nil {
+		return err
+	}
+	created := []string{dir}
+	reader := tar.NewReader(stream)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read tar stream: %w", err)
+		}
+		clean := filepath.Clean(header.Name)
+		if clean == "." {
+			continue
+		}
+		if !filepath.IsLocal(clean) {
+			return fmt.Errorf("tar entry %q escapes the cluster directory", header.Name)
+		}
+		target := filepath.Join(dir, clean)
+		perm := fs.FileMode(header.Mode).Perm()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := copyDir(target, perm); err != nil {
+				return err
+			}
+			created = append(created, target)
+		case tar.TypeReg:
+			if err := writeTarFile(reader, target, perm); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return fmt.Errorf("create link %s: %w", target, err)
+			}
+		default:
+			return fmt.Errorf("%w: tar entry %q has type %q", ErrUnsupportedFileType, header.Name, header.Typeflag)
+		}
+	}
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := syncDir(created[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTarFile(reader io.Reader, target string, perm fs.FileMode) (err error) {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perm)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", target, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close %s: %w", target, closeErr)
+		}
+	}()
+	//nolint:gosec // G110: the stream is our own pg_basebackup, bounded by the cluster it copies.
+	if _, err := io.Copy(file, reader); err != nil {
+		return fmt.Errorf("write %s: %w", target, err)
+	}
+	if err := os.Chmod(target, perm); err != nil {
+		return fmt.Errorf("set mode on %s: %w", target, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", target, err)
+	}
+	return nil
+}
+
+// mirrorDirMode creates target with live's permission bits.
+func mirrorDirMode(live, target string) error {
+	info, err := os.Stat(live)
+	if err != nil {
+		return fmt.Errorf("stat %s: %w", live, err)
+	}
+	return copyDir(target, info.Mode().Perm())
+}
+
+// copyObjects copies the running object store's tree into the archive,
+// hard-linking every file whose digest an earlier archive already holds.
+//
+// MinIO keeps writing while this runs. A file it removes between the walk
+// listing it and the copy opening it is counted and skipped rather than
+// failing the run: the object is gone from the store too, so there is
+// nothing to lose — and any row that still names it is what the restored
+// plane's own verify exists to report.
+func copyObjects(roots paths.Roots, data string, prior digestIndex, report *OnlineBackupReport) error {
+	live, err := roots.ServiceDataDir(paths.ServiceMinIO)
+	if err != nil {
+		return fmt.Errorf("resolve the object-store data directory: %w", err)
+	}
+	target := filepath.Join(data, string(paths.ServiceMinIO))
+
+	var created []string
+	walkErr := filepath.WalkDir(live, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && path != live {
+				report.Vanished++
+				return nil
+			}
+			return fmt.Errorf("walk %s: %w", path, err)
+		}
+		relative, relErr := filepath.Rel(live, path)
+		if relErr != nil {
+			return fmt.Errorf("relativise %s against %s: %w", path, live, relErr)
+		}
+		destination := filepath.Join(target, relative)
+
+		info, infoErr := entry.Info()
+		if errors.Is(infoErr, os.ErrNotExist) {
+			report.Vanished++
+			return nil
+		}
+		if infoErr != nil {
+			return fmt.Errorf("stat %s: %w", path, infoErr)
+		}
+
+		switch {
+		case entry.IsDir():
+			created = append(created, destination)
+			return copyDir(destination, info.Mode().Perm())
+		case entry.Type()&fs.ModeSymlink != 0:
+			return copySymlink(path, destination)
+		case entry.Type().IsRegular():
+			return copyObject(path, destination, info, prior, report)
+		default:
+			return fmt.Errorf("%w: %s is %s", ErrUnsupportedFileType, path, entry.Type())
+		}
+	})
+	if walkErr != nil {
+		return fmt.Errorf("copy %s into the archive: %w", live, walkErr)
+	}
+	for i := len(created) - 1; i >= 0; i-- {
+		if err := syncDir(created[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyObject places one object-store file in the archive: a hard link to
+// an earlier archive's copy when one has the same digest and size, a fresh
+// copy otherwise.
+//
+// The digest is of what was READ, so a file rewritten after hashing is at
+// worst archived at its previous content — which the digest index then
+// describes truthfully — and never as a mixture of the two.
+func copyObject(path, destination string, info fs.FileInfo, prior digestIndex, report *OnlineBackupReport) error {
+	digest, err := fileDigest(path)
+	if errors.Is(err, os.ErrNotExist) {
+		report.Vanished++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if earlier, found := prior[digest]; found {
+		if linked, linkErr := linkEarlier(earlier, destination, info.Size()); linkErr != nil {
+			return linkErr
+		} else if linked {
+			report.Objects++
+			report.Reused++
+			report.ReusedBytes += info.Size()
+			return nil
+		}
+	}
+
+	if err := copyFile(path, destination, info.Mode().Perm(), syncContents); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			report.Vanished++
+			return nil
+		}
+		return err
+	}
+	report.Objects++
+	report.CopiedBytes += info.Size()
+	return nil
+}
+
+// linkEarlier hard-links an earlier archive's file into place, reporting
+// false when that is not possible and the caller should copy instead.
+//
+// Not possible covers a file pruned since the index was read, a size that
+// disagrees with the digest's (so the index is stale, and trusting it
+// would archive the wrong bytes), and a link across filesystems.
+func linkEarlier(earlier, destination string, size int64) (bool, error) {
+	info, err := os.Lstat(earlier)
+	if err != nil || !info.Mode().IsRegular() || info.Size() != size {
+		return false, nil
+	}
+	if err := os.Link(earlier, destination); err != nil {
+		var linkErr *os.LinkError
+		if errors.As(err, &linkErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("link %s to %s: %w", earlier, destination, err)
+	}
+	return true, nil
+}
+
+// fileDigest is the hex sha256 of a file's contents.
+func fileDigest(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("hash %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// digestIndex maps a content digest to one archived file holding it.
+type digestIndex map[string]string
+
+// digestTree records the digest of every regular file in an archive's
+// data directory, keyed by slash-separated path relative to it.
+//
+// Recomputed over the ARCHIVE rather than collected during the copy, so
+// the index describes what was written, including the base backup, and a
+// later run reuses only bytes that really are on disk here.
+func digestTree(data string) (map[string]string, error) {
+	digests := map[string]string{}
+	err := filepath.WalkDir(data, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("walk %s: %w", path, err)
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		relative, relErr := filepath.Rel(data, path)
+		if relErr != nil {
+			return fmt.Errorf("relativise %s against %s: %w", path, data, relErr)
+		}
+		digest, digestErr := fileDigest(path)
+		if digestErr != nil {
+			return digestErr
+		}
+		digests[filepath.ToSlash(relative)] = digest
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("digest %s: %w", data, err)
+	}
+	return digests, nil
+}
+
+func writeDigestIndex(archive string, digests map[string]string) (err error) {
+	body, err := json.MarshalIndent(digests, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode digest index: %w", err)
+	}
+	path := filepath.Join(archive, ArchiveDigests)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("close %s: %w", path, closeErr)
+		}
+	}()
+	if _, err := file.Write(append(body, '\n')); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadDigestIndex collects the object-store digests of every COMPLETE
+// online archive under root.
+//
+// Only archives with a readable manifest contribute. A staging directory or
+// a killed run's residue holds files nobody verified, and linking from one
+// would launder unverified bytes into a verified archive. Cold archives
+// carry no index and simply contribute nothing.
+func loadDigestIndex(root string) (digestIndex, error) {
+	archives, err := listArchives(root)
+	if err != nil {
+		return nil, err
+	}
+	index := digestIndex{}
+	objectPrefix := string(paths.ServiceMinIO) + "/"
+	for _, archive := range archives {
+		body, readErr := os.ReadFile(filepath.Join(archive.path, ArchiveDigests))
+		if errors.Is(readErr, os.ErrNotExist) {
+			continue
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("read the digest index of %s: %w", archive.path, readErr)
+		}
+		var digests map[string]string
+		if jsonErr := json.Unmarshal(body, &digests); jsonErr != nil {
+			// A damaged index costs reuse, not correctness: the archive's
+			// files are simply copied again.
+			continue
+		}
+		for relative, digest := range digests {
+			if !strings.HasPrefix(relative, objectPrefix) {
+				continue
+			}
+			if _, known := index[digest]; !known {
+				index[digest] = filepath.Join(archive.path, ArchiveDataDir, filepath.FromSlash(relative))
+			}
+		}
+	}
+	return index, nil
+}
+
+// archiveEntry is one complete archive under a scheduled root.
+type archiveEntry struct {
+	created time.Time
+	path    string
+}
+
+// listArchives returns the complete online archives under root, newest
+// first.
+//
+// Cold archives placed in the same root are left out deliberately: they
+// were taken by hand, for a reason the schedule does not know, and a
+// retention pass deleting them would be acting on an operator's decision it
+// never saw.
+func listArchives(root string) ([]archiveEntry, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", root, err)
+	}
+	var archives []archiveEntry
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), onlineArchivePrefix) {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		manifest, manifestErr := ReadManifest(path)
+		if manifestErr != nil || manifest.Mode != ArchiveModeOnline {
+			continue
+		}
+		archives = append(archives, archiveEntry{path: path, created: manifest.CreatedAt})
+	}
+	sort.Slice(archives, func(i, j int) bool { return archives[i].created.After(archives[j].created) })
+	return archives, nil
+}
+
+// applyRetention removes the archives policy does not keep. The archive
+// just published is always kept, whatever the policy says.
+func applyRetention(root string, policy RetentionPolicy, current string) ([]string, error) {
+	if policy.Daily == 0 && policy.Weekly == 0 {
+		return nil, nil
+	}
+	archives, err := listArchives(root)
+	if err != nil {
+		return nil, err
+	}
+	keep := retained(archives, policy)
+	keep[current] = true
+
+	var pruned []string
+	for _, archive := range archives {
+		if keep[archive.path] {
+			continue
+		}
+		if removeErr := os.RemoveAll(archive.path); removeErr != nil {
+			return pruned, fmt.Errorf("prune %s: %w", archive.path, removeErr)
+		}
+		pruned = append(pruned, archive.path)
+	}
+	if len(pruned) > 0 {
+		return pruned, syncDir(root)
+	}
+	return pruned, nil
+}
+
+// retained selects the archives a policy keeps from a newest-first list.
+func retained(archives []archiveEntry, policy RetentionPolicy) map[string]bool {
+	keep := map[string]bool{}
+	days := map[string]bool{}
+	weeks := map[string]bool{}
+	for _, archive := range archives {
+		created := archive.created.UTC()
+		day := created.Format(time.DateOnly)
+		if !days[day] && len(days) < policy.Daily {
+			days[day] = true
+			keep[archive.path] = true
+		}
+		year, week := created.ISOWeek()
+		weekKey := fmt.Sprintf("%d-W%02d", year, week)
+		if !weeks[weekKey] && len(weeks) < policy.Weekly {
+			weeks[weekKey] = true
+			keep[archive.path] = true
+		}
+	}
+	return keep
+}
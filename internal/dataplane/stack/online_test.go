@@ -0,0 +1,192 @@
+package stack
+
+import (
+	"archive/tar"
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"orchestrator/internal/dataplane/paths"
+)
+
+// seedObjectStore lays out a minimal live object store and Postgres mount,
+// the two service directories an online archive must contain.
+func seedObjectStore(t *testing.T, cfg *Config, objects map[string]string) {
+	t.Helper()
+	for _, service := range paths.Services() {
+		mustMkdir(t, filepath.Join(cfg.Roots.Data, string(service)))
+	}
+	for name, body := range objects {
+		path := filepath.Join(cfg.Roots.Data, string(paths.ServiceMinIO), filepath.FromSlash(name))
+		mustMkdir(t, filepath.Dir(path))
+		mustWrite(t, path, []byte(body))
+	}
+}
+
+// takeOnlineArchive runs the publication half of OnlineBackup without
+// Docker: the base backup is replaced by a fixed cluster file, which is all
+// the archive's shape needs.
+func takeOnlineArchive(t *testing.T, cfg *Config, root string) (string, OnlineBackupReport) {
+	t.Helper()
+	prior, err := loadDigestIndex(root)
+	if err != nil {
+		t.Fatalf("loadDigestIndex: %v", err)
+	}
+	var report OnlineBackupReport
+	archive, err := publishOnlineArchive(root, cfg.Roots.Data, func(data string) error {
+		cluster := filepath.Join(data, string(paths.ServicePostgres), "pgdata")
+		mustMkdir(t, cluster)
+		mustWrite(t, filepath.Join(cluster, "PG_VERSION"), []byte("18\n"))
+		return copyObjects(cfg.Roots, data, prior, &report)
+	})
+	if err != nil {
+		t.Fatalf("publishOnlineArchive: %v", err)
+	}
+	if err := verifyArchive(archive); err != nil {
+		t.Fatalf("a freshly published archive must pass restore's own checks: %v", err)
+	}
+	return archive, report
+}
+
+// The second run hard-links what did not change and copies what did, and
+// the result is still a complete archive on its own.
+func TestOnlineArchiveReusesUnchangedObjects(t *testing.T) {
+	cfg := planeAt(t)
+	seedObjectStore(t, cfg, map[string]string{
+		"maestro/a/part.1": "unchanging attachment",
+		"maestro/b/part.1": "first version",
+	})
+	root := t.TempDir()
+
+	first, firstReport := takeOnlineArchive(t, cfg, root)
+	if firstReport.Objects != 2 || firstReport.Reused != 0 {
+		t.Fatalf("first run = %d objects / %d reused, want 2 / 0: nothing exists to reuse yet",
+			firstReport.Objects, firstReport.Reused)
+	}
+
+	mustWriteOver(t, filepath.Join(cfg.Roots.Data, "minio", "maestro", "b", "part.1"), []byte("second version"))
+	second, secondReport := takeOnlineArchive(t, cfg, root)
+	if secondReport.Objects != 2 || secondReport.Reused != 1 {
+		t.Fatalf("second run = %d objects / %d reused, want 2 / 1", secondReport.Objects, secondReport.Reused)
+	}
+
+	sameInode := func(name string) bool {
+		a, errA := os.Stat(filepath.Join(first, ArchiveDataDir, "minio", "maestro", name, "part.1"))
+		b, errB := os.Stat(filepath.Join(second, ArchiveDataDir, "minio", "maestro", name, "part.1"))
+		if errA != nil || errB != nil {
+			t.Fatalf("stat archived objects: %v / %v", errA, errB)
+		}
+		return os.SameFile(a, b)
+	}
+	if !sameInode("a") {
+		t.Error("the unchanged object was copied again rather than hard-linked")
+	}
+	if sameInode("b") {
+		t.Error("the changed object was linked to its old content")
+	}
+
+	// Pruning the first archive must not take the second's objects with it.
+	if err := os.RemoveAll(first); err != nil {
+		t.Fatalf("remove first archive: %v", err)
+	}
+	body, err := os.ReadFile(filepath.Join(second, ArchiveDataDir, "minio", "maestro", "a", "part.1"))
+	if err != nil || string(body) != "unchanging attachment" {
+		t.Fatalf("reused object after pruning its source = %q, %v", body, err)
+	}
+	if err := verifyArchive(second); err != nil {
+		t.Errorf("the second archive stopped verifying once the first was gone: %v", err)
+	}
+}
+
+// A stale index entry whose size disagrees is ignored rather than trusted.
+func TestLinkEarlierRefusesASizeMismatch(t *testing.T) {
+	dir := t.TempDir()
+	earlier := filepath.Join(dir, "earlier")
+	mustWrite(t, earlier, []byte("twelve bytes"))
+
+	linked, err := linkEarlier(earlier, filepath.Join(dir, "copy"), 3)
+	if err != nil || linked {
+		t.Fatalf("linkEarlier = %v, %v; want a quiet refusal so the caller copies", linked, err)
+	}
+}
+
+func TestRetainedKeepsNewestPerDayAndWeek(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("parse %s: %v", value, err)
+		}
+		return parsed
+	}
+	// Newest first, as listArchives returns them. 2026-10-12 is a Monday.
+	archives := []archiveEntry{
+		{path: "wed-late", created: at("2026-10-14T23:00:00Z")},
+		{path: "wed-early", created: at("2026-10-14T01:00:00Z")},
+		{path: "tue", created: at("2026-10-13T12:00:00Z")},
+		{path: "mon", created: at("2026-10-12T12:00:00Z")},
+		{path: "prev-sun", created: at("2026-10-11T12:00:00Z")},
+		{path: "prev-sat", created: at("2026-10-10T12:00:00Z")},
+		{path: "two-weeks", created: at("2026-10-03T12:00:00Z")},
+	}
+
+	keep := retained(archives, RetentionPolicy{Daily: 2, Weekly: 2})
+	want := map[string]bool{"wed-late": true, "tue": true, "prev-sun": true}
+	if len(keep) != len(want) {
+		t.Fatalf("kept %v, want %v", keep, want)
+	}
+	for path := range want {
+		if !keep[path] {
+			t.Errorf("%s was not kept; kept %v", path, keep)
+		}
+	}
+}
+
+func TestApplyRetentionWithoutAPolicyKeepsEverything(t *testing.T) {
+	cfg := planeAt(t)
+	seedObjectStore(t, cfg, map[string]string{"maestro/a": "x"})
+	root := t.TempDir()
+	first, _ := takeOnlineArchive(t, cfg, root)
+	second, _ := takeOnlineArchive(t, cfg, root)
+
+	pruned, err := applyRetention(root, RetentionPolicy{}, second)
+	if err != nil || len(pruned) != 0 {
+		t.Fatalf("applyRetention with no policy pruned %v, %v", pruned, err)
+	}
+
+	pruned, err = applyRetention(root, RetentionPolicy{Daily: 1}, second)
+	if err != nil {
+		t.Fatalf("applyRetention: %v", err)
+	}
+	if len(pruned) != 1 || pruned[0] != first {
+		t.Fatalf("pruned %v, want only %s", pruned, first)
+	}
+}
+
+// The base backup stream is unpacked under the cluster directory and
+// nowhere else.
+func TestExtractTarRefusesEscapingEntries(t *testing.T) {
+	var stream bytes.Buffer
+	writer := tar.NewWriter(&stream)
+	body := []byte("owned")
+	if err := writer.WriteHeader(&tar.Header{
+		Name: "../outside", Mode: 0o600, Size: int64(len(body)), Typeflag: tar.TypeReg,
+	}); err != nil {
+		t.Fatalf("write header: %v", err)
+	}
+	if _, err := writer.Write(body); err != nil {
+		t.Fatalf("write body: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := extractTar(&stream, filepath.Join(dir, "pgdata")); err == nil {
+		t.Fatal("extractTar accepted an entry outside the cluster directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "outside")); err == nil {
+		t.Error("the escaping entry was written")
+	}
+}
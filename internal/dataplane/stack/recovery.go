@@ -343,6 +343,9 @@ func authorizeRecovery(c *Config, marker *recoveryMarker) error {
 	if marker != nil {
 		return nil
 	}
+	if err := requireKeyFile(c, lifecycleRecoverKey, ErrRecoveryNotAuthorized); err != nil {
+		return err
+	}
 	_, keyErr := rootKeyFor(c, lifecycleRecoverKey)
 	switch {
 	case errors.Is(keyErr, ErrPlaneLocked):
@@ -481,7 +484,13 @@ func clearRecoveryState(c *Config) error {
 	if err := os.Remove(recoveryMarkerPath(c)); err != nil && !errors.Is(err, os.ErrNotExist) {
 		return fmt.Errorf("remove %s: %w", RecoveryMarkerFile, err)
 	}
-	return syncDir(c.Roots.Data)
+	if err := syncDir(c.Roots.Data); err != nil {
+		return err
+	}
+	// An interrupted rotation's state goes with it: the two share the
+	// isolated server, and every caller here is discarding or replacing the
+	// plane that rotation was moving.
+	return clearRotationState(c)
 }
 
 // clearRecoveryResidue is both halves, for callers whose own phase boundary
@@ -515,7 +524,7 @@ func applyRecovery(
 	// in-container authentication trap, in a new place -- and every branch
 	// of the resume table would then take the same path regardless of what
 	// actually happened.
-	alreadyApplied, probeErr := probeRecoveredPassword(ctx, c, composeFile, marker, newPassword)
+	alreadyApplied, probeErr := probeRecoveredPassword(ctx, c, composeFile, marker.Container, newPassword)
 	if probeErr != nil {
 		return probeErr
 	}
@@ -524,15 +533,15 @@ func applyRecovery(
 	}
 
 	// Step 6: one transaction, before any network exposure.
-	return changeCredentialAndDropSecrets(ctx, c, composeFile, marker, newPassword)
+	return changeCredentialAndDropSecrets(ctx, c, composeFile, marker.Container, newPassword)
 }
 
 // probeRecoveredPassword reports whether the new password already
 // authenticates, using a socket-only server with REAL authentication.
 func probeRecoveredPassword(
-	ctx context.Context, c *Config, composeFile string, marker *recoveryMarker, password string,
+	ctx context.Context, c *Config, composeFile, container, password string,
 ) (applied bool, err error) {
-	if startErr := startRecoveryServer(ctx, c, composeFile, marker, hbaScram); startErr != nil {
+	if startErr := startRecoveryServer(ctx, c, composeFile, container, hbaScram); startErr != nil {
 		return false, startErr
 	}
 	// The removal's error is PROPAGATED, not discarded. A surviving
@@ -543,7 +552,7 @@ func probeRecoveredPassword(
 	// while the server that answered it is still running would be handing
 	// back a result whose preconditions no longer hold.
 	defer func() {
-		if rmErr := removeRecoveryContainer(context.WithoutCancel(ctx), marker.Container); rmErr != nil {
+		if rmErr := removeRecoveryContainer(context.WithoutCancel(ctx), container); rmErr != nil {
 			err = errors.Join(err, rmErr)
 		}
 	}()
@@ -555,7 +564,7 @@ func probeRecoveredPassword(
 	// here is the ordinary "not yet applied" answer, so it is not wrapped as
 	// an error -- but a failure to REACH the server at all would look the
 	// same, which is why startRecoveryServer waits for readiness first.
-	out, err := dockerExec(stepCtx, marker.Container, []string{"PGPASSWORD=" + password},
+	out, err := dockerExec(stepCtx, container, []string{"PGPASSWORD=" + password},
 		"psql", "-h", "/var/run/postgresql", "-U", c.User, "-d", c.Database, "-tAc", "select 1")
 	if err == nil {
 		return true, nil
@@ -578,16 +587,39 @@ func probeRecoveredPassword(
 // ciphertext is still readable -- a state that is strictly worse than either
 // end of it.
 func changeCredentialAndDropSecrets(
-	ctx context.Context, c *Config, composeFile string, marker *recoveryMarker, password string,
+	ctx context.Context, c *Config, composeFile, container, password string,
+) error {
+	// Quoted with dollar-quoting so the derived password -- hex, but that is
+	// the deriver's business rather than a property to rely on -- cannot
+	// terminate the literal.
+	statement := fmt.Sprintf(
+		"BEGIN; ALTER USER %s PASSWORD $maestro$%s$maestro$; DELETE FROM secrets; COMMIT;",
+		quoteIdentifier(c.User), password)
+	if err := runIsolatedStatement(ctx, c, composeFile, container, statement); err != nil {
+		return fmt.Errorf("rewrite the database credential and drop every secret: %w", err)
+	}
+	return nil
+}
+
+// runIsolatedStatement runs SQL through a trust-authenticated, socket-only
+// server over the plane's PGDATA, and removes that server afterwards.
+//
+// Shared by new-key recovery and by rotation, which change the same
+// credential for different reasons and need exactly the same isolation to do
+// it: the statement has to run without the old password (recovery has lost
+// it) or without the plane answering on the network while the credential
+// moves (rotation).
+func runIsolatedStatement(
+	ctx context.Context, c *Config, composeFile, container, statement string,
 ) (err error) {
-	if startErr := startRecoveryServer(ctx, c, composeFile, marker, hbaTrust); startErr != nil {
+	if startErr := startRecoveryServer(ctx, c, composeFile, container, hbaTrust); startErr != nil {
 		return startErr
 	}
 	// Propagated for the reason the probe's is, and more urgently: this
 	// server's HBA TRUSTS whoever reaches it, so one left running is both a
 	// second postmaster over the cluster and an unauthenticated database.
 	defer func() {
-		if rmErr := removeRecoveryContainer(context.WithoutCancel(ctx), marker.Container); rmErr != nil {
+		if rmErr := removeRecoveryContainer(context.WithoutCancel(ctx), container); rmErr != nil {
 			err = errors.Join(err, rmErr)
 		}
 	}()
@@ -595,18 +627,11 @@ func changeCredentialAndDropSecrets(
 	stepCtx, cancel := context.WithTimeout(ctx, recoveryStepTimeout)
 	defer cancel()
 
-	// Quoted with dollar-quoting so the derived password -- hex, but that is
-	// the deriver's business rather than a property to rely on -- cannot
-	// terminate the literal.
-	statement := fmt.Sprintf(
-		"BEGIN; ALTER USER %s PASSWORD $maestro$%s$maestro$; DELETE FROM secrets; COMMIT;",
-		quoteIdentifier(c.User), password)
-
-	out, err := dockerExec(stepCtx, marker.Container, nil,
+	out, err := dockerExec(stepCtx, container, nil,
 		"psql", "-h", "/var/run/postgresql", "-v", "ON_ERROR_STOP=1",
 		"-U", c.User, "-d", c.Database, "-c", statement)
 	if err != nil {
-		return fmt.Errorf("rewrite the database credential and drop every secret: %w\n%s", err, out)
+		return fmt.Errorf("%w\n%s", err, out)
 	}
 	return nil
 }
@@ -630,11 +655,9 @@ const (
 // for the probe, is one config line away from trusting) whoever reaches it:
 // no TCP listener at all, no published ports, and no network attachment.
 // Item 7 measured that the recovery container reports no listener on 5432.
-func startRecoveryServer(
-	ctx context.Context, c *Config, composeFile string, marker *recoveryMarker, hba string,
-) error {
+func startRecoveryServer(ctx context.Context, c *Config, composeFile, container, hba string) error {
 	// A survivor from the previous step or a previous process owns PGDATA.
-	if err := removeRecoveryContainer(ctx, marker.Container); err != nil {
+	if err := removeRecoveryContainer(ctx, container); err != nil {
 		return err
 	}
 
@@ -666,7 +689,7 @@ func startRecoveryServer(
 
 	args := []string{
 		"run", "--detach",
-		"--name", marker.Container,
+		"--name", container,
 		"--user", strconv.Itoa(os.Getuid()) + ":" + strconv.Itoa(os.Getgid()),
 		// No network at all. The absence of a listener is the security
 		// boundary; this is defence in depth behind it.
@@ -682,7 +705,7 @@ func startRecoveryServer(
 		return fmt.Errorf("start the recovery server: %w\n%s", err, out)
 	}
 
-	return waitRecoveryServerReady(runCtx, c, marker.Container)
+	return waitRecoveryServerReady(runCtx, c, container)
 }
 
 // waitRecoveryServerReady blocks until the isolated server answers on its
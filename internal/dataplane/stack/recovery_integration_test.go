@@ -278,7 +278,7 @@ func TestRecoverKeyProbeRejectsAWrongPassword(t *testing.T) {
 		t.Fatalf("derive the real password: %v", err)
 	}
 
-	accepted, err := probeRecoveredPassword(t.Context(), cfg, testComposeFile(), marker, realPassword)
+	accepted, err := probeRecoveredPassword(t.Context(), cfg, testComposeFile(), marker.Container, realPassword)
 	if err != nil {
 		t.Fatalf("probe with the correct password: %v", err)
 	}
@@ -287,7 +287,7 @@ func TestRecoverKeyProbeRejectsAWrongPassword(t *testing.T) {
 			"forever, and recovery would re-run its transaction on every resume")
 	}
 
-	rejected, err := probeRecoveredPassword(t.Context(), cfg, testComposeFile(), marker,
+	rejected, err := probeRecoveredPassword(t.Context(), cfg, testComposeFile(), marker.Container,
 		"definitely-not-the-password")
 	if err != nil {
 		t.Fatalf("probe with a wrong password: %v", err)
@@ -318,7 +318,7 @@ func TestRecoveryServerPublishesNoListener(t *testing.T) {
 	t.Cleanup(func() {
 		_ = removeRecoveryContainer(context.WithoutCancel(t.Context()), marker.Container)
 	})
-	if err := startRecoveryServer(t.Context(), cfg, testComposeFile(), marker, hbaTrust); err != nil {
+	if err := startRecoveryServer(t.Context(), cfg, testComposeFile(), marker.Container, hbaTrust); err != nil {
 		t.Fatalf("startRecoveryServer: %v", err)
 	}
 
@@ -506,7 +506,7 @@ func assertNoRecoveryContainer(t *testing.T, cfg *Config) {
 // as a killed CLI would.
 func leaveRecoveryContainer(t *testing.T, cfg *Config, marker *recoveryMarker) {
 	t.Helper()
-	if err := startRecoveryServer(t.Context(), cfg, testComposeFile(), marker, hbaTrust); err != nil {
+	if err := startRecoveryServer(t.Context(), cfg, testComposeFile(), marker.Container, hbaTrust); err != nil {
 		t.Fatalf("start the container a kill would have orphaned: %v", err)
 	}
 	t.Cleanup(func() {
@@ -617,7 +617,7 @@ func TestRecoveryResumesAfterAKillBetweenCommitAndKeyInstall(t *testing.T) {
 	if err != nil {
 		t.Fatalf("derive: %v", err)
 	}
-	if err := changeCredentialAndDropSecrets(t.Context(), cfg, testComposeFile(), marker, password); err != nil {
+	if err := changeCredentialAndDropSecrets(t.Context(), cfg, testComposeFile(), marker.Container, password); err != nil {
 		t.Fatalf("apply the credential change a kill would have left committed: %v", err)
 	}
 	leaveRecoveryContainer(t, cfg, marker)
@@ -650,7 +650,7 @@ func TestRecoveryResumesAfterAKillBetweenKeyInstallAndMarkerRemoval(t *testing.T
 	if err != nil {
 		t.Fatalf("derive: %v", err)
 	}
-	if err := changeCredentialAndDropSecrets(t.Context(), cfg, testComposeFile(), marker, password); err != nil {
+	if err := changeCredentialAndDropSecrets(t.Context(), cfg, testComposeFile(), marker.Container, password); err != nil {
 		t.Fatalf("apply the credential change: %v", err)
 	}
 	if err := installStagedKey(cfg, marker); err != nil {
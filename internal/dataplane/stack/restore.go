@@ -301,7 +301,7 @@ var ErrArchiveCarriesLifecycleState = errors.New("archive carries lifecycle stat
 //
 // None can be present in an archive this code produced: `backup` is refused
 // against a torn tree, against an outstanding verification debt, and against
-// an interrupted recovery, so a plane carrying any of them cannot be copied
+// an interrupted recovery or rotation, so a plane carrying any of them cannot be copied
 // in the first place. An archive holding one therefore came from somewhere
 // else — a hand-assembled tree, or a copy taken by other means — and what it
 // carries is another plane's in-flight state.
@@ -317,6 +317,7 @@ var archivedLifecycleMarkers = []string{
 	RestoreIncompleteMarker,
 	RestoreUnverifiedMarker,
 	RecoveryMarkerFile,
+	RotationMarkerFile,
 }
 
 // refuseArchivedLifecycleState rejects an archive carrying marker files.
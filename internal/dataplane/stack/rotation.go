@@ -0,0 +1,597 @@
+package stack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/internal/dataplane/paths"
+	"orchestrator/internal/dataplane/secret"
+	"orchestrator/internal/dataplane/store/postgres"
+)
+
+// Root-key rotation: replacing a WORKING key.
+//
+// Recovery (recovery.go) is for a plane whose key is gone, and it pays for
+// that with every secret. Rotation starts from a key that still opens
+// everything, so nothing has to be lost: every envelope is opened under the
+// old key and re-sealed under the new one, and the credentials the key
+// derives move with it.
+//
+// It borrows recovery's shape, because the hazards are the same ones:
+//
+//   - The new key is STAGED beside the live one and installed last, so an
+//     interrupted rotation leaves a plane that still opens on the key file
+//     it has.
+//   - A marker authorizes the resume and is validated against what this
+//     configuration would have written before any field of it is used.
+//   - The database credential changes through recovery's isolated,
+//     socket-only server, and whether it has already changed is decided by
+//     a PROBE against the cluster rather than by anything this process
+//     remembers.
+//
+// And it adds one thing recovery cannot have: a period in which BOTH keys
+// open envelopes. Installing the new key moves the old one aside as the
+// retiring key rather than deleting it, and every seam the stack opens
+// accepts it until `retire-key` sweeps once more and destroys it. Destroying
+// key material is the one step that cannot be undone, so it is the one step
+// an operator takes separately.
+
+// RotationMarkerFile records an in-flight rotation at the data root, beside
+// the recovery marker it is modelled on.
+const RotationMarkerFile = ".maestro-rotation-in-progress"
+
+// NextKeyFile is where a rotation stages the key it is moving to, under the
+// config root. It is deliberately not StagedKeyFile: the two operations are
+// refused against each other, and a file neither can mistake for the other's
+// keeps it that way after a crash.
+const NextKeyFile = "root.key.next"
+
+// RetiringKeyFile is the replaced key, held under the config root until
+// `retire-key` destroys it.
+const RetiringKeyFile = "root.key.retiring"
+
+// ErrRotationInterrupted reports a plane whose rotation did not finish.
+var ErrRotationInterrupted = errors.New("data plane holds an interrupted key rotation")
+
+// ErrRotationNotAuthorized reports a rotation attempt against a plane that
+// cannot start one.
+var ErrRotationNotAuthorized = errors.New("key rotation is not authorized for this plane")
+
+// ErrRotationIncoherent reports a marker whose key material is not where
+// the rotation's own protocol would have left it.
+var ErrRotationIncoherent = errors.New("rotation marker names key material that is not present")
+
+// ErrRotationForeignMarker reports a marker whose fields do not match what
+// this configuration would have written, for the reason
+// ErrRecoveryForeignMarker gives.
+var ErrRotationForeignMarker = errors.New("rotation marker does not belong to this data plane")
+
+// ErrUnreadableSecrets reports envelopes that open under neither key.
+//
+// They were unreadable before the rotation began — the pass opens every
+// envelope under the key that sealed it — so the rotation did not cause
+// this. It refuses anyway, because moving on would retire the only key that
+// could ever be tried against them again.
+var ErrUnreadableSecrets = errors.New("secrets open under neither root key")
+
+// ErrNothingToRetire reports a retirement with no retiring key to retire.
+var ErrNothingToRetire = errors.New("no retiring root key is held")
+
+// rotationMarker is the marker's content.
+// Field order is chosen for struct alignment, not reading order.
+type rotationMarker struct {
+	// StartedAt is operator-facing, as the recovery marker's is.
+	StartedAt time.Time `json:"started_at"`
+	// Container is the isolated server's name. It is RECOVERY'S name, on
+	// purpose: the two operations are refused against each other, so they
+	// can never both own one, and every path that already removes a
+	// surviving recovery container — `down`, `reset`, `restore` — removes a
+	// surviving rotation container without learning a second name.
+	Container string `json:"container"`
+	// NextKey is the absolute path of the staged key.
+	NextKey string `json:"next_key"`
+	// RetiringKey is where the live key moves when the next one is
+	// installed.
+	RetiringKey string `json:"retiring_key"`
+}
+
+// rotationKeys are the two keys one rotation moves between.
+type rotationKeys struct {
+	old  []byte
+	next []byte
+}
+
+func rotationMarkerPath(c *Config) string {
+	return filepath.Join(c.Roots.Data, RotationMarkerFile)
+}
+
+func nextKeyPath(c *Config) string {
+	return filepath.Join(c.Roots.Config, NextKeyFile)
+}
+
+func retiringKeyPath(c *Config) string {
+	return filepath.Join(c.Roots.Config, RetiringKeyFile)
+}
+
+// loadRetiringKey returns the retiring key, or nil when none is held.
+func loadRetiringKey(c *Config) ([]byte, error) {
+	key, err := paths.LoadKeyFile(retiringKeyPath(c))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read the retiring root key: %w", err)
+	}
+	return key, nil
+}
+
+// readRotationMarker returns the marker, or nil when none exists.
+//
+// Every field is checked against the value this configuration derives, as
+// readRecoveryMarker does and for its reason: the fields become paths this
+// code renames key material between, and a container name it force-removes.
+func readRotationMarker(c *Config) (*rotationMarker, error) {
+	body, err := os.ReadFile(rotationMarkerPath(c))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil //nolint:nilnil // absence is the ordinary case, not an error
+		}
+		return nil, fmt.Errorf("read %s: %w", RotationMarkerFile, err)
+	}
+	var marker rotationMarker
+	if err := json.Unmarshal(body, &marker); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", RotationMarkerFile, err)
+	}
+
+	for _, field := range []struct{ name, got, want string }{
+		{"container", marker.Container, recoveryContainerName(c)},
+		{"next key", marker.NextKey, nextKeyPath(c)},
+		{"retiring key", marker.RetiringKey, retiringKeyPath(c)},
+	} {
+		if field.got != field.want {
+			return nil, fmt.Errorf("%w: %s names %s %q, but this configuration's is %q. A marker "+
+				"this configuration did not write describes a rotation it did not start",
+				ErrRotationForeignMarker, RotationMarkerFile, field.name, field.got, field.want)
+		}
+	}
+	return &marker, nil
+}
+
+// writeRotationMarker records an in-flight rotation durably, AFTER the key
+// it names is fsynced.
+func writeRotationMarker(c *Config, marker rotationMarker) error {
+	body, err := json.MarshalIndent(marker, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode %s: %w", RotationMarkerFile, err)
+	}
+	if err := writeFileSynced(rotationMarkerPath(c), append(body, '\n')); err != nil {
+		return err
+	}
+	return syncDir(c.Roots.Data)
+}
+
+// RotateKey replaces a working plane's root key.
+//
+// Every secret is re-sealed under the new key, and the credentials the key
+// derives — the Postgres password and the object-store credentials — move
+// with it. The sequence, each step resumable:
+//
+//  1. Stage the new key and the marker naming it.
+//  2. Stop the plane, and probe through the isolated server whether the
+//     database credential already answers to the new key. If it does, an
+//     earlier attempt finished steps 3 and 4, and they are skipped.
+//  3. Start the plane on the OLD key and re-seal every envelope. Rows an
+//     earlier attempt moved already open under the new key and are left
+//     alone.
+//  4. Stop the plane and change the credential through the isolated server.
+//  5. Install: the live key becomes the retiring key, the staged key the
+//     live one.
+//  6. Start the plane on the new key, prove the credential over the network,
+//     and clear the marker last.
+//
+// The object store needs no step: its credentials are environment, so step
+// 6's restart moves them, exactly as recovery measured.
+func RotateKey(ctx context.Context, c *Config, composeFile string) (_ postgres.RekeyReport, err error) {
+	release, lockErr := lockLifecycle(c)
+	if lockErr != nil {
+		return postgres.RekeyReport{}, lockErr
+	}
+	defer func() {
+		if relErr := release(); relErr != nil && err == nil {
+			err = relErr
+		}
+	}()
+
+	if guardErr := guardRestoreState(c, lifecycleRotateKey); guardErr != nil {
+		return postgres.RekeyReport{}, guardErr
+	}
+
+	marker, markerErr := readRotationMarker(c)
+	if markerErr != nil {
+		return postgres.RekeyReport{}, markerErr
+	}
+	var live []byte
+	if marker == nil {
+		authorized, authErr := authorizeRotation(c)
+		if authErr != nil {
+			return postgres.RekeyReport{}, authErr
+		}
+		live = authorized
+	}
+	keys, marker, stageErr := stageRotation(c, marker, live)
+	if stageErr != nil {
+		return postgres.RekeyReport{}, stageErr
+	}
+
+	report, moveErr := moveCredential(ctx, c, composeFile, marker, keys)
+	if moveErr != nil {
+		return report, moveErr
+	}
+	return report, finishRotation(ctx, c, composeFile, marker)
+}
+
+// moveCredential is steps 2 to 4: re-seal the vault and change the database
+// credential, or establish that an earlier attempt already did both.
+func moveCredential(
+	ctx context.Context, c *Config, composeFile string, marker *rotationMarker, keys rotationKeys,
+) (postgres.RekeyReport, error) {
+	newPassword, err := secret.Derive(keys.next, secret.ContextPostgresPassword)
+	if err != nil {
+		return postgres.RekeyReport{}, fmt.Errorf("derive the new database password: %w", err)
+	}
+
+	// Stopped before the probe: the isolated server opens the same PGDATA
+	// as the Compose Postgres, so the two must never run together.
+	if err := stopForIsolatedServer(ctx, c, composeFile, marker.Container); err != nil {
+		return postgres.RekeyReport{}, err
+	}
+	applied, err := probeRecoveredPassword(ctx, c, composeFile, marker.Container, newPassword)
+	if err != nil {
+		return postgres.RekeyReport{}, err
+	}
+	if applied {
+		// The credential moves only after the vault has, so a credential
+		// already on the new key means the re-seal finished too.
+		return postgres.RekeyReport{}, nil
+	}
+
+	report, err := resealVault(ctx, c, composeFile, keys)
+	if err != nil {
+		return report, err
+	}
+
+	if err := stopForIsolatedServer(ctx, c, composeFile, marker.Container); err != nil {
+		return report, err
+	}
+	// ALTER USER alone: unlike recovery, nothing in the vault is dropped —
+	// every envelope is already readable under the key this password is
+	// derived from.
+	statement := fmt.Sprintf("ALTER USER %s PASSWORD $maestro$%s$maestro$;",
+		quoteIdentifier(c.User), newPassword)
+	if err := runIsolatedStatement(ctx, c, composeFile, marker.Container, statement); err != nil {
+		return report, fmt.Errorf("move the database credential to the new key: %w", err)
+	}
+	return report, nil
+}
+
+// resealVault is step 3: the plane runs on the OLD key, and every envelope
+// is moved to the new one.
+//
+// The old key is still the live key file here — it is installed over only
+// after the credential moves — so `up` starts the plane exactly as it
+// always has.
+func resealVault(ctx context.Context, c *Config, composeFile string, keys rotationKeys) (postgres.RekeyReport, error) {
+	if err := up(ctx, c, composeFile); err != nil {
+		return postgres.RekeyReport{}, fmt.Errorf("start the plane on its current key to re-seal it: %w", err)
+	}
+	seam, err := openPlaneStore(ctx, c, keys.old)
+	if err != nil {
+		return postgres.RekeyReport{}, err
+	}
+	defer seam.Close()
+
+	report, err := seam.RekeySecrets(ctx, keys.old, keys.next)
+	if err != nil {
+		return report, fmt.Errorf("re-seal the vault under the new key: %w", err)
+	}
+	if err := refuseUnreadable(report, "dataplane-rotate-key"); err != nil {
+		return report, err
+	}
+	return report, nil
+}
+
+// refuseUnreadable turns unreadable rows into a refusal that names them.
+func refuseUnreadable(report postgres.RekeyReport, rerun string) error {
+	if len(report.Unreadable) == 0 {
+		return nil
+	}
+	named := make([]string, 0, maxEvidencePaths)
+	for _, id := range report.Unreadable {
+		if len(named) == maxEvidencePaths {
+			break
+		}
+		named = append(named, id.String())
+	}
+	return fmt.Errorf("%w: %d secret(s), including %s. They were unreadable before this pass began; "+
+		"delete or replace them, then re-run `%s`, which resumes where this left off",
+		ErrUnreadableSecrets, len(report.Unreadable), strings.Join(named, ", "), rerun)
+}
+
+// stopForIsolatedServer stops the Compose project and removes any isolated
+// server that survived an earlier attempt, so the next step owns PGDATA.
+func stopForIsolatedServer(ctx context.Context, c *Config, composeFile, container string) error {
+	env, err := c.composeEnv(placeholderKey())
+	if err != nil {
+		return err
+	}
+	if err := composeStop(ctx, c.ProjectName, composeFile, env); err != nil {
+		return err
+	}
+	return removeRecoveryContainer(ctx, container)
+}
+
+// finishRotation is steps 5 and 6.
+func finishRotation(ctx context.Context, c *Config, composeFile string, marker *rotationMarker) error {
+	// Checked, not assumed, for the reason RecoverKey checks it: the next
+	// thing here starts the Compose Postgres over the same PGDATA.
+	if gone, err := recoveryContainerGone(ctx, marker.Container); err != nil {
+		return err
+	} else if !gone {
+		return fmt.Errorf("the isolated server %s is still running: starting the plane now would "+
+			"put two postmasters over one cluster. Remove it and re-run; the rotation is resumable",
+			marker.Container)
+	}
+
+	if err := installRotatedKey(c, marker); err != nil {
+		return err
+	}
+	if err := up(ctx, c, composeFile); err != nil {
+		return fmt.Errorf("bring the plane up on its new key: %w", err)
+	}
+	// Over the network, for recovery's reason: an in-container check
+	// accepts any password.
+	if err := verifyNewCredential(ctx, c, composeFile); err != nil {
+		return err
+	}
+
+	// LAST, so a plane not yet proven on its new key still has a resume.
+	if err := os.Remove(rotationMarkerPath(c)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("clear %s: %w", RotationMarkerFile, err)
+	}
+	return syncDir(c.Roots.Data)
+}
+
+// authorizeRotation decides whether a NEW rotation may start, and returns
+// the live key it will rotate away from.
+//
+// Entry only: a resume is authorized by its marker, and must proceed even
+// when a retiring key exists, because it put it there.
+func authorizeRotation(c *Config) ([]byte, error) {
+	if err := requireKeyFile(c, lifecycleRotateKey, ErrRotationNotAuthorized); err != nil {
+		return nil, err
+	}
+	live, err := rootKeyFor(c, lifecycleRotateKey)
+	if err != nil {
+		if errors.Is(err, ErrPlaneLocked) {
+			return nil, fmt.Errorf("%w: its root-of-trust key is missing, so there is nothing to "+
+				"rotate FROM. Restore the key, or run `dataplane-recover-key`: %w",
+				ErrRotationNotAuthorized, err)
+		}
+		return nil, err
+	}
+
+	// At most two keys, ever. A third would need OpenWith to try keys in an
+	// order nothing records, and it would mean an earlier rotation's
+	// retirement — the step that proves every envelope moved — never ran.
+	retiring, err := loadRetiringKey(c)
+	if err != nil {
+		return nil, err
+	}
+	if retiring != nil {
+		return nil, fmt.Errorf("%w: %s still holds the key an earlier rotation replaced. Run "+
+			"`dataplane-retire-key` to finish that rotation before starting another",
+			ErrRotationNotAuthorized, retiringKeyPath(c))
+	}
+	return live, nil
+}
+
+// stageRotation mints and records the next key, or recovers both keys from
+// what an earlier attempt left.
+//
+// The states a resume can find are exactly the ones the install order can
+// produce, and each names its own keys:
+//
+//   - next and live present: nothing installed; live is the old key.
+//   - next and retiring present, live absent: killed between the two
+//     renames; retiring is the old key.
+//   - live and retiring present, next absent: installed; live is the new
+//     key and retiring the old.
+//
+// Anything else is refused, because it is not a state this protocol makes.
+func stageRotation(c *Config, existing *rotationMarker, live []byte) (rotationKeys, *rotationMarker, error) {
+	if existing != nil {
+		keys, err := resumeRotationKeys(c, existing)
+		if err != nil {
+			return rotationKeys{}, nil, err
+		}
+		return keys, existing, nil
+	}
+
+	// A staged key with NO marker is debris from an attempt that died
+	// between the two writes, as recovery's is, and goes the same way.
+	if err := os.Remove(nextKeyPath(c)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return rotationKeys{}, nil, fmt.Errorf("remove an orphaned staged rotation key: %w", err)
+	}
+
+	next, err := paths.NewKeyMaterial()
+	if err != nil {
+		return rotationKeys{}, nil, fmt.Errorf("mint the rotation key: %w", err)
+	}
+	// Key first, then the marker that names it.
+	if err := writeFileSynced(nextKeyPath(c), paths.EncodeKey(next)); err != nil {
+		return rotationKeys{}, nil, err
+	}
+	if err := syncDir(c.Roots.Config); err != nil {
+		return rotationKeys{}, nil, err
+	}
+	marker := &rotationMarker{
+		Container:   recoveryContainerName(c),
+		NextKey:     nextKeyPath(c),
+		RetiringKey: retiringKeyPath(c),
+		StartedAt:   time.Now().UTC(),
+	}
+	if err := writeRotationMarker(c, *marker); err != nil {
+		return rotationKeys{}, nil, err
+	}
+	return rotationKeys{old: live, next: next}, marker, nil
+}
+
+// resumeRotationKeys reads the two keys back from the state an interrupted
+// rotation left.
+func resumeRotationKeys(c *Config, marker *rotationMarker) (rotationKeys, error) {
+	load := func(path string) ([]byte, bool, error) {
+		key, err := paths.LoadKeyFile(path)
+		if err == nil {
+			return key, true, nil
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	next, haveNext, err := load(marker.NextKey)
+	if err != nil {
+		return rotationKeys{}, err
+	}
+	live, haveLive, err := load(c.Roots.KeyPath())
+	if err != nil {
+		return rotationKeys{}, err
+	}
+	retiring, haveRetiring, err := load(marker.RetiringKey)
+	if err != nil {
+		return rotationKeys{}, err
+	}
+
+	switch {
+	case haveNext && haveLive:
+		return rotationKeys{old: live, next: next}, nil
+	case haveNext && haveRetiring:
+		return rotationKeys{old: retiring, next: next}, nil
+	case haveLive && haveRetiring:
+		return rotationKeys{old: retiring, next: live}, nil
+	default:
+		return rotationKeys{}, fmt.Errorf("%w: of %s, %s and %s, only the combination (next %t, live %t, "+
+			"retiring %t) is present, which no step of a rotation leaves. Restore the missing key from "+
+			"wherever it was kept, or restore the plane from a backup",
+			ErrRotationIncoherent, marker.NextKey, c.Roots.KeyPath(), marker.RetiringKey,
+			haveNext, haveLive, haveRetiring)
+	}
+}
+
+// installRotatedKey is step 5: the live key moves aside as the retiring key,
+// then the staged key becomes the live one.
+//
+// Two renames, and the order is what makes the window between them safe: a
+// kill after the first leaves no live key at all, which is the one state
+// resumeRotationKeys reads as "retiring is old, next is new" — never as a
+// locked plane, because the marker is still present and the guard refuses
+// every verb but the resume.
+func installRotatedKey(c *Config, marker *rotationMarker) error {
+	if _, err := os.Stat(marker.NextKey); errors.Is(err, os.ErrNotExist) {
+		// Both renames already happened.
+		return nil
+	}
+	if _, err := os.Stat(c.Roots.KeyPath()); err == nil {
+		if err := os.Rename(c.Roots.KeyPath(), marker.RetiringKey); err != nil {
+			return fmt.Errorf("move the replaced key aside: %w", err)
+		}
+		if err := syncDir(c.Roots.Config); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(marker.NextKey, c.Roots.KeyPath()); err != nil {
+		return fmt.Errorf("install the rotated key: %w", err)
+	}
+	return syncDir(c.Roots.Config)
+}
+
+// clearRotationState deletes an interrupted rotation's durable artifacts:
+// the staged key and the marker. The retiring key is NOT touched — it may
+// be the only key some envelope opens under, and retiring it is
+// `retire-key`'s decision alone.
+func clearRotationState(c *Config) error {
+	if err := os.Remove(nextKeyPath(c)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove the staged rotation key: %w", err)
+	}
+	if err := syncDir(c.Roots.Config); err != nil {
+		return err
+	}
+	if err := os.Remove(rotationMarkerPath(c)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove %s: %w", RotationMarkerFile, err)
+	}
+	return syncDir(c.Roots.Data)
+}
+
+// RetireKey ends a rotation's dual-key period: it re-seals any envelope
+// still under the retiring key, and then destroys that key.
+//
+// It sweeps again rather than trusting the rotation's pass, because that
+// pass is the claim this verb exists to check before something irreversible
+// happens. A plane that rotated cleanly reports nothing moved.
+//
+// It does not start the plane. Like `verify`, it acts on a running one, and
+// an operator retiring a key should be looking at the plane they are about
+// to commit to.
+func RetireKey(ctx context.Context, c *Config) (_ postgres.RekeyReport, err error) {
+	release, lockErr := lockLifecycle(c)
+	if lockErr != nil {
+		return postgres.RekeyReport{}, lockErr
+	}
+	defer func() {
+		if relErr := release(); relErr != nil && err == nil {
+			err = relErr
+		}
+	}()
+
+	if guardErr := guardRestoreState(c, lifecycleRetireKey); guardErr != nil {
+		return postgres.RekeyReport{}, guardErr
+	}
+
+	retiring, err := loadRetiringKey(c)
+	if err != nil {
+		return postgres.RekeyReport{}, err
+	}
+	if retiring == nil {
+		return postgres.RekeyReport{}, fmt.Errorf("%w at %s: there is no rotation to finish",
+			ErrNothingToRetire, retiringKeyPath(c))
+	}
+	live, err := rootKeyFor(c, lifecycleRetireKey)
+	if err != nil {
+		return postgres.RekeyReport{}, err
+	}
+
+	seam, err := openPlaneStore(ctx, c, live)
+	if err != nil {
+		return postgres.RekeyReport{}, err
+	}
+	defer seam.Close()
+
+	report, err := seam.RekeySecrets(ctx, retiring, live)
+	if err != nil {
+		return report, fmt.Errorf("sweep the vault for envelopes still under the retiring key: %w", err)
+	}
+	if err := refuseUnreadable(report, "dataplane-retire-key"); err != nil {
+		return report, err
+	}
+
+	if err := os.Remove(retiringKeyPath(c)); err != nil {
+		return report, fmt.Errorf("destroy the retiring key: %w", err)
+	}
+	return report, syncDir(c.Roots.Config)
+}
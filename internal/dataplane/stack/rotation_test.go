@@ -0,0 +1,256 @@
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/paths"
+	"orchestrator/internal/dataplane/secret"
+)
+
+// rotatingPlane is a provisioned plane holding a live key, which is the
+// only kind a rotation may start from.
+func rotatingPlane(t *testing.T) (*Config, []byte) {
+	t.Helper()
+	cfg := planeAt(t)
+	live, err := rootKeyFor(cfg, lifecycleUp)
+	if err != nil {
+		t.Fatalf("mint the live key: %v", err)
+	}
+	populate(t, cfg, paths.ServicePostgres)
+	return cfg, live
+}
+
+// The interrupted-rotation marker is a fourth state with a fourth table,
+// and it gets the completeness guarantee the other three have.
+func TestEveryLifecycleHasARotationPolicy(t *testing.T) {
+	for _, operation := range lifecycles {
+		if _, defined := rotationPermits[operation]; !defined {
+			t.Errorf("%s has no entry in rotationPermits: decide whether it may run against a plane "+
+				"whose key material is half-replaced", operation)
+		}
+	}
+	if len(rotationPermits) != len(lifecycles) {
+		t.Errorf("rotationPermits has %d entries for %d operations: one of them names something "+
+			"that is not an operation", len(rotationPermits), len(lifecycles))
+	}
+}
+
+// The gate. `retire-key` is the refusal that matters most: mid-rotation it
+// would destroy the only key the envelopes not yet re-sealed open under.
+func TestRotationMarkerGatesEveryOperation(t *testing.T) {
+	permitted := map[lifecycle]bool{
+		lifecycleRotateKey: true, lifecycleDown: true,
+		lifecycleReset: true, lifecycleRestore: true,
+	}
+
+	for _, operation := range lifecycles {
+		t.Run(operation.String(), func(t *testing.T) {
+			cfg := planeAt(t)
+			if err := writeRotationMarker(cfg, rotationMarker{
+				Container:   recoveryContainerName(cfg),
+				NextKey:     nextKeyPath(cfg),
+				RetiringKey: retiringKeyPath(cfg),
+			}); err != nil {
+				t.Fatalf("writeRotationMarker: %v", err)
+			}
+
+			err := guardRestoreState(cfg, operation)
+			switch {
+			case permitted[operation] && err != nil:
+				t.Errorf("%s refused against an interrupted rotation, but it is one of the ways "+
+					"out of one: %v", operation, err)
+			case !permitted[operation] && !errors.Is(err, ErrRotationInterrupted):
+				t.Errorf("%s was allowed to act on a half-rotated plane (err = %v)", operation, err)
+			}
+		})
+	}
+}
+
+// Every field of the marker becomes a path this code renames key material
+// between, or a container it removes, so each is checked.
+func TestForeignRotationMarkerIsRefused(t *testing.T) {
+	for name, mutate := range map[string]func(*rotationMarker){
+		"container":    func(m *rotationMarker) { m.Container = "someone-elses-postgres" },
+		"next key":     func(m *rotationMarker) { m.NextKey = "/etc/passwd" },
+		"retiring key": func(m *rotationMarker) { m.RetiringKey = "/tmp/root.key" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := planeAt(t)
+			marker := rotationMarker{
+				Container:   recoveryContainerName(cfg),
+				NextKey:     nextKeyPath(cfg),
+				RetiringKey: retiringKeyPath(cfg),
+			}
+			mutate(&marker)
+			if err := writeRotationMarker(cfg, marker); err != nil {
+				t.Fatalf("writeRotationMarker: %v", err)
+			}
+			if _, err := readRotationMarker(cfg); !errors.Is(err, ErrRotationForeignMarker) {
+				t.Fatalf("a marker with a foreign %s was accepted (err = %v)", name, err)
+			}
+		})
+	}
+}
+
+// A fresh rotation replaces an orphaned staged key rather than adopting it,
+// and writes the marker only once the key it names is on disk.
+func TestFreshRotationStagesANewKey(t *testing.T) {
+	cfg, live := rotatingPlane(t)
+	orphan := bytes.Repeat([]byte{0x42}, paths.RootKeyLen)
+	if err := os.WriteFile(nextKeyPath(cfg), paths.EncodeKey(orphan), 0o600); err != nil {
+		t.Fatalf("plant an orphaned staged key: %v", err)
+	}
+
+	keys, marker, err := stageRotation(cfg, nil, live)
+	if err != nil {
+		t.Fatalf("stageRotation: %v", err)
+	}
+	if !bytes.Equal(keys.old, live) {
+		t.Fatal("a fresh rotation is not rotating away from the live key")
+	}
+	if bytes.Equal(keys.next, orphan) || bytes.Equal(keys.next, live) {
+		t.Fatal("a fresh rotation adopted key material it did not mint")
+	}
+	staged, err := paths.LoadKeyFile(nextKeyPath(cfg))
+	if err != nil || !bytes.Equal(staged, keys.next) {
+		t.Fatalf("the staged key on disk is not the one the rotation holds (err = %v)", err)
+	}
+	onDisk, err := readRotationMarker(cfg)
+	if err != nil || onDisk == nil || *onDisk != *marker {
+		t.Fatalf("the marker on disk is %+v (err = %v), want %+v", onDisk, err, marker)
+	}
+}
+
+// At most two keys: a second rotation waits for the first one's retirement.
+func TestRotationRefusesWhileAKeyIsRetiring(t *testing.T) {
+	cfg, _ := rotatingPlane(t)
+	retiring := bytes.Repeat([]byte{0x17}, paths.RootKeyLen)
+	if err := os.WriteFile(retiringKeyPath(cfg), paths.EncodeKey(retiring), 0o600); err != nil {
+		t.Fatalf("write the retiring key: %v", err)
+	}
+	if _, err := authorizeRotation(cfg); !errors.Is(err, ErrRotationNotAuthorized) {
+		t.Fatalf("a rotation started while an earlier one's key was still retiring (err = %v)", err)
+	}
+}
+
+// A locked plane has nothing to rotate from; recovery is the verb for it.
+func TestRotationRefusesALockedPlane(t *testing.T) {
+	cfg, _ := rotatingPlane(t)
+	if err := os.Remove(cfg.Roots.KeyPath()); err != nil {
+		t.Fatalf("lose the key: %v", err)
+	}
+	if _, err := authorizeRotation(cfg); !errors.Is(err, ErrRotationNotAuthorized) {
+		t.Fatalf("a rotation started on a plane with no key (err = %v)", err)
+	}
+}
+
+// Every state the install can be killed in names the same two keys, and a
+// state it cannot produce is refused rather than guessed at.
+func TestResumeRecoversBothKeysFromEveryInstallState(t *testing.T) {
+	cfg, live := rotatingPlane(t)
+	keys, marker, err := stageRotation(cfg, nil, live)
+	if err != nil {
+		t.Fatalf("stageRotation: %v", err)
+	}
+	check := func(state string) {
+		t.Helper()
+		resumed, resumeErr := resumeRotationKeys(cfg, marker)
+		if resumeErr != nil {
+			t.Fatalf("%s: %v", state, resumeErr)
+		}
+		if !bytes.Equal(resumed.old, keys.old) || !bytes.Equal(resumed.next, keys.next) {
+			t.Fatalf("%s: the resume swapped or lost a key", state)
+		}
+	}
+
+	check("nothing installed")
+
+	// Killed between the two renames: no live key at all.
+	if err := os.Rename(cfg.Roots.KeyPath(), marker.RetiringKey); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	check("between the renames")
+
+	if err := installRotatedKey(cfg, marker); err != nil {
+		t.Fatalf("installRotatedKey: %v", err)
+	}
+	check("installed")
+	if err := installRotatedKey(cfg, marker); err != nil {
+		t.Fatalf("a second install of an installed key failed: %v", err)
+	}
+	check("installed twice")
+
+	if err := os.Remove(marker.RetiringKey); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := resumeRotationKeys(cfg, marker); !errors.Is(err, ErrRotationIncoherent) {
+		t.Fatalf("a resume with one key left guessed which it was (err = %v)", err)
+	}
+}
+
+// The dual-key period, at the one place every seam gets its key from.
+func TestRetiringKeyStillOpensThroughTheStack(t *testing.T) {
+	cfg, live := rotatingPlane(t)
+	retiring := bytes.Repeat([]byte{0x17}, paths.RootKeyLen)
+	binding := secret.Binding{
+		Name:           "forge-token",
+		ScopeType:      "organization",
+		OrganizationID: uuid.New(),
+		SecretID:       uuid.New(),
+		ScopeID:        uuid.New(),
+		Version:        1,
+	}
+	envelope, err := secret.Seal(retiring, binding, []byte("not yet re-sealed"))
+	if err != nil {
+		t.Fatalf("Seal: %v", err)
+	}
+
+	before, err := resolvedRootKey(cfg, live)
+	if err != nil {
+		t.Fatalf("resolvedRootKey: %v", err)
+	}
+	if _, err := secret.OpenWith(before, binding, envelope); !errors.Is(err, secret.ErrDecrypt) {
+		t.Fatalf("an envelope under a key the plane does not hold opened (err = %v)", err)
+	}
+
+	if err := os.WriteFile(retiringKeyPath(cfg), paths.EncodeKey(retiring), 0o600); err != nil {
+		t.Fatalf("write the retiring key: %v", err)
+	}
+	during, err := resolvedRootKey(cfg, live)
+	if err != nil {
+		t.Fatalf("resolvedRootKey: %v", err)
+	}
+	if _, err := secret.OpenWith(during, binding, envelope); err != nil {
+		t.Fatalf("an envelope under the retiring key did not open during the dual-key period: %v", err)
+	}
+}
+
+// `reset` and `restore` clear an interrupted rotation with the recovery's
+// state, and leave a retiring key alone: destroying key material is
+// retire-key's decision.
+func TestClearRecoveryStateClearsAnInterruptedRotation(t *testing.T) {
+	cfg, live := rotatingPlane(t)
+	if _, _, err := stageRotation(cfg, nil, live); err != nil {
+		t.Fatalf("stageRotation: %v", err)
+	}
+	if err := os.WriteFile(retiringKeyPath(cfg), paths.EncodeKey(live), 0o600); err != nil {
+		t.Fatalf("write the retiring key: %v", err)
+	}
+
+	if err := clearRecoveryState(cfg); err != nil {
+		t.Fatalf("clearRecoveryState: %v", err)
+	}
+	for _, path := range []string{rotationMarkerPath(cfg), nextKeyPath(cfg)} {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s survived (err = %v)", path, err)
+		}
+	}
+	if _, err := os.Stat(retiringKeyPath(cfg)); err != nil {
+		t.Errorf("the retiring key was destroyed by a clear: %v", err)
+	}
+}
@@ -95,7 +95,7 @@ func OpenSeam(ctx context.Context, c *Config, types *registry.Registry) (_ store
 	// The key this function already resolved, wrapped — never a second
 	// KeyFile, which would remake the create-versus-load decision outside
 	// rootKeyFor.
-	keyProvider, err := resolvedRootKey(rootKey)
+	keyProvider, err := resolvedRootKey(c, rootKey)
 	if err != nil {
 		return nil, err
 	}
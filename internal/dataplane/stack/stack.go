@@ -96,7 +96,17 @@ func up(ctx context.Context, c *Config, composeFile string) (err error) {
 	if keyErr != nil {
 		return keyErr
 	}
-	if bootErr := paths.WriteBootstrap(c.Roots.Config, c.Bootstrap()); bootErr != nil {
+	// The pointer is rewritten from this config, but its root of trust is
+	// CARRIED: it is the operator's choice of where the key lives, and an
+	// `up` that reset it to the key file would strand a keyring-held key
+	// behind a pointer that no longer names it.
+	trust, trustErr := rootOfTrust(c)
+	if trustErr != nil {
+		return trustErr
+	}
+	pointer := c.Bootstrap()
+	pointer.RootOfTrust = trust
+	if bootErr := paths.WriteBootstrap(c.Roots.Config, pointer); bootErr != nil {
 		return fmt.Errorf("write bootstrap pointer: %w", bootErr)
 	}
 
@@ -231,6 +241,11 @@ const (
 	lifecycleVerify
 	lifecycleReset
 	lifecycleRecoverKey
+	// lifecycleRotateKey replaces a WORKING key, and lifecycleRetireKey ends
+	// the period after it in which the replaced key still opens envelopes.
+	// Neither is recovery: both start from a plane whose key is present.
+	lifecycleRotateKey
+	lifecycleRetireKey
 	// lifecycleUse is ordinary use of a running plane — importing, reading
 	// back, provisioning a tenant. It moves the plane between no states at
 	// all, and it is in this enumeration only because it needs the root key
@@ -246,7 +261,7 @@ const (
 var lifecycles = []lifecycle{
 	lifecycleUp, lifecycleMigrate, lifecycleForceVersion,
 	lifecycleDown, lifecycleBackup, lifecycleRestore, lifecycleVerify, lifecycleReset,
-	lifecycleRecoverKey, lifecycleUse,
+	lifecycleRecoverKey, lifecycleRotateKey, lifecycleRetireKey, lifecycleUse,
 }
 
 func (l lifecycle) String() string {
@@ -269,6 +284,10 @@ func (l lifecycle) String() string {
 		return "reset"
 	case lifecycleRecoverKey:
 		return "recover-key"
+	case lifecycleRotateKey:
+		return "rotate-key"
+	case lifecycleRetireKey:
+		return "retire-key"
 	case lifecycleUse:
 		return "use"
 	default:
@@ -386,6 +405,8 @@ var markerPermits = map[lifecycle]bool{
 	lifecycleBackup:       false,
 	lifecycleVerify:       false,
 	lifecycleRecoverKey:   false,
+	lifecycleRotateKey:    false,
+	lifecycleRetireKey:    false,
 	// Use is refused for the plainest reason of all: a torn restore is a
 	// data root that is not a plane, and writing into one adds records to
 	// a store whose contents nobody can account for.
@@ -487,6 +508,10 @@ var unverifiedPermits = map[lifecycle]bool{
 	lifecycleMigrate:      false,
 	lifecycleForceVersion: false,
 	lifecycleBackup:       false,
+	// Re-sealing unverified contents would vouch for them under a new key,
+	// and the pass owed would then be checking what the rotation wrote.
+	lifecycleRotateKey: false,
+	lifecycleRetireKey: false,
 	// A plane owing a verification pass has contents that have never been
 	// checked. Importing into it would interleave new records with
 	// unproven ones, and the pass that eventually runs could no longer say
@@ -555,6 +580,10 @@ var recoveryPermits = map[lifecycle]bool{
 	lifecycleMigrate:      false,
 	lifecycleForceVersion: false,
 	lifecycleVerify:       false,
+	// A rotation starts from a key that opens the plane, which is exactly
+	// what an interrupted recovery cannot yet promise.
+	lifecycleRotateKey: false,
+	lifecycleRetireKey: false,
 	// An interrupted recovery leaves a plane whose key material is
 	// half-replaced. Nothing may write to it until recovery finishes or is
 	// abandoned.
@@ -584,10 +613,68 @@ func guardRecoveryMarker(c *Config, operation lifecycle) error {
 		ErrRecoveryInterrupted, operation, recoveryMarkerPath(c))
 }
 
-// guardRestoreState applies BOTH marker policies, and is what every
+// rotationPermits is the fourth policy: may this operation run against a
+// plane whose key rotation was interrupted?
+//
+// The hazard is the recovery table's, in milder form. A rotation moves the
+// vault and the database credential from one key to another, and until it
+// finishes the plane's key material is half-replaced: envelopes may be under
+// either key, and the credential may already answer only to the new one
+// while the key file still holds the old. The isolated server it changes
+// the credential through has recovery's identity, and can outlive the
+// process the same way.
+//
+// The escapes are recovery's, for recovery's reasons: the resume, `down`,
+// and the two that discard or replace the plane. Both of those clear the
+// rotation's durable state with the recovery's, in clearRecoveryState.
+//
+//nolint:gochecknoglobals // Immutable policy table.
+var rotationPermits = map[lifecycle]bool{
+	// The resume itself.
+	lifecycleRotateKey: true,
+	lifecycleDown:      true,
+	lifecycleReset:     true,
+	lifecycleRestore:   true,
+
+	lifecycleUp:           false,
+	lifecycleBackup:       false,
+	lifecycleMigrate:      false,
+	lifecycleForceVersion: false,
+	lifecycleVerify:       false,
+	lifecycleRecoverKey:   false,
+	// Retiring a key mid-rotation would destroy the only key some
+	// envelopes are still sealed under.
+	lifecycleRetireKey: false,
+	lifecycleUse:       false,
+}
+
+// guardRotationMarker refuses an operation that must not act on a plane
+// whose rotation was interrupted.
+func guardRotationMarker(c *Config, operation lifecycle) error {
+	permitted, known := rotationPermits[operation]
+	if !known {
+		return fmt.Errorf("%w: no interrupted-rotation policy is defined for %s",
+			ErrRotationInterrupted, operation)
+	}
+	if permitted {
+		return nil
+	}
+	if _, err := os.Stat(rotationMarkerPath(c)); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("check for %s: %w", RotationMarkerFile, err)
+	}
+	return fmt.Errorf("%w, so %s must not run against it (%s). Its secrets and database credential "+
+		"may be under either key. Re-run `dataplane-rotate-key` to finish it, or `dataplane-reset` "+
+		"to discard the plane",
+		ErrRotationInterrupted, operation, rotationMarkerPath(c))
+}
+
+// guardRestoreState applies EVERY marker policy, and is what every
 // lifecycle verb calls.
 //
-// One entry point rather than three calls per verb: the markers describe
+// One entry point rather than four calls per verb: the markers describe
 // different states with different tables, and a caller that consulted one
 // and forgot another would be guarded against the failure it remembered.
 // A verb cannot opt into part of this by omission — which is exactly how
@@ -600,7 +687,10 @@ func guardRestoreState(c *Config, operation lifecycle) error {
 	if err := guardUnverifiedMarker(c, operation); err != nil {
 		return err
 	}
-	return guardRecoveryMarker(c, operation)
+	if err := guardRecoveryMarker(c, operation); err != nil {
+		return err
+	}
+	return guardRotationMarker(c, operation)
 }
 
 // writeRestoreMarker durably records that a restore is about to delete.
@@ -721,7 +811,15 @@ func rootKeyFor(c *Config, operation lifecycle) ([]byte, error) {
 		access = secret.MayCreate
 	}
 
-	key, keyErr := secret.KeyFile(c.Roots.Config, access).RootKey()
+	trust, err := rootOfTrust(c)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := secret.FromRootOfTrust(trust, c.Roots.Config, access)
+	if err != nil {
+		return nil, fmt.Errorf("select root-of-trust backend for %s: %w", operation, err)
+	}
+	key, keyErr := provider.RootKey()
 	if keyErr == nil {
 		return key, nil
 	}
@@ -743,11 +841,53 @@ func rootKeyFor(c *Config, operation lifecycle) ([]byte, error) {
 	// a place for a future writer's data to be silently ignored.
 	return nil, fmt.Errorf("%w (%s). Its Postgres password and object-store credentials are "+
 		"derived from the original key, so a new one would open neither. Restore the key file "+
-		"beside the backup, or run the new-key recovery path. The data root is judged non-fresh "+
+		"beside the backup (or the item the bootstrap pointer names, if the key lives elsewhere), "+
+		"or run the new-key recovery path. The data root is judged non-fresh "+
 		"because of: %s: %w",
 		ErrPlaneLocked, operation, strings.Join(evidence, ", "), wrapped)
 }
 
+// rootOfTrust is where this plane's root key lives: the bootstrap pointer's
+// choice when there is a pointer, and the key file under the config root
+// when there is not, which is every plane before its first `up`.
+//
+// A pointer that exists and does not parse is REFUSED rather than treated as
+// absent. It is hand-edited by design, and falling back to the key file
+// because an edit broke the JSON would mint a key file beside a keyring item
+// the operator believes is in use.
+func rootOfTrust(c *Config) (paths.RootOfTrust, error) {
+	pointer, err := paths.ReadBootstrap(c.Roots.Config)
+	switch {
+	case err == nil:
+		return pointer.RootOfTrust, nil
+	case errors.Is(err, os.ErrNotExist):
+		return c.Bootstrap().RootOfTrust, nil
+	default:
+		return paths.RootOfTrust{}, err
+	}
+}
+
+// requireKeyFile refuses an operation that installs a key FILE on a plane
+// whose pointer keeps the key somewhere else.
+//
+// Recovery and rotation both finish by renaming a staged key onto the key
+// file's path. Under another backend that file is read by nothing: the plane
+// would come back up on the keyring's key, which no longer opens anything
+// the operation re-sealed. Moving those operations to each backend is a
+// separate change; until then they say so rather than half-work.
+func requireKeyFile(c *Config, operation lifecycle, refusal error) error {
+	trust, err := rootOfTrust(c)
+	if err != nil {
+		return err
+	}
+	if trust.Kind != paths.RootOfTrustKeyFile {
+		return fmt.Errorf("%w: the bootstrap pointer keeps this plane's root key in %s, and %s "+
+			"installs its new key as a key file. Move the key back to the key file first",
+			refusal, trust.Kind, operation)
+	}
+	return nil
+}
+
 // resolvedRootKey wraps material rootKeyFor produced, naming the backend that
 // actually produced it.
 //
@@ -759,16 +899,37 @@ func rootKeyFor(c *Config, operation lifecycle) ([]byte, error) {
 // That is the same defect the parameter was introduced to remove, moved up a
 // level.
 //
-// The answer is BackendKeyFile because rootKeyFor resolves through
-// secret.KeyFile. A plane that obtains its key some other way — one that does
-// not hold its own key at all — must not route through here; it names its own
-// source, which is the whole point of the parameter.
-func resolvedRootKey(rootKey []byte) (secret.RootKeyProvider, error) {
-	provider, err := secret.ResolvedKey(rootKey, secret.BackendKeyFile)
+// The answer is whatever the bootstrap pointer selects, because that is
+// what rootKeyFor resolved through. A plane that obtains its key some other
+// way — one that does not hold its own key at all — must not route through
+// here; it names its own source, which is the whole point of the parameter.
+//
+// It is also where a rotated plane's RETIRING key joins the provider, for
+// the same one-place reason: every seam the stack opens comes through here,
+// so between a rotation and its retirement they all open envelopes under
+// both keys, and none of them can be the one that forgot.
+func resolvedRootKey(c *Config, rootKey []byte) (secret.RootKeyProvider, error) {
+	trust, err := rootOfTrust(c)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := secret.BackendFor(trust)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := secret.ResolvedKey(rootKey, backend)
 	if err != nil {
 		return nil, fmt.Errorf("wrap the local plane's root key: %w", err)
 	}
-	return provider, nil
+	retiring, err := loadRetiringKey(c)
+	if err != nil || retiring == nil {
+		return provider, err
+	}
+	ring, err := secret.Rotating(provider, retiring)
+	if err != nil {
+		return nil, fmt.Errorf("hold the retiring root key beside the current one: %w", err)
+	}
+	return ring, nil
 }
 
 // maxEvidencePaths bounds how many offending paths an error names. A
@@ -906,7 +1067,7 @@ func reconcileClaims(ctx context.Context, c *Config, rootKey []byte, blob *objec
 	// second time, outside rootKeyFor, which is the one place allowed to
 	// make it; a structure test enforces that and caught this exact
 	// mistake. The caller already resolved the key under the right rule.
-	keyProvider, err := resolvedRootKey(rootKey)
+	keyProvider, err := resolvedRootKey(c, rootKey)
 	if err != nil {
 		return err
 	}
@@ -1252,13 +1413,10 @@ func pinnedImage(composeFile, key string) (string, error) {
 // an unwritable mount) on stderr, and losing it turns a diagnosable
 // failure into "exit status 1".
 func composeOutput(ctx context.Context, project, composeFile string, env []string, args ...string) ([]byte, error) {
-	pins, err := loadImagePins(composeFile)
+	cmd, err := composeCommand(ctx, project, composeFile, env, args...)
 	if err != nil {
 		return nil, err
 	}
-	full := append([]string{"compose", "--project-name", project, "--file", composeFile}, args...)
-	cmd := exec.CommandContext(ctx, "docker", full...)
-	cmd.Env = append(append(os.Environ(), env...), pins...)
 
 	out, err := cmd.CombinedOutput()
 	if err != nil {
@@ -1267,6 +1425,24 @@ func composeOutput(ctx context.Context, project, composeFile string, env []strin
 	return out, nil
 }
 
+// composeCommand builds, without starting, a docker compose invocation
+// carrying the rendered environment and the image pins.
+//
+// Separate from composeOutput for the one caller that cannot buffer: the
+// online backup streams a base backup out of the Postgres container, and
+// collecting a whole cluster into memory before writing it would make the
+// archive's size a limit on the host's RAM.
+func composeCommand(ctx context.Context, project, composeFile string, env []string, args ...string) (*exec.Cmd, error) {
+	pins, err := loadImagePins(composeFile)
+	if err != nil {
+		return nil, err
+	}
+	full := append([]string{"compose", "--project-name", project, "--file", composeFile}, args...)
+	cmd := exec.CommandContext(ctx, "docker", full...)
+	cmd.Env = append(append(os.Environ(), env...), pins...)
+	return cmd, nil
+}
+
 // compose runs a docker compose subcommand against the data-plane project.
 func compose(ctx context.Context, project, composeFile string, env []string, args ...string) error {
 	_, err := composeOutput(ctx, project, composeFile, env, args...)
@@ -81,12 +81,14 @@ var keyUsingOperations = []string{"up", "Migrate", "ForceVersion"}
 
 // forbiddenKeySources are the ways to obtain a key while bypassing the
 // create-versus-load decision. paths.EnsureKey CREATES; paths.LoadKey and
-// secret.KeyFile each pick an access mode, which is precisely the choice
-// rootKeyFor exists to make in one place.
+// every secret constructor taking an Access pick an access mode, which is
+// precisely the choice rootKeyFor exists to make in one place.
 var forbiddenKeySources = map[string]string{
-	"EnsureKey": "paths",
-	"LoadKey":   "paths",
-	"KeyFile":   "secret",
+	"EnsureKey":       "paths",
+	"LoadKey":         "paths",
+	"KeyFile":         "secret",
+	"ProviderFor":     "secret",
+	"FromRootOfTrust": "secret",
 }
 
 // TestOnlyRootKeyForDecidesKeyCreation is item 7's D4 as a source rule, in
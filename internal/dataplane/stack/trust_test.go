@@ -0,0 +1,88 @@
+package stack
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orchestrator/internal/dataplane/paths"
+	"orchestrator/internal/dataplane/secret"
+)
+
+// commandTrustedPlane is a provisioned plane whose pointer keeps the root
+// key behind a helper command instead of in the key file.
+func commandTrustedPlane(t *testing.T, key []byte) *Config {
+	t.Helper()
+	cfg := planeAt(t)
+	helper := filepath.Join(t.TempDir(), "fetch-root-key")
+	script := "#!/bin/sh\nprintf '%s\\n' " + strings.TrimSpace(string(paths.EncodeKey(key))) + "\n"
+	if err := os.WriteFile(helper, []byte(script), 0o700); err != nil { //nolint:gosec // G306: it must be executable
+		t.Fatalf("write helper: %v", err)
+	}
+	pointer := cfg.Bootstrap()
+	pointer.RootOfTrust = paths.RootOfTrust{Kind: paths.RootOfTrustCommand, Command: []string{helper}}
+	if err := paths.WriteBootstrap(cfg.Roots.Config, pointer); err != nil {
+		t.Fatalf("WriteBootstrap: %v", err)
+	}
+	populate(t, cfg, paths.ServicePostgres)
+	return cfg
+}
+
+// The pointer, not the key file, decides where the key comes from, and the
+// provider the stack hands on names that backend rather than assuming one.
+func TestPointerSelectsTheRootKeyBackend(t *testing.T) {
+	want := bytes.Repeat([]byte{0x3C}, paths.RootKeyLen)
+	cfg := commandTrustedPlane(t, want)
+
+	key, err := rootKeyFor(cfg, lifecycleMigrate)
+	if err != nil {
+		t.Fatalf("rootKeyFor: %v", err)
+	}
+	if !bytes.Equal(key, want) {
+		t.Fatal("the stack did not read the key the pointer's backend holds")
+	}
+	if _, statErr := os.Stat(cfg.Roots.KeyPath()); !os.IsNotExist(statErr) {
+		t.Fatalf("a key file appeared beside a command-held key (%v)", statErr)
+	}
+
+	provider, err := resolvedRootKey(cfg, key)
+	if err != nil {
+		t.Fatalf("resolvedRootKey: %v", err)
+	}
+	if provider.Backend() != secret.BackendCommand {
+		t.Fatalf("the resolved key reports %s, want %s", provider.Backend(), secret.BackendCommand)
+	}
+}
+
+// A pointer that does not parse is refused, never read as "no pointer": the
+// fallback would be a key file minted beside a key the operator keeps
+// elsewhere.
+func TestUnreadablePointerIsNotAbsent(t *testing.T) {
+	cfg := planeAt(t)
+	if err := os.WriteFile(filepath.Join(cfg.Roots.Config, paths.BootstrapFileName), []byte("{"), 0o600); err != nil {
+		t.Fatalf("break the pointer: %v", err)
+	}
+	if _, err := rootKeyFor(cfg, lifecycleUp); err == nil {
+		t.Fatal("a broken pointer fell back to the key file")
+	}
+	if _, statErr := os.Stat(cfg.Roots.KeyPath()); !os.IsNotExist(statErr) {
+		t.Fatalf("a key was minted behind a broken pointer (%v)", statErr)
+	}
+}
+
+// Rotation and recovery install key FILES, which a plane keeping its key
+// elsewhere never reads; both refuse rather than re-seal under a key the
+// next `up` will not load.
+func TestKeyFileOperationsRefuseAnotherBackend(t *testing.T) {
+	cfg := commandTrustedPlane(t, bytes.Repeat([]byte{0x3C}, paths.RootKeyLen))
+
+	if _, err := authorizeRotation(cfg); !errors.Is(err, ErrRotationNotAuthorized) {
+		t.Fatalf("rotation under a command backend = %v, want ErrRotationNotAuthorized", err)
+	}
+	if err := authorizeRecovery(cfg, nil); !errors.Is(err, ErrRecoveryNotAuthorized) {
+		t.Fatalf("recovery under a command backend = %v, want ErrRecoveryNotAuthorized", err)
+	}
+}
@@ -45,32 +45,9 @@ func verifyLocked(ctx context.Context, c *Config) (store.VerifyReport, error) {
 	if keyErr != nil {
 		return store.VerifyReport{}, keyErr
 	}
-	dsn, dsnErr := c.DSN(rootKey)
-	if dsnErr != nil {
-		return store.VerifyReport{}, dsnErr
-	}
-	blob, blobErr := ensureBucket(ctx, c, rootKey)
-	if blobErr != nil {
-		return store.VerifyReport{}, blobErr
-	}
-	// Empty for the same reason claim reconciliation's is: verification
-	// recomputes digests over stored bytes and validates no artifact type,
-	// so a registry populated here would be a second, drifting copy of the
-	// one the Orchestrator will own.
-	types, typesErr := registry.New(nil)
-	if typesErr != nil {
-		return store.VerifyReport{}, fmt.Errorf("build an empty artifact registry: %w", typesErr)
-	}
-	// The key this function already resolved, wrapped — never a second
-	// KeyFile, which would remake the create-versus-load decision outside
-	// rootKeyFor.
-	keyProvider, keyErr := resolvedRootKey(rootKey)
-	if keyErr != nil {
-		return store.VerifyReport{}, keyErr
-	}
-	seam, openErr := postgres.Open(ctx, dsn, types, blob, keyProvider)
+	seam, openErr := openPlaneStore(ctx, c, rootKey)
 	if openErr != nil {
-		return store.VerifyReport{}, fmt.Errorf("open the persistence seam: %w", openErr)
+		return store.VerifyReport{}, openErr
 	}
 	defer seam.Close()
 
@@ -80,3 +57,40 @@ func verifyLocked(ctx context.Context, c *Config) (store.VerifyReport, error) {
 	}
 	return report, nil
 }
+
+// openPlaneStore opens the concrete store for a maintenance verb — one that
+// acts on the plane as a whole rather than through the seam's interface.
+//
+// The caller resolves the key, under its own lifecycle, and holds the
+// lifecycle lock; this only assembles what every such verb would otherwise
+// assemble by hand.
+func openPlaneStore(ctx context.Context, c *Config, rootKey []byte) (*postgres.Store, error) {
+	dsn, err := c.DSN(rootKey)
+	if err != nil {
+		return nil, err
+	}
+	blob, err := ensureBucket(ctx, c, rootKey)
+	if err != nil {
+		return nil, err
+	}
+	// Empty for the same reason claim reconciliation's is: maintenance
+	// recomputes digests or moves envelopes over stored bytes and validates
+	// no artifact type, so a registry populated here would be a second,
+	// drifting copy of the one the Orchestrator will own.
+	types, err := registry.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("build an empty artifact registry: %w", err)
+	}
+	// The key the caller already resolved, wrapped — never a second
+	// KeyFile, which would remake the create-versus-load decision outside
+	// rootKeyFor.
+	keyProvider, err := resolvedRootKey(c, rootKey)
+	if err != nil {
+		return nil, err
+	}
+	seam, err := postgres.Open(ctx, dsn, types, blob, keyProvider)
+	if err != nil {
+		return nil, fmt.Errorf("open the persistence seam: %w", err)
+	}
+	return seam, nil
+}
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"orchestrator/internal/dataplane/gen"
+	"orchestrator/internal/dataplane/secret"
+	"orchestrator/internal/dataplane/store"
+)
+
+// RekeyReport is what one re-seal pass did.
+//
+// The counts reconcile: Secrets = Resealed + Current + len(Unreadable). A
+// pass is finished when a second run over the same plane reports nothing
+// resealed, which is what makes an interrupted rotation safe to run again.
+type RekeyReport struct {
+	// Unreadable names every envelope that opened under NEITHER key. Such
+	// a row was already unreadable before the pass began; the pass cannot
+	// make it worse, and it must not be the thing that hides it.
+	Unreadable    []uuid.UUID
+	Organizations int
+	Secrets       int
+	// Resealed rows were moved from the old key to the new one by this pass.
+	Resealed int
+	// Current rows already opened under the new key, because an earlier,
+	// interrupted pass moved them.
+	Current int
+}
+
+// RekeySecrets moves every envelope in the plane from one root key to
+// another.
+//
+// It is a plane-maintenance operation, like Verify, and it is deliberately
+// not on the store interface: it acts for no user, it is reachable only from
+// the rotation verbs, and those hold the lifecycle lock EXCLUSIVELY, so no
+// seam is open while it runs.
+//
+// The NEW key is tried first, which is what makes the pass resumable: a row
+// an interrupted pass already moved is recognised and left alone rather
+// than opened under the old key, failing, and being reported unreadable.
+//
+// Each row is re-sealed for its OWN binding, rebuilt from the stored row —
+// the same rule RevealSecret follows — so the pass cannot change who may
+// read a secret even by mistake.
+func (s *Store) RekeySecrets(ctx context.Context, from, to []byte) (RekeyReport, error) {
+	organizations, err := s.queries.ListOrganizations(ctx)
+	if err != nil {
+		return RekeyReport{}, fmt.Errorf("list organizations: %w", err)
+	}
+
+	report := RekeyReport{Organizations: len(organizations)}
+	for i := range organizations {
+		organizationID := fromUUID(organizations[i].OrganizationID)
+		rows, listErr := s.queries.ListSecretsForRekey(ctx, organizations[i].OrganizationID)
+		if listErr != nil {
+			return RekeyReport{}, fmt.Errorf("list secrets in organization %s: %w", organizationID, listErr)
+		}
+		for j := range rows {
+			report.Secrets++
+			if rekeyErr := s.rekeySecret(ctx, &rows[j], from, to, &report); rekeyErr != nil {
+				return RekeyReport{}, rekeyErr
+			}
+		}
+	}
+	return report, nil
+}
+
+// rekeySecret moves one row, or records why it did not.
+func (s *Store) rekeySecret(ctx context.Context, row *gen.Secret, from, to []byte, report *RekeyReport) error {
+	secretID := fromUUID(row.SecretID)
+	binding := bindingFor(row)
+	envelope := secret.Envelope{Scheme: row.Scheme, Nonce: row.Nonce, Ciphertext: row.Ciphertext}
+
+	if _, err := secret.Open(to, binding, envelope); err == nil {
+		report.Current++
+		return nil
+	}
+	value, err := secret.Open(from, binding, envelope)
+	if err != nil {
+		if errors.Is(err, secret.ErrDecrypt) || errors.Is(err, secret.ErrUnknownScheme) {
+			report.Unreadable = append(report.Unreadable, secretID)
+			return nil
+		}
+		return fmt.Errorf("open secret %s under the old key: %w", secretID, err)
+	}
+
+	resealed, err := secret.Seal(to, binding, value.Reveal())
+	if err != nil {
+		return fmt.Errorf("re-seal secret %s: %w", secretID, err)
+	}
+	affected, err := s.queries.ResealSecret(ctx, gen.ResealSecretParams{
+		Scheme:          resealed.Scheme,
+		Nonce:           resealed.Nonce,
+		Ciphertext:      resealed.Ciphertext,
+		OrganizationID:  row.OrganizationID,
+		SecretID:        row.SecretID,
+		ExpectedVersion: row.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("write the re-sealed secret %s: %w", secretID, err)
+	}
+	if affected == 0 {
+		// Unreachable while the rotation verbs hold the lifecycle lock
+		// exclusively. Refusing is still the right reading of it: somebody
+		// wrote this row during a pass that believed it was alone.
+		return fmt.Errorf("%w: re-seal of secret %s at version %d affected no rows",
+			store.ErrSecretConflict, secretID, row.Version)
+	}
+	report.Resealed++
+	return nil
+}
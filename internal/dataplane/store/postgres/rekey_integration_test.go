@@ -0,0 +1,88 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"orchestrator/internal/dataplane/paths"
+	"orchestrator/internal/dataplane/secret"
+	"orchestrator/internal/dataplane/store"
+	"orchestrator/internal/dataplane/store/postgres"
+)
+
+// TestRekeyMovesEveryEnvelopeAndResumes is the rotation's re-seal pass,
+// against the real vault.
+//
+// Three properties, in the order a rotation depends on them: every row —
+// individual and shared — opens under the new key afterwards; a second pass
+// finds nothing left to move, which is what makes an interrupted rotation
+// safe to re-run; and the version is untouched, so a caller's optimistic
+// concurrency token survives the plane changing keys.
+func TestRekeyMovesEveryEnvelopeAndResumes(t *testing.T) {
+	v := newVault(t)
+	ctx := context.Background()
+
+	individual := v.put(t, v.userID, v.repoScope(), false, "mine")
+	shared := v.put(t, v.userID, v.orgScope(), true, "ours")
+
+	from, err := v.rootKey.RootKey()
+	if err != nil {
+		t.Fatalf("RootKey: %v", err)
+	}
+	to, err := paths.NewKeyMaterial()
+	if err != nil {
+		t.Fatalf("NewKeyMaterial: %v", err)
+	}
+	nonceBefore, _ := v.storedEnvelope(t, individual.ID)
+
+	report, err := v.store.RekeySecrets(ctx, from, to)
+	if err != nil {
+		t.Fatalf("RekeySecrets: %v", err)
+	}
+	if report.Resealed != 2 || report.Current != 0 || len(report.Unreadable) != 0 {
+		t.Fatalf("first pass reported %+v, want both rows resealed", report)
+	}
+	if nonceAfter, _ := v.storedEnvelope(t, individual.ID); bytes.Equal(nonceBefore, nonceAfter) {
+		t.Fatal("the re-sealed envelope kept its nonce")
+	}
+
+	again, err := v.store.RekeySecrets(ctx, from, to)
+	if err != nil {
+		t.Fatalf("second RekeySecrets: %v", err)
+	}
+	if again.Resealed != 0 || again.Current != 2 {
+		t.Fatalf("second pass reported %+v, want both rows already current", again)
+	}
+
+	// Opened through a store holding ONLY the new key, so a row the pass
+	// missed fails here rather than being rescued by a fallback.
+	rotated, err := secret.ResolvedKey(to, secret.BackendKeyFile)
+	if err != nil {
+		t.Fatalf("ResolvedKey: %v", err)
+	}
+	onNewKey, err := postgres.New(v.pool, testRegistry(t), v.blob, rotated)
+	if err != nil {
+		t.Fatalf("store on the new key: %v", err)
+	}
+	for _, created := range []*store.Secret{individual, shared} {
+		value, revealErr := onNewKey.RevealSecret(ctx, v.organizationID, created.ID, v.userID)
+		if revealErr != nil {
+			t.Fatalf("secret %s does not open under the new key alone: %v", created.ID, revealErr)
+		}
+		if len(value.Reveal()) == 0 {
+			t.Fatalf("secret %s opened empty", created.ID)
+		}
+		stored, getErr := onNewKey.GetSecret(ctx, v.organizationID, created.ID, v.userID)
+		if getErr != nil {
+			t.Fatalf("GetSecret: %v", getErr)
+		}
+		if stored.Version != created.Version {
+			t.Fatalf("the re-seal moved secret %s from version %d to %d; a caller holding the "+
+				"old version would now be refused for a change it never saw",
+				created.ID, created.Version, stored.Version)
+		}
+	}
+}
@@ -235,15 +235,15 @@ func (t *tx) RevealSecret(
 		return secret.Value{}, err
 	}
 
-	rootKey, err := t.rootKey.RootKey()
-	if err != nil {
-		return secret.Value{}, fmt.Errorf("read the root key to open secret %s: %w", secretID, err)
-	}
-
 	// The scheme comes from the envelope, not from today's default: the row
 	// records what sealed it, and opening it under the current scheme would
 	// be assuming the answer.
-	value, err := secret.Open(rootKey, bindingFor(&row), secret.Envelope{
+	//
+	// OpenWith rather than Open, so a plane between a key rotation and its
+	// retirement still opens an envelope under the key being retired. That
+	// is the provider's decision, not this method's: a plane holding one
+	// key opens under exactly one.
+	value, err := secret.OpenWith(t.rootKey, bindingFor(&row), secret.Envelope{
 		Scheme:     row.Scheme,
 		Nonce:      row.Nonce,
 		Ciphertext: row.Ciphertext,
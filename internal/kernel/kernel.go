@@ -450,6 +450,17 @@ func (k *Kernel) processPersistenceRequest(req *persistence.Request, ops *persis
 			}
 		}
 
+	case persistence.OpGetSpecByID:
+		if specID, ok := req.Data.(string); ok && req.Response != nil {
+			spec, err := ops.GetSpecByID(specID)
+			if err != nil {
+				k.Logger.Error("Failed to get spec %s: %v", specID, err)
+				req.Response <- err
+			} else {
+				req.Response <- spec
+			}
+		}
+
 	case persistence.OpUpsertAgentRequest:
 		if agentRequest, ok := req.Data.(*persistence.AgentRequest); ok {
 			if err := ops.UpsertAgentRequest(agentRequest); err != nil {
@@ -147,15 +147,53 @@ func (f *LLMClientFactory) CreateClientWithContext(agentType Type, stateProvider
 		return nil, fmt.Errorf("unsupported agent type: %s", agentType)
 	}
 
-	provider, err := config.GetModelProvider(modelName)
+	// The failover models are resolved as strictly as the primary: a
+	// fallback with no key is found now, not on the day it is needed.
+	names := append([]string{modelName}, config.GetEffectiveFailoverModels(agentType.String())...)
+	chain, err := resolveChain(names)
 	if err != nil {
-		return nil, fmt.Errorf("failed to determine provider for model %s: %w", modelName, err)
+		return nil, err
 	}
-	apiKey, err := config.GetAPIKey(provider)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get API key for provider %s: %w", provider, err)
+	role := &roleModels{chain: chain}
+
+	// A routed tier fails over to the role's own chain: a cheap model being
+	// down is a reason to pay more for the call, not to suspend the agent.
+	if routing := config.GetEffectiveRouting(); routing != nil {
+		for _, tier := range routing.TiersFor(agentType.String()) {
+			tierNames := []string{routing.Tiers[tier]}
+			for _, name := range names {
+				if name != tierNames[0] {
+					tierNames = append(tierNames, name)
+				}
+			}
+			tierChain, err := resolveChain(tierNames)
+			if err != nil {
+				return nil, fmt.Errorf("routing tier %s: %w", tier, err)
+			}
+			if role.tiers == nil {
+				role.routing, role.tiers = routing, make(map[string][]chainModel)
+			}
+			role.tiers[tier] = tierChain
+		}
+	}
+	return f.buildMaestroLLMsClient(role, agentType.String(), stateProvider, logger)
+}
+
+// resolveChain maps each model name to its provider and key.
+func resolveChain(names []string) ([]chainModel, error) {
+	models := make([]chainModel, 0, len(names))
+	for _, name := range names {
+		provider, err := config.GetModelProvider(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine provider for model %s: %w", name, err)
+		}
+		apiKey, err := config.GetAPIKey(provider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key for provider %s: %w", provider, err)
+		}
+		models = append(models, chainModel{model: name, provider: provider, apiKey: apiKey})
 	}
-	return f.buildMaestroLLMsClient(modelName, provider, apiKey, agentType.String(), stateProvider, logger)
+	return models, nil
 }
 
 // CreateRawClient creates a bare LLM client with no middleware for the given
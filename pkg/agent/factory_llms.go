@@ -15,7 +15,9 @@ package agent
 //     so one aggregate Event per logical call still observes validation /
 //     limiter / circuit-open / retry-exhaustion, matching Maestro's current
 //     metrics semantics (§5 M2; the RecommendedChat innermost default would
-//     hide those).
+//     hide those). With a failover chain configured it builds one such
+//     chain per model and puts a failoverClient (failover.go) between them
+//     and the suspend boundary.
 
 import (
 	"context"
@@ -43,6 +45,13 @@ type metricsObserver struct {
 	recorder      metrics.Recorder
 	stateProvider metrics.StateProvider
 	logger        *logx.Logger
+	// primary is the role's first-choice model when this observer watches a
+	// failover link, empty otherwise. It only annotates the log line: the
+	// observation already names ev.Model, the model that served the call.
+	primary string
+	// tier is the routing tier this observer's client serves, empty when the
+	// role is not routed.
+	tier string
 }
 
 // Observe implements middleware.Observer.
@@ -101,18 +110,26 @@ func (o *metricsObserver) Observe(ev mmw.Event) {
 		Error:      errText,
 		Latency:    ev.Latency,
 		Success:    success,
+		Tier:       o.tier,
 	})
 
 	if o.logger == nil {
 		return
 	}
+	var failover string
+	if o.tier != "" {
+		failover = fmt.Sprintf(" [tier %s]", o.tier)
+	}
+	if o.primary != "" && o.primary != ev.Model {
+		failover += fmt.Sprintf(" (failover for '%s')", o.primary)
+	}
 	if success {
 		total, _ := axes.Total() //nolint:errcheck // logging only; the recorder validates and escalates
-		o.logger.Info("LLM call to model '%s': latency %.3gs, request tokens: %d, response tokens: %d, reasoning tokens: %d, total tokens: %d, cost %s (agent: %s, story: %s, state: %s)",
-			ev.Model, ev.Latency.Seconds(), axes.Input, axes.Output, axes.Reasoning, total, formatCost(cost), agentID, storyID, state)
+		o.logger.Info("LLM call to model '%s'%s: latency %.3gs, request tokens: %d, response tokens: %d, reasoning tokens: %d, total tokens: %d, cost %s (agent: %s, story: %s, state: %s)",
+			ev.Model, failover, ev.Latency.Seconds(), axes.Input, axes.Output, axes.Reasoning, total, formatCost(cost), agentID, storyID, state)
 	} else {
-		o.logger.Error("LLM call to model '%s' failed: latency %.3gs, error: %s (agent: %s, story: %s, state: %s)",
-			ev.Model, ev.Latency.Seconds(), errText, agentID, storyID, state)
+		o.logger.Error("LLM call to model '%s'%s failed: latency %.3gs, error: %s (agent: %s, story: %s, state: %s)",
+			ev.Model, failover, ev.Latency.Seconds(), errText, agentID, storyID, state)
 	}
 }
 
@@ -210,8 +227,84 @@ func mapSuspend(err error) error {
 	return err
 }
 
-// buildMaestroLLMsClient constructs the flag-on client: toolkit provider →
-// hand-composed middleware chain → adapter → suspend boundary.
+// chainModel is one model of a role's failover chain, resolved to the
+// provider and key its client is built with.
+type chainModel struct {
+	model    string
+	provider string
+	apiKey   string
+}
+
+// roleModels is what CreateClientWithContext resolved for one role: its own
+// chain, and the chain behind each routing tier its rules can select.
+type roleModels struct {
+	routing *config.ModelRoutingConfig
+	tiers   map[string][]chainModel
+	chain   []chainModel
+}
+
+// buildMaestroLLMsClient constructs the flag-on client for a role: one
+// chain client for the role's own models, one more per routing tier when
+// the role is routed, and the suspend boundary around whichever it has.
+func (f *LLMClientFactory) buildMaestroLLMsClient(role *roleModels, agentTypeStr string, stateProvider metrics.StateProvider, logger *logx.Logger) (LLMClient, error) {
+	if len(role.tiers) == 0 {
+		inner, err := f.buildChainClient(role.chain, "", agentTypeStr, stateProvider, logger)
+		if err != nil {
+			return nil, err
+		}
+		return &suspendBoundary{inner: inner}, nil
+	}
+
+	routed := &routingClient{
+		routing:       role.routing,
+		stateProvider: stateProvider,
+		role:          agentTypeStr,
+		tiers:         make(map[string]llm.LLMClient, len(role.tiers)+1),
+	}
+	inner, err := f.buildChainClient(role.chain, config.RoutingTierDefault, agentTypeStr, stateProvider, logger)
+	if err != nil {
+		return nil, err
+	}
+	routed.tiers[config.RoutingTierDefault] = inner
+	for tier, models := range role.tiers {
+		inner, err := f.buildChainClient(models, tier, agentTypeStr, stateProvider, logger)
+		if err != nil {
+			return nil, fmt.Errorf("routing tier %s: %w", tier, err)
+		}
+		routed.tiers[tier] = inner
+	}
+	return &suspendBoundary{inner: routed}, nil
+}
+
+// buildChainClient constructs the client for one chain of models, first
+// choice first. A single model is built exactly as before; more than one get
+// a full chain each behind a failoverClient, so every model keeps its own
+// retry budget, circuit and metrics attribution.
+func (f *LLMClientFactory) buildChainClient(models []chainModel, tier, agentTypeStr string, stateProvider metrics.StateProvider, logger *logx.Logger) (llm.LLMClient, error) {
+	if len(models) == 0 {
+		return nil, errors.New("no model to build a client for")
+	}
+	if len(models) == 1 {
+		m := models[0]
+		return f.buildModelClient(m.model, m.provider, m.apiKey, "", tier, agentTypeStr, stateProvider, logger)
+	}
+
+	primary := models[0].model
+	links := make([]failoverLink, 0, len(models))
+	for _, m := range models {
+		client, err := f.buildModelClient(m.model, m.provider, m.apiKey, primary, tier, agentTypeStr, stateProvider, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failover model %s: %w", m.model, err)
+		}
+		links = append(links, failoverLink{model: m.model, client: client})
+	}
+	return &failoverClient{links: links, logger: logger}, nil
+}
+
+// buildModelClient constructs one model's client: toolkit provider →
+// hand-composed middleware chain → adapter → empty-response validator. The
+// suspend boundary is the caller's, since with failover it wraps the chain
+// of models rather than any one of them.
 //
 // Chain order (ChainChat: first arg outermost): metrics → validation → retry
 // → per-attempt timeout → circuit → rate limit → provider. This is the
@@ -219,7 +312,7 @@ func mapSuspend(err error) error {
 // so a single aggregate Event still observes outer rejections (§5 M2). Note
 // the deliberate tradeoff: latency now folds in retry backoff and per-attempt
 // granularity is lost — that matches Maestro's *current* metrics semantics.
-func (f *LLMClientFactory) buildMaestroLLMsClient(modelName, provider, apiKey, agentTypeStr string, stateProvider metrics.StateProvider, logger *logx.Logger) (LLMClient, error) {
+func (f *LLMClientFactory) buildModelClient(modelName, provider, apiKey, primary, tier, agentTypeStr string, stateProvider metrics.StateProvider, logger *logx.Logger) (llm.LLMClient, error) {
 	base, err := llmadapter.NewChatClient(provider, apiKey, modelName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create maestro-llms client: %w", err)
@@ -245,7 +338,7 @@ func (f *LLMClientFactory) buildMaestroLLMsClient(modelName, provider, apiKey, a
 	// agents on a provider draw from one token bucket — not one per client.
 	limiter := f.mllmsLimiters[provider]
 
-	obs := &metricsObserver{recorder: f.metricsRecorder, stateProvider: stateProvider, logger: logger}
+	obs := &metricsObserver{recorder: f.metricsRecorder, stateProvider: stateProvider, logger: logger, primary: primary, tier: tier}
 
 	// Order (ChainChat: first arg outermost): metrics → validation → retry →
 	// [per-attempt timeout] → circuit → [rate limit] → provider. Timeout and
@@ -272,9 +365,7 @@ func (f *LLMClientFactory) buildMaestroLLMsClient(modelName, provider, apiKey, a
 	// — so it sits OUTSIDE the adapter, INSIDE the suspend boundary (an
 	// empty-response error is not a provider-down signal).
 	validator := validation.NewEmptyResponseValidator(validationAgentType(agentTypeStr))
-	validated := validator.Wrap(adapter)
-
-	return &suspendBoundary{inner: validated}, nil
+	return validator.Wrap(adapter), nil
 }
 
 // validationAgentType maps the agent-type string to the validator's agent
@@ -0,0 +1,110 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"orchestrator/pkg/agent/llm"
+	"orchestrator/pkg/agent/llmerrors"
+	"orchestrator/pkg/logx"
+)
+
+// failoverLink is one model of a role's failover chain with the full client
+// buildModelClient composed for it.
+type failoverLink struct {
+	client llm.LLMClient
+	model  string
+}
+
+// failoverClient tries a role's models in order and moves to the next only
+// when the current one is unavailable in the sense mapSuspend means it: its
+// circuit is open, or a retryable provider error survived its retry
+// middleware. Anything else — a bad request, an empty response the
+// validator gave up on, a cancelled context — is the caller's answer, and
+// would be the same answer from the next model.
+//
+// It holds no health state of its own. Each link's circuit breaker already
+// is that state: a model that keeps failing opens its circuit and is then
+// skipped at the cost of one CircuitOpenError, and a recovered primary is
+// tried again when its circuit half-opens, so traffic returns to it without
+// anything here deciding when.
+//
+// When every link is unavailable the error is ServiceUnavailable, and the
+// suspend boundary outside leaves it so: the agent SUSPENDs only once the
+// whole chain is down.
+type failoverClient struct {
+	logger *logx.Logger
+	links  []failoverLink
+}
+
+// GetModelName reports the primary: it is the model the role is configured
+// with, and which model served a given call is the metrics observer's
+// record, not the client's.
+func (c *failoverClient) GetModelName() string { return c.links[0].model }
+
+//nolint:gocritic // hugeParam: signature is fixed by the llm.LLMClient interface.
+func (c *failoverClient) Complete(ctx context.Context, in llm.CompletionRequest) (llm.CompletionResponse, error) {
+	var unavailable []error
+	for i, link := range c.links {
+		resp, err := link.client.Complete(ctx, in)
+		if !isUnavailable(err) {
+			return resp, err
+		}
+		unavailable = append(unavailable, fmt.Errorf("%s: %w", link.model, err))
+		if !c.next(ctx, i) {
+			break
+		}
+	}
+	return llm.CompletionResponse{}, c.exhausted(unavailable)
+}
+
+// Stream fails over only on the error that opens the stream. Once chunks
+// flow the response is partly delivered, and restarting it on another model
+// would hand the caller two different beginnings.
+//
+//nolint:gocritic // hugeParam: signature is fixed by the llm.LLMClient interface.
+func (c *failoverClient) Stream(ctx context.Context, in llm.CompletionRequest) (<-chan llm.StreamChunk, error) {
+	var unavailable []error
+	for i, link := range c.links {
+		ch, err := link.client.Stream(ctx, in)
+		if !isUnavailable(err) {
+			return ch, err
+		}
+		unavailable = append(unavailable, fmt.Errorf("%s: %w", link.model, err))
+		if !c.next(ctx, i) {
+			break
+		}
+	}
+	return nil, c.exhausted(unavailable)
+}
+
+// next reports whether to try the link after index failed, and says so in
+// the log when it does. A cancelled context ends the walk: every remaining
+// link would fail the same way, and each would be logged as an outage.
+func (c *failoverClient) next(ctx context.Context, failed int) bool {
+	if ctx.Err() != nil || failed+1 >= len(c.links) {
+		return false
+	}
+	if c.logger != nil {
+		c.logger.Warn("LLM model '%s' unavailable; failing over to '%s' (%d of %d in the chain)",
+			c.links[failed].model, c.links[failed+1].model, failed+2, len(c.links))
+	}
+	return true
+}
+
+// exhausted is the error for a chain with no available model. It keeps each
+// link's error, so the toolkit's typed errors stay matchable through it and
+// the boundary's mapSuspend still sees them.
+func (c *failoverClient) exhausted(unavailable []error) error {
+	return llmerrors.NewServiceUnavailableError(
+		fmt.Errorf("no model in the failover chain for '%s' is available: %w", c.GetModelName(), errors.Join(unavailable...)), 0)
+}
+
+// isUnavailable reports whether err means the model could not be reached,
+// as opposed to an answer from it.
+func isUnavailable(err error) bool {
+	return err != nil && !errors.Is(err, context.Canceled) && llmerrors.IsServiceUnavailable(mapSuspend(err))
+}
+
+var _ llm.LLMClient = (*failoverClient)(nil)
@@ -0,0 +1,101 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mllms "github.com/SnapdragonPartners/maestro-llms/llms"
+	mmw "github.com/SnapdragonPartners/maestro-llms/llms/middleware"
+	"github.com/SnapdragonPartners/maestro-llms/llms/testllm"
+
+	"orchestrator/pkg/agent/internal/llmadapter"
+	"orchestrator/pkg/agent/llm"
+	"orchestrator/pkg/agent/llmerrors"
+)
+
+// link builds a failover link over a fake provider, with the metrics
+// middleware outermost as buildModelClient composes it, so the recorder sees
+// which model served a call.
+func link(rec *recordingRecorder, primary string, fake *testllm.FakeChatClient) failoverLink {
+	obs := &metricsObserver{recorder: rec, primary: primary}
+	chain := mmw.ChainChat(fake, mmw.MetricsChat(obs))
+	return failoverLink{model: fake.ModelRef.Name, client: llmadapter.Wrap(chain, fake.ModelRef.Name)}
+}
+
+func fake(provider, model string, err error) *testllm.FakeChatClient {
+	return &testllm.FakeChatClient{
+		ModelRef: mllms.ModelRef{Provider: provider, Name: model}, Err: err, Text: "served by " + model,
+	}
+}
+
+var hello = llm.CompletionRequest{Messages: []llm.CompletionMessage{{Role: llm.RoleUser, Content: "hi"}}}
+
+// TestFailoverServesFromTheNextAvailableModel: an open circuit and an
+// exhausted retryable error both move the call on, and the observation
+// names the model that answered.
+func TestFailoverServesFromTheNextAvailableModel(t *testing.T) {
+	rec := &recordingRecorder{}
+	primary := "gpt-5.4"
+	client := &suspendBoundary{inner: &failoverClient{links: []failoverLink{
+		link(rec, primary, fake("openai", primary, &mmw.CircuitOpenError{Provider: "openai", Model: primary})),
+		link(rec, primary, fake("anthropic", "claude-opus-4-6", &mllms.ProviderError{Provider: "anthropic", Kind: mllms.ErrorKindUnavailable})),
+		link(rec, primary, fake("ollama", "qwen3-coder", nil)),
+	}}}
+
+	resp, err := client.Complete(context.Background(), hello)
+	if err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if resp.Content != "served by qwen3-coder" {
+		t.Fatalf("served %q, want the third model's answer", resp.Content)
+	}
+	if rec.calls != 3 || rec.last.Model != "qwen3-coder" || !rec.last.Success {
+		t.Fatalf("observations = %d, last %+v; want the serving model recorded last", rec.calls, rec.last)
+	}
+	if client.GetModelName() != primary {
+		t.Fatalf("GetModelName = %q, want the primary", client.GetModelName())
+	}
+}
+
+// TestFailoverSuspendsOnlyWhenTheChainIsDown, and stops at an error that is
+// an answer rather than an outage: the next model would answer the same.
+func TestFailoverSuspendsOnlyWhenTheChainIsDown(t *testing.T) {
+	down := &mllms.ProviderError{Provider: "openai", Kind: mllms.ErrorKindUnavailable}
+	auth := &mllms.ProviderError{Provider: "openai", Kind: mllms.ErrorKindAuth}
+
+	tests := []struct {
+		name        string
+		errs        []error
+		wantSuspend bool
+		wantCalls   int
+	}{
+		{"whole chain down", []error{down, &mmw.CircuitOpenError{Provider: "anthropic", Model: "b"}}, true, 2},
+		{"non-retryable error is the answer", []error{auth, nil}, false, 1},
+		{"cancellation is the answer", []error{context.Canceled, nil}, false, 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := &recordingRecorder{}
+			var links []failoverLink
+			for i, err := range tc.errs {
+				links = append(links, link(rec, "a", fake("openai", string(rune('a'+i)), err)))
+			}
+			client := &suspendBoundary{inner: &failoverClient{links: links}}
+
+			_, err := client.Complete(context.Background(), hello)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := llmerrors.IsServiceUnavailable(err); got != tc.wantSuspend {
+				t.Fatalf("IsServiceUnavailable = %v, want %v (err: %v)", got, tc.wantSuspend, err)
+			}
+			if !errors.Is(err, tc.errs[0]) {
+				t.Fatal("the first model's error must stay in the chain")
+			}
+			if rec.calls != tc.wantCalls {
+				t.Fatalf("%d models called, want %d", rec.calls, tc.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package llm
+
+import "context"
+
+// Purpose says what a call is for, so a routing policy can send it to a
+// model that fits (config.ModelRoutingConfig). It travels on the context
+// rather than the request because the request reaches the provider, and the
+// purpose is Maestro's business, not the model's.
+type Purpose string
+
+// Purposes a routing rule can name. A call with none is PurposeGeneral.
+const (
+	PurposeGeneral      Purpose = ""
+	PurposeVerification Purpose = "verification"
+	PurposeProbing      Purpose = "probing"
+	PurposeConflicts    Purpose = "conflicts"
+)
+
+type purposeKey struct{}
+
+// WithPurpose labels the calls made with ctx.
+func WithPurpose(ctx context.Context, purpose Purpose) context.Context {
+	return context.WithValue(ctx, purposeKey{}, purpose)
+}
+
+// PurposeFrom returns the purpose ctx was labelled with, or PurposeGeneral.
+func PurposeFrom(ctx context.Context) Purpose {
+	purpose, _ := ctx.Value(purposeKey{}).(Purpose)
+	return purpose
+}
@@ -24,6 +24,30 @@ type StoryMetrics struct {
 	TotalCost        float64   `json:"total_cost_usd"`
 	StoryID          string    `json:"story_id"`
 	LastUpdated      time.Time `json:"last_updated"`
+
+	// Tiers breaks the totals down by routing tier, so what a routing
+	// policy saved is visible per story. Nil when no call was routed.
+	Tiers map[string]*TierMetrics `json:"tiers,omitempty"`
+}
+
+// TierMetrics is one routing tier's share of a story's calls.
+type TierMetrics struct {
+	RequestCount int64   `json:"request_count"`
+	TotalTokens  int64   `json:"total_tokens"`
+	TotalCost    float64 `json:"total_cost_usd"`
+}
+
+// copyStory returns a copy a caller may keep without racing the recorder.
+func copyStory(story *StoryMetrics) *StoryMetrics {
+	out := *story
+	if story.Tiers != nil {
+		out.Tiers = make(map[string]*TierMetrics, len(story.Tiers))
+		for tier, tierMetrics := range story.Tiers {
+			copied := *tierMetrics
+			out.Tiers[tier] = &copied
+		}
+	}
+	return &out
 }
 
 var (
@@ -44,7 +68,8 @@ func NewInternalRecorder() *InternalRecorder {
 
 // ObserveCall records metrics for a completed LLM request. Provider, agent
 // and model are unused here (the usage-log recorder consumes them); the story
-// aggregates below are unchanged in meaning.
+// aggregates below are unchanged in meaning, and a routed call is also
+// counted against its tier.
 //
 // CompletionTokens keeps its established meaning for these aggregates --
 // visible output plus reasoning -- so handleWorkAccepted's existing consumers
@@ -82,6 +107,20 @@ func (r *InternalRecorder) ObserveCall(observation *Observation) {
 	story.TotalCost += cost
 	story.RequestCount++
 	story.LastUpdated = time.Now()
+
+	if observation.Tier != "" {
+		if story.Tiers == nil {
+			story.Tiers = make(map[string]*TierMetrics)
+		}
+		tier, exists := story.Tiers[observation.Tier]
+		if !exists {
+			tier = &TierMetrics{}
+			story.Tiers[observation.Tier] = tier
+		}
+		tier.RequestCount++
+		tier.TotalTokens += promptTokens + completionTokens
+		tier.TotalCost += cost
+	}
 }
 
 // GetStoryMetrics returns the aggregated metrics for a specific story.
@@ -91,15 +130,7 @@ func (r *InternalRecorder) GetStoryMetrics(storyID string) *StoryMetrics {
 
 	if story, exists := r.stories[storyID]; exists {
 		// Return a copy to prevent external modification
-		return &StoryMetrics{
-			StoryID:          story.StoryID,
-			PromptTokens:     story.PromptTokens,
-			CompletionTokens: story.CompletionTokens,
-			TotalTokens:      story.TotalTokens,
-			TotalCost:        story.TotalCost,
-			RequestCount:     story.RequestCount,
-			LastUpdated:      story.LastUpdated,
-		}
+		return copyStory(story)
 	}
 	return nil
 }
@@ -111,15 +142,7 @@ func (r *InternalRecorder) GetAllStoryMetrics() map[string]*StoryMetrics {
 
 	result := make(map[string]*StoryMetrics)
 	for storyID, story := range r.stories {
-		result[storyID] = &StoryMetrics{
-			StoryID:          story.StoryID,
-			PromptTokens:     story.PromptTokens,
-			CompletionTokens: story.CompletionTokens,
-			TotalTokens:      story.TotalTokens,
-			TotalCost:        story.TotalCost,
-			RequestCount:     story.RequestCount,
-			LastUpdated:      story.LastUpdated,
-		}
+		result[storyID] = copyStory(story)
 	}
 	return result
 }
@@ -94,6 +94,12 @@ type Observation struct {
 	StoryID  string
 	AgentID  string
 
+	// Tier is the routing tier the call was sent to (config.ModelRoutingConfig),
+	// empty when the role's calls are not routed. It feeds the in-memory
+	// aggregates only: the usage log is a versioned external surface, and
+	// Model already says there what served the call.
+	Tier string
+
 	// Error is the failure text, required when Success is false and forbidden
 	// otherwise.
 	Error string
@@ -0,0 +1,57 @@
+package agent
+
+import (
+	"context"
+
+	"orchestrator/pkg/agent/llm"
+	"orchestrator/pkg/agent/middleware/metrics"
+	"orchestrator/pkg/config"
+)
+
+// routingClient sends each call to the tier the routing policy picks for
+// it, from the role, the agent's state at the moment of the call and the
+// purpose the call was labelled with (llm.WithPurpose).
+//
+// The state is read per call, not when the client is built: one client
+// serves an agent across every state it passes through, and PLANNING and
+// TESTING deserve different models from the same client.
+type routingClient struct {
+	routing       *config.ModelRoutingConfig
+	stateProvider metrics.StateProvider
+	tiers         map[string]llm.LLMClient // always holds config.RoutingTierDefault
+	role          string
+}
+
+// GetModelName reports the role's own model. Callers use it to size and
+// tokenise context, which has to fit the model the role is configured
+// with; a routed call is sent to a model chosen at call time.
+func (c *routingClient) GetModelName() string {
+	return c.tiers[config.RoutingTierDefault].GetModelName()
+}
+
+//nolint:gocritic // hugeParam: signature is fixed by the llm.LLMClient interface.
+func (c *routingClient) Complete(ctx context.Context, in llm.CompletionRequest) (llm.CompletionResponse, error) {
+	return c.pick(ctx).Complete(ctx, in)
+}
+
+//nolint:gocritic // hugeParam: signature is fixed by the llm.LLMClient interface.
+func (c *routingClient) Stream(ctx context.Context, in llm.CompletionRequest) (<-chan llm.StreamChunk, error) {
+	return c.pick(ctx).Stream(ctx, in)
+}
+
+// pick returns the client for the call's tier. A client built without a
+// state provider routes as though the agent had no state, so only rules
+// that leave the state open can match it.
+func (c *routingClient) pick(ctx context.Context) llm.LLMClient {
+	var state string
+	if c.stateProvider != nil {
+		state = string(c.stateProvider.GetCurrentState())
+	}
+	tier := c.routing.TierFor(c.role, state, string(llm.PurposeFrom(ctx)))
+	if client, ok := c.tiers[tier]; ok {
+		return client
+	}
+	return c.tiers[config.RoutingTierDefault]
+}
+
+var _ llm.LLMClient = (*routingClient)(nil)
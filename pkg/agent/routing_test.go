@@ -0,0 +1,74 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	mmw "github.com/SnapdragonPartners/maestro-llms/llms/middleware"
+
+	"orchestrator/pkg/agent/internal/llmadapter"
+	"orchestrator/pkg/agent/llm"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/proto"
+)
+
+// fixedState is a StateProvider whose state the test moves by hand.
+type fixedState struct{ state proto.State }
+
+func (s *fixedState) GetCurrentState() proto.State { return s.state }
+func (s *fixedState) GetStoryID() string           { return "story-1" }
+func (s *fixedState) GetID() string                { return "coder-001" }
+
+// tieredLink is a fake model's client observed as serving tier.
+func tieredLink(rec *recordingRecorder, tier, model string) llm.LLMClient {
+	obs := &metricsObserver{recorder: rec, tier: tier}
+	return llmadapter.Wrap(mmw.ChainChat(fake("anthropic", model, nil), mmw.MetricsChat(obs)), model)
+}
+
+// TestRoutingFollowsStateAndPurpose: the tier is chosen per call, from the
+// agent's state at the time and the purpose the call carries, and the
+// observation says which tier served it.
+func TestRoutingFollowsStateAndPurpose(t *testing.T) {
+	rec := &recordingRecorder{}
+	state := &fixedState{state: "CODING"}
+	client := &routingClient{
+		routing: &config.ModelRoutingConfig{
+			Tiers: map[string]string{"cheap": "claude-haiku-4-5", "strong": "claude-opus-4-6"},
+			Rules: []config.ModelRoutingRule{
+				{Role: "coder", State: "PLANNING", Tier: "strong"},
+				{Purpose: "verification", Tier: "cheap"},
+			},
+		},
+		stateProvider: state,
+		role:          "coder",
+		tiers: map[string]llm.LLMClient{
+			config.RoutingTierDefault: tieredLink(rec, config.RoutingTierDefault, "claude-sonnet-4-5"),
+			"cheap":                   tieredLink(rec, "cheap", "claude-haiku-4-5"),
+			"strong":                  tieredLink(rec, "strong", "claude-opus-4-6"),
+		},
+	}
+
+	call := func(ctx context.Context) string {
+		t.Helper()
+		resp, err := client.Complete(ctx, hello)
+		if err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+		return resp.Content
+	}
+	ctx := context.Background()
+
+	if got := call(ctx); got != "served by claude-sonnet-4-5" || rec.last.Tier != config.RoutingTierDefault {
+		t.Fatalf("unrouted call served %q in tier %q", got, rec.last.Tier)
+	}
+	if got := call(llm.WithPurpose(ctx, llm.PurposeVerification)); got != "served by claude-haiku-4-5" || rec.last.Tier != "cheap" {
+		t.Fatalf("verification served %q in tier %q", got, rec.last.Tier)
+	}
+	state.state = "PLANNING"
+	if got := call(ctx); got != "served by claude-opus-4-6" || rec.last.Tier != "strong" {
+		t.Fatalf("planning served %q in tier %q", got, rec.last.Tier)
+	}
+	if client.GetModelName() != "claude-sonnet-4-5" {
+		t.Fatalf("GetModelName = %q, want the role's own model", client.GetModelName())
+	}
+}
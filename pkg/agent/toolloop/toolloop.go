@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"orchestrator/pkg/agent"
+	"orchestrator/pkg/agent/llm"
 	"orchestrator/pkg/contextmgr"
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/persistence"
@@ -175,6 +176,11 @@ type Config[T any] struct {
 	// ActivityTracker records heartbeats for watchdog monitoring (optional).
 	// When set, RecordActivity is called at the start of each iteration.
 	ActivityTracker ActivityTracker
+
+	// Purpose labels every LLM call the loop makes (llm.WithPurpose), so a
+	// routing policy can send, say, a verification loop to a cheaper model
+	// than the state it runs in would get. Empty leaves calls unlabelled.
+	Purpose llm.Purpose
 }
 
 // Run executes the tool loop with ProcessEffect-based terminal signaling, returning an Outcome[T].
@@ -335,7 +341,7 @@ func Run[T any](tl *ToolLoop, ctx context.Context, cfg *Config[T]) Outcome[T] {
 
 		// Call LLM
 		start := time.Now()
-		resp, err := tl.llmClient.Complete(ctx, req)
+		resp, err := tl.llmClient.Complete(llm.WithPurpose(ctx, cfg.Purpose), req)
 		duration := time.Since(start)
 
 		if err != nil {
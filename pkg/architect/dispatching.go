@@ -3,6 +3,8 @@ package architect
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"orchestrator/pkg/proto"
@@ -52,11 +54,18 @@ func (d *Driver) handleDispatching(ctx context.Context) (proto.State, error) {
 	// Get ALL ready stories to dispatch (not just one)
 	readyStories := d.queue.GetReadyStories()
 	if len(readyStories) > 0 {
-		d.logger.Info("🚀 DISPATCHING: Found %d ready stories, dispatching all to enable parallel execution", len(readyStories))
+		// Stories predicted to touch the same files as a running one wait for it, to avoid rebase conflicts
+		d.predictFootprints(ctx, readyStories)
+		scheduled, deferred := d.queue.ScheduleReadyStories(readyStories)
+		for i := range deferred {
+			d.logger.Info("🗺️ DISPATCHING: Holding story %s until %s finishes: both are predicted to touch %s",
+				deferred[i].story.ID, deferred[i].overlapsWith, strings.Join(deferred[i].paths, ", "))
+		}
+		d.logger.Info("🚀 DISPATCHING: Found %d ready stories, dispatching %d to enable parallel execution", len(readyStories), len(scheduled))
 
-		// Dispatch all ready stories to maximize parallelism
+		// Dispatch all scheduled stories to maximize parallelism
 		dispatchedCount := 0
-		for _, story := range readyStories {
+		for _, story := range scheduled {
 			d.logger.Info("🚀 DISPATCHING: Dispatching story %s (%s) to coder", story.ID, story.Title)
 			if err := d.dispatchReadyStory(ctx, story.ID); err != nil {
 				d.logger.Error("🚀 DISPATCHING: Failed to dispatch story %s: %v", story.ID, err)
@@ -67,7 +76,7 @@ func (d *Driver) handleDispatching(ctx context.Context) (proto.State, error) {
 		}
 
 		d.logger.Info("🚀 DISPATCHING → MONITORING: Successfully dispatched %d/%d stories, returning to monitor coder progress",
-			dispatchedCount, len(readyStories))
+			dispatchedCount, len(scheduled))
 		return StateMonitoring, nil
 	}
 
@@ -121,6 +130,14 @@ func (d *Driver) dispatchReadyStory(ctx context.Context, storyID string) error {
 	return d.sendStoryToDispatcher(ctx, storyID)
 }
 
+// storyRequirementIDs returns the IDs of the spec requirements a story covers (never nil).
+func storyRequirementIDs(story *QueuedStory) []string {
+	if len(story.RequirementIDs) == 0 {
+		return []string{}
+	}
+	return slices.Clone(story.RequirementIDs)
+}
+
 // sendStoryToDispatcher sends a story to the dispatcher.
 func (d *Driver) sendStoryToDispatcher(ctx context.Context, storyID string) error {
 	// Create story message for the dispatcher ("coder" targets any available coder).
@@ -146,8 +163,21 @@ func (d *Driver) sendStoryToDispatcher(ctx context.Context, storyID string) erro
 		}
 		payloadData[proto.KeyContent] = content
 
-		// Requirements are embedded in story content - no separate extraction needed
-		payloadData[proto.KeyRequirements] = []string{}
+		// Requirements are embedded in story content; their IDs go to the commit message footer
+		payloadData[proto.KeyRequirements] = storyRequirementIDs(story)
+
+		// Stories stacked on a dependency, or of a spec with an epic branch, start from it and merge into it
+		baseBranch, err := d.storyBaseBranch(ctx, story)
+		if err != nil {
+			return err
+		}
+		if baseBranch != "" {
+			payloadData[proto.KeyBaseBranch] = baseBranch
+		}
+		if parentID := d.queue.StackParent(storyID); parentID != "" {
+			payloadData[proto.KeyStackedOn] = parentID
+		}
+		d.setRepoPayload(payloadData, story)
 	}
 
 	// Set typed story payload
@@ -167,6 +197,7 @@ func (d *Driver) sendStoryToDispatcher(ctx context.Context, storyID string) erro
 	if err := d.queue.UpdateStoryStatus(storyID, StatusDispatched); err != nil {
 		return fmt.Errorf("failed to mark story as dispatched: %w", err)
 	}
+	d.noteStoryDispatched(storyID)
 
 	return nil
 }
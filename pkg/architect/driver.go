@@ -16,8 +16,10 @@ import (
 	"orchestrator/pkg/contextmgr"
 	"orchestrator/pkg/dispatch"
 	execpkg "orchestrator/pkg/exec"
+	"orchestrator/pkg/forge"
 	"orchestrator/pkg/github"
 	"orchestrator/pkg/logx"
+	"orchestrator/pkg/mergequeue"
 	"orchestrator/pkg/mirror"
 	"orchestrator/pkg/persistence"
 	"orchestrator/pkg/proto"
@@ -120,6 +122,20 @@ type Driver struct {
 	monitoringIdleSince     time.Time                             // Debounce guard for system_idle detection (not persisted)
 	pmAllCompleteNotified   bool                                  // Guard: PM "all stories complete" notification already sent
 	pmAllTerminalNotified   bool                                  // Guard: PM "all stories terminal" (with failures) notification already sent
+	mergeQueue              *mergequeue.Queue                     // Speculative merge queue (nil = merge directly)
+	queuedMerges            map[string]*queuedMerge               // Merge requests waiting in the queue, keyed by request ID
+	checkWaits              map[string]*checkWait                 // Merge requests held for pending CI checks, keyed by request ID
+	checkResults            chan *checkResult                     // Completed CI check waits, consumed in MONITORING
+	stackWaits              map[string]*stackWait                 // Merge requests of stacked stories held until their dependency merges, keyed by request ID
+	watchedPRsMu            sync.Mutex                            // Protects watchedPRs (shared with the review poller)
+	watchedPRs              map[string]*watchedPR                 // Story PRs polled for human review comments, keyed by story ID
+	reviewResults           chan *prReview                        // New human review comments, consumed in MONITORING
+	pendingReviews          map[string][]forge.PRComment          // Review comments for a story's next merge response
+	reviewFollowUps         map[string]*reviewFollowUp            // Follow-up stories addressing review comments on merged PRs
+	epicsMu                 sync.Mutex                            // Protects epics (shared with web UI Accept requests)
+	epics                   map[string]*epic                      // Epic branches of specs, keyed by spec ID
+	createEpicBranch        branchCreator                         // Creates epic branches (nil = from the mirror; injectable for tests)
+	footprintRuns           map[string]*footprintRun              // When stories ran and the files they changed, for footprint accuracy logs
 }
 
 // GitHubMergeClient defines the subset of GitHub operations needed for merge requests.
@@ -194,6 +210,16 @@ func NewDriver(architectID, _ string, dispatcher *dispatch.Dispatcher, workDir s
 		agentContexts:      make(map[string]*contextmgr.ContextManager), // Initialize context map
 		reviewStreaks:      make(map[string]map[string]int),             // Initialize streak tracking
 		openIncidents:      make(map[string]*proto.Incident),            // Initialize incident tracking
+		queuedMerges:       make(map[string]*queuedMerge),               // Merge requests waiting in the merge queue
+		checkWaits:         make(map[string]*checkWait),                 // Merge requests held for CI checks
+		checkResults:       make(chan *checkResult, 16),                 // Completed CI check waits
+		stackWaits:         make(map[string]*stackWait),                 // Merge requests held for stacked dependencies
+		watchedPRs:         make(map[string]*watchedPR),                 // PRs polled for review comments
+		reviewResults:      make(chan *prReview, 16),                    // New review comments
+		pendingReviews:     make(map[string][]forge.PRComment),          // Review comments awaiting a merge request
+		reviewFollowUps:    make(map[string]*reviewFollowUp),            // Review follow-up stories
+		epics:              make(map[string]*epic),                      // Epic branches of specs
+		footprintRuns:      make(map[string]*footprintRun),              // Story runs compared with predicted footprints
 		toolLoop:           nil,                                         // Set via SetLLMClient
 		renderer:           renderer,
 		workDir:            workDir,
@@ -239,6 +265,8 @@ func NewArchitect(ctx context.Context, architectID string, dispatcher *dispatch.
 	architect.chatService = chatService
 	// Set dev chat service for development channel listener (may be nil in tests)
 	architect.devChatService = devChatService
+	// Merge through the speculative merge queue when enabled
+	architect.mergeQueue = architect.newMergeQueue(&cfg)
 
 	// NOTE: Workspace cloning is deferred to SETUP state.
 	// The architect boots in WAITING state with just a mountable directory (already created at startup).
@@ -640,6 +668,14 @@ func (d *Driver) Run(ctx context.Context) error {
 	// Start requeue requests processor goroutine.
 	go d.processRequeueRequests(ctx)
 
+	// Start polling story PRs for human review comments.
+	go d.processPRReviews(ctx)
+
+	// Start the merge queue, if enabled.
+	if d.mergeQueue != nil {
+		go d.mergeQueue.Run(ctx)
+	}
+
 	// Initialize state data
 	d.SetStateData(StateKeyStartedAt, time.Now().UTC())
 
@@ -1115,6 +1151,7 @@ func (d *Driver) processRequeueRequests(ctx context.Context) {
 				d.logger.Error("❌ Failed to requeue story %s: %v", requeueRequest.StoryID, err)
 				continue
 			}
+			d.rejectStackedMerges(requeueRequest.StoryID, "was requeued to be redone")
 
 			// Dispatch the story back to the work queue (like DISPATCHING state does).
 			// Skip dispatch if system-wide suppression is active (e.g., system-scoped failure under repair).
@@ -1134,7 +1171,7 @@ func (d *Driver) processRequeueRequests(ctx context.Context) {
 					proto.KeyStoryType:       story.StoryType,
 					proto.KeyExpress:         story.Express,  // Now false for promoted hotfixes
 					proto.KeyIsHotfix:        story.IsHotfix, // Now false for promoted hotfixes
-					proto.KeyRequirements:    []string{},     // Empty requirements for requeue
+					proto.KeyRequirements:    storyRequirementIDs(story),
 				}
 
 				// Use story content from the queue
@@ -1144,6 +1181,20 @@ func (d *Driver) processRequeueRequests(ctx context.Context) {
 				}
 				payloadData[proto.KeyContent] = content
 
+				// Requeued stories of an epic or stacked on a dependency still branch from it
+				baseBranch, err := d.storyBaseBranch(ctx, story)
+				if err != nil {
+					d.logger.Error("❌ Failed to prepare base branch for requeued story %s (stays pending): %v", requeueRequest.StoryID, err)
+					continue
+				}
+				if baseBranch != "" {
+					payloadData[proto.KeyBaseBranch] = baseBranch
+				}
+				if parentID := d.queue.StackParent(requeueRequest.StoryID); parentID != "" {
+					payloadData[proto.KeyStackedOn] = parentID
+				}
+				d.setRepoPayload(payloadData, story)
+
 				// Set typed story payload
 				storyMsg.SetTypedPayload(proto.NewGenericPayload(proto.PayloadKindStory, payloadData))
 
@@ -0,0 +1,265 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/git"
+	"orchestrator/pkg/mirror"
+)
+
+// epicBranchPrefix is the machine-managed namespace for epic branches (ADR 0023).
+const epicBranchPrefix = "maestro/epic/"
+
+// Epic states reported to the web UI.
+const (
+	EpicStateInProgress     = "in_progress"     // Stories are still merging into the epic branch
+	EpicStateAwaitingAccept = "awaiting_accept" // All stories merged; the epic PR waits for a human Accept
+	EpicStateAccepted       = "accepted"        // The epic PR merged into the target branch
+)
+
+// EpicStatus describes a spec's epic branch for the web UI.
+type EpicStatus struct {
+	SpecID       string `json:"spec_id"`
+	Branch       string `json:"branch"`
+	TargetBranch string `json:"target_branch"`
+	State        string `json:"state"`
+	PRURL        string `json:"pr_url,omitempty"`
+	StoriesDone  int    `json:"stories_done"`
+	StoriesTotal int    `json:"stories_total"`
+}
+
+// branchCreator creates branch on the forge at the head of from.
+type branchCreator func(ctx context.Context, branch, from string) error
+
+// epic tracks the branch and PR of a spec's epic. Guarded by Driver.epicsMu,
+// since the web UI accepts epics from its own goroutine.
+type epic struct {
+	prRef    string // URL (or number) of the epic PR once opened
+	accepted bool
+}
+
+// epicBranchName returns the epic branch of a spec.
+func epicBranchName(specID string) string {
+	return epicBranchPrefix + specID
+}
+
+// storyUsesEpic reports whether a story merges into its spec's epic branch.
+// Hotfixes, maintenance stories and stories of further repositories of a
+// multi-repo product always go straight to the target branch.
+func storyUsesEpic(story *QueuedStory) bool {
+	return config.EpicBranchesEnabled() && story.SpecID != "" && !story.IsHotfix && !story.IsMaintenance && story.Repo == ""
+}
+
+// storyTargetBranch returns the branch a story's PR merges into.
+func (d *Driver) storyTargetBranch(storyID string) string {
+	if d.queue != nil {
+		if story, ok := d.queue.GetStory(storyID); ok && storyUsesEpic(story) {
+			return epicBranchName(story.SpecID)
+		}
+	}
+	return config.GetGitBaseBranch()
+}
+
+// ensureEpicBranch returns the epic branch for a story's spec, creating it
+// from the target branch the first time (or again once a previous epic was
+// accepted and its branch deleted). Returns "" for stories without an epic.
+func (d *Driver) ensureEpicBranch(ctx context.Context, story *QueuedStory) (string, error) {
+	if !storyUsesEpic(story) {
+		return "", nil
+	}
+	branch := epicBranchName(story.SpecID)
+
+	d.epicsMu.Lock()
+	defer d.epicsMu.Unlock()
+	if e, ok := d.epics[story.SpecID]; ok && !e.accepted {
+		return branch, nil
+	}
+
+	create := d.createEpicBranch
+	if create == nil {
+		create = mirror.NewManager(d.workDir).CreateBranch
+	}
+	if err := create(ctx, branch, config.GetGitBaseBranch()); err != nil {
+		return "", fmt.Errorf("failed to create epic branch %s: %w", branch, err)
+	}
+	d.epics[story.SpecID] = &epic{}
+	d.logger.Info("🌿 Stories of spec %s merge into epic branch %s", story.SpecID, branch)
+	return branch, nil
+}
+
+// specUsesEpic reports whether any story of a spec merges into an epic branch.
+func (d *Driver) specUsesEpic(specID string) bool {
+	for _, story := range d.queue.GetAllStories() {
+		if story.SpecID == specID && storyUsesEpic(story) {
+			return true
+		}
+	}
+	return false
+}
+
+// openEpicPR opens the epic PR into the target branch once all of a spec's
+// stories merged. The PR is merged by a human Accept in the web UI.
+func (d *Driver) openEpicPR(ctx context.Context, specID string) {
+	if !d.specUsesEpic(specID) {
+		return
+	}
+
+	d.epicsMu.Lock()
+	defer d.epicsMu.Unlock()
+	e, ok := d.epics[specID]
+	if !ok {
+		// Restarted since the epic branch was created
+		e = &epic{}
+		d.epics[specID] = e
+	}
+	if e.prRef != "" || e.accepted {
+		return
+	}
+
+	forgeClient, err := d.newForgeClient()
+	if err != nil {
+		d.logger.Warn("🌿 Cannot open epic PR for spec %s: %v", specID, err)
+		return
+	}
+	pr, err := d.findOrCreateEpicPR(ctx, forgeClient, specID)
+	if err != nil {
+		d.logger.Warn("🌿 Failed to open epic PR for spec %s: %v", specID, err)
+		return
+	}
+	e.prRef = epicPRRef(pr)
+	d.logger.Info("🌿 Spec %s complete: epic PR %s is waiting for a human Accept", specID, e.prRef)
+}
+
+// findOrCreateEpicPR returns the open PR from a spec's epic branch into the target branch, creating it if needed.
+func (d *Driver) findOrCreateEpicPR(ctx context.Context, forgeClient forge.Client, specID string) (*forge.PullRequest, error) {
+	branch := epicBranchName(specID)
+	prs, err := forgeClient.ListPRsForBranch(ctx, branch)
+	if err != nil {
+		return nil, err
+	}
+	for i := range prs {
+		if prs[i].BaseBranch == config.GetGitBaseBranch() {
+			return &prs[i], nil
+		}
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "All stories of spec %s are merged into `%s`. Accept this epic in the Maestro web UI to merge it into `%s`.\n\n## Stories\n\n",
+		specID, branch, config.GetGitBaseBranch())
+	for _, story := range d.queue.GetAllStories() {
+		if story.SpecID == specID && storyUsesEpic(story) {
+			fmt.Fprintf(&body, "- %s (%s)\n", story.Title, story.ID)
+		}
+	}
+	return forgeClient.CreatePR(ctx, forge.PRCreateOptions{
+		Title: fmt.Sprintf("Epic: spec %s", specID),
+		Body:  body.String(),
+		Head:  branch,
+		Base:  config.GetGitBaseBranch(),
+	})
+}
+
+// epicPRRef returns the URL of an epic PR, or its number when the forge gave no URL.
+func epicPRRef(pr *forge.PullRequest) string {
+	if pr.URL != "" {
+		return pr.URL
+	}
+	return fmt.Sprintf("%d", pr.Number)
+}
+
+// GetEpics returns the epics of the specs in the queue, for the web UI.
+func (d *Driver) GetEpics() []EpicStatus {
+	if !config.EpicBranchesEnabled() || d.queue == nil {
+		return []EpicStatus{}
+	}
+
+	d.epicsMu.Lock()
+	defer d.epicsMu.Unlock()
+	epics := []EpicStatus{}
+	for _, specID := range d.queue.GetUniqueSpecIDs() {
+		status := EpicStatus{SpecID: specID, Branch: epicBranchName(specID), TargetBranch: config.GetGitBaseBranch(), State: EpicStateInProgress}
+		for _, story := range d.queue.GetAllStories() {
+			if story.SpecID != specID || !storyUsesEpic(story) {
+				continue
+			}
+			status.StoriesTotal++
+			if story.GetStatus() == StatusDone {
+				status.StoriesDone++
+			}
+		}
+		if status.StoriesTotal == 0 {
+			continue
+		}
+		if e, ok := d.epics[specID]; ok {
+			status.PRURL = e.prRef
+			if e.accepted {
+				status.State = EpicStateAccepted
+			}
+		}
+		if status.State == EpicStateInProgress && d.queue.CheckSpecComplete(specID) {
+			status.State = EpicStateAwaitingAccept
+		}
+		epics = append(epics, status)
+	}
+	return epics
+}
+
+// AcceptEpic records the human Accept of a completed spec: its epic PR is
+// merged into the target branch with a merge commit (epic history is never
+// rewritten) and the epic branch is deleted.
+func (d *Driver) AcceptEpic(ctx context.Context, specID string) error {
+	if !config.EpicBranchesEnabled() || d.queue == nil || !d.specUsesEpic(specID) {
+		return fmt.Errorf("spec %s has no epic branch", specID)
+	}
+	if !d.queue.CheckSpecComplete(specID) {
+		return fmt.Errorf("spec %s has stories that are not merged yet", specID)
+	}
+
+	d.epicsMu.Lock()
+	defer d.epicsMu.Unlock()
+	e, ok := d.epics[specID]
+	if !ok {
+		e = &epic{}
+		d.epics[specID] = e
+	}
+	if e.accepted {
+		return fmt.Errorf("epic of spec %s was already accepted", specID)
+	}
+
+	forgeClient, err := d.newForgeClient()
+	if err != nil {
+		return err
+	}
+	prRef := e.prRef
+	if prRef == "" {
+		pr, err := d.findOrCreateEpicPR(ctx, forgeClient, specID)
+		if err != nil {
+			return fmt.Errorf("failed to find epic PR: %w", err)
+		}
+		prRef = epicPRRef(pr)
+	}
+
+	result, err := forgeClient.MergePRWithResult(ctx, prRef, forge.PRMergeOptions{
+		Method:       "merge",
+		DeleteBranch: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge epic PR %s: %w", prRef, err)
+	}
+	if result.HasConflicts {
+		return fmt.Errorf("epic PR %s conflicts with %s: %s", prRef, config.GetGitBaseBranch(), result.ConflictInfo)
+	}
+	e.accepted = true
+	d.logger.Info("🌿 Epic of spec %s accepted: %s merged into %s at %s", specID, epicBranchName(specID), config.GetGitBaseBranch(), result.SHA)
+
+	if cfg, cfgErr := config.GetConfig(); cfgErr == nil && cfg.Git != nil {
+		if updateErr := git.NewRegistry(d.workDir).UpdateDependentClones(ctx, cfg.Git.RepoURL, config.GetGitBaseBranch(), result.SHA); updateErr != nil {
+			d.logger.Warn("⚠️  Failed to update dependent clones after epic merge: %v", updateErr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package architect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/config"
+)
+
+// newEpicTestDriver returns an amendment test driver with epic branches enabled
+// and branch creation recorded instead of pushed.
+func newEpicTestDriver(t *testing.T) (*Driver, *mockGitHubMergeClient, *[]string) {
+	t.Helper()
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{TargetBranch: "main", EpicBranches: true}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+	driver := newAmendmentTestDriver(t)
+	client := newMockGitHubClient()
+	driver.gitHubClient = client
+	driver.epics = make(map[string]*epic)
+
+	var created []string
+	driver.createEpicBranch = func(_ context.Context, branch, from string) error {
+		created = append(created, branch+" from "+from)
+		return nil
+	}
+	return driver, client, &created
+}
+
+// TestEnsureEpicBranch verifies a spec's epic branch is created once and
+// becomes the target of its stories.
+func TestEnsureEpicBranch(t *testing.T) {
+	driver, _, created := newEpicTestDriver(t)
+	story, _ := driver.queue.GetStory("story-pending")
+
+	for range 2 {
+		branch, err := driver.ensureEpicBranch(context.Background(), story)
+		require.NoError(t, err)
+		assert.Equal(t, "maestro/epic/spec-1", branch)
+	}
+	assert.Equal(t, []string{"maestro/epic/spec-1 from main"}, *created)
+	assert.Equal(t, "maestro/epic/spec-1", driver.storyTargetBranch("story-next"))
+
+	story.IsHotfix = true
+	branch, err := driver.ensureEpicBranch(context.Background(), story)
+	require.NoError(t, err)
+	assert.Empty(t, branch, "hotfixes go straight to the target branch")
+}
+
+// TestEpicBranches_DisabledByDefault verifies stories target the target branch without the option.
+func TestEpicBranches_DisabledByDefault(t *testing.T) {
+	driver := newReviewTestDriver(t)
+	story, _ := driver.queue.GetStory("story-pending")
+
+	branch, err := driver.ensureEpicBranch(context.Background(), story)
+	require.NoError(t, err)
+	assert.Empty(t, branch)
+	assert.Equal(t, "main", driver.storyTargetBranch("story-pending"))
+	assert.Empty(t, driver.GetEpics())
+}
+
+// TestAcceptEpic verifies a completed epic's PR is opened, then merged with a
+// merge commit and its branch deleted on Accept.
+func TestAcceptEpic(t *testing.T) {
+	driver, client, _ := newEpicTestDriver(t)
+
+	err := driver.AcceptEpic(context.Background(), "spec-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not merged yet")
+
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-pending", StatusDone))
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-next", StatusDone))
+	driver.openEpicPR(context.Background(), "spec-1")
+	require.Len(t, client.createPRCalls, 1)
+	assert.Equal(t, "maestro/epic/spec-1", client.createPRCalls[0].Head)
+	assert.Equal(t, "main", client.createPRCalls[0].Base)
+
+	status := epicStatusOf(t, driver, "spec-1")
+	assert.Equal(t, EpicStateAwaitingAccept, status.State)
+	assert.Equal(t, 3, status.StoriesDone)
+
+	require.NoError(t, driver.AcceptEpic(context.Background(), "spec-1"))
+	require.Len(t, client.mergeCalls, 1)
+	assert.Equal(t, "https://github.com/test/repo/pull/1", client.mergeCalls[0].Ref)
+	assert.Equal(t, "merge", client.mergeCalls[0].Opts.Method)
+	assert.True(t, client.mergeCalls[0].Opts.DeleteBranch)
+	assert.Equal(t, EpicStateAccepted, epicStatusOf(t, driver, "spec-1").State)
+
+	assert.Error(t, driver.AcceptEpic(context.Background(), "spec-1"), "an epic is accepted once")
+}
+
+func epicStatusOf(t *testing.T, driver *Driver, specID string) EpicStatus {
+	t.Helper()
+	for _, status := range driver.GetEpics() {
+		if status.SpecID == specID {
+			return status
+		}
+	}
+	t.Fatalf("no epic for spec %s", specID)
+	return EpicStatus{}
+}
@@ -0,0 +1,331 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/knowledge"
+	"orchestrator/pkg/mirror"
+)
+
+// footprintFileExtensions lists the extensions of files footprints name.
+const footprintFileExtensions = `(?:go|mod|ts|tsx|js|jsx|mjs|vue|svelte|py|rs|java|kt|rb|php|cs|swift|c|h|cc|cpp|hpp|sql|proto|graphql|css|scss|html|json|ya?ml|toml|md|sh|dot)`
+
+var (
+	// footprintURLPattern matches URLs, whose paths are not repository paths.
+	footprintURLPattern = regexp.MustCompile(`\bhttps?://\S+`)
+
+	// footprintFilePattern matches source file paths: paths with a directory, or
+	// backquoted file names. Bare names are left out, as product names such as
+	// Node.js look like files.
+	footprintFilePattern = regexp.MustCompile("(?:[A-Za-z0-9_.-]+/)+[A-Za-z0-9_-]+\\." + footprintFileExtensions + "\\b|`([A-Za-z0-9_-]+\\." + footprintFileExtensions + ")`")
+
+	// footprintDirPattern matches directories: backquoted paths with a slash, or words ending in a slash.
+	footprintDirPattern = regexp.MustCompile("`((?:[A-Za-z0-9_.-]+/)+[A-Za-z0-9_.-]*)`|(?:^|\\s)((?:[A-Za-z0-9_.-]+/)+)(?:\\s|$)")
+)
+
+// footprintRun records when a story ran and the files it changed, to compare
+// predicted with actual overlap between stories.
+type footprintRun struct {
+	dispatchedAt time.Time
+	mergedAt     time.Time
+	changed      []string
+}
+
+// footprintDeferral is a ready story held back because a running story, or one
+// dispatched ahead of it, is predicted to touch the same paths.
+type footprintDeferral struct {
+	story        *QueuedStory
+	overlapsWith string
+	paths        []string
+}
+
+// predictFootprint returns the paths a story is predicted to touch: files and
+// directories named in its content and approved plan, and the paths of
+// knowledge graph nodes it mentions. Directories end in "/".
+func predictFootprint(story *QueuedStory, graph *knowledge.Graph) []string {
+	text := footprintURLPattern.ReplaceAllString(story.Content+"\n"+story.ApprovedPlan, " ")
+	paths := make(map[string]bool)
+
+	for _, match := range footprintFilePattern.FindAllStringSubmatch(text, -1) {
+		file := match[0]
+		if match[1] != "" {
+			file = match[1] // Backquoted name without the quotes
+		}
+		if p := normalizeFootprintPath(file, false); p != "" {
+			paths[p] = true
+		}
+	}
+	for _, match := range footprintDirPattern.FindAllStringSubmatch(text, -1) {
+		dir := match[1] + match[2]
+		if path.Ext(strings.TrimSuffix(dir, "/")) != "" {
+			continue // A file, matched above
+		}
+		if p := normalizeFootprintPath(dir, true); p != "" {
+			paths[p] = true
+		}
+	}
+
+	if graph != nil {
+		lower := strings.ToLower(text)
+		for id, node := range graph.Nodes {
+			if node.Path == "" {
+				continue
+			}
+			if mentionsNode(lower, strings.ToLower(id)) {
+				isDir := path.Ext(node.Path) == ""
+				if p := normalizeFootprintPath(node.Path, isDir); p != "" {
+					paths[p] = true
+				}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(paths))
+	for p := range paths {
+		result = append(result, p)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// mentionsNode reports whether lowercased text names a knowledge graph node as
+// a whole word, with or without the hyphens of its ID.
+func mentionsNode(text, id string) bool {
+	for _, name := range []string{id, strings.ReplaceAll(id, "-", " ")} {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(name) + `\b`).MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeFootprintPath makes a path relative to the repository root, with
+// directories ending in "/". Returns "" for the root itself.
+func normalizeFootprintPath(p string, isDir bool) string {
+	p = strings.TrimPrefix(strings.TrimSpace(p), "/")
+	p = strings.TrimPrefix(p, "workspace/") // Coder containers mount the repository at /workspace
+	p = strings.TrimPrefix(p, "./")
+	p = path.Clean(p)
+	if p == "." || p == "" {
+		return ""
+	}
+	if isDir {
+		p += "/"
+	}
+	return p
+}
+
+// pathsOverlap reports whether two footprint paths can refer to the same file:
+// they are equal, or one is a directory containing the other.
+func pathsOverlap(a, b string) bool {
+	switch {
+	case a == b:
+		return true
+	case strings.HasSuffix(a, "/") && strings.HasPrefix(b, a):
+		return true
+	case strings.HasSuffix(b, "/") && strings.HasPrefix(a, b):
+		return true
+	}
+	return false
+}
+
+// footprintOverlap returns the paths of a that overlap a path of b.
+func footprintOverlap(a, b []string) []string {
+	var overlap []string
+	for _, pa := range a {
+		for _, pb := range b {
+			if pathsOverlap(pa, pb) {
+				overlap = append(overlap, pa)
+				break
+			}
+		}
+	}
+	return overlap
+}
+
+// SetPredictedPaths records the paths a story is predicted to touch.
+func (q *Queue) SetPredictedPaths(storyID string, paths []string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if story, exists := q.stories[storyID]; exists {
+		story.PredictedPaths = paths
+	}
+}
+
+// ScheduleReadyStories orders ready stories by priority and holds back those
+// whose predicted footprint overlaps that of a running story, or of a story
+// dispatched ahead of them, in the same repository. When every ready story
+// overlaps, the first is dispatched anyway rather than leaving coders idle.
+func (q *Queue) ScheduleReadyStories(ready []*QueuedStory) ([]*QueuedStory, []footprintDeferral) {
+	ready = slices.Clone(ready)
+	sortByPriority(ready)
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var claimed []*QueuedStory
+	for _, story := range q.stories {
+		switch story.GetStatus() {
+		case StatusDispatched, StatusPlanning, StatusCoding:
+			claimed = append(claimed, story)
+		}
+	}
+	sortByPriority(claimed) // Deterministic deferral reasons
+
+	var scheduled []*QueuedStory
+	var deferred []footprintDeferral
+	for _, story := range ready {
+		if deferral := footprintConflict(story, claimed); deferral != nil && !story.IsHotfix {
+			deferred = append(deferred, *deferral)
+			continue
+		}
+		scheduled = append(scheduled, story)
+		claimed = append(claimed, story)
+	}
+
+	if len(scheduled) == 0 && len(deferred) > 0 {
+		scheduled = append(scheduled, deferred[0].story)
+		deferred = deferred[1:]
+	}
+	return scheduled, deferred
+}
+
+// footprintConflict returns why a story should wait for one of the claimed
+// stories, or nil when its footprint overlaps none of theirs.
+func footprintConflict(story *QueuedStory, claimed []*QueuedStory) *footprintDeferral {
+	for _, other := range claimed {
+		if other.Repo != story.Repo {
+			continue
+		}
+		if overlap := footprintOverlap(story.PredictedPaths, other.PredictedPaths); len(overlap) > 0 {
+			return &footprintDeferral{story: story, overlapsWith: other.ID, paths: overlap}
+		}
+	}
+	return nil
+}
+
+// predictFootprints records a predicted footprint for stories that have none yet.
+func (d *Driver) predictFootprints(ctx context.Context, stories []*QueuedStory) {
+	graphs := make(map[string]*knowledge.Graph)
+	for _, story := range stories {
+		if len(story.PredictedPaths) > 0 {
+			continue
+		}
+		graph, loaded := graphs[story.Repo]
+		if !loaded {
+			graph = d.loadKnowledgeGraph(ctx, story.Repo)
+			graphs[story.Repo] = graph
+		}
+		paths := predictFootprint(story, graph)
+		d.queue.SetPredictedPaths(story.ID, paths)
+		if len(paths) > 0 {
+			d.logger.Debug("🗺️ Story %s is predicted to touch %s", story.ID, strings.Join(paths, ", "))
+		}
+	}
+}
+
+// refreshFootprint predicts a story's footprint again once its plan is approved.
+func (d *Driver) refreshFootprint(ctx context.Context, storyID string) {
+	story, exists := d.queue.GetStory(storyID)
+	if !exists {
+		return
+	}
+	paths := predictFootprint(story, d.loadKnowledgeGraph(ctx, story.Repo))
+	d.queue.SetPredictedPaths(storyID, paths)
+	d.logger.Debug("🗺️ Approved plan of story %s predicts it touches %d path(s)", storyID, len(paths))
+}
+
+// loadKnowledgeGraph reads a repository's knowledge graph from its mirror, or
+// returns nil when it is unavailable.
+func (d *Driver) loadKnowledgeGraph(ctx context.Context, repo string) *knowledge.Graph {
+	if d.workDir == "" {
+		return nil
+	}
+	content, err := mirror.NewRepoManager(d.workDir, repo).LoadKnowledgeGraph(ctx)
+	if err != nil || content == "" {
+		return nil
+	}
+	graph, err := knowledge.ParseDOT(content)
+	if err != nil {
+		d.logger.Debug("🗺️ Ignoring unparsable knowledge graph: %v", err)
+		return nil
+	}
+	return graph
+}
+
+// noteStoryDispatched starts a story's run for footprint accuracy logs.
+func (d *Driver) noteStoryDispatched(storyID string) {
+	if d.footprintRuns == nil {
+		return
+	}
+	d.footprintRuns[storyID] = &footprintRun{dispatchedAt: time.Now()}
+}
+
+// reportFootprint logs how well a merged story's predicted footprint matched
+// the files it changed, and its predicted and actual overlap with the stories
+// that merged while it ran, for tuning the predictor.
+func (d *Driver) reportFootprint(storyID string, changed []string) {
+	story, exists := d.queue.GetStory(storyID)
+	if !exists || d.footprintRuns == nil || len(changed) == 0 {
+		return
+	}
+
+	predicted := footprintOverlap(changed, story.PredictedPaths)
+	d.logger.Info("🗺️ Story %s changed %d file(s), %d of them within its %d predicted path(s)",
+		storyID, len(changed), len(predicted), len(story.PredictedPaths))
+	if missed := len(changed) - len(predicted); missed > 0 {
+		d.logger.Debug("🗺️ Files of story %s outside its predicted footprint: %s", storyID, strings.Join(unpredictedFiles(changed, predicted), ", "))
+	}
+
+	run, ok := d.footprintRuns[storyID]
+	if !ok {
+		run = &footprintRun{}
+		d.footprintRuns[storyID] = run
+	}
+	for otherID, other := range d.footprintRuns {
+		if otherID == storyID || other.mergedAt.IsZero() || other.mergedAt.Before(run.dispatchedAt) {
+			continue
+		}
+		otherStory, exists := d.queue.GetStory(otherID)
+		if !exists || otherStory.Repo != story.Repo {
+			continue
+		}
+		predictedOverlap := footprintOverlap(story.PredictedPaths, otherStory.PredictedPaths)
+		actualOverlap := footprintOverlap(changed, other.changed)
+		if len(predictedOverlap) == 0 && len(actualOverlap) == 0 {
+			continue
+		}
+		d.logger.Info("🗺️ Story %s and %s (merged while it ran): predicted overlap %s, actual overlap %s",
+			storyID, otherID, describePaths(predictedOverlap), describePaths(actualOverlap))
+	}
+
+	run.mergedAt = time.Now()
+	run.changed = changed
+}
+
+// unpredictedFiles returns the changed files missing from predicted.
+func unpredictedFiles(changed, predicted []string) []string {
+	var missed []string
+	for _, file := range changed {
+		if !slices.Contains(predicted, file) {
+			missed = append(missed, file)
+		}
+	}
+	return missed
+}
+
+// describePaths lists paths for a log line.
+func describePaths(paths []string) string {
+	if len(paths) == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("[%s]", strings.Join(paths, ", "))
+}
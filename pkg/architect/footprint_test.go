@@ -0,0 +1,111 @@
+package architect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/knowledge"
+)
+
+func storyIDs(stories []*QueuedStory) []string {
+	ids := make([]string, 0, len(stories))
+	for _, story := range stories {
+		ids = append(ids, story.ID)
+	}
+	return ids
+}
+
+// TestPredictFootprint verifies files and directories are taken from the
+// story and its plan, and component paths from the knowledge graph.
+func TestPredictFootprint(t *testing.T) {
+	story := addQueueStory(newTestQueue(), "story-1", StatusPending)
+	story.Content = "Add session expiry to the session store, see https://example.com/docs/spec.html. " +
+		"Keep `pkg/config/` untouched and/or documented. The page keeps its Next.js and Node.js support."
+	story.ApprovedPlan = "1. Edit /workspace/pkg/auth/session.go\n2. Add tests in ./pkg/auth/session_test.go\n3. Update web/src/ and `README.md`"
+
+	graph := knowledge.NewGraph()
+	graph.Nodes["session-store"] = &knowledge.Node{ID: "session-store", Type: "component", Path: "pkg/store"}
+	graph.Nodes["billing"] = &knowledge.Node{ID: "billing", Type: "component", Path: "pkg/billing"}
+	graph.Nodes["port"] = &knowledge.Node{ID: "port", Type: "component", Path: "pkg/port"}
+	graph.Nodes["error-handling"] = &knowledge.Node{ID: "error-handling", Type: "pattern"}
+
+	assert.Equal(t, []string{
+		"README.md",
+		"pkg/auth/session.go",
+		"pkg/auth/session_test.go",
+		"pkg/config/",
+		"pkg/store/",
+		"web/src/",
+	}, predictFootprint(story, graph))
+}
+
+func TestPathsOverlap(t *testing.T) {
+	assert.True(t, pathsOverlap("pkg/auth/session.go", "pkg/auth/session.go"))
+	assert.True(t, pathsOverlap("pkg/auth/", "pkg/auth/session.go"))
+	assert.True(t, pathsOverlap("pkg/auth/session.go", "pkg/"))
+	assert.False(t, pathsOverlap("pkg/auth/session.go", "pkg/auth/login.go"))
+	assert.False(t, pathsOverlap("pkg/auth/", "pkg/authz/"))
+}
+
+// TestScheduleReadyStories verifies stories overlapping a running story, or
+// one scheduled ahead of them, are held back.
+func TestScheduleReadyStories(t *testing.T) {
+	q := newTestQueue()
+	running := addQueueStory(q, "story-running", StatusCoding)
+	running.PredictedPaths = []string{"pkg/auth/"}
+
+	first := addQueueStory(q, "story-a", StatusPending)
+	first.Priority = 3
+	first.PredictedPaths = []string{"pkg/api/handler.go"}
+	second := addQueueStory(q, "story-b", StatusPending)
+	second.Priority = 2
+	second.PredictedPaths = []string{"pkg/api/"}
+	third := addQueueStory(q, "story-c", StatusPending)
+	third.PredictedPaths = []string{"pkg/auth/session.go"}
+	addQueueStory(q, "story-d", StatusPending) // Nothing predicted
+	otherRepo := addQueueStory(q, "story-e", StatusPending)
+	otherRepo.Repo = "api"
+	otherRepo.PredictedPaths = []string{"pkg/auth/"}
+
+	scheduled, deferred := q.ScheduleReadyStories(q.GetReadyStories())
+	assert.Equal(t, []string{"story-a", "story-d", "story-e"}, storyIDs(scheduled))
+	require.Len(t, deferred, 2)
+	assert.Equal(t, "story-b", deferred[0].story.ID)
+	assert.Equal(t, "story-a", deferred[0].overlapsWith)
+	assert.Equal(t, []string{"pkg/api/"}, deferred[0].paths)
+	assert.Equal(t, "story-c", deferred[1].story.ID)
+	assert.Equal(t, "story-running", deferred[1].overlapsWith)
+}
+
+// TestScheduleReadyStories_AllOverlap verifies the first story still runs
+// when no other work is ready.
+func TestScheduleReadyStories_AllOverlap(t *testing.T) {
+	q := newTestQueue()
+	addQueueStory(q, "story-running", StatusCoding).PredictedPaths = []string{"pkg/auth/"}
+	addQueueStory(q, "story-a", StatusPending).PredictedPaths = []string{"pkg/auth/login.go"}
+	addQueueStory(q, "story-b", StatusPending).PredictedPaths = []string{"pkg/auth/session.go"}
+
+	scheduled, deferred := q.ScheduleReadyStories(q.GetReadyStories())
+	assert.Equal(t, []string{"story-a"}, storyIDs(scheduled))
+	require.Len(t, deferred, 1)
+	assert.Equal(t, "story-b", deferred[0].story.ID)
+}
+
+// TestReportFootprint verifies a merged story's run is recorded for the
+// stories merging after it.
+func TestReportFootprint(t *testing.T) {
+	driver := newTestDriver()
+	driver.footprintRuns = make(map[string]*footprintRun)
+	driver.queue.AddStory("story-a", "spec-1", "Login", "Edit pkg/auth/login.go", "app", nil, 1)
+	driver.queue.AddStory("story-b", "spec-1", "Sessions", "Edit pkg/auth/session.go", "app", nil, 1)
+	driver.noteStoryDispatched("story-a")
+	driver.noteStoryDispatched("story-b")
+
+	driver.reportFootprint("story-a", []string{"pkg/auth/login.go", "pkg/auth/util.go"})
+	driver.reportFootprint("story-b", []string{"pkg/auth/session.go", "pkg/auth/util.go"})
+
+	assert.False(t, driver.footprintRuns["story-a"].mergedAt.IsZero())
+	assert.Equal(t, []string{"pkg/auth/util.go"}, footprintOverlap(driver.footprintRuns["story-b"].changed, driver.footprintRuns["story-a"].changed))
+}
@@ -0,0 +1,217 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+const (
+	// checkPollInterval is how often pending CI checks are polled.
+	checkPollInterval = 30 * time.Second
+
+	// maxCheckLogChars is how much of each failing check's log is sent to the coder (the tail is kept).
+	maxCheckLogChars = 3000
+)
+
+// checkWait is a merge request held until its PR's CI checks complete.
+type checkWait struct {
+	request  *proto.AgentMsg
+	done     bool
+	feedback *proto.MergeFailureFeedback // Set when the checks failed or timed out
+}
+
+// checkResult is delivered by a checks watcher when a PR's checks complete.
+type checkResult struct {
+	requestID string
+	feedback  *proto.MergeFailureFeedback
+}
+
+// gateOnChecks holds a merge until the PR's CI checks complete. Returns held
+// when the request was parked, or feedback when the checks failed; neither
+// means the merge may proceed.
+func (d *Driver) gateOnChecks(ctx context.Context, request *proto.AgentMsg, prURL, branchName, storyID string) (*proto.MergeFailureFeedback, bool) {
+	if wait, ok := d.checkWaits[request.ID]; ok && wait.done {
+		delete(d.checkWaits, request.ID)
+		return wait.feedback, false
+	}
+
+	timeout := config.GetCheckWaitTimeout()
+	if timeout <= 0 {
+		return nil, false
+	}
+
+	forgeClient, err := d.storyForgeClient(storyID)
+	if err != nil {
+		d.logger.Warn("🔀 Cannot read CI checks for story %s, merging without them: %v", storyID, err)
+		return nil, false
+	}
+	prRef, err := d.resolvePRRef(ctx, forgeClient, prURL, branchName, storyID)
+	if err != nil {
+		// The merge path reports the same failure to the coder
+		return nil, false
+	}
+	status, err := forgeClient.GetPRChecks(ctx, prRef)
+	if err != nil {
+		d.logger.Warn("🔀 Cannot read CI checks for story %s, merging without them: %v", storyID, err)
+		return nil, false
+	}
+
+	switch status.State {
+	case forge.CheckStatePending:
+		d.logger.Info("🔀 Holding merge of story %s until %d CI check(s) on %s complete", storyID, len(status.Checks), shortCheckSHA(status.SHA))
+		d.checkWaits[request.ID] = &checkWait{request: request}
+		go d.watchChecks(ctx, forgeClient, prRef, request.ID, checkPollInterval, timeout)
+		return nil, true
+	case forge.CheckStateFailure:
+		return d.checksFeedback(ctx, forgeClient, status), false
+	default:
+		return nil, false
+	}
+}
+
+// watchChecks polls a PR's checks every interval until they complete or timeout
+// passes, then delivers the outcome to MONITORING.
+func (d *Driver) watchChecks(ctx context.Context, forgeClient forge.Client, prRef, requestID string, interval, timeout time.Duration) {
+	feedback, err := d.awaitChecks(ctx, forgeClient, prRef, "", interval, timeout)
+	if err != nil {
+		return
+	}
+	select {
+	case d.checkResults <- &checkResult{requestID: requestID, feedback: feedback}:
+	case <-ctx.Done():
+	}
+}
+
+// awaitQueueChecks waits for the CI checks on the commit the merge queue pushed
+// to a PR, since the forge neither runs nor requires checks on a commit it has
+// not seen. Returns feedback when they failed, stayed pending or could not be read.
+func (d *Driver) awaitQueueChecks(ctx context.Context, forgeClient forge.Client, prRef, headSHA string) (*proto.MergeFailureFeedback, error) {
+	timeout := config.GetCheckWaitTimeout()
+	if timeout <= 0 {
+		return nil, nil
+	}
+	d.logger.Info("🚦 Waiting for CI checks on %s of %s before merging", shortCheckSHA(headSHA), prRef)
+	return d.awaitChecks(ctx, forgeClient, prRef, headSHA, checkPollInterval, timeout)
+}
+
+// awaitChecks polls a PR's checks every interval until they complete or timeout
+// passes. Returns feedback when they failed, stayed pending or could not be read,
+// and an error only when ctx is cancelled. A non-empty headSHA waits for the
+// checks of that commit, which the forge may not report right after a push.
+func (d *Driver) awaitChecks(ctx context.Context, forgeClient forge.Client, prRef, headSHA string, interval, timeout time.Duration) (*proto.MergeFailureFeedback, error) {
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		status, err := forgeClient.GetPRChecks(ctx, prRef)
+		if err != nil {
+			d.logger.Warn("🔀 Failed to poll CI checks for %s: %v", prRef, err)
+			if !time.Now().After(deadline) {
+				continue
+			}
+			// Still answer, or a held coder waits out its own timeout
+			return unreadableChecksFeedback(err, timeout), nil
+		}
+
+		stale := headSHA != "" && status.SHA != headSHA
+		switch {
+		case !stale && status.State == forge.CheckStateFailure:
+			return d.checksFeedback(ctx, forgeClient, status), nil
+		case !stale && status.State != forge.CheckStatePending:
+			return nil, nil
+		case time.Now().After(deadline):
+			return pendingChecksFeedback(status, timeout), nil
+		}
+	}
+}
+
+// receiveCheckResult records a watcher's outcome and returns the held request to reprocess.
+func (d *Driver) receiveCheckResult(result *checkResult) *proto.AgentMsg {
+	wait, ok := d.checkWaits[result.requestID]
+	if !ok || wait.done {
+		// Already answered, e.g. with review comments that arrived first
+		d.logger.Debug("🔀 Ignoring CI check result for request %s that is no longer held", result.requestID)
+		return nil
+	}
+	wait.done = true
+	wait.feedback = result.feedback
+	return wait.request
+}
+
+// checksFeedback describes failed checks for the coder, with the tail of each failing check's log.
+func (d *Driver) checksFeedback(ctx context.Context, forgeClient forge.Client, status *forge.CheckStatus) *proto.MergeFailureFeedback {
+	failed := status.Failed()
+	names := make([]string, len(failed))
+	var output strings.Builder
+	for i := range failed {
+		names[i] = failed[i].Name
+		fmt.Fprintf(&output, "=== %s ===\n", failed[i].Name)
+		log, err := forgeClient.GetCheckLog(ctx, failed[i])
+		if err != nil {
+			d.logger.Warn("🔀 Failed to fetch log for check %s: %v", failed[i].Name, err)
+			log = failed[i].Summary
+		}
+		output.WriteString(tailCheckLog(log))
+		if failed[i].URL != "" {
+			fmt.Fprintf(&output, "\nDetails: %s", failed[i].URL)
+		}
+		output.WriteString("\n\n")
+	}
+	return &proto.MergeFailureFeedback{
+		Stage:   proto.MergeStageChecks,
+		Command: strings.Join(names, ", "),
+		Output:  strings.TrimSpace(output.String()),
+	}
+}
+
+// pendingChecksFeedback reports checks that did not complete in time.
+func pendingChecksFeedback(status *forge.CheckStatus, timeout time.Duration) *proto.MergeFailureFeedback {
+	var pending []string
+	for i := range status.Checks {
+		if status.Checks[i].State == forge.CheckStatePending {
+			pending = append(pending, status.Checks[i].Name)
+		}
+	}
+	return &proto.MergeFailureFeedback{
+		Stage:   proto.MergeStageChecks,
+		Command: strings.Join(pending, ", "),
+		Output:  fmt.Sprintf("CI checks were still pending after %s. Push again to re-run them if they are stuck.", timeout),
+	}
+}
+
+// unreadableChecksFeedback reports checks that could not be read before the wait timed out.
+func unreadableChecksFeedback(err error, timeout time.Duration) *proto.MergeFailureFeedback {
+	return &proto.MergeFailureFeedback{
+		Stage:  proto.MergeStageChecks,
+		Output: fmt.Sprintf("CI checks could not be read for %s: %v. Ask to merge again to retry.", timeout, err),
+	}
+}
+
+// tailCheckLog keeps the end of a check log, where failures are reported.
+func tailCheckLog(log string) string {
+	log = strings.TrimSpace(log)
+	if len(log) <= maxCheckLogChars {
+		return log
+	}
+	return "[... earlier log truncated ...]\n" + log[len(log)-maxCheckLogChars:]
+}
+
+func shortCheckSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
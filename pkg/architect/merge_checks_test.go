@@ -0,0 +1,169 @@
+package architect
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/proto"
+)
+
+// checksGitHubClient adds simulated CI checks to the merge mock.
+type checksGitHubClient struct {
+	*mockGitHubMergeClient
+	status *forge.CheckStatus
+	logs   map[string]string
+	err    error
+}
+
+func (c *checksGitHubClient) GetPRChecks(_ context.Context, _ string) (*forge.CheckStatus, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.status, nil
+}
+
+func (c *checksGitHubClient) GetCheckLog(_ context.Context, check forge.Check) (string, error) {
+	return c.logs[check.Name], nil
+}
+
+func newChecksTestDriver(t *testing.T, status *forge.CheckStatus) (*Driver, *checksGitHubClient) {
+	t.Helper()
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{TargetBranch: "main"}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+	client := &checksGitHubClient{mockGitHubMergeClient: newMockGitHubClient(), status: status, logs: map[string]string{}}
+	driver := &Driver{
+		BaseStateMachine: agent.NewBaseStateMachine("test-architect", StateRequest, nil, nil),
+		gitHubClient:     client,
+		logger:           logx.NewLogger("test-merge-checks"),
+		checkWaits:       make(map[string]*checkWait),
+		checkResults:     make(chan *checkResult, 1),
+	}
+	return driver, client
+}
+
+func newChecksMergeRequest() *proto.AgentMsg {
+	request := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-001", "architect")
+	request.Metadata = map[string]string{proto.KeyStoryID: "story-123"}
+	request.SetTypedPayload(proto.NewMergeRequestPayload(&proto.MergeRequestPayload{
+		PRURL: "https://github.com/test/repo/pull/42",
+	}))
+	return request
+}
+
+func extractMergeResponse(t *testing.T, msg *proto.AgentMsg) *proto.MergeResponsePayload {
+	t.Helper()
+	require.NotNil(t, msg)
+	resp, err := msg.GetTypedPayload().ExtractMergeResponse()
+	require.NoError(t, err)
+	return resp
+}
+
+// TestHandleMergeRequest_FailedChecksSentBack verifies red CI blocks the merge
+// and the failing check's log reaches the coder.
+func TestHandleMergeRequest_FailedChecksSentBack(t *testing.T) {
+	driver, client := newChecksTestDriver(t, &forge.CheckStatus{
+		SHA:   "abc123",
+		State: forge.CheckStateFailure,
+		Checks: []forge.Check{
+			{Name: "build", State: forge.CheckStateSuccess},
+			{Name: "test", State: forge.CheckStateFailure, ID: "99", URL: "https://github.com/test/repo/actions/runs/1/job/99"},
+		},
+	})
+	client.logs["test"] = "--- FAIL: TestCheckout (0.01s)\n    expected 200, got 500"
+
+	result, err := driver.handleMergeRequest(context.Background(), newChecksMergeRequest())
+	require.NoError(t, err)
+
+	resp := extractMergeResponse(t, result)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+	assert.Contains(t, resp.Feedback, "CI checks failed")
+	assert.Contains(t, resp.Feedback, "TestCheckout")
+	assert.Contains(t, resp.Feedback, "actions/runs/1/job/99")
+
+	feedback, err := proto.DecodeMergeFailureFeedback(resp.Metadata[proto.KeyMergeFailureFeedback])
+	require.NoError(t, err)
+	assert.Equal(t, proto.MergeStageChecks, feedback.Stage)
+	assert.Equal(t, "test", feedback.Command)
+}
+
+// TestHandleMergeRequest_HeldForPendingChecks verifies a merge waits for
+// pending checks and proceeds once they pass.
+func TestHandleMergeRequest_HeldForPendingChecks(t *testing.T) {
+	driver, _ := newChecksTestDriver(t, &forge.CheckStatus{
+		SHA:    "abc123",
+		State:  forge.CheckStatePending,
+		Checks: []forge.Check{{Name: "test", State: forge.CheckStatePending}},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel() // Stops the watcher
+
+	request := newChecksMergeRequest()
+	result, err := driver.handleMergeRequest(ctx, request)
+	require.NoError(t, err)
+	assert.Nil(t, result, "merge should be held while checks are pending")
+	require.Contains(t, driver.checkWaits, request.ID)
+
+	// The watcher reports the checks passed
+	held := driver.receiveCheckResult(&checkResult{requestID: request.ID})
+	require.Same(t, request, held)
+
+	result, err = driver.handleMergeRequest(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, string(proto.ApprovalStatusApproved), extractMergeResponse(t, result).Status)
+	assert.Empty(t, driver.checkWaits)
+}
+
+// TestWatchChecks_UnreadableUntilTimeout verifies a watcher whose polls keep
+// failing still answers the held merge once the wait times out.
+func TestWatchChecks_UnreadableUntilTimeout(t *testing.T) {
+	driver, client := newChecksTestDriver(t, nil)
+	client.err = errors.New("forge unavailable")
+
+	request := newChecksMergeRequest()
+	driver.checkWaits[request.ID] = &checkWait{request: request}
+	go driver.watchChecks(context.Background(), &mockForgeAdapter{mock: client}, "42", request.ID, time.Millisecond, 5*time.Millisecond)
+
+	var result *checkResult
+	select {
+	case result = <-driver.checkResults:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watcher never delivered a result")
+	}
+	require.NotNil(t, result.feedback)
+	assert.Contains(t, result.feedback.Output, "forge unavailable")
+
+	require.Same(t, request, driver.receiveCheckResult(result))
+	response, err := driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	resp := extractMergeResponse(t, response)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+	assert.Contains(t, resp.Feedback, "could not be read")
+	assert.Empty(t, driver.checkWaits)
+}
+
+// TestAwaitChecks_WaitsForPushedHead verifies checks reported for an earlier
+// head do not count for the commit the merge queue pushed.
+func TestAwaitChecks_WaitsForPushedHead(t *testing.T) {
+	driver, client := newChecksTestDriver(t, &forge.CheckStatus{SHA: "old123", State: forge.CheckStateSuccess})
+	forgeClient := &mockForgeAdapter{mock: client}
+
+	feedback, err := driver.awaitChecks(context.Background(), forgeClient, "42", "new456", time.Millisecond, 5*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, feedback, "checks of the previous head must not let the merge through")
+	assert.Contains(t, feedback.Output, "still pending")
+
+	client.status = &forge.CheckStatus{SHA: "new456", State: forge.CheckStateSuccess}
+	feedback, err = driver.awaitChecks(context.Background(), forgeClient, "42", "new456", time.Millisecond, time.Second)
+	require.NoError(t, err)
+	assert.Nil(t, feedback)
+}
@@ -0,0 +1,114 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/github"
+	"orchestrator/pkg/mergequeue"
+	"orchestrator/pkg/proto"
+)
+
+// queuedMerge is a coder merge request waiting in the merge queue.
+type queuedMerge struct {
+	request *proto.AgentMsg
+	result  *mergequeue.Result // Set once the queue has judged the PR
+}
+
+// newMergeQueue creates the merge queue when it is enabled in config.
+func (d *Driver) newMergeQueue(cfg *config.Config) *mergequeue.Queue {
+	mq := config.GetMergeQueueConfig()
+	if !mq.Enabled {
+		return nil
+	}
+
+	targetBranch := github.DefaultBranch
+	if cfg.Git != nil && cfg.Git.TargetBranch != "" {
+		targetBranch = cfg.Git.TargetBranch
+	}
+	var buildCommand, testCommand string
+	if cfg.Build != nil {
+		buildCommand, testCommand = cfg.Build.Build, cfg.Build.Test
+	}
+	image := func() string {
+		if id := config.GetPinnedImageID(); id != "" {
+			return id
+		}
+		return config.GetSafeImageID()
+	}
+
+	backend := mergequeue.NewGitBackend(d.workDir, targetBranch, buildCommand, testCommand,
+		time.Duration(mq.TestTimeoutMinutes)*time.Minute, d.newForgeClient, image)
+	backend.AwaitChecks = d.awaitQueueChecks
+	return mergequeue.New(backend, mq.Depth)
+}
+
+// mergeQueueResults returns the queue's result channel, or nil (never ready) when the queue is disabled.
+func (d *Driver) mergeQueueResults() <-chan *mergequeue.Result {
+	if d.mergeQueue == nil {
+		return nil
+	}
+	return d.mergeQueue.Results()
+}
+
+// enqueueMerge puts the coder's PR in the merge queue. The coder is answered
+// when the queue delivers the PR's result.
+func (d *Driver) enqueueMerge(ctx context.Context, request *proto.AgentMsg, prURL, branchName, storyID string) error {
+	forgeClient, err := d.newForgeClient()
+	if err != nil {
+		return err
+	}
+	prRef, err := d.resolvePRRef(ctx, forgeClient, prURL, branchName, storyID)
+	if err != nil {
+		return err
+	}
+	if branchName == "" {
+		return fmt.Errorf("merge queue requires the PR branch name")
+	}
+
+	d.queuedMerges[request.ID] = &queuedMerge{request: request}
+	position := d.mergeQueue.Enqueue(&mergequeue.Candidate{
+		StoryID:   storyID,
+		PRRef:     prRef,
+		Branch:    branchName,
+		RequestID: request.ID,
+	})
+	d.logger.Info("🚦 Story %s queued for merge at position %d", storyID, position)
+	return nil
+}
+
+// receiveMergeQueueResult pairs a queue result with its waiting merge request.
+// Returns the request to reprocess, or nil if it is unknown.
+func (d *Driver) receiveMergeQueueResult(result *mergequeue.Result) *proto.AgentMsg {
+	queued, ok := d.queuedMerges[result.Candidate.RequestID]
+	if !ok {
+		d.logger.Warn("🚦 Merge queue result for unknown request %s (story %s)", result.Candidate.RequestID, result.Candidate.StoryID)
+		return nil
+	}
+	queued.result = result
+	return queued.request
+}
+
+// takeMergeQueueResult returns and forgets the queue result for a merge request, if it has one.
+func (d *Driver) takeMergeQueueResult(requestID string) *mergequeue.Result {
+	queued, ok := d.queuedMerges[requestID]
+	if !ok || queued.result == nil {
+		return nil
+	}
+	delete(d.queuedMerges, requestID)
+	return queued.result
+}
+
+// mergeQueueOutcome converts a queue result into the merge attempt result used by handleMergeRequest.
+func mergeQueueOutcome(result *mergequeue.Result) (*MergeAttemptResult, *proto.MergeFailureFeedback, error) {
+	switch {
+	case result.Err != nil:
+		return nil, nil, fmt.Errorf("merge queue failed: %w", result.Err)
+	case result.Feedback != nil:
+		return nil, result.Feedback, nil
+	default:
+		return &MergeAttemptResult{CommitSHA: result.CommitSHA}, nil, nil
+	}
+}
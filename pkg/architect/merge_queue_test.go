@@ -0,0 +1,83 @@
+package architect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/mergequeue"
+	"orchestrator/pkg/proto"
+)
+
+func newMergeQueueTestDriver() *Driver {
+	return &Driver{
+		BaseStateMachine: agent.NewBaseStateMachine("test-architect", StateRequest, nil, nil),
+		gitHubClient:     newMockGitHubClient(),
+		logger:           logx.NewLogger("test-merge-queue"),
+		mergeQueue:       mergequeue.New(nil, 2), // Never run: the test delivers results itself
+		queuedMerges:     make(map[string]*queuedMerge),
+	}
+}
+
+func newQueuedMergeRequest() *proto.AgentMsg {
+	request := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-001", "architect")
+	request.Metadata = map[string]string{proto.KeyStoryID: "story-123"}
+	request.SetTypedPayload(proto.NewMergeRequestPayload(&proto.MergeRequestPayload{
+		PRURL:      "https://github.com/test/repo/pull/42",
+		BranchName: "maestro/story-123",
+	}))
+	return request
+}
+
+// TestHandleMergeRequest_QueuedThenSentBack verifies that a merge request is
+// queued without a reply and answered with structured feedback once the queue fails it.
+func TestHandleMergeRequest_QueuedThenSentBack(t *testing.T) {
+	driver := newMergeQueueTestDriver()
+	request := newQueuedMergeRequest()
+
+	result, err := driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	assert.Nil(t, result, "queued merge should not be answered yet")
+	assert.Equal(t, []string{"story-123"}, driver.mergeQueue.Pending())
+
+	feedback := &proto.MergeFailureFeedback{
+		Stage:   proto.MergeStageTest,
+		BaseSHA: "0123456789abcdef",
+		Ahead:   []string{"story-100"},
+		Command: "make test",
+		Output:  "--- FAIL: TestLogin",
+	}
+	queued := driver.receiveMergeQueueResult(&mergequeue.Result{
+		Candidate: &mergequeue.Candidate{StoryID: "story-123", RequestID: request.ID},
+		Feedback:  feedback,
+	})
+	require.Same(t, request, queued)
+
+	result, err = driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, request.ID, result.ParentMsgID)
+
+	mergeResp, err := result.GetTypedPayload().ExtractMergeResponse()
+	require.NoError(t, err)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), mergeResp.Status)
+	assert.Contains(t, mergeResp.Feedback, "story-100")
+	assert.Contains(t, mergeResp.Feedback, "TestLogin")
+
+	decoded, err := proto.DecodeMergeFailureFeedback(mergeResp.Metadata[proto.KeyMergeFailureFeedback])
+	require.NoError(t, err)
+	assert.Equal(t, feedback, decoded)
+	assert.Empty(t, driver.queuedMerges)
+}
+
+// TestReceiveMergeQueueResult_UnknownRequest verifies stray results are ignored.
+func TestReceiveMergeQueueResult_UnknownRequest(t *testing.T) {
+	driver := newMergeQueueTestDriver()
+	assert.Nil(t, driver.receiveMergeQueueResult(&mergequeue.Result{
+		Candidate: &mergequeue.Candidate{StoryID: "story-9", RequestID: "missing"},
+	}))
+}
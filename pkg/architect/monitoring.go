@@ -40,9 +40,17 @@ func (d *Driver) handleMonitoring(ctx context.Context) (proto.State, error) {
 	d.reconcileOpenIncidents(ctx)
 	d.checkAndOpenIdleIncident(ctx)
 
+	// Answer held merges of stories stacked on a story that just merged
+	if request := d.nextReleasedStackMerge(); request != nil {
+		d.SetStateData(StateKeyCurrentRequest, request)
+		return StateRequest, nil
+	}
+
 	// In monitoring state, we wait for either:
 	// 1. Coder questions/requests (transition to REQUEST).
-	// 2. Heartbeat to check for new ready stories.
+	// 2. Merge queue and CI check results (answer the waiting coder in REQUEST).
+	// 3. Human review comments on story PRs.
+	// 4. Heartbeat to check for new ready stories.
 	select {
 	case questionMsg, ok := <-d.questionsCh:
 		if !ok {
@@ -57,6 +65,39 @@ func (d *Driver) handleMonitoring(ctx context.Context) (proto.State, error) {
 		d.SetStateData(StateKeyCurrentRequest, questionMsg)
 		return StateRequest, nil
 
+	case result := <-d.mergeQueueResults():
+		// The merge queue judged a PR; answer its coder from REQUEST
+		request := d.receiveMergeQueueResult(result)
+		if request == nil {
+			return StateMonitoring, nil
+		}
+		d.monitoringIdleSince = time.Time{}
+		d.SetStateData(StateKeyCurrentRequest, request)
+		return StateRequest, nil
+
+	case result := <-d.checkResults:
+		// A held merge's CI checks completed; answer its coder from REQUEST
+		request := d.receiveCheckResult(result)
+		if request == nil {
+			return StateMonitoring, nil
+		}
+		d.monitoringIdleSince = time.Time{}
+		d.SetStateData(StateKeyCurrentRequest, request)
+		return StateRequest, nil
+
+	case review := <-d.reviewResults:
+		// Human review comments on a story PR; route them to its coder
+		request, reopen := d.routePRReview(review)
+		switch {
+		case request != nil:
+			d.SetStateData(StateKeyCurrentRequest, request)
+			return StateRequest, nil
+		case reopen:
+			d.SetStateData(StateKeyPRReviewPending, review)
+			return StateRequest, nil
+		}
+		return StateMonitoring, nil
+
 	case <-time.After(HeartbeatInterval):
 		// Check for unread developer chat messages
 		if d.devChatService != nil && d.devChatService.HaveNewMessagesForChannel(d.GetAgentID(), chat.ChannelDevelopment) {
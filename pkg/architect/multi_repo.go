@@ -0,0 +1,68 @@
+package architect
+
+import (
+	"fmt"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+// storyForgeClient returns the forge client for the repository a story changes.
+func (d *Driver) storyForgeClient(storyID string) (forge.Client, error) {
+	repo := d.storyRepo(storyID)
+	if repo == "" || d.gitHubClient != nil {
+		return d.newForgeClient()
+	}
+	client, err := forge.NewRepoClient(d.workDir, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge client for repository %s: %w", repo, err)
+	}
+	return client, nil
+}
+
+// storyRepo returns the repository a story changes ("" for the primary one).
+func (d *Driver) storyRepo(storyID string) string {
+	if d.queue == nil {
+		return ""
+	}
+	if story, ok := d.queue.GetStory(storyID); ok {
+		return story.Repo
+	}
+	return ""
+}
+
+// isCrossRepoStack reports whether a stacked story waits for a dependency in
+// another repository of a multi-repo product.
+func (d *Driver) isCrossRepoStack(storyID string) bool {
+	story, ok := d.queue.GetStory(storyID)
+	if !ok {
+		return false
+	}
+	parent, ok := d.queue.GetStory(d.queue.StackParent(storyID))
+	return ok && parent.Repo != story.Repo
+}
+
+// stackedSiblingBranches returns, for a story stacked on a dependency in
+// another repository, that repository's approved branch, so the coder reads
+// the code it builds on. Returns nil otherwise.
+func (d *Driver) stackedSiblingBranches(storyID string) map[string]string {
+	if !d.isCrossRepoStack(storyID) {
+		return nil
+	}
+	parentID := d.queue.StackParent(storyID)
+	parent, _ := d.queue.GetStory(parentID)
+	return map[string]string{config.RepoDisplayName(parent.Repo): d.queue.ApprovedBranch(parentID)}
+}
+
+// setRepoPayload tells the coder which repository a story changes and, for a
+// story stacked on a dependency in another repository, which branch of that
+// repository to read.
+func (d *Driver) setRepoPayload(payloadData map[string]any, story *QueuedStory) {
+	if story.Repo != "" {
+		payloadData[proto.KeyRepo] = story.Repo
+	}
+	if branches := d.stackedSiblingBranches(story.ID); branches != nil {
+		payloadData[proto.KeySiblingBranches] = branches
+	}
+}
@@ -0,0 +1,103 @@
+package architect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/config"
+)
+
+// newMultiRepoTestDriver returns a stack test driver for a product with a
+// further "api" repository, where story-child in the primary repository
+// depends on story-parent in the api repository. Stacked PRs are off.
+func newMultiRepoTestDriver(t *testing.T) (*Driver, *stackGitHubClient) {
+	t.Helper()
+	driver, client := newStackTestDriver(t)
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{
+		RepoURL:      "https://github.com/test/web.git",
+		TargetBranch: "main",
+		IgnoreChecks: true,
+		Repos:        []config.RepoConfig{{Name: "api", URL: "https://github.com/test/api.git", Role: "backend"}},
+	}})
+	driver.queue.SetStoryRepo("story-parent", "api")
+	return driver, client
+}
+
+// TestCrossRepoStack verifies a dependent in another repository starts once
+// its dependency is approved, from its own target branch, reading the
+// dependency's approved branch.
+func TestCrossRepoStack(t *testing.T) {
+	driver, _ := newMultiRepoTestDriver(t)
+	assert.NotContains(t, readyStoryIDs(driver.queue), "story-child")
+
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	assert.Contains(t, readyStoryIDs(driver.queue), "story-child")
+
+	child, _ := driver.queue.GetStory("story-child")
+	branch, err := driver.storyBaseBranch(context.Background(), child)
+	require.NoError(t, err)
+	assert.Empty(t, branch, "a cross-repo dependent starts from its own target branch")
+	assert.Equal(t, "story-parent", driver.queue.StackParent("story-child"))
+	assert.Equal(t, map[string]string{"api": "maestro-story-parent"}, driver.stackedSiblingBranches(child.ID))
+}
+
+// TestCrossRepoStack_SameRepoNeedsOption verifies dependencies within one
+// repository still wait for the merge unless stacked PRs are enabled.
+func TestCrossRepoStack_SameRepoNeedsOption(t *testing.T) {
+	driver, _ := newMultiRepoTestDriver(t)
+	driver.queue.SetStoryRepo("story-child", "api")
+
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	assert.NotContains(t, readyStoryIDs(driver.queue), "story-child")
+}
+
+// TestCrossRepoMerge_HeldThenReleased verifies a cross-repo dependent's merge
+// waits for its dependency, then goes ahead without a retarget.
+func TestCrossRepoMerge_HeldThenReleased(t *testing.T) {
+	driver, client := newMultiRepoTestDriver(t)
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	driver.queue.StackStory("story-child")
+	client.base = "main"
+
+	request := newStackMergeRequest("story-child", "maestro-story-child")
+	result, err := driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	assert.Nil(t, result, "merge should be held until the dependency merges")
+	assert.Empty(t, client.mergeCalls)
+
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-parent", StatusDone))
+	driver.releaseStackedMerges("story-parent")
+	require.Same(t, request, driver.nextReleasedStackMerge())
+
+	feedback, held, err := driver.gateOnStackParent(context.Background(), request,
+		"https://github.com/test/repo/pull/9", "maestro-story-child", "story-child")
+	require.NoError(t, err)
+	assert.False(t, held)
+	assert.Nil(t, feedback, "the PR already targets its own repository's branch")
+	assert.Empty(t, client.retargets)
+	assert.Empty(t, driver.queue.StackParent("story-child"))
+}
+
+// TestConvertToolResultToRequirements_Repo verifies story repositories are
+// resolved, with the primary repository recorded as empty.
+func TestConvertToolResultToRequirements_Repo(t *testing.T) {
+	driver, _ := newMultiRepoTestDriver(t)
+	requirement := func(repo string) map[string]any {
+		return map[string]any{"title": "Add endpoint", "description": "Serve sessions", "repo": repo}
+	}
+
+	reqs, err := driver.convertToolResultToRequirements(map[string]any{
+		"requirements": []any{requirement("api"), requirement("web"), requirement("")},
+	})
+	require.NoError(t, err)
+	require.Len(t, reqs, 3)
+	assert.Equal(t, "api", reqs[0].Repo)
+	assert.Empty(t, reqs[1].Repo)
+	assert.Empty(t, reqs[2].Repo)
+
+	_, err = driver.convertToolResultToRequirements(map[string]any{"requirements": []any{requirement("mobile")}})
+	assert.ErrorContains(t, err, "unknown repository")
+}
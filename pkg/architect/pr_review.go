@@ -0,0 +1,298 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/mergequeue"
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/proto"
+)
+
+const (
+	// reviewPollInterval is how often watched PRs are checked for new human comments.
+	reviewPollInterval = 2 * time.Minute
+
+	// reviewWatchWindow is how long a merged PR keeps being watched for late review comments.
+	reviewWatchWindow = 24 * time.Hour
+)
+
+// watchedPR is a story PR polled for human review comments.
+type watchedPR struct {
+	mergedAt time.Time
+	seen     map[string]bool // Comment keys already routed
+	polled   bool            // Whether the PR was polled since the watch started
+	storyID  string
+	prRef    string
+}
+
+// prReview is a batch of new human comments on a story's PR.
+type prReview struct {
+	storyID  string
+	prRef    string
+	comments []forge.PRComment
+}
+
+// reviewFollowUp links a follow-up story to the review comments it addresses.
+type reviewFollowUp struct {
+	prRef    string
+	comments []forge.PRComment
+}
+
+// watchPR starts polling a story's PR for human review comments.
+func (d *Driver) watchPR(storyID, prRef string) {
+	if prRef == "" || d.watchedPRs == nil {
+		return
+	}
+	d.watchedPRsMu.Lock()
+	defer d.watchedPRsMu.Unlock()
+	if watched, ok := d.watchedPRs[storyID]; ok {
+		watched.prRef = prRef
+		return
+	}
+	d.watchedPRs[storyID] = &watchedPR{storyID: storyID, prRef: prRef, seen: make(map[string]bool)}
+}
+
+// markPRMerged keeps watching a merged PR for late comments until reviewWatchWindow passes.
+func (d *Driver) markPRMerged(storyID string) {
+	d.watchedPRsMu.Lock()
+	defer d.watchedPRsMu.Unlock()
+	if watched, ok := d.watchedPRs[storyID]; ok {
+		watched.mergedAt = time.Now()
+	}
+}
+
+// processPRReviews polls watched PRs for new human comments and delivers
+// them to MONITORING until ctx is cancelled.
+func (d *Driver) processPRReviews(ctx context.Context) {
+	ticker := time.NewTicker(reviewPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, w := range d.watchedPRSnapshot() {
+			forgeClient, err := d.storyForgeClient(w.storyID)
+			if err != nil {
+				d.logger.Debug("💬 Skipping PR review poll of %s: %v", w.prRef, err)
+				continue
+			}
+			review := d.pollPRReview(ctx, forgeClient, w)
+			if review == nil {
+				continue
+			}
+			select {
+			case d.reviewResults <- review:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// watchedPRSnapshot returns the PRs to poll, dropping merged PRs past the watch window.
+func (d *Driver) watchedPRSnapshot() []*watchedPR {
+	d.watchedPRsMu.Lock()
+	defer d.watchedPRsMu.Unlock()
+	watched := make([]*watchedPR, 0, len(d.watchedPRs))
+	for storyID, w := range d.watchedPRs {
+		if !w.mergedAt.IsZero() && time.Since(w.mergedAt) > reviewWatchWindow {
+			delete(d.watchedPRs, storyID)
+			continue
+		}
+		watched = append(watched, w)
+	}
+	return watched
+}
+
+// pollPRReview returns the PR's human comments not routed yet, or nil.
+func (d *Driver) pollPRReview(ctx context.Context, forgeClient forge.Client, w *watchedPR) *prReview {
+	d.watchedPRsMu.Lock()
+	prRef := w.prRef
+	d.watchedPRsMu.Unlock()
+
+	comments, err := forgeClient.ListPRComments(ctx, prRef)
+	if err != nil {
+		d.logger.Warn("💬 Failed to read comments on %s: %v", prRef, err)
+		return nil
+	}
+
+	d.watchedPRsMu.Lock()
+	defer d.watchedPRsMu.Unlock()
+
+	// A watch started after a restart has routed nothing yet, but comments
+	// older than Maestro's latest reply were routed and answered before it.
+	var answeredBefore time.Time
+	if !w.polled {
+		answeredBefore = latestMaestroReply(comments)
+		w.polled = true
+	}
+
+	var fresh []forge.PRComment
+	for i := range comments {
+		key := comments[i].Key()
+		if w.seen[key] {
+			continue
+		}
+		w.seen[key] = true
+		if comments[i].FromMaestro() || strings.HasSuffix(comments[i].Author, "[bot]") || strings.TrimSpace(comments[i].Body) == "" {
+			continue
+		}
+		if comments[i].CreatedAt.Before(answeredBefore) {
+			continue
+		}
+		fresh = append(fresh, comments[i])
+	}
+	if len(fresh) == 0 {
+		return nil
+	}
+	d.logger.Info("💬 %d new review comment(s) on %s (story %s)", len(fresh), prRef, w.storyID)
+	return &prReview{storyID: w.storyID, prRef: prRef, comments: fresh}
+}
+
+// latestMaestroReply returns when Maestro last commented on the PR, or the zero time.
+func latestMaestroReply(comments []forge.PRComment) time.Time {
+	var latest time.Time
+	for i := range comments {
+		if comments[i].FromMaestro() && comments[i].CreatedAt.After(latest) {
+			latest = comments[i].CreatedAt
+		}
+	}
+	return latest
+}
+
+// routePRReview sends review comments to the story's coder. A held merge is
+// answered with them now; otherwise they gate the coder's next merge request,
+// or reopen the story with a follow-up if it already merged. Returns the held
+// request to reprocess, and whether the review needs REQUEST to reopen the story.
+func (d *Driver) routePRReview(review *prReview) (*proto.AgentMsg, bool) {
+	story, ok := d.queue.GetStory(review.storyID)
+	if !ok {
+		d.logger.Warn("💬 Review comments for unknown story %s", review.storyID)
+		return nil, false
+	}
+	if story.GetStatus() == StatusDone {
+		return nil, true
+	}
+
+	feedback := reviewFeedback(review.comments)
+	if request := d.releaseHeldMerge(review.storyID, feedback); request != nil {
+		d.logger.Info("💬 Sending review comments to the coder waiting on story %s", review.storyID)
+		return request, false
+	}
+
+	d.pendingReviews[review.storyID] = append(d.pendingReviews[review.storyID], review.comments...)
+	d.logger.Info("💬 Review comments on story %s will be sent with its next merge response", review.storyID)
+	return nil, false
+}
+
+// releaseHeldMerge answers a merge request held for CI checks or a stacked
+// dependency, or waiting in the merge queue, with review feedback. Returns the request to reprocess, or nil.
+func (d *Driver) releaseHeldMerge(storyID string, feedback *proto.MergeFailureFeedback) *proto.AgentMsg {
+	for _, wait := range d.checkWaits {
+		if !wait.done && wait.request.Metadata[proto.KeyStoryID] == storyID {
+			wait.done = true
+			wait.feedback = feedback
+			return wait.request
+		}
+	}
+	for _, wait := range d.stackWaits {
+		if !wait.done && wait.request.Metadata[proto.KeyStoryID] == storyID {
+			wait.done = true
+			wait.feedback = feedback
+			return wait.request
+		}
+	}
+	if d.mergeQueue == nil {
+		return nil
+	}
+	for requestID, queued := range d.queuedMerges {
+		if queued.result != nil || queued.request.Metadata[proto.KeyStoryID] != storyID {
+			continue
+		}
+		// A PR already being tested cannot be pulled; its coder gets the comments next time
+		if candidate := d.mergeQueue.Remove(requestID); candidate != nil {
+			queued.result = &mergequeue.Result{Candidate: candidate, Feedback: feedback}
+			return queued.request
+		}
+	}
+	return nil
+}
+
+// takePendingReview returns and forgets review feedback waiting for a story's next merge request.
+func (d *Driver) takePendingReview(storyID string) *proto.MergeFailureFeedback {
+	comments := d.pendingReviews[storyID]
+	if len(comments) == 0 {
+		return nil
+	}
+	delete(d.pendingReviews, storyID)
+	return reviewFeedback(comments)
+}
+
+// reopenStoryForReview turns review comments on a merged story's PR into a
+// follow-up story, since completed stories are immutable.
+func (d *Driver) reopenStoryForReview(review *prReview) error {
+	story, ok := d.queue.GetStory(review.storyID)
+	if !ok {
+		return fmt.Errorf("story %s not found", review.storyID)
+	}
+	followUpID, err := persistence.GenerateStoryID()
+	if err != nil {
+		return fmt.Errorf("failed to generate follow-up story ID: %w", err)
+	}
+
+	content := fmt.Sprintf("Story %s (%s) was merged in %s, and a reviewer has since left comments on it. "+
+		"Address each comment below.\n\n## Original Story\n\n%s\n\n## Review Comments\n%s",
+		story.ID, story.Title, review.prRef, story.Content, reviewFeedback(review.comments).String())
+	d.queue.AddStory(followUpID, story.SpecID, story.Title+" (review follow-up)", content, story.StoryType, []string{story.ID}, 1)
+	d.queue.AddRequirementIDs(followUpID, slices.Clone(story.RequirementIDs))
+	d.queue.SetStoryRepo(followUpID, story.Repo)
+	d.reviewFollowUps[followUpID] = &reviewFollowUp{prRef: review.prRef, comments: review.comments}
+	d.logger.Info("💬 Review comments on merged story %s reopened it as %s", story.ID, followUpID)
+	return nil
+}
+
+// replyToReviewFollowUp answers the comments a merged follow-up story addressed on the original PR.
+func (d *Driver) replyToReviewFollowUp(ctx context.Context, storyID, prURL, commitSHA string) {
+	followUp, ok := d.reviewFollowUps[storyID]
+	if !ok {
+		return
+	}
+	delete(d.reviewFollowUps, storyID)
+
+	forgeClient, err := d.storyForgeClient(storyID)
+	if err != nil {
+		d.logger.Warn("💬 Cannot reply to review comments on %s: %v", followUp.prRef, err)
+		return
+	}
+	reply := fmt.Sprintf("Addressed in %s (commit %s).", prURL, shortCheckSHA(commitSHA))
+	for i := range followUp.comments {
+		if err := forgeClient.ReplyToPRComment(ctx, followUp.prRef, followUp.comments[i], reply); err != nil {
+			d.logger.Warn("💬 Failed to reply to review comment %s on %s: %v", followUp.comments[i].Key(), followUp.prRef, err)
+		}
+	}
+}
+
+// reviewFeedback describes review comments for the coder.
+func reviewFeedback(comments []forge.PRComment) *proto.MergeFailureFeedback {
+	feedback := &proto.MergeFailureFeedback{Stage: proto.MergeStageReview}
+	for i := range comments {
+		feedback.Comments = append(feedback.Comments, proto.ReviewComment{
+			ID:     comments[i].ID,
+			Kind:   string(comments[i].Kind),
+			Author: comments[i].Author,
+			Body:   comments[i].Body,
+			Path:   comments[i].Path,
+			Line:   comments[i].Line,
+		})
+	}
+	return feedback
+}
@@ -0,0 +1,162 @@
+package architect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+// reviewGitHubClient adds simulated PR comments to the merge mock.
+type reviewGitHubClient struct {
+	*mockGitHubMergeClient
+	comments []forge.PRComment
+	replies  []string
+}
+
+func (c *reviewGitHubClient) ListPRComments(_ context.Context, _ string) ([]forge.PRComment, error) {
+	return c.comments, nil
+}
+
+func (c *reviewGitHubClient) ReplyToPRComment(_ context.Context, _ string, comment forge.PRComment, body string) error {
+	c.replies = append(c.replies, comment.Key()+": "+body)
+	return nil
+}
+
+// newReviewTestDriver returns an amendment test driver with review routing state.
+func newReviewTestDriver(t *testing.T) *Driver {
+	t.Helper()
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{TargetBranch: "main"}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+	driver := newAmendmentTestDriver(t)
+	driver.gitHubClient = newMockGitHubClient()
+	driver.checkWaits = make(map[string]*checkWait)
+	driver.watchedPRs = make(map[string]*watchedPR)
+	driver.pendingReviews = make(map[string][]forge.PRComment)
+	driver.reviewFollowUps = make(map[string]*reviewFollowUp)
+	return driver
+}
+
+func lineComment(id int64, body string) forge.PRComment {
+	return forge.PRComment{ID: id, Kind: forge.PRCommentLine, Author: "alice", Body: body, Path: "login.go", Line: 12}
+}
+
+// TestPollPRReview_OnlyNewHumanComments verifies Maestro's own comments, bots
+// and already-routed comments are skipped.
+func TestPollPRReview_OnlyNewHumanComments(t *testing.T) {
+	driver := newReviewTestDriver(t)
+	client := &reviewGitHubClient{mockGitHubMergeClient: newMockGitHubClient(), comments: []forge.PRComment{
+		lineComment(1, "Handle the empty password case."),
+		{ID: 2, Kind: forge.PRCommentConversation, Author: "maestro", Body: "Addressed in abc123.\n\n" + forge.CommentMarker},
+		{ID: 3, Kind: forge.PRCommentConversation, Author: "dependabot[bot]", Body: "Bump"},
+	}}
+	driver.watchPR("story-pending", "https://github.com/test/repo/pull/7")
+	w := driver.watchedPRSnapshot()[0]
+	forgeClient := &mockForgeAdapter{mock: client}
+
+	review := driver.pollPRReview(context.Background(), forgeClient, w)
+	require.NotNil(t, review)
+	require.Len(t, review.comments, 1)
+	assert.Equal(t, int64(1), review.comments[0].ID)
+
+	assert.Nil(t, driver.pollPRReview(context.Background(), forgeClient, w), "comments are routed once")
+}
+
+// TestPollPRReview_RestartSkipsAnsweredComments verifies a watch started after
+// a restart does not send comments Maestro already replied to again.
+func TestPollPRReview_RestartSkipsAnsweredComments(t *testing.T) {
+	driver := newReviewTestDriver(t)
+	start := time.Now().Add(-time.Hour)
+	answered := lineComment(1, "Handle the empty password case.")
+	answered.CreatedAt = start
+	reply := forge.PRComment{ID: 2, Kind: forge.PRCommentLine, Author: "maestro", Body: "Addressed in abc123.\n\n" + forge.CommentMarker, CreatedAt: start.Add(10 * time.Minute)}
+	later := lineComment(3, "Also trim the username.")
+	later.CreatedAt = start.Add(20 * time.Minute)
+	client := &reviewGitHubClient{mockGitHubMergeClient: newMockGitHubClient(), comments: []forge.PRComment{answered, reply, later}}
+
+	driver.watchPR("story-pending", "https://github.com/test/repo/pull/7")
+	w := driver.watchedPRSnapshot()[0]
+
+	review := driver.pollPRReview(context.Background(), &mockForgeAdapter{mock: client}, w)
+	require.NotNil(t, review)
+	require.Len(t, review.comments, 1)
+	assert.Equal(t, int64(3), review.comments[0].ID)
+}
+
+// TestRoutePRReview_GatesNextMerge verifies comments on a story in progress are
+// sent to its coder as a change request with its next merge response.
+func TestRoutePRReview_GatesNextMerge(t *testing.T) {
+	driver := newReviewTestDriver(t)
+
+	request, reopen := driver.routePRReview(&prReview{storyID: "story-pending", prRef: "7", comments: []forge.PRComment{lineComment(1, "Handle the empty password case.")}})
+	assert.Nil(t, request)
+	assert.False(t, reopen)
+
+	merge := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-001", "architect")
+	merge.Metadata = map[string]string{proto.KeyStoryID: "story-pending"}
+	merge.SetTypedPayload(proto.NewMergeRequestPayload(&proto.MergeRequestPayload{PRURL: "https://github.com/test/repo/pull/7"}))
+
+	result, err := driver.handleMergeRequest(context.Background(), merge)
+	require.NoError(t, err)
+	resp := extractMergeResponse(t, result)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+	assert.Contains(t, resp.Feedback, "login.go:12")
+	assert.Contains(t, resp.Feedback, "empty password")
+
+	feedback, err := proto.DecodeMergeFailureFeedback(resp.Metadata[proto.KeyMergeFailureFeedback])
+	require.NoError(t, err)
+	assert.Equal(t, proto.MergeStageReview, feedback.Stage)
+	require.Len(t, feedback.Comments, 1)
+	assert.Equal(t, "line", feedback.Comments[0].Kind)
+	assert.Empty(t, driver.pendingReviews)
+}
+
+// TestRoutePRReview_ReleasesHeldMerge verifies a merge held for CI checks is
+// answered with the review comments right away.
+func TestRoutePRReview_ReleasesHeldMerge(t *testing.T) {
+	driver := newReviewTestDriver(t)
+	held := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-001", "architect")
+	held.Metadata = map[string]string{proto.KeyStoryID: "story-pending"}
+	driver.checkWaits[held.ID] = &checkWait{request: held}
+
+	request, _ := driver.routePRReview(&prReview{storyID: "story-pending", comments: []forge.PRComment{lineComment(1, "Rename this.")}})
+	require.Same(t, held, request)
+	assert.Equal(t, proto.MergeStageReview, driver.checkWaits[held.ID].feedback.Stage)
+
+	// The checks watcher finishing later must not overwrite the review
+	assert.Nil(t, driver.receiveCheckResult(&checkResult{requestID: held.ID}))
+}
+
+// TestRoutePRReview_ReopensMergedStory verifies comments on a merged PR become
+// a follow-up story whose merge answers them on the original PR.
+func TestRoutePRReview_ReopensMergedStory(t *testing.T) {
+	driver := newReviewTestDriver(t)
+	client := &reviewGitHubClient{mockGitHubMergeClient: newMockGitHubClient()}
+	driver.gitHubClient = client
+	review := &prReview{storyID: "story-done", prRef: "https://github.com/test/repo/pull/3", comments: []forge.PRComment{lineComment(5, "This leaks the session token.")}}
+
+	_, reopen := driver.routePRReview(review)
+	require.True(t, reopen)
+	require.NoError(t, driver.reopenStoryForReview(review))
+
+	require.Len(t, driver.reviewFollowUps, 1)
+	var followUpID string
+	for id := range driver.reviewFollowUps {
+		followUpID = id
+	}
+	story, ok := driver.queue.GetStory(followUpID)
+	require.True(t, ok)
+	assert.Equal(t, []string{"story-done"}, story.DependsOn)
+	assert.Contains(t, story.Content, "This leaks the session token.")
+
+	driver.replyToReviewFollowUp(context.Background(), followUpID, "https://github.com/test/repo/pull/9", "0123456789abcdef")
+	assert.Equal(t, []string{"line-5: Addressed in https://github.com/test/repo/pull/9 (commit 01234567)."}, client.replies)
+	assert.Empty(t, driver.reviewFollowUps)
+}
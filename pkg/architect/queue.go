@@ -3,10 +3,12 @@ package architect
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sort"
 	"sync"
 	"time"
 
+	"orchestrator/pkg/config"
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/persistence"
 	"orchestrator/pkg/proto"
@@ -133,6 +135,8 @@ type Queue struct {
 	persistenceChannel chan<- *persistence.Request // Channel for database operations
 	dispatchSuppressed bool                        // When true, GetReadyStories returns empty (system repair in progress)
 	suppressReason     string                      // Why dispatch is suppressed (for logging)
+	approvedBranches   map[string]string           // Pushed branches of stories whose code is approved but not merged (stacked PRs)
+	stackedOn          map[string]string           // Stacked story → the story whose branch it was dispatched on
 }
 
 // NewQueue creates a new queue manager with database persistence.
@@ -140,6 +144,8 @@ func NewQueue(persistenceChannel chan<- *persistence.Request) *Queue {
 	return &Queue{
 		stories:            make(map[string]*QueuedStory),
 		persistenceChannel: persistenceChannel,
+		approvedBranches:   make(map[string]string),
+		stackedOn:          make(map[string]string),
 		// readyStoryCh will be set by SetReadyChannel.
 	}
 }
@@ -186,6 +192,34 @@ func (q *Queue) AddStory(storyID, specID, title, content, storyType string, depe
 	q.checkAndNotifyReady()
 }
 
+// AddRequirementIDs records spec requirement IDs (R-001) a story covers, for traceability.
+// IDs already recorded are not duplicated.
+func (q *Queue) AddRequirementIDs(storyID string, requirementIDs []string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	story, exists := q.stories[storyID]
+	if !exists {
+		return
+	}
+	for _, id := range requirementIDs {
+		if id != "" && !slices.Contains(story.RequirementIDs, id) {
+			story.RequirementIDs = append(story.RequirementIDs, id)
+		}
+	}
+}
+
+// SetStoryRepo records the repository a story changes in a multi-repo product
+// ("" for the primary repository).
+func (q *Queue) SetStoryRepo(storyID, repo string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if story, exists := q.stories[storyID]; exists {
+		story.Repo = repo
+	}
+}
+
 // FlushToDatabase writes all in-memory stories and dependencies to the database for persistence.
 // This uses the new persistence functions and ensures proper ordering (stories first, then dependencies).
 func (q *Queue) FlushToDatabase() {
@@ -197,20 +231,29 @@ func (q *Queue) FlushToDatabase() {
 	for _, queuedStory := range q.stories {
 		// Convert QueuedStory to persistence.Story with complete data
 		dbStory := &persistence.Story{
-			ID:            queuedStory.ID,
-			SpecID:        queuedStory.SpecID,
-			Title:         queuedStory.Title,
-			Content:       queuedStory.Content,      // Now includes story content
-			ApprovedPlan:  queuedStory.ApprovedPlan, // Now includes approved plan
-			Status:        queuedStory.GetStatus().ToDatabaseStatus(),
-			Priority:      queuedStory.Priority,
-			CreatedAt:     queuedStory.LastUpdated,
-			StartedAt:     queuedStory.StartedAt,
-			CompletedAt:   queuedStory.CompletedAt,
-			AssignedAgent: queuedStory.AssignedAgent,
-			StoryType:     queuedStory.StoryType,
-			TokensUsed:    0,   // Metrics data added during completion
-			CostUSD:       0.0, // Metrics data added during completion
+			ID:             queuedStory.ID,
+			SpecID:         queuedStory.SpecID,
+			Title:          queuedStory.Title,
+			Content:        queuedStory.Content,      // Now includes story content
+			ApprovedPlan:   queuedStory.ApprovedPlan, // Now includes approved plan
+			Status:         queuedStory.GetStatus().ToDatabaseStatus(),
+			Priority:       queuedStory.Priority,
+			CreatedAt:      queuedStory.LastUpdated,
+			StartedAt:      queuedStory.StartedAt,
+			CompletedAt:    queuedStory.CompletedAt,
+			AssignedAgent:  queuedStory.AssignedAgent,
+			StoryType:      queuedStory.StoryType,
+			Repo:           queuedStory.Repo,
+			PredictedPaths: queuedStory.PredictedPaths,
+			TokensUsed:     0,   // Metrics data added during completion
+			CostUSD:        0.0, // Metrics data added during completion
+
+			// Completion and traceability data, so a flush never clears it
+			PRID:              queuedStory.PRID,
+			CommitHash:        queuedStory.CommitHash,
+			CompletionSummary: queuedStory.CompletionSummary,
+			RequirementIDs:    queuedStory.RequirementIDs,
+			Verification:      queuedStory.Verification,
 		}
 
 		persistence.PersistStory(dbStory, q.persistenceChannel)
@@ -236,18 +279,22 @@ func (q *Queue) NextReadyStory() *QueuedStory {
 		return nil
 	}
 
-	// Sort by priority (higher first), then by estimated points (smaller first), then by ID for deterministic ordering.
-	sort.Slice(ready, func(i, j int) bool {
-		if ready[i].Priority == ready[j].Priority {
-			if ready[i].EstimatedPoints == ready[j].EstimatedPoints {
-				return ready[i].ID < ready[j].ID
+	sortByPriority(ready)
+	return ready[0]
+}
+
+// sortByPriority sorts stories by priority (higher first), then by estimated
+// points (smaller first), then by ID for deterministic ordering.
+func sortByPriority(stories []*QueuedStory) {
+	sort.Slice(stories, func(i, j int) bool {
+		if stories[i].Priority == stories[j].Priority {
+			if stories[i].EstimatedPoints == stories[j].EstimatedPoints {
+				return stories[i].ID < stories[j].ID
 			}
-			return ready[i].EstimatedPoints < ready[j].EstimatedPoints
+			return stories[i].EstimatedPoints < stories[j].EstimatedPoints
 		}
-		return ready[i].Priority > ready[j].Priority // Higher priority first
+		return stories[i].Priority > stories[j].Priority // Higher priority first
 	})
-
-	return ready[0]
 }
 
 // SuppressDispatch prevents new story dispatch during system-level repair.
@@ -291,8 +338,8 @@ func (q *Queue) GetReadyStories() []*QueuedStory {
 			continue
 		}
 
-		// Check if all dependencies are completed.
-		if q.areDependenciesMetLocked(story) {
+		// Check if all dependencies are completed, or the one left can be stacked on.
+		if q.areDependenciesMetLocked(story) || q.stackParentLocked(story) != nil {
 			ready = append(ready, story)
 		}
 	}
@@ -432,6 +479,117 @@ func (q *Queue) areDependenciesMetLocked(story *QueuedStory) bool {
 	return true
 }
 
+// stackParentLocked returns the dependency a pending story can be stacked on:
+// the only unmet dependency, once its code is approved and pushed. Stories
+// stack on a dependency in the same repository when stacked PRs are enabled,
+// and always on one in another repository of a multi-repo product. Its merge
+// still waits for areDependenciesMetLocked.
+// Must be called with mutex held (read or write).
+func (q *Queue) stackParentLocked(story *QueuedStory) *QueuedStory {
+	if story.IsHotfix {
+		return nil
+	}
+	var parent *QueuedStory
+	for _, depID := range story.DependsOn {
+		dep, exists := q.stories[depID]
+		if !exists {
+			return nil
+		}
+		if dep.GetStatus() == StatusDone {
+			continue
+		}
+		if parent != nil || dep.GetStatus() != StatusCoding || q.approvedBranches[depID] == "" {
+			return nil
+		}
+		parent = dep
+	}
+	if parent != nil && parent.Repo == story.Repo && !config.StackedPRsEnabled() {
+		return nil
+	}
+	return parent
+}
+
+// SetApprovedBranch records the pushed branch of a story whose code is
+// approved, so dependents can be stacked on it until it merges.
+func (q *Queue) SetApprovedBranch(storyID, branch string) {
+	q.mutex.Lock()
+	if q.approvedBranches == nil {
+		q.approvedBranches = make(map[string]string)
+	}
+	q.approvedBranches[storyID] = branch
+	q.mutex.Unlock()
+
+	q.checkAndNotifyReady()
+}
+
+// StackStory records the dispatch of a story. Returns the story it is
+// stacked on and the branch to start from, or empty strings when its
+// dependencies are met.
+func (q *Queue) StackStory(storyID string) (parentID, branch string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	delete(q.stackedOn, storyID)
+	story, exists := q.stories[storyID]
+	if !exists || q.areDependenciesMetLocked(story) {
+		return "", ""
+	}
+	parent := q.stackParentLocked(story)
+	if parent == nil {
+		return "", ""
+	}
+	if q.stackedOn == nil {
+		q.stackedOn = make(map[string]string)
+	}
+	q.stackedOn[storyID] = parent.ID
+	return parent.ID, q.approvedBranches[parent.ID]
+}
+
+// ApprovedBranch returns the pushed branch of a story whose code is approved, or "".
+func (q *Queue) ApprovedBranch(storyID string) string {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return q.approvedBranches[storyID]
+}
+
+// StackParent returns the story a stacked story was dispatched on, or "".
+func (q *Queue) StackParent(storyID string) string {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return q.stackedOn[storyID]
+}
+
+// Unstack records that a stacked story's PR now targets its own base branch.
+func (q *Queue) Unstack(storyID string) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	delete(q.stackedOn, storyID)
+}
+
+// UnmetDependency returns the first of a story's dependencies that is not done, or "".
+func (q *Queue) UnmetDependency(storyID string) string {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	story, exists := q.stories[storyID]
+	if !exists {
+		return ""
+	}
+	for _, depID := range story.DependsOn {
+		if dep, ok := q.stories[depID]; !ok || dep.GetStatus() != StatusDone {
+			return depID
+		}
+	}
+	return ""
+}
+
+// DependenciesMet reports whether all of a story's dependencies are done.
+func (q *Queue) DependenciesMet(storyID string) bool {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	story, exists := q.stories[storyID]
+	return exists && q.areDependenciesMetLocked(story)
+}
+
 // checkAndNotifyReady checks for stories that became ready and notifies via channel.
 // Must be called without holding the lock - it acquires its own read lock.
 func (q *Queue) checkAndNotifyReady() {
@@ -443,7 +601,7 @@ func (q *Queue) checkAndNotifyReady() {
 	q.mutex.RLock()
 	var readyIDs []string
 	for _, story := range q.stories {
-		if story.GetStatus() == StatusPending && q.areDependenciesMetLocked(story) {
+		if story.GetStatus() == StatusPending && (q.areDependenciesMetLocked(story) || q.stackParentLocked(story) != nil) {
 			readyIDs = append(readyIDs, story.ID)
 		}
 	}
@@ -511,6 +669,7 @@ func (q *Queue) RequeueStory(storyID string) error {
 	story.ApprovedPlan = "" // Clear approved plan for fresh start
 	story.StartedAt = nil
 	story.LastUpdated = time.Now().UTC()
+	delete(q.approvedBranches, storyID) // Nothing can be stacked on its old branch
 
 	return nil
 }
@@ -733,6 +892,10 @@ func (q *Queue) UpdateStoryStatus(storyID string, status StoryStatus) error {
 		return err
 	}
 	story.LastUpdated = time.Now().UTC()
+	if status == StatusPending {
+		// A story sent back to pending starts over; nothing can be stacked on its old branch
+		delete(q.approvedBranches, storyID)
+	}
 	q.mutex.Unlock() // Release before persistence
 
 	// Persist to database (no mutex needed)
@@ -805,6 +968,8 @@ func (q *Queue) ClearAll() {
 	defer q.mutex.Unlock()
 
 	q.stories = make(map[string]*QueuedStory)
+	q.approvedBranches = make(map[string]string)
+	q.stackedOn = make(map[string]string)
 }
 
 // ClearSpec removes only stories belonging to the given spec from the queue.
@@ -899,7 +1064,7 @@ func (q *Queue) checkAndNotifyReadyLocked() {
 	}
 
 	for storyID, story := range q.stories {
-		if story.GetStatus() == StatusPending && q.areDependenciesSatisfiedLocked(storyID) {
+		if story.GetStatus() == StatusPending && (q.areDependenciesSatisfiedLocked(storyID) || q.stackParentLocked(story) != nil) {
 			// Non-blocking send
 			select {
 			case q.readyStoryCh <- storyID:
@@ -44,6 +44,16 @@ func (d *Driver) handleRequest(ctx context.Context) (proto.State, error) {
 		return StateMonitoring, nil
 	}
 
+	// Check for review comments on a merged story (set by MONITORING)
+	if review, ok := stateData[StateKeyPRReviewPending].(*prReview); ok && review != nil {
+		d.SetStateData(StateKeyPRReviewPending, nil)
+		if err := d.reopenStoryForReview(review); err != nil {
+			d.logger.Error("Failed to reopen story %s for review comments: %v", review.storyID, err)
+			return StateMonitoring, nil
+		}
+		return StateDispatching, nil
+	}
+
 	// Get the current request from state data.
 	requestMsg, exists := stateData[StateKeyCurrentRequest].(*proto.AgentMsg)
 	if !exists || requestMsg == nil {
@@ -464,23 +474,27 @@ func (d *Driver) handleApprovalRequest(ctx context.Context, requestMsg *proto.Ag
 					d.logger.Error("Failed to set approved plan for story %s: %v", storyIDStr, err)
 				} else {
 					d.logger.Info("✅ Set approved plan for story %s", storyIDStr)
+					d.refreshFootprint(ctx, storyIDStr)
 					// Persist just this story to database with the updated approved plan
 					if story, exists := d.queue.GetStory(storyIDStr); exists {
 						dbStory := &persistence.Story{
-							ID:            story.ID,
-							SpecID:        story.SpecID,
-							Title:         story.Title,
-							Content:       story.Content,
-							ApprovedPlan:  story.ApprovedPlan,
-							Status:        story.GetStatus().ToDatabaseStatus(),
-							Priority:      story.Priority,
-							CreatedAt:     story.LastUpdated,
-							StartedAt:     story.StartedAt,
-							CompletedAt:   story.CompletedAt,
-							AssignedAgent: story.AssignedAgent,
-							StoryType:     story.StoryType,
-							TokensUsed:    0,   // Metrics data added during completion
-							CostUSD:       0.0, // Metrics data added during completion
+							ID:             story.ID,
+							SpecID:         story.SpecID,
+							Title:          story.Title,
+							Content:        story.Content,
+							ApprovedPlan:   story.ApprovedPlan,
+							Status:         story.GetStatus().ToDatabaseStatus(),
+							Priority:       story.Priority,
+							CreatedAt:      story.LastUpdated,
+							StartedAt:      story.StartedAt,
+							CompletedAt:    story.CompletedAt,
+							AssignedAgent:  story.AssignedAgent,
+							StoryType:      story.StoryType,
+							Repo:           story.Repo,
+							RequirementIDs: story.RequirementIDs,
+							PredictedPaths: story.PredictedPaths,
+							TokensUsed:     0,   // Metrics data added during completion
+							CostUSD:        0.0, // Metrics data added during completion
 						}
 
 						persistence.PersistStory(dbStory, d.persistenceChannel)
@@ -529,6 +543,7 @@ func (d *Driver) handleRequeueRequest(_ /* ctx */ context.Context, requeueMsg *p
 	if err := d.queue.UpdateStoryStatus(storyIDStr, StatusPending); err != nil {
 		return fmt.Errorf("failed to requeue story %s: %w", storyIDStr, err)
 	}
+	d.rejectStackedMerges(storyIDStr, "was requeued to be redone")
 
 	// Log the requeue event - this will appear in the architect logs.
 	// Requeue completed successfully
@@ -735,6 +750,9 @@ func (d *Driver) checkSpecCompletion(ctx context.Context, storyID string) {
 			}
 		}
 
+		// Open the epic PR for the human Accept when the spec's stories merged into an epic branch
+		d.openEpicPR(ctx, specID)
+
 		// onSpecComplete runs synchronously — may add maintenance stories to queue
 		d.onSpecComplete(ctx, specID)
 	} else {
@@ -807,12 +825,18 @@ func (d *Driver) notifyPMAllStoriesComplete(ctx context.Context) error {
 		}
 	}
 
+	// Flag spec requirements that no story implemented
+	message := "All development stories have been completed successfully."
+	if specID != "" {
+		message += d.uncoveredRequirementsNote(specID)
+	}
+
 	// Build notification payload
 	notificationPayload := &proto.AllStoriesCompletePayload{
 		SpecID:       specID,
 		TotalStories: totalStories,
 		Timestamp:    time.Now().UTC().Format(time.RFC3339),
-		Message:      "All development stories have been completed successfully.",
+		Message:      message,
 		DemoReady:    true, // Assume demo is ready when all stories complete
 	}
 
@@ -1417,23 +1441,40 @@ func (d *Driver) attemptStoryEdit(ctx context.Context, cm *contextmgr.ContextMan
 		return
 	}
 
-	// Check for full story rewrite first (takes precedence over notes)
 	revisedContent, _ := utils.SafeAssert[string](effectData["revised_content"])
-	if revisedContent != "" {
-		story.Content = revisedContent
+	notes, _ := utils.SafeAssert[string](effectData["notes"])
+	switch applyStoryEdit(story, notes, revisedContent, "Implementation Notes (Auto-generated)") {
+	case storyEditRewritten:
 		d.logger.Info("📝 Replaced story content for %s (%d chars) — architect rewrote the story", storyID, len(revisedContent))
-		return
+	case storyEditAnnotated:
+		d.logger.Info("📝 Appended implementation notes to story %s (%d chars)", storyID, len(notes))
+	default:
+		d.logger.Info("📝 Architect provided no edits for story %s", storyID)
 	}
+}
 
-	// Fall back to appending implementation notes
-	notes, _ := utils.SafeAssert[string](effectData["notes"])
+// storyEditResult reports which story_edit change applyStoryEdit made.
+type storyEditResult int
+
+const (
+	storyEditNone storyEditResult = iota
+	storyEditRewritten
+	storyEditAnnotated
+)
+
+// applyStoryEdit applies story_edit semantics to a story: revised content
+// replaces the story (taking precedence over notes), otherwise non-empty notes
+// are appended under the given heading.
+func applyStoryEdit(story *QueuedStory, notes, revisedContent, notesHeading string) storyEditResult {
+	if revisedContent != "" {
+		story.Content = revisedContent
+		return storyEditRewritten
+	}
 	if notes == "" {
-		d.logger.Info("📝 Architect provided no edits for story %s", storyID)
-		return
+		return storyEditNone
 	}
-
-	story.Content += "\n\n## Implementation Notes (Auto-generated)\n\n" + notes
-	d.logger.Info("📝 Appended implementation notes to story %s (%d chars)", storyID, len(notes))
+	story.Content += "\n\n## " + notesHeading + "\n\n" + notes
+	return storyEditAnnotated
 }
 
 // buildStoryEditPrompt renders the story edit template for the architect.
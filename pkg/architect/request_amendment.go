@@ -0,0 +1,448 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/agent/toolloop"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/proto"
+	"orchestrator/pkg/templates"
+	"orchestrator/pkg/tools"
+	"orchestrator/pkg/utils"
+)
+
+// amendmentStoryUpdate is one story_updates entry of a submit_amendment call.
+type amendmentStoryUpdate struct {
+	StoryID        string
+	Action         string
+	Notes          string
+	RevisedContent string
+	RequirementIDs []string
+}
+
+// handleSpecAmendment processes an amendment to the approved spec from PM.
+// Only the requirement-level diff is reviewed (review_complete); once approved,
+// submit_amendment maps the changes onto the spec's existing stories, which are
+// edited, reopened with a follow-up story, or joined by new stories.
+func (d *Driver) handleSpecAmendment(ctx context.Context, requestMsg *proto.AgentMsg, approvalPayload *proto.ApprovalRequestPayload) (*proto.AgentMsg, error) {
+	diff := approvalPayload.Metadata["spec_diff"]
+	if diff == "" {
+		return nil, fmt.Errorf("spec amendment request has no spec_diff metadata")
+	}
+	version, err := strconv.Atoi(approvalPayload.Metadata["spec_version"])
+	if err != nil || version < 2 {
+		version = 2
+	}
+
+	specID := d.amendableSpecID()
+	if specID == "" {
+		return d.specReviewResponse(requestMsg, proto.ApprovalStatusNeedsChanges,
+			"There is no spec in development to amend. Submit the revised specification as a new spec (amendment=false)."), nil
+	}
+	d.logger.Info("🔍 Architect reviewing amendment of spec %s (v%d)", specID, version)
+
+	stories := d.specStories(specID)
+	extra := map[string]any{
+		"spec_id":      specID,
+		"spec_version": version,
+		"stories":      stories,
+	}
+
+	cm := d.getContextForAgent(requestMsg.FromAgent)
+	prompt, err := d.renderer.RenderWithUserInstructions(templates.SpecAmendmentReviewTemplate,
+		&templates.TemplateData{TaskContent: diff, Extra: extra}, d.workDir, "ARCHITECT")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render spec amendment review template: %w", err)
+	}
+	cm.AddMessage("user", prompt)
+
+	// FIRST TOOLLOOP: review only the delta with review_complete
+	specReviewTools := d.getSpecReviewTools()
+	var reviewCompleteTool tools.Tool
+	var generalTools []tools.Tool
+	for _, tool := range specReviewTools {
+		switch tool.Name() {
+		case tools.ToolReviewComplete:
+			reviewCompleteTool = tool
+		case tools.ToolSubmitStories:
+			// Not used for amendments
+		default:
+			generalTools = append(generalTools, tool)
+		}
+	}
+	if reviewCompleteTool == nil {
+		return nil, fmt.Errorf("review_complete tool not found in spec review tools")
+	}
+
+	reviewOut := toolloop.Run(d.toolLoop, ctx, &toolloop.Config[ReviewCompleteResult]{
+		ContextManager:     cm,
+		GeneralTools:       generalTools,
+		TerminalTool:       reviewCompleteTool,
+		MaxIterations:      20, // The delta is small; exploration is for checking it against existing code
+		MaxTokens:          agent.ArchitectMaxTokens,
+		Temperature:        config.GetTemperature(config.TempRoleArchitect),
+		AgentID:            d.GetAgentID(),
+		DebugLogging:       config.GetDebugLLMMessages(),
+		PersistenceChannel: d.persistenceChannel,
+		OnLLMError:         d.makeOnLLMErrorCallback("spec_amendment_review"),
+	})
+	if reviewOut.Kind != toolloop.OutcomeProcessEffect {
+		return nil, fmt.Errorf("spec amendment review failed: %w", reviewOut.Err)
+	}
+	if reviewOut.Signal != tools.SignalReviewComplete {
+		return nil, fmt.Errorf("expected REVIEW_COMPLETE signal, got: %s", reviewOut.Signal)
+	}
+	effectData, ok := utils.SafeAssert[map[string]any](reviewOut.EffectData)
+	if !ok {
+		return nil, fmt.Errorf("REVIEW_COMPLETE effect data is not map[string]any: %T", reviewOut.EffectData)
+	}
+
+	status := utils.GetMapFieldOr[string](effectData, "status", "")
+	feedback := utils.GetMapFieldOr[string](effectData, "feedback", "")
+	d.logger.Info("✅ Spec amendment review completed with status: %s", status)
+
+	if status != "APPROVED" {
+		approvalStatus := proto.ApprovalStatusNeedsChanges
+		if status == "REJECTED" {
+			approvalStatus = proto.ApprovalStatusRejected
+		}
+		return d.specReviewResponse(requestMsg, approvalStatus, feedback), nil
+	}
+
+	// SECOND TOOLLOOP: map the changes onto the stories with submit_amendment
+	storiesPrompt, err := d.renderer.RenderWithUserInstructions(templates.SpecAmendmentStoriesTemplate,
+		&templates.TemplateData{TaskContent: diff, Extra: extra}, d.workDir, "ARCHITECT")
+	if err != nil {
+		return nil, fmt.Errorf("failed to render spec amendment stories template: %w", err)
+	}
+	cm.AddMessage("user", storiesPrompt)
+
+	const maxAmendmentRetries = 2
+	var storyIDs []string
+	var applyErr error
+	for attempt := 0; attempt <= maxAmendmentRetries; attempt++ {
+		if attempt > 0 {
+			cm.AddMessage("user", fmt.Sprintf(
+				"Your submit_amendment call could not be applied: %s\nPlease call submit_amendment again with corrected story updates.",
+				applyErr.Error()))
+			d.logger.Warn("🔄 Amendment mapping retry %d/%d: %v", attempt, maxAmendmentRetries, applyErr)
+		}
+
+		out := toolloop.Run(d.toolLoop, ctx, &toolloop.Config[SubmitAmendmentResult]{
+			ContextManager:     cm,
+			GeneralTools:       nil,
+			TerminalTool:       tools.NewSubmitAmendmentTool(),
+			MaxIterations:      5,
+			SingleTurn:         true,
+			MaxTokens:          agent.ArchitectMaxTokens,
+			Temperature:        config.GetTemperature(config.TempRoleArchitect),
+			AgentID:            d.GetAgentID(),
+			DebugLogging:       config.GetDebugLLMMessages(),
+			PersistenceChannel: d.persistenceChannel,
+			OnLLMError:         d.makeOnLLMErrorCallback("spec_amendment_stories"),
+		})
+		if out.Kind != toolloop.OutcomeProcessEffect {
+			return nil, fmt.Errorf("amendment story mapping failed: %w", out.Err)
+		}
+		if out.Signal != tools.SignalAmendmentSubmitted {
+			return nil, fmt.Errorf("expected AMENDMENT_SUBMITTED signal, got: %s", out.Signal)
+		}
+		amendmentData, dataOk := utils.SafeAssert[map[string]any](out.EffectData)
+		if !dataOk {
+			return nil, fmt.Errorf("AMENDMENT_SUBMITTED effect data is not map[string]any: %T", out.EffectData)
+		}
+
+		storyIDs, applyErr = d.applyAmendment(specID, version, amendmentData)
+		if applyErr == nil {
+			break
+		}
+		d.logger.Warn("⚠️ Amendment mapping attempt %d failed validation: %v", attempt+1, applyErr)
+	}
+
+	if applyErr != nil {
+		d.logger.Error("❌ Amendment mapping failed after %d attempts: %v", maxAmendmentRetries+1, applyErr)
+		return d.specReviewResponse(requestMsg, proto.ApprovalStatusNeedsChanges,
+			fmt.Sprintf("The amendment could not be applied to the existing stories: %s. Please clarify the requirement changes.", applyErr.Error())), nil
+	}
+
+	// Record the new spec version with the diff that produced it
+	now := time.Now()
+	persistence.PersistSpec(&persistence.Spec{
+		ID:          specID,
+		Content:     approvalPayload.Content,
+		Version:     version,
+		Diff:        diff,
+		CreatedAt:   now,
+		ProcessedAt: &now,
+	}, d.persistenceChannel)
+
+	// New work reopens the spec: PM must be notified again when it completes
+	d.pmAllCompleteNotified = false
+	d.pmAllTerminalNotified = false
+	d.SetStateData(StateKeySpecID, specID)
+	d.SetStateData(StateKeySpecApprovedLoad, true)
+
+	d.logger.Info("✅ Amendment applied to spec %s (v%d): %d stories updated or added", specID, version, len(storyIDs))
+	return d.specReviewResponse(requestMsg, proto.ApprovalStatusApproved,
+		fmt.Sprintf("Spec amendment approved - spec %s is now version %d (%d stories updated or added)", specID, version, len(storyIDs))), nil
+}
+
+// amendableSpecID returns the spec an amendment applies to: the most recently
+// loaded spec, or (after a restart) the spec of the newest spec-driven story.
+func (d *Driver) amendableSpecID() string {
+	if specID := utils.GetStateValueOr[string](d.BaseStateMachine, StateKeySpecID, ""); specID != "" {
+		return specID
+	}
+	var newest *QueuedStory
+	for _, story := range d.queue.GetAllStories() {
+		if story.IsHotfix || story.IsMaintenance || story.SpecID == "" || story.SpecID == "hotfix" {
+			continue
+		}
+		if newest == nil || story.CreatedAt.After(newest.CreatedAt) {
+			newest = story
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+	return newest.SpecID
+}
+
+// specStories returns the stories of a spec, oldest first.
+func (d *Driver) specStories(specID string) []*QueuedStory {
+	var stories []*QueuedStory
+	for _, story := range d.queue.GetAllStories() {
+		if story.SpecID == specID {
+			stories = append(stories, story)
+		}
+	}
+	slices.SortFunc(stories, func(a, b *QueuedStory) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return stories
+}
+
+// applyAmendment validates a submit_amendment result against the queue and applies it.
+// Everything is validated before the queue changes, so a rejected mapping can be resubmitted.
+// Returns the IDs of the edited, follow-up, and new stories.
+//
+//nolint:cyclop // Validation then application of three kinds of update is inherently sequential
+func (d *Driver) applyAmendment(specID string, version int, effectData map[string]any) ([]string, error) {
+	updates, err := parseAmendmentUpdates(effectData["story_updates"])
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate story updates against the story's progress
+	for i := range updates {
+		update := &updates[i]
+		story, exists := d.queue.GetStory(update.StoryID)
+		if !exists || story.SpecID != specID {
+			return nil, fmt.Errorf("story %s is not a story of spec %s", update.StoryID, specID)
+		}
+		switch story.GetStatus() {
+		case StatusNew, StatusPending, StatusOnHold:
+			if update.Action != tools.AmendmentActionEdit {
+				return nil, fmt.Errorf("story %s has not started (%s) - use edit, not %s", story.ID, story.Status, update.Action)
+			}
+		case StatusDispatched, StatusPlanning, StatusCoding, StatusDone:
+			if update.Action != tools.AmendmentActionReopen {
+				return nil, fmt.Errorf("story %s is %s - use reopen, not %s", story.ID, story.Status, update.Action)
+			}
+		default:
+			return nil, fmt.Errorf("story %s is %s and cannot be amended - add a new requirement instead", story.ID, story.Status)
+		}
+	}
+
+	// Validate new stories and their dependencies (ordinal IDs or existing story IDs)
+	var requirements []Requirement
+	if reqs, _ := utils.SafeAssert[[]any](effectData["requirements"]); len(reqs) > 0 {
+		requirements, err = d.convertToolResultToRequirements(map[string]any{"requirements": reqs})
+		if err != nil {
+			return nil, fmt.Errorf("invalid new_requirements: %w", err)
+		}
+	}
+	ordinals := make(map[string]int, len(requirements))
+	for i := range requirements {
+		if _, dup := ordinals[requirements[i].ID]; dup {
+			return nil, fmt.Errorf("duplicate requirement ID %q in new_requirements", requirements[i].ID)
+		}
+		ordinals[requirements[i].ID] = i
+	}
+	for i := range requirements {
+		for _, dep := range requirements[i].Dependencies {
+			if _, isOrdinal := ordinals[dep]; isOrdinal {
+				continue
+			}
+			if _, exists := d.queue.GetStory(dep); !exists {
+				return nil, fmt.Errorf("requirement %q depends on %q, which is neither a new requirement nor an existing story", requirements[i].ID, dep)
+			}
+		}
+	}
+	if cycle := ordinalCycle(requirements, ordinals); cycle != "" {
+		return nil, fmt.Errorf("dependency cycle in new_requirements: %s", cycle)
+	}
+
+	// Apply story updates
+	heading := fmt.Sprintf("Amendment (spec v%d)", version)
+	var storyIDs []string
+	for i := range updates {
+		update := &updates[i]
+		story, _ := d.queue.GetStory(update.StoryID)
+
+		if update.Action == tools.AmendmentActionEdit {
+			applyStoryEdit(story, update.Notes, update.RevisedContent, heading)
+			story.LastUpdated = time.Now()
+			d.queue.AddRequirementIDs(story.ID, update.RequirementIDs)
+			storyIDs = append(storyIDs, story.ID)
+			d.logger.Info("📝 Amendment edited story %s (requirements: %v)", story.ID, update.RequirementIDs)
+			continue
+		}
+
+		// Reopen: completed stories are immutable, so the change becomes a follow-up story
+		followUpID, genErr := persistence.GenerateStoryID()
+		if genErr != nil {
+			return nil, fmt.Errorf("failed to generate story ID: %w", genErr)
+		}
+		followUp := &QueuedStory{Story: persistence.Story{Title: story.Title, Content: story.Content}}
+		applyStoryEdit(followUp, update.Notes, update.RevisedContent, heading)
+		content := fmt.Sprintf("This story reopens %s (%s, %s) to apply spec amendment v%d. Change the existing implementation; do not start over.\n\n%s",
+			story.ID, story.Title, story.Status, version, followUp.Content)
+		d.queue.AddStory(followUpID, specID, story.Title+" (amended)", content, story.StoryType, []string{story.ID}, 2)
+		d.queue.AddRequirementIDs(followUpID, append(slices.Clone(story.RequirementIDs), update.RequirementIDs...))
+		d.queue.SetStoryRepo(followUpID, story.Repo)
+		storyIDs = append(storyIDs, followUpID)
+		d.logger.Info("🔁 Amendment reopened story %s as %s (requirements: %v)", story.ID, followUpID, update.RequirementIDs)
+	}
+
+	// Add new stories
+	ordinalToStory := make(map[string]string, len(requirements))
+	for i := range requirements {
+		storyID, genErr := persistence.GenerateStoryID()
+		if genErr != nil {
+			return nil, fmt.Errorf("failed to generate story ID: %w", genErr)
+		}
+		ordinalToStory[requirements[i].ID] = storyID
+	}
+	for i := range requirements {
+		req := &requirements[i]
+		deps := make([]string, 0, len(req.Dependencies))
+		for _, dep := range req.Dependencies {
+			if storyID, isOrdinal := ordinalToStory[dep]; isOrdinal {
+				deps = append(deps, storyID)
+			} else {
+				deps = append(deps, dep)
+			}
+		}
+		title, content := d.requirementToStoryContent(req)
+		d.queue.AddStory(ordinalToStory[req.ID], specID, title, content, req.StoryType, deps, req.EstimatedPoints)
+		d.queue.AddRequirementIDs(ordinalToStory[req.ID], req.Covers)
+		d.queue.SetStoryRepo(ordinalToStory[req.ID], req.Repo)
+		storyIDs = append(storyIDs, ordinalToStory[req.ID])
+	}
+
+	d.queue.FlushToDatabase()
+
+	existing := utils.GetStateValueOr[[]string](d.BaseStateMachine, StateKeyStoryIDs, nil)
+	allIDs := append(slices.Clone(existing), storyIDs...)
+	d.SetStateData(StateKeyStoryIDs, allIDs)
+	d.SetStateData(StateKeyStoriesCount, len(allIDs))
+
+	return storyIDs, nil
+}
+
+// parseAmendmentUpdates converts submit_amendment story_updates into typed updates.
+func parseAmendmentUpdates(raw any) ([]amendmentStoryUpdate, error) {
+	items, _ := utils.SafeAssert[[]any](raw)
+	updates := make([]amendmentStoryUpdate, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for i, item := range items {
+		m, ok := utils.SafeAssert[map[string]any](item)
+		if !ok {
+			return nil, fmt.Errorf("story_updates[%d] is not an object", i)
+		}
+		update := amendmentStoryUpdate{
+			StoryID:        utils.GetMapFieldOr(m, "story_id", ""),
+			Action:         utils.GetMapFieldOr(m, "action", ""),
+			Notes:          utils.GetMapFieldOr(m, "implementation_notes", ""),
+			RevisedContent: utils.GetMapFieldOr(m, "revised_content", ""),
+		}
+		if ids, ok := utils.SafeAssert[[]any](m["requirement_ids"]); ok {
+			for _, id := range ids {
+				if s, ok := utils.SafeAssert[string](id); ok {
+					update.RequirementIDs = append(update.RequirementIDs, s)
+				}
+			}
+		}
+		if seen[update.StoryID] {
+			return nil, fmt.Errorf("story %s is updated more than once - combine its changes into one update", update.StoryID)
+		}
+		seen[update.StoryID] = true
+		updates = append(updates, update)
+	}
+	return updates, nil
+}
+
+// ordinalCycle returns a description of a dependency cycle among new
+// requirements, or "" when their ordinal dependencies form a DAG.
+func ordinalCycle(requirements []Requirement, ordinals map[string]int) string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(requirements))
+	var path []string
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		state[i] = visiting
+		path = append(path, requirements[i].ID)
+		for _, dep := range requirements[i].Dependencies {
+			j, isOrdinal := ordinals[dep]
+			if !isOrdinal {
+				continue
+			}
+			if state[j] == visiting {
+				path = append(path, dep)
+				return true
+			}
+			if state[j] == unvisited && visit(j) {
+				return true
+			}
+		}
+		state[i] = visited
+		path = path[:len(path)-1]
+		return false
+	}
+	for i := range requirements {
+		if state[i] == unvisited && visit(i) {
+			return strings.Join(path, " → ")
+		}
+	}
+	return ""
+}
+
+// specReviewResponse builds the RESPONSE to a spec approval request.
+func (d *Driver) specReviewResponse(requestMsg *proto.AgentMsg, status proto.ApprovalStatus, feedback string) *proto.AgentMsg {
+	response := proto.NewAgentMsg(proto.MsgTypeRESPONSE, d.GetAgentID(), requestMsg.FromAgent)
+	response.ParentMsgID = requestMsg.ID
+
+	approvalResult := &proto.ApprovalResult{
+		ID:         proto.GenerateApprovalID(),
+		RequestID:  requestMsg.Metadata["approval_id"],
+		Type:       proto.ApprovalTypeSpec,
+		Status:     status,
+		Feedback:   feedback,
+		ReviewedBy: d.GetAgentID(),
+		ReviewedAt: response.Timestamp,
+	}
+
+	response.SetTypedPayload(proto.NewApprovalResponsePayload(approvalResult))
+	response.SetMetadata("approval_id", approvalResult.ID)
+	return response
+}
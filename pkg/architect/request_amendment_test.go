@@ -0,0 +1,138 @@
+package architect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/tools"
+)
+
+// newAmendmentTestDriver returns a driver whose queue holds two pending stories and one done story of spec-1.
+func newAmendmentTestDriver(t *testing.T) *Driver {
+	t.Helper()
+	driver := newTestDriver()
+	driver.queue.AddStory("story-pending", "spec-1", "Login form", "Build the login form.", "app", nil, 2)
+	driver.queue.AddStory("story-done", "spec-1", "Registration", "Build registration.", "app", nil, 3)
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-done", StatusDone))
+	driver.queue.AddStory("story-next", "spec-1", "Logout", "Build logout.", "app", nil, 1)
+	driver.queue.AddStory("story-other", "spec-0", "Bootstrap", "Set up the project.", "devops", nil, 1)
+	return driver
+}
+
+// TestApplyAmendment_EditReopenAndAdd verifies each kind of story update is applied.
+func TestApplyAmendment_EditReopenAndAdd(t *testing.T) {
+	driver := newAmendmentTestDriver(t)
+
+	effectData := map[string]any{
+		"story_updates": []any{
+			map[string]any{
+				"story_id":             "story-pending",
+				"action":               tools.AmendmentActionEdit,
+				"requirement_ids":      []any{"R-002"},
+				"implementation_notes": "Add a 'remember me' checkbox.",
+			},
+			map[string]any{
+				"story_id":             "story-done",
+				"action":               tools.AmendmentActionReopen,
+				"requirement_ids":      []any{"R-001"},
+				"implementation_notes": "Return HTTP 409 for duplicate emails.",
+			},
+		},
+		"requirements": []any{
+			map[string]any{
+				"id":                  "req_001",
+				"title":               "Password reset",
+				"description":         "Users can reset a forgotten password",
+				"acceptance_criteria": []any{"reset link expires after 1 hour"},
+				"story_type":          "app",
+				"dependencies":        []any{"story-done"},
+			},
+		},
+	}
+
+	storyIDs, err := driver.applyAmendment("spec-1", 2, effectData)
+	require.NoError(t, err)
+	require.Len(t, storyIDs, 3)
+	assert.Equal(t, "story-pending", storyIDs[0])
+
+	edited, _ := driver.queue.GetStory("story-pending")
+	assert.Contains(t, edited.Content, "Amendment (spec v2)")
+	assert.Contains(t, edited.Content, "remember me")
+
+	followUp, exists := driver.queue.GetStory(storyIDs[1])
+	require.True(t, exists)
+	assert.Equal(t, "Registration (amended)", followUp.Title)
+	assert.Equal(t, []string{"story-done"}, followUp.DependsOn)
+	assert.Contains(t, followUp.Content, "HTTP 409")
+
+	added, exists := driver.queue.GetStory(storyIDs[2])
+	require.True(t, exists)
+	assert.Equal(t, "spec-1", added.SpecID)
+	assert.Equal(t, []string{"story-done"}, added.DependsOn)
+
+	done, _ := driver.queue.GetStory("story-done")
+	assert.Equal(t, "Build registration.", done.Content, "completed stories must not be rewritten")
+}
+
+// TestApplyAmendment_RejectsInvalidMappings verifies nothing is applied when any update is invalid.
+func TestApplyAmendment_RejectsInvalidMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  map[string]any
+		wantErr string
+	}{
+		{"edit of completed story", map[string]any{"story_id": "story-done", "action": tools.AmendmentActionEdit, "implementation_notes": "x"}, "use reopen"},
+		{"reopen of pending story", map[string]any{"story_id": "story-pending", "action": tools.AmendmentActionReopen, "implementation_notes": "x"}, "use edit"},
+		{"story of another spec", map[string]any{"story_id": "story-other", "action": tools.AmendmentActionEdit, "implementation_notes": "x"}, "not a story of spec spec-1"},
+		{"unknown story", map[string]any{"story_id": "story-missing", "action": tools.AmendmentActionEdit, "implementation_notes": "x"}, "not a story of spec spec-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := newAmendmentTestDriver(t)
+			before := len(driver.queue.GetAllStories())
+
+			_, err := driver.applyAmendment("spec-1", 2, map[string]any{
+				"story_updates": []any{
+					map[string]any{"story_id": "story-next", "action": tools.AmendmentActionEdit, "implementation_notes": "valid"},
+					tt.update,
+				},
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+
+			next, _ := driver.queue.GetStory("story-next")
+			assert.False(t, strings.Contains(next.Content, "valid"), "valid updates must not be applied when another fails")
+			assert.Len(t, driver.queue.GetAllStories(), before)
+		})
+	}
+}
+
+// TestApplyAmendment_NewRequirementCycle verifies cycles among new requirements are rejected.
+func TestApplyAmendment_NewRequirementCycle(t *testing.T) {
+	driver := newAmendmentTestDriver(t)
+
+	_, err := driver.applyAmendment("spec-1", 2, map[string]any{
+		"requirements": []any{
+			map[string]any{"id": "req_001", "title": "A", "description": "a", "acceptance_criteria": []any{"a"}, "story_type": "app", "dependencies": []any{"req_002"}},
+			map[string]any{"id": "req_002", "title": "B", "description": "b", "acceptance_criteria": []any{"b"}, "story_type": "app", "dependencies": []any{"req_001"}},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle")
+}
+
+// TestAmendableSpecID verifies the spec is found from the queue when no spec ID is in state.
+func TestAmendableSpecID(t *testing.T) {
+	driver := newTestDriver()
+	assert.Empty(t, driver.amendableSpecID())
+
+	driver.queue.AddStory("story-1", "spec-1", "Feature", "content", "app", nil, 1)
+	assert.Equal(t, "spec-1", driver.amendableSpecID())
+
+	driver.SetStateData(StateKeySpecID, "spec-2")
+	assert.Equal(t, "spec-2", driver.amendableSpecID())
+}
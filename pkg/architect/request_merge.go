@@ -3,6 +3,8 @@ package architect
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -37,9 +39,43 @@ func (d *Driver) handleMergeRequest(ctx context.Context, request *proto.AgentMsg
 	storyIDStr := request.Metadata["story_id"]
 
 	d.logger.Info("🔀 Processing merge request for story %s: PR=%s, branch=%s", storyIDStr, prURLStr, branchNameStr)
+	d.watchPR(storyIDStr, strings.TrimSpace(prURLStr))
+	if (config.StackedPRsEnabled() || config.IsMultiRepo()) && branchNameStr != "" {
+		// Approved and pushed: dependents may now be stacked on this branch
+		d.queue.SetApprovedBranch(storyIDStr, branchNameStr)
+	}
 
-	// Attempt merge using GitHub CLI.
-	mergeResult, err := d.attemptPRMerge(ctx, prURLStr, branchNameStr, storyIDStr)
+	// Attempt merge once CI checks pass, through the merge queue when it is enabled.
+	var mergeResult *MergeAttemptResult
+	var failureFeedback *proto.MergeFailureFeedback
+	if result := d.takeMergeQueueResult(request.ID); result != nil {
+		mergeResult, failureFeedback, err = mergeQueueOutcome(result)
+	} else {
+		var held bool
+		if failureFeedback, held, err = d.gateOnStackParent(ctx, request, prURLStr, branchNameStr, storyIDStr); held {
+			// The coder is answered once the story it is stacked on merges
+			return nil, nil
+		}
+		if failureFeedback == nil && err == nil {
+			if failureFeedback = d.takePendingReview(storyIDStr); failureFeedback == nil {
+				if failureFeedback, held = d.gateOnChecks(ctx, request, prURLStr, branchNameStr, storyIDStr); held {
+					// The coder is answered once the checks complete
+					return nil, nil
+				}
+			}
+		}
+		switch {
+		case err != nil, failureFeedback != nil:
+		case d.mergeQueue != nil && d.storyTargetBranch(storyIDStr) == config.GetGitBaseBranch() && d.storyRepo(storyIDStr) == "":
+			// The queue tests against the primary repository's target branch; other stories merge directly
+			if err = d.enqueueMerge(ctx, request, prURLStr, branchNameStr, storyIDStr); err == nil {
+				// The coder is answered when the queue delivers the PR's result
+				return nil, nil
+			}
+		default:
+			mergeResult, err = d.attemptPRMerge(ctx, prURLStr, branchNameStr, storyIDStr)
+		}
+	}
 
 	// Create RESPONSE using unified protocol.
 	resultMsg := proto.NewAgentMsg(proto.MsgTypeRESPONSE, d.GetAgentID(), request.FromAgent)
@@ -59,17 +95,28 @@ func (d *Driver) handleMergeRequest(ctx context.Context, request *proto.AgentMsg
 		// Categorize error for appropriate response
 		status, feedback := d.categorizeMergeError(err)
 		d.logger.Error("🔀 Merge failed for story %s: %s (status: %s)", storyIDStr, err.Error(), status)
+		d.rejectStackedMerges(storyIDStr, "failed to merge")
 
 		mergeResponsePayload.Status = string(status)
 		mergeResponsePayload.Feedback = feedback
 		if status == proto.ApprovalStatusNeedsChanges {
 			mergeResponsePayload.ErrorDetails = err.Error() // Preserve detailed error for debugging
 		}
+	} else if failureFeedback != nil {
+		// CI checks or the merge queue failed the PR; tell the coder exactly what failed
+		d.logger.Warn("🔀 Story %s sent back at the %s stage", storyIDStr, failureFeedback.Stage)
+		d.rejectStackedMerges(storyIDStr, fmt.Sprintf("was sent back at the %s stage", failureFeedback.Stage))
+
+		mergeResponsePayload.Status = string(proto.ApprovalStatusNeedsChanges)
+		mergeResponsePayload.Feedback = failureFeedback.String()
+		mergeResponsePayload.ConflictDetails = failureFeedback.String()
+		mergeResponsePayload.Metadata[proto.KeyMergeFailureFeedback] = failureFeedback.Encode()
 	} else if mergeResult != nil && mergeResult.HasConflicts {
 		// Merge conflicts are always recoverable
 		// Check if knowledge.dot is among the conflicting files and provide specific guidance
 		conflictFeedback := d.generateConflictGuidance(mergeResult.ConflictInfo)
 		d.logger.Warn("🔀 Merge conflicts for story %s: %s", storyIDStr, mergeResult.ConflictInfo)
+		d.rejectStackedMerges(storyIDStr, "hit merge conflicts")
 
 		mergeResponsePayload.Status = string(proto.ApprovalStatusNeedsChanges)
 		mergeResponsePayload.Feedback = conflictFeedback
@@ -82,9 +129,12 @@ func (d *Driver) handleMergeRequest(ctx context.Context, request *proto.AgentMsg
 		mergeResponsePayload.Feedback = "Pull request merged successfully"
 		mergeResponsePayload.MergeCommit = mergeResult.CommitSHA
 
-		// Update all dependent clones (architect, PM) to reflect the merge
+		// Update all dependent clones (architect, PM) to reflect the merge.
+		// They are clones of the primary repository, which other repositories' merges leave unchanged.
 		cfg, cfgErr := config.GetConfig()
-		if cfgErr == nil {
+		if cfgErr == nil && d.storyRepo(storyIDStr) != "" {
+			d.logger.Debug("🔀 Story %s merged in repository %s, dependent clones unchanged", storyIDStr, d.storyRepo(storyIDStr))
+		} else if cfgErr == nil {
 			registry := git.NewRegistry(d.workDir)
 			if updateErr := registry.UpdateDependentClones(ctx, cfg.Git.RepoURL, cfg.Git.TargetBranch, mergeResult.CommitSHA); updateErr != nil {
 				d.logger.Warn("⚠️  Failed to update dependent clones after merge: %v (merge succeeded, continuing)", updateErr)
@@ -106,8 +156,28 @@ func (d *Driver) handleMergeRequest(ctx context.Context, request *proto.AgentMsg
 		// Prepare completion summary
 		completionSummary := fmt.Sprintf("Story completed via merge. PR: %s, Commit: %s", prURLStr, mergeResult.CommitSHA)
 
+		// Record the coder's acceptance-criteria verification for the traceability matrix,
+		// and compare the files the story changed with its predicted footprint
+		if metadata, ok := mergePayload["metadata"].(map[string]any); ok && d.queue != nil {
+			if verification, ok := metadata[proto.KeyVerification].(string); ok && verification != "" {
+				if story, exists := d.queue.GetStory(storyIDStr); exists {
+					story.Verification = verification
+				}
+			}
+			if changedFiles, ok := metadata[proto.KeyChangedFiles].(string); ok && changedFiles != "" {
+				d.reportFootprint(storyIDStr, strings.Split(changedFiles, "\n"))
+			}
+		}
+
 		// Handle work acceptance (queue completion, database persistence, state transition signal)
 		d.handleWorkAccepted(ctx, storyIDStr, "merge", prIDPtr, &mergeResult.CommitSHA, &completionSummary)
+
+		// Stories stacked on this one can now be retargeted and rebased
+		d.releaseStackedMerges(storyIDStr)
+
+		// Keep watching the PR for late review comments, and answer any this story addressed
+		d.markPRMerged(storyIDStr)
+		d.replyToReviewFollowUp(ctx, storyIDStr, prURLStr, mergeResult.CommitSHA)
 	}
 
 	// Set typed merge response payload
@@ -138,6 +208,10 @@ func (d *Driver) queryStoryMetrics(_ context.Context, storyID string) *metrics.S
 	if storyMetrics != nil {
 		d.logger.Info("📊 Story %s metrics: prompt tokens: %d, completion tokens: %d, total tokens: %d, total cost: $%.6f",
 			storyID, storyMetrics.PromptTokens, storyMetrics.CompletionTokens, storyMetrics.TotalTokens, storyMetrics.TotalCost)
+		for _, tier := range slices.Sorted(maps.Keys(storyMetrics.Tiers)) {
+			t := storyMetrics.Tiers[tier]
+			d.logger.Info("📊   tier %s: %d requests, %d tokens, $%.6f", tier, t.RequestCount, t.TotalTokens, t.TotalCost)
+		}
 	} else {
 		d.logger.Warn("📊 No metrics found for story %s", storyID)
 	}
@@ -223,57 +297,14 @@ The knowledge graph is critical for architectural consistency. Take time to merg
 // attemptPRMerge attempts to merge a PR using the forge client.
 // This function is mode-aware: in airplane mode it uses Gitea, otherwise GitHub.
 func (d *Driver) attemptPRMerge(ctx context.Context, prURL, branchName, storyID string) (*MergeAttemptResult, error) {
-	// Get config for target branch lookup (needed for PR creation)
-	cfg, cfgErr := config.GetConfig()
-
-	// Use injected client if available (for testing), otherwise create from forge
-	var forgeClient forge.Client
-	if d.gitHubClient != nil {
-		// For testing - wrap the mock in a simple adapter
-		forgeClient = &mockForgeAdapter{mock: d.gitHubClient}
-	} else {
-		// Create forge client (mode-aware: GitHub or Gitea)
-		client, err := forge.NewClient(d.workDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create forge client: %w", err)
-		}
-		forgeClient = client
-		d.logger.Debug("🔀 Using forge provider: %s", forgeClient.Provider())
+	forgeClient, err := d.storyForgeClient(storyID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Determine the PR reference (URL or branch)
-	var prRef string
-	if prURL != "" && prURL != " " {
-		prRef = prURL
-		d.logger.Info("🔀 Attempting to merge PR: %s", prURL)
-	} else if branchName != "" {
-		// Check if PR exists for branch, create if needed
-		d.logger.Info("🔀 Looking for existing PR for branch: %s", branchName)
-		prs, listErr := forgeClient.ListPRsForBranch(ctx, branchName)
-		if listErr != nil || len(prs) == 0 {
-			// No PR found, create one
-			d.logger.Info("🔀 No existing PR found, creating new PR for branch: %s", branchName)
-			targetBranch := github.DefaultBranch
-			if cfgErr == nil && cfg.Git != nil && cfg.Git.TargetBranch != "" {
-				targetBranch = cfg.Git.TargetBranch
-			}
-			pr, createErr := forgeClient.CreatePR(ctx, forge.PRCreateOptions{
-				Title: fmt.Sprintf("Story merge: %s", storyID),
-				Body:  fmt.Sprintf("Automated merge for story %s", storyID),
-				Head:  branchName,
-				Base:  targetBranch,
-			})
-			if createErr != nil {
-				return nil, fmt.Errorf("failed to create PR for branch %s: %w", branchName, createErr)
-			}
-			prRef = fmt.Sprintf("%d", pr.Number)
-			d.logger.Info("🔀 PR #%d created successfully", pr.Number)
-		} else {
-			prRef = fmt.Sprintf("%d", prs[0].Number)
-			d.logger.Info("🔀 Found existing PR #%d for branch: %s", prs[0].Number, branchName)
-		}
-	} else {
-		return nil, fmt.Errorf("no PR URL or branch name provided for merge")
+	prRef, err := d.resolvePRRef(ctx, forgeClient, prURL, branchName, storyID)
+	if err != nil {
+		return nil, err
 	}
 
 	// Attempt merge with squash and delete branch
@@ -301,6 +332,56 @@ func (d *Driver) attemptPRMerge(ctx context.Context, prURL, branchName, storyID
 	return result, nil
 }
 
+// newForgeClient returns the forge client for merge operations.
+func (d *Driver) newForgeClient() (forge.Client, error) {
+	// Use injected client if available (for testing), otherwise create from forge
+	if d.gitHubClient != nil {
+		// For testing - wrap the mock in a simple adapter
+		return &mockForgeAdapter{mock: d.gitHubClient}, nil
+	}
+	// Create forge client (mode-aware: GitHub or Gitea)
+	client, err := forge.NewClient(d.workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge client: %w", err)
+	}
+	d.logger.Debug("🔀 Using forge provider: %s", client.Provider())
+	return client, nil
+}
+
+// resolvePRRef returns the PR reference (URL or number) to merge, creating a PR
+// for the branch when the coder did not supply one and none exists.
+func (d *Driver) resolvePRRef(ctx context.Context, forgeClient forge.Client, prURL, branchName, storyID string) (string, error) {
+	if prURL != "" && prURL != " " {
+		d.logger.Info("🔀 Attempting to merge PR: %s", prURL)
+		return prURL, nil
+	}
+	if branchName == "" {
+		return "", fmt.Errorf("no PR URL or branch name provided for merge")
+	}
+
+	// Check if PR exists for branch, create if needed
+	d.logger.Info("🔀 Looking for existing PR for branch: %s", branchName)
+	prs, listErr := forgeClient.ListPRsForBranch(ctx, branchName)
+	if listErr == nil && len(prs) > 0 {
+		d.logger.Info("🔀 Found existing PR #%d for branch: %s", prs[0].Number, branchName)
+		return fmt.Sprintf("%d", prs[0].Number), nil
+	}
+
+	// No PR found, create one
+	d.logger.Info("🔀 No existing PR found, creating new PR for branch: %s", branchName)
+	pr, createErr := forgeClient.CreatePR(ctx, forge.PRCreateOptions{
+		Title: fmt.Sprintf("Story merge: %s", storyID),
+		Body:  fmt.Sprintf("Automated merge for story %s", storyID),
+		Head:  branchName,
+		Base:  d.storyTargetBranch(storyID),
+	})
+	if createErr != nil {
+		return "", fmt.Errorf("failed to create PR for branch %s: %w", branchName, createErr)
+	}
+	d.logger.Info("🔀 PR #%d created successfully", pr.Number)
+	return fmt.Sprintf("%d", pr.Number), nil
+}
+
 // mockForgeAdapter adapts GitHubMergeClient (test mock) to forge.Client interface.
 // This is only used in tests when d.gitHubClient is injected.
 type mockForgeAdapter struct {
@@ -309,7 +390,10 @@ type mockForgeAdapter struct {
 
 func (a *mockForgeAdapter) Provider() forge.Provider { return forge.ProviderGitHub }
 func (a *mockForgeAdapter) RepoPath() string         { return "test/repo" }
-func (a *mockForgeAdapter) GetPR(_ context.Context, _ string) (*forge.PullRequest, error) {
+func (a *mockForgeAdapter) GetPR(ctx context.Context, ref string) (*forge.PullRequest, error) {
+	if m, ok := a.mock.(prMock); ok {
+		return m.GetPR(ctx, ref)
+	}
 	return nil, fmt.Errorf("not implemented in mock")
 }
 func (a *mockForgeAdapter) GetOrCreatePR(_ context.Context, _ forge.PRCreateOptions) (*forge.PullRequest, error) {
@@ -321,9 +405,73 @@ func (a *mockForgeAdapter) MergePR(_ context.Context, _ string, _ forge.PRMergeO
 func (a *mockForgeAdapter) ClosePR(_ context.Context, _ string) error {
 	return fmt.Errorf("not implemented in mock")
 }
+func (a *mockForgeAdapter) UpdatePRBase(ctx context.Context, ref, base string) error {
+	if m, ok := a.mock.(baseMock); ok {
+		return m.UpdatePRBase(ctx, ref, base)
+	}
+	return fmt.Errorf("not implemented in mock")
+}
 func (a *mockForgeAdapter) CleanupMergedBranches(_ context.Context, _ string, _ []string) ([]string, error) {
 	return nil, fmt.Errorf("not implemented in mock")
 }
+func (a *mockForgeAdapter) GetIssue(_ context.Context, _ int) (*forge.Issue, error) {
+	return nil, fmt.Errorf("not implemented in mock")
+}
+func (a *mockForgeAdapter) CommentOnIssue(_ context.Context, _ int, _ string) error {
+	return fmt.Errorf("not implemented in mock")
+}
+
+// baseMock is implemented by test mocks that record retargeted PRs.
+type baseMock interface {
+	UpdatePRBase(ctx context.Context, ref, base string) error
+}
+
+// prMock is implemented by test mocks that serve a PR's details.
+type prMock interface {
+	GetPR(ctx context.Context, ref string) (*forge.PullRequest, error)
+}
+
+// reviewMock is implemented by test mocks that simulate PR review comments.
+type reviewMock interface {
+	ListPRComments(ctx context.Context, ref string) ([]forge.PRComment, error)
+	ReplyToPRComment(ctx context.Context, ref string, comment forge.PRComment, body string) error
+}
+
+// ListPRComments reports no comments unless the mock simulates them.
+func (a *mockForgeAdapter) ListPRComments(ctx context.Context, ref string) ([]forge.PRComment, error) {
+	if m, ok := a.mock.(reviewMock); ok {
+		return m.ListPRComments(ctx, ref)
+	}
+	return nil, nil
+}
+
+func (a *mockForgeAdapter) ReplyToPRComment(ctx context.Context, ref string, comment forge.PRComment, body string) error {
+	if m, ok := a.mock.(reviewMock); ok {
+		return m.ReplyToPRComment(ctx, ref, comment, body)
+	}
+	return fmt.Errorf("not implemented in mock")
+}
+
+// checksMock is implemented by test mocks that simulate forge CI checks.
+type checksMock interface {
+	GetPRChecks(ctx context.Context, ref string) (*forge.CheckStatus, error)
+	GetCheckLog(ctx context.Context, check forge.Check) (string, error)
+}
+
+// GetPRChecks reports no checks unless the mock simulates them.
+func (a *mockForgeAdapter) GetPRChecks(ctx context.Context, ref string) (*forge.CheckStatus, error) {
+	if m, ok := a.mock.(checksMock); ok {
+		return m.GetPRChecks(ctx, ref)
+	}
+	return &forge.CheckStatus{State: forge.CheckStateNone}, nil
+}
+
+func (a *mockForgeAdapter) GetCheckLog(ctx context.Context, check forge.Check) (string, error) {
+	if m, ok := a.mock.(checksMock); ok {
+		return m.GetCheckLog(ctx, check)
+	}
+	return "", fmt.Errorf("not implemented in mock")
+}
 
 func (a *mockForgeAdapter) ListPRsForBranch(ctx context.Context, branch string) ([]forge.PullRequest, error) {
 	prs, err := a.mock.ListPRsForBranch(ctx, branch)
@@ -25,6 +25,11 @@ func (d *Driver) handleSpecReview(ctx context.Context, requestMsg *proto.AgentMs
 		return nil, fmt.Errorf("spec not found in approval request Content field")
 	}
 
+	// Amendments to an approved spec are reviewed as a requirement-level delta
+	if approvalPayload.Metadata != nil && approvalPayload.Metadata["spec_type"] == "amendment" {
+		return d.handleSpecAmendment(ctx, requestMsg, approvalPayload)
+	}
+
 	// Detect if this is a bootstrap spec (Spec 0) sent separately from user spec
 	isBootstrapSpec := approvalPayload.Metadata != nil && approvalPayload.Metadata["spec_type"] == "bootstrap"
 
@@ -235,6 +240,9 @@ func (d *Driver) handleSpecReview(ctx context.Context, requestMsg *proto.AgentMs
 	if platformCfg, cfgErr := config.GetConfig(); cfgErr == nil && platformCfg.Project != nil && platformCfg.Project.PrimaryPlatform != "" {
 		storyGenExtra["primary_platform"] = platformCfg.Project.PrimaryPlatform
 	}
+	if config.IsMultiRepo() {
+		storyGenExtra["repos"] = config.GetRepos()
+	}
 	storyGenData := &templates.TemplateData{
 		TaskContent: completeSpec,
 		Extra:       storyGenExtra,
@@ -3,8 +3,10 @@ package architect
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"orchestrator/pkg/config"
 	"orchestrator/pkg/persistence"
 	"orchestrator/pkg/proto"
 	"orchestrator/pkg/utils"
@@ -24,6 +26,8 @@ type Requirement struct {
 	Tags               []string          `json:"tags"`
 	Details            map[string]string `json:"details"`
 	StoryType          string            `json:"story_type"` // "devops" or "app"
+	Covers             []string          `json:"covers"`     // Spec requirement IDs (R-001) the story implements
+	Repo               string            `json:"repo"`       // Repository the story changes ("" for the primary one)
 }
 
 // requirementToStoryContent converts a requirement to story title and rich markdown content.
@@ -32,6 +36,9 @@ func (d *Driver) requirementToStoryContent(req *Requirement) (string, string) {
 	title := req.Title
 
 	content := fmt.Sprintf("**Task**\n%s\n\n", req.Description)
+	if len(req.Covers) > 0 {
+		content += fmt.Sprintf("**Spec Requirements**\n%s\n\n", strings.Join(req.Covers, ", "))
+	}
 	content += "**Acceptance Criteria**\n"
 	for _, criteria := range req.AcceptanceCriteria {
 		content += fmt.Sprintf("* %s\n", criteria)
@@ -67,6 +74,12 @@ func (d *Driver) convertToolResultToRequirements(toolResult map[string]any) ([]R
 		description := utils.GetMapFieldOr(reqMap, "description", "")
 		storyType := utils.GetMapFieldOr(reqMap, "story_type", "")
 
+		// Resolve the repository the story changes in a multi-repo product
+		repo, repoErr := config.ResolveRepo(utils.GetMapFieldOr(reqMap, "repo", ""))
+		if repoErr != nil {
+			return nil, fmt.Errorf("requirement %d: %w", i, repoErr)
+		}
+
 		// Handle acceptance criteria array
 		var acceptanceCriteria []string
 		if acAny, ok := utils.SafeAssert[[]any](reqMap["acceptance_criteria"]); ok {
@@ -89,6 +102,16 @@ func (d *Driver) convertToolResultToRequirements(toolResult map[string]any) ([]R
 			}
 		}
 
+		// Handle spec requirement IDs the story covers
+		var covers []string
+		if coversAny, ok := utils.SafeAssert[[]any](reqMap["covers"]); ok {
+			for _, c := range coversAny {
+				if id, ok := utils.SafeAssert[string](c); ok && id != "" {
+					covers = append(covers, strings.TrimSpace(id))
+				}
+			}
+		}
+
 		// Handle estimated points (could be float64 from JSON or int)
 		estimatedPoints := 2 // Default
 		if points, ok := utils.SafeAssert[float64](reqMap["estimated_points"]); ok {
@@ -105,6 +128,8 @@ func (d *Driver) convertToolResultToRequirements(toolResult map[string]any) ([]R
 			EstimatedPoints:    estimatedPoints,
 			Dependencies:       dependencies,
 			StoryType:          storyType,
+			Covers:             covers,
+			Repo:               repo,
 		}
 
 		// Validate and set reasonable defaults
@@ -230,6 +255,8 @@ func (d *Driver) loadStoriesFromSubmitResultData(_ context.Context, specMarkdown
 		resolvedDeps = append(resolvedDeps, preExistingIDs...)
 
 		d.queue.AddStory(entry.storyID, specID, entry.title, entry.content, entry.req.StoryType, resolvedDeps, entry.req.EstimatedPoints)
+		d.queue.AddRequirementIDs(entry.storyID, entry.req.Covers)
+		d.queue.SetStoryRepo(entry.storyID, entry.req.Repo)
 	}
 
 	if len(preExistingIDs) > 0 {
@@ -0,0 +1,143 @@
+package architect
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/proto"
+)
+
+// stackWait is a stacked story's merge request, held until the story it is
+// stacked on merges.
+type stackWait struct {
+	request  *proto.AgentMsg
+	parentID string
+	done     bool
+	feedback *proto.MergeFailureFeedback // Set when review comments released the request first
+}
+
+// storyBaseBranch returns the branch a dispatched story starts from and its PR
+// targets: the approved branch of the dependency it is stacked on, its epic
+// branch, or "" for the target branch. A story stacked on a dependency in
+// another repository starts from its own base and only merges after it.
+func (d *Driver) storyBaseBranch(ctx context.Context, story *QueuedStory) (string, error) {
+	if parentID, branch := d.queue.StackStory(story.ID); branch != "" {
+		if d.isCrossRepoStack(story.ID) {
+			d.logger.Info("🔗 Story %s starts while %s in another repository is approved and merges after it", story.ID, parentID)
+		} else {
+			d.logger.Info("🥞 Story %s is stacked on %s: it starts from %s and merges after it", story.ID, parentID, branch)
+			return branch, nil
+		}
+	}
+	return d.ensureEpicBranch(ctx, story)
+}
+
+// gateOnStackParent holds the merge of a stacked story until the story it is
+// stacked on merges, then retargets its PR to the story's own base branch and
+// returns feedback asking the coder to rebase onto it. Returns held when the
+// request was parked; nil feedback and no error mean the PR may merge as is.
+// Stacks are recorded in memory only, so the hold follows the story's unmet
+// dependencies and the retarget the PR's actual base, which both survive a restart.
+func (d *Driver) gateOnStackParent(ctx context.Context, request *proto.AgentMsg, prURL, branchName, storyID string) (*proto.MergeFailureFeedback, bool, error) {
+	if wait, ok := d.stackWaits[request.ID]; ok && wait.done {
+		delete(d.stackWaits, request.ID)
+		if wait.feedback != nil {
+			return wait.feedback, false, nil
+		}
+	}
+
+	if d.queue == nil || !(config.StackedPRsEnabled() || config.IsMultiRepo()) {
+		return nil, false, nil
+	}
+	parentID := d.queue.StackParent(storyID)
+	if !d.queue.DependenciesMet(storyID) {
+		if parentID == "" {
+			// Stacked before a restart: a story only starts early on its one unmet dependency
+			parentID = d.queue.UnmetDependency(storyID)
+		}
+		d.logger.Info("🥞 Holding merge of story %s until %s, which it is stacked on, merges", storyID, parentID)
+		d.stackWaits[request.ID] = &stackWait{request: request, parentID: parentID}
+		return nil, true, nil
+	}
+
+	base := d.storyTargetBranch(storyID)
+	forgeClient, err := d.storyForgeClient(storyID)
+	if err != nil {
+		return nil, false, err
+	}
+	prRef, err := d.resolvePRRef(ctx, forgeClient, prURL, branchName, storyID)
+	if err != nil {
+		return nil, false, err
+	}
+	pr, err := forgeClient.GetPR(ctx, prRef)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read the base of PR %s: %w", prRef, err)
+	}
+	if pr.BaseBranch == "" || pr.BaseBranch == base {
+		// Not stacked, or stacked on a dependency in another repository, which merged there
+		if parentID != "" {
+			d.queue.Unstack(storyID)
+			d.logger.Info("🔗 %s merged: story %s may merge now", parentID, storyID)
+		}
+		return nil, false, nil
+	}
+
+	if err := forgeClient.UpdatePRBase(ctx, prRef, base); err != nil {
+		return nil, false, fmt.Errorf("failed to retarget stacked PR %s to %s: %w", prRef, base, err)
+	}
+	d.queue.Unstack(storyID)
+	d.logger.Info("🥞 PR %s of story %s targeted %s and now targets %s", prRef, storyID, pr.BaseBranch, base)
+
+	ahead := []string{parentID}
+	if parentID == "" {
+		if story, ok := d.queue.GetStory(storyID); ok {
+			ahead = slices.Clone(story.DependsOn)
+		}
+	}
+	return &proto.MergeFailureFeedback{
+		Stage: proto.MergeStageRetarget,
+		Base:  base,
+		Ahead: ahead,
+	}, false, nil
+}
+
+// releaseStackedMerges marks the held merges of stories stacked on a merged
+// story for MONITORING to answer.
+func (d *Driver) releaseStackedMerges(parentID string) {
+	for _, wait := range d.stackWaits {
+		if wait.parentID == parentID && !wait.done {
+			wait.done = true
+		}
+	}
+}
+
+// rejectStackedMerges answers the held merges of stories stacked on a story
+// that did not merge, so their coders are not left waiting on a merge that is
+// not coming. reason says what happened to the parent.
+func (d *Driver) rejectStackedMerges(parentID, reason string) {
+	for _, wait := range d.stackWaits {
+		if wait.parentID != parentID || wait.done {
+			continue
+		}
+		d.logger.Info("🥞 Story %s did not merge: answering the held merge of a story stacked on it", parentID)
+		wait.done = true
+		wait.feedback = &proto.MergeFailureFeedback{
+			Stage: proto.MergeStageStackParent,
+			Output: fmt.Sprintf("%s, which your PR is stacked on, %s. Your PR has nothing to fix for this; "+
+				"ask to merge again and it will be held until %s merges.", parentID, reason, parentID),
+		}
+	}
+}
+
+// nextReleasedStackMerge returns a held stacked merge whose parent merged, to
+// reprocess in REQUEST, or nil.
+func (d *Driver) nextReleasedStackMerge() *proto.AgentMsg {
+	for _, wait := range d.stackWaits {
+		if wait.done {
+			return wait.request
+		}
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package architect
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+// stackGitHubClient serves the base of the PR and records retargets on top of the merge mock.
+type stackGitHubClient struct {
+	*mockGitHubMergeClient
+	base      string
+	retargets []string
+}
+
+func (c *stackGitHubClient) GetPR(_ context.Context, ref string) (*forge.PullRequest, error) {
+	return &forge.PullRequest{URL: ref, BaseBranch: c.base}, nil
+}
+
+func (c *stackGitHubClient) UpdatePRBase(_ context.Context, ref, base string) error {
+	c.retargets = append(c.retargets, ref+" -> "+base)
+	c.base = base
+	return nil
+}
+
+// newStackTestDriver returns a review test driver with stacked PRs enabled,
+// where story-child depends on story-parent, which is being coded.
+func newStackTestDriver(t *testing.T) (*Driver, *stackGitHubClient) {
+	t.Helper()
+	driver := newReviewTestDriver(t)
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{TargetBranch: "main", StackedPRs: true, IgnoreChecks: true}})
+
+	client := &stackGitHubClient{mockGitHubMergeClient: newMockGitHubClient()}
+	driver.gitHubClient = client
+	driver.stackWaits = make(map[string]*stackWait)
+
+	driver.queue.AddStory("story-parent", "spec-2", "Sessions", "Store sessions.", "app", nil, 2)
+	driver.queue.AddStory("story-child", "spec-2", "Session expiry", "Expire sessions.", "app", []string{"story-parent"}, 1)
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-parent", StatusCoding))
+	return driver, client
+}
+
+func newStackMergeRequest(storyID, branch string) *proto.AgentMsg {
+	request := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-002", "architect")
+	request.Metadata = map[string]string{proto.KeyStoryID: storyID}
+	request.SetTypedPayload(proto.NewMergeRequestPayload(&proto.MergeRequestPayload{
+		PRURL:      "https://github.com/test/repo/pull/9",
+		BranchName: branch,
+	}))
+	return request
+}
+
+func readyStoryIDs(q *Queue) []string {
+	var ids []string
+	for _, story := range q.GetReadyStories() {
+		ids = append(ids, story.ID)
+	}
+	return ids
+}
+
+// TestStackStory verifies a dependent becomes ready once its dependency's
+// code is approved, and starts from that dependency's branch.
+func TestStackStory(t *testing.T) {
+	driver, _ := newStackTestDriver(t)
+	assert.NotContains(t, readyStoryIDs(driver.queue), "story-child")
+
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	assert.Contains(t, readyStoryIDs(driver.queue), "story-child")
+	assert.False(t, driver.queue.DependenciesMet("story-child"), "stacking does not complete the dependency")
+
+	child, _ := driver.queue.GetStory("story-child")
+	branch, err := driver.storyBaseBranch(context.Background(), child)
+	require.NoError(t, err)
+	assert.Equal(t, "maestro-story-parent", branch)
+	assert.Equal(t, "story-parent", driver.queue.StackParent("story-child"))
+
+	// A requeued dependency starts over, so nothing stacks on its old branch
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-parent", StatusPending))
+	assert.NotContains(t, readyStoryIDs(driver.queue), "story-child")
+}
+
+// TestStackStory_DisabledByDefault verifies dependents wait for the merge without the option.
+func TestStackStory_DisabledByDefault(t *testing.T) {
+	driver, _ := newStackTestDriver(t)
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{TargetBranch: "main"}})
+
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	assert.NotContains(t, readyStoryIDs(driver.queue), "story-child")
+
+	parentID, branch := driver.queue.StackStory("story-child")
+	assert.Empty(t, parentID)
+	assert.Empty(t, branch)
+}
+
+// TestStackedMerge_HeldThenRetargeted verifies a stacked story's merge waits
+// for its dependency, then its PR is retargeted and its coder asked to rebase.
+func TestStackedMerge_HeldThenRetargeted(t *testing.T) {
+	driver, client := newStackTestDriver(t)
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	driver.queue.StackStory("story-child")
+	client.base = "maestro-story-parent"
+
+	request := newStackMergeRequest("story-child", "maestro-story-child")
+	result, err := driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	assert.Nil(t, result, "merge should be held until the dependency merges")
+	assert.Nil(t, driver.nextReleasedStackMerge())
+	assert.Empty(t, client.mergeCalls)
+
+	// The dependency merges
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-parent", StatusDone))
+	driver.releaseStackedMerges("story-parent")
+	require.Same(t, request, driver.nextReleasedStackMerge())
+
+	result, err = driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	resp := extractMergeResponse(t, result)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+	assert.Equal(t, []string{"https://github.com/test/repo/pull/9 -> main"}, client.retargets)
+	assert.Empty(t, client.mergeCalls, "the rebased PR is merged on its next request")
+
+	feedback, err := proto.DecodeMergeFailureFeedback(resp.Metadata[proto.KeyMergeFailureFeedback])
+	require.NoError(t, err)
+	assert.Equal(t, proto.MergeStageRetarget, feedback.Stage)
+	assert.Equal(t, "main", feedback.Base)
+	assert.Empty(t, driver.stackWaits)
+	assert.Empty(t, driver.queue.StackParent("story-child"))
+
+	// Resubmitted after the rebase, it is no longer gated
+	feedback, held, err := driver.gateOnStackParent(context.Background(), newStackMergeRequest("story-child", "maestro-story-child"),
+		"https://github.com/test/repo/pull/9", "maestro-story-child", "story-child")
+	require.NoError(t, err)
+	assert.False(t, held)
+	assert.Nil(t, feedback)
+}
+
+// TestStackedMerge_AfterRestart verifies a stacked story's merge is still held
+// and its PR retargeted when the architect restarted and lost its stacks.
+func TestStackedMerge_AfterRestart(t *testing.T) {
+	driver, client := newStackTestDriver(t)
+	driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+	driver.queue.StackStory("story-child")
+	client.base = "maestro-story-parent"
+	driver.queue.stackedOn = make(map[string]string) // Not persisted
+
+	request := newStackMergeRequest("story-child", "maestro-story-child")
+	result, err := driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	assert.Nil(t, result, "merge should be held until the dependency merges")
+	assert.Empty(t, client.mergeCalls)
+	require.Contains(t, driver.stackWaits, request.ID)
+	assert.Equal(t, "story-parent", driver.stackWaits[request.ID].parentID)
+
+	require.NoError(t, driver.queue.UpdateStoryStatus("story-parent", StatusDone))
+	driver.releaseStackedMerges("story-parent")
+	require.Same(t, request, driver.nextReleasedStackMerge())
+
+	result, err = driver.handleMergeRequest(context.Background(), request)
+	require.NoError(t, err)
+	resp := extractMergeResponse(t, result)
+	assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+	assert.Equal(t, []string{"https://github.com/test/repo/pull/9 -> main"}, client.retargets)
+	assert.Empty(t, client.mergeCalls, "the PR never merges into the dependency's branch")
+
+	feedback, err := proto.DecodeMergeFailureFeedback(resp.Metadata[proto.KeyMergeFailureFeedback])
+	require.NoError(t, err)
+	assert.Equal(t, proto.MergeStageRetarget, feedback.Stage)
+	assert.Equal(t, []string{"story-parent"}, feedback.Ahead)
+}
+
+// TestStackedMerge_ParentDidNotMerge verifies a held stacked merge is answered
+// when the story it is stacked on fails to merge or is requeued.
+func TestStackedMerge_ParentDidNotMerge(t *testing.T) {
+	testCases := []struct {
+		name   string
+		parent func(t *testing.T, driver *Driver, client *stackGitHubClient)
+		reason string
+	}{
+		{
+			name: "MergeConflict",
+			parent: func(t *testing.T, driver *Driver, client *stackGitHubClient) {
+				client.returnMergeConflict("CONFLICT (content): Merge conflict in session.go")
+				result, err := driver.handleMergeRequest(context.Background(), newStackMergeRequest("story-parent", "maestro-story-parent"))
+				require.NoError(t, err)
+				assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), extractMergeResponse(t, result).Status)
+			},
+			reason: "hit merge conflicts",
+		},
+		{
+			name: "Requeued",
+			parent: func(t *testing.T, driver *Driver, _ *stackGitHubClient) {
+				requeue := proto.NewAgentMsg(proto.MsgTypeREQUEST, "coder-001", "architect")
+				requeue.Metadata = map[string]string{proto.KeyStoryID: "story-parent"}
+				require.NoError(t, driver.handleRequeueRequest(context.Background(), requeue))
+			},
+			reason: "was requeued",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			driver, client := newStackTestDriver(t)
+			driver.queue.SetApprovedBranch("story-parent", "maestro-story-parent")
+			driver.queue.StackStory("story-child")
+
+			request := newStackMergeRequest("story-child", "maestro-story-child")
+			result, err := driver.handleMergeRequest(context.Background(), request)
+			require.NoError(t, err)
+			require.Nil(t, result, "merge should be held until the dependency merges")
+
+			tc.parent(t, driver, client)
+			require.Same(t, request, driver.nextReleasedStackMerge())
+
+			result, err = driver.handleMergeRequest(context.Background(), request)
+			require.NoError(t, err)
+			resp := extractMergeResponse(t, result)
+			assert.Equal(t, string(proto.ApprovalStatusNeedsChanges), resp.Status)
+			assert.Contains(t, resp.Feedback, tc.reason)
+			assert.Empty(t, client.retargets)
+			assert.Empty(t, driver.stackWaits)
+
+			feedback, err := proto.DecodeMergeFailureFeedback(resp.Metadata[proto.KeyMergeFailureFeedback])
+			require.NoError(t, err)
+			assert.Equal(t, proto.MergeStageStackParent, feedback.Stage)
+			assert.Equal(t, "story-parent", driver.queue.StackParent("story-child"), "the child stays stacked")
+		})
+	}
+}
@@ -47,6 +47,9 @@ const (
 	// Dev-chat tracking.
 	StateKeyDevChatPending = "dev_chat_pending" // bool - dev-chat messages waiting to be processed
 
+	// PR review tracking.
+	StateKeyPRReviewPending = "pr_review_pending" // *prReview - review comments on a merged story to reopen it with
+
 	// Lifecycle tracking.
 	StateKeyStartedAt = "started_at" // time.Time - when architect started
 
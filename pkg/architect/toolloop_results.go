@@ -111,3 +111,9 @@ func ExtractSubmitStories(calls []agent.ToolCall, results []any) (SubmitStoriesR
 
 	return SubmitStoriesResult{}, toolloop.ErrNoTerminalTool
 }
+
+// SubmitAmendmentResult contains the outcome of a submit_amendment tool call.
+// The story mapping itself is carried by the tool's ProcessEffect data.
+type SubmitAmendmentResult struct {
+	Success bool
+}
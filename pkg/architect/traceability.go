@@ -0,0 +1,83 @@
+package architect
+
+import (
+	"fmt"
+	"strings"
+
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/specs"
+	"orchestrator/pkg/traceability"
+)
+
+// GetTraceabilityReport builds the requirement → story → PR traceability matrix of a spec.
+// An empty specID selects the spec currently in development.
+// Used by the web UI to render and export the matrix.
+func (d *Driver) GetTraceabilityReport(specID string) (*traceability.Report, error) {
+	if specID == "" {
+		specID = d.amendableSpecID()
+	}
+	if specID == "" {
+		return nil, fmt.Errorf("no spec in development")
+	}
+
+	spec, err := d.loadSpec(specID)
+	if err != nil {
+		return nil, err
+	}
+
+	requirements, err := specs.ParseRequirements(spec.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse requirements of spec %s: %w", specID, err)
+	}
+
+	queued := d.queue.GetAllStories()
+	stories := make([]*persistence.Story, 0, len(queued))
+	for _, story := range queued {
+		stories = append(stories, story.ToPersistenceStory())
+	}
+
+	report := traceability.Build(specID, requirements, stories)
+	report.Version = spec.Version
+	return report, nil
+}
+
+// loadSpec reads a spec from the database.
+func (d *Driver) loadSpec(specID string) (*persistence.Spec, error) {
+	if d.persistenceChannel == nil {
+		return nil, fmt.Errorf("persistence is not available")
+	}
+
+	respCh := make(chan interface{}, 1)
+	d.persistenceChannel <- &persistence.Request{
+		Operation: persistence.OpGetSpecByID,
+		Data:      specID,
+		Response:  respCh,
+	}
+
+	resp := <-respCh
+	if err, ok := resp.(error); ok {
+		return nil, fmt.Errorf("failed to load spec %s: %w", specID, err)
+	}
+	spec, ok := resp.(*persistence.Spec)
+	if !ok || spec == nil {
+		return nil, fmt.Errorf("spec %s not found", specID)
+	}
+	return spec, nil
+}
+
+// uncoveredRequirementsNote returns a note naming the spec's requirements that no
+// story covers, for the spec-completion notification. Empty when every requirement
+// is covered or the matrix cannot be built.
+func (d *Driver) uncoveredRequirementsNote(specID string) string {
+	report, err := d.GetTraceabilityReport(specID)
+	if err != nil {
+		d.logger.Debug("Traceability check skipped for spec %s: %v", specID, err)
+		return ""
+	}
+	if report.Complete() {
+		return ""
+	}
+	d.logger.Warn("⚠️ Spec %s completed with %d requirement(s) not covered by any story: %s",
+		specID, len(report.Uncovered), strings.Join(report.Uncovered, ", "))
+	return fmt.Sprintf(" Warning: requirement(s) %s are not covered by any story.", strings.Join(report.Uncovered, ", "))
+}
@@ -0,0 +1,96 @@
+package architect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/traceability"
+)
+
+const traceabilityTestSpec = `# Feature: Accounts
+
+## Requirements
+
+### R-001: User Registration
+**Type:** functional
+**Priority:** must
+**Dependencies:** []
+
+**Description:** Users can create accounts.
+
+**Acceptance Criteria:**
+- [ ] Duplicate email check
+
+### R-002: User Login
+**Type:** functional
+**Priority:** must
+**Dependencies:** [R-001]
+
+**Description:** Registered users can log in.
+
+**Acceptance Criteria:**
+- [ ] Wrong password shows an error
+
+### R-003: Password Reset
+**Type:** functional
+**Priority:** should
+**Dependencies:** [R-001]
+
+**Description:** Users can reset a forgotten password.
+
+**Acceptance Criteria:**
+- [ ] Reset link expires after 1 hour
+`
+
+// serveSpec answers the driver's spec lookups with the given spec.
+func serveSpec(t *testing.T, driver *Driver, spec *persistence.Spec) {
+	t.Helper()
+	ch := make(chan *persistence.Request, 1)
+	driver.persistenceChannel = ch
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		for {
+			select {
+			case req := <-ch:
+				if req.Operation == persistence.OpGetSpecByID && req.Response != nil {
+					req.Response <- spec
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+func TestGetTraceabilityReport(t *testing.T) {
+	driver := newAmendmentTestDriver(t)
+	serveSpec(t, driver, &persistence.Spec{ID: "spec-1", Content: traceabilityTestSpec, Version: 2})
+
+	driver.queue.AddRequirementIDs("story-done", []string{"R-001"})
+	driver.queue.AddRequirementIDs("story-pending", []string{"R-002", "R-002"})
+	story, _ := driver.queue.GetStory("story-done")
+	story.Verification = `{"status":"pass","criteria":[{"criterion":"Duplicate email check","requirement_id":"R-001","result":"pass"}]}`
+
+	report, err := driver.GetTraceabilityReport("spec-1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Version)
+	require.Len(t, report.Requirements, 3)
+	assert.Equal(t, traceability.StatusVerified, report.Requirements[0].Status)
+	assert.Equal(t, traceability.StatusInProgress, report.Requirements[1].Status)
+	assert.Len(t, report.Requirements[1].Stories, 1, "duplicate requirement IDs should be collapsed")
+	assert.Equal(t, []string{"R-003"}, report.Uncovered)
+
+	assert.Contains(t, driver.uncoveredRequirementsNote("spec-1"), "R-003")
+}
+
+func TestGetTraceabilityReport_NoPersistence(t *testing.T) {
+	driver := newAmendmentTestDriver(t)
+	driver.persistenceChannel = nil
+	_, err := driver.GetTraceabilityReport("spec-1")
+	assert.Error(t, err)
+	assert.Empty(t, driver.uncoveredRequirementsNote("spec-1"))
+}
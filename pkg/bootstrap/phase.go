@@ -221,7 +221,7 @@ func (p *Phase) setupWorkspace(ctx context.Context) (string, error) {
 	agentID := "bootstrap"
 
 	// Use projectRoot as the agent work directory for bootstrap.
-	cloneResult, err := p.cloneManager.SetupWorkspace(ctx, agentID, dummyStoryID, p.projectRoot)
+	cloneResult, err := p.cloneManager.SetupWorkspace(ctx, agentID, dummyStoryID, p.projectRoot, "")
 	if err != nil {
 		return "", fmt.Errorf("failed to setup workspace: %w", err)
 	}
@@ -0,0 +1,391 @@
+// Package browser drives a headless Chrome in a sidecar container for
+// browser-based acceptance-criteria verification.
+//
+// The browser is controlled over the Chrome DevTools Protocol (CDP) with a
+// deliberately small client: one page, commands sent one at a time, and
+// page interaction done by evaluating JavaScript. That is all verification
+// needs — navigate, click, fill a form, read the page, take a screenshot —
+// and it keeps the orchestrator free of a browser-automation framework and
+// of the Node toolchain one would drag into the sidecar image.
+package browser
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ErrElementNotFound is returned when a selector matches nothing on the page.
+var ErrElementNotFound = errors.New("no element matches the selector")
+
+const (
+	// defaultViewportWidth and defaultViewportHeight size the page like a
+	// laptop screen, so screenshots show the layout reviewers expect.
+	defaultViewportWidth  = 1280
+	defaultViewportHeight = 800
+
+	// maxPageText caps text and DOM reads; a whole page's markup would
+	// swamp the verifier's context for no benefit.
+	maxPageText = 20000
+
+	loadPollInterval = 100 * time.Millisecond
+	defaultLoadWait  = 30 * time.Second
+)
+
+// cdpMessage is a CDP command, response or event.
+type cdpMessage struct {
+	Params    any             `json:"params,omitempty"`
+	Error     *cdpError       `json:"error,omitempty"`
+	Method    string          `json:"method,omitempty"`
+	SessionID string          `json:"sessionId,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	ID        int64           `json:"id,omitempty"`
+}
+
+type cdpError struct {
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+	Code    int    `json:"code"`
+}
+
+// Session is a connection to one page in a browser. It is safe for
+// concurrent use, but commands are serialised: the verifier acts on the page
+// one step at a time, and interleaving would make no sense.
+type Session struct {
+	conn      *websocket.Conn
+	sessionID string
+	mu        sync.Mutex
+	nextID    int64
+}
+
+// Connect opens a page in the browser whose DevTools HTTP endpoint is at
+// endpoint (e.g. "http://127.0.0.1:49153").
+func Connect(ctx context.Context, endpoint string) (*Session, error) {
+	wsURL, err := browserWebSocketURL(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: 10 * time.Second}
+	conn, resp, err := dialer.DialContext(ctx, wsURL, nil)
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to browser: %w", err)
+	}
+	// Screenshots of long pages are large; the default read limit is unlimited,
+	// but set one so a misbehaving browser cannot exhaust memory.
+	conn.SetReadLimit(64 << 20)
+
+	s := &Session{conn: conn}
+	if err := s.openPage(ctx); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// browserWebSocketURL asks the DevTools HTTP endpoint for the browser's
+// WebSocket URL. Chrome reports it with the address it listens on inside the
+// container, so the host and port are replaced with the endpoint's.
+func browserWebSocketURL(ctx context.Context, endpoint string) (string, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid browser endpoint %q: %w", endpoint, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(endpoint, "/")+"/json/version", http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("browser not reachable: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("browser version endpoint returned %d", resp.StatusCode)
+	}
+
+	var version struct {
+		WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&version); err != nil {
+		return "", fmt.Errorf("failed to parse browser version: %w", err)
+	}
+	wsURL, err := url.Parse(version.WebSocketDebuggerURL)
+	if err != nil || wsURL.Path == "" {
+		return "", fmt.Errorf("browser reported no usable WebSocket URL: %q", version.WebSocketDebuggerURL)
+	}
+	wsURL.Host = base.Host
+	return wsURL.String(), nil
+}
+
+// openPage creates a page target, attaches to it and sizes its viewport.
+func (s *Session) openPage(ctx context.Context) error {
+	var target struct {
+		TargetID string `json:"targetId"`
+	}
+	if err := s.call(ctx, "", "Target.createTarget", map[string]any{"url": "about:blank"}, &target); err != nil {
+		return fmt.Errorf("failed to open page: %w", err)
+	}
+	var attached struct {
+		SessionID string `json:"sessionId"`
+	}
+	if err := s.call(ctx, "", "Target.attachToTarget", map[string]any{"targetId": target.TargetID, "flatten": true}, &attached); err != nil {
+		return fmt.Errorf("failed to attach to page: %w", err)
+	}
+	s.sessionID = attached.SessionID
+
+	return s.call(ctx, s.sessionID, "Emulation.setDeviceMetricsOverride", map[string]any{
+		"width": defaultViewportWidth, "height": defaultViewportHeight, "deviceScaleFactor": 1, "mobile": false,
+	}, nil)
+}
+
+// call sends a command and waits for its response, skipping the events the
+// browser sends in between. sessionID is empty for browser-level commands.
+func (s *Session) call(ctx context.Context, sessionID, method string, params, result any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := s.nextID
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+		_ = s.conn.SetReadDeadline(deadline)
+	} else {
+		_ = s.conn.SetWriteDeadline(time.Time{})
+		_ = s.conn.SetReadDeadline(time.Time{})
+	}
+	if err := s.conn.WriteJSON(cdpMessage{ID: id, SessionID: sessionID, Method: method, Params: params}); err != nil {
+		return fmt.Errorf("%s: failed to send: %w", method, err)
+	}
+
+	for {
+		var msg cdpMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("%s: failed to read response: %w", method, err)
+		}
+		if msg.ID != id {
+			continue // an event, or a response to an abandoned call
+		}
+		if msg.Error != nil {
+			return fmt.Errorf("%s: %s", method, msg.Error.Message)
+		}
+		if result != nil && len(msg.Result) > 0 {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				return fmt.Errorf("%s: failed to parse result: %w", method, err)
+			}
+		}
+		return nil
+	}
+}
+
+// evaluate runs a JavaScript expression in the page and decodes its value.
+func (s *Session) evaluate(ctx context.Context, expression string, out any) error {
+	var res struct {
+		ExceptionDetails *struct {
+			Exception *struct {
+				Description string `json:"description"`
+			} `json:"exception"`
+			Text string `json:"text"`
+		} `json:"exceptionDetails"`
+		Result struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"result"`
+	}
+	err := s.call(ctx, s.sessionID, "Runtime.evaluate", map[string]any{
+		"expression": expression, "returnByValue": true, "awaitPromise": true,
+	}, &res)
+	if err != nil {
+		return err
+	}
+	if res.ExceptionDetails != nil {
+		msg := res.ExceptionDetails.Text
+		if res.ExceptionDetails.Exception != nil && res.ExceptionDetails.Exception.Description != "" {
+			msg = res.ExceptionDetails.Exception.Description
+		}
+		return fmt.Errorf("script error: %s", msg)
+	}
+	if out != nil && len(res.Result.Value) > 0 {
+		if err := json.Unmarshal(res.Result.Value, out); err != nil {
+			return fmt.Errorf("failed to decode script result: %w", err)
+		}
+	}
+	return nil
+}
+
+// Navigate loads url and waits for the document to finish loading, up to
+// the context's deadline or defaultLoadWait.
+func (s *Session) Navigate(ctx context.Context, target string) (string, error) {
+	var nav struct {
+		ErrorText string `json:"errorText"`
+	}
+	if err := s.call(ctx, s.sessionID, "Page.navigate", map[string]any{"url": target}, &nav); err != nil {
+		return "", err
+	}
+	if nav.ErrorText != "" {
+		return "", fmt.Errorf("navigation to %s failed: %s", target, nav.ErrorText)
+	}
+	if err := s.waitForLoad(ctx); err != nil {
+		return "", err
+	}
+	return s.Title(ctx)
+}
+
+// waitForLoad polls document.readyState. Polling keeps the client free of
+// event subscriptions, and a page's load is a matter of a second or two.
+func (s *Session) waitForLoad(ctx context.Context) error {
+	deadline := time.Now().Add(defaultLoadWait)
+	for {
+		var state string
+		if err := s.evaluate(ctx, "document.readyState", &state); err == nil && state == "complete" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("page did not finish loading within %s", defaultLoadWait)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for page load: %w", ctx.Err())
+		case <-time.After(loadPollInterval):
+		}
+	}
+}
+
+// Title returns the page's title.
+func (s *Session) Title(ctx context.Context) (string, error) {
+	var title string
+	err := s.evaluate(ctx, "document.title", &title)
+	return title, err
+}
+
+// URL returns the page's current URL, which differs from the one navigated
+// to after a redirect or a click that navigates.
+func (s *Session) URL(ctx context.Context) (string, error) {
+	var current string
+	err := s.evaluate(ctx, "location.href", &current)
+	return current, err
+}
+
+// Click clicks the first element matching the CSS selector, then waits for
+// any navigation it caused to finish.
+func (s *Session) Click(ctx context.Context, selector string) error {
+	script := fmt.Sprintf(`(() => {
+		const el = document.querySelector(%s);
+		if (!el) return false;
+		el.scrollIntoView({block: "center"});
+		el.click();
+		return true;
+	})()`, jsString(selector))
+	if err := s.elementAction(ctx, script, selector); err != nil {
+		return err
+	}
+	return s.waitForLoad(ctx)
+}
+
+// Fill sets the value of the input, textarea or select matching selector
+// and fires the input and change events frameworks listen for. The value is
+// set through the element prototype's setter so React's tracking sees it.
+func (s *Session) Fill(ctx context.Context, selector, value string) error {
+	script := fmt.Sprintf(`(() => {
+		const el = document.querySelector(%s);
+		if (!el) return false;
+		el.focus();
+		const proto = Object.getPrototypeOf(el);
+		const setter = Object.getOwnPropertyDescriptor(proto, "value")?.set;
+		if (setter) { setter.call(el, %s); } else { el.value = %s; }
+		el.dispatchEvent(new Event("input", {bubbles: true}));
+		el.dispatchEvent(new Event("change", {bubbles: true}));
+		return true;
+	})()`, jsString(selector), jsString(value), jsString(value))
+	return s.elementAction(ctx, script, selector)
+}
+
+func (s *Session) elementAction(ctx context.Context, script, selector string) error {
+	var found bool
+	if err := s.evaluate(ctx, script, &found); err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrElementNotFound, selector)
+	}
+	return nil
+}
+
+// Text returns the rendered text of the element matching selector, or of
+// the whole page when selector is empty, truncated to maxPageText.
+func (s *Session) Text(ctx context.Context, selector string) (string, bool, error) {
+	return s.read(ctx, selector, "innerText")
+}
+
+// DOM returns the outer HTML of the element matching selector, or of the
+// whole document when selector is empty, truncated to maxPageText.
+func (s *Session) DOM(ctx context.Context, selector string) (string, bool, error) {
+	return s.read(ctx, selector, "outerHTML")
+}
+
+func (s *Session) read(ctx context.Context, selector, property string) (string, bool, error) {
+	target := "document.body"
+	if property == "outerHTML" {
+		target = "document.documentElement"
+	}
+	if selector != "" {
+		target = fmt.Sprintf("document.querySelector(%s)", jsString(selector))
+	}
+	var value *string
+	script := fmt.Sprintf(`(() => { const el = %s; return el ? el.%s : null; })()`, target, property)
+	if err := s.evaluate(ctx, script, &value); err != nil {
+		return "", false, err
+	}
+	if value == nil {
+		return "", false, fmt.Errorf("%w: %s", ErrElementNotFound, selector)
+	}
+	text, truncated := *value, false
+	if len(text) > maxPageText {
+		text, truncated = text[:maxPageText], true
+	}
+	return text, truncated, nil
+}
+
+// Screenshot captures the page as PNG: the viewport, or the whole
+// scrollable page when fullPage is set.
+func (s *Session) Screenshot(ctx context.Context, fullPage bool) ([]byte, error) {
+	var shot struct {
+		Data string `json:"data"`
+	}
+	err := s.call(ctx, s.sessionID, "Page.captureScreenshot", map[string]any{
+		"format": "png", "captureBeyondViewport": fullPage,
+	}, &shot)
+	if err != nil {
+		return nil, err
+	}
+	png, err := base64.StdEncoding.DecodeString(shot.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	return png, nil
+}
+
+// Close closes the connection. The page goes with the sidecar.
+func (s *Session) Close() error {
+	if err := s.conn.Close(); err != nil {
+		return fmt.Errorf("failed to close browser connection: %w", err)
+	}
+	return nil
+}
+
+// jsString quotes s as a JavaScript string literal.
+func jsString(s string) string {
+	quoted, _ := json.Marshal(s) //nolint:errchkjson // marshalling a string cannot fail
+	return string(quoted)
+}
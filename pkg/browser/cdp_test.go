@@ -0,0 +1,175 @@
+package browser
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeDevTools is a DevTools endpoint that answers the commands Session
+// sends. Runtime.evaluate results come from evaluate, keyed by a substring
+// of the expression.
+type fakeDevTools struct {
+	evaluate map[string]any
+	methods  []string
+	mu       sync.Mutex
+}
+
+func (f *fakeDevTools) seen() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.methods...)
+}
+
+func (f *fakeDevTools) serve(t *testing.T) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	upgrader := websocket.Upgrader{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/json/version", func(w http.ResponseWriter, _ *http.Request) {
+		// Chrome reports the address it listens on inside its container.
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"webSocketDebuggerUrl": "ws://0.0.0.0:9222/devtools/browser/abc",
+		})
+	})
+	mux.HandleFunc("/devtools/browser/abc", func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		for {
+			var msg cdpMessage
+			if err := conn.ReadJSON(&msg); err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.methods = append(f.methods, msg.Method)
+			f.mu.Unlock()
+			// An event before every response, as a live browser sends.
+			_ = conn.WriteJSON(map[string]any{"method": "Page.frameNavigated", "params": map[string]any{}})
+			_ = conn.WriteJSON(f.respond(msg))
+		}
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeDevTools) respond(msg cdpMessage) map[string]any {
+	response := map[string]any{"id": msg.ID}
+	switch msg.Method {
+	case "Target.createTarget":
+		response["result"] = map[string]any{"targetId": "T1"}
+	case "Target.attachToTarget":
+		response["result"] = map[string]any{"sessionId": "S1"}
+	case "Page.navigate":
+		response["result"] = map[string]any{"frameId": "F1"}
+	case "Page.captureScreenshot":
+		response["result"] = map[string]any{"data": base64.StdEncoding.EncodeToString([]byte("png-bytes"))}
+	case "Runtime.evaluate":
+		params, _ := msg.Params.(map[string]any)
+		expression, _ := params["expression"].(string)
+		for key, value := range f.evaluate {
+			if strings.Contains(expression, key) {
+				response["result"] = map[string]any{"result": map[string]any{"value": value}}
+				return response
+			}
+		}
+		response["result"] = map[string]any{"result": map[string]any{}}
+	default:
+		response["result"] = map[string]any{}
+	}
+	return response
+}
+
+func TestSession_NavigateAndScreenshot(t *testing.T) {
+	devTools := &fakeDevTools{evaluate: map[string]any{
+		"document.readyState": "complete",
+		"document.title":      "Dashboard",
+	}}
+	server := devTools.serve(t)
+
+	session, err := Connect(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	title, err := session.Navigate(context.Background(), "http://app:3000/")
+	if err != nil {
+		t.Fatalf("Navigate: %v", err)
+	}
+	if title != "Dashboard" {
+		t.Errorf("title = %q, want Dashboard", title)
+	}
+
+	png, err := session.Screenshot(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+	if string(png) != "png-bytes" {
+		t.Errorf("screenshot = %q", png)
+	}
+
+	methods := devTools.seen()
+	want := []string{"Target.createTarget", "Target.attachToTarget", "Emulation.setDeviceMetricsOverride", "Page.navigate"}
+	for i, method := range want {
+		if i >= len(methods) || methods[i] != method {
+			t.Fatalf("methods = %v, want prefix %v", methods, want)
+		}
+	}
+}
+
+func TestSession_ClickMissingElement(t *testing.T) {
+	devTools := &fakeDevTools{evaluate: map[string]any{"el.click()": false, "el.innerText": nil}}
+	server := devTools.serve(t)
+
+	session, err := Connect(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	err = session.Click(context.Background(), "#missing")
+	if !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("expected ErrElementNotFound, got %v", err)
+	}
+	if _, _, err := session.Text(context.Background(), "#missing"); !errors.Is(err, ErrElementNotFound) {
+		t.Errorf("expected ErrElementNotFound from Text, got %v", err)
+	}
+}
+
+func TestSession_TextTruncates(t *testing.T) {
+	devTools := &fakeDevTools{evaluate: map[string]any{"innerText": strings.Repeat("x", maxPageText+10)}}
+	server := devTools.serve(t)
+
+	session, err := Connect(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	text, truncated, err := session.Text(context.Background(), "")
+	if err != nil {
+		t.Fatalf("Text: %v", err)
+	}
+	if !truncated || len(text) != maxPageText {
+		t.Errorf("expected text truncated to %d, got %d (truncated=%v)", maxPageText, len(text), truncated)
+	}
+}
+
+func TestJSString(t *testing.T) {
+	var decoded string
+	if err := json.Unmarshal([]byte(jsString(`a"b</script>`)), &decoded); err != nil || decoded != `a"b</script>` {
+		t.Errorf("jsString did not round-trip: %q (%v)", decoded, err)
+	}
+}
@@ -0,0 +1,61 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Launcher starts a sidecar the first time a page is asked for and tears it
+// down on Close. A verification run that never touches the browser never
+// pays for starting one.
+type Launcher struct {
+	sidecar *Sidecar
+	session *Session
+	mu      sync.Mutex
+}
+
+// NewLauncher returns a launcher for sidecar.
+func NewLauncher(sidecar *Sidecar) *Launcher {
+	return &Launcher{sidecar: sidecar}
+}
+
+// Page returns the browser page, starting the sidecar if needed.
+func (l *Launcher) Page(ctx context.Context) (*Session, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.session != nil {
+		return l.session, nil
+	}
+
+	endpoint, err := l.sidecar.Start(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := Connect(ctx, endpoint)
+	if err != nil {
+		_ = l.sidecar.Stop(context.WithoutCancel(ctx))
+		return nil, err
+	}
+	l.session = session
+	return session, nil
+}
+
+// Started reports whether the sidecar is running.
+func (l *Launcher) Started() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.session != nil
+}
+
+// Close closes the page and stops the sidecar, if either was started.
+func (l *Launcher) Close(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.session == nil {
+		return nil
+	}
+	closeErr := l.session.Close()
+	l.session = nil
+	return errors.Join(closeErr, l.sidecar.Stop(ctx))
+}
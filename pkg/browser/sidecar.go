@@ -0,0 +1,214 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/demo"
+)
+
+// DefaultImage is the sidecar image when browser.image is unset: Chrome's
+// headless shell with DevTools listening on 9222. In airplane mode it must
+// have been pulled beforehand.
+const DefaultImage = "chromedp/headless-shell:stable"
+
+const (
+	devToolsPort     = "9222/tcp"
+	readyWait        = 30 * time.Second
+	readyPoll        = 250 * time.Millisecond
+	sidecarLabel     = "maestro.role=browser"
+	containerPrefix  = "maestro-browser-"
+	ownNetworkSuffix = "-browser"
+)
+
+// ComposeNetworkName is the network compose_up puts an agent's stack and dev
+// container on ("{project}_default", the project being "maestro-{agentID}").
+func ComposeNetworkName(agentID string) string {
+	return "maestro-" + agentID + "_default"
+}
+
+// Sidecar runs a headless browser next to an agent's dev container.
+//
+// The browser joins the agent's compose network when compose_up has created
+// one, so it reaches compose services by hostname exactly as the app does.
+// Otherwise it gets a network of its own. Either way the agent's container
+// is connected to it, so an app the agent runs is at http://{container}:{port}.
+//
+// DevTools is published on a loopback port only: the orchestrator drives the
+// browser from the host, and nothing else should.
+//
+//nolint:govet // fieldalignment: Logical grouping preferred for readability
+type Sidecar struct {
+	Image        string
+	Name         string
+	AgentID      string
+	AppContainer string // agent's dev container, connected to the browser's network
+
+	// CommandRunner allows injecting a mock for testing.
+	// If nil, uses exec.CommandContext.
+	CommandRunner func(ctx context.Context, name string, args ...string) *exec.Cmd
+
+	networkManager *demo.NetworkManager
+	network        string
+	ownsNetwork    bool
+	endpoint       string
+}
+
+// NewSidecar describes the browser sidecar for an agent. Nothing runs until Start.
+func NewSidecar(agentID, image, appContainer string) *Sidecar {
+	if image == "" {
+		image = DefaultImage
+	}
+	return &Sidecar{
+		Image:        image,
+		Name:         containerPrefix + agentID,
+		AgentID:      agentID,
+		AppContainer: appContainer,
+	}
+}
+
+func (s *Sidecar) runCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	if s.CommandRunner != nil {
+		return s.CommandRunner(ctx, name, args...)
+	}
+	return exec.CommandContext(ctx, name, args...)
+}
+
+func (s *Sidecar) networks() *demo.NetworkManager {
+	if s.networkManager == nil {
+		s.networkManager = &demo.NetworkManager{CommandRunner: s.CommandRunner}
+	}
+	return s.networkManager
+}
+
+// Network returns the network the browser is on once started.
+func (s *Sidecar) Network() string {
+	return s.network
+}
+
+// Start runs the sidecar and returns its DevTools endpoint. A container
+// left over from an earlier run under the same name is replaced.
+func (s *Sidecar) Start(ctx context.Context) (string, error) {
+	if err := s.joinNetwork(ctx); err != nil {
+		return "", err
+	}
+
+	_ = s.runCommand(ctx, "docker", "rm", "-f", s.Name).Run()
+	args := []string{
+		"run", "-d", "--name", s.Name, "--network", s.network, "--label", sidecarLabel,
+		"--shm-size", "256m", "-p", "127.0.0.1::9222", s.Image,
+	}
+	if output, err := s.runCommand(ctx, "docker", args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("failed to start browser sidecar from %s: %w, output: %s",
+			s.Image, err, strings.TrimSpace(string(output)))
+	}
+
+	output, err := s.runCommand(ctx, "docker", "port", s.Name, devToolsPort).Output()
+	if err != nil {
+		_ = s.Stop(context.WithoutCancel(ctx))
+		return "", fmt.Errorf("failed to find browser sidecar's DevTools port: %w", err)
+	}
+	hostPort := parseHostPort(string(output))
+	if hostPort == "" {
+		_ = s.Stop(context.WithoutCancel(ctx))
+		return "", fmt.Errorf("browser sidecar published no loopback port (docker port said %q)", strings.TrimSpace(string(output)))
+	}
+	s.endpoint = "http://" + hostPort
+
+	if err := waitReady(ctx, s.endpoint); err != nil {
+		_ = s.Stop(context.WithoutCancel(ctx))
+		return "", err
+	}
+	return s.endpoint, nil
+}
+
+// joinNetwork picks the browser's network and connects the app container to it.
+func (s *Sidecar) joinNetwork(ctx context.Context) error {
+	networks := s.networks()
+	compose := ComposeNetworkName(s.AgentID)
+	exists, err := networks.NetworkExists(ctx, compose)
+	if err != nil {
+		return fmt.Errorf("failed to check compose network: %w", err)
+	}
+	if exists {
+		s.network = compose
+	} else {
+		s.network = containerPrefix + s.AgentID + ownNetworkSuffix
+		if err := networks.EnsureNetwork(ctx, s.network); err != nil {
+			return fmt.Errorf("failed to create browser network: %w", err)
+		}
+		s.ownsNetwork = true
+	}
+
+	if s.AppContainer != "" {
+		if err := networks.ConnectContainer(ctx, s.network, s.AppContainer); err != nil {
+			return fmt.Errorf("failed to connect %s to the browser network: %w", s.AppContainer, err)
+		}
+	}
+	return nil
+}
+
+// parseHostPort picks the IPv4 loopback binding from `docker port` output,
+// which lists one binding per line ("127.0.0.1:49153").
+func parseHostPort(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); strings.HasPrefix(line, "127.0.0.1:") {
+			return line
+		}
+	}
+	return ""
+}
+
+// waitReady polls the DevTools version endpoint until Chrome answers.
+func waitReady(ctx context.Context, endpoint string) error {
+	deadline := time.Now().Add(readyWait)
+	client := &http.Client{Timeout: 2 * time.Second}
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/json/version", http.NoBody)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		if resp, err := client.Do(req); err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("browser sidecar did not become ready within %s", readyWait)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for browser sidecar: %w", ctx.Err())
+		case <-time.After(readyPoll):
+		}
+	}
+}
+
+// Stop removes the sidecar and, if it created one, its network. The app
+// container is disconnected from that network first so it can be removed;
+// the compose network is left alone, since compose_up owns it.
+func (s *Sidecar) Stop(ctx context.Context) error {
+	output, err := s.runCommand(ctx, "docker", "rm", "-f", s.Name).CombinedOutput()
+	if err != nil && !strings.Contains(string(output), "No such container") {
+		return fmt.Errorf("failed to remove browser sidecar: %w, output: %s", err, strings.TrimSpace(string(output)))
+	}
+	if s.ownsNetwork {
+		networks := s.networks()
+		if s.AppContainer != "" {
+			if err := networks.DisconnectContainer(ctx, s.network, s.AppContainer); err != nil {
+				return fmt.Errorf("failed to disconnect %s from the browser network: %w", s.AppContainer, err)
+			}
+		}
+		if err := networks.RemoveNetwork(ctx, s.network); err != nil {
+			return fmt.Errorf("failed to remove browser network: %w", err)
+		}
+		s.ownsNetwork = false
+	}
+	s.endpoint = ""
+	return nil
+}
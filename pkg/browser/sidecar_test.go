@@ -0,0 +1,79 @@
+package browser
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestParseHostPort(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"ipv4 only", "127.0.0.1:49153\n", "127.0.0.1:49153"},
+		{"ipv6 listed first", "[::1]:49154\n127.0.0.1:49153\n", "127.0.0.1:49153"},
+		{"no loopback", "0.0.0.0:49153\n", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseHostPort(tt.output); got != tt.want {
+				t.Errorf("parseHostPort(%q) = %q, want %q", tt.output, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSidecar_DefaultImage(t *testing.T) {
+	s := NewSidecar("coder-001", "", "maestro-coder-001")
+	if s.Image != DefaultImage {
+		t.Errorf("Image = %q, want %q", s.Image, DefaultImage)
+	}
+	if s.Name != "maestro-browser-coder-001" {
+		t.Errorf("Name = %q", s.Name)
+	}
+}
+
+func TestSidecar_JoinsComposeNetwork(t *testing.T) {
+	var commands []string
+	s := NewSidecar("coder-001", "", "maestro-coder-001")
+	s.CommandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0") // every network exists
+	}
+
+	if err := s.joinNetwork(context.Background()); err != nil {
+		t.Fatalf("joinNetwork: %v", err)
+	}
+	if s.Network() != ComposeNetworkName("coder-001") {
+		t.Errorf("Network = %q, want the compose network", s.Network())
+	}
+	if s.ownsNetwork {
+		t.Error("sidecar should not own the compose network")
+	}
+	for _, cmd := range commands {
+		if strings.Contains(cmd, "network create") {
+			t.Errorf("unexpected network creation: %s", cmd)
+		}
+	}
+}
+
+func TestSidecar_CreatesOwnNetwork(t *testing.T) {
+	s := NewSidecar("coder-001", "", "maestro-coder-001")
+	s.CommandRunner = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
+		if len(args) >= 2 && args[0] == "network" && args[1] == "inspect" {
+			return exec.CommandContext(ctx, "sh", "-c", "exit 1") // no network yet
+		}
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	if err := s.joinNetwork(context.Background()); err != nil {
+		t.Fatalf("joinNetwork: %v", err)
+	}
+	if s.Network() != "maestro-browser-coder-001-browser" || !s.ownsNetwork {
+		t.Errorf("Network = %q (owned=%v), want a network of the sidecar's own", s.Network(), s.ownsNetwork)
+	}
+}
@@ -34,7 +34,7 @@ func (c *Coder) handleAwaitMerge(ctx context.Context, sm *agent.BaseStateMachine
 }
 
 // processMergeResult processes the architect's merge response and determines next state.
-func (c *Coder) processMergeResult(_ context.Context, sm *agent.BaseStateMachine, result *git.MergeResult) (proto.State, bool, error) {
+func (c *Coder) processMergeResult(ctx context.Context, sm *agent.BaseStateMachine, result *git.MergeResult) (proto.State, bool, error) {
 	// Store completion timestamp
 	sm.SetStateData(KeyMergeCompletedAt, time.Now().UTC())
 
@@ -53,6 +53,11 @@ func (c *Coder) processMergeResult(_ context.Context, sm *agent.BaseStateMachine
 		return proto.StateDone, false, nil
 
 	case string(proto.ApprovalStatusNeedsChanges):
+		if base := retargetedBase(result.FailureFeedback); base != "" {
+			// The story this PR was stacked on merged; move onto the new base and resubmit
+			return c.rebaseOntoRetargetedBase(ctx, sm, base)
+		}
+
 		// Get merge feedback
 		feedback := result.ConflictInfo
 		if feedback == "" {
@@ -60,6 +65,7 @@ func (c *Coder) processMergeResult(_ context.Context, sm *agent.BaseStateMachine
 		}
 
 		c.logger.Info("🧑‍💻 Merge needs changes, transitioning to CODING: %s", feedback)
+		c.rememberReviewComments(sm, result.FailureFeedback)
 
 		// Use mini-template to format the merge failure message
 		if c.renderer != nil {
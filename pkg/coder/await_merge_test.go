@@ -229,6 +229,20 @@ func TestProcessMergeResult_UnknownStatus(t *testing.T) {
 	}
 }
 
+func TestRetargetedBase(t *testing.T) {
+	retarget := (&proto.MergeFailureFeedback{Stage: proto.MergeStageRetarget, Base: "main", Ahead: []string{"story-1"}}).Encode()
+	if got := retargetedBase(retarget); got != "main" {
+		t.Errorf("Expected retargeted base main, got: %q", got)
+	}
+
+	checks := (&proto.MergeFailureFeedback{Stage: proto.MergeStageChecks, Command: "test"}).Encode()
+	for _, raw := range []string{"", checks, "not json"} {
+		if got := retargetedBase(raw); got != "" {
+			t.Errorf("Expected no retargeted base for %q, got: %q", raw, got)
+		}
+	}
+}
+
 // =============================================================================
 // applyPendingContainerConfig tests
 // =============================================================================
@@ -13,6 +13,7 @@ import (
 	"orchestrator/pkg/forge"
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/mirror"
+	"orchestrator/pkg/tools"
 	"orchestrator/pkg/utils"
 )
 
@@ -59,6 +60,14 @@ func NewCloneManager(gitRunner GitRunner, projectWorkDir, repoURL, baseBranch, m
 	}
 }
 
+// ForRepo returns a clone manager for another repository of a multi-repo
+// product, sharing this manager's settings.
+func (c *CloneManager) ForRepo(repoURL string) *CloneManager {
+	repoManager := *c
+	repoManager.repoURLOverride = repoURL
+	return &repoManager
+}
+
 // SetContainerManager sets the container manager for Docker cleanup operations.
 func (c *CloneManager) SetContainerManager(containerManager ContainerManager) {
 	c.containerManager = containerManager
@@ -103,7 +112,8 @@ type CloneResult struct {
 }
 
 // SetupWorkspace creates a self-contained git repository for complete agent isolation.
-func (c *CloneManager) SetupWorkspace(ctx context.Context, agentID, storyID, agentWorkDir string) (*CloneResult, error) {
+// The story branch starts at baseBranch (an epic branch), or at the base branch when empty.
+func (c *CloneManager) SetupWorkspace(ctx context.Context, agentID, storyID, agentWorkDir, baseBranch string) (*CloneResult, error) {
 	c.logger.Debug("SetupWorkspace called with agentID=%s, storyID=%s, agentWorkDir=%s", agentID, storyID, agentWorkDir)
 	c.logger.Debug("ProjectWorkDir: %s", c.projectWorkDir)
 
@@ -137,11 +147,21 @@ func (c *CloneManager) SetupWorkspace(ctx context.Context, agentID, storyID, age
 
 	// Step 5: Create and checkout branch.
 	branchName := c.buildBranchName(storyID)
-	actualBranchName, err := c.createBranch(ctx, agentWorkDirPath, branchName)
+	if baseBranch == "" {
+		baseBranch = c.getBaseBranch()
+	}
+	actualBranchName, err := c.createBranch(ctx, agentWorkDirPath, branchName, "origin/"+baseBranch)
 	if err != nil {
 		return nil, logx.Wrap(err, "failed to create branch")
 	}
 
+	// Step 6: Record an epic base so reviews diff against it rather than main.
+	if baseBranch != c.getBaseBranch() {
+		if _, err := c.gitRunner.Run(ctx, agentWorkDirPath, "config", tools.DiffBaseConfigKey, baseBranch); err != nil {
+			return nil, logx.Wrap(err, "failed to record base branch")
+		}
+	}
+
 	return &CloneResult{
 		WorkDir:    agentWorkDirPath,
 		BranchName: actualBranchName,
@@ -382,7 +402,8 @@ func (c *CloneManager) createFreshClone(ctx context.Context, mirrorPath, agentWo
 
 	// When forge provider is Gitea, add 'forge' remote for push/fetch.
 	// Push/fetch helpers prefer 'forge' when it exists, falling back to 'github'.
-	if config.GetForgeProvider() == config.ForgeProviderGitea {
+	// Gitea only hosts the primary repository of a multi-repo product.
+	if config.GetForgeProvider() == config.ForgeProviderGitea && c.getRepoURL() == config.GetGitRepoURL() {
 		if forgeURL, forgeErr := buildForgeGitURL(); forgeErr == nil {
 			c.logger.Debug("Adding forge remote (gitea provider): %s", forgeURL)
 			_, err = c.gitRunner.Run(ctx, agentWorkDir, "remote", "add", "forge", forgeURL)
@@ -422,8 +443,8 @@ func buildForgeGitURL() (string, error) {
 	return cloneURL, nil
 }
 
-// createBranch creates and checks out a new branch in the agent clone directory.
-func (c *CloneManager) createBranch(ctx context.Context, agentWorkDir, branchName string) (string, error) {
+// createBranch creates and checks out a new branch at startPoint in the agent clone directory.
+func (c *CloneManager) createBranch(ctx context.Context, agentWorkDir, branchName, startPoint string) (string, error) {
 	c.logger.Debug("createBranch called with agentWorkDir=%s, branchName=%s", agentWorkDir, branchName)
 
 	// Check if agent work directory exists before trying to create branch.
@@ -437,7 +458,7 @@ func (c *CloneManager) createBranch(ctx context.Context, agentWorkDir, branchNam
 	existingBranches, err := c.getExistingBranches(ctx, agentWorkDir)
 	if err != nil {
 		c.logger.Warn("Failed to get existing branches, falling back to trial-and-error method: %v", err)
-		return c.createBranchWithRetry(ctx, agentWorkDir, branchName)
+		return c.createBranchWithRetry(ctx, agentWorkDir, branchName, startPoint)
 	}
 
 	// Find an available branch name.
@@ -448,7 +469,7 @@ func (c *CloneManager) createBranch(ctx context.Context, agentWorkDir, branchNam
 	for attempt <= maxAttempts {
 		if !c.branchExists(branchName, existingBranches) {
 			// Branch name is available, create it.
-			_, err := c.gitRunner.Run(ctx, agentWorkDir, "switch", "-c", branchName)
+			_, err := c.gitRunner.Run(ctx, agentWorkDir, "switch", "-c", branchName, startPoint)
 			if err == nil {
 				// Success! Log if we had to use an incremented name.
 				if attempt > 1 {
@@ -537,13 +558,13 @@ func (c *CloneManager) branchExists(branchName string, existingBranches []string
 // createBranchWithRetry is the fallback method that uses trial-and-error.
 //
 //nolint:dupl
-func (c *CloneManager) createBranchWithRetry(ctx context.Context, agentWorkDir, branchName string) (string, error) {
+func (c *CloneManager) createBranchWithRetry(ctx context.Context, agentWorkDir, branchName, startPoint string) (string, error) {
 	originalBranchName := branchName
 	attempt := 1
 	maxAttempts := 10
 
 	for attempt <= maxAttempts {
-		_, err := c.gitRunner.Run(ctx, agentWorkDir, "switch", "-c", branchName)
+		_, err := c.gitRunner.Run(ctx, agentWorkDir, "switch", "-c", branchName, startPoint)
 		if err == nil {
 			// Success! Log if we had to use an incremented name.
 			if attempt > 1 {
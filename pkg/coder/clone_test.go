@@ -545,7 +545,7 @@ func TestCreateBranch_Success(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	branchName, err := cm.createBranch(ctx, workDir, "story-001")
+	branchName, err := cm.createBranch(ctx, workDir, "story-001", "origin/main")
 
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
@@ -579,7 +579,7 @@ func TestCreateBranch_CollisionRetry(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	branchName, err := cm.createBranch(ctx, workDir, "feature-branch")
+	branchName, err := cm.createBranch(ctx, workDir, "feature-branch", "origin/main")
 
 	if err != nil {
 		t.Errorf("Expected no error after finding available name, got: %v", err)
@@ -590,6 +590,30 @@ func TestCreateBranch_CollisionRetry(t *testing.T) {
 	}
 }
 
+func TestCreateBranch_FromEpicBranch(t *testing.T) {
+	cm, mockGit := setupCloneManagerTest(t)
+	workDir := t.TempDir()
+
+	var switchArgs []string
+	mockGit.OnRun(func(_ context.Context, _ string, args ...string) ([]byte, error) {
+		if len(args) > 0 && args[0] == "switch" {
+			switchArgs = args
+		}
+		return []byte{}, nil
+	})
+
+	branchName, err := cm.createBranch(context.Background(), workDir, "story-001", "origin/maestro/epic/spec-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if branchName != "story-001" {
+		t.Errorf("Expected branch name 'story-001', got: %s", branchName)
+	}
+	if strings.Join(switchArgs, " ") != "switch -c story-001 origin/maestro/epic/spec-1" {
+		t.Errorf("Expected story branch to start at the epic branch, got: %v", switchArgs)
+	}
+}
+
 // =============================================================================
 // createBranchWithRetry tests
 // =============================================================================
@@ -605,7 +629,7 @@ func TestCreateBranchWithRetry_Success(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	branchName, err := cm.createBranchWithRetry(ctx, "/workspace", "feature-branch")
+	branchName, err := cm.createBranchWithRetry(ctx, "/workspace", "feature-branch", "origin/main")
 
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
@@ -627,7 +651,7 @@ func TestCreateBranchWithRetry_AllAttemptsFail(t *testing.T) {
 	})
 
 	ctx := context.Background()
-	_, err := cm.createBranchWithRetry(ctx, "/workspace", "feature-branch")
+	_, err := cm.createBranchWithRetry(ctx, "/workspace", "feature-branch", "origin/main")
 
 	if err == nil {
 		t.Error("Expected error when all attempts fail")
@@ -864,7 +888,7 @@ func TestSetupWorkspace_InvalidProjectDir(t *testing.T) {
 	)
 
 	ctx := context.Background()
-	_, err := cm.SetupWorkspace(ctx, "test-agent", "test-story", "/workspace")
+	_, err := cm.SetupWorkspace(ctx, "test-agent", "test-story", "/workspace", "")
 
 	if err == nil {
 		t.Error("Expected error for non-existent project directory")
@@ -43,8 +43,13 @@ const (
 	KeyErrorMessage            = "error_message"
 	KeyStoryMessageID          = "story_message_id"
 	KeyStoryID                 = "story_id"
-	KeyExpress                 = "express"   // Express story flag (skip planning)
-	KeyIsHotfix                = "is_hotfix" // Hotfix flag (for routing/identification)
+	KeyExpress                 = "express"          // Express story flag (skip planning)
+	KeyIsHotfix                = "is_hotfix"        // Hotfix flag (for routing/identification)
+	KeyBaseBranch              = "base_branch"      // Epic or stacked-on branch the story branches from and merges into, if any
+	KeyStackedOn               = "stacked_on"       // Story whose branch this story is stacked on, until it merges
+	KeyRepo                    = "repo"             // Repository the story changes in a multi-repo product ("" for the primary one)
+	KeySiblingBranches         = "sibling_branches" // Branch to check out per other repository, when stacked on a dependency there
+	KeyRequirementIDs          = "requirement_ids"  // Spec requirement IDs the story covers (commit message footer)
 	KeyQuestionSubmitted       = "question_submitted"
 	KeyPlanSubmitted           = "plan_submitted"
 	KeyStoryCompletedAt        = "story_completed_at"
@@ -80,6 +85,7 @@ const (
 	KeyPRURL                   = "pr_url"
 	KeyPRCreated               = "pr_created"
 	KeyPRSkipped               = "pr_skipped"
+	KeyReviewReplies           = "review_replies" // JSON review comments to reply to once addressed
 	KeyTaskContent             = "task_content"
 	KeyPlanApprovalResult      = "plan_approval_result"
 	KeyCodeApprovalResult      = "code_approval_result"
@@ -154,11 +160,13 @@ var CoderTransitions = map[proto.State][]proto.State{ //nolint:gochecknoglobals
 	// BUDGET_REVIEW can continue (→CODING), pivot (→PLANNING), or abandon (→ERROR).
 	StateBudgetReview: {StatePlanning, StateCoding, proto.StateError},
 
-	// PREPARE_MERGE can commit and create PR (→AWAIT_MERGE), encounter recoverable git errors (→CODING), or hit unrecoverable errors (→ERROR).
-	StatePrepareMerge: {StateAwaitMerge, StateCoding, proto.StateError},
+	// PREPARE_MERGE can commit and create PR (→AWAIT_MERGE), encounter recoverable git errors (→CODING),
+	// escalate rebase conflicts the conflict resolver could not resolve (→QUESTION), or hit unrecoverable errors (→ERROR).
+	StatePrepareMerge: {StateAwaitMerge, StateCoding, StateQuestion, proto.StateError},
 
-	// AWAIT_MERGE can complete successfully (→DONE), encounter merge conflicts (→CODING), or have channel closure (→ERROR).
-	StateAwaitMerge: {proto.StateDone, StateCoding, proto.StateError},
+	// AWAIT_MERGE can complete successfully (→DONE), encounter merge conflicts (→CODING), resubmit a stacked PR rebased onto its new base (→PREPARE_MERGE),
+	// escalate conflicts of that rebase (→QUESTION), or have channel closure (→ERROR).
+	StateAwaitMerge: {proto.StateDone, StateCoding, StatePrepareMerge, StateQuestion, proto.StateError},
 
 	// QUESTION asks architect for guidance, then returns to origin state (PLANNING or CODING), or hits error.
 	StateQuestion: {StatePlanning, StateCoding, proto.StateError},
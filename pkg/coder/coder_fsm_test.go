@@ -111,11 +111,14 @@ func TestIsValidCoderTransition_AllowedTransitions(t *testing.T) {
 		// PREPARE_MERGE transitions
 		{StatePrepareMerge, StateAwaitMerge},
 		{StatePrepareMerge, StateCoding},
+		{StatePrepareMerge, StateQuestion},
 		{StatePrepareMerge, proto.StateError},
 
 		// AWAIT_MERGE transitions
 		{StateAwaitMerge, proto.StateDone},
 		{StateAwaitMerge, StateCoding},
+		{StateAwaitMerge, StatePrepareMerge},
+		{StateAwaitMerge, StateQuestion},
 		{StateAwaitMerge, proto.StateError},
 
 		// QUESTION transitions
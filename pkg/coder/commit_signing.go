@@ -0,0 +1,106 @@
+package coder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/config"
+	execpkg "orchestrator/pkg/exec"
+)
+
+// signingKeyDir is where a coder's commit signing key is mounted in its coding container.
+const signingKeyDir = "/signing"
+
+// appendSigningKeyMount writes the coder's commit signing key (git.commit_signing)
+// from the secrets store to a private temp directory and adds its read-only
+// mount. The directory is removed when the container stops (removeSigningKey),
+// so the decrypted key only exists while a container can use it. Mounts are
+// returned unchanged when commits are not signed.
+func (c *Coder) appendSigningKeyMount(mounts []execpkg.Mount) ([]execpkg.Mount, error) {
+	if config.GetCommitSigning() == "" {
+		return mounts, nil
+	}
+
+	key, err := config.GetSigningKey(c.GetID())
+	if err != nil {
+		return nil, fmt.Errorf("commit signing is enabled but no signing key is stored (set %s or %s_<AGENT_ID>): %w",
+			config.SigningKeySecret, config.SigningKeySecret, err)
+	}
+
+	// A replacement container gets its own copy; the old one is stopped next
+	c.removeSigningKey()
+	keyDir, err := os.MkdirTemp("", "maestro-signing-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key directory: %w", err)
+	}
+	c.signingKeyDir = keyDir
+	if err := os.WriteFile(filepath.Join(keyDir, "key"), []byte(strings.TrimSpace(key)+"\n"), 0o600); err != nil {
+		c.removeSigningKey()
+		return nil, fmt.Errorf("failed to write signing key: %w", err)
+	}
+
+	return append(mounts, execpkg.Mount{
+		Source:      keyDir,
+		Destination: signingKeyDir,
+		ReadOnly:    true,
+	}), nil
+}
+
+// removeSigningKey deletes the host copy of the signing key, if one was written.
+func (c *Coder) removeSigningKey() {
+	if c.signingKeyDir == "" {
+		return
+	}
+	if err := os.RemoveAll(c.signingKeyDir); err != nil {
+		c.logger.Warn("Failed to remove signing key directory %s: %v", c.signingKeyDir, err)
+		return
+	}
+	c.signingKeyDir = ""
+}
+
+// configureCommitSigning configures git in the coding container to sign every
+// commit (including rebased ones) with the mounted signing key.
+func (c *Coder) configureCommitSigning(ctx context.Context) error {
+	format := config.GetCommitSigning()
+	if format == "" {
+		return nil
+	}
+
+	opts := &execpkg.Opts{
+		WorkDir: "/workspace",
+		Timeout: 30 * time.Second,
+	}
+	keyPath := signingKeyDir + "/key"
+
+	var settings [][2]string
+	switch format {
+	case config.CommitSigningSSH:
+		settings = [][2]string{{"gpg.format", "ssh"}, {"user.signingkey", keyPath}}
+	case config.CommitSigningGPG:
+		// Import the key into the container's keyring ($HOME/.gnupg) and sign with its fingerprint
+		script := fmt.Sprintf("gpg --batch --quiet --import %s && gpg --batch --with-colons --list-secret-keys | awk -F: '/^fpr/ {print $10; exit}'", keyPath)
+		result, err := c.longRunningExecutor.Run(ctx, []string{"sh", "-c", script}, opts)
+		fingerprint := strings.TrimSpace(result.Stdout)
+		if err != nil || result.ExitCode != 0 || fingerprint == "" {
+			return fmt.Errorf("failed to import GPG signing key: %w (stderr: %s)", err, result.Stderr)
+		}
+		settings = [][2]string{{"gpg.format", "openpgp"}, {"user.signingkey", fingerprint}}
+	default:
+		return fmt.Errorf("unknown commit signing format %q", format)
+	}
+	settings = append(settings, [2]string{"commit.gpgsign", "true"})
+
+	for _, setting := range settings {
+		result, err := c.longRunningExecutor.Run(ctx, []string{"git", "config", setting[0], setting[1]}, opts)
+		if err != nil || result.ExitCode != 0 {
+			return fmt.Errorf("failed to set git %s: %w (stderr: %s)", setting[0], err, result.Stderr)
+		}
+	}
+
+	c.logger.Info("🔏 Commits will be signed with the %s key of %s", format, c.GetID())
+	return nil
+}
@@ -0,0 +1,54 @@
+package coder
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/proto"
+)
+
+func TestSigningKeyRemovedWithContainer(t *testing.T) {
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{CommitSigning: config.CommitSigningSSH}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+	t.Setenv("MAESTRO_"+config.SigningKeySecret, "private-key")
+
+	c := &Coder{
+		BaseStateMachine: agent.NewBaseStateMachine("coder-001", proto.StateWaiting, nil, nil),
+		logger:           logx.NewLogger("test-signing"),
+	}
+
+	mounts, err := c.appendSigningKeyMount(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(mounts) != 1 || mounts[0].Destination != signingKeyDir || !mounts[0].ReadOnly {
+		t.Fatalf("Unexpected mounts: %+v", mounts)
+	}
+	first := mounts[0].Source
+	if data, readErr := os.ReadFile(filepath.Join(first, "key")); readErr != nil || string(data) != "private-key\n" {
+		t.Fatalf("Expected the key in the mounted directory, got %q (err: %v)", data, readErr)
+	}
+
+	// A replacement container gets a fresh copy and the previous one is removed
+	mounts, err = c.appendSigningKeyMount(nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	second := mounts[0].Source
+	if _, statErr := os.Stat(first); !os.IsNotExist(statErr) {
+		t.Errorf("Expected the previous key directory %s to be removed", first)
+	}
+
+	c.cleanupContainer(context.Background(), "test")
+	if _, statErr := os.Stat(second); !os.IsNotExist(statErr) {
+		t.Errorf("Expected the key directory %s to be removed with the container", second)
+	}
+	if c.signingKeyDir != "" {
+		t.Errorf("Expected no key directory to be tracked, got %s", c.signingKeyDir)
+	}
+}
@@ -0,0 +1,558 @@
+package coder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/agent/llm"
+	"orchestrator/pkg/agent/toolloop"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/contextmgr"
+	execpkg "orchestrator/pkg/exec"
+	"orchestrator/pkg/proto"
+	"orchestrator/pkg/templates"
+	"orchestrator/pkg/tools"
+	"orchestrator/pkg/utils"
+)
+
+const (
+	maxConflictResolutionIterations = 8
+	conflictResolutionTemperature   = 0.2
+
+	// maxConflictResolutionSteps bounds how many replayed commits of one rebase
+	// the resolver handles before handing the rest to the coder.
+	maxConflictResolutionSteps = 5
+
+	// conflictContextLines is how many lines around a hunk are shown to the LLM.
+	conflictContextLines = 3
+
+	maxMergedChangesLen = 4000
+)
+
+// conflictHunk is one conflicted region of a file, as written by git with
+// merge.conflictStyle=diff3. During a rebase "ours" is the branch being rebased
+// onto and "theirs" is the story commit being replayed.
+type conflictHunk struct {
+	File   string
+	Index  int // 1-based position of the hunk in its file
+	Before string
+	Ours   string
+	Base   string
+	Theirs string
+	After  string
+}
+
+// conflictResolution is the outcome of a run of the conflict resolver.
+type conflictResolution struct {
+	Resolved  bool
+	Summaries []string // How each resolved commit's conflicts were combined
+	Report    string   // Why resolution stopped, when not resolved
+}
+
+// resolveRebaseConflicts handles a rebase stopped on conflicts: a fresh-context LLM
+// loop proposes a resolution for every conflicted hunk, which is applied before the
+// rebase continues, for up to maxConflictResolutionSteps commits. Once the rebase
+// completes the tests run again and the branch is pushed.
+//
+// Returns resolved=true when the caller can carry on with the rebased, pushed branch.
+// Otherwise the returned state sends the coder back to CODING (failing tests or push)
+// or escalates the conflict to the architect with a report (QUESTION, resuming in CODING).
+func (c *Coder) resolveRebaseConflicts(
+	ctx context.Context,
+	sm *agent.BaseStateMachine,
+	conflictErr *RebaseConflictError,
+	localBranch, remoteBranch, targetBranch string,
+) (proto.State, bool) {
+	c.logger.Info("🔀 Rebase onto %s stopped on conflicts in %s, starting conflict resolver", targetBranch, strings.Join(conflictErr.ConflictingFiles, ", "))
+	result := c.runConflictResolver(ctx, sm, targetBranch)
+
+	if !result.Resolved {
+		return c.escalateRebaseConflict(ctx, sm, conflictErr, targetBranch, result), false
+	}
+
+	resolvedNote := "Rebase conflicts were resolved automatically:\n- " + strings.Join(result.Summaries, "\n- ")
+	if testFailureMsg := c.runPostRebaseTests(ctx); testFailureMsg != "" {
+		msg := resolvedNote + "\n\n" + testFailureMsg
+		c.contextManager.AddMessage("system", msg)
+		sm.SetStateData(KeyResumeInput, msg)
+		return StateCoding, false
+	}
+	if err := c.pushRebasedBranch(ctx, localBranch, remoteBranch, targetBranch); err != nil {
+		msg := fmt.Sprintf("%s\n\nPushing the rebased branch failed: %v\n\nPush it again, then use the done tool to resubmit.", resolvedNote, err)
+		c.contextManager.AddMessage("system", msg)
+		sm.SetStateData(KeyResumeInput, msg)
+		return StateCoding, false
+	}
+
+	c.logger.Info("🔀 Conflicts resolved, tests passed and rebased branch pushed")
+	return StatePrepareMerge, true
+}
+
+// runConflictResolver resolves the conflicts of each replayed commit in turn.
+// The workspace is left mid-rebase when it fails.
+func (c *Coder) runConflictResolver(ctx context.Context, sm *agent.BaseStateMachine, targetBranch string) conflictResolution {
+	var result conflictResolution
+	if c.renderer == nil || c.LLMClient == nil {
+		result.Report = "The conflict resolver is not available."
+		return result
+	}
+
+	for step := 1; step <= maxConflictResolutionSteps; step++ {
+		files := c.getConflictingFiles(ctx)
+		if len(files) == 0 {
+			result.Report = "The rebase stopped, but git reports no conflicted files to resolve."
+			return result
+		}
+
+		hunks, err := c.loadConflictHunks(ctx, files)
+		if err != nil {
+			result.Report = err.Error()
+			return result
+		}
+
+		resolutions, summary, report := c.proposeConflictResolutions(ctx, sm, targetBranch, files, hunks)
+		if report != "" {
+			result.Report = report
+			return result
+		}
+		if err := c.applyConflictResolutions(ctx, files, resolutions); err != nil {
+			result.Report = fmt.Sprintf("Applying the proposed resolutions failed: %v\nProposed approach: %s", err, summary)
+			return result
+		}
+		result.Summaries = append(result.Summaries, summary)
+		c.logger.Info("🔀 Resolved %d conflicted hunk(s) in %d file(s): %s", len(hunks), len(files), summary)
+
+		stillConflicted, err := c.continueRebaseAfterResolution(ctx)
+		if err != nil {
+			result.Report = err.Error()
+			return result
+		}
+		if !stillConflicted {
+			result.Resolved = true
+			return result
+		}
+	}
+
+	result.Report = fmt.Sprintf("The rebase kept stopping on conflicts after %d commits were resolved automatically.", maxConflictResolutionSteps)
+	return result
+}
+
+// loadConflictHunks rewrites the conflicted files with base, ours and theirs
+// markers and parses their hunks.
+func (c *Coder) loadConflictHunks(ctx context.Context, files []string) ([]conflictHunk, error) {
+	opts := &execpkg.Opts{WorkDir: c.workDir, Timeout: 30 * time.Second}
+	args := append([]string{"git", "checkout", "--conflict=diff3", "--"}, files...)
+	if result, err := c.longRunningExecutor.Run(ctx, args, opts); err != nil || result.ExitCode != 0 {
+		return nil, fmt.Errorf("could not show three-way conflicts for %s (%s): %v", strings.Join(files, ", "), strings.TrimSpace(result.Stderr), err)
+	}
+
+	var hunks []conflictHunk
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(c.workDir, file))
+		if err != nil {
+			return nil, fmt.Errorf("%s cannot be resolved automatically (deleted on one side, or unreadable): %w", file, err)
+		}
+		fileHunks, err := parseConflictHunks(file, string(content))
+		if err != nil {
+			return nil, err
+		}
+		if len(fileHunks) == 0 {
+			return nil, fmt.Errorf("%s is conflicted but has no conflict markers (binary file, or a rename or delete conflict)", file)
+		}
+		hunks = append(hunks, fileHunks...)
+	}
+	return hunks, nil
+}
+
+// proposeConflictResolutions runs the LLM loop over the conflicted hunks. It returns
+// the resolution of each hunk, keyed by file and hunk number, and the LLM's summary,
+// or a report of why no resolution was proposed.
+//
+//nolint:cyclop // Outcome handling mirrors verification.go
+func (c *Coder) proposeConflictResolutions(
+	ctx context.Context,
+	sm *agent.BaseStateMachine,
+	targetBranch string,
+	files []string,
+	hunks []conflictHunk,
+) (resolutions map[string]map[int]string, summary, report string) {
+	hunkCounts := make(map[string]int, len(files))
+	for i := range hunks {
+		hunkCounts[hunks[i].File]++
+	}
+
+	templateData := &templates.TemplateData{
+		TaskContent: utils.GetStateValueOr[string](sm, string(stateDataKeyTaskContent), ""),
+		Plan:        utils.GetStateValueOr[string](sm, KeyPlan, ""),
+		Extra: map[string]any{
+			"TargetBranch":  targetBranch,
+			"Conflicts":     formatConflictHunksForPrompt(hunks),
+			"MergedChanges": c.mergedChangesFor(ctx, files),
+			"StoryCommit":   c.replayedCommitMessage(ctx),
+			"MaxTurns":      maxConflictResolutionIterations,
+		},
+	}
+	prompt, err := c.renderer.RenderWithUserInstructions(templates.ConflictResolutionTemplate, templateData, c.workDir, "CODER")
+	if err != nil {
+		return nil, "", fmt.Sprintf("The conflict resolver prompt could not be rendered: %v", err)
+	}
+
+	resolverCM := contextmgr.NewContextManager()
+	resolverCM.ResetForNewTemplate("conflict-resolution", prompt)
+
+	shellTool, err := c.createVerificationToolProvider().Get(tools.ToolShell)
+	if err != nil {
+		return nil, "", fmt.Sprintf("The conflict resolver tools could not be set up: %v", err)
+	}
+
+	storyID := utils.GetStateValueOr[string](sm, KeyStoryID, "")
+	loop := toolloop.New(c.LLMClient, c.logger)
+	cfg := &toolloop.Config[struct{}]{
+		ContextManager:     resolverCM,
+		GeneralTools:       []tools.Tool{shellTool},
+		TerminalTool:       tools.NewResolveConflictsTool(hunkCounts),
+		MaxIterations:      maxConflictResolutionIterations,
+		MaxTokens:          8192,
+		Temperature:        conflictResolutionTemperature,
+		AgentID:            c.GetAgentID(),
+		DebugLogging:       config.GetDebugLLMMessages(),
+		ActivityTracker:    c.activityTracker,
+		PersistenceChannel: c.persistenceChannel,
+		StoryID:            storyID,
+		Purpose:            llm.PurposeConflicts,
+		BeforeIteration: func(iteration int, cm *contextmgr.ContextManager) {
+			if iteration == maxConflictResolutionIterations-1 {
+				cm.AddMessage("user", "You have 1 tool call remaining. Call resolve_conflicts now, with unresolvable_reason if you cannot resolve every hunk.")
+			}
+		},
+	}
+
+	out := toolloop.Run[struct{}](loop, ctx, cfg)
+	switch out.Kind {
+	case toolloop.OutcomeProcessEffect:
+		data, _ := out.EffectData.(map[string]any)
+		summary, _ = data["summary"].(string)
+		switch out.Signal {
+		case tools.SignalConflictsResolved:
+			return collectConflictResolutions(data), summary, ""
+		case tools.SignalConflictsUnresolved:
+			reason, _ := data["unresolvable_reason"].(string)
+			return nil, "", fmt.Sprintf("The resolver judged the conflicts unresolvable: %s\nWhat it found: %s", reason, summary)
+		default:
+			return nil, "", fmt.Sprintf("The resolver ended with an unexpected signal: %s", out.Signal)
+		}
+	case toolloop.OutcomeMaxIterations, toolloop.OutcomeNoToolTwice:
+		return nil, "", fmt.Sprintf("The resolver did not submit resolutions (%s at iteration %d).", out.Kind, out.Iteration)
+	case toolloop.OutcomeLLMError:
+		return nil, "", fmt.Sprintf("The resolver failed with an LLM error: %v", out.Err)
+	case toolloop.OutcomeGracefulShutdown:
+		return nil, "", "The resolver was interrupted by shutdown."
+	default:
+		return nil, "", fmt.Sprintf("The resolver ended unexpectedly: %s", out.Kind)
+	}
+}
+
+// collectConflictResolutions indexes the resolve_conflicts tool's resolutions by file and hunk.
+func collectConflictResolutions(data map[string]any) map[string]map[int]string {
+	resolutions := make(map[string]map[int]string)
+	items, _ := data["resolutions"].([]any)
+	for _, item := range items {
+		resolution, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		file, _ := resolution["file"].(string)
+		hunk, _ := resolution["hunk"].(int)
+		text, _ := resolution["resolution"].(string)
+		if resolutions[file] == nil {
+			resolutions[file] = make(map[int]string)
+		}
+		resolutions[file][hunk] = text
+	}
+	return resolutions
+}
+
+// applyConflictResolutions writes the resolved files and stages them. Every file
+// is resolved in memory first, so a bad resolution leaves the workspace untouched.
+func (c *Coder) applyConflictResolutions(ctx context.Context, files []string, resolutions map[string]map[int]string) error {
+	resolved := make(map[string]string, len(files))
+	for _, file := range files {
+		path := filepath.Join(c.workDir, file)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		merged, err := resolveConflictHunks(file, string(content), resolutions[file])
+		if err != nil {
+			return err
+		}
+		resolved[path] = merged
+	}
+
+	for path, merged := range resolved {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		if err := os.WriteFile(path, []byte(merged), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	opts := &execpkg.Opts{WorkDir: c.workDir, Timeout: 30 * time.Second}
+	result, err := c.longRunningExecutor.Run(ctx, append([]string{"git", "add", "--"}, files...), opts)
+	if err != nil || result.ExitCode != 0 {
+		return fmt.Errorf("failed to stage resolved files: %v (stderr: %s)", err, result.Stderr)
+	}
+	return nil
+}
+
+// continueRebaseAfterResolution continues the rebase without opening an editor.
+// Returns true when a later commit stopped on conflicts in turn.
+func (c *Coder) continueRebaseAfterResolution(ctx context.Context) (bool, error) {
+	opts := &execpkg.Opts{WorkDir: c.workDir, Timeout: 2 * time.Minute}
+	result, err := c.longRunningExecutor.Run(ctx, []string{"git", "-c", "core.editor=true", "rebase", "--continue"}, opts)
+	if err == nil && result.ExitCode == 0 {
+		return false, nil
+	}
+	if len(c.getConflictingFiles(ctx)) > 0 {
+		return true, nil
+	}
+	return false, fmt.Errorf("git rebase --continue failed after resolving conflicts: %v (stderr: %s)", err, strings.TrimSpace(result.Stderr))
+}
+
+// mergedChangesFor describes the commits on the branch being rebased onto that
+// touched the conflicted files since the story branched. Squash-merged stories
+// carry their PR description in the commit message.
+func (c *Coder) mergedChangesFor(ctx context.Context, files []string) string {
+	onto := "HEAD"
+	if data, err := os.ReadFile(filepath.Join(c.workDir, ".git", "rebase-merge", "onto")); err == nil {
+		onto = strings.TrimSpace(string(data))
+	}
+
+	opts := &execpkg.Opts{WorkDir: c.workDir, Timeout: 30 * time.Second}
+	args := append([]string{"git", "log", "--no-merges", "--max-count=5", "--format=### %h %s%n%n%b", "REBASE_HEAD.." + onto, "--"}, files...)
+	result, err := c.longRunningExecutor.Run(ctx, args, opts)
+	if err != nil || result.ExitCode != 0 {
+		c.logger.Debug("🔀 Could not list merged changes to %s: %v", strings.Join(files, ", "), err)
+		return ""
+	}
+	return truncateText(strings.TrimSpace(result.Stdout), maxMergedChangesLen)
+}
+
+// replayedCommitMessage returns the message of the story commit the rebase stopped on.
+func (c *Coder) replayedCommitMessage(ctx context.Context) string {
+	opts := &execpkg.Opts{WorkDir: c.workDir, Timeout: 30 * time.Second}
+	result, err := c.longRunningExecutor.Run(ctx, []string{"git", "log", "-1", "--format=%B", "REBASE_HEAD"}, opts)
+	if err != nil || result.ExitCode != 0 {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// escalateRebaseConflict asks the architect how to resolve conflicts the resolver
+// could not, and leaves the coder in CODING with the conflict report once answered.
+func (c *Coder) escalateRebaseConflict(
+	ctx context.Context,
+	sm *agent.BaseStateMachine,
+	conflictErr *RebaseConflictError,
+	targetBranch string,
+	result conflictResolution,
+) proto.State {
+	files := c.getConflictingFiles(ctx)
+	if len(files) == 0 {
+		files = conflictErr.ConflictingFiles
+	}
+	report := buildConflictReport(targetBranch, files, result)
+	c.logger.Warn("🔀 Conflict resolver could not resolve the rebase, escalating: %s", result.Report)
+
+	msg := c.buildConflictResolutionMessage(&MergeConflictInfo{
+		Kind:             FailureRebaseConflict,
+		ErrorOutput:      conflictErr.ErrorOutput,
+		ConflictingFiles: files,
+		GitStatus:        c.getGitStatusForError(ctx),
+		MidRebase:        true,
+		AttemptNumber:    utils.GetStateValueOr[int](sm, KeyMergeAttemptCount, 0),
+		MaxAttempts:      MaxTotalAttempts,
+	}) + "\n" + report
+	c.contextManager.AddMessage("system", msg)
+
+	sm.SetStateData(KeyPendingQuestion, map[string]any{
+		"question": fmt.Sprintf("Rebasing onto %s stopped on conflicts in %s that could not be resolved automatically. How should they be resolved?",
+			targetBranch, strings.Join(files, ", ")),
+		"context": report,
+		"urgency": "high",
+		"origin":  string(StateCoding),
+	})
+	return StateQuestion
+}
+
+// buildConflictReport summarises a failed conflict resolution for the architect.
+func buildConflictReport(targetBranch string, files []string, result conflictResolution) string {
+	var sb strings.Builder
+	sb.WriteString("## Conflict Report\n\n")
+	fmt.Fprintf(&sb, "Rebasing onto `%s` stopped on conflicts in:\n", targetBranch)
+	for _, file := range files {
+		fmt.Fprintf(&sb, "- `%s`\n", file)
+	}
+	if len(result.Summaries) > 0 {
+		sb.WriteString("\nConflicts of earlier commits were resolved automatically:\n")
+		for _, summary := range result.Summaries {
+			fmt.Fprintf(&sb, "- %s\n", summary)
+		}
+	}
+	fmt.Fprintf(&sb, "\n**Why automatic resolution stopped:** %s\n", result.Report)
+	return sb.String()
+}
+
+// parseConflictHunks parses the diff3-style conflict hunks of a file.
+func parseConflictHunks(file, content string) ([]conflictHunk, error) {
+	lines := strings.SplitAfter(content, "\n")
+	var hunks []conflictHunk
+	for i := 0; i < len(lines); i++ {
+		if !isConflictMarker(lines[i], '<') {
+			continue
+		}
+		hunk := conflictHunk{
+			File:   file,
+			Index:  len(hunks) + 1,
+			Before: strings.Join(lines[max(0, i-conflictContextLines):i], ""),
+		}
+		end, err := parseConflictHunkBody(lines, i, &hunk)
+		if err != nil {
+			return nil, fmt.Errorf("%s hunk %d: %w", file, hunk.Index, err)
+		}
+		hunk.After = strings.Join(lines[end+1:min(len(lines), end+1+conflictContextLines)], "")
+		hunks = append(hunks, hunk)
+		i = end
+	}
+	return hunks, nil
+}
+
+// parseConflictHunkBody fills in the sides of the hunk starting at line start,
+// and returns the index of its closing marker.
+func parseConflictHunkBody(lines []string, start int, hunk *conflictHunk) (int, error) {
+	var ours, base, theirs strings.Builder
+	section := &ours
+	hasBase := false
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isConflictMarker(line, '|'):
+			section = &base
+			hasBase = true
+		case isConflictMarker(line, '=') && section != &theirs:
+			section = &theirs
+		case isConflictMarker(line, '>'):
+			if section != &theirs {
+				return 0, fmt.Errorf("conflict markers out of order")
+			}
+			if !hasBase {
+				return 0, fmt.Errorf("no base section; conflicts must use diff3 style")
+			}
+			hunk.Ours, hunk.Base, hunk.Theirs = ours.String(), base.String(), theirs.String()
+			return i, nil
+		case isConflictMarker(line, '<'):
+			return 0, fmt.Errorf("nested conflict markers")
+		default:
+			section.WriteString(line)
+		}
+	}
+	return 0, fmt.Errorf("unterminated conflict")
+}
+
+// isConflictMarker reports whether line is a conflict marker made of seven ch characters.
+func isConflictMarker(line string, ch byte) bool {
+	if len(line) < 7 || strings.Count(line[:7], string(ch)) != 7 {
+		return false
+	}
+	rest := line[7:]
+	return rest == "" || rest[0] == ' ' || rest[0] == '\n' || rest[0] == '\r'
+}
+
+// resolveConflictHunks replaces each conflict hunk of content with its resolution.
+func resolveConflictHunks(file, content string, resolutions map[int]string) (string, error) {
+	lines := strings.SplitAfter(content, "\n")
+	var sb strings.Builder
+	index := 0
+	for i := 0; i < len(lines); i++ {
+		if !isConflictMarker(lines[i], '<') {
+			sb.WriteString(lines[i])
+			continue
+		}
+		index++
+		var hunk conflictHunk
+		end, err := parseConflictHunkBody(lines, i, &hunk)
+		if err != nil {
+			return "", fmt.Errorf("%s hunk %d: %w", file, index, err)
+		}
+		resolution, ok := resolutions[index]
+		if !ok {
+			return "", fmt.Errorf("%s hunk %d has no resolution", file, index)
+		}
+		for _, ch := range []byte{'<', '|', '=', '>'} {
+			for _, line := range strings.SplitAfter(resolution, "\n") {
+				if isConflictMarker(line, ch) {
+					return "", fmt.Errorf("the resolution of %s hunk %d still contains conflict markers", file, index)
+				}
+			}
+		}
+		sb.WriteString(resolution)
+		if resolution != "" && !strings.HasSuffix(resolution, "\n") && end+1 < len(lines) {
+			sb.WriteString("\n")
+		}
+		i = end
+	}
+	if index != len(resolutions) {
+		return "", fmt.Errorf("%s has %d hunk(s) but %d resolution(s)", file, index, len(resolutions))
+	}
+	return sb.String(), nil
+}
+
+// formatConflictHunksForPrompt renders the hunks with their three sides for the resolver prompt.
+func formatConflictHunksForPrompt(hunks []conflictHunk) string {
+	counts := make(map[string]int)
+	for i := range hunks {
+		counts[hunks[i].File]++
+	}
+
+	var sb strings.Builder
+	for i := range hunks {
+		hunk := &hunks[i]
+		fmt.Fprintf(&sb, "### `%s` — hunk %d of %d\n\n", hunk.File, hunk.Index, counts[hunk.File])
+		writeConflictSide(&sb, "Context before", hunk.Before)
+		writeConflictSide(&sb, "Base", hunk.Base)
+		writeConflictSide(&sb, "Ours (target branch)", hunk.Ours)
+		writeConflictSide(&sb, "Theirs (your story)", hunk.Theirs)
+		writeConflictSide(&sb, "Context after", hunk.After)
+	}
+	return sb.String()
+}
+
+// writeConflictSide writes one labelled side of a hunk as a fenced block.
+func writeConflictSide(sb *strings.Builder, label, text string) {
+	if text == "" {
+		if strings.HasPrefix(label, "Context") {
+			return
+		}
+		text = "(empty)\n"
+	}
+	fmt.Fprintf(sb, "**%s:**\n```\n%s", label, text)
+	if !strings.HasSuffix(text, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString("```\n\n")
+}
+
+// truncateText caps text at limit bytes, noting the truncation.
+func truncateText(text string, limit int) string {
+	if len(text) <= limit {
+		return text
+	}
+	return text[:limit] + "\n[... truncated ...]"
+}
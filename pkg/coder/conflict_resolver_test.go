@@ -0,0 +1,188 @@
+package coder
+
+import (
+	"strings"
+	"testing"
+)
+
+const conflictedFile = `package main
+
+import "fmt"
+
+func greet(name string) {
+<<<<<<< HEAD
+	fmt.Printf("Hello, %s!\n", strings.TrimSpace(name))
+||||||| parent of 1a2b3c4 (Add greeting)
+	fmt.Printf("Hello, %s\n", name)
+=======
+	fmt.Printf("Hi, %s\n", name)
+>>>>>>> 1a2b3c4 (Add greeting)
+}
+
+func farewell() {
+<<<<<<< HEAD
+	fmt.Println("Bye")
+||||||| parent of 1a2b3c4 (Add greeting)
+=======
+	fmt.Println("Goodbye")
+>>>>>>> 1a2b3c4 (Add greeting)
+}
+`
+
+func TestParseConflictHunks(t *testing.T) {
+	hunks, err := parseConflictHunks("main.go", conflictedFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(hunks) != 2 {
+		t.Fatalf("Expected 2 hunks, got %d", len(hunks))
+	}
+
+	first := hunks[0]
+	if first.File != "main.go" || first.Index != 1 {
+		t.Errorf("Unexpected hunk identity: %s #%d", first.File, first.Index)
+	}
+	if first.Ours != "\tfmt.Printf(\"Hello, %s!\\n\", strings.TrimSpace(name))\n" {
+		t.Errorf("Unexpected ours: %q", first.Ours)
+	}
+	if first.Base != "\tfmt.Printf(\"Hello, %s\\n\", name)\n" {
+		t.Errorf("Unexpected base: %q", first.Base)
+	}
+	if first.Theirs != "\tfmt.Printf(\"Hi, %s\\n\", name)\n" {
+		t.Errorf("Unexpected theirs: %q", first.Theirs)
+	}
+	if first.Before != "import \"fmt\"\n\nfunc greet(name string) {\n" {
+		t.Errorf("Unexpected context before: %q", first.Before)
+	}
+	if first.After != "}\n\nfunc farewell() {\n" {
+		t.Errorf("Unexpected context after: %q", first.After)
+	}
+
+	if hunks[1].Index != 2 || hunks[1].Base != "" || hunks[1].Theirs != "\tfmt.Println(\"Goodbye\")\n" {
+		t.Errorf("Unexpected second hunk: %+v", hunks[1])
+	}
+}
+
+func TestParseConflictHunks_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "NoBase",
+			content: "<<<<<<< HEAD\na\n=======\nb\n>>>>>>> theirs\n",
+			wantErr: "diff3",
+		},
+		{
+			name:    "Unterminated",
+			content: "<<<<<<< HEAD\na\n||||||| base\n=======\nb\n",
+			wantErr: "unterminated",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseConflictHunks("main.go", tc.content)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestResolveConflictHunks(t *testing.T) {
+	resolved, err := resolveConflictHunks("main.go", conflictedFile, map[int]string{
+		1: "\tfmt.Printf(\"Hi, %s!\\n\", strings.TrimSpace(name))",
+		2: "\tfmt.Println(\"Goodbye\")\n",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	expected := `package main
+
+import "fmt"
+
+func greet(name string) {
+	fmt.Printf("Hi, %s!\n", strings.TrimSpace(name))
+}
+
+func farewell() {
+	fmt.Println("Goodbye")
+}
+`
+	if resolved != expected {
+		t.Errorf("Unexpected resolved content:\n%s", resolved)
+	}
+}
+
+func TestResolveConflictHunks_Invalid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		resolutions map[int]string
+		wantErr     string
+	}{
+		{
+			name:        "MissingHunk",
+			resolutions: map[int]string{1: "a\n"},
+			wantErr:     "hunk 2 has no resolution",
+		},
+		{
+			name:        "ExtraHunk",
+			resolutions: map[int]string{1: "a\n", 2: "b\n", 3: "c\n"},
+			wantErr:     "2 hunk(s) but 3 resolution(s)",
+		},
+		{
+			name:        "MarkersLeft",
+			resolutions: map[int]string{1: "a\n=======\nb\n", 2: "c\n"},
+			wantErr:     "still contains conflict markers",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := resolveConflictHunks("main.go", conflictedFile, tc.resolutions)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFormatConflictHunksForPrompt(t *testing.T) {
+	hunks, err := parseConflictHunks("main.go", conflictedFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	prompt := formatConflictHunksForPrompt(hunks)
+	for _, part := range []string{
+		"### `main.go` — hunk 1 of 2",
+		"### `main.go` — hunk 2 of 2",
+		"**Base:**\n```\n(empty)\n```",
+		"**Ours (target branch):**",
+		"**Theirs (your story):**\n```\n\tfmt.Println(\"Goodbye\")\n```",
+	} {
+		if !strings.Contains(prompt, part) {
+			t.Errorf("Expected prompt to contain %q, got:\n%s", part, prompt)
+		}
+	}
+}
+
+func TestBuildConflictReport(t *testing.T) {
+	report := buildConflictReport("main", []string{"api/handler.go"}, conflictResolution{
+		Summaries: []string{"Kept the new error type and the story's validation"},
+		Report:    "The resolver judged the conflicts unresolvable: both sides rename the route",
+	})
+
+	for _, part := range []string{
+		"Rebasing onto `main` stopped on conflicts in:\n- `api/handler.go`",
+		"- Kept the new error type and the story's validation",
+		"**Why automatic resolution stopped:** The resolver judged the conflicts unresolvable",
+	} {
+		if !strings.Contains(report, part) {
+			t.Errorf("Expected report to contain %q, got:\n%s", part, report)
+		}
+	}
+}
@@ -72,6 +72,7 @@ type Coder struct {
 	workDir                       string                 // Current working directory (may be story-specific)
 	originalWorkDir               string                 // Original agent work directory (for cleanup)
 	containerName                 string                 // Current story container name
+	signingKeyDir                 string                 // Host temp directory holding the mounted signing key, removed with the container
 	codingBudget                  int                    // Iteration budgets
 	todoList                      *TodoList              // Implementation todo list
 	claudeCodeAvailabilityChecked bool                   // Whether Claude Code availability has been checked for this story
@@ -1318,6 +1319,7 @@ func (c *Coder) createCodingToolProvider(storyType string, isHotfix bool, storyI
 	}
 
 	// Create agent context for coding (read-write access)
+	targetBranch, _ := c.getTargetBranch()
 	agentCtx := tools.AgentContext{
 		Executor:        c.longRunningExecutor, // Use container executor
 		Agent:           c,                     // Pass agent reference for workDir access
@@ -1326,9 +1328,12 @@ func (c *Coder) createCodingToolProvider(storyType string, isHotfix bool, storyI
 		ReadOnly:        false,                 // Coding requires write access
 		NetworkDisabled: false,                 // May need network for builds/tests
 		WorkDir:         c.workDir,
-		AgentID:         c.GetAgentID(),            // Required for compose_up project name isolation
-		StoryID:         storyID,                   // For done tool commit message prefix
-		TargetBranch:    config.GetGitBaseBranch(), // For done tool merge-base check
+		AgentID:         c.GetAgentID(), // Required for compose_up project name isolation
+		StoryID:         storyID,        // For done tool commit message prefix
+		TargetBranch:    targetBranch,   // For done tool merge-base check
+		StoryType:       storyType,      // For done tool commit message template
+		IsHotfix:        isHotfix,
+		RequirementIDs:  utils.GetStateValueOr[[]string](c.BaseStateMachine, KeyRequirementIDs, nil),
 	}
 
 	return tools.NewProvider(&agentCtx, codingTools)
@@ -1366,6 +1371,7 @@ func (c *Coder) createClaudeCodeCodingToolProvider(storyType, storyID string) *t
 	}
 
 	// Create agent context for coding (read-write access)
+	targetBranch, _ := c.getTargetBranch()
 	agentCtx := tools.AgentContext{
 		Executor:        c.longRunningExecutor, // Use container executor
 		Agent:           c,                     // Pass agent reference for workDir access
@@ -1374,9 +1380,12 @@ func (c *Coder) createClaudeCodeCodingToolProvider(storyType, storyID string) *t
 		ReadOnly:        false,                 // Coding requires write access
 		NetworkDisabled: false,                 // May need network for builds/tests
 		WorkDir:         c.workDir,
-		AgentID:         c.GetAgentID(),            // Required for chat tools (chat_read needs agent_id)
-		StoryID:         storyID,                   // For done tool commit message prefix
-		TargetBranch:    config.GetGitBaseBranch(), // For done tool merge-base check
+		AgentID:         c.GetAgentID(), // Required for chat tools (chat_read needs agent_id)
+		StoryID:         storyID,        // For done tool commit message prefix
+		TargetBranch:    targetBranch,   // For done tool merge-base check
+		StoryType:       storyType,      // For done tool commit message template
+		IsHotfix:        utils.GetStateValueOr[bool](c.BaseStateMachine, KeyIsHotfix, false),
+		RequirementIDs:  utils.GetStateValueOr[[]string](c.BaseStateMachine, KeyRequirementIDs, nil),
 	}
 
 	return tools.NewProvider(&agentCtx, codingTools)
@@ -1839,6 +1848,10 @@ func (c *Coder) SwitchContainer(ctx context.Context, newImage, stagingImageID, s
 			})
 		}
 	}
+	var mountErr error
+	if execOpts.ExtraMounts, mountErr = c.appendSigningKeyMount(execOpts.ExtraMounts); mountErr != nil {
+		return "", fmt.Errorf("commit signing setup failed: %w", mountErr)
+	}
 
 	agentID := c.GetID()
 	sanitizedAgentID := utils.SanitizeContainerName(agentID)
@@ -1907,7 +1920,12 @@ func (c *Coder) SwitchContainer(ctx context.Context, newImage, stagingImageID, s
 		c.logger.Warn("Failed to remap origin to container mirror path (non-fatal): %v", err)
 	}
 
-	// Step 8: Reset Claude Code availability flags (new container may differ)
+	// Step 8: Re-import the signing key; a GPG keyring does not survive the container
+	if err := c.configureCommitSigning(ctx); err != nil {
+		return "", fmt.Errorf("commit signing setup failed in new container: %w", err)
+	}
+
+	// Step 9: Reset Claude Code availability flags (new container may differ)
 	c.claudeCodeAvailabilityChecked = false
 	c.claudeCodeAvailable = false
 
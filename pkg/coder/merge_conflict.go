@@ -146,6 +146,27 @@ func (c *Coder) detectGitWorkspaceState(ctx context.Context) (*GitWorkspaceState
 	return state, nil
 }
 
+// branchChangedFiles returns the files the story branch changes relative to the target branch.
+func (c *Coder) branchChangedFiles(ctx context.Context, targetBranch string) ([]string, error) {
+	result, err := c.longRunningExecutor.Run(ctx, []string{
+		"git", "diff", "--name-only", fmt.Sprintf("origin/%s...HEAD", targetBranch),
+	}, &execpkg.Opts{WorkDir: c.workDir, Timeout: 30 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed files: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("failed to list changed files: exit=%d, stderr: %s", result.ExitCode, result.Stderr)
+	}
+
+	var files []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 // getRemoteHEAD refreshes the mirror and returns the current SHA of the target branch.
 // Uses refreshMirrorOnHost to sync the mirror from GitHub, then fetches from origin (mirror)
 // inside the container to get the latest refs.
@@ -318,9 +339,10 @@ func (c *Coder) buildConflictResolutionMessage(info *MergeConflictInfo) string {
 		sb.WriteString("5. If you get stuck, you can abort with: `git rebase --abort`\n\n")
 		sb.WriteString("**For binary files:** Use `git checkout --ours <file>` (keep yours) or `git checkout --theirs <file>` (keep theirs)\n\n")
 	} else {
+		targetBranch, _ := c.getTargetBranch()
 		sb.WriteString("1. View current state: `git status`\n")
-		sb.WriteString("2. Fetch latest: `git fetch origin main`\n")
-		sb.WriteString("3. Rebase onto main: `git rebase origin/main`\n")
+		fmt.Fprintf(&sb, "2. Fetch latest: `git fetch origin %s`\n", targetBranch)
+		fmt.Fprintf(&sb, "3. Rebase onto %s: `git rebase origin/%s`\n", targetBranch, targetBranch)
 		sb.WriteString("4. Resolve any conflicts that appear\n")
 		sb.WriteString("5. Stage resolved files: `git add <filename>`\n")
 		sb.WriteString("6. Continue: `git rebase --continue`\n\n")
@@ -0,0 +1,124 @@
+package coder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/config"
+	execpkg "orchestrator/pkg/exec"
+	"orchestrator/pkg/utils"
+)
+
+// siblingReposDir is where the other repositories of a multi-repo product are
+// mounted read-only in a coder's containers, one directory per repository.
+const siblingReposDir = "/repos"
+
+// storyCloneManager returns the clone manager for the repository the story changes.
+func (c *Coder) storyCloneManager(sm *agent.BaseStateMachine) (*CloneManager, error) {
+	repo := utils.GetStateValueOr[string](sm, KeyRepo, "")
+	if repo == "" {
+		return c.cloneManager, nil
+	}
+	repoURL, err := config.GetRepoURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	return c.cloneManager.ForRepo(repoURL), nil
+}
+
+// siblingReposHostDir returns the host directory holding the coder's checkouts
+// of the other repositories.
+func (c *Coder) siblingReposHostDir() (string, error) {
+	dir, err := filepath.Abs(filepath.Join(config.GetProjectDir(), ".maestro", "repos", utils.SanitizeIdentifier(c.GetID())))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve sibling repository directory: %w", err)
+	}
+	return dir, nil
+}
+
+// setupSiblingRepos checks out the other repositories of a multi-repo product
+// for the coder to read: at the target branch, or at the approved branch of a
+// dependency the story is stacked on. The task content gains a note listing them.
+func (c *Coder) setupSiblingRepos(ctx context.Context, sm *agent.BaseStateMachine) error {
+	if !config.IsMultiRepo() {
+		return nil
+	}
+	hostDir, err := c.siblingReposHostDir()
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(hostDir); err != nil {
+		return fmt.Errorf("failed to clear sibling repository directory: %w", err)
+	}
+	if err := os.MkdirAll(hostDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create sibling repository directory: %w", err)
+	}
+
+	storyRepo := config.RepoDisplayName(utils.GetStateValueOr[string](sm, KeyRepo, ""))
+	branches := utils.GetStateValueOr[map[string]string](sm, KeySiblingBranches, nil)
+
+	var lines []string
+	for _, repo := range config.GetRepos() {
+		if repo.Name == storyRepo {
+			continue
+		}
+		mirrorPath, err := c.cloneManager.ForRepo(repo.URL).ensureMirrorClone(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to update mirror of %s: %w", repo.Name, err)
+		}
+
+		branch := config.GetGitBaseBranch()
+		if stacked := branches[repo.Name]; stacked != "" {
+			if _, err := c.cloneManager.gitRunner.RunQuiet(ctx, mirrorPath, "rev-parse", "--verify", "refs/heads/"+stacked); err == nil {
+				branch = stacked
+			} else {
+				c.logger.Warn("📦 Branch %s of %s is not in the mirror, reading %s instead", stacked, repo.Name, branch)
+			}
+		}
+		if _, err := c.cloneManager.gitRunner.Run(ctx, hostDir, "clone", "--quiet", "--branch", branch, mirrorPath, repo.Name); err != nil {
+			return fmt.Errorf("failed to check out %s of %s: %w", branch, repo.Name, err)
+		}
+
+		line := fmt.Sprintf("- `%s/%s` (%s, branch %s)", siblingReposDir, repo.Name, repo.Name, branch)
+		if repo.Role != "" {
+			line = fmt.Sprintf("- `%s/%s`: %s (branch %s)", siblingReposDir, repo.Name, repo.Role, branch)
+		}
+		lines = append(lines, line)
+		c.logger.Info("📦 Checked out %s of %s for reference", branch, repo.Name)
+	}
+
+	content := utils.GetStateValueOr[string](sm, string(stateDataKeyTaskContent), "")
+	if strings.Contains(content, "**Other Repositories**") {
+		return nil // SETUP re-ran for the same story
+	}
+	note := fmt.Sprintf("\n\n**Other Repositories**\nThis product spans several repositories; this story changes only **%s** (your workspace). "+
+		"The others are checked out read-only for reference, so your changes line up with their code:\n%s\n",
+		storyRepo, strings.Join(lines, "\n"))
+	sm.SetStateData(string(stateDataKeyTaskContent), content+note)
+	return nil
+}
+
+// appendSiblingReposMount adds the read-only mount of the other repositories'
+// checkouts, when setupSiblingRepos created them.
+func (c *Coder) appendSiblingReposMount(mounts []execpkg.Mount) []execpkg.Mount {
+	if !config.IsMultiRepo() {
+		return mounts
+	}
+	hostDir, err := c.siblingReposHostDir()
+	if err != nil {
+		c.logger.Warn("📦 Cannot mount sibling repositories: %v", err)
+		return mounts
+	}
+	if _, err := os.Stat(hostDir); err != nil {
+		return mounts
+	}
+	return append(mounts, execpkg.Mount{
+		Source:      hostDir,
+		Destination: siblingReposDir,
+		ReadOnly:    true,
+	})
+}
@@ -157,28 +157,12 @@ func (c *Coder) handlePrepareMerge(ctx context.Context, sm *agent.BaseStateMachi
 		// This handles non-fast-forward errors robustly without brittle string matching.
 		// Worst case: rebase fails and we fall through to normal error handling.
 		c.logger.Info("🔀 Push failed - attempting auto-rebase onto %s", targetBranch)
-		if rebaseErr := c.attemptRebaseAndRetryPush(ctx, localBranch, remoteBranch, targetBranch); rebaseErr == nil {
+		if rebaseErr := c.attemptRebaseAndRetryPush(ctx, localBranch, remoteBranch, targetBranch, ""); rebaseErr == nil {
 			// Rebase and push succeeded - run tests to verify rebased code still works
 			c.logger.Info("🔀 Auto-rebase succeeded, running post-rebase tests")
-
-			// Run tests to verify rebased code
-			if c.buildService != nil {
-				testResult, testErr := c.runTestWithBuildService(ctx, c.workDir)
-				if testErr != nil {
-					c.logger.Warn("🔀 Post-rebase tests failed with error: %v", testErr)
-					testFailureMsg := fmt.Sprintf("Tests failed after rebase:\n\n%s\n\nPlease fix the issues and try again.", testErr.Error())
-					sm.SetStateData(KeyResumeInput, testFailureMsg)
-					return StateCoding, false, nil
-				}
-				if !testResult.passed && !testResult.skipped {
-					c.logger.Warn("🔀 Post-rebase tests failed")
-					testFailureMsg := fmt.Sprintf("Tests failed after rebase:\n\n%s\n\nPlease fix the issues and try again.", truncateOutput(testResult.output))
-					sm.SetStateData(KeyResumeInput, testFailureMsg)
-					return StateCoding, false, nil
-				}
-				c.logger.Info("🔀 Post-rebase tests passed, continuing to PR creation")
-			} else {
-				c.logger.Warn("🔀 No build service available, skipping post-rebase tests")
+			if testFailureMsg := c.runPostRebaseTests(ctx); testFailureMsg != "" {
+				sm.SetStateData(KeyResumeInput, testFailureMsg)
+				return StateCoding, false, nil
 			}
 			// Rebase succeeded, continue to PR creation below
 		} else {
@@ -188,46 +172,43 @@ func (c *Coder) handlePrepareMerge(ctx context.Context, sm *agent.BaseStateMachi
 			// Check for RebaseConflictError - workspace left in mid-rebase state
 			var conflictErr *RebaseConflictError
 			if errors.As(rebaseErr, &conflictErr) {
-				c.logger.Info("🔀 Rebase conflict - building resolution guidance for coder")
-
-				conflictInfo := &MergeConflictInfo{
-					Kind:             FailureRebaseConflict,
-					ErrorOutput:      conflictErr.ErrorOutput,
-					ConflictingFiles: conflictErr.ConflictingFiles,
-					GitStatus:        conflictErr.GitStatus,
-					MidRebase:        true,
-					AttemptNumber:    attemptCount,
-					MaxAttempts:      MaxTotalAttempts,
+				nextState, resolved := c.resolveRebaseConflicts(ctx, sm, conflictErr, localBranch, remoteBranch, targetBranch)
+				if !resolved {
+					return nextState, false, nil
 				}
-
-				msg := c.buildConflictResolutionMessage(conflictInfo)
-				c.contextManager.AddMessage("system", msg)
-				sm.SetStateData(KeyResumeInput, msg)
-				return StateCoding, false, nil
-			}
-
-			// Not a conflict - fall back to recoverable/unrecoverable error handling
-			if c.isRecoverableGitError(pushErr) {
-				c.logger.Info("🔀 Git push failed (recoverable), returning to CODING: %v", pushErr)
-				pushFailureMsg := fmt.Sprintf("Git push failed. Fix the following issues and try again: %s\n\nAuto-rebase also failed: %s", pushErr.Error(), rebaseErr.Error())
-				if renderedMessage, renderErr := c.renderer.RenderSimple(templates.GitPushFailureTemplate, pushErr.Error()); renderErr != nil {
-					c.logger.Error("Failed to render git push failure message: %v", renderErr)
-					c.contextManager.AddMessage("system", pushFailureMsg)
-				} else {
-					c.contextManager.AddMessage("system", renderedMessage)
-					pushFailureMsg = renderedMessage
+				// Conflicts resolved and branch pushed, continue to PR creation below
+			} else {
+				// Not a conflict - fall back to recoverable/unrecoverable error handling
+				if c.isRecoverableGitError(pushErr) {
+					c.logger.Info("🔀 Git push failed (recoverable), returning to CODING: %v", pushErr)
+					pushFailureMsg := fmt.Sprintf("Git push failed. Fix the following issues and try again: %s\n\nAuto-rebase also failed: %s", pushErr.Error(), rebaseErr.Error())
+					if renderedMessage, renderErr := c.renderer.RenderSimple(templates.GitPushFailureTemplate, pushErr.Error()); renderErr != nil {
+						c.logger.Error("Failed to render git push failure message: %v", renderErr)
+						c.contextManager.AddMessage("system", pushFailureMsg)
+					} else {
+						c.contextManager.AddMessage("system", renderedMessage)
+						pushFailureMsg = renderedMessage
+					}
+					// Set resume input for Claude Code mode
+					sm.SetStateData(KeyResumeInput, pushFailureMsg)
+					return StateCoding, false, nil
 				}
-				// Set resume input for Claude Code mode
-				sm.SetStateData(KeyResumeInput, pushFailureMsg)
-				return StateCoding, false, nil
+				c.logger.Error("🔀 Git push failed (unrecoverable): %v", pushErr)
+				return proto.StateError, false, logx.Wrap(pushErr, "git push failed")
 			}
-			c.logger.Error("🔀 Git push failed (unrecoverable): %v", pushErr)
-			return proto.StateError, false, logx.Wrap(pushErr, "git push failed")
 		}
 	}
 
 	// Step 3: Get existing PR or create new one using GitHub CLI
-	prURL, err := c.getOrCreatePullRequest(ctx, storyID, remoteBranch, targetBranch)
+	prBody := fmt.Sprintf("Automated pull request for story %s implementation.\n\nGenerated by maestro coder agent.", storyID)
+	verificationJSON := ""
+	if veRaw, exists := sm.GetStateValue(KeyVerificationEvidence); exists && veRaw != nil {
+		if outcome, ok := rehydrateVerificationOutcome(veRaw); ok {
+			prBody += formatScreenshotEvidence(outcome.Evidence)
+			verificationJSON = verificationForTraceability(&outcome)
+		}
+	}
+	prURL, err := c.getOrCreatePullRequest(ctx, storyID, prBody, remoteBranch, targetBranch)
 	if err != nil {
 		// Special handling for "No commits between" error - indicates work detection mismatch
 		if strings.Contains(err.Error(), "No commits between") {
@@ -266,8 +247,31 @@ func (c *Coder) handlePrepareMerge(ctx context.Context, sm *agent.BaseStateMachi
 
 	c.logger.Info("🔀 PR created successfully: %s", prURL)
 
+	// Tell reviewers whose comments this push addressed
+	c.replyToReviewComments(ctx, sm, prURL)
+
 	// Step 4: Send merge request to architect
 	mergeEff := effect.NewMergeEffect(storyID, prURL, remoteBranch)
+	if mq := config.GetMergeQueueConfig(); mq.Enabled {
+		// The merge queue builds and tests the PR before answering
+		mergeEff.Timeout = mq.MergeWaitTimeout()
+	}
+	// The architect also holds the reply while the PR's CI checks run
+	mergeEff.Timeout += config.GetCheckWaitTimeout()
+	if utils.GetStateValueOr[string](sm, KeyStackedOn, "") != "" {
+		// and while the story this one is stacked on has not merged
+		mergeEff.Timeout += config.GetStackWaitTimeout()
+	}
+	mergeEff.Metadata = make(map[string]string)
+	if verificationJSON != "" {
+		mergeEff.Metadata[proto.KeyVerification] = verificationJSON
+	}
+	// The architect compares the files changed with the story's predicted footprint
+	if changedFiles, filesErr := c.branchChangedFiles(ctx, targetBranch); filesErr != nil {
+		c.logger.Debug("🔀 Could not list changed files for the merge request: %v", filesErr)
+	} else if len(changedFiles) > 0 {
+		mergeEff.Metadata[proto.KeyChangedFiles] = strings.Join(changedFiles, "\n")
+	}
 
 	// Execute merge effect - blocks until architect responds or times out
 	result, err := c.ExecuteEffect(ctx, mergeEff)
@@ -283,8 +287,15 @@ func (c *Coder) handlePrepareMerge(ctx context.Context, sm *agent.BaseStateMachi
 	return StateAwaitMerge, false, nil
 }
 
-// getTargetBranch retrieves the target branch from global config.
+// getTargetBranch returns the branch the story's PR targets: its epic branch
+// when the architect assigned one, otherwise the target branch from global config.
 func (c *Coder) getTargetBranch() (string, error) {
+	if c.BaseStateMachine != nil {
+		if baseBranch := utils.GetStateValueOr[string](c.BaseStateMachine, KeyBaseBranch, ""); baseBranch != "" {
+			return baseBranch, nil
+		}
+	}
+
 	cfg, err := config.GetConfig()
 	if err != nil {
 		// Return default when config is not available
@@ -364,11 +375,11 @@ func (c *Coder) pushBranch(ctx context.Context, localBranch, remoteBranch string
 
 // getOrCreatePullRequest checks if a PR exists for the branch, otherwise creates one.
 // This function is mode-aware: in airplane mode it uses Gitea, otherwise GitHub.
-func (c *Coder) getOrCreatePullRequest(ctx context.Context, storyID, headBranch, baseBranch string) (string, error) {
+func (c *Coder) getOrCreatePullRequest(ctx context.Context, storyID, body, headBranch, baseBranch string) (string, error) {
 	c.logger.Debug("🔀 Checking for existing PR: %s -> %s", headBranch, baseBranch)
 
-	// Create forge client (mode-aware: GitHub or Gitea)
-	forgeClient, err := forge.NewClient(c.workDir)
+	// Create forge client for the story's repository (mode-aware: GitHub or Gitea)
+	forgeClient, err := forge.NewRepoClient(c.workDir, utils.GetStateValueOr[string](c.BaseStateMachine, KeyRepo, ""))
 	if err != nil {
 		return "", fmt.Errorf("failed to create forge client: %w", err)
 	}
@@ -376,8 +387,7 @@ func (c *Coder) getOrCreatePullRequest(ctx context.Context, storyID, headBranch,
 	c.logger.Debug("🔀 Using forge provider: %s", forgeClient.Provider())
 
 	// Create PR with meaningful title and body
-	title := fmt.Sprintf("Story %s: Implementation", storyID)
-	body := fmt.Sprintf("Automated pull request for story %s implementation.\n\nGenerated by maestro coder agent.", storyID)
+	title := c.pullRequestTitle(ctx, storyID, baseBranch)
 
 	pr, err := forgeClient.GetOrCreatePR(ctx, forge.PRCreateOptions{
 		Title: title,
@@ -397,6 +407,28 @@ func (c *Coder) getOrCreatePullRequest(ctx context.Context, storyID, headBranch,
 	return pr.URL, nil
 }
 
+// pullRequestTitle returns the PR title, which becomes the subject of the squash
+// commit. With a commit message template (git.commit_template) it is the subject
+// of the story's first commit, so the target branch follows the same convention.
+func (c *Coder) pullRequestTitle(ctx context.Context, storyID, baseBranch string) string {
+	title := fmt.Sprintf("Story %s: Implementation", storyID)
+	if config.GetCommitTemplate() == config.DefaultCommitTemplate {
+		return title
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "log", "--reverse", "--format=%s", "origin/"+baseBranch+"..HEAD")
+	cmd.Dir = c.workDir
+	output, err := cmd.Output()
+	if err != nil {
+		c.logger.Warn("🔀 Failed to read commit subjects for the PR title: %v", err)
+		return title
+	}
+	if subject, _, _ := strings.Cut(strings.TrimSpace(string(output)), "\n"); subject != "" {
+		return subject
+	}
+	return title
+}
+
 // isRecoverableGitError determines if a git error is recoverable (should return to CODING) or unrecoverable (ERROR).
 func (c *Coder) isRecoverableGitError(err error) bool {
 	if err == nil {
@@ -465,9 +497,31 @@ func (e *RebaseConflictError) Error() string {
 	return fmt.Sprintf("rebase has conflicts that require manual resolution: %s", e.ErrorOutput)
 }
 
+// runPostRebaseTests runs the tests on rebased code. Returns the message to
+// send the coder back with when they fail, or "" when they pass or cannot run.
+func (c *Coder) runPostRebaseTests(ctx context.Context) string {
+	if c.buildService == nil {
+		c.logger.Warn("🔀 No build service available, skipping post-rebase tests")
+		return ""
+	}
+	testResult, testErr := c.runTestWithBuildService(ctx, c.workDir)
+	if testErr != nil {
+		c.logger.Warn("🔀 Post-rebase tests failed with error: %v", testErr)
+		return fmt.Sprintf("Tests failed after rebase:\n\n%s\n\nPlease fix the issues and try again.", testErr.Error())
+	}
+	if !testResult.passed && !testResult.skipped {
+		c.logger.Warn("🔀 Post-rebase tests failed")
+		return fmt.Sprintf("Tests failed after rebase:\n\n%s\n\nPlease fix the issues and try again.", truncateOutput(testResult.output))
+	}
+	c.logger.Info("🔀 Post-rebase tests passed, continuing to PR creation")
+	return ""
+}
+
 // attemptRebaseAndRetryPush attempts to rebase the current branch onto the target branch
 // and retry the push with --force-with-lease. This handles the common case in parallel
 // story development where another story was merged to main while this story was being developed.
+// When upstream is set, only the commits after it are replayed (git rebase --onto), which moves a
+// stacked story off the branch of a story that was squash-merged in the meantime.
 //
 // Returns nil on success (rebase + push succeeded).
 // Returns *RebaseConflictError if rebase has conflicts (workspace left in mid-rebase state).
@@ -477,7 +531,7 @@ func (e *RebaseConflictError) Error() string {
 // in mid-rebase state so the coder can resolve conflicts using git commands, then use
 // 'git rebase --continue' to finish. The caller should return to CODING state with
 // detailed resolution instructions.
-func (c *Coder) attemptRebaseAndRetryPush(ctx context.Context, localBranch, remoteBranch, targetBranch string) error {
+func (c *Coder) attemptRebaseAndRetryPush(ctx context.Context, localBranch, remoteBranch, targetBranch, upstream string) error {
 	opts := &execpkg.Opts{
 		WorkDir: c.workDir,
 		Timeout: 2 * time.Minute,
@@ -501,7 +555,11 @@ func (c *Coder) attemptRebaseAndRetryPush(ctx context.Context, localBranch, remo
 
 	// Step 3: Attempt rebase onto origin/targetBranch
 	c.logger.Debug("🔀 Rebasing onto origin/%s", targetBranch)
-	result, err := c.longRunningExecutor.Run(ctx, []string{"git", "rebase", fmt.Sprintf("origin/%s", targetBranch)}, opts)
+	rebaseArgs := []string{"git", "rebase", fmt.Sprintf("origin/%s", targetBranch)}
+	if upstream != "" {
+		rebaseArgs = []string{"git", "rebase", "--onto", fmt.Sprintf("origin/%s", targetBranch), upstream}
+	}
+	result, err := c.longRunningExecutor.Run(ctx, rebaseArgs, opts)
 	if err != nil {
 		// Check if this is a conflict
 		if strings.Contains(strings.ToLower(result.Stderr), "conflict") ||
@@ -526,7 +584,12 @@ func (c *Coder) attemptRebaseAndRetryPush(ctx context.Context, localBranch, remo
 	}
 
 	c.logger.Info("🔀 Rebase successful, pushing with --force-with-lease")
+	return c.pushRebasedBranch(ctx, localBranch, remoteBranch, targetBranch)
+}
 
+// pushRebasedBranch pushes a rebased branch with --force-with-lease, after refreshing
+// the tracking refs the lease is checked against.
+func (c *Coder) pushRebasedBranch(ctx context.Context, localBranch, remoteBranch, targetBranch string) error {
 	// Step 4: Fetch from forge remote to get fresh tracking refs for --force-with-lease safety.
 	// Without this, --force-with-lease fails with "stale info" because the local tracking
 	// ref doesn't match what's actually on the remote.
@@ -654,7 +717,7 @@ func (c *Coder) refreshMirrorOnHost(ctx context.Context) error {
 		return fmt.Errorf("project directory not configured")
 	}
 
-	mirrorMgr := mirror.NewManager(projectDir)
+	mirrorMgr := mirror.NewRepoManager(projectDir, utils.GetStateValueOr[string](c.BaseStateMachine, KeyRepo, ""))
 	if err := mirrorMgr.RefreshFromForge(ctx); err != nil {
 		c.logger.Warn("Mirror refresh failed, attempting recovery: %v", err)
 		// Recover via EnsureMirror rather than deleting the mirror here. Its
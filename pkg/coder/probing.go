@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"orchestrator/pkg/agent"
+	"orchestrator/pkg/agent/llm"
 	"orchestrator/pkg/agent/toolloop"
 	"orchestrator/pkg/config"
 	"orchestrator/pkg/contextmgr"
@@ -94,13 +95,15 @@ func shouldRunAdversarialProbing(sm *agent.BaseStateMachine) bool {
 // implementation for edge-case robustness issues.
 //
 // The loop is read-only (shell only, network disabled) and produces structured findings.
+// Stories with a compose stack also get http_request, so endpoints can be probed with
+// real requests; the exchanges are attached to the evidence.
 // Returns ProbingOutcome with explicit pass/fail/unavailable status.
 //
 //nolint:dupl // Outcome processing mirrors verification.go but with different types and signals
 func (c *Coder) runAdversarialProbing(
 	ctx context.Context,
 	sm *agent.BaseStateMachine,
-	_ string, // workspacePath — unused, shell tool uses executor's workdir
+	workspacePath string,
 	changedFiles []string,
 ) ProbingOutcome {
 	c.logger.Info("🔬 Starting adversarial robustness probing")
@@ -141,6 +144,15 @@ func (c *Coder) runAdversarialProbing(
 		},
 	}
 
+	maxIterations := maxProbingIterations
+	httpTool := c.newHTTPRequestTool(workspacePath)
+	if httpTool != nil {
+		maxIterations = maxHTTPProbingIterations
+		templateData.Extra["HTTPHosts"] = composeServiceHosts(workspacePath)
+		templateData.Extra["HTTP"] = true
+	}
+	templateData.Extra["MaxTurns"] = maxIterations
+
 	// Render probing template with user instructions from .maestro/*instructions*.md
 	if c.renderer == nil {
 		c.logger.Warn("🔬 Template renderer not available, skipping probing")
@@ -182,6 +194,9 @@ func (c *Coder) runAdversarialProbing(
 		}
 	}
 	generalTools := []tools.Tool{shellTool}
+	if httpTool != nil {
+		generalTools = append(generalTools, httpTool)
+	}
 
 	// Create terminal tool directly (not from provider — follows coding.go pattern)
 	terminalTool := tools.NewSubmitProbingTool()
@@ -192,7 +207,7 @@ func (c *Coder) runAdversarialProbing(
 		ContextManager:     probingCM,
 		GeneralTools:       generalTools,
 		TerminalTool:       terminalTool,
-		MaxIterations:      maxProbingIterations,
+		MaxIterations:      maxIterations,
 		MaxTokens:          4096,
 		Temperature:        probingTemperature,
 		AgentID:            c.GetAgentID(),
@@ -200,6 +215,7 @@ func (c *Coder) runAdversarialProbing(
 		ActivityTracker:    c.activityTracker,
 		PersistenceChannel: c.persistenceChannel,
 		StoryID:            storyID,
+		Purpose:            llm.PurposeProbing,
 		ToolCircuitBreaker: &toolloop.ToolCircuitBreakerConfig{
 			MaxConsecutiveFailures: 3,
 			OnTrip: func(_ string, label string, count int) {
@@ -207,7 +223,7 @@ func (c *Coder) runAdversarialProbing(
 			},
 		},
 		BeforeIteration: func(iteration int, cm *contextmgr.ContextManager) {
-			if iteration == maxProbingIterations-1 {
+			if iteration == maxIterations-1 {
 				c.logger.Info("🔍 Injecting submit reminder at probing iteration %d", iteration)
 				cm.AddMessage("user", "You have 1 tool call remaining. Call submit_probing now with your findings. If any area was not fully investigated, mark it as inconclusive and submit.")
 			}
@@ -221,6 +237,9 @@ func (c *Coder) runAdversarialProbing(
 	switch out.Kind {
 	case toolloop.OutcomeProcessEffect:
 		evidence, _ := out.EffectData.(map[string]any)
+		if httpTool != nil {
+			attachHTTPExchanges(evidence, httpTool.Exchanges())
+		}
 		switch out.Signal {
 		case tools.SignalProbingPass:
 			c.logger.Info("🔬 Probing passed (iteration %d)", out.Iteration)
@@ -374,6 +393,8 @@ func formatProbingEvidence(outcome ProbingOutcome) string {
 		b.WriteString(fmt.Sprintf("Probing summary: %s\n", summary))
 	}
 
+	b.WriteString(formatHTTPExchangeSummary(outcome.Evidence))
+
 	return b.String()
 }
 
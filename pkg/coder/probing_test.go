@@ -994,3 +994,40 @@ func TestVerificationUnavailable_ProbingSkipped(t *testing.T) {
 		t.Error("Expected reason in formatted output")
 	}
 }
+
+func TestAttachHTTPExchanges_FormatsForReview(t *testing.T) {
+	evidence := map[string]any{"summary": "probed the items endpoint"}
+	attachHTTPExchanges(evidence, []tools.HTTPExchange{
+		{Method: "POST", URL: "http://api:8080/v1/items", Status: 201, Assertions: []tools.HTTPAssertion{
+			{Check: "status", Expected: "201", Actual: "201", Passed: true},
+		}},
+		{Method: "POST", URL: "http://api:8080/v1/items", Status: 500, Assertions: []tools.HTTPAssertion{
+			{Check: "status", Expected: "400", Actual: "500", Passed: false},
+		}},
+	})
+
+	// Evidence holds the JSON round-trip shape, so resumed runs format the same way.
+	exchanges, ok := evidence["http_exchanges"].([]any)
+	if !ok || len(exchanges) != 2 {
+		t.Fatalf("expected 2 exchanges as []any, got %T", evidence["http_exchanges"])
+	}
+
+	result := formatProbingEvidence(ProbingOutcome{Status: ProbingFail, Evidence: evidence})
+	if !strings.Contains(result, "HTTP requests sent: 2, 1 missed expectations") {
+		t.Errorf("expected exchange summary, got:\n%s", result)
+	}
+	if !strings.Contains(result, "#2 POST http://api:8080/v1/items: status expected 400, got 500") {
+		t.Errorf("expected the failed exchange, got:\n%s", result)
+	}
+}
+
+func TestAttachHTTPExchanges_NoneSent(t *testing.T) {
+	evidence := map[string]any{}
+	attachHTTPExchanges(evidence, nil)
+	if _, ok := evidence["http_exchanges"]; ok {
+		t.Error("expected no http_exchanges key when nothing was sent")
+	}
+	if summary := formatHTTPExchangeSummary(evidence); summary != "" {
+		t.Errorf("expected empty summary, got %q", summary)
+	}
+}
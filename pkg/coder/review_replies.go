@@ -0,0 +1,91 @@
+package coder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+	"orchestrator/pkg/utils"
+)
+
+// rememberReviewComments keeps the human review comments from a merge
+// response, so they are answered on the PR once the coder resubmits.
+func (c *Coder) rememberReviewComments(sm *agent.BaseStateMachine, rawFeedback string) {
+	if rawFeedback == "" {
+		return
+	}
+	feedback, err := proto.DecodeMergeFailureFeedback(rawFeedback)
+	if err != nil {
+		c.logger.Warn("Ignoring malformed merge failure feedback: %v", err)
+		return
+	}
+	if feedback.Stage != proto.MergeStageReview || len(feedback.Comments) == 0 {
+		return
+	}
+
+	comments := append(c.pendingReviewComments(sm), feedback.Comments...)
+	data, err := json.Marshal(comments)
+	if err != nil {
+		c.logger.Warn("Failed to store review comments: %v", err)
+		return
+	}
+	sm.SetStateData(KeyReviewReplies, string(data))
+}
+
+// pendingReviewComments returns the review comments waiting for a reply.
+func (c *Coder) pendingReviewComments(sm *agent.BaseStateMachine) []proto.ReviewComment {
+	raw := utils.GetStateValueOr[string](sm, KeyReviewReplies, "")
+	if raw == "" {
+		return nil
+	}
+	var comments []proto.ReviewComment
+	if err := json.Unmarshal([]byte(raw), &comments); err != nil {
+		c.logger.Warn("Discarding malformed review comments: %v", err)
+		return nil
+	}
+	return comments
+}
+
+// replyToReviewComments tells reviewers their comments were addressed by the
+// push that was just made to the PR. Failures are logged; they never block the merge.
+func (c *Coder) replyToReviewComments(ctx context.Context, sm *agent.BaseStateMachine, prURL string) {
+	comments := c.pendingReviewComments(sm)
+	if len(comments) == 0 {
+		return
+	}
+	sm.SetStateData(KeyReviewReplies, "")
+
+	// The PR lives on the story's repository, which may not be the primary one.
+	forgeClient, err := forge.NewRepoClient(c.workDir, utils.GetStateValueOr[string](sm, KeyRepo, ""))
+	if err != nil {
+		c.logger.Warn("💬 Cannot reply to review comments: %v", err)
+		return
+	}
+
+	reply := "Addressed in the latest push to this PR."
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = c.workDir
+	if out, err := cmd.Output(); err == nil {
+		reply = fmt.Sprintf("Addressed in %s.", strings.TrimSpace(string(out)))
+	}
+
+	for i := range comments {
+		comment := forge.PRComment{
+			ID:     comments[i].ID,
+			Kind:   forge.PRCommentKind(comments[i].Kind),
+			Author: comments[i].Author,
+			Body:   comments[i].Body,
+			Path:   comments[i].Path,
+			Line:   comments[i].Line,
+		}
+		if err := forgeClient.ReplyToPRComment(ctx, prURL, comment, reply); err != nil {
+			c.logger.Warn("💬 Failed to reply to review comment %s: %v", comment.Key(), err)
+		}
+	}
+	c.logger.Info("💬 Replied to %d review comment(s) on %s", len(comments), prURL)
+}
@@ -0,0 +1,66 @@
+package coder
+
+import (
+	"context"
+	"testing"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	forgegithub "orchestrator/pkg/forge/github"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/proto"
+)
+
+// replyRecordingClient records review replies.
+// Embedding forge.Client leaves the unused methods nil.
+type replyRecordingClient struct {
+	forge.Client
+	repoURL string
+	replies []string
+}
+
+func (f *replyRecordingClient) ReplyToPRComment(_ context.Context, _ string, comment forge.PRComment, _ string) error {
+	f.replies = append(f.replies, comment.Key())
+	return nil
+}
+
+func TestReplyToReviewComments_SecondaryRepo(t *testing.T) {
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{
+		RepoURL: "https://github.com/acme/backend.git",
+		Repos:   []config.RepoConfig{{Name: "frontend", URL: "https://github.com/acme/frontend.git"}},
+	}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+	var client *replyRecordingClient
+	forge.RegisterGitHubRepoClientFactory(func(repoURL string) (forge.Client, error) {
+		client = &replyRecordingClient{repoURL: repoURL}
+		return client, nil
+	})
+	t.Cleanup(func() { forge.RegisterGitHubRepoClientFactory(forgegithub.NewClientForRepo) })
+
+	sm := agent.NewBaseStateMachine("coder-001", proto.StateWaiting, nil, nil)
+	sm.SetStateData(KeyRepo, "frontend")
+	c := &Coder{
+		BaseStateMachine: sm,
+		logger:           logx.NewLogger("test-replies"),
+		workDir:          t.TempDir(),
+	}
+
+	feedback := &proto.MergeFailureFeedback{
+		Stage:    proto.MergeStageReview,
+		Comments: []proto.ReviewComment{{ID: 7, Kind: "review", Author: "alice", Body: "Rename this"}},
+	}
+	c.rememberReviewComments(sm, feedback.Encode())
+	c.replyToReviewComments(context.Background(), sm, "https://github.com/acme/frontend/pull/3")
+
+	if client == nil || client.repoURL != "https://github.com/acme/frontend.git" {
+		t.Fatalf("Expected a client for the frontend repository, got %+v", client)
+	}
+	if len(client.replies) != 1 {
+		t.Errorf("Expected one reply, got %v", client.replies)
+	}
+	if len(c.pendingReviewComments(sm)) != 0 {
+		t.Error("Expected the replied comments to be cleared")
+	}
+}
@@ -65,7 +65,12 @@ func (c *Coder) handleSetup(ctx context.Context, sm *agent.BaseStateMachine) (pr
 	agentID := c.BaseStateMachine.GetAgentID()
 	// Make agent ID filesystem-safe using shared sanitization helper
 	fsafeAgentID := utils.SanitizeIdentifier(agentID)
-	cloneResult, err := c.cloneManager.SetupWorkspace(ctx, fsafeAgentID, storyIDStr, c.workDir)
+	baseBranch := utils.GetStateValueOr[string](sm, KeyBaseBranch, "")
+	cloneManager, err := c.storyCloneManager(sm)
+	if err != nil {
+		return proto.StateError, false, logx.Wrap(err, "workspace setup failed")
+	}
+	cloneResult, err := cloneManager.SetupWorkspace(ctx, fsafeAgentID, storyIDStr, c.workDir, baseBranch)
 	if err != nil {
 		// Check if this is a git network error → SUSPEND instead of ERROR
 		var gitNetErr *GitNetworkError
@@ -95,6 +100,11 @@ func (c *Coder) handleSetup(ctx context.Context, sm *agent.BaseStateMachine) (pr
 	// Git user identity is now configured during CloneManager.SetupWorkspace() on the host
 	// This avoids read-only filesystem issues with container mounts
 
+	// Check out the product's other repositories read-only for context
+	if err := c.setupSiblingRepos(ctx, sm); err != nil {
+		return proto.StateError, false, logx.Wrap(err, "sibling repository setup failed")
+	}
+
 	// Configure container with read-only workspace for planning phase
 	if c.longRunningExecutor != nil {
 		if err := c.configureWorkspaceMount(ctx, true, "planning"); err != nil {
@@ -205,6 +215,17 @@ func (c *Coder) configureWorkspaceMount(ctx context.Context, readonly bool, purp
 		}
 	}
 
+	// Multi-repo products mount the other repositories read-only
+	extraMounts = c.appendSiblingReposMount(extraMounts)
+
+	// Coding containers get the commit signing key when commits are signed
+	if !readonly {
+		var err error
+		if extraMounts, err = c.appendSigningKeyMount(extraMounts); err != nil {
+			return logx.Wrap(err, "commit signing setup failed")
+		}
+	}
+
 	// Create execution options for new container
 	execOpts := execpkg.Opts{
 		WorkDir:         c.workDir,
@@ -298,6 +319,9 @@ func (c *Coder) configureWorkspaceMount(ctx context.Context, readonly bool, purp
 		if err := c.setupGitHubAuthentication(ctx); err != nil {
 			return logx.Wrap(err, "GitHub authentication setup failed - cannot proceed with coding")
 		}
+		if err := c.configureCommitSigning(ctx); err != nil {
+			return logx.Wrap(err, "commit signing setup failed")
+		}
 	}
 
 	// Update shell tool to use new container
@@ -317,10 +341,10 @@ func (c *Coder) remapOriginToContainerMirror(ctx context.Context) error {
 		return nil // No container to remap in
 	}
 
-	// Extract repo name from config to build container mirror path
-	repoURL := config.GetGitRepoURL()
-	if repoURL == "" {
-		return fmt.Errorf("no git repo URL configured")
+	// Extract repo name from the story's repository to build container mirror path
+	repoURL, err := config.GetRepoURL(utils.GetStateValueOr[string](c.BaseStateMachine, KeyRepo, ""))
+	if err != nil {
+		return err
 	}
 	repoName := filepath.Base(repoURL)
 	repoName = strings.TrimSuffix(repoName, ".git")
@@ -365,6 +389,9 @@ func (c *Coder) cleanupContainer(ctx context.Context, reason string) {
 		// Clear container name
 		c.containerName = ""
 	}
+
+	// The decrypted signing key must not outlive the container it was mounted into
+	c.removeSigningKey()
 }
 
 // updateShellToolForStory is no longer needed in the new ToolProvider system.
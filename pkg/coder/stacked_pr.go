@@ -0,0 +1,95 @@
+package coder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/agent"
+	"orchestrator/pkg/config"
+	execpkg "orchestrator/pkg/exec"
+	"orchestrator/pkg/proto"
+	"orchestrator/pkg/tools"
+	"orchestrator/pkg/utils"
+)
+
+// retargetedBase returns the branch a stacked PR was retargeted to, when the
+// merge feedback says the story it was stacked on merged.
+func retargetedBase(rawFeedback string) string {
+	if rawFeedback == "" {
+		return ""
+	}
+	feedback, err := proto.DecodeMergeFailureFeedback(rawFeedback)
+	if err != nil || feedback.Stage != proto.MergeStageRetarget {
+		return ""
+	}
+	return feedback.Base
+}
+
+// rebaseOntoRetargetedBase moves a stacked story onto the branch its PR now
+// targets after the story it was stacked on merged. Only the story's own
+// commits are replayed, since the merged story's commits reached the new base
+// as a squash. The rebased branch is pushed and tested, then merged again.
+func (c *Coder) rebaseOntoRetargetedBase(ctx context.Context, sm *agent.BaseStateMachine, base string) (proto.State, bool, error) {
+	localBranch := utils.GetStateValueOr[string](sm, KeyLocalBranchName, "")
+	remoteBranch := utils.GetStateValueOr[string](sm, KeyRemoteBranchName, "")
+	oldBase, _ := c.getTargetBranch()
+
+	opts := &execpkg.Opts{
+		WorkDir: c.workDir,
+		Timeout: 30 * time.Second,
+	}
+
+	// The story's own commits start where it branched from the old base
+	upstream := ""
+	result, err := c.longRunningExecutor.Run(ctx, []string{"git", "merge-base", "HEAD", "origin/" + oldBase}, opts)
+	if err == nil && result.ExitCode == 0 {
+		upstream = strings.TrimSpace(result.Stdout)
+	} else {
+		c.logger.Warn("🥞 Cannot find where the story branched from %s, rebasing all of its commits: %v", oldBase, err)
+	}
+
+	c.setBaseBranch(ctx, sm, base)
+	c.logger.Info("🥞 %s merged, rebasing the stacked story onto %s", oldBase, base)
+
+	rebaseErr := c.attemptRebaseAndRetryPush(ctx, localBranch, remoteBranch, base, upstream)
+	if rebaseErr == nil {
+		if testFailureMsg := c.runPostRebaseTests(ctx); testFailureMsg != "" {
+			sm.SetStateData(KeyResumeInput, testFailureMsg)
+			return StateCoding, false, nil
+		}
+		return StatePrepareMerge, false, nil
+	}
+
+	var conflictErr *RebaseConflictError
+	if errors.As(rebaseErr, &conflictErr) {
+		nextState, _ := c.resolveRebaseConflicts(ctx, sm, conflictErr, localBranch, remoteBranch, base)
+		return nextState, false, nil
+	}
+	msg := fmt.Sprintf("The story your PR was stacked on has merged and your PR now targets %s, but rebasing onto it failed: %v\n\n"+
+		"Rebase your branch onto origin/%s, then use the done tool to resubmit.", base, rebaseErr, base)
+	c.contextManager.AddMessage("system", msg)
+	sm.SetStateData(KeyResumeInput, msg)
+	return StateCoding, false, nil
+}
+
+// setBaseBranch records the branch the story now merges into, for its PR,
+// later rebases and the get_diff baseline.
+func (c *Coder) setBaseBranch(ctx context.Context, sm *agent.BaseStateMachine, base string) {
+	opts := &execpkg.Opts{
+		WorkDir: c.workDir,
+		Timeout: 30 * time.Second,
+	}
+	args := []string{"git", "config", tools.DiffBaseConfigKey, base}
+	if base == config.GetGitBaseBranch() {
+		base = ""
+		args = []string{"git", "config", "--unset", tools.DiffBaseConfigKey}
+	}
+	sm.SetStateData(KeyBaseBranch, base)
+	sm.SetStateData(KeyStackedOn, "")
+	if result, err := c.longRunningExecutor.Run(ctx, args, opts); err != nil || result.ExitCode != 0 {
+		c.logger.Debug("🥞 Failed to update %s: %v (stderr: %s)", tools.DiffBaseConfigKey, err, result.Stderr)
+	}
+}
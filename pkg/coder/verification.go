@@ -2,15 +2,18 @@ package coder
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
 	"orchestrator/pkg/agent"
+	"orchestrator/pkg/agent/llm"
 	"orchestrator/pkg/agent/toolloop"
 	"orchestrator/pkg/config"
 	"orchestrator/pkg/contextmgr"
 	"orchestrator/pkg/templates"
 	"orchestrator/pkg/tools"
+	"orchestrator/pkg/traceability"
 	"orchestrator/pkg/utils"
 )
 
@@ -54,13 +57,16 @@ const (
 // that the implementation satisfies the story's acceptance criteria.
 //
 // The loop is read-only (shell only, network disabled) and produces structured evidence.
+// When browser verification is enabled the verifier also gets the browser tool, which
+// reaches the running app through a sidecar; its screenshots are attached to the evidence.
+// Stories with a compose stack also get http_request, whose exchanges are attached likewise.
 // Returns VerificationOutcome with explicit pass/fail/unavailable status.
 //
 //nolint:dupl // Outcome processing mirrors probing.go but with different types and signals
 func (c *Coder) runAcceptanceCriteriaVerification(
 	ctx context.Context,
 	sm *agent.BaseStateMachine,
-	_ string, // workspacePath — unused, shell tool uses executor's workdir
+	workspacePath string,
 	changedFiles []string,
 ) VerificationOutcome {
 	c.logger.Info("🔍 Starting acceptance-criteria verification")
@@ -92,6 +98,21 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 		},
 	}
 
+	maxIterations := maxVerificationIterations
+	verifierBrowser := c.newVerificationBrowser(storyID)
+	if verifierBrowser != nil {
+		defer verifierBrowser.close(c)
+		maxIterations = maxBrowserVerificationIterations
+		templateData.Extra["Browser"] = true
+	}
+	httpTool := c.newHTTPRequestTool(workspacePath)
+	if httpTool != nil {
+		maxIterations = max(maxIterations, maxHTTPVerificationIterations)
+		templateData.Extra["HTTPHosts"] = composeServiceHosts(workspacePath)
+		templateData.Extra["HTTP"] = true
+	}
+	templateData.Extra["MaxTurns"] = maxIterations
+
 	// Render verification template with user instructions from .maestro/*instructions*.md
 	if c.renderer == nil {
 		c.logger.Warn("🔍 Template renderer not available, skipping verification")
@@ -128,6 +149,12 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 		}
 	}
 	generalTools := []tools.Tool{shellTool}
+	if verifierBrowser != nil {
+		generalTools = append(generalTools, verifierBrowser.tool)
+	}
+	if httpTool != nil {
+		generalTools = append(generalTools, httpTool)
+	}
 
 	// Create terminal tool directly (not from provider — follows coding.go pattern)
 	terminalTool := tools.NewSubmitVerificationTool()
@@ -138,7 +165,7 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 		ContextManager:     verificationCM,
 		GeneralTools:       generalTools,
 		TerminalTool:       terminalTool,
-		MaxIterations:      maxVerificationIterations,
+		MaxIterations:      maxIterations,
 		MaxTokens:          4096,
 		Temperature:        verificationTemperature,
 		AgentID:            c.GetAgentID(),
@@ -146,6 +173,7 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 		ActivityTracker:    c.activityTracker,
 		PersistenceChannel: c.persistenceChannel,
 		StoryID:            storyID,
+		Purpose:            llm.PurposeVerification,
 		ToolCircuitBreaker: &toolloop.ToolCircuitBreakerConfig{
 			MaxConsecutiveFailures: 3,
 			OnTrip: func(_ string, label string, count int) {
@@ -153,7 +181,7 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 			},
 		},
 		BeforeIteration: func(iteration int, cm *contextmgr.ContextManager) {
-			if iteration == maxVerificationIterations-1 {
+			if iteration == maxIterations-1 {
 				c.logger.Info("🔍 Injecting submit reminder at iteration %d", iteration)
 				cm.AddMessage("user", "You have 1 tool call remaining. Do not use shell again unless absolutely necessary. Call submit_verification now. If any criterion is not fully proven, mark it partial or unverified and submit.")
 			}
@@ -167,6 +195,12 @@ func (c *Coder) runAcceptanceCriteriaVerification(
 	switch out.Kind {
 	case toolloop.OutcomeProcessEffect:
 		evidence, _ := out.EffectData.(map[string]any)
+		if verifierBrowser != nil {
+			attachScreenshots(evidence, verifierBrowser.tool.Screenshots())
+		}
+		if httpTool != nil {
+			attachHTTPExchanges(evidence, httpTool.Exchanges())
+		}
 		switch out.Signal {
 		case tools.SignalVerificationPass:
 			c.logger.Info("🔍 Verification passed (iteration %d)", out.Iteration)
@@ -343,6 +377,9 @@ func formatVerificationEvidence(outcome VerificationOutcome) string {
 				icon = "❓"
 			}
 			b.WriteString(fmt.Sprintf("  %s %s\n", icon, criterion))
+			if refs, ok := cm["screenshots"].([]any); ok && len(refs) > 0 {
+				b.WriteString(fmt.Sprintf("     screenshots: %s\n", joinScreenshotRefs(refs)))
+			}
 		}
 	}
 
@@ -351,6 +388,8 @@ func formatVerificationEvidence(outcome VerificationOutcome) string {
 		b.WriteString(fmt.Sprintf("Verification confidence: %s\n", confidence))
 	}
 
+	b.WriteString(formatHTTPExchangeSummary(outcome.Evidence))
+
 	// Add gaps if any
 	if gaps, ok := outcome.Evidence["gaps"].([]any); ok && len(gaps) > 0 {
 		b.WriteString("Gaps:\n")
@@ -394,3 +433,33 @@ func rehydrateVerificationOutcome(raw any) (VerificationOutcome, bool) {
 
 	return outcome, outcome.Status != ""
 }
+
+// verificationForTraceability condenses a verification outcome into the JSON the
+// architect records on the story at merge, for the spec's traceability matrix.
+// Evidence text is left out — the PR and the coder's logs keep it.
+func verificationForTraceability(outcome *VerificationOutcome) string {
+	v := traceability.Verification{Status: string(outcome.Status)}
+	if criteria, ok := outcome.Evidence["acceptance_criteria_checked"].([]any); ok {
+		for _, item := range criteria {
+			cm, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			criterion, _ := cm["criterion"].(string)
+			requirementID, _ := cm["requirement_id"].(string)
+			result, _ := cm["result"].(string)
+			method, _ := cm["method"].(string)
+			v.Criteria = append(v.Criteria, traceability.CriterionResult{
+				Criterion:     criterion,
+				RequirementID: requirementID,
+				Result:        result,
+				Method:        method,
+			})
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,164 @@
+package coder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/browser"
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/tools"
+)
+
+const (
+	// maxBrowserVerificationIterations replaces maxVerificationIterations when
+	// the verifier has a browser: exercising a UI takes a navigate, a fill
+	// or two, a click and a screenshot per criterion, which five turns cannot
+	// cover.
+	maxBrowserVerificationIterations = 12
+
+	browserStopTimeout = 30 * time.Second
+)
+
+// verificationBrowser is the browser half of one verification run: the
+// sidecar launcher and the tool the verifier drives it with.
+type verificationBrowser struct {
+	launcher *browser.Launcher
+	tool     *tools.BrowserTool
+}
+
+// newVerificationBrowser returns the run's browser, or nil when browser
+// verification is not enabled. Nothing starts until the verifier first
+// uses the tool.
+func (c *Coder) newVerificationBrowser(storyID string) *verificationBrowser {
+	settings := config.GetBrowserConfig()
+	if !settings.Enabled {
+		return nil
+	}
+
+	appHost := c.GetContainerName()
+	launcher := browser.NewLauncher(browser.NewSidecar(c.GetAgentID(), settings.Image, appHost))
+	page := func(ctx context.Context) (tools.BrowserPage, error) {
+		session, err := launcher.Page(ctx)
+		if err != nil {
+			return nil, err //nolint:nilnil // avoid a typed-nil BrowserPage
+		}
+		return session, nil
+	}
+	return &verificationBrowser{
+		launcher: launcher,
+		tool:     tools.NewBrowserTool(page, verificationEvidenceDir(storyID), appHost),
+	}
+}
+
+// verificationEvidenceDir is where a verification run's screenshots go:
+// under the project's .maestro directory on the host, one directory per
+// run so a re-verification after fixes does not overwrite the first.
+func verificationEvidenceDir(storyID string) string {
+	base := config.GetProjectDir()
+	if base == "" {
+		base = os.TempDir()
+	}
+	if storyID == "" {
+		storyID = "unknown-story"
+	}
+	return filepath.Join(base, config.ProjectConfigDir, "evidence", storyID, time.Now().UTC().Format("20060102T150405Z"))
+}
+
+// close stops the sidecar if the run started one. The run's outcome does not
+// depend on it, so a failure is only logged.
+func (b *verificationBrowser) close(c *Coder) {
+	ctx, cancel := context.WithTimeout(context.Background(), browserStopTimeout)
+	defer cancel()
+	if err := b.launcher.Close(ctx); err != nil {
+		c.logger.Warn("🔍 Failed to stop browser sidecar: %v", err)
+	}
+}
+
+// attachScreenshots records the run's screenshots in its evidence, in the
+// []any of map[string]any shape the rest of the evidence uses.
+func attachScreenshots(evidence map[string]any, shots []tools.Screenshot) {
+	if evidence == nil || len(shots) == 0 {
+		return
+	}
+	list := make([]any, 0, len(shots))
+	for i := range shots {
+		list = append(list, map[string]any{
+			"name":    shots[i].Name,
+			"path":    shots[i].Path,
+			"caption": shots[i].Caption,
+			"url":     shots[i].URL,
+		})
+	}
+	evidence["screenshots"] = list
+}
+
+// formatScreenshotEvidence renders the screenshots in verification evidence
+// as a PR description section, noting which criteria cited each one. The
+// images stay on the orchestrator host, so the section gives their paths
+// relative to the project rather than embedding them. Empty when the run
+// took no screenshots.
+func formatScreenshotEvidence(evidence map[string]any) string {
+	shots, ok := evidence["screenshots"].([]any)
+	if !ok || len(shots) == 0 {
+		return ""
+	}
+
+	citedBy := make(map[string][]string)
+	if criteria, ok := evidence["acceptance_criteria_checked"].([]any); ok {
+		for _, item := range criteria {
+			cm, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			criterion, _ := cm["criterion"].(string)
+			refs, _ := cm["screenshots"].([]any)
+			for _, ref := range refs {
+				if name, ok := ref.(string); ok {
+					citedBy[name] = append(citedBy[name], criterion)
+				}
+			}
+		}
+	}
+
+	projectDir := config.GetProjectDir()
+	var b strings.Builder
+	b.WriteString("\n\n## UI Verification Screenshots\n\n")
+	for _, item := range shots {
+		shot, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := shot["name"].(string)
+		caption, _ := shot["caption"].(string)
+		pageURL, _ := shot["url"].(string)
+		path, _ := shot["path"].(string)
+		if rel, err := filepath.Rel(projectDir, path); projectDir != "" && err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+
+		b.WriteString(fmt.Sprintf("- **%s** — %s\n", name, caption))
+		if pageURL != "" {
+			b.WriteString(fmt.Sprintf("  - Page: %s\n", pageURL))
+		}
+		for _, criterion := range citedBy[name] {
+			b.WriteString(fmt.Sprintf("  - Shows: %s\n", criterion))
+		}
+		b.WriteString(fmt.Sprintf("  - File: `%s`\n", path))
+	}
+	return b.String()
+}
+
+// joinScreenshotRefs lists a criterion's screenshot names for review evidence.
+func joinScreenshotRefs(refs []any) string {
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if name, ok := ref.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,119 @@
+package coder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"orchestrator/pkg/browser"
+	"orchestrator/pkg/demo"
+	"orchestrator/pkg/tools"
+)
+
+const (
+	// maxHTTPVerificationIterations and maxHTTPProbingIterations replace the
+	// shell-only budgets when the loop can send HTTP requests: exercising an
+	// endpoint takes a call per case, on top of reading the code.
+	maxHTTPVerificationIterations = 8
+	maxHTTPProbingIterations      = 6
+)
+
+// newHTTPRequestTool returns the http_request tool for a verification or
+// probing run, or nil when the story has no compose stack: without one
+// there is no network of services to talk to.
+func (c *Coder) newHTTPRequestTool(workspacePath string) *tools.HTTPRequestTool {
+	if workspacePath == "" || !demo.ComposeFileExists(workspacePath) || c.longRunningExecutor == nil {
+		return nil
+	}
+	stack := demo.NewStack("maestro-"+c.GetAgentID(), demo.ComposeFilePath(workspacePath), "")
+	network := browser.ComposeNetworkName(c.GetAgentID())
+	ownContainer := c.GetContainerName()
+
+	hosts := func(ctx context.Context) ([]string, error) {
+		services, err := stack.ListServices()
+		if err != nil {
+			return nil, err //nolint:wrapcheck // the tool adds context
+		}
+		containers, err := demo.NewNetworkManager().ListNetworkContainers(ctx, network)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // the tool adds context
+		}
+		for _, name := range containers {
+			// The browser sidecar is on the network too, but is not a service.
+			if name != ownContainer && !strings.HasPrefix(name, "maestro-browser-") {
+				services = append(services, name)
+			}
+		}
+		slices.Sort(services)
+		return slices.Compact(services), nil
+	}
+	return tools.NewHTTPRequestTool(c.longRunningExecutor, hosts)
+}
+
+// composeServiceHosts lists the compose services for the prompt, best effort.
+func composeServiceHosts(workspacePath string) string {
+	services, err := demo.NewStack("", demo.ComposeFilePath(workspacePath), "").ListServices()
+	if err != nil || len(services) == 0 {
+		return ""
+	}
+	slices.Sort(services)
+	return strings.Join(services, ", ")
+}
+
+// attachHTTPExchanges records a run's request/response pairs in its
+// evidence. They go through JSON so the evidence holds the []any of
+// map[string]any shape it has after a resume.
+func attachHTTPExchanges(evidence map[string]any, exchanges []tools.HTTPExchange) {
+	if evidence == nil || len(exchanges) == 0 {
+		return
+	}
+	data, err := json.Marshal(exchanges)
+	if err != nil {
+		return
+	}
+	var list []any
+	if err := json.Unmarshal(data, &list); err != nil {
+		return
+	}
+	evidence["http_exchanges"] = list
+}
+
+// formatHTTPExchangeSummary summarises recorded exchanges for review
+// evidence: how many were sent and which missed their expectations.
+func formatHTTPExchangeSummary(evidence map[string]any) string {
+	exchanges, ok := evidence["http_exchanges"].([]any)
+	if !ok || len(exchanges) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	var failed []string
+	for i, item := range exchanges {
+		ex, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		assertions, _ := ex["assertions"].([]any)
+		for _, a := range assertions {
+			if am, ok := a.(map[string]any); ok && am["passed"] != true {
+				method, _ := ex["method"].(string)
+				target, _ := ex["url"].(string)
+				check, _ := am["check"].(string)
+				expected, _ := am["expected"].(string)
+				actual, _ := am["actual"].(string)
+				failed = append(failed, fmt.Sprintf("  - #%d %s %s: %s expected %s, got %s", i+1, method, target, check, expected, actual))
+				break
+			}
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("HTTP requests sent: %d", len(exchanges)))
+	if len(failed) > 0 {
+		b.WriteString(fmt.Sprintf(", %d missed expectations:\n", len(failed)))
+		b.WriteString(strings.Join(failed, "\n"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -440,3 +440,63 @@ func TestProducerConsumerRoundTrip_AllPass(t *testing.T) {
 		t.Error("Expected criterion text in formatted output")
 	}
 }
+
+func TestFormatScreenshotEvidence(t *testing.T) {
+	evidence := map[string]any{
+		"acceptance_criteria_checked": []any{
+			map[string]any{
+				"criterion":   "Login shows an error for a wrong password",
+				"result":      "pass",
+				"screenshots": []any{"shot-01.png"},
+			},
+		},
+	}
+	attachScreenshots(evidence, []tools.Screenshot{
+		{Name: "shot-01.png", Path: "/tmp/evidence/shot-01.png", Caption: "error banner", URL: "http://maestro-coder-001:3000/login"},
+	})
+
+	section := formatScreenshotEvidence(evidence)
+	for _, want := range []string{"UI Verification Screenshots", "shot-01.png", "error banner", "/login", "Login shows an error"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("expected %q in section:\n%s", want, section)
+		}
+	}
+
+	if review := formatVerificationEvidence(VerificationOutcome{Status: VerificationPass, Evidence: evidence}); !strings.Contains(review, "screenshots: shot-01.png") {
+		t.Errorf("expected review evidence to cite the screenshot:\n%s", review)
+	}
+}
+
+func TestFormatScreenshotEvidence_NoScreenshots(t *testing.T) {
+	if section := formatScreenshotEvidence(map[string]any{"confidence": "high"}); section != "" {
+		t.Errorf("expected no section, got %q", section)
+	}
+	if section := formatScreenshotEvidence(nil); section != "" {
+		t.Errorf("expected no section for nil evidence, got %q", section)
+	}
+}
+
+func TestVerificationForTraceability(t *testing.T) {
+	outcome := VerificationOutcome{
+		Status: VerificationPass,
+		Evidence: map[string]any{
+			"acceptance_criteria_checked": []any{
+				map[string]any{"criterion": "Login works", "method": "browser", "result": "pass", "evidence": "ok", "requirement_id": "R-002"},
+				map[string]any{"criterion": "Docs updated", "method": "inspection", "result": "partial", "evidence": "readme"},
+			},
+		},
+	}
+
+	got := verificationForTraceability(&outcome)
+	want := `{"status":"pass","criteria":[` +
+		`{"criterion":"Login works","requirement_id":"R-002","result":"pass","method":"browser"},` +
+		`{"criterion":"Docs updated","result":"partial","method":"inspection"}]}`
+	if got != want {
+		t.Errorf("verificationForTraceability() =\n%s\nwant\n%s", got, want)
+	}
+
+	unavailable := VerificationOutcome{Status: VerificationUnavailable, Reason: "timeout"}
+	if got := verificationForTraceability(&unavailable); got != `{"status":"unavailable"}` {
+		t.Errorf("Unexpected verification for unavailable outcome: %s", got)
+	}
+}
@@ -120,6 +120,40 @@ func (c *Coder) handleWaiting(ctx context.Context, sm *agent.BaseStateMachine) (
 			}
 		}
 
+		// Extract the epic or stacked-on branch the story branches from, when the architect assigned one
+		baseBranch, _ := payloadData[proto.KeyBaseBranch].(string)
+		stackedOn, _ := payloadData[proto.KeyStackedOn].(string)
+		switch {
+		case stackedOn != "":
+			c.logger.Info("🥞 Story is stacked on %s: it starts from %s", stackedOn, baseBranch)
+		case baseBranch != "":
+			c.logger.Info("🌿 Story targets epic branch %s", baseBranch)
+		}
+
+		// Extract the repository the story changes and the branches to read of the others
+		repo, _ := payloadData[proto.KeyRepo].(string)
+		if repo != "" {
+			c.logger.Info("📦 Story changes repository %s", repo)
+		}
+		siblingBranches := make(map[string]string)
+		if branches, ok := payloadData[proto.KeySiblingBranches].(map[string]any); ok {
+			for name, branch := range branches {
+				if branchStr, ok := branch.(string); ok && branchStr != "" {
+					siblingBranches[name] = branchStr
+				}
+			}
+		}
+
+		// Extract the spec requirement IDs the story covers, for the commit message footer
+		var requirementIDs []string
+		if ids, ok := payloadData[proto.KeyRequirements].([]any); ok {
+			for _, id := range ids {
+				if idStr, ok := id.(string); ok && idStr != "" {
+					requirementIDs = append(requirementIDs, idStr)
+				}
+			}
+		}
+
 		// Store the task content, story ID, story type, express, and hotfix flags for use in later states.
 		sm.SetStateData(string(stateDataKeyTaskContent), contentStr)
 		sm.SetStateData(KeyStoryMessageID, storyMsg.ID)
@@ -127,6 +161,11 @@ func (c *Coder) handleWaiting(ctx context.Context, sm *agent.BaseStateMachine) (
 		sm.SetStateData(proto.KeyStoryType, storyType) // Store story type for testing decisions
 		sm.SetStateData(KeyExpress, isExpress)         // Store express flag for planning bypass
 		sm.SetStateData(KeyIsHotfix, isHotfix)         // Store hotfix flag for routing/identification
+		sm.SetStateData(KeyBaseBranch, baseBranch)     // Empty unless the story targets an epic branch or is stacked
+		sm.SetStateData(KeyStackedOn, stackedOn)
+		sm.SetStateData(KeyRepo, repo)
+		sm.SetStateData(KeySiblingBranches, siblingBranches)
+		sm.SetStateData(KeyRequirementIDs, requirementIDs)
 		sm.SetStateData(string(stateDataKeyStartedAt), time.Now().UTC())
 
 		logx.DebugState(ctx, "coder", "transition", "WAITING -> SETUP", "received story message")
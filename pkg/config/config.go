@@ -56,6 +56,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/google/uuid"
@@ -468,6 +469,81 @@ type AgentConfig struct {
 
 	// Airplane mode model overrides
 	Airplane *AirplaneAgentConfig `json:"airplane,omitempty"` // Model overrides for airplane (offline) mode
+
+	// Failover lists, per role ("coder", "architect", "pm"), the models to
+	// fall back to, in order, when the role's model is unavailable past the
+	// retry middleware. The role's own model is always first and is not
+	// repeated here. A role with no entry suspends as before.
+	Failover map[string][]string `json:"failover,omitempty"`
+
+	// Routing sends calls to a cheaper or stronger model than the role's own
+	// by what the call is for. Nil routes every call to the role's model.
+	Routing *ModelRoutingConfig `json:"routing,omitempty"`
+}
+
+// RoutingTierDefault is the tier name that means the role's own model. It
+// needs no entry in Tiers; a rule can name it to pin a state or purpose to
+// the role's model ahead of a broader rule that would route it away.
+const RoutingTierDefault = "default"
+
+// ModelRoutingConfig maps (role, FSM state, call purpose) to a model tier.
+//
+// Tiers name models once so rules can say what a call deserves ("cheap",
+// "strong") rather than which model that is today. Rules are tried in order
+// and the first match wins, so specific rules go before broad ones; a call
+// no rule matches uses the role's own model.
+type ModelRoutingConfig struct {
+	Tiers map[string]string  `json:"tiers"`
+	Rules []ModelRoutingRule `json:"rules"`
+}
+
+// ModelRoutingRule selects a tier. An empty selector matches anything, so
+// {"purpose": "probing", "tier": "cheap"} covers probing in every role and
+// state.
+type ModelRoutingRule struct {
+	Role    string `json:"role,omitempty"`    // "coder", "architect" or "pm"
+	State   string `json:"state,omitempty"`   // FSM state, e.g. "PLANNING" or "CODE_REVIEW"
+	Purpose string `json:"purpose,omitempty"` // call purpose, e.g. "verification", "probing", "conflicts"
+	Tier    string `json:"tier"`
+}
+
+// Matches reports whether the rule selects a call.
+func (r *ModelRoutingRule) Matches(role, state, purpose string) bool {
+	return (r.Role == "" || r.Role == role) &&
+		(r.State == "" || r.State == state) &&
+		(r.Purpose == "" || r.Purpose == purpose)
+}
+
+// TierFor returns the tier of the first rule that matches, or
+// RoutingTierDefault when none does.
+func (c *ModelRoutingConfig) TierFor(role, state, purpose string) string {
+	if c == nil {
+		return RoutingTierDefault
+	}
+	for i := range c.Rules {
+		if c.Rules[i].Matches(role, state, purpose) {
+			return c.Rules[i].Tier
+		}
+	}
+	return RoutingTierDefault
+}
+
+// TiersFor returns the non-default tiers some rule could select for role,
+// in first-mention order: the tiers a role's client has to be able to reach.
+func (c *ModelRoutingConfig) TiersFor(role string) []string {
+	if c == nil {
+		return nil
+	}
+	var tiers []string
+	seen := map[string]bool{RoutingTierDefault: true}
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if (rule.Role == "" || rule.Role == role) && !seen[rule.Tier] {
+			seen[rule.Tier] = true
+			tiers = append(tiers, rule.Tier)
+		}
+	}
+	return tiers
 }
 
 // All constants bundled together for easy maintenance.
@@ -610,6 +686,28 @@ type GitConfig struct {
 	BranchPattern string `json:"branch_pattern"` // Branch name pattern (default: story-{STORY_ID})
 	GitUserName   string `json:"git_user_name"`  // Git commit author name (default: Maestro {AGENT_ID})
 	GitUserEmail  string `json:"git_user_email"` // Git commit author email (default: maestro-{AGENT_ID}@localhost)
+
+	IgnoreChecks        bool `json:"ignore_checks,omitempty"`         // Merge without waiting for the forge's CI checks
+	CheckTimeoutMinutes int  `json:"check_timeout_minutes,omitempty"` // How long a merge waits for pending CI checks (default: 30)
+
+	EpicBranches bool `json:"epic_branches,omitempty"` // Merge each spec's stories into an epic branch that a human accepts into the target branch
+
+	StackedPRs       bool `json:"stacked_prs,omitempty"`        // Start a story on the branch of the dependency it waits for once that dependency's code is approved
+	StackWaitMinutes int  `json:"stack_wait_minutes,omitempty"` // How long a stacked story's merge waits for its dependency to merge (default: 120)
+
+	CommitTemplate string `json:"commit_template,omitempty"` // Coder commit message: "conventional", a Go template, or "" for "Story {ID}: summary"
+	CommitSigning  string `json:"commit_signing,omitempty"`  // Sign coder commits: "ssh", "gpg", or "" (unsigned)
+
+	RepoRole string       `json:"repo_role,omitempty"` // What repo_url holds when the product spans several repositories (e.g. "Go API server")
+	Repos    []RepoConfig `json:"repos,omitempty"`     // Further repositories of a multi-repo product; repo_url stays the primary one
+}
+
+// RepoConfig is one further repository of a multi-repo product. Stories name
+// the repository they change; the primary repository is repo_url.
+type RepoConfig struct {
+	Name string `json:"name"`           // Name stories use to target the repository (e.g. "frontend")
+	URL  string `json:"url"`            // Git repository URL for clone/push
+	Role string `json:"role,omitempty"` // What the repository holds, shown to the architect (e.g. "React web client")
 }
 
 // WebUIConfig contains web UI server settings.
@@ -645,6 +743,31 @@ type ChatConfig struct {
 // Search is auto-enabled when API keys are detected, but can be explicitly disabled.
 type SearchConfig struct {
 	Enabled *bool `json:"enabled,omitempty"` // Whether web search is enabled (nil = auto-detect from API keys)
+
+	// Provider pins the backend: "google", "brave", "bing" or "searxng".
+	// Empty uses the first one whose credentials are present, in that order.
+	Provider SearchProviderType `json:"provider,omitempty"`
+
+	// SearXNGURL is the base URL of a SearXNG instance with the JSON format
+	// enabled (e.g. "http://localhost:8888"). The SEARXNG_URL environment
+	// variable is used when this is empty.
+	SearXNGURL string `json:"searxng_url,omitempty"`
+
+	// MaxQueriesPerSession bounds the searches sent to the provider in one
+	// orchestrator session; answers from the session's cache do not count.
+	// Zero means no limit.
+	MaxQueriesPerSession int `json:"max_queries_per_session,omitempty"`
+
+	// AllowDomains, when set, keeps only results on these domains or their
+	// subdomains. DenyDomains always drops results on its domains, and wins
+	// over AllowDomains.
+	AllowDomains []string `json:"allow_domains,omitempty"`
+	DenyDomains  []string `json:"deny_domains,omitempty"`
+
+	// DocsDirs are documentation directories the docs_search tool searches
+	// alongside the docs it indexes from installed dependencies. Relative
+	// paths are relative to the workspace inside the agent's container.
+	DocsDirs []string `json:"docs_dirs,omitempty"`
 }
 
 // LogsConfig contains log file management configuration.
@@ -674,6 +797,67 @@ type DemoConfig struct {
 	HealthcheckTimeoutSeconds int    `json:"healthcheck_timeout_seconds"` // Max wait time for app to become healthy (default: 60)
 }
 
+// BrowserConfig controls browser-driven verification of UI stories: a
+// headless browser sidecar the verifier navigates, clicks and screenshots.
+type BrowserConfig struct {
+	// Enabled gives acceptance-criteria verification the browser tool.
+	// Off by default: the sidecar image is a sizeable pull, and stories
+	// without a UI gain nothing from it.
+	Enabled bool `json:"enabled"`
+
+	// Image is the sidecar image; it must serve Chrome DevTools on port
+	// 9222. Empty uses chromedp/headless-shell:stable.
+	Image string `json:"image,omitempty"`
+}
+
+// MergeQueueConfig controls the orchestrator-owned merge queue. When enabled,
+// approved PRs are rebased onto the queue head in a scratch clone and built
+// and tested in the project container; only green candidates are merged.
+type MergeQueueConfig struct {
+	// Enabled queues merge requests instead of merging each PR as it arrives.
+	Enabled bool `json:"enabled"`
+
+	// Depth is how many queued PRs are tested at once, each on top of the
+	// ones ahead of it (default: 3). 1 tests one PR at a time.
+	Depth int `json:"depth,omitempty"`
+
+	// TestTimeoutMinutes bounds the build and test run of one candidate (default: 20).
+	TestTimeoutMinutes int `json:"test_timeout_minutes,omitempty"`
+}
+
+// Merge queue defaults.
+const (
+	DefaultMergeQueueDepth              = 3
+	DefaultMergeQueueTestTimeoutMinutes = 20
+)
+
+// DefaultCheckTimeoutMinutes is how long a merge waits for pending CI checks by default.
+const DefaultCheckTimeoutMinutes = 30
+
+// DefaultStackWaitMinutes is how long a stacked story's merge waits for its dependency to merge by default.
+const DefaultStackWaitMinutes = 120
+
+// Commit signing formats (git.commit_signing).
+const (
+	CommitSigningSSH = "ssh"
+	CommitSigningGPG = "gpg"
+)
+
+// Commit message templates (git.commit_template). Templates are Go templates
+// over tools.CommitInfo, with a join function for lists.
+const (
+	CommitTemplateConventional = "conventional" // Alias for ConventionalCommitTemplate
+
+	DefaultCommitTemplate      = "{{with .StoryID}}Story {{.}}: {{end}}{{.Summary}}"
+	ConventionalCommitTemplate = "{{.Type}}{{with .Scope}}({{.}}){{end}}: {{.Subject}}\n\n{{.Body}}\n\n" +
+		"{{with .StoryID}}Story: {{.}}{{end}}{{with .RequirementIDs}}\nRefs: {{join . \", \"}}{{end}}"
+)
+
+// ParseCommitTemplate parses a commit message template.
+func ParseCommitTemplate(text string) (*template.Template, error) {
+	return template.New("commit").Funcs(template.FuncMap{"join": strings.Join}).Parse(text)
+}
+
 // PortInfo describes a detected listening port in a container.
 type PortInfo struct {
 	Port        int    `json:"port"`         // Container port number
@@ -745,21 +929,23 @@ type Config struct {
 	TelemetryEnabled bool   `json:"telemetry_enabled,omitempty"` // Opt-in failure telemetry reporting
 
 	// === PROJECT-SPECIFIC SETTINGS (per .maestro/config.json) ===
-	Project     *ProjectInfo       `json:"project"`     // Basic project metadata (name, platform)
-	Container   *ContainerConfig   `json:"container"`   // Container settings (NO build state/metadata)
-	Build       *BuildConfig       `json:"build"`       // Build commands and targets
-	Agents      *AgentConfig       `json:"agents"`      // Which models to use and rate limits for this project
-	Git         *GitConfig         `json:"git"`         // Git repository and branching settings
-	Forge       *ForgeConfig       `json:"forge"`       // Forge provider settings (github or gitea)
-	WebUI       *WebUIConfig       `json:"webui"`       // Web UI server settings
-	Chat        *ChatConfig        `json:"chat"`        // Agent chat system settings
-	Search      *SearchConfig      `json:"search"`      // Web search settings
-	PM          *PMConfig          `json:"pm"`          // PM agent settings
-	Logs        *LogsConfig        `json:"logs"`        // Log file management settings
-	Debug       *DebugConfig       `json:"debug"`       // Debug settings
-	Demo        *DemoConfig        `json:"demo"`        // Demo mode settings
-	Maintenance *MaintenanceConfig `json:"maintenance"` // Automated maintenance mode settings
-	Agentsh     *AgentshConfig     `json:"agentsh"`     // Agentsh security gateway settings
+	Project     *ProjectInfo       `json:"project"`               // Basic project metadata (name, platform)
+	Container   *ContainerConfig   `json:"container"`             // Container settings (NO build state/metadata)
+	Build       *BuildConfig       `json:"build"`                 // Build commands and targets
+	Agents      *AgentConfig       `json:"agents"`                // Which models to use and rate limits for this project
+	Git         *GitConfig         `json:"git"`                   // Git repository and branching settings
+	Forge       *ForgeConfig       `json:"forge"`                 // Forge provider settings (github or gitea)
+	WebUI       *WebUIConfig       `json:"webui"`                 // Web UI server settings
+	Chat        *ChatConfig        `json:"chat"`                  // Agent chat system settings
+	Search      *SearchConfig      `json:"search"`                // Web search settings
+	PM          *PMConfig          `json:"pm"`                    // PM agent settings
+	Logs        *LogsConfig        `json:"logs"`                  // Log file management settings
+	Debug       *DebugConfig       `json:"debug"`                 // Debug settings
+	Demo        *DemoConfig        `json:"demo"`                  // Demo mode settings
+	Browser     *BrowserConfig     `json:"browser,omitempty"`     // Browser-driven UI verification settings
+	MergeQueue  *MergeQueueConfig  `json:"merge_queue,omitempty"` // Speculative merge queue settings
+	Maintenance *MaintenanceConfig `json:"maintenance"`           // Automated maintenance mode settings
+	Agentsh     *AgentshConfig     `json:"agentsh"`               // Agentsh security gateway settings
 
 	// === RUNTIME-ONLY STATE (NOT PERSISTED) ===
 	SessionID        string `json:"-"` // Current orchestrator session UUID (generated at startup or loaded for restarts)
@@ -823,6 +1009,187 @@ func GetProjectMaestroDir() (string, error) {
 	return filepath.Join(projectDir, ProjectConfigDir), nil
 }
 
+// GetBrowserConfig returns the browser verification settings; the zero
+// value (disabled) when none are configured.
+func GetBrowserConfig() BrowserConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Browser == nil {
+		return BrowserConfig{}
+	}
+	return *config.Browser
+}
+
+// GetMergeQueueConfig returns the merge queue settings with defaults applied;
+// disabled when none are configured.
+func GetMergeQueueConfig() MergeQueueConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.MergeQueue == nil {
+		return MergeQueueConfig{}
+	}
+	mq := *config.MergeQueue
+	if mq.Depth <= 0 {
+		mq.Depth = DefaultMergeQueueDepth
+	}
+	if mq.TestTimeoutMinutes <= 0 {
+		mq.TestTimeoutMinutes = DefaultMergeQueueTestTimeoutMinutes
+	}
+	return mq
+}
+
+// GetCheckWaitTimeout returns how long the architect holds a merge for the
+// PR's pending CI checks; zero when checks are ignored or no config is loaded.
+func GetCheckWaitTimeout() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || (config.Git != nil && config.Git.IgnoreChecks) {
+		return 0
+	}
+	if config.Git == nil || config.Git.CheckTimeoutMinutes <= 0 {
+		return DefaultCheckTimeoutMinutes * time.Minute
+	}
+	return time.Duration(config.Git.CheckTimeoutMinutes) * time.Minute
+}
+
+// GetCommitTemplate returns the coder commit message template, resolving the
+// "conventional" alias.
+func GetCommitTemplate() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Git == nil || config.Git.CommitTemplate == "" {
+		return DefaultCommitTemplate
+	}
+	if config.Git.CommitTemplate == CommitTemplateConventional {
+		return ConventionalCommitTemplate
+	}
+	return config.Git.CommitTemplate
+}
+
+// GetCommitSigning returns how coder commits are signed: "ssh", "gpg", or "" for unsigned.
+func GetCommitSigning() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Git == nil {
+		return ""
+	}
+	return config.Git.CommitSigning
+}
+
+// RepoNameFromURL returns the name of a repository: the last path component of its URL.
+func RepoNameFromURL(repoURL string) string {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	if i := strings.LastIndexAny(repoURL, "/:"); i >= 0 {
+		repoURL = repoURL[i+1:]
+	}
+	return repoURL
+}
+
+// IsMultiRepo reports whether the project spans more than one repository.
+func IsMultiRepo() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return config != nil && config.Git != nil && len(config.Git.Repos) > 0
+}
+
+// GetRepos returns every repository of the project, the primary one (repo_url)
+// first. Returns nil when no repository is configured.
+func GetRepos() []RepoConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Git == nil || config.Git.RepoURL == "" {
+		return nil
+	}
+	repos := []RepoConfig{{Name: RepoNameFromURL(config.Git.RepoURL), URL: config.Git.RepoURL, Role: config.Git.RepoRole}}
+	return append(repos, config.Git.Repos...)
+}
+
+// ResolveRepo returns the name stories record for a repository: "" for the
+// primary repository (by name or empty), the name itself for a further one.
+func ResolveRepo(name string) (string, error) {
+	repos := GetRepos()
+	if name == "" || (len(repos) > 0 && name == repos[0].Name) {
+		return "", nil
+	}
+	for _, repo := range repos {
+		if repo.Name == name {
+			return name, nil
+		}
+	}
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	return "", fmt.Errorf("unknown repository %q (configured: %s)", name, strings.Join(names, ", "))
+}
+
+// RepoDisplayName returns the configured name of a repository by the name
+// stories record, naming the primary repository after its URL.
+func RepoDisplayName(repo string) string {
+	if repo == "" {
+		return RepoNameFromURL(GetGitRepoURL())
+	}
+	return repo
+}
+
+// GetRepoURL returns the URL of a repository by the name stories record, the
+// primary repository's for "".
+func GetRepoURL(name string) (string, error) {
+	if name == "" {
+		if repoURL := GetGitRepoURL(); repoURL != "" {
+			return repoURL, nil
+		}
+		return "", fmt.Errorf("no git repository configured")
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	if config != nil && config.Git != nil {
+		for _, repo := range config.Git.Repos {
+			if repo.Name == name {
+				return repo.URL, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("unknown repository %q", name)
+}
+
+// EpicBranchesEnabled reports whether stories merge into a per-spec epic
+// branch instead of directly into the target branch.
+func EpicBranchesEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return config != nil && config.Git != nil && config.Git.EpicBranches
+}
+
+// StackedPRsEnabled reports whether a story may start on the branch of a
+// dependency whose code is approved instead of waiting for it to merge.
+func StackedPRsEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return config != nil && config.Git != nil && config.Git.StackedPRs
+}
+
+// GetStackWaitTimeout returns how long the architect holds a stacked story's
+// merge until the story it is stacked on merges; zero when stories are never
+// stacked (stacking is off and the project has a single repository).
+func GetStackWaitTimeout() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Git == nil || (!config.Git.StackedPRs && len(config.Git.Repos) == 0) {
+		return 0
+	}
+	if config.Git.StackWaitMinutes <= 0 {
+		return DefaultStackWaitMinutes * time.Minute
+	}
+	return time.Duration(config.Git.StackWaitMinutes) * time.Minute
+}
+
+// MergeWaitTimeout is how long a coder waits for the merge queue to judge its
+// PR: a few test windows, allowing for failures ahead of it and retests.
+func (c MergeQueueConfig) MergeWaitTimeout() time.Duration {
+	return 6 * time.Duration(c.TestTimeoutMinutes) * time.Minute
+}
+
 // GetProjectDir returns the current project directory.
 // Must call LoadConfig first to initialize projectDir.
 func GetProjectDir() string {
@@ -1421,6 +1788,9 @@ func UpdateGit(git *GitConfig) error {
 			git.RepoURL = convertedURL
 		}
 	}
+	for i := range git.Repos {
+		git.Repos[i].URL = convertSSHToHTTPS(git.Repos[i].URL)
+	}
 
 	// Apply git user defaults if not provided
 	if git.GitUserName == "" {
@@ -1784,11 +2154,95 @@ func validateAgentConfigInternal(agents *AgentConfig, _ *Config) error {
 		}
 	}
 
+	if err := validateFailover(agents); err != nil {
+		return err
+	}
+	if err := validateRouting(agents); err != nil {
+		return err
+	}
+
 	// No need to validate MaxConnections or TPM - those are removed from config
 	// Rate limits are now per-provider, not per-model
 	return nil
 }
 
+// validateFailover checks every failover chain against the role it serves.
+//
+// A chain is refused whole rather than trimmed: a fallback that cannot be
+// mapped to a provider would otherwise surface only on the day the primary
+// is down, which is the one day nobody wants to learn the config was wrong.
+func validateFailover(agents *AgentConfig) error {
+	primaries := map[string]string{
+		AgentTypeCoder:     agents.CoderModel,
+		AgentTypeArchitect: agents.ArchitectModel,
+		AgentTypePM:        agents.PMModel,
+	}
+	for role, chain := range agents.Failover {
+		primary, known := primaries[role]
+		if !known {
+			return fmt.Errorf("failover: unknown role '%s' (want %s, %s or %s)",
+				role, AgentTypeCoder, AgentTypeArchitect, AgentTypePM)
+		}
+		// Claude Code mode runs the coder through the Claude Code CLI, not
+		// through the client a chain would wrap; a chain there would be
+		// configuration that silently does nothing.
+		if role == AgentTypeCoder && agents.CoderMode == CoderModeClaudeCode && len(chain) > 0 {
+			return fmt.Errorf("failover: coder_mode '%s' does not use the model client, so a coder failover chain cannot apply",
+				CoderModeClaudeCode)
+		}
+		seen := map[string]bool{primary: true}
+		for _, model := range chain {
+			if _, err := GetModelProvider(model); err != nil {
+				return fmt.Errorf("failover.%s model '%s': %w", role, model, err)
+			}
+			if seen[model] {
+				return fmt.Errorf("failover.%s lists '%s' twice, or lists the role's own model; each model is tried once",
+					role, model)
+			}
+			seen[model] = true
+		}
+	}
+	return nil
+}
+
+// validateRouting checks that every rule names a role that exists and a
+// tier that resolves to a model. States and purposes are not checked here:
+// they belong to the agent packages, which this package cannot import, and
+// a rule naming one that never occurs simply never matches.
+func validateRouting(agents *AgentConfig) error {
+	routing := agents.Routing
+	if routing == nil {
+		return nil
+	}
+	for tier, model := range routing.Tiers {
+		if tier == RoutingTierDefault {
+			return fmt.Errorf("routing.tiers: '%s' is reserved for the role's own model", RoutingTierDefault)
+		}
+		if _, err := GetModelProvider(model); err != nil {
+			return fmt.Errorf("routing.tiers.%s model '%s': %w", tier, model, err)
+		}
+	}
+	for i := range routing.Rules {
+		rule := &routing.Rules[i]
+		switch rule.Role {
+		case "", AgentTypeCoder, AgentTypeArchitect, AgentTypePM:
+		default:
+			return fmt.Errorf("routing.rules[%d]: unknown role '%s'", i, rule.Role)
+		}
+		if _, ok := routing.Tiers[rule.Tier]; !ok && rule.Tier != RoutingTierDefault {
+			return fmt.Errorf("routing.rules[%d]: tier '%s' is not defined in routing.tiers", i, rule.Tier)
+		}
+		// As with failover, Claude Code mode does not call the coder's
+		// model through the client routing would choose between.
+		if agents.CoderMode == CoderModeClaudeCode && rule.Tier != RoutingTierDefault &&
+			(rule.Role == AgentTypeCoder || rule.Role == "") {
+			return fmt.Errorf("routing.rules[%d]: coder_mode '%s' does not use the model client, so a rule that routes the coder cannot apply",
+				i, CoderModeClaudeCode)
+		}
+	}
+	return nil
+}
+
 // applyDefaults sets default values for missing configuration.
 func applyDefaults(config *Config) {
 	// Initialize sections if nil
@@ -1894,6 +2348,9 @@ func applyDefaults(config *Config) {
 			config.Git.RepoURL = convertedURL
 		}
 	}
+	for i := range config.Git.Repos {
+		config.Git.Repos[i].URL = convertSSHToHTTPS(config.Git.Repos[i].URL)
+	}
 
 	// Apply agent defaults
 	if config.Agents.MaxCoders == 0 {
@@ -2111,6 +2568,53 @@ func validateConfig(config *Config) error {
 			}
 			return fmt.Errorf("git repo_url must start with 'git@' or 'https://'")
 		}
+
+		names := map[string]bool{RepoNameFromURL(config.Git.RepoURL): true}
+		mirrors := map[string]bool{RepoNameFromURL(config.Git.RepoURL): true}
+		for i, repo := range config.Git.Repos {
+			if repo.Name == "" || strings.ContainsAny(repo.Name, " \t/") {
+				return fmt.Errorf("git repos[%d].name must be a non-empty name without spaces or slashes, got %q", i, repo.Name)
+			}
+			if names[repo.Name] {
+				return fmt.Errorf("git repos[%d].name %q is already used by another repository", i, repo.Name)
+			}
+			names[repo.Name] = true
+			if !strings.HasPrefix(repo.URL, "git@") && !strings.HasPrefix(repo.URL, "https://") &&
+				!(allowHTTP && strings.HasPrefix(repo.URL, "http://")) {
+				return fmt.Errorf("git repos[%d].url must be a git@ or https:// repository URL, got %q", i, repo.URL)
+			}
+			if mirrors[RepoNameFromURL(repo.URL)] {
+				return fmt.Errorf("git repos[%d].url %q has the same repository name as another repository, so they would share a mirror", i, repo.URL)
+			}
+			mirrors[RepoNameFromURL(repo.URL)] = true
+		}
+	} else if config.Git != nil && len(config.Git.Repos) > 0 {
+		return fmt.Errorf("git repos requires git repo_url, the primary repository")
+	}
+
+	if err := validateSearchConfig(config.Search); err != nil {
+		return err
+	}
+
+	if config.Browser != nil && strings.ContainsAny(config.Browser.Image, " \t\n") {
+		return fmt.Errorf("browser.image must be a Docker image reference, got %q", config.Browser.Image)
+	}
+
+	if config.Git != nil {
+		switch config.Git.CommitSigning {
+		case "", CommitSigningSSH, CommitSigningGPG:
+		default:
+			return fmt.Errorf("git.commit_signing must be %q, %q or empty (got %q)", CommitSigningSSH, CommitSigningGPG, config.Git.CommitSigning)
+		}
+		if config.Git.CommitTemplate != "" && config.Git.CommitTemplate != CommitTemplateConventional {
+			if _, err := ParseCommitTemplate(config.Git.CommitTemplate); err != nil {
+				return fmt.Errorf("git.commit_template is not a valid template: %w", err)
+			}
+		}
+	}
+
+	if config.MergeQueue != nil && (config.MergeQueue.Depth < 0 || config.MergeQueue.TestTimeoutMinutes < 0) {
+		return fmt.Errorf("merge_queue.depth and merge_queue.test_timeout_minutes must not be negative")
 	}
 
 	// Validate WebUI settings
@@ -2522,6 +3026,69 @@ func GetEffectivePMModel() string {
 	return config.Agents.PMModel
 }
 
+// GetEffectiveFailoverModels returns the models the role fails over to, in
+// order, after its effective model.
+//
+// In airplane mode only local models are kept: the mode exists because the
+// network is not there, and failing over to a hosted model would spend the
+// outage on calls that cannot succeed. The effective model itself is
+// dropped if the chain names it, since airplane overrides can make a
+// fallback the primary.
+func GetEffectiveFailoverModels(role string) []string {
+	var primary string
+	switch role {
+	case AgentTypeCoder:
+		primary = GetEffectiveCoderModel()
+	case AgentTypeArchitect:
+		primary = GetEffectiveArchitectModel()
+	case AgentTypePM:
+		primary = GetEffectivePMModel()
+	default:
+		return nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Agents == nil {
+		return nil
+	}
+	airplane := config.OperatingMode == OperatingModeAirplane
+	var chain []string
+	for _, model := range config.Agents.Failover[role] {
+		if model == primary {
+			continue
+		}
+		if airplane {
+			if provider, err := GetModelProvider(model); err != nil || provider != ProviderOllama {
+				continue
+			}
+		}
+		chain = append(chain, model)
+	}
+	return chain
+}
+
+// GetEffectiveRouting returns a copy of the routing policy, or nil when
+// calls are not routed.
+//
+// Airplane mode routes nothing: its per-role overrides already pick the
+// local model each role runs on, and a tier table written for hosted models
+// would send calls where the network is not.
+func GetEffectiveRouting() *ModelRoutingConfig {
+	mu.RLock()
+	defer mu.RUnlock()
+	if config == nil || config.Agents == nil || config.Agents.Routing == nil ||
+		config.OperatingMode == OperatingModeAirplane {
+		return nil
+	}
+	routing := config.Agents.Routing
+	tiers := make(map[string]string, len(routing.Tiers))
+	for tier, model := range routing.Tiers {
+		tiers[tier] = model
+	}
+	return &ModelRoutingConfig{Tiers: tiers, Rules: append([]ModelRoutingRule(nil), routing.Rules...)}
+}
+
 // Agent type constants for smart model selection.
 const (
 	AgentTypeCoder     = "coder"
@@ -2735,12 +3302,22 @@ func validateRateLimitCapacity(cfg *Config) {
 		ProviderOllama:    cfg.Agents.Resilience.RateLimit.Ollama.TokensPerMinute,
 	}
 
-	// Only check models actually selected in config (not all known models)
+	// Only check models actually selected in config (not all known models),
+	// fallbacks included: a fallback that can never fit its bucket fails
+	// over into a permanent block.
 	selectedModels := []string{
 		cfg.Agents.CoderModel,
 		cfg.Agents.ArchitectModel,
 		cfg.Agents.PMModel,
 	}
+	for _, chain := range cfg.Agents.Failover {
+		selectedModels = append(selectedModels, chain...)
+	}
+	if cfg.Agents.Routing != nil {
+		for _, model := range cfg.Agents.Routing.Tiers {
+			selectedModels = append(selectedModels, model)
+		}
+	}
 
 	for _, modelName := range selectedModels {
 		if modelName == "" {
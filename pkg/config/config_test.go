@@ -309,3 +309,59 @@ func TestIsAdversarialProbingEnabled_ExplicitlyFalse(t *testing.T) {
 		t.Error("Expected adversarial probing disabled when explicitly false")
 	}
 }
+
+func TestResolveRepo(t *testing.T) {
+	SetConfigForTesting(&Config{Git: &GitConfig{
+		RepoURL: "https://github.com/acme/api.git",
+		Repos:   []RepoConfig{{Name: "web", URL: "https://github.com/acme/web.git", Role: "React client"}},
+	}})
+	defer SetConfigForTesting(nil)
+
+	if !IsMultiRepo() {
+		t.Fatal("Expected a multi-repo project")
+	}
+	repos := GetRepos()
+	if len(repos) != 2 || repos[0].Name != "api" || repos[1].Name != "web" {
+		t.Fatalf("GetRepos() = %+v, want api then web", repos)
+	}
+
+	for name, want := range map[string]string{"": "", "api": "", "web": "web"} {
+		got, err := ResolveRepo(name)
+		if err != nil || got != want {
+			t.Errorf("ResolveRepo(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ResolveRepo("mobile"); err == nil {
+		t.Error("Expected an error for an unknown repository")
+	}
+
+	if url, err := GetRepoURL("web"); err != nil || url != "https://github.com/acme/web.git" {
+		t.Errorf("GetRepoURL(web) = %q, %v", url, err)
+	}
+	if url, err := GetRepoURL(""); err != nil || url != "https://github.com/acme/api.git" {
+		t.Errorf("GetRepoURL(\"\") = %q, %v", url, err)
+	}
+}
+
+func TestValidateConfig_Repos(t *testing.T) {
+	tests := []struct {
+		name    string
+		repos   []RepoConfig
+		wantErr bool
+	}{
+		{"valid", []RepoConfig{{Name: "web", URL: "git@github.com:acme/web.git"}}, false},
+		{"missing name", []RepoConfig{{URL: "https://github.com/acme/web.git"}}, true},
+		{"primary name", []RepoConfig{{Name: "api", URL: "https://github.com/acme/web.git"}}, true},
+		{"bad url", []RepoConfig{{Name: "web", URL: "ftp://example.com/web.git"}}, true},
+		{"shared mirror", []RepoConfig{{Name: "other", URL: "https://github.com/other/api.git"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateConfig(&Config{Git: &GitConfig{RepoURL: "https://github.com/acme/api.git", Repos: tt.repos}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
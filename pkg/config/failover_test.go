@@ -0,0 +1,87 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateFailover(t *testing.T) {
+	agents := func(failover map[string][]string) *AgentConfig {
+		return &AgentConfig{
+			MaxCoders:      2,
+			CoderModel:     "claude-sonnet-4-5",
+			ArchitectModel: "gpt-5.4",
+			PMModel:        "claude-opus-4-6",
+			Failover:       failover,
+		}
+	}
+
+	if err := validateAgentConfigInternal(agents(map[string][]string{
+		AgentTypeArchitect: {"claude-opus-4-6", "llama3.1:70b"},
+	}), nil); err != nil {
+		t.Fatalf("a valid chain was refused: %v", err)
+	}
+
+	tests := map[string]struct {
+		failover map[string][]string
+		want     string
+	}{
+		"unknown role":       {map[string][]string{"reviewer": {"gpt-5.4"}}, "unknown role"},
+		"unmapped model":     {map[string][]string{AgentTypePM: {"not-a-known-model"}}, "failover.pm"},
+		"repeats primary":    {map[string][]string{AgentTypeArchitect: {"gpt-5.4"}}, "twice"},
+		"repeats a fallback": {map[string][]string{AgentTypeCoder: {"llama3.1:8b", "llama3.1:8b"}}, "twice"},
+	}
+	for name, tc := range tests {
+		err := validateAgentConfigInternal(agents(tc.failover), nil)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want one mentioning %q", name, err, tc.want)
+		}
+	}
+
+	// Claude Code mode does not go through the client a chain wraps.
+	claudeCode := agents(map[string][]string{AgentTypeCoder: {"claude-opus-4-6"}})
+	claudeCode.CoderMode = CoderModeClaudeCode
+	if err := validateAgentConfigInternal(claudeCode, nil); err == nil {
+		t.Error("a coder chain under claude-code mode was accepted")
+	}
+}
+
+// In airplane mode a chain keeps only local models, and never repeats the
+// model the airplane override made primary.
+func TestGetEffectiveFailoverModels(t *testing.T) {
+	mu.Lock()
+	originalConfig := config
+	config = &Config{
+		OperatingMode: OperatingModeStandard,
+		Agents: &AgentConfig{
+			CoderModel:     "claude-sonnet-4-5",
+			ArchitectModel: "gpt-5.4",
+			PMModel:        "claude-opus-4-6",
+			Airplane:       &AirplaneAgentConfig{ArchitectModel: "llama3.1:70b"},
+			Failover: map[string][]string{
+				AgentTypeArchitect: {"claude-opus-4-6", "llama3.1:70b", "qwen3-coder:30b"},
+			},
+		},
+	}
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		config = originalConfig
+		mu.Unlock()
+	}()
+
+	if got, want := GetEffectiveFailoverModels(AgentTypeArchitect), []string{"claude-opus-4-6", "llama3.1:70b", "qwen3-coder:30b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("standard mode chain = %v, want %v", got, want)
+	}
+	if got := GetEffectiveFailoverModels(AgentTypePM); got != nil {
+		t.Fatalf("a role with no chain got %v", got)
+	}
+
+	mu.Lock()
+	config.OperatingMode = OperatingModeAirplane
+	mu.Unlock()
+	if got, want := GetEffectiveFailoverModels(AgentTypeArchitect), []string{"qwen3-coder:30b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("airplane mode chain = %v, want %v", got, want)
+	}
+}
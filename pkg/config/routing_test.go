@@ -0,0 +1,71 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoutingTierFor(t *testing.T) {
+	routing := &ModelRoutingConfig{
+		Tiers: map[string]string{"cheap": "claude-haiku-4-5", "strong": "claude-opus-4-6"},
+		Rules: []ModelRoutingRule{
+			{Role: AgentTypeCoder, State: "CODE_REVIEW", Tier: "strong"},
+			{Role: AgentTypeCoder, State: "PLANNING", Tier: RoutingTierDefault},
+			{Purpose: "probing", Tier: "cheap"},
+			{Role: AgentTypeCoder, Tier: "cheap"},
+		},
+	}
+	cases := []struct{ role, state, purpose, want string }{
+		{AgentTypeCoder, "CODE_REVIEW", "", "strong"},
+		{AgentTypeCoder, "PLANNING", "probing", RoutingTierDefault}, // first match wins
+		{AgentTypeArchitect, "REQUEST", "probing", "cheap"},
+		{AgentTypeCoder, "CODING", "", "cheap"},
+		{AgentTypePM, "WORKING", "", RoutingTierDefault},
+	}
+	for _, c := range cases {
+		if got := routing.TierFor(c.role, c.state, c.purpose); got != c.want {
+			t.Errorf("TierFor(%s, %s, %q) = %s, want %s", c.role, c.state, c.purpose, got, c.want)
+		}
+	}
+	if got, want := routing.TiersFor(AgentTypeCoder), []string{"strong", "cheap"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("TiersFor(coder) = %v, want %v", got, want)
+	}
+	if got := routing.TiersFor(AgentTypePM); !reflect.DeepEqual(got, []string{"cheap"}) {
+		t.Errorf("TiersFor(pm) = %v, want the role-less probing rule's tier", got)
+	}
+	if (*ModelRoutingConfig)(nil).TierFor(AgentTypeCoder, "CODING", "") != RoutingTierDefault {
+		t.Error("no policy must route to the role's own model")
+	}
+}
+
+func TestValidateRouting(t *testing.T) {
+	agents := func(routing *ModelRoutingConfig) *AgentConfig {
+		return &AgentConfig{
+			MaxCoders: 2, CoderModel: "claude-sonnet-4-5", ArchitectModel: "gpt-5.4", PMModel: "claude-opus-4-6",
+			Routing: routing,
+		}
+	}
+	tiers := map[string]string{"cheap": "claude-haiku-4-5"}
+	if err := validateAgentConfigInternal(agents(&ModelRoutingConfig{
+		Tiers: tiers, Rules: []ModelRoutingRule{{Purpose: "verification", Tier: "cheap"}},
+	}), nil); err != nil {
+		t.Fatalf("a valid policy was refused: %v", err)
+	}
+
+	tests := map[string]struct {
+		routing *ModelRoutingConfig
+		want    string
+	}{
+		"undefined tier": {&ModelRoutingConfig{Tiers: tiers, Rules: []ModelRoutingRule{{Tier: "strong"}}}, "not defined"},
+		"unknown role":   {&ModelRoutingConfig{Tiers: tiers, Rules: []ModelRoutingRule{{Role: "qa", Tier: "cheap"}}}, "unknown role"},
+		"unmapped model": {&ModelRoutingConfig{Tiers: map[string]string{"cheap": "not-a-model"}}, "routing.tiers.cheap"},
+		"reserved tier":  {&ModelRoutingConfig{Tiers: map[string]string{RoutingTierDefault: "claude-haiku-4-5"}}, "reserved"},
+	}
+	for name, tc := range tests {
+		err := validateAgentConfigInternal(agents(tc.routing), nil)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want one mentioning %q", name, err, tc.want)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"slices"
+	"strings"
 
 	"orchestrator/pkg/logx"
 )
@@ -13,6 +18,12 @@ const (
 	EnvGoogleSearchAPIKey = "GOOGLE_SEARCH_API_KEY"
 	// EnvGoogleSearchCX is the environment variable for Google Custom Search Engine ID.
 	EnvGoogleSearchCX = "GOOGLE_SEARCH_CX"
+	// EnvBraveSearchAPIKey is the environment variable for the Brave Search API subscription token.
+	EnvBraveSearchAPIKey = "BRAVE_SEARCH_API_KEY"
+	// EnvBingSearchAPIKey is the environment variable for the Bing Web Search subscription key.
+	EnvBingSearchAPIKey = "BING_SEARCH_API_KEY"
+	// EnvSearXNGURL is the environment variable for a SearXNG instance's base URL.
+	EnvSearXNGURL = "SEARXNG_URL"
 )
 
 // SearchProviderType identifies which search provider is available.
@@ -20,41 +31,109 @@ type SearchProviderType string
 
 // Search provider type constants.
 const (
-	SearchProviderNone   SearchProviderType = ""
-	SearchProviderGoogle SearchProviderType = "google"
+	SearchProviderNone    SearchProviderType = ""
+	SearchProviderGoogle  SearchProviderType = "google"
+	SearchProviderBrave   SearchProviderType = "brave"
+	SearchProviderBing    SearchProviderType = "bing"
+	SearchProviderSearXNG SearchProviderType = "searxng"
 )
 
+// searchProviderOrder is the auto-detection priority. SearXNG is last: it is
+// the one a team runs itself, so an instance that happens to be configured
+// should not win over a hosted provider someone is paying for, unless
+// search.provider says so.
+var searchProviderOrder = []SearchProviderType{ //nolint:gochecknoglobals // read-only priority list
+	SearchProviderGoogle, SearchProviderBrave, SearchProviderBing, SearchProviderSearXNG,
+}
+
 // SearchAPIStatus contains information about available search APIs.
 type SearchAPIStatus struct {
 	Available    bool               // Whether any search API is available
 	Provider     SearchProviderType // Which provider is available (empty if none)
 	GoogleAPIKey string             // Google API key (if available)
 	GoogleCX     string             // Google Custom Search Engine ID (if available)
+	BraveAPIKey  string             // Brave Search subscription token (if available)
+	BingAPIKey   string             // Bing Web Search subscription key (if available)
+	SearXNGURL   string             // SearXNG base URL (if available)
 }
 
-// DetectSearchAPIs checks environment variables and returns status of available search APIs.
+// DetectSearchAPIs checks environment variables and the loaded config and
+// returns status of available search APIs.
 // This function is idempotent and can be called multiple times.
 func DetectSearchAPIs() SearchAPIStatus {
-	status := SearchAPIStatus{}
-
-	// Check Google Custom Search (highest priority)
-	googleAPIKey := os.Getenv(EnvGoogleSearchAPIKey)
-	googleCX := os.Getenv(EnvGoogleSearchCX)
-	if googleAPIKey != "" && googleCX != "" {
-		status.Available = true
-		status.Provider = SearchProviderGoogle
-		status.GoogleAPIKey = googleAPIKey
-		status.GoogleCX = googleCX
-		return status
+	mu.RLock()
+	var search *SearchConfig
+	var airplane bool
+	if config != nil {
+		search, airplane = config.Search, config.OperatingMode == OperatingModeAirplane
 	}
+	mu.RUnlock()
+	return detectSearchAPIs(search, airplane)
+}
 
-	// Future: Check other providers here in priority order
-	// braveKey := os.Getenv(EnvBraveSearchAPIKey)
-	// if braveKey != "" { ... }
+// detectSearchAPIs picks the provider search settles on: the pinned one if
+// search.provider names one, else the first in searchProviderOrder whose
+// credentials are present.
+//
+// Airplane mode considers SearXNG only. The hosted providers are not
+// reachable offline, and offering web_search backed by one would hand the
+// agents a tool that fails on every call.
+func detectSearchAPIs(search *SearchConfig, airplane bool) SearchAPIStatus {
+	status := SearchAPIStatus{
+		GoogleAPIKey: os.Getenv(EnvGoogleSearchAPIKey),
+		GoogleCX:     os.Getenv(EnvGoogleSearchCX),
+		BraveAPIKey:  os.Getenv(EnvBraveSearchAPIKey),
+		BingAPIKey:   os.Getenv(EnvBingSearchAPIKey),
+		SearXNGURL:   os.Getenv(EnvSearXNGURL),
+	}
+	candidates := searchProviderOrder
+	if search != nil {
+		if search.SearXNGURL != "" {
+			status.SearXNGURL = search.SearXNGURL
+		}
+		if search.Provider != SearchProviderNone {
+			candidates = []SearchProviderType{search.Provider}
+		}
+	}
 
+	for _, provider := range candidates {
+		if airplane && provider != SearchProviderSearXNG {
+			continue
+		}
+		if status.hasCredentials(provider) {
+			status.Available, status.Provider = true, provider
+			break
+		}
+	}
 	return status
 }
 
+// hasCredentials reports whether status carries what provider needs.
+func (s *SearchAPIStatus) hasCredentials(provider SearchProviderType) bool {
+	switch provider {
+	case SearchProviderGoogle:
+		return s.GoogleAPIKey != "" && s.GoogleCX != ""
+	case SearchProviderBrave:
+		return s.BraveAPIKey != ""
+	case SearchProviderBing:
+		return s.BingAPIKey != ""
+	case SearchProviderSearXNG:
+		return s.SearXNGURL != ""
+	default:
+		return false
+	}
+}
+
+// searchSetupHint says how to make a provider available, for the warnings
+// IsSearchEnabled logs.
+func searchSetupHint(airplane bool) string {
+	if airplane {
+		return fmt.Sprintf("airplane mode searches through SearXNG only; set search.searxng_url or %s", EnvSearXNGURL)
+	}
+	return fmt.Sprintf("set %s and %s, %s, %s, or search.searxng_url / %s",
+		EnvGoogleSearchAPIKey, EnvGoogleSearchCX, EnvBraveSearchAPIKey, EnvBingSearchAPIKey, EnvSearXNGURL)
+}
+
 // IsSearchEnabled determines if web search should be enabled based on config and API availability.
 // Returns true if:
 //   - Config explicitly enables search (search.enabled = true), OR
@@ -73,32 +152,28 @@ func IsSearchEnabled(cfg *Config) bool {
 		// No config - check if APIs are available
 		status := DetectSearchAPIs()
 		if !status.Available {
-			logger.Warn("Web search disabled: no search API keys found. Set %s and %s to enable.",
-				EnvGoogleSearchAPIKey, EnvGoogleSearchCX)
+			logger.Warn("Web search disabled: no search provider found; %s.", searchSetupHint(IsAirplaneMode()))
 		}
 		return status.Available
 	}
 
+	airplane := cfg.OperatingMode == OperatingModeAirplane
+	status := detectSearchAPIs(cfg.Search, airplane)
+
 	// If explicitly configured, respect that setting
 	if cfg.Search.Enabled != nil {
 		enabled := *cfg.Search.Enabled
-		if enabled {
-			// User wants search enabled - verify we have APIs
-			status := DetectSearchAPIs()
-			if !status.Available {
-				logger.Warn("Web search enabled in config but no API keys found. Set %s and %s.",
-					EnvGoogleSearchAPIKey, EnvGoogleSearchCX)
-				return false
-			}
+		if enabled && !status.Available {
+			// User wants search enabled - but there is nothing to search with
+			logger.Warn("Web search enabled in config but no search provider found; %s.", searchSetupHint(airplane))
+			return false
 		}
 		return enabled
 	}
 
 	// Auto-detect based on API availability
-	status := DetectSearchAPIs()
 	if !status.Available {
-		logger.Warn("Web search disabled: no search API keys found. Set %s and %s to enable.",
-			EnvGoogleSearchAPIKey, EnvGoogleSearchCX)
+		logger.Warn("Web search disabled: no search provider found; %s.", searchSetupHint(airplane))
 	}
 	return status.Available
 }
@@ -108,3 +183,42 @@ func IsSearchEnabled(cfg *Config) bool {
 func GetSearchProvider() SearchProviderType {
 	return DetectSearchAPIs().Provider
 }
+
+// validateSearchConfig checks the search settings' shape. Whether the named
+// provider has credentials is not checked: search degrades to disabled
+// without them, as it always has.
+func validateSearchConfig(search *SearchConfig) error {
+	if search == nil {
+		return nil
+	}
+	switch search.Provider {
+	case SearchProviderNone, SearchProviderGoogle, SearchProviderBrave, SearchProviderBing, SearchProviderSearXNG:
+	default:
+		return fmt.Errorf("search.provider must be one of google, brave, bing or searxng, got %q", search.Provider)
+	}
+	if search.SearXNGURL != "" {
+		parsed, err := url.Parse(search.SearXNGURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("search.searxng_url must be an http(s) URL, got %q", search.SearXNGURL)
+		}
+	}
+	if search.MaxQueriesPerSession < 0 {
+		return fmt.Errorf("search.max_queries_per_session must not be negative, got %d", search.MaxQueriesPerSession)
+	}
+	for _, list := range []struct {
+		name    string
+		domains []string
+	}{{"allow_domains", search.AllowDomains}, {"deny_domains", search.DenyDomains}} {
+		for _, domain := range list.domains {
+			if domain == "" || strings.ContainsAny(domain, "/:* ") {
+				return fmt.Errorf("search.%s entry %q must be a bare domain such as example.com", list.name, domain)
+			}
+		}
+	}
+	for _, dir := range search.DocsDirs {
+		if strings.TrimSpace(dir) == "" || slices.Contains(strings.Split(path.Clean(dir), "/"), "..") {
+			return fmt.Errorf("search.docs_dirs entry %q must be a directory without '..' components", dir)
+		}
+	}
+	return nil
+}
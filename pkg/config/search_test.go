@@ -187,3 +187,64 @@ func TestGetSearchProvider(t *testing.T) {
 		t.Errorf("Expected provider=SearchProviderGoogle, got %q", provider)
 	}
 }
+
+func TestDetectSearchAPIs_ProviderSelection(t *testing.T) {
+	clearEnv := func() {
+		for _, env := range []string{EnvGoogleSearchAPIKey, EnvGoogleSearchCX, EnvBraveSearchAPIKey, EnvBingSearchAPIKey, EnvSearXNGURL} {
+			t.Setenv(env, "")
+		}
+	}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		search   *SearchConfig
+		airplane bool
+		want     SearchProviderType
+	}{
+		{"nothing configured", nil, nil, false, SearchProviderNone},
+		{"brave over bing", map[string]string{EnvBraveSearchAPIKey: "b", EnvBingSearchAPIKey: "m"}, nil, false, SearchProviderBrave},
+		{"hosted over searxng", map[string]string{EnvBingSearchAPIKey: "m", EnvSearXNGURL: "http://searx:8080"}, nil, false, SearchProviderBing},
+		{"searxng from config", nil, &SearchConfig{SearXNGURL: "http://searx:8080"}, false, SearchProviderSearXNG},
+		{"pinned provider", map[string]string{EnvBraveSearchAPIKey: "b", EnvBingSearchAPIKey: "m"}, &SearchConfig{Provider: SearchProviderBing}, false, SearchProviderBing},
+		{"pinned provider without credentials", map[string]string{EnvBraveSearchAPIKey: "b"}, &SearchConfig{Provider: SearchProviderBing}, false, SearchProviderNone},
+		{"airplane skips hosted", map[string]string{EnvBraveSearchAPIKey: "b", EnvSearXNGURL: "http://localhost:8888"}, nil, true, SearchProviderSearXNG},
+		{"airplane without searxng", map[string]string{EnvBraveSearchAPIKey: "b"}, nil, true, SearchProviderNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv()
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			status := detectSearchAPIs(tt.search, tt.airplane)
+			if status.Provider != tt.want || status.Available != (tt.want != SearchProviderNone) {
+				t.Errorf("got provider %q (available=%v), want %q", status.Provider, status.Available, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateSearchConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		search  *SearchConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"full", &SearchConfig{Provider: SearchProviderSearXNG, SearXNGURL: "https://searx.internal", MaxQueriesPerSession: 50,
+			AllowDomains: []string{"go.dev"}, DenyDomains: []string{"ads.example.com"}}, false},
+		{"unknown provider", &SearchConfig{Provider: "yahoo"}, true},
+		{"searxng url without scheme", &SearchConfig{SearXNGURL: "searx.internal"}, true},
+		{"negative quota", &SearchConfig{MaxQueriesPerSession: -1}, true},
+		{"domain with path", &SearchConfig{AllowDomains: []string{"go.dev/blog"}}, true},
+		{"wildcard domain", &SearchConfig{DenyDomains: []string{"*.example.com"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSearchConfig(tt.search); (err != nil) != tt.wantErr {
+				t.Errorf("validateSearchConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
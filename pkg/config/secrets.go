@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
 
 	"golang.org/x/crypto/scrypt"
@@ -32,6 +33,30 @@ var systemSecretNames = map[string]bool{
 	"GOOGLE_GENAI_API_KEY": true,
 	"GITHUB_TOKEN":         true,
 	"SSL_KEY_PEM":          true,
+	SigningKeySecret:       true,
+}
+
+// SigningKeySecret holds the private key coders sign commits with (git.commit_signing).
+// A per-agent key can be stored as SigningKeySecret + "_" + agent ID, e.g. GIT_SIGNING_KEY_CODER_001.
+const SigningKeySecret = "GIT_SIGNING_KEY"
+
+// isSystemSecretName reports whether name is an allowed system secret name.
+func isSystemSecretName(name string) bool {
+	return systemSecretNames[name] || strings.HasPrefix(name, SigningKeySecret+"_")
+}
+
+// signingKeySecretName returns the per-agent signing key secret name of an agent.
+func signingKeySecretName(agentID string) string {
+	return SigningKeySecret + "_" + strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(agentID))
+}
+
+// GetSigningKey returns the commit signing key of an agent: its own key when
+// one is stored, otherwise the shared key.
+func GetSigningKey(agentID string) (string, error) {
+	if key, err := GetSystemSecret(signingKeySecretName(agentID)); err == nil {
+		return key, nil
+	}
+	return GetSystemSecret(SigningKeySecret)
 }
 
 // StructuredSecrets separates system (Maestro operational) from user (app-injected) secrets.
@@ -203,8 +228,8 @@ func SetSecret(name, value string, secretType SecretType) error {
 	}
 
 	if secretType == SecretTypeSystem {
-		if !systemSecretNames[name] {
-			return fmt.Errorf("unknown system secret name %q (allowed: ANTHROPIC_API_KEY, OPENAI_API_KEY, GOOGLE_GENAI_API_KEY, GITHUB_TOKEN, SSL_KEY_PEM)", name)
+		if !isSystemSecretName(name) {
+			return fmt.Errorf("unknown system secret name %q (allowed: ANTHROPIC_API_KEY, OPENAI_API_KEY, GOOGLE_GENAI_API_KEY, GITHUB_TOKEN, SSL_KEY_PEM, GIT_SIGNING_KEY[_<AGENT_ID>])", name)
 		}
 	}
 
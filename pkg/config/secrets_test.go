@@ -283,6 +283,29 @@ func TestGetSystemSecretMaestroPrefixPrecedence(t *testing.T) {
 	})
 }
 
+func TestGetSigningKey(t *testing.T) {
+	t.Cleanup(func() { SetDecryptedSecrets(nil) })
+	SetDecryptedSecrets(&StructuredSecrets{System: map[string]string{}})
+
+	if err := SetSecret("GIT_SIGNING_KEY", "shared-key", SecretTypeSystem); err != nil {
+		t.Fatalf("shared signing key should be an allowed system secret: %v", err)
+	}
+	if err := SetSecret("GIT_SIGNING_KEY_CODER_001", "coder-1-key", SecretTypeSystem); err != nil {
+		t.Fatalf("per-agent signing key should be an allowed system secret: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Unsetenv("GIT_SIGNING_KEY")
+		_ = os.Unsetenv("GIT_SIGNING_KEY_CODER_001")
+	})
+
+	if key, err := GetSigningKey("coder-001"); err != nil || key != "coder-1-key" {
+		t.Errorf("expected the agent's own key, got %q (err: %v)", key, err)
+	}
+	if key, err := GetSigningKey("coder-002"); err != nil || key != "shared-key" {
+		t.Errorf("expected the shared key as fallback, got %q (err: %v)", key, err)
+	}
+}
+
 func TestProjectPasswordMemory(t *testing.T) {
 	// Clear any existing password
 	ClearProjectPassword()
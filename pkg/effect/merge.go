@@ -11,11 +11,12 @@ import (
 
 // MergeEffect represents a merge request effect that blocks until architect responds with merge result.
 type MergeEffect struct {
-	StoryID     string        // The story ID for the merge request
-	PRUrl       string        // The pull request URL to merge
-	BranchName  string        // The branch name to merge
-	TargetAgent string        // Target agent (typically "architect")
-	Timeout     time.Duration // Timeout for waiting for response
+	StoryID     string            // The story ID for the merge request
+	PRUrl       string            // The pull request URL to merge
+	BranchName  string            // The branch name to merge
+	TargetAgent string            // Target agent (typically "architect")
+	Timeout     time.Duration     // Timeout for waiting for response
+	Metadata    map[string]string // Optional merge metadata (e.g. verification results)
 }
 
 // Execute sends a merge request and blocks waiting for the architect's response.
@@ -30,6 +31,7 @@ func (e *MergeEffect) Execute(ctx context.Context, runtime Runtime) (any, error)
 		StoryID:    e.StoryID,
 		BranchName: e.BranchName,
 		PRURL:      e.PRUrl,
+		Metadata:   e.Metadata,
 	}
 
 	// Set typed payload
@@ -84,9 +86,10 @@ func (e *MergeEffect) Execute(ctx context.Context, runtime Runtime) (any, error)
 	}
 
 	result := &git.MergeResult{
-		Status:       mergeResponse.Status,
-		ConflictInfo: mergeResponse.ConflictDetails,
-		MergeCommit:  mergeResponse.MergeCommit,
+		Status:          mergeResponse.Status,
+		ConflictInfo:    mergeResponse.ConflictDetails,
+		MergeCommit:     mergeResponse.MergeCommit,
+		FailureFeedback: mergeResponse.Metadata[proto.KeyMergeFailureFeedback],
 	}
 
 	runtime.Info("📥 Received merge response: %s", result.Status)
@@ -4,6 +4,8 @@ package forge
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -122,6 +124,172 @@ type MergeResult struct {
 	HasConflicts bool
 }
 
+// CommentMarker is embedded (as an HTML comment, invisible when rendered) in
+// every comment Maestro posts, so its own comments are not mistaken for review feedback.
+const CommentMarker = "<!-- maestro -->"
+
+// PRCommentKind distinguishes where a PR comment was left.
+type PRCommentKind string
+
+// PR comment kinds.
+const (
+	// PRCommentConversation is a comment on the PR's conversation thread.
+	PRCommentConversation PRCommentKind = "conversation"
+	// PRCommentReview is the body of a submitted review.
+	PRCommentReview PRCommentKind = "review"
+	// PRCommentLine is a review comment on a line of the diff.
+	PRCommentLine PRCommentKind = "line"
+)
+
+// PRComment is a comment left on a pull request.
+//
+//nolint:govet // Logical field grouping preferred over memory optimization
+type PRComment struct {
+	// ID is the provider's comment ID (unique per kind).
+	ID int64 `json:"id"`
+
+	// Kind is where the comment was left.
+	Kind PRCommentKind `json:"kind"`
+
+	// Author is the commenter's login.
+	Author string `json:"author"`
+
+	// Body is the comment text.
+	Body string `json:"body"`
+
+	// Path and Line locate a line comment in the diff.
+	Path string `json:"path,omitempty"`
+	Line int    `json:"line,omitempty"`
+
+	// URL is the web URL for the comment.
+	URL string `json:"url,omitempty"`
+
+	// CreatedAt is when the comment was posted.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Key identifies the comment across kinds.
+func (c *PRComment) Key() string {
+	return fmt.Sprintf("%s-%d", c.Kind, c.ID)
+}
+
+// FromMaestro reports whether Maestro posted the comment.
+func (c *PRComment) FromMaestro() bool {
+	return strings.Contains(c.Body, CommentMarker)
+}
+
+// CheckState is the state of a CI check, or the combined state of all checks on a commit.
+type CheckState string
+
+// Check states.
+const (
+	// CheckStateNone means the commit has no checks.
+	CheckStateNone CheckState = "none"
+	// CheckStatePending means a check is queued or running.
+	CheckStatePending CheckState = "pending"
+	// CheckStateSuccess means the check passed (or was neutral or skipped).
+	CheckStateSuccess CheckState = "success"
+	// CheckStateFailure means the check failed, errored, timed out or was cancelled.
+	CheckStateFailure CheckState = "failure"
+)
+
+// Check is one CI check run or commit status on a PR head.
+//
+//nolint:govet // Logical field grouping preferred over memory optimization
+type Check struct {
+	// Name is the check run name or status context.
+	Name string `json:"name"`
+
+	// State is the normalized check state.
+	State CheckState `json:"state"`
+
+	// URL is the web URL for the check's details.
+	URL string `json:"url,omitempty"`
+
+	// Summary is the provider's short description of the result.
+	Summary string `json:"summary,omitempty"`
+
+	// ID identifies the check for log retrieval (GitHub Actions job ID); empty when logs are not available.
+	ID string `json:"id,omitempty"`
+}
+
+// CheckStatus is the combined CI status of a PR head commit.
+type CheckStatus struct {
+	// SHA is the head commit the checks ran on.
+	SHA string `json:"sha"`
+
+	// State is the combined state: failure if any check failed, otherwise
+	// pending if any is still running, success once all passed, none without checks.
+	State CheckState `json:"state"`
+
+	// Checks are the individual checks.
+	Checks []Check `json:"checks,omitempty"`
+}
+
+// Failed returns the checks that failed.
+func (s *CheckStatus) Failed() []Check {
+	var failed []Check
+	for i := range s.Checks {
+		if s.Checks[i].State == CheckStateFailure {
+			failed = append(failed, s.Checks[i])
+		}
+	}
+	return failed
+}
+
+// CombineCheckStates returns the combined state of checks.
+func CombineCheckStates(checks []Check) CheckState {
+	if len(checks) == 0 {
+		return CheckStateNone
+	}
+	state := CheckStateSuccess
+	for i := range checks {
+		switch checks[i].State {
+		case CheckStateFailure:
+			return CheckStateFailure
+		case CheckStatePending:
+			state = CheckStatePending
+		}
+	}
+	return state
+}
+
+// Issue represents an issue from any forge provider.
+//
+//nolint:govet // Logical field grouping preferred over memory optimization
+type Issue struct {
+	// Number is the issue number/index.
+	Number int `json:"number"`
+
+	// URL is the web URL for the issue.
+	URL string `json:"url"`
+
+	// Title is the issue title.
+	Title string `json:"title"`
+
+	// Body is the issue description.
+	Body string `json:"body"`
+
+	// State is the issue state (open, closed).
+	State string `json:"state"`
+
+	// Author is the login of the user who opened the issue.
+	Author string `json:"author"`
+
+	// Labels are the issue's label names.
+	Labels []string `json:"labels,omitempty"`
+
+	// Comments are the issue's comments, oldest first.
+	Comments []IssueComment `json:"comments,omitempty"`
+}
+
+// IssueComment is a comment on an issue.
+type IssueComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+}
+
 // Client defines the interface for forge operations.
 // Both GitHub and Gitea clients implement this interface.
 type Client interface {
@@ -154,8 +322,49 @@ type Client interface {
 	// ClosePR closes a pull request without merging.
 	ClosePR(ctx context.Context, ref string) error
 
+	// UpdatePRBase changes the branch a pull request merges into.
+	UpdatePRBase(ctx context.Context, ref, base string) error
+
+	// ListPRComments returns a PR's conversation comments, review bodies and line comments, oldest first.
+	ListPRComments(ctx context.Context, ref string) ([]PRComment, error)
+
+	// ReplyToPRComment replies to a comment, marked as Maestro's: in its thread
+	// for line comments, otherwise on the PR conversation quoting it.
+	ReplyToPRComment(ctx context.Context, ref string, comment PRComment, body string) error
+
+	// GetPRChecks returns the combined CI check status of a PR's head commit.
+	GetPRChecks(ctx context.Context, ref string) (*CheckStatus, error)
+
+	// GetCheckLog returns the log of a failed check, or its summary when the
+	// provider has no log for it.
+	GetCheckLog(ctx context.Context, check Check) (string, error)
+
+	// Issue operations
+
+	// GetIssue retrieves an issue with its labels and comments.
+	GetIssue(ctx context.Context, number int) (*Issue, error)
+
+	// CommentOnIssue adds a comment to an issue.
+	CommentOnIssue(ctx context.Context, number int, body string) error
+
 	// Branch operations
 
 	// CleanupMergedBranches deletes branches that have been merged.
 	CleanupMergedBranches(ctx context.Context, target string, protectedPatterns []string) ([]string, error)
 }
+
+// QuoteReply formats a reply to a comment on the PR conversation, for
+// providers without threaded replies.
+func QuoteReply(comment PRComment, body string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s ", comment.Author)
+	if comment.Path != "" {
+		fmt.Fprintf(&b, "(`%s:%d`) ", comment.Path, comment.Line)
+	}
+	b.WriteString("wrote:\n")
+	for _, line := range strings.Split(strings.TrimSpace(comment.Body), "\n") {
+		b.WriteString("> " + line + "\n")
+	}
+	b.WriteString("\n" + body)
+	return b.String()
+}
@@ -15,6 +15,23 @@ func NewClient(projectDir string) (Client, error) {
 	return newGitHubClient()
 }
 
+// NewRepoClient creates the forge client for one repository of a multi-repo
+// product, by the name stories record ("" for the primary repository).
+// Further repositories are always on GitHub: Gitea hosts only the primary one.
+func NewRepoClient(projectDir, repo string) (Client, error) {
+	if repo == "" {
+		return NewClient(projectDir)
+	}
+	if config.GetForgeProvider() == config.ForgeProviderGitea {
+		return nil, fmt.Errorf("repository %s is not hosted on Gitea: airplane mode supports only the primary repository", repo)
+	}
+	repoURL, err := config.GetRepoURL(repo)
+	if err != nil {
+		return nil, err
+	}
+	return newGitHubRepoClient(repoURL)
+}
+
 // newGiteaClient creates a Gitea client from runtime state.
 // This is defined here as a function that will be implemented by the gitea package.
 // We use a function variable to allow the gitea package to register itself.
@@ -48,6 +65,13 @@ var newGitHubClient = func() (Client, error) {
 	return nil, fmt.Errorf("github client not yet integrated with forge.Client interface")
 }
 
+// newGitHubRepoClient creates a GitHub client for a repository URL.
+//
+//nolint:gochecknoglobals // Factory pattern requires global registration
+var newGitHubRepoClient = func(repoURL string) (Client, error) {
+	return nil, fmt.Errorf("github client not registered for %s", repoURL)
+}
+
 // RegisterGiteaClientFactory allows the gitea package to register its client factory.
 // This avoids import cycles between forge and gitea packages.
 func RegisterGiteaClientFactory(factory func(projectDir string) (Client, error)) {
@@ -58,3 +82,8 @@ func RegisterGiteaClientFactory(factory func(projectDir string) (Client, error))
 func RegisterGitHubClientFactory(factory func() (Client, error)) {
 	newGitHubClient = factory
 }
+
+// RegisterGitHubRepoClientFactory registers the GitHub client factory for further repositories.
+func RegisterGitHubRepoClientFactory(factory func(repoURL string) (Client, error)) {
+	newGitHubRepoClient = factory
+}
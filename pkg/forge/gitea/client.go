@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -164,6 +165,28 @@ func (c *Client) ListPRsForBranch(ctx context.Context, branch string) ([]forge.P
 	return result, nil
 }
 
+// openPRsPageSize is how many pull requests ListOpenPRs requests per page.
+const openPRsPageSize = 50
+
+// ListOpenPRs lists every open pull request in the repository, oldest first.
+func (c *Client) ListOpenPRs(ctx context.Context) ([]forge.PullRequest, error) {
+	var result []forge.PullRequest
+	for page := 1; ; page++ {
+		var giteaPRs []giteaPR
+		path := fmt.Sprintf("/repos/%s/%s/pulls?state=open&sort=oldest&limit=%d&page=%d",
+			c.owner, c.repo, openPRsPageSize, page)
+		if err := c.getJSON(ctx, path, &giteaPRs); err != nil {
+			return nil, fmt.Errorf("list open PRs failed: %w", err)
+		}
+		for i := range giteaPRs {
+			result = append(result, *convertPR(&giteaPRs[i]))
+		}
+		if len(giteaPRs) < openPRsPageSize {
+			return result, nil
+		}
+	}
+}
+
 // GetPR retrieves a pull request by number, PR URL, or branch name.
 func (c *Client) GetPR(ctx context.Context, ref string) (*forge.PullRequest, error) {
 	// Check if ref is a number
@@ -425,6 +448,281 @@ func (c *Client) ClosePR(ctx context.Context, ref string) error {
 	return nil
 }
 
+// UpdatePRBase changes the branch a pull request merges into.
+func (c *Client) UpdatePRBase(ctx context.Context, ref, base string) error {
+	pr, err := c.GetPR(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("failed to get PR: %w", err)
+	}
+
+	payload := map[string]interface{}{
+		"base": base,
+	}
+
+	path := fmt.Sprintf("/repos/%s/%s/pulls/%d", c.owner, c.repo, pr.Number)
+
+	resp, err := c.doRequest(ctx, http.MethodPatch, path, payload)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("update PR base failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	c.logger.Info("Retargeted PR #%d to %s", pr.Number, base)
+	return nil
+}
+
+// Gitea PR review API response structures.
+type giteaReview struct {
+	SubmittedAt time.Time `json:"submitted_at"`
+	User        giteaUser `json:"user"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"` // APPROVED, PENDING, COMMENT, REQUEST_CHANGES
+	HTMLURL     string    `json:"html_url"`
+	ID          int64     `json:"id"`
+}
+
+type giteaReviewComment struct {
+	CreatedAt        time.Time `json:"created_at"`
+	User             giteaUser `json:"user"`
+	Body             string    `json:"body"`
+	Path             string    `json:"path"`
+	HTMLURL          string    `json:"html_url"`
+	ID               int64     `json:"id"`
+	Position         int       `json:"position"`          // Line in the new file
+	OriginalPosition int       `json:"original_position"` // Line in the old file
+}
+
+// ListPRComments returns a PR's conversation comments, review bodies and line comments, oldest first.
+func (c *Client) ListPRComments(ctx context.Context, ref string) ([]forge.PRComment, error) {
+	pr, err := c.GetPR(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	base := fmt.Sprintf("/repos/%s/%s", c.owner, c.repo)
+
+	var conversation []struct {
+		giteaComment
+		HTMLURL string `json:"html_url"`
+		ID      int64  `json:"id"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/issues/%d/comments", base, pr.Number), &conversation); err != nil {
+		return nil, fmt.Errorf("get comments for PR #%d: %w", pr.Number, err)
+	}
+	var reviews []giteaReview
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/pulls/%d/reviews", base, pr.Number), &reviews); err != nil {
+		return nil, fmt.Errorf("get reviews for PR #%d: %w", pr.Number, err)
+	}
+
+	var comments []forge.PRComment
+	for i := range conversation {
+		comments = append(comments, forge.PRComment{
+			ID:        conversation[i].ID,
+			Kind:      forge.PRCommentConversation,
+			Author:    conversation[i].User.Login,
+			Body:      conversation[i].Body,
+			URL:       conversation[i].HTMLURL,
+			CreatedAt: conversation[i].CreatedAt,
+		})
+	}
+	for i := range reviews {
+		if reviews[i].State == "PENDING" {
+			continue
+		}
+		if reviews[i].Body != "" {
+			comments = append(comments, forge.PRComment{
+				ID:        reviews[i].ID,
+				Kind:      forge.PRCommentReview,
+				Author:    reviews[i].User.Login,
+				Body:      reviews[i].Body,
+				URL:       reviews[i].HTMLURL,
+				CreatedAt: reviews[i].SubmittedAt,
+			})
+		}
+
+		var lines []giteaReviewComment
+		if err := c.getJSON(ctx, fmt.Sprintf("%s/pulls/%d/reviews/%d/comments", base, pr.Number, reviews[i].ID), &lines); err != nil {
+			return nil, fmt.Errorf("get comments for review %d: %w", reviews[i].ID, err)
+		}
+		for j := range lines {
+			line := lines[j].Position
+			if line == 0 {
+				line = lines[j].OriginalPosition
+			}
+			comments = append(comments, forge.PRComment{
+				ID:        lines[j].ID,
+				Kind:      forge.PRCommentLine,
+				Author:    lines[j].User.Login,
+				Body:      lines[j].Body,
+				Path:      lines[j].Path,
+				Line:      line,
+				URL:       lines[j].HTMLURL,
+				CreatedAt: lines[j].CreatedAt,
+			})
+		}
+	}
+	slices.SortStableFunc(comments, func(a, b forge.PRComment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return comments, nil
+}
+
+// ReplyToPRComment quotes the comment on the PR conversation.
+// Gitea's API has no endpoint for replying in a line comment's thread.
+func (c *Client) ReplyToPRComment(ctx context.Context, ref string, comment forge.PRComment, body string) error {
+	pr, err := c.GetPR(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return c.CommentOnIssue(ctx, pr.Number, forge.QuoteReply(comment, body+"\n\n"+forge.CommentMarker))
+}
+
+// giteaCombinedStatus is Gitea's combined commit status response.
+type giteaCombinedStatus struct {
+	Statuses []struct {
+		Context     string `json:"context"`
+		Status      string `json:"status"` // pending, success, error, failure, warning
+		TargetURL   string `json:"target_url"`
+		Description string `json:"description"`
+	} `json:"statuses"`
+}
+
+// GetPRChecks returns the combined commit status of a PR's head.
+// Gitea Actions and external CI both report through commit statuses.
+func (c *Client) GetPRChecks(ctx context.Context, ref string) (*forge.CheckStatus, error) {
+	pr, err := c.GetPR(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var combined giteaCombinedStatus
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s/status", c.owner, c.repo, pr.HeadSHA), &combined); err != nil {
+		return nil, fmt.Errorf("get status for %s: %w", pr.HeadSHA, err)
+	}
+
+	checks := make([]forge.Check, 0, len(combined.Statuses))
+	for _, status := range combined.Statuses {
+		state := forge.CheckStateFailure
+		switch status.Status {
+		case "success", "warning":
+			state = forge.CheckStateSuccess
+		case "pending":
+			state = forge.CheckStatePending
+		}
+		checks = append(checks, forge.Check{
+			Name:    status.Context,
+			State:   state,
+			URL:     status.TargetURL,
+			Summary: status.Description,
+		})
+	}
+
+	return &forge.CheckStatus{SHA: pr.HeadSHA, State: forge.CombineCheckStates(checks), Checks: checks}, nil
+}
+
+// GetCheckLog returns the check's description and details link.
+// Gitea's API does not serve Actions job logs for commit statuses.
+func (c *Client) GetCheckLog(_ context.Context, check forge.Check) (string, error) {
+	if check.URL == "" {
+		return check.Summary, nil
+	}
+	return fmt.Sprintf("%s\nDetails: %s", check.Summary, check.URL), nil
+}
+
+// Gitea issue API response structures.
+type giteaUser struct {
+	Login string `json:"login"`
+}
+
+type giteaIssue struct {
+	Number  int       `json:"number"`
+	HTMLURL string    `json:"html_url"`
+	Title   string    `json:"title"`
+	Body    string    `json:"body"`
+	State   string    `json:"state"` // open, closed
+	User    giteaUser `json:"user"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+type giteaComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      giteaUser `json:"user"`
+	Body      string    `json:"body"`
+}
+
+// GetIssue retrieves an issue with its labels and comments.
+func (c *Client) GetIssue(ctx context.Context, number int) (*forge.Issue, error) {
+	var gi giteaIssue
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d", c.owner, c.repo, number), &gi); err != nil {
+		return nil, fmt.Errorf("get issue #%d: %w", number, err)
+	}
+
+	var comments []giteaComment
+	if err := c.getJSON(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", c.owner, c.repo, number), &comments); err != nil {
+		return nil, fmt.Errorf("get comments for issue #%d: %w", number, err)
+	}
+
+	issue := &forge.Issue{
+		Number: gi.Number,
+		URL:    gi.HTMLURL,
+		Title:  gi.Title,
+		Body:   gi.Body,
+		State:  gi.State,
+		Author: gi.User.Login,
+	}
+	for _, label := range gi.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for i := range comments {
+		issue.Comments = append(issue.Comments, forge.IssueComment{
+			CreatedAt: comments[i].CreatedAt,
+			Author:    comments[i].User.Login,
+			Body:      comments[i].Body,
+		})
+	}
+	return issue, nil
+}
+
+// CommentOnIssue adds a comment to an issue.
+func (c *Client) CommentOnIssue(ctx context.Context, number int, body string) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d/comments", c.owner, c.repo, number)
+
+	resp, err := c.doRequest(ctx, http.MethodPost, path, map[string]interface{}{"body": body})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("comment on issue #%d failed with status %d: %s", number, resp.StatusCode, string(respBody))
+	}
+	return nil
+}
+
+// getJSON performs a GET request and decodes a 200 response into result.
+func (c *Client) getJSON(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
 // CleanupMergedBranches deletes branches that have been merged.
 func (c *Client) CleanupMergedBranches(ctx context.Context, target string, protectedPatterns []string) ([]string, error) {
 	// List all branches
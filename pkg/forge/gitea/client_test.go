@@ -73,6 +73,39 @@ func TestListPRsForBranch(t *testing.T) {
 	}
 }
 
+// TestListOpenPRs tests listing open PRs across pages.
+func TestListOpenPRs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/maestro/myrepo/pulls" || r.URL.Query().Get("state") != "open" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var prs []giteaPR
+		if r.URL.Query().Get("page") == "1" {
+			for i := 1; i <= openPRsPageSize; i++ {
+				prs = append(prs, giteaPR{Number: i, Head: giteaRef{Ref: "story-a"}, Base: giteaRef{Ref: "main"}})
+			}
+		} else {
+			prs = []giteaPR{{Number: openPRsPageSize + 1, Title: "Last", Head: giteaRef{Ref: "story-b"}, Base: giteaRef{Ref: "main"}}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(prs)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+	prs, err := client.ListOpenPRs(context.Background())
+	if err != nil {
+		t.Fatalf("ListOpenPRs failed: %v", err)
+	}
+	if len(prs) != openPRsPageSize+1 {
+		t.Fatalf("Expected %d PRs, got %d", openPRsPageSize+1, len(prs))
+	}
+	if last := prs[len(prs)-1]; last.Title != "Last" || last.HeadBranch != "story-b" {
+		t.Errorf("Unexpected last PR: %+v", last)
+	}
+}
+
 // TestGetPRByNumber tests getting a PR by number.
 func TestGetPRByNumber(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -331,6 +364,38 @@ func TestClosePR(t *testing.T) {
 	}
 }
 
+// TestUpdatePRBase tests retargeting a PR.
+func TestUpdatePRBase(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/repos/maestro/myrepo/pulls/2" {
+			if r.Method == http.MethodGet {
+				pr := giteaPR{Number: 2, State: "open", Base: giteaRef{Ref: "story-1"}}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(pr)
+				return
+			}
+			if r.Method == http.MethodPatch {
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				if body["base"] != "main" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+
+	if err := client.UpdatePRBase(context.Background(), "2", "main"); err != nil {
+		t.Fatalf("UpdatePRBase failed: %v", err)
+	}
+}
+
 // TestGetOrCreatePR_Existing tests getting existing PR.
 func TestGetOrCreatePR_Existing(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -452,3 +517,146 @@ func TestPRNumberFromURL(t *testing.T) {
 		}
 	}
 }
+
+// TestGetIssue tests getting an issue with its labels and comments.
+func TestGetIssue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/repos/maestro/myrepo/issues/7":
+			_, _ = w.Write([]byte(`{"number":7,"html_url":"http://localhost:3000/maestro/myrepo/issues/7","title":"Export to CSV","body":"Users want CSV.","state":"open","user":{"login":"alice"},"labels":[{"name":"feature"}]}`))
+		case "/api/v1/repos/maestro/myrepo/issues/7/comments":
+			_, _ = w.Write([]byte(`[{"created_at":"2025-01-02T03:04:05Z","user":{"login":"bob"},"body":"Include headers."}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+	issue, err := client.GetIssue(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetIssue failed: %v", err)
+	}
+	if issue.Title != "Export to CSV" || issue.Author != "alice" || issue.State != "open" {
+		t.Errorf("Unexpected issue: %+v", issue)
+	}
+	if len(issue.Labels) != 1 || issue.Labels[0] != "feature" {
+		t.Errorf("Expected label 'feature', got %v", issue.Labels)
+	}
+	if len(issue.Comments) != 1 || issue.Comments[0].Author != "bob" || issue.Comments[0].CreatedAt.IsZero() {
+		t.Errorf("Unexpected comments: %+v", issue.Comments)
+	}
+
+	if _, err := client.GetIssue(context.Background(), 8); err == nil {
+		t.Error("GetIssue should fail for nonexistent issue")
+	}
+}
+
+// TestCommentOnIssue tests commenting on an issue.
+func TestCommentOnIssue(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/api/v1/repos/maestro/myrepo/issues/7/comments" {
+			var req map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			gotBody = req["body"]
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":1}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+	if err := client.CommentOnIssue(context.Background(), 7, "Story merged"); err != nil {
+		t.Fatalf("CommentOnIssue failed: %v", err)
+	}
+	if gotBody != "Story merged" {
+		t.Errorf("Expected comment body 'Story merged', got %q", gotBody)
+	}
+	if err := client.CommentOnIssue(context.Background(), 8, "x"); err == nil {
+		t.Error("CommentOnIssue should fail for nonexistent issue")
+	}
+}
+
+// TestGetPRChecks tests combining the PR head's commit statuses.
+func TestGetPRChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/maestro/myrepo/pulls/1":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(giteaPR{Number: 1, Head: giteaRef{Ref: "feature-branch", SHA: "abc123"}})
+		case "/api/v1/repos/maestro/myrepo/commits/abc123/status":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"state":"failure","statuses":[
+				{"context":"ci/build","status":"success"},
+				{"context":"ci/test","status":"failure","description":"2 tests failed","target_url":"http://localhost:3000/maestro/myrepo/actions/runs/7"},
+				{"context":"ci/lint","status":"pending"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+	ctx := context.Background()
+
+	status, err := client.GetPRChecks(ctx, "1")
+	if err != nil {
+		t.Fatalf("GetPRChecks failed: %v", err)
+	}
+	if status.SHA != "abc123" || status.State != forge.CheckStateFailure {
+		t.Errorf("Expected failure on abc123, got %s on %s", status.State, status.SHA)
+	}
+	failed := status.Failed()
+	if len(failed) != 1 || failed[0].Name != "ci/test" {
+		t.Fatalf("Expected ci/test to fail, got %+v", failed)
+	}
+
+	log, err := client.GetCheckLog(ctx, failed[0])
+	if err != nil {
+		t.Fatalf("GetCheckLog failed: %v", err)
+	}
+	if log != "2 tests failed\nDetails: http://localhost:3000/maestro/myrepo/actions/runs/7" {
+		t.Errorf("Unexpected check log: %q", log)
+	}
+}
+
+// TestListPRComments tests merging conversation, review and line comments in order.
+func TestListPRComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/repos/maestro/myrepo/pulls/1":
+			_ = json.NewEncoder(w).Encode(giteaPR{Number: 1, Head: giteaRef{Ref: "feature-branch", SHA: "abc123"}})
+		case "/api/v1/repos/maestro/myrepo/issues/1/comments":
+			_, _ = w.Write([]byte(`[{"id":10,"body":"Looks close","user":{"login":"alice"},"created_at":"2024-01-01T10:00:00Z"}]`))
+		case "/api/v1/repos/maestro/myrepo/pulls/1/reviews":
+			_, _ = w.Write([]byte(`[
+				{"id":20,"body":"Needs work","state":"REQUEST_CHANGES","user":{"login":"bob"},"submitted_at":"2024-01-01T09:00:00Z"},
+				{"id":21,"body":"draft","state":"PENDING","user":{"login":"bob"}}]`))
+		case "/api/v1/repos/maestro/myrepo/pulls/1/reviews/20/comments":
+			_, _ = w.Write([]byte(`[{"id":30,"body":"Check for nil","path":"main.go","position":0,"original_position":14,"user":{"login":"bob"},"created_at":"2024-01-01T09:00:01Z"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", "maestro", "myrepo")
+	comments, err := client.ListPRComments(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("ListPRComments failed: %v", err)
+	}
+	if len(comments) != 3 {
+		t.Fatalf("Expected 3 comments, got %+v", comments)
+	}
+	if comments[0].Key() != "review-20" || comments[1].Key() != "line-30" || comments[2].Key() != "conversation-10" {
+		t.Errorf("Unexpected comment order: %s, %s, %s", comments[0].Key(), comments[1].Key(), comments[2].Key())
+	}
+	if comments[1].Path != "main.go" || comments[1].Line != 14 || comments[1].Author != "bob" {
+		t.Errorf("Unexpected line comment: %+v", comments[1])
+	}
+}
@@ -5,6 +5,9 @@ package github
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"orchestrator/pkg/config"
@@ -33,7 +36,13 @@ func NewClientFromConfig() (forge.Client, error) {
 		return nil, fmt.Errorf("git repo_url not configured")
 	}
 
-	ghClient, err := github.NewClientFromRemote(cfg.Git.RepoURL)
+	return NewClientForRepo(cfg.Git.RepoURL)
+}
+
+// NewClientForRepo creates a GitHub forge client for a repository URL, such as
+// a further repository of a multi-repo product.
+func NewClientForRepo(repoURL string) (forge.Client, error) {
+	ghClient, err := github.NewClientFromRemote(repoURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GitHub client: %w", err)
 	}
@@ -141,11 +150,213 @@ func (c *Client) ClosePR(ctx context.Context, ref string) error {
 	return c.ghClient.ClosePR(ctx, ref)
 }
 
+// UpdatePRBase changes the branch a pull request merges into.
+func (c *Client) UpdatePRBase(ctx context.Context, ref, base string) error {
+	return c.ghClient.UpdatePRBase(ctx, ref, base)
+}
+
+// ListPRComments returns a PR's conversation comments, review bodies and line comments, oldest first.
+func (c *Client) ListPRComments(ctx context.Context, ref string) ([]forge.PRComment, error) {
+	pr, err := c.ghClient.GetPR(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := c.ghClient.ListIssueComments(ctx, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	reviews, err := c.ghClient.ListReviews(ctx, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := c.ghClient.ListReviewComments(ctx, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]forge.PRComment, 0, len(conversation)+len(reviews)+len(lines))
+	for i := range conversation {
+		comments = append(comments, forge.PRComment{
+			ID:        conversation[i].ID,
+			Kind:      forge.PRCommentConversation,
+			Author:    conversation[i].User.Login,
+			Body:      conversation[i].Body,
+			URL:       conversation[i].HTMLURL,
+			CreatedAt: conversation[i].CreatedAt,
+		})
+	}
+	for i := range reviews {
+		// Reviews without a body are only approvals or carriers for line comments
+		if reviews[i].Body == "" || reviews[i].State == "PENDING" {
+			continue
+		}
+		comments = append(comments, forge.PRComment{
+			ID:        reviews[i].ID,
+			Kind:      forge.PRCommentReview,
+			Author:    reviews[i].User.Login,
+			Body:      reviews[i].Body,
+			URL:       reviews[i].HTMLURL,
+			CreatedAt: reviews[i].SubmittedAt,
+		})
+	}
+	for i := range lines {
+		line := lines[i].Line
+		if line == 0 {
+			line = lines[i].OriginalLine
+		}
+		comments = append(comments, forge.PRComment{
+			ID:        lines[i].ID,
+			Kind:      forge.PRCommentLine,
+			Author:    lines[i].User.Login,
+			Body:      lines[i].Body,
+			Path:      lines[i].Path,
+			Line:      line,
+			URL:       lines[i].HTMLURL,
+			CreatedAt: lines[i].CreatedAt,
+		})
+	}
+	slices.SortStableFunc(comments, func(a, b forge.PRComment) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return comments, nil
+}
+
+// ReplyToPRComment replies in a line comment's thread, or quotes other comments on the conversation.
+func (c *Client) ReplyToPRComment(ctx context.Context, ref string, comment forge.PRComment, body string) error {
+	body += "\n\n" + forge.CommentMarker
+	if comment.Kind != forge.PRCommentLine {
+		return c.ghClient.CommentOnPR(ctx, ref, forge.QuoteReply(comment, body))
+	}
+	pr, err := c.ghClient.GetPR(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return c.ghClient.ReplyToReviewComment(ctx, pr.Number, comment.ID, body)
+}
+
+// GetPRChecks returns the combined check run and commit status state of a PR's head.
+func (c *Client) GetPRChecks(ctx context.Context, ref string) (*forge.CheckStatus, error) {
+	pr, err := c.ghClient.GetPR(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := c.ghClient.ListCheckRuns(ctx, pr.HeadRefOid)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := c.ghClient.ListCommitStatuses(ctx, pr.HeadRefOid)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]forge.Check, 0, len(runs)+len(statuses))
+	for i := range runs {
+		check := forge.Check{
+			Name:    runs[i].Name,
+			State:   checkRunState(&runs[i]),
+			URL:     runs[i].HTMLURL,
+			Summary: runs[i].Output.Title,
+		}
+		// Only GitHub Actions check runs are jobs with downloadable logs
+		if runs[i].App.Slug == "github-actions" {
+			check.ID = strconv.FormatInt(runs[i].ID, 10)
+		}
+		if check.Summary == "" {
+			check.Summary = runs[i].Output.Summary
+		}
+		checks = append(checks, check)
+	}
+	for i := range statuses {
+		checks = append(checks, forge.Check{
+			Name:    statuses[i].Context,
+			State:   commitStatusState(statuses[i].State),
+			URL:     statuses[i].TargetURL,
+			Summary: statuses[i].Description,
+		})
+	}
+
+	return &forge.CheckStatus{SHA: pr.HeadRefOid, State: forge.CombineCheckStates(checks), Checks: checks}, nil
+}
+
+// GetCheckLog returns the job log of a failed GitHub Actions check.
+func (c *Client) GetCheckLog(ctx context.Context, check forge.Check) (string, error) {
+	if check.ID == "" {
+		return check.Summary, nil
+	}
+	jobID, err := strconv.ParseInt(check.ID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid job ID %q: %w", check.ID, err)
+	}
+	return c.ghClient.GetJobLog(ctx, jobID)
+}
+
+// checkRunState normalizes a check run's status and conclusion.
+func checkRunState(run *github.CheckRun) forge.CheckState {
+	if run.Status != "completed" {
+		return forge.CheckStatePending
+	}
+	switch run.Conclusion {
+	case "success", "neutral", "skipped":
+		return forge.CheckStateSuccess
+	default:
+		return forge.CheckStateFailure
+	}
+}
+
+// commitStatusState normalizes a commit status state.
+func commitStatusState(state string) forge.CheckState {
+	switch state {
+	case "success":
+		return forge.CheckStateSuccess
+	case "pending":
+		return forge.CheckStatePending
+	default:
+		return forge.CheckStateFailure
+	}
+}
+
 // CleanupMergedBranches deletes branches that have been merged.
 func (c *Client) CleanupMergedBranches(ctx context.Context, target string, protectedPatterns []string) ([]string, error) {
 	return c.ghClient.CleanupMergedBranches(ctx, target, protectedPatterns)
 }
 
+// GetIssue retrieves an issue with its labels and comments.
+func (c *Client) GetIssue(ctx context.Context, number int) (*forge.Issue, error) {
+	issue, err := c.ghClient.GetIssue(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return convertIssue(issue), nil
+}
+
+// CommentOnIssue adds a comment to an issue.
+func (c *Client) CommentOnIssue(ctx context.Context, number int, body string) error {
+	return c.ghClient.CommentOnIssue(ctx, number, body)
+}
+
+// convertIssue converts a github.Issue to forge.Issue.
+func convertIssue(issue *github.Issue) *forge.Issue {
+	result := &forge.Issue{
+		Number: issue.Number,
+		URL:    issue.URL,
+		Title:  issue.Title,
+		Body:   issue.Body,
+		State:  strings.ToLower(issue.State),
+		Author: issue.Author.Login,
+	}
+	for _, label := range issue.Labels {
+		result.Labels = append(result.Labels, label.Name)
+	}
+	for i := range issue.Comments {
+		result.Comments = append(result.Comments, forge.IssueComment{
+			CreatedAt: issue.Comments[i].CreatedAt,
+			Author:    issue.Comments[i].Author.Login,
+			Body:      issue.Comments[i].Body,
+		})
+	}
+	return result
+}
+
 // convertPR converts a github.PullRequest to forge.PullRequest.
 func convertPR(pr *github.PullRequest) forge.PullRequest {
 	result := forge.PullRequest{
@@ -7,6 +7,7 @@ import (
 // init registers the GitHub client factory with the forge package.
 func init() {
 	forge.RegisterGitHubClientFactory(newClientFromFactory)
+	forge.RegisterGitHubRepoClientFactory(NewClientForRepo)
 }
 
 // newClientFromFactory creates a GitHub forge client.
@@ -13,9 +13,10 @@ import (
 
 // MergeResult represents the result of a git merge operation.
 type MergeResult struct {
-	Status       string
-	ConflictInfo string
-	MergeCommit  string
+	Status          string
+	ConflictInfo    string
+	MergeCommit     string
+	FailureFeedback string // Structured failure feedback (JSON), when the architect sent any
 }
 
 // WorkDoneResult contains detailed information about repository state
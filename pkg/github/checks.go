@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// CheckRun represents a GitHub check run (REST API shape).
+//
+//nolint:govet // Logical grouping preferred over memory optimization
+type CheckRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress, completed
+	Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	HTMLURL    string `json:"html_url"`
+	DetailsURL string `json:"details_url"`
+	App        struct {
+		Slug string `json:"slug"` // "github-actions" for workflow jobs
+	} `json:"app"`
+	Output struct {
+		Title   string `json:"title"`
+		Summary string `json:"summary"`
+	} `json:"output"`
+}
+
+// CommitStatus represents a legacy commit status (REST API shape).
+type CommitStatus struct {
+	Context     string `json:"context"`
+	State       string `json:"state"` // pending, success, failure, error
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+}
+
+// ListCheckRuns lists the check runs for a commit.
+func (c *Client) ListCheckRuns(ctx context.Context, sha string) ([]CheckRun, error) {
+	args := []string{"api", fmt.Sprintf("/repos/%s/commits/%s/check-runs?per_page=100", c.RepoPath(), sha)}
+
+	var result struct {
+		CheckRuns []CheckRun `json:"check_runs"`
+	}
+	if err := c.runJSON(ctx, &result, args...); err != nil {
+		return nil, fmt.Errorf("failed to list check runs for %s: %w", sha, err)
+	}
+
+	return result.CheckRuns, nil
+}
+
+// ListCommitStatuses returns the latest commit status per context for a commit.
+func (c *Client) ListCommitStatuses(ctx context.Context, sha string) ([]CommitStatus, error) {
+	args := []string{"api", fmt.Sprintf("/repos/%s/commits/%s/status", c.RepoPath(), sha)}
+
+	var result struct {
+		Statuses []CommitStatus `json:"statuses"`
+	}
+	if err := c.runJSON(ctx, &result, args...); err != nil {
+		return nil, fmt.Errorf("failed to get commit status for %s: %w", sha, err)
+	}
+
+	return result.Statuses, nil
+}
+
+// GetJobLog returns the log of a GitHub Actions job.
+func (c *Client) GetJobLog(ctx context.Context, jobID int64) (string, error) {
+	output, err := c.APIGet(ctx, fmt.Sprintf("/repos/%s/actions/jobs/%s/logs", c.RepoPath(), strconv.FormatInt(jobID, 10)))
+	if err != nil {
+		return "", fmt.Errorf("failed to get log for job %d: %w", jobID, err)
+	}
+	return string(output), nil
+}
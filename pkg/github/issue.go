@@ -0,0 +1,68 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Issue represents a GitHub issue.
+// Field names match gh CLI --json output (GraphQL field names).
+//
+//nolint:govet // Logical grouping preferred over memory optimization
+type Issue struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	State  string `json:"state"` // OPEN, CLOSED
+	Author struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Comments []IssueComment `json:"comments"`
+}
+
+// IssueComment represents a comment on a GitHub issue (gh CLI shape).
+type IssueComment struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Author    struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Body string `json:"body"`
+}
+
+// GetIssue retrieves an issue with its labels and comments.
+func (c *Client) GetIssue(ctx context.Context, number int) (*Issue, error) {
+	args := []string{
+		"issue", "view", strconv.Itoa(number),
+		"--repo", c.RepoPath(),
+		"--json", "number,url,title,body,state,author,labels,comments",
+	}
+
+	var issue Issue
+	if err := c.runJSON(ctx, &issue, args...); err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+
+	return &issue, nil
+}
+
+// CommentOnIssue adds a comment to an issue.
+func (c *Client) CommentOnIssue(ctx context.Context, number int, body string) error {
+	args := []string{
+		"issue", "comment", strconv.Itoa(number),
+		"--repo", c.RepoPath(),
+		"--body", body,
+	}
+
+	_, err := c.run(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("failed to comment on issue #%d: %w", number, err)
+	}
+
+	return nil
+}
@@ -188,6 +188,22 @@ func (c *Client) ClosePR(ctx context.Context, ref string) error {
 	return nil
 }
 
+// UpdatePRBase changes the branch a pull request merges into.
+func (c *Client) UpdatePRBase(ctx context.Context, ref, base string) error {
+	args := []string{
+		"pr", "edit", ref,
+		"--base", base,
+		"--repo", c.RepoPath(),
+	}
+
+	_, err := c.run(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("failed to change base of PR %s to %s: %w", ref, base, err)
+	}
+
+	return nil
+}
+
 // PRExists checks if a PR exists for the given branch.
 func (c *Client) PRExists(ctx context.Context, branch string) (bool, error) {
 	prs, err := c.ListPRsForBranch(ctx, branch)
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RESTUser is a user as the REST API returns it.
+type RESTUser struct {
+	Login string `json:"login"`
+}
+
+// RESTComment is a PR conversation comment (REST API issue comment shape).
+type RESTComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      RESTUser  `json:"user"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	ID        int64     `json:"id"`
+}
+
+// ReviewComment is a review comment on a line of a PR's diff.
+type ReviewComment struct {
+	CreatedAt    time.Time `json:"created_at"`
+	User         RESTUser  `json:"user"`
+	Body         string    `json:"body"`
+	Path         string    `json:"path"`
+	HTMLURL      string    `json:"html_url"`
+	ID           int64     `json:"id"`
+	Line         int       `json:"line"`
+	OriginalLine int       `json:"original_line"` // Set when the line is outdated by later pushes
+	InReplyToID  int64     `json:"in_reply_to_id"`
+}
+
+// Review is a submitted PR review.
+type Review struct {
+	SubmittedAt time.Time `json:"submitted_at"`
+	User        RESTUser  `json:"user"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"` // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED, PENDING
+	HTMLURL     string    `json:"html_url"`
+	ID          int64     `json:"id"`
+}
+
+// ListIssueComments lists the conversation comments on an issue or PR.
+func (c *Client) ListIssueComments(ctx context.Context, number int) ([]RESTComment, error) {
+	var comments []RESTComment
+	if err := c.apiGetJSON(ctx, fmt.Sprintf("/repos/%s/issues/%d/comments?per_page=100", c.RepoPath(), number), &comments); err != nil {
+		return nil, fmt.Errorf("failed to list comments on #%d: %w", number, err)
+	}
+	return comments, nil
+}
+
+// ListReviewComments lists the line comments on a PR's diff.
+func (c *Client) ListReviewComments(ctx context.Context, prNumber int) ([]ReviewComment, error) {
+	var comments []ReviewComment
+	if err := c.apiGetJSON(ctx, fmt.Sprintf("/repos/%s/pulls/%d/comments?per_page=100", c.RepoPath(), prNumber), &comments); err != nil {
+		return nil, fmt.Errorf("failed to list review comments on PR #%d: %w", prNumber, err)
+	}
+	return comments, nil
+}
+
+// ListReviews lists the reviews submitted on a PR.
+func (c *Client) ListReviews(ctx context.Context, prNumber int) ([]Review, error) {
+	var reviews []Review
+	if err := c.apiGetJSON(ctx, fmt.Sprintf("/repos/%s/pulls/%d/reviews?per_page=100", c.RepoPath(), prNumber), &reviews); err != nil {
+		return nil, fmt.Errorf("failed to list reviews on PR #%d: %w", prNumber, err)
+	}
+	return reviews, nil
+}
+
+// ReplyToReviewComment replies in a line comment's thread.
+func (c *Client) ReplyToReviewComment(ctx context.Context, prNumber int, commentID int64, body string) error {
+	endpoint := fmt.Sprintf("/repos/%s/pulls/%d/comments/%d/replies", c.RepoPath(), prNumber, commentID)
+	if _, err := c.API(ctx, "POST", endpoint, map[string]interface{}{"body": body}); err != nil {
+		return fmt.Errorf("failed to reply to review comment %d: %w", commentID, err)
+	}
+	return nil
+}
+
+// apiGetJSON performs a GET request to the GitHub API and decodes the response.
+func (c *Client) apiGetJSON(ctx context.Context, endpoint string, result interface{}) error {
+	output, err := c.APIGet(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(output, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
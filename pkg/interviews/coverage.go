@@ -0,0 +1,162 @@
+package interviews
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Coverage records which questionnaires an interview follows and what it
+// learned for each question. It is kept in PM state data as JSON so it
+// survives a resume.
+type Coverage struct {
+	// Selected lists the questionnaire IDs the PM picked for this feature.
+	Selected []string `json:"selected,omitempty"`
+	// DeclinedReason is set when the PM decided no questionnaire applies.
+	DeclinedReason string `json:"declined_reason,omitempty"`
+	// Answers maps questionnaire ID to question ID to a summary of the answer.
+	Answers map[string]map[string]string `json:"answers,omitempty"`
+}
+
+// Decode parses coverage stored by Encode. Empty or invalid input yields
+// empty coverage: losing it only means the questions are asked again.
+func Decode(raw string) *Coverage {
+	c := &Coverage{}
+	if raw != "" {
+		_ = json.Unmarshal([]byte(raw), c)
+	}
+	return c
+}
+
+// Encode serializes the coverage for state data.
+func (c *Coverage) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// Select adds a questionnaire to the interview and clears any earlier decline.
+func (c *Coverage) Select(id string) {
+	if !slices.Contains(c.Selected, id) {
+		c.Selected = append(c.Selected, id)
+	}
+	c.DeclinedReason = ""
+}
+
+// Decline records that no questionnaire applies. It fails once one has been
+// selected: a selected questionnaire must be covered, not waved off.
+func (c *Coverage) Decline(reason string) error {
+	if len(c.Selected) > 0 {
+		return fmt.Errorf("questionnaire already selected (%s)", strings.Join(c.Selected, ", "))
+	}
+	c.DeclinedReason = reason
+	return nil
+}
+
+// Record stores the answer summary for a question of a selected questionnaire.
+func (c *Coverage) Record(questionnaireID, questionID, summary string) {
+	if c.Answers == nil {
+		c.Answers = make(map[string]map[string]string)
+	}
+	if c.Answers[questionnaireID] == nil {
+		c.Answers[questionnaireID] = make(map[string]string)
+	}
+	c.Answers[questionnaireID][questionID] = summary
+}
+
+// Missing lists the required questions of selected questionnaires that have
+// no answer yet, as "questionnaire/question".
+func (c *Coverage) Missing(questionnaires []*Questionnaire) []string {
+	var missing []string
+	for _, q := range questionnaires {
+		if !slices.Contains(c.Selected, q.ID) {
+			continue
+		}
+		for _, question := range q.Questions {
+			if !question.Optional && c.Answers[q.ID][question.ID] == "" {
+				missing = append(missing, q.ID+"/"+question.ID)
+			}
+		}
+	}
+	return missing
+}
+
+// CheckComplete returns an error explaining what is left to do before a spec
+// may be submitted: picking (or declining) a questionnaire when any exist,
+// and answering every required question of the selected ones.
+func (c *Coverage) CheckComplete(questionnaires []*Questionnaire) error {
+	if len(questionnaires) == 0 {
+		return nil
+	}
+	if len(c.Selected) == 0 && c.DeclinedReason == "" {
+		return fmt.Errorf("select the interview questionnaire(s) matching this feature, or decline them with a reason")
+	}
+	if missing := c.Missing(questionnaires); len(missing) > 0 {
+		return fmt.Errorf("interview questionnaire questions not yet covered: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// QuestionStatus is one question's coverage, for display.
+type QuestionStatus struct {
+	ID       string `json:"id"`
+	Prompt   string `json:"prompt"`
+	Optional bool   `json:"optional,omitempty"`
+	Answer   string `json:"answer,omitempty"`
+}
+
+// QuestionnaireStatus is one selected questionnaire's coverage, for display.
+type QuestionnaireStatus struct {
+	ID        string           `json:"id"`
+	Title     string           `json:"title"`
+	Questions []QuestionStatus `json:"questions"`
+	Covered   int              `json:"covered"`
+	Required  int              `json:"required"`
+}
+
+// Report summarizes coverage for the PM and the web UI preview.
+type Report struct {
+	Available      []string              `json:"available"`
+	DeclinedReason string                `json:"declined_reason,omitempty"`
+	Questionnaires []QuestionnaireStatus `json:"questionnaires,omitempty"`
+	Missing        []string              `json:"missing,omitempty"`
+	Complete       bool                  `json:"complete"`
+}
+
+// Report builds the coverage report against the loaded questionnaires.
+// Selected IDs whose file has since been removed are skipped.
+func (c *Coverage) Report(questionnaires []*Questionnaire) *Report {
+	report := &Report{
+		Available:      make([]string, 0, len(questionnaires)),
+		DeclinedReason: c.DeclinedReason,
+		Missing:        c.Missing(questionnaires),
+		Complete:       c.CheckComplete(questionnaires) == nil,
+	}
+	for _, q := range questionnaires {
+		report.Available = append(report.Available, q.ID)
+		if !slices.Contains(c.Selected, q.ID) {
+			continue
+		}
+		status := QuestionnaireStatus{ID: q.ID, Title: q.Title}
+		for _, question := range q.Questions {
+			answer := c.Answers[q.ID][question.ID]
+			status.Questions = append(status.Questions, QuestionStatus{
+				ID:       question.ID,
+				Prompt:   question.Prompt,
+				Optional: question.Optional,
+				Answer:   answer,
+			})
+			if !question.Optional {
+				status.Required++
+				if answer != "" {
+					status.Covered++
+				}
+			}
+		}
+		report.Questionnaires = append(report.Questionnaires, status)
+	}
+	return report
+}
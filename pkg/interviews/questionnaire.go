@@ -0,0 +1,139 @@
+// Package interviews loads the PM's interview questionnaires and tracks which
+// of their questions an interview has covered.
+//
+// A questionnaire is a YAML file in .maestro/interviews/ describing the
+// questions a kind of feature (a new REST endpoint, a data pipeline, a UI
+// page) must answer before its spec is written:
+//
+//	id: rest-endpoint
+//	title: New REST endpoint
+//	description: Any feature that adds or changes an HTTP API.
+//	questions:
+//	  - id: auth
+//	    prompt: Who may call the endpoint, and how are they authenticated?
+//	  - id: errors
+//	    prompt: What does the endpoint return for invalid input?
+//	    hint: Status codes and error body shape.
+//	  - id: pagination
+//	    prompt: Are list responses paginated?
+//	    optional: true
+package interviews
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the questionnaire directory, relative to the project directory.
+const Dir = ".maestro/interviews"
+
+// validID matches questionnaire and question IDs. They are typed back by the
+// PM in tool calls, so they are kept short and unambiguous.
+var validID = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Question is one item a questionnaire requires the interview to cover.
+type Question struct {
+	ID       string `yaml:"id" json:"id"`
+	Prompt   string `yaml:"prompt" json:"prompt"`
+	Hint     string `yaml:"hint,omitempty" json:"hint,omitempty"`
+	Optional bool   `yaml:"optional,omitempty" json:"optional,omitempty"`
+}
+
+// Questionnaire is a reusable set of requirement questions for one kind of feature.
+type Questionnaire struct {
+	ID          string     `yaml:"id" json:"id"`
+	Title       string     `yaml:"title" json:"title"`
+	Description string     `yaml:"description,omitempty" json:"description,omitempty"`
+	Questions   []Question `yaml:"questions" json:"questions"`
+}
+
+// Question returns the question with the given ID, or nil.
+func (q *Questionnaire) Question(id string) *Question {
+	for i := range q.Questions {
+		if q.Questions[i].ID == id {
+			return &q.Questions[i]
+		}
+	}
+	return nil
+}
+
+// Load reads every questionnaire in the project's .maestro/interviews/
+// directory, sorted by ID. A missing directory means no questionnaires.
+func Load(projectDir string) ([]*Questionnaire, error) {
+	dir := filepath.Join(projectDir, Dir)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var questionnaires []*Questionnaire
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read questionnaire %s: %w", entry.Name(), err)
+		}
+		q, err := Parse(data, strings.TrimSuffix(entry.Name(), ext))
+		if err != nil {
+			return nil, fmt.Errorf("questionnaire %s: %w", entry.Name(), err)
+		}
+		if other, dup := seen[q.ID]; dup {
+			return nil, fmt.Errorf("questionnaire %s: id %q is also used by %s", entry.Name(), q.ID, other)
+		}
+		seen[q.ID] = entry.Name()
+		questionnaires = append(questionnaires, q)
+	}
+
+	sort.Slice(questionnaires, func(i, j int) bool { return questionnaires[i].ID < questionnaires[j].ID })
+	return questionnaires, nil
+}
+
+// Parse decodes and validates one questionnaire. defaultID is used when the
+// file does not set an id (Load passes the file name).
+func Parse(data []byte, defaultID string) (*Questionnaire, error) {
+	var q Questionnaire
+	if err := yaml.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if q.ID == "" {
+		q.ID = defaultID
+	}
+	if !validID.MatchString(q.ID) {
+		return nil, fmt.Errorf("invalid id %q (use lowercase letters, digits, '-' and '_')", q.ID)
+	}
+	if q.Title == "" {
+		q.Title = q.ID
+	}
+	if len(q.Questions) == 0 {
+		return nil, fmt.Errorf("no questions")
+	}
+
+	seen := make(map[string]bool, len(q.Questions))
+	for i := range q.Questions {
+		question := &q.Questions[i]
+		if !validID.MatchString(question.ID) {
+			return nil, fmt.Errorf("question %d: invalid id %q", i+1, question.ID)
+		}
+		if seen[question.ID] {
+			return nil, fmt.Errorf("question %d: duplicate id %q", i+1, question.ID)
+		}
+		seen[question.ID] = true
+		if strings.TrimSpace(question.Prompt) == "" {
+			return nil, fmt.Errorf("question %q: prompt is required", question.ID)
+		}
+	}
+	return &q, nil
+}
@@ -0,0 +1,127 @@
+package interviews
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const restEndpointYAML = `title: New REST endpoint
+description: Any feature that adds or changes an HTTP API.
+questions:
+  - id: auth
+    prompt: Who may call the endpoint?
+  - id: errors
+    prompt: What is returned for invalid input?
+  - id: pagination
+    prompt: Are list responses paginated?
+    optional: true
+`
+
+func writeQuestionnaire(t *testing.T, projectDir, name, content string) {
+	t.Helper()
+	dir := filepath.Join(projectDir, Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	projectDir := t.TempDir()
+	if qs, err := Load(projectDir); err != nil || qs != nil {
+		t.Fatalf("missing directory: got %v, %v", qs, err)
+	}
+
+	writeQuestionnaire(t, projectDir, "rest-endpoint.yaml", restEndpointYAML)
+	writeQuestionnaire(t, projectDir, "ui.yml", "id: a-ui-page\nquestions:\n  - id: layout\n    prompt: Which layout?\n")
+	writeQuestionnaire(t, projectDir, "notes.txt", "ignored")
+
+	qs, err := Load(projectDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(qs) != 2 || qs[0].ID != "a-ui-page" || qs[1].ID != "rest-endpoint" {
+		t.Fatalf("unexpected questionnaires: %+v", qs)
+	}
+	if qs[0].Title != "a-ui-page" || qs[1].Question("pagination") == nil || !qs[1].Question("pagination").Optional {
+		t.Errorf("unexpected questionnaire contents: %+v %+v", qs[0], qs[1])
+	}
+
+	writeQuestionnaire(t, projectDir, "dup.yaml", "id: rest-endpoint\nquestions:\n  - id: x\n    prompt: y\n")
+	if _, err := Load(projectDir); err == nil || !strings.Contains(err.Error(), "also used by") {
+		t.Errorf("expected duplicate id error, got %v", err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no questions":       "title: Empty\n",
+		"bad id":             "id: Has Spaces\nquestions:\n  - id: a\n    prompt: b\n",
+		"duplicate question": "questions:\n  - id: a\n    prompt: b\n  - id: a\n    prompt: c\n",
+		"missing prompt":     "questions:\n  - id: a\n",
+		"invalid yaml":       "questions: [",
+	}
+	for name, content := range tests {
+		if _, err := Parse([]byte(content), "q"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	q, err := Parse([]byte(restEndpointYAML), "rest-endpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	qs := []*Questionnaire{q}
+
+	c := Decode("")
+	if err := c.CheckComplete(qs); err == nil || !strings.Contains(err.Error(), "select") {
+		t.Errorf("expected a selection error, got %v", err)
+	}
+	if err := c.CheckComplete(nil); err != nil {
+		t.Errorf("no questionnaires should never block: %v", err)
+	}
+
+	c.Select("rest-endpoint")
+	c.Record("rest-endpoint", "auth", "Logged-in users via session cookie")
+	if missing := c.Missing(qs); len(missing) != 1 || missing[0] != "rest-endpoint/errors" {
+		t.Errorf("Missing() = %v", missing)
+	}
+	if err := c.Decline("not an API"); err == nil {
+		t.Error("declining after a selection should fail")
+	}
+
+	c.Record("rest-endpoint", "errors", "400 with a JSON error body")
+	restored := Decode(c.Encode())
+	if err := restored.CheckComplete(qs); err != nil {
+		t.Errorf("expected complete coverage after round trip, got %v", err)
+	}
+
+	report := restored.Report(qs)
+	if !report.Complete || len(report.Questionnaires) != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if status := report.Questionnaires[0]; status.Covered != 2 || status.Required != 2 || len(status.Questions) != 3 {
+		t.Errorf("unexpected status: %+v", status)
+	}
+}
+
+func TestCoverage_Decline(t *testing.T) {
+	q, _ := Parse([]byte(restEndpointYAML), "rest-endpoint")
+	c := &Coverage{}
+	if err := c.Decline("copy change only"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CheckComplete([]*Questionnaire{q}); err != nil {
+		t.Errorf("declined coverage should be complete: %v", err)
+	}
+	c.Select("rest-endpoint")
+	if c.DeclinedReason != "" {
+		t.Error("selecting should clear the decline")
+	}
+}
@@ -0,0 +1,303 @@
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/mirror"
+	"orchestrator/pkg/proto"
+)
+
+const (
+	// maxFeedbackOutput is how much failing output is sent back to the coder (the tail is kept).
+	maxFeedbackOutput = 4000
+
+	queueAuthorName  = "Maestro Merge Queue"
+	queueAuthorEmail = "merge-queue@maestro.local"
+)
+
+// AwaitChecksFunc waits for a PR's CI checks on headSHA to complete. It returns
+// feedback when they failed or did not complete, and an error when it was cancelled.
+type AwaitChecksFunc func(ctx context.Context, client forge.Client, prRef, headSHA string) (*proto.MergeFailureFeedback, error)
+
+// GitBackend is the Backend used by the architect. It squashes candidates in
+// scratch clones of the mirror, runs the project's build and test commands in
+// the project container image, and merges through the forge.
+type GitBackend struct {
+	Mirror       *mirror.Manager
+	NewForge     func() (forge.Client, error) // Forge client factory
+	Image        func() string                // Project container image; empty when none is built yet
+	AwaitChecks  AwaitChecksFunc              // Waits for CI checks on the rebuilt PR head; nil merges at once
+	logger       *logx.Logger
+	ScratchDir   string // Parent directory of scratch clones
+	TargetBranch string
+	BuildCommand string
+	TestCommand  string
+	TestTimeout  time.Duration
+}
+
+// NewGitBackend creates a backend working under projectDir.
+func NewGitBackend(projectDir, targetBranch, buildCommand, testCommand string, testTimeout time.Duration,
+	newForge func() (forge.Client, error), image func() string) *GitBackend {
+	return &GitBackend{
+		Mirror:       mirror.NewManager(projectDir),
+		NewForge:     newForge,
+		Image:        image,
+		logger:       logx.NewLogger("merge-queue"),
+		ScratchDir:   filepath.Join(projectDir, ".tmp", "merge-queue"),
+		TargetBranch: targetBranch,
+		BuildCommand: buildCommand,
+		TestCommand:  testCommand,
+		TestTimeout:  testTimeout,
+	}
+}
+
+// Head refreshes the mirror and returns the target branch head.
+func (b *GitBackend) Head(ctx context.Context) (string, error) {
+	if err := b.Mirror.RefreshFromForge(ctx); err != nil {
+		return "", fmt.Errorf("failed to refresh mirror: %w", err)
+	}
+	mirrorPath, err := b.Mirror.GetMirrorPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to get mirror path: %w", err)
+	}
+	out, err := git(ctx, mirrorPath, "rev-parse", "refs/heads/"+b.TargetBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", b.TargetBranch, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// Prepare clones the mirror and squashes each candidate onto base, in order.
+func (b *GitBackend) Prepare(ctx context.Context, base string, ahead []*Candidate, c *Candidate) (*Speculation, *proto.MergeFailureFeedback, error) {
+	mirrorPath, err := b.Mirror.GetMirrorPath()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get mirror path: %w", err)
+	}
+	if err := os.MkdirAll(b.ScratchDir, 0755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(b.ScratchDir, sanitize(c.StoryID)+"-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create scratch clone directory: %w", err)
+	}
+	spec := &Speculation{Dir: dir, BaseSHA: base}
+
+	// Cloning reads the mirror, so it must not race a refresh or recovery (ADR 0027)
+	unlock := mirror.LockPath(mirrorPath)
+	_, err = git(ctx, "", "clone", "--quiet", "--no-checkout", mirrorPath, dir)
+	unlock()
+	if err != nil {
+		return spec, nil, fmt.Errorf("failed to clone mirror: %w", err)
+	}
+	if _, err := git(ctx, dir, "checkout", "--quiet", "--detach", base); err != nil {
+		return spec, nil, fmt.Errorf("failed to check out %s: %w", base, err)
+	}
+
+	for _, cand := range append(append([]*Candidate{}, ahead...), c) {
+		out, mergeErr := git(ctx, dir, "merge", "--squash", "origin/"+cand.Branch)
+		if mergeErr != nil {
+			if cand != c {
+				return spec, nil, fmt.Errorf("candidate %s ahead of %s does not apply", cand.StoryID, c.StoryID)
+			}
+			conflicts, _ := git(ctx, dir, "diff", "--name-only", "--diff-filter=U")
+			return spec, &proto.MergeFailureFeedback{
+				Stage:     proto.MergeStageRebase,
+				Conflicts: strings.Fields(conflicts),
+				Output:    tail(out),
+			}, nil
+		}
+		if _, err := git(ctx, dir, "-c", "user.name="+queueAuthorName, "-c", "user.email="+queueAuthorEmail,
+			"commit", "--quiet", "--allow-empty", "--no-verify", "-m", cand.StoryID+" (merge queue)"); err != nil {
+			return spec, nil, fmt.Errorf("failed to commit %s: %w", cand.StoryID, err)
+		}
+		if cand != c {
+			spec.Ahead = append(spec.Ahead, cand.StoryID)
+		}
+	}
+
+	if spec.Commit, err = revParse(ctx, dir, "HEAD"); err != nil {
+		return spec, nil, err
+	}
+	if spec.Tree, err = revParse(ctx, dir, "HEAD^{tree}"); err != nil {
+		return spec, nil, err
+	}
+	if spec.BranchSHA, err = revParse(ctx, dir, "origin/"+c.Branch); err != nil {
+		return spec, nil, err
+	}
+	return spec, nil, nil
+}
+
+// Test runs the build and test commands in the project container.
+func (b *GitBackend) Test(ctx context.Context, spec *Speculation) (*proto.MergeFailureFeedback, error) {
+	image := b.Image()
+	if image == "" {
+		return nil, fmt.Errorf("no project container image available to test in")
+	}
+
+	testCtx, cancel := context.WithTimeout(ctx, b.TestTimeout)
+	defer cancel()
+
+	for _, step := range []struct {
+		stage   proto.MergeFailureStage
+		command string
+	}{{proto.MergeStageBuild, b.BuildCommand}, {proto.MergeStageTest, b.TestCommand}} {
+		if step.command == "" {
+			continue
+		}
+		out, err := b.runInContainer(testCtx, image, spec.Dir, step.command)
+		if err == nil {
+			continue
+		}
+		if testCtx.Err() != nil && ctx.Err() == nil {
+			return &proto.MergeFailureFeedback{
+				Stage:   step.stage,
+				Command: step.command,
+				Output:  tail(out) + fmt.Sprintf("\n[timed out after %s]", b.TestTimeout),
+			}, nil
+		}
+		var exitErr *exec.ExitError
+		// Exit codes 125-127 come from docker itself, not the command
+		if errors.As(err, &exitErr) && exitErr.ExitCode() < 125 {
+			return &proto.MergeFailureFeedback{Stage: step.stage, Command: step.command, Output: tail(out)}, nil
+		}
+		return nil, fmt.Errorf("failed to run %q in container: %w: %s", step.command, err, tail(out))
+	}
+	return nil, nil
+}
+
+// Land replays the tested commit on the current head, waits for CI checks on
+// the rebuilt PR head and merges it.
+func (b *GitBackend) Land(ctx context.Context, spec *Speculation, c *Candidate) (string, *proto.MergeFailureFeedback, error) {
+	if err := b.Mirror.RefreshFromForge(ctx); err != nil {
+		return "", nil, fmt.Errorf("failed to refresh mirror: %w", err)
+	}
+	if _, err := git(ctx, spec.Dir, "fetch", "--quiet", "origin"); err != nil {
+		return "", nil, fmt.Errorf("failed to fetch mirror: %w", err)
+	}
+
+	// Rebuild the PR as the candidate's squashed commit on top of the current head
+	if _, err := git(ctx, spec.Dir, "checkout", "--quiet", "--detach", "origin/"+b.TargetBranch); err != nil {
+		return "", nil, fmt.Errorf("failed to check out %s: %w", b.TargetBranch, err)
+	}
+	if _, err := git(ctx, spec.Dir, "-c", "user.name="+queueAuthorName, "-c", "user.email="+queueAuthorEmail,
+		"cherry-pick", "--allow-empty", spec.Commit); err != nil {
+		_, _ = git(ctx, spec.Dir, "cherry-pick", "--abort")
+		return "", nil, ErrStale
+	}
+	tree, err := revParse(ctx, spec.Dir, "HEAD^{tree}")
+	if err != nil {
+		return "", nil, err
+	}
+	if tree != spec.Tree {
+		return "", nil, ErrStale
+	}
+	head, err := revParse(ctx, spec.Dir, "HEAD")
+	if err != nil {
+		return "", nil, err
+	}
+
+	remoteURL, err := b.Mirror.GetRemoteURL(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get forge URL: %w", err)
+	}
+	push := exec.CommandContext(ctx, "git", "push", "--quiet",
+		fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", c.Branch, spec.BranchSHA),
+		remoteURL, "HEAD:refs/heads/"+c.Branch)
+	push.Dir = spec.Dir
+	push.Env = mirror.GitAuthEnv()
+	if out, err := push.CombinedOutput(); err != nil {
+		return "", nil, fmt.Errorf("failed to update PR branch %s: %w: %s", c.Branch, err, tail(string(out)))
+	}
+
+	client, err := b.NewForge()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create forge client: %w", err)
+	}
+	if b.AwaitChecks != nil {
+		// The push replaced the PR head, so the checks that gated the request no longer apply
+		feedback, checksErr := b.AwaitChecks(ctx, client, c.PRRef, head)
+		if checksErr != nil {
+			return "", nil, fmt.Errorf("failed to wait for CI checks on %s: %w", c.PRRef, checksErr)
+		}
+		if feedback != nil {
+			return "", feedback, nil
+		}
+	}
+	result, err := client.MergePRWithResult(ctx, c.PRRef, forge.PRMergeOptions{Method: "squash", DeleteBranch: true})
+	if err != nil {
+		return "", nil, fmt.Errorf("merge failed: %w", err)
+	}
+	if result.HasConflicts {
+		return "", &proto.MergeFailureFeedback{Stage: proto.MergeStageMerge, Output: tail(result.ConflictInfo)}, nil
+	}
+	return result.SHA, nil, nil
+}
+
+// Discard removes the scratch clone.
+func (b *GitBackend) Discard(spec *Speculation) {
+	if spec.Dir == "" {
+		return
+	}
+	if err := os.RemoveAll(spec.Dir); err != nil {
+		b.logger.Warn("Failed to remove scratch clone %s: %v", spec.Dir, err)
+	}
+}
+
+// runInContainer runs command in a throwaway container of the project image
+// with the scratch clone mounted as the workspace.
+func (b *GitBackend) runInContainer(ctx context.Context, image, dir, command string) (string, error) {
+	cmd := exec.CommandContext(ctx, "docker", "run", "--rm",
+		"--label", "com.maestro.managed=true",
+		"--label", "com.maestro.agent=merge-queue",
+		"--volume", dir+":/workspace",
+		"--workdir", "/workspace",
+		image, "sh", "-c", command)
+	out, err := cmd.CombinedOutput()
+	return string(out), err //nolint:wrapcheck // caller inspects the exit code
+}
+
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+func revParse(ctx context.Context, dir, ref string) (string, error) {
+	out, err := git(ctx, dir, "rev-parse", ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// tail keeps the end of long output, where build and test failures are reported.
+func tail(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) <= maxFeedbackOutput {
+		return output
+	}
+	return "[... earlier output truncated ...]\n" + output[len(output)-maxFeedbackOutput:]
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' {
+			return '-'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,179 @@
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+// checksForge reports pending CI checks on a PR branch's head for a number of
+// polls, then their final state, and refuses to merge a head whose checks have
+// not passed, like a branch with required status checks.
+type checksForge struct {
+	forge.Client
+	upstream     string
+	branch       string
+	pendingPolls int
+	final        forge.CheckState
+	passed       string // Head whose checks passed
+	merged       bool
+}
+
+func (f *checksForge) GetPRChecks(_ context.Context, _ string) (*forge.CheckStatus, error) {
+	out, err := exec.Command("git", "-C", f.upstream, "rev-parse", "refs/heads/"+f.branch).Output()
+	if err != nil {
+		return nil, err
+	}
+	status := &forge.CheckStatus{SHA: strings.TrimSpace(string(out)), State: forge.CheckStatePending}
+	if f.pendingPolls > 0 {
+		f.pendingPolls--
+		return status, nil
+	}
+	status.State = f.final
+	if f.final == forge.CheckStateSuccess {
+		f.passed = status.SHA
+	}
+	return status, nil
+}
+
+func (f *checksForge) MergePRWithResult(_ context.Context, _ string, _ forge.PRMergeOptions) (*forge.MergeResult, error) {
+	out, err := exec.Command("git", "-C", f.upstream, "rev-parse", "refs/heads/"+f.branch).Output()
+	if err != nil {
+		return nil, err
+	}
+	if f.passed != strings.TrimSpace(string(out)) {
+		return nil, errors.New("required status checks have not passed")
+	}
+	f.merged = true
+	return &forge.MergeResult{Merged: true, SHA: "merged-sha"}, nil
+}
+
+// pollChecks stands in for the architect's checks wait: it polls until the
+// checks on headSHA complete.
+func pollChecks(ctx context.Context, client forge.Client, prRef, headSHA string) (*proto.MergeFailureFeedback, error) {
+	for {
+		status, err := client.GetPRChecks(ctx, prRef)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case status.SHA != headSHA || status.State == forge.CheckStatePending:
+			continue
+		case status.State == forge.CheckStateFailure:
+			return &proto.MergeFailureFeedback{Stage: proto.MergeStageChecks, Output: "lint failed"}, nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// newLandTest returns a backend whose mirror follows a local upstream with a
+// story-1 branch, and a speculation of that branch prepared on main.
+func newLandTest(t *testing.T, client *checksForge) (*GitBackend, *Speculation, *Candidate) {
+	t.Helper()
+	root := t.TempDir()
+	upstream := filepath.Join(root, "app.git")
+	runGit(t, root, "init", "--quiet", "--bare", "--initial-branch=main", upstream)
+
+	work := filepath.Join(root, "work")
+	runGit(t, root, "clone", "--quiet", upstream, work)
+	if err := os.WriteFile(filepath.Join(work, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", "initial")
+	runGit(t, work, "push", "--quiet", "origin", "HEAD:main")
+	runGit(t, work, "checkout", "--quiet", "-b", "story-1")
+	if err := os.WriteFile(filepath.Join(work, "story.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, work, "add", ".")
+	runGit(t, work, "commit", "--quiet", "-m", "story")
+	runGit(t, work, "push", "--quiet", "origin", "story-1")
+
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{RepoURL: upstream, TargetBranch: "main"}})
+	t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+	client.upstream, client.branch = upstream, "story-1"
+	backend := NewGitBackend(filepath.Join(root, "project"), "main", "", "", time.Minute,
+		func() (forge.Client, error) { return client, nil }, func() string { return "" })
+	if _, err := backend.Mirror.EnsureMirror(context.Background()); err != nil {
+		t.Fatalf("EnsureMirror failed: %v", err)
+	}
+	head, err := backend.Head(context.Background())
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	c := &Candidate{StoryID: "story-1", PRRef: "1", Branch: "story-1"}
+	spec, feedback, err := backend.Prepare(context.Background(), head, nil, c)
+	if err != nil || feedback != nil {
+		t.Fatalf("Prepare failed: %v %+v", err, feedback)
+	}
+	t.Cleanup(func() { backend.Discard(spec) })
+	return backend, spec, c
+}
+
+// TestGitBackendLand_WaitsForChecks verifies the rebuilt PR head is merged
+// only once its CI checks pass, as the forge requires.
+func TestGitBackendLand_WaitsForChecks(t *testing.T) {
+	client := &checksForge{pendingPolls: 2, final: forge.CheckStateSuccess}
+	backend, spec, c := newLandTest(t, client)
+
+	var awaited string
+	backend.AwaitChecks = func(ctx context.Context, forgeClient forge.Client, prRef, headSHA string) (*proto.MergeFailureFeedback, error) {
+		awaited = headSHA
+		return pollChecks(ctx, forgeClient, prRef, headSHA)
+	}
+
+	sha, feedback, err := backend.Land(context.Background(), spec, c)
+	if err != nil || feedback != nil {
+		t.Fatalf("Expected the PR to merge, got %v %+v", err, feedback)
+	}
+	if sha != "merged-sha" || !client.merged {
+		t.Errorf("Expected the PR to be merged, got %q", sha)
+	}
+	if awaited == "" || awaited != client.passed {
+		t.Errorf("Expected checks to be awaited on the pushed head, awaited %q, passed %q", awaited, client.passed)
+	}
+}
+
+// TestGitBackendLand_FailedChecks verifies failing checks on the rebuilt PR
+// head are returned as feedback instead of merging.
+func TestGitBackendLand_FailedChecks(t *testing.T) {
+	client := &checksForge{pendingPolls: 1, final: forge.CheckStateFailure}
+	backend, spec, c := newLandTest(t, client)
+	backend.AwaitChecks = pollChecks
+
+	_, feedback, err := backend.Land(context.Background(), spec, c)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if feedback == nil || feedback.Stage != proto.MergeStageChecks {
+		t.Fatalf("Expected checks feedback, got %+v", feedback)
+	}
+	if client.merged {
+		t.Error("Expected the PR not to be merged")
+	}
+}
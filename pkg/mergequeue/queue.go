@@ -0,0 +1,289 @@
+// Package mergequeue implements the orchestrator-owned merge queue.
+//
+// Approved PRs are queued in arrival order. The queue takes a window of up to
+// depth candidates and tests them speculatively in parallel: each candidate is
+// squashed onto the queue head together with every candidate ahead of it in
+// the window, then built and tested in the project container. Results are
+// applied in order — a green candidate is merged through the forge, and the
+// first red one is sent back to its coder with structured feedback. Candidates
+// behind a failure were tested on a tree that included it, so they return to
+// the front of the queue for the next window.
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/proto"
+)
+
+// ErrStale reports that the queue head moved after a candidate was tested,
+// so its tested tree can no longer be merged as-is.
+var ErrStale = errors.New("queue head moved since the candidate was tested")
+
+// maxStaleRetries bounds how often a candidate is retested after its tested
+// tree went stale before the queue gives up on it.
+const maxStaleRetries = 3
+
+// Candidate is a PR waiting in the merge queue.
+type Candidate struct {
+	EnqueuedAt time.Time
+	StoryID    string
+	PRRef      string // PR URL or number, as the forge client accepts it
+	Branch     string // PR head branch
+	RequestID  string // ID of the coder's merge request, for correlating the reply
+	staleRuns  int
+}
+
+// Result is the outcome of one candidate.
+// Exactly one of CommitSHA, Feedback and Err is set.
+type Result struct {
+	Candidate *Candidate
+	CommitSHA string                      // Merge commit when the PR landed
+	Feedback  *proto.MergeFailureFeedback // Why the PR was sent back to its coder
+	Err       error                       // Queue failure; the PR was neither merged nor judged
+}
+
+// Speculation is a candidate's tested tree in a scratch clone.
+type Speculation struct {
+	Dir       string   // Scratch clone holding the tree
+	BaseSHA   string   // Queue head the window was built on
+	Commit    string   // The candidate's squashed commit
+	Tree      string   // Tree ID that was tested
+	BranchSHA string   // PR head the candidate was squashed from
+	Ahead     []string // Stories applied before the candidate
+}
+
+// Backend performs the queue's git, container and forge work.
+type Backend interface {
+	// Head refreshes the mirror and returns the target branch head.
+	Head(ctx context.Context) (string, error)
+
+	// Prepare squashes the candidates ahead, then the candidate, onto base in a
+	// scratch clone. Feedback is returned when the candidate itself does not apply.
+	Prepare(ctx context.Context, base string, ahead []*Candidate, c *Candidate) (*Speculation, *proto.MergeFailureFeedback, error)
+
+	// Test builds and tests the speculation. Feedback is returned when the build or tests fail.
+	Test(ctx context.Context, spec *Speculation) (*proto.MergeFailureFeedback, error)
+
+	// Land replays the tested commit on the current head, updates the PR branch,
+	// waits for its CI checks and merges it through the forge. Returns ErrStale
+	// when the replayed tree differs from the tested one.
+	Land(ctx context.Context, spec *Speculation, c *Candidate) (string, *proto.MergeFailureFeedback, error)
+
+	// Discard removes the speculation's scratch clone.
+	Discard(spec *Speculation)
+}
+
+// Queue is the merge queue. Enqueue adds candidates; Run processes them and
+// delivers one Result per candidate on Results.
+type Queue struct {
+	backend Backend
+	logger  *logx.Logger
+	wake    chan struct{}
+	results chan *Result
+	pending []*Candidate
+	depth   int
+	mu      sync.Mutex
+}
+
+// New creates a merge queue testing up to depth candidates at once.
+func New(backend Backend, depth int) *Queue {
+	if depth <= 0 {
+		depth = 1
+	}
+	return &Queue{
+		backend: backend,
+		depth:   depth,
+		logger:  logx.NewLogger("merge-queue"),
+		wake:    make(chan struct{}, 1),
+		results: make(chan *Result, 16),
+	}
+}
+
+// Enqueue adds a candidate to the back of the queue and returns its 1-based position.
+func (q *Queue) Enqueue(c *Candidate) int {
+	if c.EnqueuedAt.IsZero() {
+		c.EnqueuedAt = time.Now().UTC()
+	}
+	q.mu.Lock()
+	q.pending = append(q.pending, c)
+	position := len(q.pending)
+	q.mu.Unlock()
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return position
+}
+
+// Remove takes a candidate that has not been picked up for testing out of the
+// queue. Returns nil if it is not waiting.
+func (q *Queue) Remove(requestID string) *Candidate {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i, c := range q.pending {
+		if c.RequestID == requestID {
+			q.pending = slices.Delete(q.pending, i, i+1)
+			return c
+		}
+	}
+	return nil
+}
+
+// Pending returns the story IDs waiting in the queue, front first.
+func (q *Queue) Pending() []string {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ids := make([]string, len(q.pending))
+	for i, c := range q.pending {
+		ids[i] = c.StoryID
+	}
+	return ids
+}
+
+// Results delivers the outcome of each candidate.
+func (q *Queue) Results() <-chan *Result {
+	return q.results
+}
+
+// Run processes the queue until ctx is cancelled.
+func (q *Queue) Run(ctx context.Context) {
+	q.logger.Info("🚦 Merge queue started (depth %d)", q.depth)
+	for {
+		if q.processWindow(ctx) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			q.logger.Info("🚦 Merge queue stopping")
+			return
+		case <-q.wake:
+		}
+	}
+}
+
+// outcome is a candidate's speculative test result.
+type outcome struct {
+	spec     *Speculation
+	feedback *proto.MergeFailureFeedback
+	err      error
+}
+
+// processWindow tests and lands the next window of candidates.
+// Returns false when the queue was empty.
+func (q *Queue) processWindow(ctx context.Context) bool {
+	window := q.take()
+	if len(window) == 0 {
+		return false
+	}
+
+	base, err := q.backend.Head(ctx)
+	if err != nil {
+		q.logger.Error("🚦 Failed to read queue head: %v", err)
+		for _, c := range window {
+			q.emit(ctx, &Result{Candidate: c, Err: err})
+		}
+		return true
+	}
+	q.logger.Info("🚦 Testing %d candidate(s) on %s", len(window), shortSHA(base))
+
+	outcomes := make([]outcome, len(window))
+	var wg sync.WaitGroup
+	for i := range window {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			o := &outcomes[i]
+			o.spec, o.feedback, o.err = q.backend.Prepare(ctx, base, window[:i], window[i])
+			if o.feedback == nil && o.err == nil {
+				o.feedback, o.err = q.backend.Test(ctx, o.spec)
+			}
+		}(i)
+	}
+	wg.Wait()
+	defer func() {
+		for i := range outcomes {
+			if outcomes[i].spec != nil {
+				q.backend.Discard(outcomes[i].spec)
+			}
+		}
+	}()
+
+	var merged []string
+	for i, c := range window {
+		o := &outcomes[i]
+		rest := window[i+1:]
+
+		if o.err == nil && o.feedback == nil {
+			var sha string
+			sha, o.feedback, o.err = q.backend.Land(ctx, o.spec, c)
+			if errors.Is(o.err, ErrStale) && c.staleRuns < maxStaleRetries {
+				c.staleRuns++
+				q.logger.Info("🚦 %s went stale after testing, retesting", c.StoryID)
+				q.requeueFront(window[i:])
+				return true
+			}
+			if o.err == nil && o.feedback == nil {
+				q.logger.Info("🚦 Merged %s as %s", c.StoryID, shortSHA(sha))
+				q.emit(ctx, &Result{Candidate: c, CommitSHA: sha})
+				merged = append(merged, c.StoryID)
+				continue
+			}
+		}
+
+		if o.feedback != nil {
+			o.feedback.BaseSHA = base
+			o.feedback.Ahead = merged
+			q.logger.Info("🚦 %s failed at %s, sending it back", c.StoryID, o.feedback.Stage)
+			q.emit(ctx, &Result{Candidate: c, Feedback: o.feedback})
+		} else {
+			q.logger.Error("🚦 %s could not be processed: %v", c.StoryID, o.err)
+			q.emit(ctx, &Result{Candidate: c, Err: o.err})
+		}
+		// Everything behind was tested with this candidate in its tree
+		q.requeueFront(rest)
+		return true
+	}
+	return true
+}
+
+// take removes the next window from the front of the queue.
+func (q *Queue) take() []*Candidate {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := min(q.depth, len(q.pending))
+	window := make([]*Candidate, n)
+	copy(window, q.pending[:n])
+	q.pending = q.pending[n:]
+	return window
+}
+
+// requeueFront puts candidates back at the front of the queue, in order.
+func (q *Queue) requeueFront(candidates []*Candidate) {
+	if len(candidates) == 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(append([]*Candidate{}, candidates...), q.pending...)
+}
+
+func (q *Queue) emit(ctx context.Context, result *Result) {
+	select {
+	case q.results <- result:
+	case <-ctx.Done():
+	}
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
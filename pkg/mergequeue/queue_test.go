@@ -0,0 +1,210 @@
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"orchestrator/pkg/proto"
+)
+
+// fakeBackend simulates candidates: conflicts never apply, and a tree fails
+// its tests when it contains a story listed in breaks.
+type fakeBackend struct {
+	conflicts map[string]bool
+	breaks    map[string]bool
+	stale     map[string]int // Times Land reports the story's tree as stale
+	mu        sync.Mutex
+	tested    [][]string // Trees that were tested, as story lists
+	landed    []string
+	headErr   error
+}
+
+// Head identifies the head by how many stories have landed on it.
+func (f *fakeBackend) Head(_ context.Context) (string, error) {
+	return fmt.Sprintf("base%04d", len(f.landed)), f.headErr
+}
+
+func (f *fakeBackend) Prepare(_ context.Context, base string, ahead []*Candidate, c *Candidate) (*Speculation, *proto.MergeFailureFeedback, error) {
+	spec := &Speculation{BaseSHA: base, Commit: c.StoryID}
+	for _, a := range ahead {
+		if f.conflicts[a.StoryID] {
+			return spec, nil, errors.New("ahead candidate does not apply")
+		}
+		spec.Ahead = append(spec.Ahead, a.StoryID)
+	}
+	if f.conflicts[c.StoryID] {
+		return spec, &proto.MergeFailureFeedback{Stage: proto.MergeStageRebase, Conflicts: []string{"main.go"}}, nil
+	}
+	return spec, nil, nil
+}
+
+func (f *fakeBackend) Test(_ context.Context, spec *Speculation) (*proto.MergeFailureFeedback, error) {
+	tree := append(append([]string{}, spec.Ahead...), spec.Commit)
+	f.mu.Lock()
+	f.tested = append(f.tested, tree)
+	f.mu.Unlock()
+	for _, id := range tree {
+		if f.breaks[id] {
+			return &proto.MergeFailureFeedback{Stage: proto.MergeStageTest, Command: "make test", Output: "FAIL"}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakeBackend) Land(_ context.Context, spec *Speculation, c *Candidate) (string, *proto.MergeFailureFeedback, error) {
+	if f.stale[c.StoryID] > 0 {
+		f.stale[c.StoryID]--
+		return "", nil, ErrStale
+	}
+	// The tested tree is current only if exactly the stories ahead of it landed since its base
+	if spec.BaseSHA != fmt.Sprintf("base%04d", len(f.landed)-len(spec.Ahead)) ||
+		!slices.Equal(spec.Ahead, f.landed[len(f.landed)-len(spec.Ahead):]) {
+		return "", nil, ErrStale
+	}
+	f.landed = append(f.landed, c.StoryID)
+	return "sha-" + c.StoryID, nil, nil
+}
+
+func (f *fakeBackend) Discard(_ *Speculation) {}
+
+func newFake() *fakeBackend {
+	return &fakeBackend{conflicts: map[string]bool{}, breaks: map[string]bool{}, stale: map[string]int{}}
+}
+
+// drain processes the queue until it is empty and collects results by story.
+func drain(t *testing.T, q *Queue) map[string]*Result {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results := make(map[string]*Result)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for r := range q.results {
+			results[r.Candidate.StoryID] = r
+		}
+	}()
+	for q.processWindow(ctx) {
+	}
+	close(q.results)
+	<-done
+	return results
+}
+
+func enqueue(q *Queue, ids ...string) {
+	for _, id := range ids {
+		q.Enqueue(&Candidate{StoryID: id, Branch: "story-" + id, RequestID: "req-" + id})
+	}
+}
+
+func TestQueue_AllGreenLandInOrder(t *testing.T) {
+	backend := newFake()
+	q := New(backend, 3)
+	enqueue(q, "a", "b", "c")
+
+	results := drain(t, q)
+
+	if !slices.Equal(backend.landed, []string{"a", "b", "c"}) {
+		t.Errorf("landed = %v, want [a b c]", backend.landed)
+	}
+	for _, id := range []string{"a", "b", "c"} {
+		if results[id] == nil || results[id].CommitSHA != "sha-"+id {
+			t.Errorf("result for %s = %+v, want merged", id, results[id])
+		}
+	}
+	// Speculation: c was tested on top of a and b in a single window
+	if len(backend.tested) != 3 {
+		t.Errorf("expected 3 test runs, got %d: %v", len(backend.tested), backend.tested)
+	}
+}
+
+func TestQueue_FailureRetestsCandidatesBehindIt(t *testing.T) {
+	backend := newFake()
+	backend.breaks["b"] = true
+	q := New(backend, 3)
+	enqueue(q, "a", "b", "c")
+
+	results := drain(t, q)
+
+	if !slices.Equal(backend.landed, []string{"a", "c"}) {
+		t.Errorf("landed = %v, want [a c]", backend.landed)
+	}
+	fb := results["b"].Feedback
+	if fb == nil || fb.Stage != proto.MergeStageTest || fb.BaseSHA != "base0000" || !slices.Equal(fb.Ahead, []string{"a"}) {
+		t.Errorf("feedback for b = %+v", fb)
+	}
+	// c was first tested with b in its tree, then retested alone on the head a landed on
+	if last := backend.tested[len(backend.tested)-1]; !slices.Equal(last, []string{"c"}) {
+		t.Errorf("expected c to be retested without b, tested trees: %v", backend.tested)
+	}
+	if results["c"] == nil || results["c"].CommitSHA == "" {
+		t.Errorf("expected c merged, got %+v", results["c"])
+	}
+}
+
+func TestQueue_ConflictIsSentBack(t *testing.T) {
+	backend := newFake()
+	backend.conflicts["a"] = true
+	q := New(backend, 2)
+	enqueue(q, "a", "b")
+
+	results := drain(t, q)
+
+	if fb := results["a"].Feedback; fb == nil || fb.Stage != proto.MergeStageRebase {
+		t.Errorf("feedback for a = %+v, want rebase failure", fb)
+	}
+	if results["b"] == nil || results["b"].CommitSHA != "sha-b" {
+		t.Errorf("expected b merged after a was removed, got %+v", results["b"])
+	}
+}
+
+func TestQueue_StaleCandidateIsRetestedThenGivenUp(t *testing.T) {
+	backend := newFake()
+	backend.stale["a"] = 1
+	backend.stale["b"] = maxStaleRetries + 1
+	q := New(backend, 1)
+	enqueue(q, "a", "b")
+
+	results := drain(t, q)
+
+	if results["a"] == nil || results["a"].CommitSHA != "sha-a" {
+		t.Errorf("expected a merged after one retest, got %+v", results["a"])
+	}
+	if results["b"] == nil || !errors.Is(results["b"].Err, ErrStale) {
+		t.Errorf("expected b to fail as stale, got %+v", results["b"])
+	}
+}
+
+func TestQueue_HeadErrorFailsWindow(t *testing.T) {
+	backend := newFake()
+	backend.headErr = errors.New("mirror unavailable")
+	q := New(backend, 2)
+	enqueue(q, "a", "b")
+
+	results := drain(t, q)
+
+	for _, id := range []string{"a", "b"} {
+		if results[id] == nil || results[id].Err == nil {
+			t.Errorf("expected error result for %s, got %+v", id, results[id])
+		}
+	}
+}
+
+func TestQueue_EnqueuePosition(t *testing.T) {
+	q := New(newFake(), 1)
+	if pos := q.Enqueue(&Candidate{StoryID: "a"}); pos != 1 {
+		t.Errorf("position = %d, want 1", pos)
+	}
+	if pos := q.Enqueue(&Candidate{StoryID: "b"}); pos != 2 {
+		t.Errorf("position = %d, want 2", pos)
+	}
+	if got := strings.Join(q.Pending(), ","); got != "a,b" {
+		t.Errorf("Pending() = %s", got)
+	}
+}
@@ -87,21 +87,30 @@ func mutexForPath(path string) *sync.Mutex {
 type Manager struct {
 	logger     *logx.Logger
 	projectDir string
+	repo       string // Further repository of a multi-repo product, or "" for repo_url
 }
 
 // NewManager creates a new mirror manager for the given project directory.
 func NewManager(projectDir string) *Manager {
+	return NewRepoManager(projectDir, "")
+}
+
+// NewRepoManager creates a mirror manager for one repository of a multi-repo
+// product, by the name stories record ("" for the primary repository).
+func NewRepoManager(projectDir, repo string) *Manager {
 	return &Manager{
 		logger:     logx.NewLogger("mirror"),
 		projectDir: projectDir,
+		repo:       repo,
 	}
 }
 
 // GetFetchURL returns the upstream URL based on forge provider.
 // When using Gitea forge, reads from runtime state (forge_state.json).
 // When using GitHub forge, returns the configured GitHub URL.
+// Gitea only hosts the primary repository; further repositories always fetch from their URL.
 func (m *Manager) GetFetchURL() (string, error) {
-	if config.GetForgeProvider() == config.ForgeProviderGitea {
+	if config.GetForgeProvider() == config.ForgeProviderGitea && m.repo == "" {
 		// Load forge state to get Gitea URL
 		state, err := forge.LoadState(m.projectDir)
 		if err != nil {
@@ -118,14 +127,7 @@ func (m *Manager) GetFetchURL() (string, error) {
 
 // getGitHubURL returns the GitHub repository URL from config.
 func (m *Manager) getGitHubURL() (string, error) {
-	cfg, err := config.GetConfig()
-	if err != nil {
-		return "", fmt.Errorf("failed to get config: %w", err)
-	}
-	if cfg.Git == nil || cfg.Git.RepoURL == "" {
-		return "", fmt.Errorf("no git repository configured")
-	}
-	return cfg.Git.RepoURL, nil
+	return config.GetRepoURL(m.repo)
 }
 
 // RefreshFromForge updates the mirror from the current forge (GitHub or Gitea).
@@ -257,16 +259,12 @@ func (m *Manager) getRemoteURL(ctx context.Context, mirrorPath string) (string,
 // GetMirrorPath returns the path to the mirror directory.
 // This extracts the repository name from the configured URL.
 func (m *Manager) GetMirrorPath() (string, error) {
-	cfg, err := config.GetConfig()
+	repoURL, err := m.getGitHubURL()
 	if err != nil {
-		return "", fmt.Errorf("failed to get config: %w", err)
-	}
-
-	if cfg.Git == nil || cfg.Git.RepoURL == "" {
-		return "", fmt.Errorf("no git repository configured")
+		return "", err
 	}
 
-	repoName := extractRepoName(cfg.Git.RepoURL)
+	repoName := extractRepoName(repoURL)
 	return filepath.Join(m.projectDir, ".mirrors", repoName), nil
 }
 
@@ -333,22 +331,16 @@ func (m *Manager) validateOrRecoverMirror(ctx context.Context, mirrorPath, repoU
 }
 
 // EnsureMirror creates or updates a git mirror.
-// Reads repository URL from config.GetConfig().Git.RepoURL.
+// Reads the repository URL from config: repo_url, or the manager's further repository.
 // In airplane mode with existing mirror, updates from Gitea instead of GitHub.
 // Returns the path to the mirror directory.
 func (m *Manager) EnsureMirror(ctx context.Context) (string, error) {
 	// Get git configuration
-	cfg, err := config.GetConfig()
+	repoURL, err := m.getGitHubURL()
 	if err != nil {
-		return "", fmt.Errorf("failed to get config: %w", err)
-	}
-
-	if cfg.Git == nil || cfg.Git.RepoURL == "" {
-		return "", fmt.Errorf("no git repository configured")
+		return "", err
 	}
 
-	repoURL := cfg.Git.RepoURL
-
 	// Create .mirrors directory
 	mirrorDir := filepath.Join(m.projectDir, ".mirrors")
 	err = os.MkdirAll(mirrorDir, 0755)
@@ -394,10 +386,13 @@ func (m *Manager) EnsureMirror(ctx context.Context) (string, error) {
 		m.logger.Info("✅ Initial commit created and pushed to GitHub")
 	}
 
-	// Detect and update the default branch in config if not already set correctly
-	err = m.updateDefaultBranch(ctx, repoMirrorPath)
-	if err != nil {
-		m.logger.Warn("Failed to detect default branch: %v (will use config value)", err)
+	// Detect and update the default branch in config if not already set correctly.
+	// The primary repository's default branch is the project's target branch.
+	if m.repo == "" {
+		err = m.updateDefaultBranch(ctx, repoMirrorPath)
+		if err != nil {
+			m.logger.Warn("Failed to detect default branch: %v (will use config value)", err)
+		}
 	}
 
 	return repoMirrorPath, nil
@@ -460,6 +455,74 @@ func gitAuthEnv() []string {
 	)
 }
 
+// GitAuthEnv returns the environment for host-side git commands that talk to
+// the forge directly (fetch, push), with credentials from gitAuthEnv.
+func GitAuthEnv() []string {
+	return gitAuthEnv()
+}
+
+// GetRemoteURL returns the mirror's upstream URL — GitHub, or Gitea in airplane mode.
+func (m *Manager) GetRemoteURL(ctx context.Context) (string, error) {
+	mirrorPath, err := m.GetMirrorPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to get mirror path: %w", err)
+	}
+	return m.getRemoteURL(ctx, mirrorPath)
+}
+
+// CreateBranch creates branch on the upstream at the mirror's head of from, so
+// coder clones and PRs can use it. An existing upstream branch is kept as is.
+func (m *Manager) CreateBranch(ctx context.Context, branch, from string) error {
+	mirrorPath, err := m.GetMirrorPath()
+	if err != nil {
+		return fmt.Errorf("failed to get mirror path: %w", err)
+	}
+
+	// Writes the mirror's refs, so it serializes with other writers (ADR 0027)
+	defer LockPath(mirrorPath)()
+
+	if !mirrorExists(mirrorPath) {
+		return fmt.Errorf("mirror does not exist at %s", mirrorPath)
+	}
+	return m.createBranch(ctx, mirrorPath, branch, from)
+}
+
+// createBranch implements CreateBranch. The caller must hold LockPath(mirrorPath).
+func (m *Manager) createBranch(ctx context.Context, mirrorPath, branch, from string) error {
+	repoURL, err := m.getRemoteURL(ctx, mirrorPath)
+	if err != nil {
+		return err
+	}
+
+	lsCmd := exec.CommandContext(ctx, "git", "ls-remote", "--heads", repoURL, "refs/heads/"+branch)
+	lsCmd.Dir = mirrorPath
+	lsCmd.Env = gitAuthEnv()
+	output, err := lsCmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git ls-remote failed: %w\nOutput: %s", err, string(output))
+	}
+	if strings.TrimSpace(string(output)) != "" {
+		// Already upstream (e.g. created before a restart); make sure the mirror has it
+		return updateGitMirror(ctx, mirrorPath)
+	}
+
+	// A mirror remote refuses explicit refspecs, so push to its URL
+	pushCmd := exec.CommandContext(ctx, "git", "push", repoURL, fmt.Sprintf("refs/heads/%s:refs/heads/%s", from, branch))
+	pushCmd.Dir = mirrorPath
+	pushCmd.Env = gitAuthEnv()
+	if output, err := pushCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git push %s failed: %w\nOutput: %s", branch, err, string(output))
+	}
+
+	refCmd := exec.CommandContext(ctx, "git", "update-ref", "refs/heads/"+branch, "refs/heads/"+from)
+	refCmd.Dir = mirrorPath
+	if output, err := refCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git update-ref %s failed: %w\nOutput: %s", branch, err, string(output))
+	}
+	m.logger.Info("🌿 Created branch %s from %s", branch, from)
+	return nil
+}
+
 // extractRepoName extracts the repository name from a git URL.
 func extractRepoName(repoURL string) string {
 	// Remove .git suffix if present
@@ -583,7 +646,10 @@ func (m *Manager) initializeEmptyRepository(ctx context.Context, mirrorPath stri
 		return fmt.Errorf("failed to get config: %w", err)
 	}
 
-	repoURL := cfg.Git.RepoURL
+	repoURL, err := m.getGitHubURL()
+	if err != nil {
+		return err
+	}
 	defaultBranch := cfg.Git.TargetBranch
 	if defaultBranch == "" {
 		defaultBranch = defaultBranchName
@@ -718,6 +784,18 @@ func (m *Manager) HasMaestroMd(ctx context.Context) (bool, error) {
 // LoadMaestroMd reads MAESTRO.md content from the repository mirror.
 // Returns empty string and nil error if file doesn't exist.
 func (m *Manager) LoadMaestroMd(ctx context.Context) (string, error) {
+	return m.loadFile(ctx, ".maestro/MAESTRO.md")
+}
+
+// LoadKnowledgeGraph reads the knowledge graph (.maestro/knowledge.dot) from the repository mirror.
+// Returns empty string and nil error if file doesn't exist.
+func (m *Manager) LoadKnowledgeGraph(ctx context.Context) (string, error) {
+	return m.loadFile(ctx, ".maestro/knowledge.dot")
+}
+
+// loadFile reads a file at the target branch from the repository mirror.
+// Returns empty string and nil error if file doesn't exist.
+func (m *Manager) loadFile(ctx context.Context, path string) (string, error) {
 	mirrorPath, err := m.GetMirrorPath()
 	if err != nil {
 		return "", fmt.Errorf("failed to get mirror path: %w", err)
@@ -738,7 +816,7 @@ func (m *Manager) LoadMaestroMd(ctx context.Context) (string, error) {
 	}
 
 	// Use git show to read file content from the bare mirror
-	cmd := exec.CommandContext(ctx, "git", "show", fmt.Sprintf("%s:.maestro/MAESTRO.md", branch))
+	cmd := exec.CommandContext(ctx, "git", "show", fmt.Sprintf("%s:%s", branch, path))
 	cmd.Dir = mirrorPath
 	output, err := cmd.CombinedOutput()
 
@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"orchestrator/pkg/config"
 )
 
 func TestExtractRepoName(t *testing.T) {
@@ -57,6 +59,30 @@ func TestExtractRepoName(t *testing.T) {
 	}
 }
 
+func TestGetMirrorPath_Repos(t *testing.T) {
+	config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{
+		RepoURL: "https://github.com/acme/api.git",
+		Repos:   []config.RepoConfig{{Name: "web", URL: "https://github.com/acme/web-client.git"}},
+	}})
+	defer config.SetConfigForTesting(nil)
+
+	projectDir := t.TempDir()
+	tests := map[string]string{"": "api.git", "web": "web-client.git"}
+	for repo, want := range tests {
+		path, err := NewRepoManager(projectDir, repo).GetMirrorPath()
+		if err != nil {
+			t.Fatalf("GetMirrorPath(%q) error: %v", repo, err)
+		}
+		if path != filepath.Join(projectDir, ".mirrors", want) {
+			t.Errorf("GetMirrorPath(%q) = %s, want .mirrors/%s", repo, path, want)
+		}
+	}
+
+	if _, err := NewRepoManager(projectDir, "mobile").GetMirrorPath(); err == nil {
+		t.Error("Expected an error for an unknown repository")
+	}
+}
+
 func TestMirrorExists(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -265,3 +291,44 @@ func TestEnsureRemoteURL_NoChangeWhenSame(t *testing.T) {
 		t.Errorf("Expected URL '%s', got '%s'", targetURL, got)
 	}
 }
+
+func TestCreateBranch(t *testing.T) {
+	tmpDir := t.TempDir()
+	upstream := filepath.Join(tmpDir, "upstream.git")
+	initBareRepo(t, upstream)
+
+	// Give the upstream a main branch with one commit
+	work := filepath.Join(tmpDir, "work")
+	for _, args := range [][]string{
+		{"init", "-b", "main", work},
+		{"-C", work, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "initial"},
+		{"-C", work, "push", upstream, "main"},
+		{"clone", "--mirror", upstream, filepath.Join(tmpDir, "mirror.git")},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	mirrorPath := filepath.Join(tmpDir, "mirror.git")
+
+	mgr := NewManager(tmpDir)
+	ctx := context.Background()
+	if err := mgr.createBranch(ctx, mirrorPath, "maestro/epic/spec-1", "main"); err != nil {
+		t.Fatalf("createBranch failed: %v", err)
+	}
+	// A second call keeps the existing branch
+	if err := mgr.createBranch(ctx, mirrorPath, "maestro/epic/spec-1", "main"); err != nil {
+		t.Fatalf("createBranch for existing branch failed: %v", err)
+	}
+
+	for _, repo := range []string{upstream, mirrorPath} {
+		output, err := exec.Command("git", "-C", repo, "rev-parse", "refs/heads/main", "refs/heads/maestro/epic/spec-1").CombinedOutput()
+		if err != nil {
+			t.Fatalf("epic branch missing in %s: %v\n%s", repo, err, output)
+		}
+		shas := strings.Fields(string(output))
+		if len(shas) != 2 || shas[0] != shas[1] {
+			t.Errorf("Expected epic branch at main's head in %s, got %v", repo, shas)
+		}
+	}
+}
@@ -11,11 +11,24 @@ import (
 )
 
 // Spec represents a specification document.
+// Version starts at 1 and increases with each approved amendment; Diff is the
+// requirement-level diff from the previous version (empty for version 1).
 type Spec struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	ProcessedAt *time.Time `json:"processed_at,omitempty"`
 	ID          string     `json:"id"`
 	Content     string     `json:"content"`
+	Diff        string     `json:"diff,omitempty"`
+	Version     int        `json:"version"`
+}
+
+// SpecVersion is one entry of a spec's version history.
+type SpecVersion struct {
+	CreatedAt time.Time `json:"created_at"`
+	SpecID    string    `json:"spec_id"`
+	Content   string    `json:"content"`
+	Diff      string    `json:"diff,omitempty"`
+	Version   int       `json:"version"`
 }
 
 // Story represents a development story generated from a spec.
@@ -30,7 +43,8 @@ type Story struct {
 	Status       string `json:"status"`
 	Priority     int    `json:"priority"`
 	ApprovedPlan string `json:"approved_plan,omitempty"`
-	StoryType    string `json:"story_type"` // "devops" or "app"
+	StoryType    string `json:"story_type"`     // "devops" or "app"
+	Repo         string `json:"repo,omitempty"` // Repository the story changes in a multi-repo product ("" for the primary one)
 
 	// Timestamps
 	CreatedAt   time.Time  `json:"created_at"`
@@ -50,6 +64,13 @@ type Story struct {
 	CommitHash        string `json:"commit_hash,omitempty"`        // Commit hash from merge
 	CompletionSummary string `json:"completion_summary,omitempty"` // Summary of what was completed
 
+	// Traceability
+	RequirementIDs []string `json:"requirement_ids,omitempty"` // Spec requirement IDs (R-001) the story covers
+	Verification   string   `json:"verification,omitempty"`    // Acceptance-criteria verification results as JSON
+
+	// Scheduling
+	PredictedPaths []string `json:"predicted_paths,omitempty"` // Files and directories (ending in /) the story is predicted to touch
+
 	// Extensibility
 	Metadata string `json:"metadata,omitempty"` // JSON blob for extensibility
 
@@ -165,23 +165,88 @@ func NewDatabaseOperations(db *sql.DB, sessionID string) *DatabaseOperations {
 	}
 }
 
-// UpsertSpec inserts or updates a spec record.
+// UpsertSpec inserts or updates a spec record and records its version in the
+// spec history. Upserting a version that is already recorded (e.g. to set
+// processed_at) leaves the history unchanged.
 func (ops *DatabaseOperations) UpsertSpec(spec *Spec) error {
+	version := max(spec.Version, 1)
 	query := `
-		INSERT INTO specs (id, session_id, content, created_at, processed_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO specs (id, session_id, content, version, created_at, processed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			content = excluded.content,
+			version = excluded.version,
 			processed_at = excluded.processed_at
 	`
 
-	_, err := ops.db.Exec(query, spec.ID, ops.sessionID, spec.Content, spec.CreatedAt, spec.ProcessedAt)
+	_, err := ops.db.Exec(query, spec.ID, ops.sessionID, spec.Content, version, spec.CreatedAt, spec.ProcessedAt)
 	if err != nil {
 		return fmt.Errorf("failed to upsert spec %s: %w", spec.ID, err)
 	}
+
+	_, err = ops.db.Exec(`
+		INSERT OR IGNORE INTO spec_versions (spec_id, session_id, version, content, diff)
+		VALUES (?, ?, ?, ?, ?)
+	`, spec.ID, ops.sessionID, version, spec.Content, spec.Diff)
+	if err != nil {
+		return fmt.Errorf("failed to record version %d of spec %s: %w", version, spec.ID, err)
+	}
 	return nil
 }
 
+// GetSpecVersions returns the version history of a spec, oldest first.
+func (ops *DatabaseOperations) GetSpecVersions(specID string) ([]*SpecVersion, error) {
+	rows, err := ops.db.Query(`
+		SELECT spec_id, version, content, diff, created_at FROM spec_versions
+		WHERE session_id = ? AND spec_id = ?
+		ORDER BY version
+	`, ops.sessionID, specID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query versions of spec %s: %w", specID, err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var versions []*SpecVersion
+	for rows.Next() {
+		v := &SpecVersion{}
+		if err := rows.Scan(&v.SpecID, &v.Version, &v.Content, &v.Diff, &v.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan spec version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read versions of spec %s: %w", specID, err)
+	}
+	return versions, nil
+}
+
+// JoinRequirementIDs encodes requirement IDs for the stories.requirement_ids column.
+// Requirement IDs (R-001) never contain commas.
+func JoinRequirementIDs(ids []string) string {
+	return strings.Join(ids, ",")
+}
+
+// SplitRequirementIDs decodes the stories.requirement_ids column.
+func SplitRequirementIDs(column string) []string {
+	if column == "" {
+		return nil
+	}
+	return strings.Split(column, ",")
+}
+
+// JoinPaths encodes file paths for the stories.predicted_paths column.
+func JoinPaths(paths []string) string {
+	return strings.Join(paths, "\n")
+}
+
+// SplitPaths decodes the stories.predicted_paths column.
+func SplitPaths(column string) []string {
+	if column == "" {
+		return nil
+	}
+	return strings.Split(column, "\n")
+}
+
 // UpsertStory inserts or updates a story record.
 func (ops *DatabaseOperations) UpsertStory(story *Story) error {
 	query := `
@@ -189,8 +254,9 @@ func (ops *DatabaseOperations) UpsertStory(story *Story) error {
 			id, session_id, spec_id, title, content, status, priority, approved_plan,
 			created_at, started_at, completed_at, assigned_agent,
 			tokens_used, cost_usd, metadata, story_type, pr_id, commit_hash, completion_summary,
-			hold_reason, hold_since, hold_owner, hold_note, blocked_by_failure_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			hold_reason, hold_since, hold_owner, hold_note, blocked_by_failure_id,
+			requirement_ids, verification, repo, predicted_paths
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			spec_id = excluded.spec_id,
 			title = excluded.title,
@@ -212,7 +278,11 @@ func (ops *DatabaseOperations) UpsertStory(story *Story) error {
 			hold_since = excluded.hold_since,
 			hold_owner = excluded.hold_owner,
 			hold_note = excluded.hold_note,
-			blocked_by_failure_id = excluded.blocked_by_failure_id
+			blocked_by_failure_id = excluded.blocked_by_failure_id,
+			requirement_ids = excluded.requirement_ids,
+			verification = excluded.verification,
+			repo = excluded.repo,
+			predicted_paths = excluded.predicted_paths
 	`
 
 	_, err := ops.db.Exec(query,
@@ -221,6 +291,7 @@ func (ops *DatabaseOperations) UpsertStory(story *Story) error {
 		story.CompletedAt, story.AssignedAgent, story.TokensUsed,
 		story.CostUSD, story.Metadata, story.StoryType, story.PRID, story.CommitHash, story.CompletionSummary,
 		story.HoldReason, story.HoldSince, story.HoldOwner, story.HoldNote, story.BlockedByFailureID,
+		JoinRequirementIDs(story.RequirementIDs), story.Verification, story.Repo, JoinPaths(story.PredictedPaths),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to upsert story %s: %w", story.ID, err)
@@ -477,11 +548,11 @@ func (ops *DatabaseOperations) GetStoryByID(storyID string) (*Story, error) {
 
 // GetSpecByID returns a spec by its ID.
 func (ops *DatabaseOperations) GetSpecByID(specID string) (*Spec, error) {
-	query := `SELECT id, content, created_at, processed_at FROM specs WHERE session_id = ? AND id = ?`
+	query := `SELECT id, content, version, created_at, processed_at FROM specs WHERE session_id = ? AND id = ?`
 
 	spec := &Spec{}
 	err := ops.db.QueryRow(query, ops.sessionID, specID).Scan(
-		&spec.ID, &spec.Content, &spec.CreatedAt, &spec.ProcessedAt,
+		&spec.ID, &spec.Content, &spec.Version, &spec.CreatedAt, &spec.ProcessedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -769,8 +840,8 @@ func (ops *DatabaseOperations) BatchUpsertStoriesWithDependencies(req *BatchUpse
 		INSERT INTO stories (
 			id, session_id, spec_id, title, content, status, priority, approved_plan,
 			created_at, started_at, completed_at, assigned_agent,
-			tokens_used, cost_usd, metadata, story_type
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tokens_used, cost_usd, metadata, story_type, requirement_ids, repo, predicted_paths
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(id) DO UPDATE SET
 			spec_id = excluded.spec_id,
 			title = excluded.title,
@@ -784,7 +855,10 @@ func (ops *DatabaseOperations) BatchUpsertStoriesWithDependencies(req *BatchUpse
 			tokens_used = excluded.tokens_used,
 			cost_usd = excluded.cost_usd,
 			metadata = excluded.metadata,
-			story_type = excluded.story_type
+			story_type = excluded.story_type,
+			requirement_ids = excluded.requirement_ids,
+			repo = excluded.repo,
+			predicted_paths = excluded.predicted_paths
 	`
 
 	for _, story := range req.Stories {
@@ -792,7 +866,8 @@ func (ops *DatabaseOperations) BatchUpsertStoriesWithDependencies(req *BatchUpse
 			story.ID, ops.sessionID, story.SpecID, story.Title, story.Content, story.Status,
 			story.Priority, story.ApprovedPlan, story.CreatedAt, story.StartedAt,
 			story.CompletedAt, story.AssignedAgent, story.TokensUsed,
-			story.CostUSD, story.Metadata, story.StoryType,
+			story.CostUSD, story.Metadata, story.StoryType, JoinRequirementIDs(story.RequirementIDs), story.Repo,
+			JoinPaths(story.PredictedPaths),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to upsert story %s: %w", story.ID, err)
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 // Helper function to create a new database for each test.
@@ -59,6 +60,50 @@ func TestDatabaseOperations(t *testing.T) {
 		}
 	})
 
+	// Test spec versioning: amendments bump the version and keep the history
+	t.Run("SpecVersions", func(t *testing.T) {
+		ops, cleanup := createTestDB(t)
+		defer cleanup()
+
+		specID := GenerateSpecID()
+		spec := &Spec{ID: specID, Content: "v1 content"}
+		if err := ops.UpsertSpec(spec); err != nil {
+			t.Fatalf("Failed to upsert spec: %v", err)
+		}
+		// Re-upserting the same version (e.g. to mark it processed) adds no history
+		now := time.Now()
+		spec.ProcessedAt = &now
+		if err := ops.UpsertSpec(spec); err != nil {
+			t.Fatalf("Failed to re-upsert spec: %v", err)
+		}
+		amended := &Spec{ID: specID, Content: "v2 content", Version: 2, Diff: "### Added R-003: Export"}
+		if err := ops.UpsertSpec(amended); err != nil {
+			t.Fatalf("Failed to upsert amendment: %v", err)
+		}
+
+		latest, err := ops.GetSpecByID(specID)
+		if err != nil {
+			t.Fatalf("Failed to get spec: %v", err)
+		}
+		if latest.Version != 2 || latest.Content != "v2 content" {
+			t.Errorf("Expected latest version 2, got %d (%q)", latest.Version, latest.Content)
+		}
+
+		versions, err := ops.GetSpecVersions(specID)
+		if err != nil {
+			t.Fatalf("Failed to get spec versions: %v", err)
+		}
+		if len(versions) != 2 {
+			t.Fatalf("Expected 2 versions, got %d", len(versions))
+		}
+		if versions[0].Version != 1 || versions[0].Content != "v1 content" || versions[0].Diff != "" {
+			t.Errorf("Unexpected version 1: %+v", versions[0])
+		}
+		if versions[1].Version != 2 || versions[1].Diff != amended.Diff {
+			t.Errorf("Unexpected version 2: %+v", versions[1])
+		}
+	})
+
 	// Test story operations
 	t.Run("StoryOperations", func(t *testing.T) {
 		ops, cleanup := createTestDB(t)
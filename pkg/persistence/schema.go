@@ -13,7 +13,7 @@ import (
 )
 
 // CurrentSchemaVersion defines the current schema version for migration support.
-const CurrentSchemaVersion = 23
+const CurrentSchemaVersion = 28
 
 // InitializeDatabase creates and initializes the SQLite database with the required schema.
 // This function is idempotent and safe to call multiple times.
@@ -137,6 +137,16 @@ func runMigration(db *sql.DB, version int) error {
 		return migrateToVersion22(db)
 	case 23:
 		return migrateToVersion23(db)
+	case 24:
+		return migrateToVersion24(db)
+	case 25:
+		return migrateToVersion25(db)
+	case 26:
+		return migrateToVersion26(db)
+	case 27:
+		return migrateToVersion27(db)
+	case 28:
+		return migrateToVersion28(db)
 	default:
 		return fmt.Errorf("unknown migration version: %d", version)
 	}
@@ -1096,6 +1106,7 @@ func createSchema(db *sql.DB) error {
 			id TEXT PRIMARY KEY,
 			session_id TEXT NOT NULL,
 			content TEXT NOT NULL,
+			version INTEGER NOT NULL DEFAULT 1,
 			created_at DATETIME DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
 			processed_at DATETIME
 		)`,
@@ -1125,7 +1136,11 @@ func createSchema(db *sql.DB) error {
 			hold_since DATETIME,
 			hold_owner TEXT,
 			hold_note TEXT,
-			blocked_by_failure_id TEXT
+			blocked_by_failure_id TEXT,
+			requirement_ids TEXT,
+			verification TEXT,
+			repo TEXT,
+			predicted_paths TEXT
 		)`,
 
 		// Story dependencies junction table
@@ -1510,6 +1525,9 @@ func createSchema(db *sql.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_failures_signature ON failures(signature)",
 	}
 
+	tables = append(tables, searchCacheDDL...)
+	tables = append(tables, specVersionsDDL...)
+
 	// Execute table creation
 	for _, ddl := range tables {
 		if _, err := db.Exec(ddl); err != nil {
@@ -1560,6 +1578,103 @@ func createSchema(db *sql.DB) error {
 	return nil
 }
 
+// searchCacheDDL is the web_search cache, shared by createSchema and the v24
+// migration so the two cannot drift.
+//
+// A row is one query a provider answered, so the rows of a session are also
+// its count against search.max_queries_per_session. The results are stored
+// before domain filtering: a changed allow or deny list applies to cached
+// answers too, without a search.
+var searchCacheDDL = []string{ //nolint:gochecknoglobals // shared DDL
+	`CREATE TABLE IF NOT EXISTS search_cache (
+		session_id TEXT NOT NULL,
+		provider TEXT NOT NULL,
+		query TEXT NOT NULL,
+		max_results INTEGER NOT NULL,
+		results_json TEXT NOT NULL,
+		created_at DATETIME DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+		PRIMARY KEY (session_id, provider, query, max_results)
+	)`,
+}
+
+// migrateToVersion24 adds the web_search result cache.
+func migrateToVersion24(db *sql.DB) error {
+	for _, ddl := range searchCacheDDL {
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("failed to create search cache: %w", err)
+		}
+	}
+	return nil
+}
+
+// specVersionsDDL is the spec version history, shared by createSchema and the
+// v25 migration. The specs table keeps the latest version; every version,
+// with the requirement-level diff that produced it, is kept here.
+var specVersionsDDL = []string{ //nolint:gochecknoglobals // shared DDL
+	`CREATE TABLE IF NOT EXISTS spec_versions (
+		spec_id TEXT NOT NULL,
+		session_id TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		content TEXT NOT NULL,
+		diff TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT (strftime('%Y-%m-%dT%H:%M:%fZ','now')),
+		PRIMARY KEY (spec_id, version)
+	)`,
+}
+
+// migrateToVersion25 adds spec versions: a version column on specs and the
+// spec_versions history table.
+func migrateToVersion25(db *sql.DB) error {
+	if !tableHasColumn(db, "specs", "version") {
+		if _, err := db.Exec("ALTER TABLE specs ADD COLUMN version INTEGER NOT NULL DEFAULT 1"); err != nil {
+			return fmt.Errorf("failed to add specs.version: %w", err)
+		}
+	}
+	for _, ddl := range specVersionsDDL {
+		if _, err := db.Exec(ddl); err != nil {
+			return fmt.Errorf("failed to create spec versions: %w", err)
+		}
+	}
+	return nil
+}
+
+// migrateToVersion26 adds traceability columns to stories: the spec requirement
+// IDs a story covers and its acceptance-criteria verification results.
+func migrateToVersion26(db *sql.DB) error {
+	for _, column := range []string{"requirement_ids", "verification"} {
+		if tableHasColumn(db, "stories", column) {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE stories ADD COLUMN %s TEXT", column)); err != nil {
+			return fmt.Errorf("failed to add stories.%s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// migrateToVersion27 adds the repository a story changes in a multi-repo product.
+func migrateToVersion27(db *sql.DB) error {
+	if tableHasColumn(db, "stories", "repo") {
+		return nil
+	}
+	if _, err := db.Exec("ALTER TABLE stories ADD COLUMN repo TEXT"); err != nil {
+		return fmt.Errorf("failed to add stories.repo: %w", err)
+	}
+	return nil
+}
+
+// migrateToVersion28 adds the paths a story is predicted to touch, used to
+// keep stories that would collide from running at the same time.
+func migrateToVersion28(db *sql.DB) error {
+	if tableHasColumn(db, "stories", "predicted_paths") {
+		return nil
+	}
+	if _, err := db.Exec("ALTER TABLE stories ADD COLUMN predicted_paths TEXT"); err != nil {
+		return fmt.Errorf("failed to add stories.predicted_paths: %w", err)
+	}
+	return nil
+}
+
 // setSchemaVersion records the current schema version.
 func setSchemaVersion(db *sql.DB, version int) error {
 	_, err := db.Exec(`
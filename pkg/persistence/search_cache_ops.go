@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// GetCachedSearch returns the results this session stored for a query, as
+// the JSON StoreSearchResults was given.
+func (ops *DatabaseOperations) GetCachedSearch(provider, query string, maxResults int) (string, bool, error) {
+	var resultsJSON string
+	err := ops.db.QueryRow(`
+		SELECT results_json FROM search_cache
+		WHERE session_id = ? AND provider = ? AND query = ? AND max_results = ?
+	`, ops.sessionID, provider, query, maxResults).Scan(&resultsJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read cached search: %w", err)
+	}
+	return resultsJSON, true, nil
+}
+
+// StoreSearchResults caches a provider's answer to a query for the rest of
+// the session. Storing the same query twice keeps the later answer, which
+// only happens when two agents missed the cache at once.
+func (ops *DatabaseOperations) StoreSearchResults(provider, query string, maxResults int, resultsJSON string) error {
+	_, err := ops.db.Exec(`
+		INSERT OR REPLACE INTO search_cache (session_id, provider, query, max_results, results_json)
+		VALUES (?, ?, ?, ?, ?)
+	`, ops.sessionID, provider, query, maxResults, resultsJSON)
+	if err != nil {
+		return fmt.Errorf("failed to cache search: %w", err)
+	}
+	return nil
+}
+
+// CountSearchQueries returns how many queries a provider answered in this
+// session: the cache's rows, one per distinct query.
+func (ops *DatabaseOperations) CountSearchQueries() (int, error) {
+	var count int
+	if err := ops.db.QueryRow(`SELECT COUNT(*) FROM search_cache WHERE session_id = ?`, ops.sessionID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count searches: %w", err)
+	}
+	return count, nil
+}
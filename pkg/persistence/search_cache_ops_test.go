@@ -0,0 +1,43 @@
+package persistence
+
+import "testing"
+
+// The cache is per session, per provider and per result count, and its rows
+// are the session's query count.
+func TestSearchCache(t *testing.T) {
+	ops, cleanup := createTestDB(t)
+	defer cleanup()
+
+	if _, found, err := ops.GetCachedSearch("brave", "go 1.26 release notes", 5); err != nil || found {
+		t.Fatalf("empty cache: found=%v err=%v", found, err)
+	}
+	if err := ops.StoreSearchResults("brave", "go 1.26 release notes", 5, `[{"title":"Go 1.26"}]`); err != nil {
+		t.Fatalf("StoreSearchResults: %v", err)
+	}
+	got, found, err := ops.GetCachedSearch("brave", "go 1.26 release notes", 5)
+	if err != nil || !found || got != `[{"title":"Go 1.26"}]` {
+		t.Fatalf("GetCachedSearch = %q, %v, %v", got, found, err)
+	}
+	for _, miss := range []struct {
+		provider string
+		results  int
+	}{{"searxng", 5}, {"brave", 10}} {
+		if _, found, _ := ops.GetCachedSearch(miss.provider, "go 1.26 release notes", miss.results); found {
+			t.Errorf("%s/%d answered from another provider's or size's entry", miss.provider, miss.results)
+		}
+	}
+
+	other := NewDatabaseOperations(ops.db, "other-session")
+	if _, found, _ := other.GetCachedSearch("brave", "go 1.26 release notes", 5); found {
+		t.Error("one session read another's cache")
+	}
+	if err := ops.StoreSearchResults("brave", "go 1.26 release notes", 5, `[]`); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if count, err := ops.CountSearchQueries(); err != nil || count != 1 {
+		t.Fatalf("CountSearchQueries = %d, %v; want 1", count, err)
+	}
+	if count, _ := other.CountSearchQueries(); count != 0 {
+		t.Fatalf("other session counted %d queries", count)
+	}
+}
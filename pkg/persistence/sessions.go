@@ -547,7 +547,8 @@ func GetAllStoriesForSession(db *sql.DB, sessionID string) ([]*Story, error) {
 		SELECT id, spec_id, title, content, status, priority, approved_plan,
 		       created_at, started_at, completed_at, assigned_agent,
 		       tokens_used, cost_usd, metadata, story_type,
-		       COALESCE(hold_reason, '') AS hold_reason, hold_since, COALESCE(hold_owner, '') AS hold_owner, COALESCE(hold_note, '') AS hold_note, COALESCE(blocked_by_failure_id, '') AS blocked_by_failure_id
+		       COALESCE(hold_reason, '') AS hold_reason, hold_since, COALESCE(hold_owner, '') AS hold_owner, COALESCE(hold_note, '') AS hold_note, COALESCE(blocked_by_failure_id, '') AS blocked_by_failure_id,
+		       COALESCE(pr_id, ''), COALESCE(commit_hash, ''), COALESCE(requirement_ids, ''), COALESCE(verification, ''), COALESCE(repo, ''), COALESCE(predicted_paths, '')
 		FROM stories
 		WHERE session_id = ?
 		ORDER BY priority DESC, created_at ASC
@@ -560,6 +561,7 @@ func GetAllStoriesForSession(db *sql.DB, sessionID string) ([]*Story, error) {
 	var stories []*Story
 	for rows.Next() {
 		story := &Story{}
+		var requirementIDs, predictedPaths string
 		scanErr := rows.Scan(
 			&story.ID, &story.SpecID, &story.Title, &story.Content,
 			&story.Status, &story.Priority, &story.ApprovedPlan,
@@ -568,10 +570,13 @@ func GetAllStoriesForSession(db *sql.DB, sessionID string) ([]*Story, error) {
 			&story.Metadata, &story.StoryType,
 			&story.HoldReason, &story.HoldSince, &story.HoldOwner,
 			&story.HoldNote, &story.BlockedByFailureID,
+			&story.PRID, &story.CommitHash, &requirementIDs, &story.Verification, &story.Repo, &predictedPaths,
 		)
 		if scanErr != nil {
 			return nil, fmt.Errorf("failed to scan story: %w", scanErr)
 		}
+		story.RequirementIDs = SplitRequirementIDs(requirementIDs)
+		story.PredictedPaths = SplitPaths(predictedPaths)
 		stories = append(stories, story)
 	}
 
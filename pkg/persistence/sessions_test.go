@@ -48,6 +48,13 @@ func setupTestDB(t *testing.T) *sql.DB {
 		hold_owner TEXT DEFAULT '',
 		hold_note TEXT DEFAULT '',
 		blocked_by_failure_id TEXT DEFAULT '',
+		pr_id TEXT,
+		commit_hash TEXT,
+		completion_summary TEXT,
+		requirement_ids TEXT,
+		verification TEXT,
+		repo TEXT,
+		predicted_paths TEXT,
 		PRIMARY KEY (id, session_id),
 		FOREIGN KEY (session_id) REFERENCES sessions(session_id)
 	);
@@ -450,6 +457,84 @@ func TestGetAllStoriesForSession(t *testing.T) {
 	}
 }
 
+func TestGetAllStoriesForSession_Traceability(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	sessionID := "session-trace"
+	_, err := db.Exec(`
+		INSERT INTO stories (id, session_id, spec_id, title, content, status, story_type, pr_id, commit_hash, requirement_ids, verification)
+		VALUES ('story-1', ?, 'spec-1', 'Title', 'Content', 'done', 'app', '42', 'abc123', ?, '{"status":"pass"}')
+	`, sessionID, JoinRequirementIDs([]string{"R-001", "R-003"}))
+	if err != nil {
+		t.Fatalf("Failed to insert story: %v", err)
+	}
+
+	result, err := GetAllStoriesForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetAllStoriesForSession failed: %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 story, got %d", len(result))
+	}
+	got := result[0]
+	if got.PRID != "42" || got.CommitHash != "abc123" || got.Verification != `{"status":"pass"}` {
+		t.Errorf("Unexpected completion data: pr=%q commit=%q verification=%q", got.PRID, got.CommitHash, got.Verification)
+	}
+	if len(got.RequirementIDs) != 2 || got.RequirementIDs[0] != "R-001" || got.RequirementIDs[1] != "R-003" {
+		t.Errorf("Expected requirement IDs [R-001 R-003], got %v", got.RequirementIDs)
+	}
+}
+
+func TestGetAllStoriesForSession_Repo(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	sessionID := "session-repo"
+	_, err := db.Exec(`
+		INSERT INTO stories (id, session_id, spec_id, title, content, status, story_type, repo)
+		VALUES ('story-1', ?, 'spec-1', 'Title', 'Content', 'new', 'app', 'web'),
+		       ('story-2', ?, 'spec-1', 'Title', 'Content', 'new', 'app', NULL)
+	`, sessionID, sessionID)
+	if err != nil {
+		t.Fatalf("Failed to insert stories: %v", err)
+	}
+
+	result, err := GetAllStoriesForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetAllStoriesForSession failed: %v", err)
+	}
+	repos := map[string]string{}
+	for _, story := range result {
+		repos[story.ID] = story.Repo
+	}
+	if repos["story-1"] != "web" || repos["story-2"] != "" {
+		t.Errorf("Expected repos web and primary, got %v", repos)
+	}
+}
+
+func TestGetAllStoriesForSession_PredictedPaths(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	sessionID := "session-paths"
+	_, err := db.Exec(`
+		INSERT INTO stories (id, session_id, spec_id, title, content, status, story_type, predicted_paths)
+		VALUES ('story-1', ?, 'spec-1', 'Title', 'Content', 'new', 'app', ?)
+	`, sessionID, JoinPaths([]string{"pkg/auth/", "cmd/main.go"}))
+	if err != nil {
+		t.Fatalf("Failed to insert story: %v", err)
+	}
+
+	result, err := GetAllStoriesForSession(db, sessionID)
+	if err != nil {
+		t.Fatalf("GetAllStoriesForSession failed: %v", err)
+	}
+	if len(result) != 1 || len(result[0].PredictedPaths) != 2 || result[0].PredictedPaths[1] != "cmd/main.go" {
+		t.Errorf("Expected predicted paths to round-trip, got %+v", result)
+	}
+}
+
 func TestGetAllStoriesForSession_WithDependencies(t *testing.T) {
 	db := setupTestDB(t)
 	defer func() { _ = db.Close() }()
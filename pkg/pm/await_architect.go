@@ -112,6 +112,22 @@ func (d *Driver) handleAwaitArchitect(ctx context.Context) (proto.State, error)
 					d.logger.Info("📝 Approval feedback: %s", approvalResult.Feedback)
 				}
 
+				// Tell the source issue (if any) that its spec is in development
+				if awaitingSpecType != "hotfix" {
+					specTitle := utils.GetMapFieldOr[string](d.GetDraftSpecMetadata(), "title", "")
+					d.commentOnSourceIssue(formatSpecApprovedComment(specTitle))
+				}
+
+				// Record the approved spec so later amendments can be diffed against it
+				switch awaitingSpecType {
+				case "user":
+					d.SetStateData(StateKeyApprovedSpecMd, utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyUserSpecMd, ""))
+					d.SetStateData(StateKeySpecVersion, 1)
+				case "amendment":
+					d.SetStateData(StateKeyApprovedSpecMd, utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyUserSpecMd, ""))
+					d.SetStateData(StateKeySpecVersion, d.specVersion()+1)
+				}
+
 				// Clear user spec data from state - the spec has been submitted and we
 				// don't want stale data prepended to future hotfixes.
 				// The conversation context still has the spec history for PM reference.
@@ -122,6 +138,9 @@ func (d *Driver) handleAwaitArchitect(ctx context.Context) (proto.State, error)
 				d.SetStateData(StateKeyIsHotfix, nil)
 				d.SetStateData(StateKeyTurnCount, nil)
 				d.SetStateData(StateKeyAwaitingSpecType, nil)
+				d.SetStateData(StateKeyInterviewCoverage, nil)
+				d.SetStateData(StateKeyIsAmendment, nil)
+				d.SetStateData(StateKeyAmendmentDiff, nil)
 
 				// Re-run bootstrap detection to refresh bootstrap state.
 				// This will either regenerate bootstrap spec (if something's still missing)
@@ -138,7 +157,8 @@ func (d *Driver) handleAwaitArchitect(ctx context.Context) (proto.State, error)
 					"The specification has been approved by the architect and submitted for development. "+
 						"Use the chat_ask_user tool to inform the user of this good news. Let them know you'll notify them "+
 						"when there's a demo ready or when development completes. Also let them know they can request "+
-						"tweaks or quick changes in the meantime. IMPORTANT: You MUST call chat_ask_user to post this message.")
+						"tweaks or quick changes in the meantime, and that changes to the requirements themselves can be "+
+						"submitted as an amendment of the approved spec. IMPORTANT: You MUST call chat_ask_user to post this message.")
 
 				// Transition to WORKING so PM generates response to user
 				return StateWorking, nil
@@ -206,6 +226,9 @@ func (d *Driver) handleStoryCompleteNotification(payload *proto.MessagePayload)
 		d.detectAndStoreBootstrapRequirements(context.Background())
 	}
 
+	// Post progress to the source issue (if any)
+	d.commentOnSourceIssue(formatStoryMergedComment(storyComplete))
+
 	// Inject a user message so PM can inform the user about the completion
 	// when spec review completes and we transition to WORKING
 	completionMsg := fmt.Sprintf(
@@ -101,6 +101,8 @@ func (d *Driver) handleAllStoriesCompleteNotification(payload *proto.MessagePayl
 	d.logger.Info("🎉 All stories complete! Spec: %s, Total: %d stories", allComplete.SpecID, allComplete.TotalStories)
 
 	d.SetStateData(StateKeyInFlight, false)
+	d.commentOnSourceIssue(fmt.Sprintf("🎉 All %d stories for this issue are complete.", allComplete.TotalStories))
+	d.SetStateData(StateKeySourceIssue, nil)
 
 	completionMsg := fmt.Sprintf(
 		"Great news! All development work has been completed. "+
@@ -131,6 +133,9 @@ func (d *Driver) handleAllStoriesTerminalNotification(payload *proto.MessagePayl
 		terminal.TotalStories, len(terminal.FailedStories), len(terminal.SkippedStories), terminal.SpecID)
 
 	d.SetStateData(StateKeyInFlight, false)
+	d.commentOnSourceIssue(fmt.Sprintf("⚠️ Development finished, but %d of %d stories for this issue did not succeed.",
+		len(terminal.FailedStories)+len(terminal.SkippedStories), terminal.TotalStories))
+	d.SetStateData(StateKeySourceIssue, nil)
 
 	msg := fmt.Sprintf(
 		"Development work is complete but %d out of %d stories did not succeed. ",
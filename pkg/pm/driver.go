@@ -13,6 +13,7 @@ import (
 	"orchestrator/pkg/contextmgr"
 	"orchestrator/pkg/dispatch"
 	execpkg "orchestrator/pkg/exec"
+	"orchestrator/pkg/forge"
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/persistence"
 	"orchestrator/pkg/proto"
@@ -84,8 +85,25 @@ const (
 	// to the architect. Prevents duplicate sends on re-entry to WORKING.
 	StateKeyBootstrapSpecSent = "bootstrap_spec_sent"
 	// StateKeyAwaitingSpecType tracks which type of spec the PM is awaiting
-	// architect response for. Values: "bootstrap", "user", "hotfix".
+	// architect response for. Values: "bootstrap", "user", "amendment", "hotfix".
 	StateKeyAwaitingSpecType = "awaiting_spec_type"
+	// StateKeyInterviewCoverage stores interview questionnaire coverage as JSON
+	// (selected questionnaires and recorded answers). Cleared on spec approval.
+	StateKeyInterviewCoverage = "interview_coverage"
+	// StateKeySourceIssue stores the forge issue the spec was imported from as JSON.
+	// Kept while development is in flight so status comments can be posted back;
+	// cleared when all stories are done.
+	StateKeySourceIssue = "source_issue"
+	// StateKeyApprovedSpecMd stores the most recently approved user spec.
+	// Amendments are diffed against it; kept across development.
+	StateKeyApprovedSpecMd = "approved_spec_md"
+	// StateKeySpecVersion stores the version of the approved spec (int).
+	// Set to 1 on approval of a new spec, incremented per approved amendment.
+	StateKeySpecVersion = "spec_version"
+	// StateKeyIsAmendment marks the previewed spec as an amendment of the approved spec.
+	StateKeyIsAmendment = "is_amendment"
+	// StateKeyAmendmentDiff stores the requirement-level diff of a previewed amendment.
+	StateKeyAmendmentDiff = "amendment_diff"
 
 	// Incident/ask tracking (durable asks & incidents system).
 	StateKeyCurrentAsk         = "current_ask"          // string (JSON) - active UserAsk or empty
@@ -112,6 +130,7 @@ type Driver struct {
 	demoAvailable           bool                       // True when bootstrap is complete (no missing components)
 	currentAsk              *proto.UserAsk             // At most one active ask (PM-owned)
 	openIncidents           map[string]*proto.Incident // Mirrored from architect (architect-owned)
+	issueClient             forge.Client               // Forge client for issue import/comments (created lazily)
 }
 
 // NewPM creates a new PM agent with all dependencies initialized.
@@ -739,6 +758,32 @@ func (d *Driver) IsInFlight() bool {
 	return utils.GetStateValueOr[bool](d.BaseStateMachine, StateKeyInFlight, false)
 }
 
+// GetAmendmentDiff returns the requirement-level diff of the previewed spec
+// when it amends the approved spec, or "" when the draft is not an amendment.
+func (d *Driver) GetAmendmentDiff() string {
+	if !utils.GetStateValueOr[bool](d.BaseStateMachine, StateKeyIsAmendment, false) {
+		return ""
+	}
+	return utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyAmendmentDiff, "")
+}
+
+// specVersion returns the version of the approved spec, or 0 if none was approved.
+// The version is a float64 after a JSON round-trip through resume.
+func (d *Driver) specVersion() int {
+	value, ok := d.GetStateValue(StateKeySpecVersion)
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
 // GetDraftSpecMetadata returns the draft specification metadata if available.
 // Returns nil if no metadata is available.
 func (d *Driver) GetDraftSpecMetadata() map[string]any {
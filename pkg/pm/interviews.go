@@ -0,0 +1,54 @@
+package pm
+
+import (
+	"orchestrator/pkg/interviews"
+	"orchestrator/pkg/tools"
+	"orchestrator/pkg/utils"
+)
+
+// interviewCoverage returns the questionnaire coverage recorded so far this interview.
+func (d *Driver) interviewCoverage() *interviews.Coverage {
+	return interviews.Decode(utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyInterviewCoverage, ""))
+}
+
+// injectInterviewCoverage shares one coverage value between interview_questionnaire,
+// which records it, and spec_submit, which refuses full specs until it is complete.
+func (d *Driver) injectInterviewCoverage(specSubmit *tools.SpecSubmitTool) *interviews.Coverage {
+	coverage := d.interviewCoverage()
+	specSubmit.SetInterviewCoverage(coverage)
+	if tool, err := d.toolProvider.Get(tools.ToolInterviewQuestionnaire); err == nil {
+		if questionnaireTool, ok := tool.(*tools.InterviewQuestionnaireTool); ok {
+			questionnaireTool.SetCoverage(coverage)
+		}
+	}
+	return coverage
+}
+
+// saveInterviewCoverage writes coverage back to state data as JSON so it
+// survives a resume and is visible to the WebUI preview.
+func (d *Driver) saveInterviewCoverage(coverage *interviews.Coverage) {
+	if coverage == nil {
+		return
+	}
+	d.SetStateData(StateKeyInterviewCoverage, coverage.Encode())
+}
+
+// loadQuestionnaires loads the available questionnaires for the interview prompt.
+func (d *Driver) loadQuestionnaires() []*interviews.Questionnaire {
+	questionnaires, err := interviews.Load(d.workDir)
+	if err != nil {
+		d.logger.Warn("Failed to load interview questionnaires: %v", err)
+		return nil
+	}
+	return questionnaires
+}
+
+// GetInterviewCoverage returns questionnaire coverage for the WebUI preview.
+// Returns nil when the project defines no questionnaires.
+func (d *Driver) GetInterviewCoverage() *interviews.Report {
+	questionnaires, err := interviews.Load(d.workDir)
+	if err != nil || len(questionnaires) == 0 {
+		return nil
+	}
+	return d.interviewCoverage().Report(questionnaires)
+}
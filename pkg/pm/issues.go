@@ -0,0 +1,290 @@
+package pm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/forge"
+	_ "orchestrator/pkg/forge/gitea"  // Auto-register Gitea client.
+	_ "orchestrator/pkg/forge/github" // Auto-register GitHub client.
+	"orchestrator/pkg/proto"
+	"orchestrator/pkg/utils"
+)
+
+// Issue import modes for ImportIssue.
+const (
+	// IssueImportInterview starts an interview with the issue as its starting point.
+	IssueImportInterview = "interview"
+	// IssueImportDraft turns the issue directly into a spec draft in PREVIEW.
+	IssueImportDraft = "draft"
+)
+
+// issueCommentTimeout bounds status comments posted back to the source issue.
+// They are best effort and must not stall the PM's state handlers.
+const issueCommentTimeout = 30 * time.Second
+
+// issueRefPattern matches issue URLs on either forge (.../issues/12).
+var issueRefPattern = regexp.MustCompile(`/issues/(\d+)/?$`)
+
+// sourceIssue links the spec being developed to the issue it was imported from.
+type sourceIssue struct {
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Title  string `json:"title"`
+}
+
+// parseIssueRef accepts "12", "#12" or an issue URL and returns the issue number.
+func parseIssueRef(ref string) (int, error) {
+	ref = strings.TrimSpace(ref)
+	if m := issueRefPattern.FindStringSubmatch(ref); m != nil {
+		ref = m[1]
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(ref, "#"))
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid issue reference %q (use a number, #number, or the issue URL)", ref)
+	}
+	return number, nil
+}
+
+// formatIssueMarkdown renders an issue (title, labels, body, comments) as markdown.
+func formatIssueMarkdown(issue *forge.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Issue #%d: %s\n\n", issue.Number, issue.Title)
+	fmt.Fprintf(&b, "- **URL:** %s\n- **State:** %s\n", issue.URL, issue.State)
+	if issue.Author != "" {
+		fmt.Fprintf(&b, "- **Opened by:** %s\n", issue.Author)
+	}
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(&b, "- **Labels:** %s\n", strings.Join(issue.Labels, ", "))
+	}
+	b.WriteString("\n### Description\n\n")
+	if strings.TrimSpace(issue.Body) == "" {
+		b.WriteString("_(no description)_\n")
+	} else {
+		b.WriteString(strings.TrimSpace(issue.Body) + "\n")
+	}
+	if len(issue.Comments) > 0 {
+		b.WriteString("\n### Comments\n")
+		for i := range issue.Comments {
+			c := &issue.Comments[i]
+			fmt.Fprintf(&b, "\n**%s** (%s):\n\n%s\n", c.Author, c.CreatedAt.Format("2006-01-02"), strings.TrimSpace(c.Body))
+		}
+	}
+	return b.String()
+}
+
+// issueSpecDraft turns an issue into a spec draft for PREVIEW. The issue is
+// kept verbatim under a link to its source; the user reviews it and either
+// submits it or continues the interview to refine it.
+func issueSpecDraft(issue *forge.Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", issue.Title)
+	fmt.Fprintf(&b, "> Imported from issue #%d: %s\n\n", issue.Number, issue.URL)
+	if strings.TrimSpace(issue.Body) != "" {
+		b.WriteString(strings.TrimSpace(issue.Body) + "\n")
+	}
+	if len(issue.Comments) > 0 {
+		b.WriteString("\n## Discussion\n")
+		for i := range issue.Comments {
+			c := &issue.Comments[i]
+			fmt.Fprintf(&b, "\n**%s:** %s\n", c.Author, strings.TrimSpace(c.Body))
+		}
+	}
+	return b.String()
+}
+
+// getIssueClient returns the forge client used for issue import and status comments.
+func (d *Driver) getIssueClient() (forge.Client, error) {
+	if d.issueClient != nil {
+		return d.issueClient, nil
+	}
+	client, err := forge.NewClient(d.workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge client: %w", err)
+	}
+	d.issueClient = client
+	return client, nil
+}
+
+// ImportIssue fetches a forge issue and uses it as PM input.
+// This is called by the WebUI when the user imports an issue.
+// Mode IssueImportInterview starts (or continues) an interview from the issue;
+// mode IssueImportDraft puts the issue directly into PREVIEW as a spec draft.
+// Either way, the issue is linked to the resulting spec and receives status
+// comments as the spec is approved and its stories merge.
+// An empty expertise keeps the current level (or the default).
+//
+//nolint:cyclop // Validation of mode/state plus the two import paths is inherent
+func (d *Driver) ImportIssue(ctx context.Context, ref, mode, expertise string) error {
+	if mode == "" {
+		mode = IssueImportInterview
+	}
+	if mode != IssueImportInterview && mode != IssueImportDraft {
+		return fmt.Errorf("invalid import mode %q (must be '%s' or '%s')", mode, IssueImportInterview, IssueImportDraft)
+	}
+	number, err := parseIssueRef(ref)
+	if err != nil {
+		return err
+	}
+
+	currentState := d.GetCurrentState()
+	if currentState != StateWaiting && currentState != StateAwaitUser {
+		return fmt.Errorf("cannot import an issue in state %s (must be WAITING or AWAIT_USER)", currentState)
+	}
+	if d.IsInFlight() {
+		return fmt.Errorf("cannot import an issue while development is in progress")
+	}
+	if mode == IssueImportDraft && currentState != StateWaiting {
+		return fmt.Errorf("an issue can only be imported as a draft before the interview starts - import it as an interview instead")
+	}
+
+	client, err := d.getIssueClient()
+	if err != nil {
+		return err
+	}
+	issue, err := client.GetIssue(ctx, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch issue #%d: %w", number, err)
+	}
+
+	if expertise == "" {
+		expertise = utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyUserExpertise, DefaultExpertise)
+	}
+	issueMarkdown := formatIssueMarkdown(issue)
+
+	if mode == IssueImportDraft {
+		// A draft skips the interview, so the project must already be set up
+		if _, needsBootstrap := d.detectAndStoreBootstrapRequirements(ctx); needsBootstrap {
+			return fmt.Errorf("project setup is incomplete - import the issue as an interview so the PM can finish setup first")
+		}
+
+		d.setSourceIssue(issue)
+		d.SetStateData(StateKeyUserExpertise, expertise)
+		d.SetStateData(StateKeyUserSpecMd, issueSpecDraft(issue))
+		d.SetStateData(StateKeySpecMetadata, map[string]any{"title": issue.Title, "source_issue": issue.URL})
+		d.SetStateData(StateKeySpecUploaded, true)
+		d.SetStateData(StateKeyIsHotfix, false)
+
+		// If the user continues the interview, the PM needs to know where the draft came from
+		d.contextManager.AddMessage("system", fmt.Sprintf(`# Issue Imported as Spec Draft
+
+The user imported issue #%d as a specification draft, which is now in preview. If they continue the interview, refine the draft with them and submit the result with spec_submit.
+
+%s`, issue.Number, issueMarkdown))
+
+		if err := d.TransitionTo(ctx, StatePreview, nil); err != nil {
+			return fmt.Errorf("failed to transition to PREVIEW: %w", err)
+		}
+		d.logger.Info("📥 Issue #%d imported as spec draft - transitioned to PREVIEW", issue.Number)
+		return nil
+	}
+
+	// Interview mode: start the interview if it has not started yet
+	if currentState == StateWaiting {
+		if err := d.StartInterview(expertise); err != nil {
+			return err
+		}
+	}
+	d.setSourceIssue(issue)
+
+	d.contextManager.AddMessage("system", fmt.Sprintf(`# Issue Imported as Interview Starting Point
+
+The user imported issue #%d as the starting point for this interview. Treat its description and comments as what the user has told you so far:
+- Do NOT ask for information the issue already states
+- Ask about what is missing or ambiguous (scope, acceptance criteria, edge cases)
+- When the requirements are clear, draft the spec and call spec_submit
+
+%s`, issue.Number, issueMarkdown))
+
+	// From AWAIT_USER, start working on the issue right away
+	if d.GetCurrentState() == StateAwaitUser {
+		if err := d.TransitionTo(ctx, StateWorking, nil); err != nil {
+			return fmt.Errorf("failed to transition to WORKING: %w", err)
+		}
+	}
+	d.logger.Info("📥 Issue #%d imported as interview starting point", issue.Number)
+	return nil
+}
+
+// setSourceIssue links the spec in progress to an imported issue.
+func (d *Driver) setSourceIssue(issue *forge.Issue) {
+	raw, err := json.Marshal(&sourceIssue{Number: issue.Number, URL: issue.URL, Title: issue.Title})
+	if err != nil {
+		d.logger.Warn("Failed to marshal source issue: %v", err)
+		return
+	}
+	d.SetStateData(StateKeySourceIssue, string(raw))
+}
+
+// getSourceIssue returns the issue the current spec was imported from, or nil.
+func (d *Driver) getSourceIssue() *sourceIssue {
+	raw := utils.GetStateValueOr[string](d.BaseStateMachine, StateKeySourceIssue, "")
+	if raw == "" {
+		return nil
+	}
+	var issue sourceIssue
+	if err := json.Unmarshal([]byte(raw), &issue); err != nil || issue.Number == 0 {
+		return nil
+	}
+	return &issue
+}
+
+// commentOnSourceIssue posts a status comment on the source issue, if any.
+// Failures are logged and otherwise ignored: the comment is informational.
+func (d *Driver) commentOnSourceIssue(body string) {
+	issue := d.getSourceIssue()
+	if issue == nil {
+		return
+	}
+	client, err := d.getIssueClient()
+	if err != nil {
+		d.logger.Warn("⚠️ Cannot comment on issue #%d: %v", issue.Number, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), issueCommentTimeout)
+	defer cancel()
+	if err := client.CommentOnIssue(ctx, issue.Number, body); err != nil {
+		d.logger.Warn("⚠️ Failed to comment on issue #%d: %v", issue.Number, err)
+		return
+	}
+	d.logger.Info("💬 Posted status comment on issue #%d", issue.Number)
+}
+
+// formatSpecApprovedComment is the source issue comment for an approved spec.
+func formatSpecApprovedComment(specTitle string) string {
+	msg := "✅ A specification for this issue was approved and development has started."
+	if specTitle != "" {
+		msg = fmt.Sprintf("✅ The specification **%s**, drafted from this issue, was approved and development has started.", specTitle)
+	}
+	return msg + " Progress will be posted here as stories merge."
+}
+
+// formatStoryMergedComment is the source issue comment for a completed story.
+func formatStoryMergedComment(story *proto.StoryCompletePayload) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🔀 Story merged: **%s** (`%s`)", story.Title, story.StoryID)
+	if story.IsHotfix {
+		b.WriteString(" [hotfix]")
+	}
+	if story.PRID != "" {
+		fmt.Fprintf(&b, "\n\nPR: %s", story.PRID)
+	}
+	if story.Summary != "" {
+		fmt.Fprintf(&b, "\n\n%s", story.Summary)
+	}
+	return b.String()
+}
+
+// sourceIssueContext describes the source issue for the architect, or "".
+func (d *Driver) sourceIssueContext() string {
+	issue := d.getSourceIssue()
+	if issue == nil {
+		return ""
+	}
+	return fmt.Sprintf(" (imported from issue #%d: %s)", issue.Number, issue.URL)
+}
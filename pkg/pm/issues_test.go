@@ -0,0 +1,135 @@
+package pm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/proto"
+)
+
+// fakeIssueClient serves a single issue and records comments.
+// Embedding forge.Client leaves the unused methods nil.
+type fakeIssueClient struct {
+	forge.Client
+	issue    *forge.Issue
+	comments []string
+}
+
+func (f *fakeIssueClient) GetIssue(_ context.Context, number int) (*forge.Issue, error) {
+	if f.issue == nil || f.issue.Number != number {
+		return nil, fmt.Errorf("issue #%d not found", number)
+	}
+	return f.issue, nil
+}
+
+func (f *fakeIssueClient) CommentOnIssue(_ context.Context, _ int, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func newFakeIssueClient() *fakeIssueClient {
+	return &fakeIssueClient{issue: &forge.Issue{
+		Number: 42,
+		URL:    "https://github.com/acme/app/issues/42",
+		Title:  "Export reports to CSV",
+		Body:   "Users need to download reports as CSV.",
+		State:  "open",
+		Author: "alice",
+		Labels: []string{"feature"},
+		Comments: []forge.IssueComment{
+			{CreatedAt: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), Author: "bob", Body: "Include column headers."},
+		},
+	}}
+}
+
+func TestParseIssueRef(t *testing.T) {
+	tests := map[string]int{
+		"42":                                    42,
+		"#42":                                   42,
+		" 7 ":                                   7,
+		"https://github.com/acme/app/issues/42": 42,
+		"http://localhost:3000/acme/app/issues/3/": 3,
+	}
+	for ref, want := range tests {
+		got, err := parseIssueRef(ref)
+		if err != nil || got != want {
+			t.Errorf("parseIssueRef(%q) = %d, %v; want %d", ref, got, err, want)
+		}
+	}
+	for _, ref := range []string{"", "abc", "#0", "https://github.com/acme/app/pull/42"} {
+		if _, err := parseIssueRef(ref); err == nil {
+			t.Errorf("parseIssueRef(%q) should fail", ref)
+		}
+	}
+}
+
+func TestFormatIssueMarkdown(t *testing.T) {
+	issue := newFakeIssueClient().issue
+	md := formatIssueMarkdown(issue)
+	for _, want := range []string{"## Issue #42: Export reports to CSV", "**Labels:** feature", "download reports as CSV", "**bob** (2025-01-02)"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("formatIssueMarkdown() missing %q:\n%s", want, md)
+		}
+	}
+
+	draft := issueSpecDraft(issue)
+	if !strings.HasPrefix(draft, "# Export reports to CSV\n") || !strings.Contains(draft, "Imported from issue #42") {
+		t.Errorf("unexpected spec draft:\n%s", draft)
+	}
+}
+
+func TestImportIssue_InterviewFromAwaitUser(t *testing.T) {
+	driver := createTestDriver(StateAwaitUser)
+	client := newFakeIssueClient()
+	driver.issueClient = client
+
+	if err := driver.ImportIssue(context.Background(), "#42", IssueImportInterview, ""); err != nil {
+		t.Fatalf("ImportIssue() error = %v", err)
+	}
+	if state := driver.GetCurrentState(); state != StateWorking {
+		t.Errorf("expected WORKING after import, got %s", state)
+	}
+	source := driver.getSourceIssue()
+	if source == nil || source.Number != 42 || source.URL != client.issue.URL {
+		t.Fatalf("source issue not linked: %+v", source)
+	}
+	if !strings.Contains(driver.sourceIssueContext(), "issue #42") {
+		t.Errorf("unexpected source issue context %q", driver.sourceIssueContext())
+	}
+
+	driver.commentOnSourceIssue(formatStoryMergedComment(&proto.StoryCompletePayload{StoryID: "s-1", Title: "CSV export", PRID: "17"}))
+	if len(client.comments) != 1 || !strings.Contains(client.comments[0], "CSV export") {
+		t.Errorf("expected one story comment, got %v", client.comments)
+	}
+}
+
+func TestImportIssue_Rejections(t *testing.T) {
+	tests := []struct {
+		name  string
+		state proto.State
+		ref   string
+		mode  string
+	}{
+		{"invalid mode", StateWaiting, "42", "spec"},
+		{"invalid ref", StateWaiting, "nope", IssueImportInterview},
+		{"wrong state", StatePreview, "42", IssueImportInterview},
+		{"draft after interview started", StateAwaitUser, "42", IssueImportDraft},
+		{"unknown issue", StateAwaitUser, "43", IssueImportInterview},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver := createTestDriver(tt.state)
+			driver.issueClient = newFakeIssueClient()
+			if err := driver.ImportIssue(context.Background(), tt.ref, tt.mode, ""); err == nil {
+				t.Error("expected an error")
+			}
+			if driver.getSourceIssue() != nil {
+				t.Error("source issue should not be linked after a failed import")
+			}
+		})
+	}
+}
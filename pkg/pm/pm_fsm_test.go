@@ -39,6 +39,7 @@ func TestIsValidPMTransition(t *testing.T) {
 		// WAITING transitions
 		{StateWaiting, StateWaiting, "WAITING -> WAITING (polling for state changes)"},
 		{StateWaiting, StateAwaitUser, "WAITING -> AWAIT_USER (interview starts)"},
+		{StateWaiting, StatePreview, "WAITING -> PREVIEW (issue imported as spec draft)"},
 		{StateWaiting, proto.StateDone, "WAITING -> DONE (shutdown)"},
 
 		// WORKING transitions
@@ -90,7 +91,6 @@ func TestInvalidPMTransitions(t *testing.T) {
 	}{
 		// Invalid WAITING transitions
 		{StateWaiting, proto.StateError, "WAITING -> ERROR (invalid)"},
-		{StateWaiting, StatePreview, "WAITING -> PREVIEW (invalid - upload removed, files via chat)"},
 
 		// Invalid AWAIT_USER transitions
 		{StateAwaitUser, StateWaiting, "AWAIT_USER -> WAITING (invalid - must go through WORKING)"},
@@ -218,7 +218,7 @@ func TestValidNextStates(t *testing.T) {
 		from     proto.State
 		expected []proto.State
 	}{
-		{StateWaiting, []proto.State{StateWaiting, StateSetup, StateWorking, StateAwaitUser, StateAwaitArchitect, proto.StateDone}},
+		{StateWaiting, []proto.State{StateWaiting, StateSetup, StateWorking, StateAwaitUser, StatePreview, StateAwaitArchitect, proto.StateDone}},
 		{StateSetup, []proto.State{StateWaiting, proto.StateError}},
 		{StateWorking, []proto.State{StateWorking, StateAwaitUser, StatePreview, StateAwaitArchitect, proto.StateError, proto.StateDone}},
 		{StateAwaitUser, []proto.State{StateAwaitUser, StateWorking, proto.StateError, proto.StateDone}},
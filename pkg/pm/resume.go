@@ -262,6 +262,20 @@ func (d *Driver) collectBootstrapParamsJSON() *string {
 	if isHotfix, ok := utils.GetStateValue[bool](d.BaseStateMachine, StateKeyIsHotfix); ok {
 		bootstrapParams[StateKeyIsHotfix] = isHotfix
 	}
+	if coverage, ok := utils.GetStateValue[string](d.BaseStateMachine, StateKeyInterviewCoverage); ok && coverage != "" {
+		bootstrapParams[StateKeyInterviewCoverage] = coverage
+	}
+	if issue, ok := utils.GetStateValue[string](d.BaseStateMachine, StateKeySourceIssue); ok && issue != "" {
+		bootstrapParams[StateKeySourceIssue] = issue
+	}
+	if approved, ok := utils.GetStateValue[string](d.BaseStateMachine, StateKeyApprovedSpecMd); ok && approved != "" {
+		bootstrapParams[StateKeyApprovedSpecMd] = approved
+		bootstrapParams[StateKeySpecVersion] = d.specVersion()
+	}
+	if isAmendment, ok := utils.GetStateValue[bool](d.BaseStateMachine, StateKeyIsAmendment); ok && isAmendment {
+		bootstrapParams[StateKeyIsAmendment] = isAmendment
+		bootstrapParams[StateKeyAmendmentDiff] = d.GetAmendmentDiff()
+	}
 
 	if len(bootstrapParams) == 0 {
 		return nil
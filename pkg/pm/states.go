@@ -37,6 +37,7 @@ var validTransitions = map[proto.State][]proto.State{
 		StateWorking,        // User starts interview - PM begins working
 		StateAwaitUser,      // User starts interview - wait for first message
 		StateAwaitArchitect, // Bootstrap spec (Spec 0) sent at interview start
+		StatePreview,        // Issue imported directly as a spec draft
 		proto.StateDone,
 	},
 	StateSetup: {
@@ -33,6 +33,7 @@ func TestAllValidTransitions(t *testing.T) {
 			StateWorking,
 			StateAwaitUser,
 			StateAwaitArchitect, // Bootstrap spec (Spec 0) sent at startup
+			StatePreview,        // Issue imported as spec draft
 			proto.StateDone,
 		},
 		StateWorking: {
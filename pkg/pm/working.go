@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"orchestrator/pkg/agent"
@@ -190,6 +191,10 @@ func (d *Driver) setupInterviewContext() error {
 		templateName = templates.PMInterviewStartTemplate
 		// Indicate that bootstrap tool is available for config revisions (not required)
 		templateData.Extra["ConfigRevisionAvailable"] = true
+		// List the team's questionnaires so the PM can select the ones that apply
+		if questionnaires := d.loadQuestionnaires(); len(questionnaires) > 0 {
+			templateData.Extra["Questionnaires"] = questionnaires
+		}
 		d.logger.Info("📋 Using interview template (config complete, bootstrap available for revisions)")
 	}
 
@@ -238,6 +243,13 @@ func (d *Driver) callLLMWithTools(ctx context.Context, prompt string) (string, e
 		inFlight := utils.GetStateValueOr[bool](d.BaseStateMachine, StateKeyInFlight, false)
 		submitTool.SetInFlight(inFlight)
 		d.logger.Info("📝 Injected in_flight=%v into spec_submit tool", inFlight)
+
+		// Inject the approved spec so amendments can be diffed against it
+		submitTool.SetApprovedSpec(utils.GetStateValueOr[string](d.BaseStateMachine, StateKeyApprovedSpecMd, ""))
+
+		// Share questionnaire coverage with spec_submit and persist whatever the loop records
+		coverage := d.injectInterviewCoverage(submitTool)
+		defer d.saveInterviewCoverage(coverage)
 	}
 
 	terminalTool := specSubmitTool
@@ -413,11 +425,15 @@ func (d *Driver) callLLMWithTools(ctx context.Context, prompt string) (string, e
 				summary := utils.GetMapFieldOr[string](effectData, "summary", "")
 				metadata, _ := utils.SafeAssert[map[string]any](effectData["metadata"])
 				isHotfix := utils.GetMapFieldOr[bool](effectData, "is_hotfix", false)
+				isAmendment := utils.GetMapFieldOr[bool](effectData, "is_amendment", false)
+				amendmentDiff := utils.GetMapFieldOr[string](effectData, "amendment_diff", "")
 
 				// Store specs using canonical state keys
 				d.SetStateData(StateKeyUserSpecMd, userSpec)
 				d.SetStateData(StateKeySpecMetadata, metadata)
 				d.SetStateData(StateKeyIsHotfix, isHotfix)
+				d.SetStateData(StateKeyIsAmendment, isAmendment)
+				d.SetStateData(StateKeyAmendmentDiff, amendmentDiff)
 
 				// Note: Bootstrap requirements are not stored from spec_submit effect.
 				// They're already available via GetBootstrapRequirements().ToRequirementIDs()
@@ -584,10 +600,23 @@ func (d *Driver) sendSpecApprovalRequest(_ context.Context) error {
 		Content:               userSpec,      // User requirements only
 		BootstrapRequirements: bootstrapReqs, // Bootstrap requirement IDs (architect renders spec)
 		Reason:                "PM has completed specification and requests architect review",
-		Context:               "Specification ready for validation and story generation",
+		Context:               "Specification ready for validation and story generation" + d.sourceIssueContext(),
 		Confidence:            proto.ConfidenceHigh,
 	}
 
+	// Amendments carry the requirement-level diff so the architect reviews only the delta
+	specType := "user"
+	if diff := d.GetAmendmentDiff(); diff != "" {
+		specType = "amendment"
+		nextVersion := d.specVersion() + 1
+		approvalPayload.Reason = fmt.Sprintf("PM has amended the approved specification (version %d) and requests architect review", nextVersion)
+		approvalPayload.Metadata = map[string]string{
+			"spec_type":    specType,
+			"spec_diff":    diff,
+			"spec_version": strconv.Itoa(nextVersion),
+		}
+	}
+
 	// Create REQUEST message
 	requestMsg := &proto.AgentMsg{
 		ID:        fmt.Sprintf("pm-spec-req-%d", time.Now().UnixNano()),
@@ -597,16 +626,16 @@ func (d *Driver) sendSpecApprovalRequest(_ context.Context) error {
 		Payload:   proto.NewApprovalRequestPayload(approvalPayload),
 	}
 
-	// Track that we're awaiting a user spec response
-	d.SetStateData(StateKeyAwaitingSpecType, "user")
+	// Track that we're awaiting a user spec (or amendment) response
+	d.SetStateData(StateKeyAwaitingSpecType, specType)
 
 	// Send via dispatcher
 	if err := d.dispatcher.DispatchMessage(requestMsg); err != nil {
 		return fmt.Errorf("failed to dispatch REQUEST: %w", err)
 	}
 
-	d.logger.Info("📤 Sent spec approval REQUEST to architect (user: %d bytes, bootstrap reqs: %v, id: %s)",
-		len(userSpec), bootstrapReqs, requestMsg.ID)
+	d.logger.Info("📤 Sent spec approval REQUEST to architect (%s: %d bytes, bootstrap reqs: %v, id: %s)",
+		specType, len(userSpec), bootstrapReqs, requestMsg.ID)
 
 	// Checkpoint state for crash recovery (spec submission is a stable boundary)
 	if cfg, err := config.GetConfig(); err == nil && cfg.SessionID != "" {
@@ -0,0 +1,133 @@
+package proto
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MergeFailureStage is the merge queue step at which a candidate PR failed.
+type MergeFailureStage string
+
+const (
+	// MergeStageRebase means the PR does not apply cleanly on top of the queue head.
+	MergeStageRebase MergeFailureStage = "rebase"
+	// MergeStageBuild means the project build failed on the rebased PR.
+	MergeStageBuild MergeFailureStage = "build"
+	// MergeStageTest means the project tests failed on the rebased PR.
+	MergeStageTest MergeFailureStage = "test"
+	// MergeStageMerge means the forge refused to merge the tested PR.
+	MergeStageMerge MergeFailureStage = "merge"
+	// MergeStageChecks means the forge's CI checks on the PR failed.
+	MergeStageChecks MergeFailureStage = "checks"
+	// MergeStageReview means a human reviewer left comments on the PR.
+	MergeStageReview MergeFailureStage = "review"
+	// MergeStageRetarget means the story a stacked PR was based on merged and
+	// the PR now targets Base; the coder rebases onto it and resubmits.
+	MergeStageRetarget MergeFailureStage = "retarget"
+	// MergeStageStackParent means the story a stacked PR is based on did not
+	// merge, so the PR cannot merge yet.
+	MergeStageStackParent MergeFailureStage = "stack_parent"
+)
+
+// MergeFailureFeedback is the structured reason the merge queue sent a PR back
+// to its coder. It travels as JSON in MergeResponsePayload.Metadata under
+// KeyMergeFailureFeedback.
+type MergeFailureFeedback struct {
+	Stage     MergeFailureStage `json:"stage"`
+	Base      string            `json:"base,omitempty"`      // Branch a retargeted PR now merges into
+	BaseSHA   string            `json:"base_sha,omitempty"`  // Queue head the PR was rebased onto
+	Ahead     []string          `json:"ahead,omitempty"`     // Stories merged ahead of the PR, included in the tested tree
+	Command   string            `json:"command,omitempty"`   // Build or test command, or CI checks, that failed
+	Conflicts []string          `json:"conflicts,omitempty"` // Files that conflicted during the rebase
+	Output    string            `json:"output,omitempty"`    // Tail of the failing step's output
+	Comments  []ReviewComment   `json:"comments,omitempty"`  // Human review comments to address
+}
+
+// ReviewComment is a human review comment on a PR, as sent to the coder that owns it.
+type ReviewComment struct {
+	ID     int64  `json:"id"`
+	Kind   string `json:"kind"` // conversation, review or line
+	Author string `json:"author"`
+	Body   string `json:"body"`
+	Path   string `json:"path,omitempty"`
+	Line   int    `json:"line,omitempty"`
+}
+
+// Encode returns the feedback as JSON for response metadata.
+func (f *MergeFailureFeedback) Encode() string {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// DecodeMergeFailureFeedback parses feedback from response metadata.
+func DecodeMergeFailureFeedback(raw string) (*MergeFailureFeedback, error) {
+	var f MergeFailureFeedback
+	if err := json.Unmarshal([]byte(raw), &f); err != nil {
+		return nil, fmt.Errorf("invalid merge failure feedback: %w", err)
+	}
+	return &f, nil
+}
+
+// String renders the feedback for the coder's context.
+func (f *MergeFailureFeedback) String() string {
+	var b strings.Builder
+	switch f.Stage {
+	case MergeStageRebase:
+		b.WriteString("The merge queue could not rebase your PR onto the target branch")
+	case MergeStageBuild:
+		b.WriteString("The build failed after the merge queue rebased your PR onto the target branch")
+	case MergeStageTest:
+		b.WriteString("Tests failed after the merge queue rebased your PR onto the target branch")
+	case MergeStageChecks:
+		b.WriteString("CI checks failed on your PR")
+	case MergeStageReview:
+		b.WriteString("A reviewer requested changes on your PR")
+	case MergeStageRetarget:
+		fmt.Fprintf(&b, "The story your PR was stacked on has merged, so your PR now targets %s and must be rebased onto it", f.Base)
+	case MergeStageStackParent:
+		b.WriteString("The story your PR is stacked on did not merge, so your PR cannot merge yet")
+	default:
+		b.WriteString("The forge refused to merge your tested PR")
+	}
+	if f.BaseSHA != "" {
+		fmt.Fprintf(&b, " (at %s)", shortSHA(f.BaseSHA))
+	}
+	b.WriteString(".\n")
+	if len(f.Ahead) > 0 {
+		fmt.Fprintf(&b, "Merged just ahead of it: %s.\n", strings.Join(f.Ahead, ", "))
+	}
+	if len(f.Conflicts) > 0 {
+		fmt.Fprintf(&b, "Conflicting files: %s\n", strings.Join(f.Conflicts, ", "))
+	}
+	if f.Command != "" {
+		fmt.Fprintf(&b, "Command: %s\n", f.Command)
+	}
+	if len(f.Comments) > 0 {
+		b.WriteString("Address every comment below, then resubmit; each comment gets a reply on the PR when you do.\n")
+	}
+	for _, c := range f.Comments {
+		b.WriteString("\n")
+		if c.Path != "" {
+			fmt.Fprintf(&b, "%s on %s:%d:\n", c.Author, c.Path, c.Line)
+		} else {
+			fmt.Fprintf(&b, "%s:\n", c.Author)
+		}
+		b.WriteString(c.Body + "\n")
+	}
+	if f.Output != "" {
+		b.WriteString("\n")
+		b.WriteString(f.Output)
+	}
+	return b.String()
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -109,6 +109,15 @@ const (
 	KeyIsHotfix        = "is_hotfix" // Route to dedicated hotfix coder
 	KeyFilePath        = "file_path"
 	KeyBackend         = "backend"
+	KeyVerification    = "verification"     // Acceptance-criteria verification JSON sent with a merge request
+	KeyChangedFiles    = "changed_files"    // Newline-separated files a story's branch changes, sent with a merge request
+	KeyBaseBranch      = "base_branch"      // Branch the story branches from and its PR targets (an epic branch), when not the target branch
+	KeyStackedOn       = "stacked_on"       // Dependency whose approved branch a stacked story starts from; its merge waits for it
+	KeyRepo            = "repo"             // Repository a story changes in a multi-repo product (absent for the primary one)
+	KeySiblingBranches = "sibling_branches" // Branch to read per other repository, for a story stacked on a dependency there
+
+	// Merge response keys.
+	KeyMergeFailureFeedback = "merge_failure_feedback" // MergeFailureFeedback JSON when the merge queue rejects a PR
 
 	// Additional tracking keys (extending unified protocol keys above).
 	KeyQuestionID        = "question_id"
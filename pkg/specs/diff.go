@@ -0,0 +1,178 @@
+package specs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeKind classifies a requirement-level change between two spec versions.
+type ChangeKind string
+
+const (
+	// ChangeAdded marks a requirement that only exists in the new version.
+	ChangeAdded ChangeKind = "added"
+	// ChangeChanged marks a requirement whose fields differ between versions.
+	ChangeChanged ChangeKind = "changed"
+	// ChangeRemoved marks a requirement that only exists in the old version.
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// RequirementChange is one entry of a requirement-level spec diff.
+//
+//nolint:govet // Field alignment optimization would hurt readability; logical grouping is more important.
+type RequirementChange struct {
+	Kind   ChangeKind
+	ID     string       // Requirement ID (R-001)
+	Old    *Requirement // nil for added requirements
+	New    *Requirement // nil for removed requirements
+	Fields []string     // Changed fields, for ChangeChanged only
+}
+
+// Title returns the requirement title of the most recent version.
+func (c *RequirementChange) Title() string {
+	if c.New != nil {
+		return c.New.Title
+	}
+	return c.Old.Title
+}
+
+// Diff compares the requirements of two spec versions by requirement ID.
+// Changes are ordered as the requirements appear in the new version,
+// followed by removed requirements in their old order.
+func Diff(old, updated *SpecPack) []RequirementChange {
+	oldByID := make(map[string]*Requirement, len(old.Requirements))
+	for i := range old.Requirements {
+		oldByID[old.Requirements[i].ID] = &old.Requirements[i]
+	}
+
+	var changes []RequirementChange
+	seen := make(map[string]bool, len(updated.Requirements))
+	for i := range updated.Requirements {
+		req := &updated.Requirements[i]
+		seen[req.ID] = true
+		prev, exists := oldByID[req.ID]
+		if !exists {
+			changes = append(changes, RequirementChange{Kind: ChangeAdded, ID: req.ID, New: req})
+			continue
+		}
+		if fields := changedFields(prev, req); len(fields) > 0 {
+			changes = append(changes, RequirementChange{Kind: ChangeChanged, ID: req.ID, Old: prev, New: req, Fields: fields})
+		}
+	}
+
+	for i := range old.Requirements {
+		req := &old.Requirements[i]
+		if !seen[req.ID] {
+			changes = append(changes, RequirementChange{Kind: ChangeRemoved, ID: req.ID, Old: req})
+		}
+	}
+
+	return changes
+}
+
+// changedFields lists the fields that differ between two versions of a requirement.
+// Line numbers are ignored: moving a requirement is not a change.
+func changedFields(a, b *Requirement) []string {
+	var fields []string
+	if a.Title != b.Title {
+		fields = append(fields, "title")
+	}
+	if a.Type != b.Type {
+		fields = append(fields, "type")
+	}
+	if a.Priority != b.Priority {
+		fields = append(fields, "priority")
+	}
+	if !slices.Equal(a.Dependencies, b.Dependencies) {
+		fields = append(fields, "dependencies")
+	}
+	if a.Description != b.Description {
+		fields = append(fields, "description")
+	}
+	if !slices.Equal(a.AcceptanceCriteria, b.AcceptanceCriteria) {
+		fields = append(fields, "acceptance criteria")
+	}
+	return fields
+}
+
+// FormatDiff renders a requirement-level diff as markdown for review.
+func FormatDiff(changes []RequirementChange) string {
+	if len(changes) == 0 {
+		return "_No requirement changes._\n"
+	}
+
+	var b strings.Builder
+	for i := range changes {
+		c := &changes[i]
+		fmt.Fprintf(&b, "### %s %s: %s\n\n", kindLabel(c.Kind), c.ID, c.Title())
+		switch c.Kind {
+		case ChangeAdded:
+			writeRequirement(&b, c.New)
+		case ChangeRemoved:
+			writeRequirement(&b, c.Old)
+		case ChangeChanged:
+			writeFieldChanges(&b, c)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func kindLabel(kind ChangeKind) string {
+	switch kind {
+	case ChangeAdded:
+		return "Added"
+	case ChangeRemoved:
+		return "Removed"
+	default:
+		return "Changed"
+	}
+}
+
+// writeRequirement renders the reviewable fields of a whole requirement.
+func writeRequirement(b *strings.Builder, req *Requirement) {
+	if req.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", req.Description)
+	}
+	if len(req.Dependencies) > 0 {
+		fmt.Fprintf(b, "**Dependencies:** %s\n\n", strings.Join(req.Dependencies, ", "))
+	}
+	if len(req.AcceptanceCriteria) > 0 {
+		b.WriteString("**Acceptance Criteria:**\n")
+		for _, ac := range req.AcceptanceCriteria {
+			fmt.Fprintf(b, "- %s\n", ac)
+		}
+	}
+}
+
+// writeFieldChanges renders before/after values for each changed field.
+// Acceptance criteria are listed as added (+) and removed (-) items.
+func writeFieldChanges(b *strings.Builder, c *RequirementChange) {
+	for _, field := range c.Fields {
+		switch field {
+		case "title":
+			fmt.Fprintf(b, "- **Title:** %q → %q\n", c.Old.Title, c.New.Title)
+		case "type":
+			fmt.Fprintf(b, "- **Type:** %q → %q\n", c.Old.Type, c.New.Type)
+		case "priority":
+			fmt.Fprintf(b, "- **Priority:** %q → %q\n", c.Old.Priority, c.New.Priority)
+		case "dependencies":
+			fmt.Fprintf(b, "- **Dependencies:** [%s] → [%s]\n", strings.Join(c.Old.Dependencies, ", "), strings.Join(c.New.Dependencies, ", "))
+		case "description":
+			fmt.Fprintf(b, "- **Description:**\n  - Before: %s\n  - After: %s\n", c.Old.Description, c.New.Description)
+		case "acceptance criteria":
+			b.WriteString("- **Acceptance Criteria:**\n")
+			for _, ac := range c.New.AcceptanceCriteria {
+				if !slices.Contains(c.Old.AcceptanceCriteria, ac) {
+					fmt.Fprintf(b, "  - + %s\n", ac)
+				}
+			}
+			for _, ac := range c.Old.AcceptanceCriteria {
+				if !slices.Contains(c.New.AcceptanceCriteria, ac) {
+					fmt.Fprintf(b, "  - − %s\n", ac)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package specs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old, err := Parse(validSpec)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	// R-001: one criterion replaced; R-002: removed; R-003: added
+	amended := strings.Replace(validSpec, "- [ ] Duplicate email check (return clear error)", "- [ ] Duplicate email check (HTTP 409)", 1)
+	amended = amended[:strings.Index(amended, "### R-002")] + `### R-003: Password Reset
+**Type:** functional
+**Priority:** should
+**Dependencies:** [R-001]
+
+**Description:** Users can reset a forgotten password by email.
+
+**Acceptance Criteria:**
+- [ ] Reset link expires after 1 hour
+`
+	updated, err := Parse(amended)
+	if err != nil {
+		t.Fatalf("Failed to parse amended spec: %v", err)
+	}
+
+	changes := Diff(old, updated)
+	if len(changes) != 3 {
+		t.Fatalf("Expected 3 changes, got %d: %+v", len(changes), changes)
+	}
+
+	want := []struct {
+		kind ChangeKind
+		id   string
+	}{{ChangeChanged, "R-001"}, {ChangeAdded, "R-003"}, {ChangeRemoved, "R-002"}}
+	for i, w := range want {
+		if changes[i].Kind != w.kind || changes[i].ID != w.id {
+			t.Errorf("change %d = %s %s, want %s %s", i, changes[i].Kind, changes[i].ID, w.kind, w.id)
+		}
+	}
+	if fields := changes[0].Fields; len(fields) != 1 || fields[0] != "acceptance criteria" {
+		t.Errorf("Expected only acceptance criteria to change, got %v", fields)
+	}
+
+	rendered := FormatDiff(changes)
+	for _, s := range []string{
+		"### Changed R-001: User Registration",
+		"+ Duplicate email check (HTTP 409)",
+		"− Duplicate email check (return clear error)",
+		"### Added R-003: Password Reset",
+		"### Removed R-002: User Login",
+	} {
+		if !strings.Contains(rendered, s) {
+			t.Errorf("FormatDiff() missing %q:\n%s", s, rendered)
+		}
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	spec, err := Parse(validSpec)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+	// Moving text around without changing requirements is not a change
+	moved, err := Parse(strings.Replace(validSpec, "## Requirements\n", "## Requirements\n\n\n", 1))
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+	if changes := Diff(spec, moved); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
@@ -55,6 +55,22 @@ func Parse(markdown string) (*SpecPack, error) {
 	return spec, nil
 }
 
+// ParseRequirements extracts the requirements of a spec without requiring
+// frontmatter, so a spec embedded in a larger document (such as a user spec
+// appended to the infrastructure spec) can still be traced by requirement ID.
+func ParseRequirements(markdown string) ([]Requirement, error) {
+	body := markdown
+	if _, specBody, err := splitFrontmatter(markdown); err == nil {
+		body = specBody
+	}
+
+	spec := &SpecPack{}
+	if err := parseBody(body, spec); err != nil {
+		return nil, fmt.Errorf("failed to parse markdown body: %w", err)
+	}
+	return spec.Requirements, nil
+}
+
 // splitFrontmatter splits markdown into YAML frontmatter and body.
 //
 //nolint:gocritic // Separate return values are clearer than a struct for this simple case.
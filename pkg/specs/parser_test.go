@@ -0,0 +1,14 @@
+package specs
+
+import "testing"
+
+func TestParseRequirements_EmbeddedSpec(t *testing.T) {
+	// A user spec appended to another document has no leading frontmatter
+	reqs, err := ParseRequirements("# Infrastructure\n\nSet up the build.\n\n" + validSpec)
+	if err != nil {
+		t.Fatalf("ParseRequirements failed: %v", err)
+	}
+	if len(reqs) != 2 || reqs[0].ID != "R-001" || reqs[1].ID != "R-002" {
+		t.Errorf("Expected R-001 and R-002, got %+v", reqs)
+	}
+}
@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"orchestrator/pkg/config"
+)
+
+// LedgerFile is the filename of the sync ledger within the .maestro directory.
+const LedgerFile = "sync_ledger.json"
+
+// Ledger records what earlier syncs did, so a repeated sync only pushes
+// branches that moved and migrates pull requests once.
+type Ledger struct {
+	// LastSync is when the last sync other than a dry run finished.
+	LastSync time.Time `json:"last_sync"`
+	// TargetBranch is the branch reconciled between the forges.
+	TargetBranch string `json:"target_branch"`
+	// Branches maps each branch pushed to GitHub to the SHA pushed.
+	Branches map[string]string `json:"branches"`
+	// PullRequests maps migrated Gitea PR numbers to their GitHub PR URLs.
+	PullRequests map[int]string `json:"pull_requests"`
+}
+
+// ledgerPath returns the path of the sync ledger in the project directory.
+func ledgerPath(projectDir string) string {
+	return filepath.Join(projectDir, config.ProjectConfigDir, LedgerFile)
+}
+
+// LoadLedger reads the sync ledger, or returns an empty one before the first sync.
+func LoadLedger(projectDir string) (*Ledger, error) {
+	ledger := &Ledger{
+		Branches:     make(map[string]string),
+		PullRequests: make(map[int]string),
+	}
+	data, err := os.ReadFile(ledgerPath(projectDir))
+	if os.IsNotExist(err) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync ledger: %w", err)
+	}
+	if err := json.Unmarshal(data, ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse sync ledger: %w", err)
+	}
+	if ledger.Branches == nil {
+		ledger.Branches = make(map[string]string)
+	}
+	if ledger.PullRequests == nil {
+		ledger.PullRequests = make(map[int]string)
+	}
+	return ledger, nil
+}
+
+// SaveLedger writes the sync ledger to the project directory.
+func SaveLedger(projectDir string, ledger *Ledger) error {
+	if err := os.MkdirAll(filepath.Join(projectDir, config.ProjectConfigDir), 0755); err != nil {
+		return fmt.Errorf("failed to create .maestro directory: %w", err)
+	}
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync ledger: %w", err)
+	}
+	if err := os.WriteFile(ledgerPath(projectDir), data, 0644); err != nil {
+		return fmt.Errorf("failed to write sync ledger: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/forge/gitea"
+	forgegithub "orchestrator/pkg/forge/github"
+)
+
+// MigratedPR records an open Gitea pull request opened on GitHub.
+type MigratedPR struct {
+	// GiteaNumber is the pull request number on Gitea.
+	GiteaNumber int
+	// Title is the pull request title.
+	Title string
+	// Head is the branch the pull request merges.
+	Head string
+	// Base is the branch the pull request merges into.
+	Base string
+	// GitHubURL is the GitHub pull request (empty in dry-run).
+	GitHubURL string
+}
+
+// giteaPRLister lists the open pull requests on Gitea.
+type giteaPRLister interface {
+	ListOpenPRs(ctx context.Context) ([]forge.PullRequest, error)
+}
+
+// gitHubPROpener opens pull requests on GitHub.
+type gitHubPROpener interface {
+	GetOrCreatePR(ctx context.Context, opts forge.PRCreateOptions) (*forge.PullRequest, error)
+}
+
+// migratePullRequests opens a GitHub pull request for every open Gitea pull
+// request not migrated by an earlier sync. onGitHub holds the branches that
+// exist on GitHub once the branch push is done.
+func (s *Syncer) migratePullRequests(ctx context.Context, ledger *Ledger, onGitHub map[string]bool, result *Result) {
+	if s.giteaPRs == nil {
+		s.giteaPRs = gitea.NewClient(s.gitea.url, s.gitea.token, s.gitea.owner, s.gitea.repo)
+	}
+
+	prs, err := s.giteaPRs.ListOpenPRs(ctx)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Could not list open Gitea PRs: %v", err))
+		return
+	}
+
+	for i := range prs {
+		pr := &prs[i]
+		if url, ok := ledger.PullRequests[pr.Number]; ok {
+			s.logger.Debug("Gitea PR #%d already migrated to %s", pr.Number, url)
+			continue
+		}
+		if !onGitHub[pr.HeadBranch] {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Gitea PR #%d not migrated: branch %s is not on GitHub", pr.Number, pr.HeadBranch))
+			continue
+		}
+		if pr.BaseBranch != s.gitHub.targetBranch && !onGitHub[pr.BaseBranch] {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Gitea PR #%d not migrated: base branch %s is not on GitHub", pr.Number, pr.BaseBranch))
+			continue
+		}
+
+		migrated := MigratedPR{GiteaNumber: pr.Number, Title: pr.Title, Head: pr.HeadBranch, Base: pr.BaseBranch}
+		if s.dryRun {
+			s.logger.Info("[DRY-RUN] Would open GitHub PR for Gitea PR #%d: %s", pr.Number, pr.Title)
+			result.PRsMigrated = append(result.PRsMigrated, migrated)
+			continue
+		}
+
+		if s.gitHubPRs == nil {
+			client, clientErr := forgegithub.NewClientForRepo(s.gitHub.repoURL)
+			if clientErr != nil {
+				result.Warnings = append(result.Warnings,
+					fmt.Sprintf("Could not create GitHub client to migrate PRs: %v", clientErr))
+				return
+			}
+			s.gitHubPRs = client
+		}
+
+		opened, openErr := s.gitHubPRs.GetOrCreatePR(ctx, forge.PRCreateOptions{
+			Title: pr.Title,
+			Body:  migratedPRBody(pr),
+			Head:  pr.HeadBranch,
+			Base:  pr.BaseBranch,
+		})
+		if openErr != nil {
+			result.Warnings = append(result.Warnings,
+				fmt.Sprintf("Failed to migrate Gitea PR #%d: %v", pr.Number, openErr))
+			continue
+		}
+
+		migrated.GitHubURL = opened.URL
+		ledger.PullRequests[pr.Number] = opened.URL
+		result.PRsMigrated = append(result.PRsMigrated, migrated)
+		s.logger.Info("✓ Migrated Gitea PR #%d to %s", pr.Number, opened.URL)
+	}
+}
+
+// migratedPRBody keeps the Gitea description and notes where the PR came from.
+func migratedPRBody(pr *forge.PullRequest) string {
+	note := fmt.Sprintf("_Migrated from local Gitea PR #%d by `maestro --sync`._", pr.Number)
+	if pr.Body == "" {
+		return note
+	}
+	return pr.Body + "\n\n---\n" + note
+}
@@ -0,0 +1,75 @@
+package sync
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/logx"
+)
+
+func TestMigratePullRequests(t *testing.T) {
+	giteaPRs := &fakeGiteaPRs{prs: []forge.PullRequest{
+		{Number: 1, Title: "Already migrated", HeadBranch: "story-1", BaseBranch: "main"},
+		{Number: 2, Title: "Add login", Body: "Adds the login form.", HeadBranch: "story-2", BaseBranch: "main"},
+		{Number: 3, Title: "Stacked", HeadBranch: "story-3", BaseBranch: "story-2"},
+		{Number: 4, Title: "Missing head", HeadBranch: "story-4", BaseBranch: "main"},
+		{Number: 5, Title: "Missing base", HeadBranch: "story-5", BaseBranch: "story-x"},
+	}}
+	gitHubPRs := &fakeGitHubPRs{}
+	syncer := &Syncer{
+		logger:    logx.NewLogger("syncer-test"),
+		gitHub:    &gitHubTarget{targetBranch: "main"},
+		giteaPRs:  giteaPRs,
+		gitHubPRs: gitHubPRs,
+	}
+	ledger := &Ledger{PullRequests: map[int]string{1: "https://github.com/org/repo/pull/7"}}
+	onGitHub := map[string]bool{"main": true, "story-1": true, "story-2": true, "story-3": true, "story-5": true}
+	result := &Result{}
+
+	syncer.migratePullRequests(context.Background(), ledger, onGitHub, result)
+
+	if len(gitHubPRs.opened) != 2 {
+		t.Fatalf("Expected 2 PRs to be opened, got %+v", gitHubPRs.opened)
+	}
+	first := gitHubPRs.opened[0]
+	if first.Title != "Add login" || first.Head != "story-2" || first.Base != "main" {
+		t.Errorf("Unexpected PR: %+v", first)
+	}
+	if !strings.HasPrefix(first.Body, "Adds the login form.") || !strings.Contains(first.Body, "local Gitea PR #2") {
+		t.Errorf("Expected the Gitea description and a migration note, got %q", first.Body)
+	}
+	if gitHubPRs.opened[1].Base != "story-2" {
+		t.Errorf("Expected the stacked PR to keep its base, got %+v", gitHubPRs.opened[1])
+	}
+
+	if len(result.PRsMigrated) != 2 || ledger.PullRequests[2] == "" || ledger.PullRequests[3] == "" {
+		t.Errorf("Expected migrated PRs in the result and ledger, got %+v / %+v", result.PRsMigrated, ledger.PullRequests)
+	}
+	if len(result.Warnings) != 2 {
+		t.Errorf("Expected warnings for PRs #4 and #5, got %v", result.Warnings)
+	}
+}
+
+func TestMigratePullRequests_DryRun(t *testing.T) {
+	gitHubPRs := &fakeGitHubPRs{}
+	syncer := &Syncer{
+		logger:    logx.NewLogger("syncer-test"),
+		gitHub:    &gitHubTarget{targetBranch: "main"},
+		giteaPRs:  &fakeGiteaPRs{prs: []forge.PullRequest{{Number: 2, Title: "Add login", HeadBranch: "story-2", BaseBranch: "main"}}},
+		gitHubPRs: gitHubPRs,
+		dryRun:    true,
+	}
+	ledger := &Ledger{PullRequests: map[int]string{}}
+	result := &Result{}
+
+	syncer.migratePullRequests(context.Background(), ledger, map[string]bool{"story-2": true}, result)
+
+	if len(gitHubPRs.opened) != 0 || len(ledger.PullRequests) != 0 {
+		t.Error("Expected a dry run not to open PRs or record them")
+	}
+	if len(result.PRsMigrated) != 1 || result.PRsMigrated[0].GiteaNumber != 2 {
+		t.Errorf("Expected the PR in the preview, got %+v", result.PRsMigrated)
+	}
+}
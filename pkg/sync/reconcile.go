@@ -0,0 +1,313 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"orchestrator/pkg/config"
+)
+
+// Divergence describes how the target branch on Gitea relates to GitHub.
+type Divergence string
+
+// Divergence values.
+const (
+	// DivergenceInSync means both forges point at the same commit.
+	DivergenceInSync Divergence = "in-sync"
+	// DivergenceGiteaAhead means GitHub can fast-forward to Gitea.
+	DivergenceGiteaAhead Divergence = "gitea-ahead"
+	// DivergenceGitHubAhead means Gitea can fast-forward to GitHub.
+	DivergenceGitHubAhead Divergence = "github-ahead"
+	// DivergenceDiverged means both forges gained commits the other lacks.
+	DivergenceDiverged Divergence = "diverged"
+	// DivergenceNoUpstream means GitHub has no target branch yet.
+	DivergenceNoUpstream Divergence = "no-upstream"
+)
+
+// Strategy is how offline work is integrated when the target branch diverged.
+type Strategy string
+
+// Strategy values.
+const (
+	// StrategyMerge merges the offline target branch into GitHub's. Offline
+	// commits keep their SHAs, so open story branches stay based on them.
+	StrategyMerge Strategy = "merge"
+	// StrategyRebase replays offline commits on top of GitHub's target branch
+	// for a linear history. Story branches still point at the pre-rebase commits.
+	StrategyRebase Strategy = "rebase"
+)
+
+// ParseStrategy validates a strategy name, defaulting to merge when empty.
+func ParseStrategy(name string) (Strategy, error) {
+	switch Strategy(name) {
+	case "", StrategyMerge:
+		return StrategyMerge, nil
+	case StrategyRebase:
+		return StrategyRebase, nil
+	default:
+		return "", fmt.Errorf("unknown sync strategy %q (expected %q or %q)", name, StrategyMerge, StrategyRebase)
+	}
+}
+
+// integrationBranch is the scratch branch offline work is integrated on.
+const integrationBranch = "maestro-sync"
+
+// TargetReport describes how the target branch was reconciled.
+type TargetReport struct {
+	// Divergence is how the forges related before the sync.
+	Divergence Divergence
+	// Strategy is how diverged work was integrated.
+	Strategy Strategy
+	// GiteaSHA is the target branch on Gitea before the sync.
+	GiteaSHA string
+	// GitHubSHA is the target branch on GitHub before the sync (empty without upstream).
+	GitHubSHA string
+	// Ahead is the number of commits only Gitea has.
+	Ahead int
+	// Behind is the number of commits only GitHub has.
+	Behind int
+	// OfflineCommits lists the commits only Gitea has, as "<short sha> <subject>".
+	OfflineCommits []string
+	// IntegratedSHA is the integrated commit when the branch diverged and integration succeeded.
+	IntegratedSHA string
+	// Conflicts lists the files that stopped integration.
+	Conflicts []string
+}
+
+// ResultSHA returns the commit both forges should point at after the sync,
+// or "" when integration stopped on conflicts.
+func (r *TargetReport) ResultSHA() string {
+	switch r.Divergence {
+	case DivergenceInSync, DivergenceGiteaAhead, DivergenceNoUpstream:
+		return r.GiteaSHA
+	case DivergenceGitHubAhead:
+		return r.GitHubSHA
+	default:
+		return r.IntegratedSHA
+	}
+}
+
+// reconcileTarget compares the target branch on both forges and, when they
+// diverged, integrates the offline work in the scratch clone.
+func (s *Syncer) reconcileTarget(ctx context.Context, tmpDir string) (*TargetReport, error) {
+	repoDir := filepath.Join(tmpDir, "repo")
+	target := s.gitHub.targetBranch
+	report := &TargetReport{Strategy: s.strategy}
+
+	giteaSHA, err := runGit(ctx, repoDir, "rev-parse", "--verify", "refs/remotes/origin/"+target)
+	if err != nil {
+		return nil, fmt.Errorf("target branch %s not found on Gitea: %w", target, err)
+	}
+	report.GiteaSHA = giteaSHA
+
+	gitHubSHA, err := runGit(ctx, repoDir, "rev-parse", "--verify", "--quiet", "refs/remotes/github/"+target)
+	if err != nil {
+		report.Divergence = DivergenceNoUpstream
+		return report, nil
+	}
+	report.GitHubSHA = gitHubSHA
+
+	if report.Ahead, err = countCommits(ctx, repoDir, gitHubSHA, giteaSHA); err != nil {
+		return nil, err
+	}
+	if report.Behind, err = countCommits(ctx, repoDir, giteaSHA, gitHubSHA); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case report.Ahead == 0 && report.Behind == 0:
+		report.Divergence = DivergenceInSync
+		return report, nil
+	case report.Behind == 0:
+		report.Divergence = DivergenceGiteaAhead
+		return report, nil
+	case report.Ahead == 0:
+		report.Divergence = DivergenceGitHubAhead
+		return report, nil
+	}
+
+	report.Divergence = DivergenceDiverged
+	offline, err := runGit(ctx, repoDir, "log", "--format=%h %s", gitHubSHA+".."+giteaSHA)
+	if err != nil {
+		return nil, err
+	}
+	if offline != "" {
+		report.OfflineCommits = strings.Split(offline, "\n")
+	}
+
+	s.logger.Info("🔀 %s diverged: %d offline and %d upstream commit(s), integrating with %s",
+		target, report.Ahead, report.Behind, s.strategy)
+	if err := s.integrate(ctx, repoDir, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// integrate combines the diverged target branches on a scratch branch. Conflicts
+// are recorded in the report and the operation is aborted, leaving both forges untouched.
+func (s *Syncer) integrate(ctx context.Context, repoDir string, report *TargetReport) error {
+	var start string
+	var args []string
+	switch report.Strategy {
+	case StrategyRebase:
+		start = report.GiteaSHA
+		args = []string{"rebase", report.GitHubSHA}
+	default:
+		start = report.GitHubSHA
+		args = []string{"merge", "--no-ff", "-m",
+			fmt.Sprintf("Merge offline work from Gitea into %s", s.gitHub.targetBranch), report.GiteaSHA}
+	}
+
+	if _, err := runGit(ctx, repoDir, "checkout", "-B", integrationBranch, start); err != nil {
+		return fmt.Errorf("failed to create integration branch: %w", err)
+	}
+
+	name, email := s.gitIdentity()
+	if _, err := runGit(ctx, repoDir, "config", "user.name", name); err != nil {
+		return err
+	}
+	if _, err := runGit(ctx, repoDir, "config", "user.email", email); err != nil {
+		return err
+	}
+
+	if _, err := runGit(ctx, repoDir, args...); err != nil {
+		conflicts, diffErr := runGit(ctx, repoDir, "diff", "--name-only", "--diff-filter=U")
+		_, abortErr := runGit(ctx, repoDir, args[0], "--abort")
+		if diffErr != nil || conflicts == "" {
+			return fmt.Errorf("failed to %s offline work: %w", args[0], errors.Join(err, abortErr))
+		}
+		report.Conflicts = strings.Split(conflicts, "\n")
+		s.logger.Warn("⚠️ %s stopped on conflicts in %d file(s)", args[0], len(report.Conflicts))
+		return nil
+	}
+
+	integrated, err := runGit(ctx, repoDir, "rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	report.IntegratedSHA = integrated
+	return nil
+}
+
+// pushTarget brings the target branch on both forges to the reconciled commit.
+func (s *Syncer) pushTarget(ctx context.Context, tmpDir string, report *TargetReport, result *Result) error {
+	repoDir := filepath.Join(tmpDir, "repo")
+	target := s.gitHub.targetBranch
+	ref := "refs/heads/" + target
+	sha := report.ResultSHA()
+
+	pushGitHub := report.Divergence == DivergenceGiteaAhead ||
+		report.Divergence == DivergenceNoUpstream || report.Divergence == DivergenceDiverged
+	pushGitea := report.Divergence == DivergenceGitHubAhead || report.Divergence == DivergenceDiverged
+
+	if pushGitHub {
+		if s.dryRun {
+			s.logger.Info("[DRY-RUN] Would push %s to GitHub at %s", target, shortSHA(sha))
+		} else {
+			if _, err := runGit(ctx, repoDir, "push", "github", sha+":"+ref); err != nil {
+				return fmt.Errorf("failed to push %s to GitHub: %w", target, err)
+			}
+			s.logger.Info("✓ Pushed %s to GitHub: %s", target, shortSHA(sha))
+		}
+		result.MainPushed = true
+	} else {
+		result.MainUpToDate = true
+	}
+
+	if pushGitea {
+		if s.dryRun {
+			s.logger.Info("[DRY-RUN] Would update %s on Gitea to %s", target, shortSHA(sha))
+		} else {
+			// The lease keeps an offline merge that landed during the sync from being overwritten.
+			lease := fmt.Sprintf("--force-with-lease=%s:%s", ref, report.GiteaSHA)
+			if _, err := runGit(ctx, repoDir, "push", lease, "origin", sha+":"+ref); err != nil {
+				return fmt.Errorf("failed to update %s on Gitea: %w", target, err)
+			}
+			s.logger.Info("✓ Updated %s on Gitea: %s", target, shortSHA(sha))
+		}
+		result.GiteaUpdated = true
+	}
+
+	return nil
+}
+
+// gitIdentity returns the committer used for integration commits.
+func (s *Syncer) gitIdentity() (name, email string) {
+	name, email = s.userName, s.userEmail
+	if name == "" {
+		name = config.DefaultGitUserName
+	}
+	if email == "" {
+		email = config.DefaultGitUserEmail
+	}
+	return strings.ReplaceAll(name, "{AGENT_ID}", "sync"), strings.ReplaceAll(email, "{AGENT_ID}", "sync")
+}
+
+// remoteBranches maps each branch of a remote in the scratch clone to its SHA.
+func remoteBranches(ctx context.Context, repoDir, remote string) (map[string]string, error) {
+	output, err := runGit(ctx, repoDir, "for-each-ref", "--format=%(refname:strip=3) %(objectname)", "refs/remotes/"+remote)
+	if err != nil {
+		return nil, err
+	}
+	branches := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		name, sha, ok := strings.Cut(line, " ")
+		if !ok || name == "HEAD" {
+			continue
+		}
+		branches[name] = sha
+	}
+	return branches, nil
+}
+
+// countCommits returns the number of commits reachable from to but not from.
+func countCommits(ctx context.Context, repoDir, from, to string) (int, error) {
+	output, err := runGit(ctx, repoDir, "rev-list", "--count", from+".."+to)
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(output)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected rev-list output %q: %w", output, err)
+	}
+	return count, nil
+}
+
+// isAncestor reports whether ancestor is reachable from descendant.
+func isAncestor(ctx context.Context, repoDir, ancestor, descendant string) (bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", ancestor, descendant)
+	cmd.Dir = repoDir
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base failed: %w", err)
+}
+
+// runGit runs a git command in dir and returns its trimmed output.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w\nOutput: %s", args[0], err, string(output))
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// shortSHA abbreviates a commit SHA for logs and reports.
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
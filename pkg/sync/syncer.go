@@ -13,28 +13,24 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"orchestrator/pkg/config"
 	"orchestrator/pkg/forge"
+	"orchestrator/pkg/forge/gitea"
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/mirror"
 )
 
-// Sync result status constants.
-const (
-	StatusPushed   = "pushed"
-	StatusUpToDate = "up-to-date"
-)
-
 // Result contains the results of a sync operation.
 type Result struct {
 	// BranchesPushed lists branches that were pushed to GitHub.
 	BranchesPushed []string
 	// Warnings contains non-fatal issues encountered during sync.
 	Warnings []string
-	// Success indicates if the sync completed successfully.
+	// Success indicates if the sync completed; false when the target branch has conflicts.
 	Success bool
 	// MainPushed indicates if the main branch was pushed.
 	MainPushed bool
@@ -42,6 +38,16 @@ type Result struct {
 	MainUpToDate bool
 	// MirrorUpdated indicates if the mirror was updated from GitHub.
 	MirrorUpdated bool
+	// Target describes how the target branch was reconciled between the forges.
+	Target *TargetReport
+	// GiteaUpdated indicates if upstream changes were brought into Gitea.
+	GiteaUpdated bool
+	// BranchesUnchanged lists branches GitHub already had at the same commit.
+	BranchesUnchanged []string
+	// BranchesSkipped lists branches that moved on GitHub and were not overwritten.
+	BranchesSkipped []string
+	// PRsMigrated lists open Gitea pull requests opened on GitHub.
+	PRsMigrated []MigratedPR
 }
 
 // gitHubTarget represents the GitHub remote target.
@@ -55,6 +61,7 @@ type giteaSource struct {
 	url   string
 	owner string
 	repo  string
+	token string
 }
 
 // Syncer handles synchronization between Gitea and GitHub.
@@ -62,7 +69,12 @@ type Syncer struct {
 	logger     *logx.Logger
 	gitHub     *gitHubTarget
 	gitea      *giteaSource
+	giteaPRs   giteaPRLister
+	gitHubPRs  gitHubPROpener
 	projectDir string
+	userName   string
+	userEmail  string
+	strategy   Strategy
 	dryRun     bool
 }
 
@@ -92,6 +104,9 @@ func NewSyncer(projectDir string, dryRun bool) (*Syncer, error) {
 	return &Syncer{
 		projectDir: projectDir,
 		dryRun:     dryRun,
+		strategy:   StrategyMerge,
+		userName:   cfg.Git.GitUserName,
+		userEmail:  cfg.Git.GitUserEmail,
 		logger:     logx.NewLogger("syncer"),
 		gitHub: &gitHubTarget{
 			repoURL:      cfg.Git.RepoURL,
@@ -101,11 +116,22 @@ func NewSyncer(projectDir string, dryRun bool) (*Syncer, error) {
 			url:   state.URL,
 			owner: state.Owner,
 			repo:  state.RepoName,
+			token: state.Token,
 		},
 	}, nil
 }
 
-// SyncToGitHub synchronizes changes from Gitea to GitHub.
+// WithStrategy sets how offline work is integrated when the target branch diverged.
+func (s *Syncer) WithStrategy(strategy Strategy) *Syncer {
+	s.strategy = strategy
+	return s
+}
+
+// SyncToGitHub reconciles Gitea with GitHub after airplane mode. Work happens
+// in a scratch clone: branches that moved since the last sync are pushed, a
+// diverged target branch is integrated with upstream changes and pushed to
+// both forges, and open Gitea PRs are opened on GitHub. In dry-run the clone
+// still integrates, so the result reports conflicts without touching either forge.
 func (s *Syncer) SyncToGitHub(ctx context.Context) (*Result, error) {
 	result := &Result{}
 
@@ -139,23 +165,55 @@ func (s *Syncer) SyncToGitHub(ctx context.Context) (*Result, error) {
 			fmt.Sprintf("Could not fetch from GitHub (may not exist yet): %v", fetchErr))
 	}
 
-	// Step 5: Push all branches to GitHub
+	ledger, err := LoadLedger(s.projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Detect divergence on the target branch and integrate offline work
+	s.logger.Info("🔀 Reconciling %s...", s.gitHub.targetBranch)
+	report, err := s.reconcileTarget(ctx, tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile %s: %w", s.gitHub.targetBranch, err)
+	}
+	result.Target = report
+
+	// Step 6: Push branches that moved since the last sync
 	s.logger.Info("📤 Pushing branches to GitHub...")
-	pushed, pushErr := s.pushAllBranches(ctx, tmpDir)
+	onGitHub, pushErr := s.pushAllBranches(ctx, tmpDir, ledger, result)
 	if pushErr != nil {
 		return nil, fmt.Errorf("failed to push branches: %w", pushErr)
 	}
-	result.BranchesPushed = pushed
 
-	// Step 6: Push main branch
-	mainResult, mainErr := s.pushMain(ctx, tmpDir)
-	if mainErr != nil {
-		return nil, fmt.Errorf("failed to push main branch: %w", mainErr)
+	// Step 7: Bring the target branch on both forges to the reconciled commit
+	if len(report.Conflicts) > 0 {
+		result.Warnings = append(result.Warnings,
+			fmt.Sprintf("%s diverged and could not be integrated automatically; resolve the conflicts and sync again",
+				s.gitHub.targetBranch))
+	} else {
+		if targetErr := s.pushTarget(ctx, tmpDir, report, result); targetErr != nil {
+			return nil, targetErr
+		}
 	}
-	result.MainPushed = mainResult == StatusPushed
-	result.MainUpToDate = mainResult == StatusUpToDate
 
-	// Step 7: Update mirror from GitHub
+	// Step 8: Open GitHub PRs for open Gitea PRs
+	s.logger.Info("🔁 Migrating open pull requests...")
+	s.migratePullRequests(ctx, ledger, onGitHub, result)
+
+	if !s.dryRun {
+		ledger.TargetBranch = s.gitHub.targetBranch
+		ledger.LastSync = time.Now()
+		if saveErr := SaveLedger(s.projectDir, ledger); saveErr != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Failed to save sync ledger: %v", saveErr))
+		}
+	}
+
+	// A target branch left diverged means the mirror must keep following Gitea.
+	if len(report.Conflicts) > 0 {
+		return result, nil
+	}
+
+	// Step 9: Update mirror from GitHub
 	if !s.dryRun {
 		s.logger.Info("📥 Updating mirror from GitHub...")
 		if mirrorErr := s.updateMirror(ctx); mirrorErr != nil {
@@ -211,6 +269,14 @@ func (s *Syncer) cloneFromGitea(ctx context.Context, tmpDir string) error {
 		return fmt.Errorf("git clone failed: %w\nOutput: %s", err, string(output))
 	}
 
+	// Pushes bring upstream changes into Gitea, which needs the admin token.
+	if s.gitea.token != "" {
+		pushURL := strings.Replace(giteaURL, "://", fmt.Sprintf("://%s:%s@", gitea.DefaultAdminUser, s.gitea.token), 1)
+		if _, err := runGit(ctx, filepath.Join(tmpDir, "repo"), "remote", "set-url", "--push", "origin", pushURL); err != nil {
+			return fmt.Errorf("failed to set Gitea push URL: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -242,86 +308,90 @@ func (s *Syncer) fetchGitHub(ctx context.Context, tmpDir string) error {
 	return nil
 }
 
-// pushAllBranches pushes all branches to GitHub.
-func (s *Syncer) pushAllBranches(ctx context.Context, tmpDir string) ([]string, error) {
+// pushAllBranches pushes every Gitea branch that GitHub lacks or that moved
+// since the last sync, and returns the branches GitHub has afterwards. A branch
+// that also moved on GitHub is skipped rather than overwritten.
+func (s *Syncer) pushAllBranches(ctx context.Context, tmpDir string, ledger *Ledger, result *Result) (map[string]bool, error) {
 	repoDir := filepath.Join(tmpDir, "repo")
 
-	// List all branches
-	cmd := exec.CommandContext(ctx, "git", "branch", "-r", "--list", "origin/*")
-	cmd.Dir = repoDir
-	output, err := cmd.CombinedOutput()
+	giteaBranches, err := remoteBranches(ctx, repoDir, "origin")
 	if err != nil {
-		return nil, fmt.Errorf("git branch list failed: %w\nOutput: %s", err, string(output))
+		return nil, fmt.Errorf("failed to list Gitea branches: %w", err)
+	}
+	gitHubBranches, err := remoteBranches(ctx, repoDir, "github")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GitHub branches: %w", err)
+	}
+
+	onGitHub := make(map[string]bool, len(gitHubBranches))
+	for branch := range gitHubBranches {
+		onGitHub[branch] = true
 	}
 
-	branches := strings.Split(strings.TrimSpace(string(output)), "\n")
-	pushed := make([]string, 0, len(branches))
+	names := make([]string, 0, len(giteaBranches))
+	for branch := range giteaBranches {
+		names = append(names, branch)
+	}
+	sort.Strings(names)
 
-	for _, branch := range branches {
-		branch = strings.TrimSpace(branch)
-		if branch == "" {
+	for _, branchName := range names {
+		// Skip main branch (handled separately)
+		if branchName == s.gitHub.targetBranch {
 			continue
 		}
+		sha := giteaBranches[branchName]
+		remoteSHA, exists := gitHubBranches[branchName]
 
-		// Skip HEAD reference
-		if strings.Contains(branch, "HEAD") {
+		if remoteSHA == sha {
+			result.BranchesUnchanged = append(result.BranchesUnchanged, branchName)
+			ledger.Branches[branchName] = sha
 			continue
 		}
 
-		// Extract branch name (remove "origin/" prefix)
-		branchName := strings.TrimPrefix(branch, "origin/")
-
-		// Skip main branch (handled separately)
-		if branchName == s.gitHub.targetBranch {
-			continue
+		// A branch may be replaced when GitHub still has what the last sync
+		// pushed; otherwise only fast-forwards are safe.
+		var args []string
+		switch {
+		case !exists:
+			args = []string{"push", "github"}
+		case ledger.Branches[branchName] == remoteSHA:
+			args = []string{"push", fmt.Sprintf("--force-with-lease=refs/heads/%s:%s", branchName, remoteSHA), "github"}
+		default:
+			fastForward, ancestorErr := isAncestor(ctx, repoDir, remoteSHA, sha)
+			if ancestorErr != nil {
+				return nil, ancestorErr
+			}
+			if !fastForward {
+				s.logger.Warn("Branch %s changed on GitHub since the last sync, not overwriting it", branchName)
+				result.BranchesSkipped = append(result.BranchesSkipped, branchName)
+				result.Warnings = append(result.Warnings,
+					fmt.Sprintf("Branch %s changed on GitHub since the last sync and was not pushed", branchName))
+				continue
+			}
+			args = []string{"push", "github"}
 		}
 
 		if s.dryRun {
 			s.logger.Info("[DRY-RUN] Would push branch: %s", branchName)
-			pushed = append(pushed, branchName)
+			result.BranchesPushed = append(result.BranchesPushed, branchName)
+			onGitHub[branchName] = true
 			continue
 		}
 
 		// Push to GitHub
-		pushCmd := exec.CommandContext(ctx, "git", "push", "github", fmt.Sprintf("origin/%s:refs/heads/%s", branchName, branchName))
-		pushCmd.Dir = repoDir
-		pushOutput, pushErr := pushCmd.CombinedOutput()
-		if pushErr != nil {
-			s.logger.Warn("Failed to push branch %s: %v\nOutput: %s", branchName, pushErr, string(pushOutput))
+		if _, pushErr := runGit(ctx, repoDir, append(args, sha+":refs/heads/"+branchName)...); pushErr != nil {
+			s.logger.Warn("Failed to push branch %s: %v", branchName, pushErr)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Failed to push branch %s", branchName))
 			continue
 		}
 
-		pushed = append(pushed, branchName)
+		result.BranchesPushed = append(result.BranchesPushed, branchName)
+		ledger.Branches[branchName] = sha
+		onGitHub[branchName] = true
 		s.logger.Info("✓ Pushed branch: %s", branchName)
 	}
 
-	return pushed, nil
-}
-
-// pushMain pushes the main branch to GitHub.
-func (s *Syncer) pushMain(ctx context.Context, tmpDir string) (string, error) {
-	repoDir := filepath.Join(tmpDir, "repo")
-
-	if s.dryRun {
-		s.logger.Info("[DRY-RUN] Would push main branch: %s", s.gitHub.targetBranch)
-		return StatusPushed, nil
-	}
-
-	// Push main branch
-	cmd := exec.CommandContext(ctx, "git", "push", "github",
-		fmt.Sprintf("origin/%s:refs/heads/%s", s.gitHub.targetBranch, s.gitHub.targetBranch))
-	cmd.Dir = repoDir
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		// Check if it's "already up-to-date"
-		if strings.Contains(string(output), "Everything up-to-date") {
-			return StatusUpToDate, nil
-		}
-		return "", fmt.Errorf("git push main failed: %w\nOutput: %s", err, string(output))
-	}
-
-	s.logger.Info("✓ Pushed main branch: %s", s.gitHub.targetBranch)
-	return StatusPushed, nil
+	return onGitHub, nil
 }
 
 // updateMirror updates the local mirror from GitHub.
@@ -0,0 +1,314 @@
+package sync
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orchestrator/pkg/forge"
+	"orchestrator/pkg/logx"
+)
+
+// testForges holds a bare "Gitea" and "GitHub" repository and a working clone
+// that pushes to both.
+type testForges struct {
+	giteaURL string
+	giteaDir string
+	gitHub   string
+	work     string
+}
+
+func newTestForges(t *testing.T) *testForges {
+	t.Helper()
+	base := t.TempDir()
+	f := &testForges{
+		giteaURL: filepath.Join(base, "gitea"),
+		giteaDir: filepath.Join(base, "gitea", "maestro", "project.git"),
+		gitHub:   filepath.Join(base, "github.git"),
+		work:     filepath.Join(base, "work"),
+	}
+	for _, dir := range []string{f.giteaDir, f.gitHub, f.work} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	gitCmd(t, f.giteaDir, "init", "--bare", "--initial-branch=main")
+	gitCmd(t, f.gitHub, "init", "--bare", "--initial-branch=main")
+
+	gitCmd(t, f.work, "init", "--initial-branch=main")
+	gitCmd(t, f.work, "config", "user.email", "test@test.com")
+	gitCmd(t, f.work, "config", "user.name", "Test")
+	gitCmd(t, f.work, "remote", "add", "gitea", f.giteaDir)
+	gitCmd(t, f.work, "remote", "add", "github", f.gitHub)
+	f.commit(t, "README.md", "# Project\n", "Initial commit")
+	gitCmd(t, f.work, "push", "gitea", "main")
+	gitCmd(t, f.work, "push", "github", "main")
+	return f
+}
+
+// commit writes a file in the working clone and commits it on the current branch.
+func (f *testForges) commit(t *testing.T, file, content, message string) string {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(f.work, file), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", file, err)
+	}
+	gitCmd(t, f.work, "add", "-A")
+	gitCmd(t, f.work, "commit", "-m", message)
+	return gitCmd(t, f.work, "rev-parse", "HEAD")
+}
+
+// diverge gives GitHub's main an upstream commit and Gitea's main an offline one.
+func (f *testForges) diverge(t *testing.T, upstreamFile, offlineFile string) (upstream, offline string) {
+	t.Helper()
+	upstream = f.commit(t, upstreamFile, "upstream\n", "Upstream change")
+	gitCmd(t, f.work, "push", "github", "main")
+	gitCmd(t, f.work, "reset", "--hard", "HEAD~1")
+	offline = f.commit(t, offlineFile, "offline\n", "Offline change")
+	gitCmd(t, f.work, "push", "gitea", "main")
+	return upstream, offline
+}
+
+func (f *testForges) newSyncer(t *testing.T, dryRun bool) *Syncer {
+	t.Helper()
+	return &Syncer{
+		logger:     logx.NewLogger("syncer-test"),
+		gitHub:     &gitHubTarget{repoURL: f.gitHub, targetBranch: "main"},
+		gitea:      &giteaSource{url: f.giteaURL, owner: "maestro", repo: "project"},
+		giteaPRs:   &fakeGiteaPRs{},
+		gitHubPRs:  &fakeGitHubPRs{},
+		projectDir: t.TempDir(),
+		strategy:   StrategyMerge,
+		dryRun:     dryRun,
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func TestSyncToGitHub_IncrementalBranches(t *testing.T) {
+	f := newTestForges(t)
+	f.commit(t, "offline.txt", "offline\n", "Offline change")
+	gitCmd(t, f.work, "push", "gitea", "main")
+	gitCmd(t, f.work, "checkout", "-b", "story-1")
+	f.commit(t, "story.txt", "v1\n", "Story work")
+	gitCmd(t, f.work, "push", "gitea", "story-1")
+
+	syncer := f.newSyncer(t, false)
+	result, err := syncer.SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if !result.Success || result.Target.Divergence != DivergenceGiteaAhead || !result.MainPushed {
+		t.Fatalf("Expected a successful gitea-ahead sync, got %+v (target %+v)", result, result.Target)
+	}
+	if len(result.BranchesPushed) != 1 || result.BranchesPushed[0] != "story-1" {
+		t.Errorf("Expected story-1 to be pushed, got %v", result.BranchesPushed)
+	}
+	if gitCmd(t, f.gitHub, "rev-parse", "main") != gitCmd(t, f.giteaDir, "rev-parse", "main") {
+		t.Error("Expected GitHub main to match Gitea main")
+	}
+
+	ledger, err := LoadLedger(syncer.projectDir)
+	if err != nil {
+		t.Fatalf("LoadLedger failed: %v", err)
+	}
+	if ledger.Branches["story-1"] != gitCmd(t, f.giteaDir, "rev-parse", "story-1") || ledger.TargetBranch != "main" {
+		t.Errorf("Unexpected ledger: %+v", ledger)
+	}
+
+	// A second sync has nothing to push.
+	result, err = syncer.SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("Second SyncToGitHub failed: %v", err)
+	}
+	if result.Target.Divergence != DivergenceInSync || !result.MainUpToDate || len(result.BranchesPushed) != 0 {
+		t.Errorf("Expected nothing to push, got %+v", result)
+	}
+	if len(result.BranchesUnchanged) != 1 || result.BranchesUnchanged[0] != "story-1" {
+		t.Errorf("Expected story-1 unchanged, got %v", result.BranchesUnchanged)
+	}
+
+	// A branch rewritten offline replaces what the last sync pushed.
+	f.commit(t, "story.txt", "v2\n", "Story rework")
+	gitCmd(t, f.work, "reset", "--soft", "HEAD~2")
+	rewritten := f.commit(t, "story.txt", "v2\n", "Story work, squashed")
+	gitCmd(t, f.work, "push", "--force", "gitea", "story-1")
+
+	result, err = syncer.SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("Third SyncToGitHub failed: %v", err)
+	}
+	if len(result.BranchesPushed) != 1 || gitCmd(t, f.gitHub, "rev-parse", "story-1") != rewritten {
+		t.Errorf("Expected rewritten story-1 on GitHub, got %+v", result)
+	}
+}
+
+func TestSyncToGitHub_SkipsBranchMovedOnGitHub(t *testing.T) {
+	f := newTestForges(t)
+	gitCmd(t, f.work, "checkout", "-b", "story-1")
+	base := f.commit(t, "story.txt", "v1\n", "Story work")
+	f.commit(t, "review.txt", "fix\n", "Fix from review on GitHub")
+	gitCmd(t, f.work, "push", "github", "story-1")
+	gitCmd(t, f.work, "reset", "--hard", base)
+	f.commit(t, "offline.txt", "more\n", "More offline work")
+	gitCmd(t, f.work, "push", "gitea", "story-1")
+	onGitHub := gitCmd(t, f.gitHub, "rev-parse", "story-1")
+
+	result, err := f.newSyncer(t, false).SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if len(result.BranchesSkipped) != 1 || len(result.BranchesPushed) != 0 {
+		t.Errorf("Expected story-1 to be skipped, got %+v", result)
+	}
+	if gitCmd(t, f.gitHub, "rev-parse", "story-1") != onGitHub {
+		t.Error("Expected story-1 on GitHub to be left alone")
+	}
+}
+
+func TestSyncToGitHub_GitHubAhead(t *testing.T) {
+	f := newTestForges(t)
+	upstream := f.commit(t, "upstream.txt", "upstream\n", "Upstream change")
+	gitCmd(t, f.work, "push", "github", "main")
+
+	result, err := f.newSyncer(t, false).SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if result.Target.Divergence != DivergenceGitHubAhead || !result.GiteaUpdated || result.MainPushed {
+		t.Errorf("Expected only Gitea to be updated, got %+v (target %+v)", result, result.Target)
+	}
+	if gitCmd(t, f.giteaDir, "rev-parse", "main") != upstream {
+		t.Error("Expected Gitea main to fast-forward to GitHub")
+	}
+}
+
+func TestSyncToGitHub_DivergedMerge(t *testing.T) {
+	f := newTestForges(t)
+	upstream, offline := f.diverge(t, "upstream.txt", "offline.txt")
+
+	result, err := f.newSyncer(t, false).SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	report := result.Target
+	if report.Divergence != DivergenceDiverged || report.Ahead != 1 || report.Behind != 1 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if len(report.OfflineCommits) != 1 || !strings.HasSuffix(report.OfflineCommits[0], "Offline change") {
+		t.Errorf("Unexpected offline commits: %v", report.OfflineCommits)
+	}
+	if !result.Success || !result.MainPushed || !result.GiteaUpdated {
+		t.Errorf("Expected both forges to be updated, got %+v", result)
+	}
+
+	for _, repo := range []string{f.gitHub, f.giteaDir} {
+		if got := gitCmd(t, repo, "rev-parse", "main"); got != report.IntegratedSHA {
+			t.Errorf("Expected main at %s in %s, got %s", report.IntegratedSHA, repo, got)
+		}
+	}
+	parents := gitCmd(t, f.gitHub, "rev-parse", "main^1", "main^2")
+	if parents != upstream+"\n"+offline {
+		t.Errorf("Expected a merge of upstream and offline work, got parents:\n%s", parents)
+	}
+}
+
+func TestSyncToGitHub_DivergedRebase(t *testing.T) {
+	f := newTestForges(t)
+	upstream, _ := f.diverge(t, "upstream.txt", "offline.txt")
+
+	result, err := f.newSyncer(t, false).WithStrategy(StrategyRebase).SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if !result.Success || result.Target.IntegratedSHA == "" {
+		t.Fatalf("Expected a successful rebase, got %+v (target %+v)", result, result.Target)
+	}
+	if gitCmd(t, f.gitHub, "rev-parse", "main^") != upstream {
+		t.Error("Expected the offline commit to be replayed on top of upstream")
+	}
+	if gitCmd(t, f.gitHub, "log", "-1", "--format=%s", "main") != "Offline change" {
+		t.Error("Expected the rebased offline commit at the tip of main")
+	}
+}
+
+func TestSyncToGitHub_DivergedDryRun(t *testing.T) {
+	f := newTestForges(t)
+	upstream, offline := f.diverge(t, "upstream.txt", "offline.txt")
+
+	syncer := f.newSyncer(t, true)
+	result, err := syncer.SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if !result.Success || result.Target.IntegratedSHA == "" || !result.MainPushed || !result.GiteaUpdated {
+		t.Errorf("Expected the dry run to preview both pushes, got %+v", result)
+	}
+	if gitCmd(t, f.gitHub, "rev-parse", "main") != upstream || gitCmd(t, f.giteaDir, "rev-parse", "main") != offline {
+		t.Error("Expected a dry run to leave both forges untouched")
+	}
+	if _, err := os.Stat(ledgerPath(syncer.projectDir)); !os.IsNotExist(err) {
+		t.Error("Expected a dry run not to write the ledger")
+	}
+}
+
+func TestSyncToGitHub_DivergedConflict(t *testing.T) {
+	f := newTestForges(t)
+	upstream, offline := f.diverge(t, "README.md", "README.md")
+
+	result, err := f.newSyncer(t, false).SyncToGitHub(context.Background())
+	if err != nil {
+		t.Fatalf("SyncToGitHub failed: %v", err)
+	}
+	if result.Success || result.MirrorUpdated || result.MainPushed {
+		t.Errorf("Expected the sync to stop before touching main, got %+v", result)
+	}
+	if len(result.Target.Conflicts) != 1 || result.Target.Conflicts[0] != "README.md" {
+		t.Errorf("Expected a conflict in README.md, got %v", result.Target.Conflicts)
+	}
+	if gitCmd(t, f.gitHub, "rev-parse", "main") != upstream || gitCmd(t, f.giteaDir, "rev-parse", "main") != offline {
+		t.Error("Expected both forges to keep their main branch")
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for name, want := range map[string]Strategy{"": StrategyMerge, "merge": StrategyMerge, "rebase": StrategyRebase} {
+		got, err := ParseStrategy(name)
+		if err != nil || got != want {
+			t.Errorf("ParseStrategy(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+	if _, err := ParseStrategy("squash"); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+// fakeGiteaPRs returns a fixed list of open pull requests.
+type fakeGiteaPRs struct {
+	prs []forge.PullRequest
+}
+
+func (f *fakeGiteaPRs) ListOpenPRs(_ context.Context) ([]forge.PullRequest, error) {
+	return f.prs, nil
+}
+
+// fakeGitHubPRs records the pull requests it is asked to open.
+type fakeGitHubPRs struct {
+	opened []forge.PRCreateOptions
+}
+
+func (f *fakeGitHubPRs) GetOrCreatePR(_ context.Context, opts forge.PRCreateOptions) (*forge.PullRequest, error) {
+	f.opened = append(f.opened, opts)
+	return &forge.PullRequest{URL: "https://github.com/org/repo/pull/" + opts.Head}, nil
+}
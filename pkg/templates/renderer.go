@@ -60,6 +60,8 @@ const (
 	TestingVerificationTemplate StateTemplate = "coder/testing_verification.tpl.md"
 	// TestingAdversarialProbingTemplate is the template for adversarial robustness probing in TESTING.
 	TestingAdversarialProbingTemplate StateTemplate = "coder/testing_adversarial_probing.tpl.md"
+	// ConflictResolutionTemplate is the template for resolving rebase conflicts in PREPARE_MERGE.
+	ConflictResolutionTemplate StateTemplate = "coder/conflict_resolution.tpl.md"
 	// ApprovalTemplate is the template for code approval requests.
 	ApprovalTemplate StateTemplate = "coder/approval.tpl.md"
 	// TestFailureInstructionsTemplate is the mini-template for app test failure instructions.
@@ -95,6 +97,10 @@ const (
 	SpecReviewTemplate StateTemplate = "architect/spec_review.tpl.md"
 	// SpecAnalysisTemplate is the template for architect story generation (second phase - after approval).
 	SpecAnalysisTemplate StateTemplate = "architect/spec_analysis.tpl.md"
+	// SpecAmendmentReviewTemplate is the template for architect review of a spec amendment's requirement changes.
+	SpecAmendmentReviewTemplate StateTemplate = "architect/spec_amendment_review.tpl.md"
+	// SpecAmendmentStoriesTemplate is the template for mapping an approved amendment onto existing stories.
+	SpecAmendmentStoriesTemplate StateTemplate = "architect/spec_amendment_stories.tpl.md"
 	// TechnicalQATemplate is the template for architect technical Q&A state.
 	TechnicalQATemplate StateTemplate = "architect/technical_qa.tpl.md"
 	// CodeReviewTemplate is the template for architect code review state.
@@ -175,6 +181,7 @@ func NewRenderer() (*Renderer, error) {
 		TestingTemplate,
 		TestingVerificationTemplate,
 		TestingAdversarialProbingTemplate,
+		ConflictResolutionTemplate,
 		ApprovalTemplate,
 		TestFailureInstructionsTemplate,
 		DevOpsTestFailureInstructionsTemplate,
@@ -192,6 +199,8 @@ func NewRenderer() (*Renderer, error) {
 		BudgetReviewCodingTemplate,
 		SpecReviewTemplate,
 		SpecAnalysisTemplate,
+		SpecAmendmentReviewTemplate,
+		SpecAmendmentStoriesTemplate,
 		TechnicalQATemplate,
 		CodeReviewTemplate,
 		AppCodeReviewTemplate,
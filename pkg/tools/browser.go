@@ -0,0 +1,259 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ToolBrowser is the constant name for the headless browser tool.
+const ToolBrowser = "browser"
+
+// maxScreenshotsPerRun bounds the screenshots one verification run keeps;
+// past a handful they stop being evidence and start being noise.
+const maxScreenshotsPerRun = 10
+
+// BrowserPage is the page the browser tool drives. *browser.Session
+// implements it; tests substitute a fake.
+type BrowserPage interface {
+	Navigate(ctx context.Context, target string) (string, error)
+	URL(ctx context.Context) (string, error)
+	Click(ctx context.Context, selector string) error
+	Fill(ctx context.Context, selector, value string) error
+	Text(ctx context.Context, selector string) (string, bool, error)
+	DOM(ctx context.Context, selector string) (string, bool, error)
+	Screenshot(ctx context.Context, fullPage bool) ([]byte, error)
+}
+
+// Screenshot is a page capture saved as verification evidence.
+type Screenshot struct {
+	Name    string `json:"name"`    // e.g. "shot-01.png"; what submit_verification refers to
+	Path    string `json:"path"`    // file on the orchestrator host
+	Caption string `json:"caption"` // what the verifier said it shows
+	URL     string `json:"url"`     // page the capture was taken of
+}
+
+// BrowserTool lets the verifier use the application as a user would:
+// navigate, click, fill forms, read the page and take screenshots. The
+// browser runs in a sidecar on the dev container's network; the page is
+// opened on first use, so a run that never browses never starts one.
+//
+// Screenshots are written to evidenceDir on the host and kept in the tool,
+// for the caller to attach to the verification evidence.
+type BrowserTool struct {
+	page        func(ctx context.Context) (BrowserPage, error)
+	evidenceDir string
+	appHost     string
+	screenshots []Screenshot
+	mu          sync.Mutex
+}
+
+// NewBrowserTool creates a browser tool. page opens (or returns) the page;
+// appHost is the dev container's hostname on the browser's network, which
+// localhost URLs are rewritten to.
+func NewBrowserTool(page func(ctx context.Context) (BrowserPage, error), evidenceDir, appHost string) *BrowserTool {
+	return &BrowserTool{page: page, evidenceDir: evidenceDir, appHost: appHost}
+}
+
+// Name returns the tool name.
+func (t *BrowserTool) Name() string {
+	return ToolBrowser
+}
+
+// Screenshots returns the screenshots taken so far.
+func (t *BrowserTool) Screenshots() []Screenshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Screenshot(nil), t.screenshots...)
+}
+
+// PromptDocumentation returns formatted tool documentation for prompts.
+func (t *BrowserTool) PromptDocumentation() string {
+	return `- **browser** - Drive a headless browser against the running application
+  - Parameters: action (REQUIRED: navigate, click, fill, text, dom, screenshot), url, selector, value, caption, full_page
+  - navigate: url (http/https). The app runs in your dev container; localhost URLs are rewritten to reach it
+  - click / fill: selector (CSS); fill also takes value
+  - text / dom: optional selector; defaults to the whole page
+  - screenshot: caption (REQUIRED, what it shows), full_page (optional). Returns a name to cite in submit_verification`
+}
+
+// Definition returns the tool definition for LLM.
+func (t *BrowserTool) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name: ToolBrowser,
+		Description: `Drive a headless browser to verify UI behaviour. Navigate to the running app, click elements, fill form fields, read rendered text or DOM, and take screenshots as evidence. ` +
+			`The app must already be running in your dev container; localhost URLs are rewritten to reach it.`,
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"action": {
+					Type:        "string",
+					Description: "What to do",
+					Enum:        []string{"navigate", "click", "fill", "text", "dom", "screenshot"},
+				},
+				"url": {
+					Type:        "string",
+					Description: "URL to open (navigate only), e.g. http://localhost:3000/login",
+				},
+				"selector": {
+					Type:        "string",
+					Description: "CSS selector of the element (click, fill; optional for text and dom)",
+				},
+				"value": {
+					Type:        "string",
+					Description: "Value to enter (fill only)",
+				},
+				"caption": {
+					Type:        "string",
+					Description: "What the screenshot shows, e.g. 'error banner after submitting an empty form' (screenshot only)",
+				},
+				"full_page": {
+					Type:        "boolean",
+					Description: "Capture the whole scrollable page rather than the viewport (screenshot only)",
+				},
+			},
+			Required: []string{"action"},
+		},
+	}
+}
+
+// Exec executes the browser tool.
+func (t *BrowserTool) Exec(ctx context.Context, args map[string]any) (*ExecResult, error) {
+	action, ok := args["action"].(string)
+	if !ok || action == "" {
+		return nil, fmt.Errorf("action is required and must be a string")
+	}
+	selector, _ := args["selector"].(string)
+
+	page, err := t.page(ctx)
+	if err != nil {
+		return t.errorResult(fmt.Sprintf("browser unavailable: %v", err))
+	}
+
+	response := map[string]any{"success": true, "action": action}
+	switch action {
+	case "navigate":
+		err = t.navigate(ctx, page, args, response)
+	case "click":
+		if selector == "" {
+			return nil, fmt.Errorf("selector is required for click")
+		}
+		err = page.Click(ctx, selector)
+	case "fill":
+		value, hasValue := args["value"].(string)
+		if selector == "" || !hasValue {
+			return nil, fmt.Errorf("selector and value are required for fill")
+		}
+		err = page.Fill(ctx, selector, value)
+	case "text", "dom":
+		read := page.Text
+		if action == "dom" {
+			read = page.DOM
+		}
+		var content string
+		var truncated bool
+		if content, truncated, err = read(ctx, selector); err == nil {
+			response["content"] = content
+			response["truncated"] = truncated
+		}
+	case "screenshot":
+		err = t.screenshot(ctx, page, args, response)
+	default:
+		return nil, fmt.Errorf("unknown action %q", action)
+	}
+	if err != nil {
+		return t.errorResult(err.Error())
+	}
+
+	if _, has := response["url"]; !has {
+		if current, urlErr := page.URL(ctx); urlErr == nil {
+			response["url"] = current
+		}
+	}
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
+
+func (t *BrowserTool) navigate(ctx context.Context, page BrowserPage, args, response map[string]any) error {
+	raw, _ := args["url"].(string)
+	target, err := url.Parse(raw)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("url must be an absolute http(s) URL, got %q", raw)
+	}
+	// The browser runs in its own container, where localhost is the browser
+	// itself. Agents think of the app they started as being on localhost.
+	if host := target.Hostname(); t.appHost != "" && (host == "localhost" || host == "127.0.0.1" || host == "0.0.0.0") {
+		if port := target.Port(); port != "" {
+			target.Host = t.appHost + ":" + port
+		} else {
+			target.Host = t.appHost
+		}
+		response["note"] = fmt.Sprintf("localhost is the browser's own container; opened %s instead", target.String())
+	}
+
+	title, err := page.Navigate(ctx, target.String())
+	if err != nil {
+		return err
+	}
+	response["title"] = title
+	return nil
+}
+
+func (t *BrowserTool) screenshot(ctx context.Context, page BrowserPage, args, response map[string]any) error {
+	caption, _ := args["caption"].(string)
+	if strings.TrimSpace(caption) == "" {
+		return fmt.Errorf("caption is required for screenshot: say what it shows")
+	}
+	fullPage, _ := args["full_page"].(bool)
+
+	t.mu.Lock()
+	taken := len(t.screenshots)
+	t.mu.Unlock()
+	if taken >= maxScreenshotsPerRun {
+		return fmt.Errorf("screenshot limit reached (%d); cite the ones already taken", maxScreenshotsPerRun)
+	}
+
+	png, err := page.Screenshot(ctx, fullPage)
+	if err != nil {
+		return err
+	}
+	current, _ := page.URL(ctx)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	name := fmt.Sprintf("shot-%02d.png", len(t.screenshots)+1)
+	path := filepath.Join(t.evidenceDir, name)
+	if err := os.MkdirAll(t.evidenceDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create evidence directory: %w", err)
+	}
+	if err := os.WriteFile(path, png, 0o644); err != nil { //nolint:gosec // evidence is meant to be readable
+		return fmt.Errorf("failed to save screenshot: %w", err)
+	}
+	t.screenshots = append(t.screenshots, Screenshot{Name: name, Path: path, Caption: caption, URL: current})
+
+	response["screenshot"] = name
+	response["url"] = current
+	response["bytes"] = len(png)
+	return nil
+}
+
+// errorResult creates a JSON error response.
+func (t *BrowserTool) errorResult(msg string) (*ExecResult, error) {
+	response := map[string]any{
+		"success": false,
+		"error":   msg,
+	}
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal error response: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
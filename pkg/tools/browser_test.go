@@ -0,0 +1,185 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakePage records what the browser tool asked of it.
+type fakePage struct {
+	clickErr  error
+	navigated string
+	clicked   string
+	filled    [2]string
+	url       string
+}
+
+func (p *fakePage) Navigate(_ context.Context, target string) (string, error) {
+	p.navigated = target
+	p.url = target
+	return "Login", nil
+}
+
+func (p *fakePage) URL(_ context.Context) (string, error) { return p.url, nil }
+
+func (p *fakePage) Click(_ context.Context, selector string) error {
+	p.clicked = selector
+	return p.clickErr
+}
+
+func (p *fakePage) Fill(_ context.Context, selector, value string) error {
+	p.filled = [2]string{selector, value}
+	return nil
+}
+
+func (p *fakePage) Text(_ context.Context, _ string) (string, bool, error) {
+	return "Welcome back", false, nil
+}
+
+func (p *fakePage) DOM(_ context.Context, _ string) (string, bool, error) {
+	return "<html></html>", false, nil
+}
+
+func (p *fakePage) Screenshot(_ context.Context, _ bool) ([]byte, error) {
+	return []byte("\x89PNG"), nil
+}
+
+func newFakeBrowserTool(t *testing.T, page *fakePage) *BrowserTool {
+	t.Helper()
+	return NewBrowserTool(func(_ context.Context) (BrowserPage, error) { return page, nil }, t.TempDir(), "maestro-coder-001")
+}
+
+func decodeBrowserResult(t *testing.T, result *ExecResult) map[string]any {
+	t.Helper()
+	var response map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &response); err != nil {
+		t.Fatalf("result is not JSON: %v", err)
+	}
+	return response
+}
+
+func TestBrowserTool_NavigateRewritesLocalhost(t *testing.T) {
+	page := &fakePage{}
+	tool := newFakeBrowserTool(t, page)
+
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "navigate", "url": "http://localhost:3000/login"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page.navigated != "http://maestro-coder-001:3000/login" {
+		t.Errorf("navigated to %q, want the dev container's hostname", page.navigated)
+	}
+	response := decodeBrowserResult(t, result)
+	if response["title"] != "Login" || response["note"] == nil {
+		t.Errorf("unexpected response: %v", response)
+	}
+}
+
+func TestBrowserTool_NavigateRejectsNonHTTP(t *testing.T) {
+	page := &fakePage{}
+	tool := newFakeBrowserTool(t, page)
+
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "navigate", "url": "file:///etc/passwd"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response := decodeBrowserResult(t, result); response["success"] != false {
+		t.Errorf("expected failure, got %v", response)
+	}
+	if page.navigated != "" {
+		t.Errorf("page should not have navigated, went to %q", page.navigated)
+	}
+}
+
+func TestBrowserTool_ClickAndFill(t *testing.T) {
+	page := &fakePage{}
+	tool := newFakeBrowserTool(t, page)
+
+	if _, err := tool.Exec(context.Background(), map[string]any{"action": "fill", "selector": "#email", "value": "a@b.c"}); err != nil {
+		t.Fatalf("fill: %v", err)
+	}
+	if page.filled != [2]string{"#email", "a@b.c"} {
+		t.Errorf("filled %v", page.filled)
+	}
+	if _, err := tool.Exec(context.Background(), map[string]any{"action": "click"}); err == nil {
+		t.Error("expected error for click without selector")
+	}
+
+	page.clickErr = errors.New("no element matches the selector: #go")
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "click", "selector": "#go"})
+	if err != nil {
+		t.Fatalf("click: %v", err)
+	}
+	if response := decodeBrowserResult(t, result); response["success"] != false {
+		t.Errorf("expected a failed result for a missing element, got %v", response)
+	}
+}
+
+func TestBrowserTool_ScreenshotSavesEvidence(t *testing.T) {
+	page := &fakePage{url: "http://maestro-coder-001:3000/"}
+	tool := newFakeBrowserTool(t, page)
+
+	if _, err := tool.Exec(context.Background(), map[string]any{"action": "screenshot"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tool.Screenshots()) != 0 {
+		t.Fatal("screenshot without a caption should not be saved")
+	}
+
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "screenshot", "caption": "home page"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response := decodeBrowserResult(t, result); response["screenshot"] != "shot-01.png" {
+		t.Errorf("unexpected response: %v", response)
+	}
+
+	shots := tool.Screenshots()
+	if len(shots) != 1 {
+		t.Fatalf("expected 1 screenshot, got %d", len(shots))
+	}
+	if shots[0].Caption != "home page" || shots[0].URL != page.url || filepath.Base(shots[0].Path) != "shot-01.png" {
+		t.Errorf("unexpected screenshot record: %+v", shots[0])
+	}
+	if _, err := os.Stat(shots[0].Path); err != nil {
+		t.Errorf("screenshot file not written: %v", err)
+	}
+}
+
+func TestBrowserTool_ScreenshotLimit(t *testing.T) {
+	tool := newFakeBrowserTool(t, &fakePage{})
+	for i := 0; i < maxScreenshotsPerRun; i++ {
+		if _, err := tool.Exec(context.Background(), map[string]any{"action": "screenshot", "caption": "step"}); err != nil {
+			t.Fatalf("screenshot %d: %v", i, err)
+		}
+	}
+
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "screenshot", "caption": "one too many"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response := decodeBrowserResult(t, result); response["success"] != false {
+		t.Errorf("expected the limit to refuse the screenshot, got %v", response)
+	}
+	if len(tool.Screenshots()) != maxScreenshotsPerRun {
+		t.Errorf("expected %d screenshots, got %d", maxScreenshotsPerRun, len(tool.Screenshots()))
+	}
+}
+
+func TestBrowserTool_PageUnavailable(t *testing.T) {
+	tool := NewBrowserTool(func(_ context.Context) (BrowserPage, error) {
+		return nil, errors.New("docker not running")
+	}, t.TempDir(), "")
+
+	result, err := tool.Exec(context.Background(), map[string]any{"action": "text"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response := decodeBrowserResult(t, result); response["success"] != false {
+		t.Errorf("expected failure, got %v", response)
+	}
+}
@@ -374,11 +374,14 @@ func (l *LintTool) Exec(ctx context.Context, args map[string]any) (*ExecResult,
 // If no changes exist on the branch at all (Case A), it instead signals
 // STORY_COMPLETE for architect verification.
 type DoneTool struct {
-	agent        Agent            // Optional agent reference for todo checking
-	executor     execpkg.Executor // Optional executor for git commit operations
-	workDir      string           // Workspace directory for git operations
-	storyID      string           // Story ID for commit message prefix
-	targetBranch string           // Target branch for merge-base check (defaults to "main")
+	agent          Agent            // Optional agent reference for todo checking
+	executor       execpkg.Executor // Optional executor for git commit operations
+	workDir        string           // Workspace directory for git operations
+	storyID        string           // Story ID for commit message prefix
+	targetBranch   string           // Target branch for merge-base check (defaults to "main")
+	storyType      string           // Story type for the commit message template
+	isHotfix       bool             // Hotfix flag for the commit message template
+	requirementIDs []string         // Requirement IDs for the commit message template
 }
 
 // NewDoneTool creates a new done tool instance.
@@ -659,11 +662,7 @@ func (d *DoneTool) attemptCommit(ctx context.Context, opts *execpkg.Opts, summar
 		}
 	}
 
-	// Build commit message with story prefix
-	commitMsg := summary
-	if d.storyID != "" {
-		commitMsg = fmt.Sprintf("Story %s: %s", d.storyID, summary)
-	}
+	commitMsg := d.commitMessage(ctx, opts, summary)
 
 	// Commit
 	result, err = d.executor.Run(ctx, []string{"git", "commit", "-m", commitMsg}, opts)
@@ -678,6 +677,25 @@ func (d *DoneTool) attemptCommit(ctx context.Context, opts *execpkg.Opts, summar
 	return commitResult{message: fmt.Sprintf("Changes committed and advancing to TESTING state. Commit: %s", strings.TrimSpace(result.Stdout))}
 }
 
+// commitMessage renders the commit message template (git.commit_template) for
+// the staged changes, falling back to the story-prefixed summary.
+func (d *DoneTool) commitMessage(ctx context.Context, opts *execpkg.Opts, summary string) string {
+	var paths []string
+	if result, err := d.executor.Run(ctx, []string{"git", "diff", "--cached", "--name-only"}, opts); err == nil && result.ExitCode == 0 {
+		paths = strings.Fields(result.Stdout)
+	}
+
+	msg, err := renderCommitMessage(newCommitInfo(summary, d.storyID, d.storyType, d.isHotfix, d.requirementIDs, paths))
+	if err != nil || msg == "" {
+		logx.Warnf("Failed to render commit message template, using default message: %v", err)
+		if d.storyID != "" {
+			return fmt.Sprintf("Story %s: %s", d.storyID, summary)
+		}
+		return summary
+	}
+	return msg
+}
+
 // branchHasCommits checks whether the current branch has any commits beyond the target branch.
 // Returns true if there are prior commits (Case B) or if the check fails (safe fallback).
 // Returns false if the branch has no commits at all (Case A: story already complete).
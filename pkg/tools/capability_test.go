@@ -41,6 +41,7 @@ func TestToolProviderDevOpsPlanningTools(t *testing.T) {
 		ToolChatRead:      false,
 		ToolWebSearch:     false,
 		ToolWebFetch:      false,
+		ToolDocsSearch:    false,
 		ToolReportBlocked: false,
 	}
 
@@ -93,6 +94,7 @@ func TestToolProviderAppPlanningTools(t *testing.T) {
 		ToolChatRead:      false,
 		ToolWebSearch:     false,
 		ToolWebFetch:      false,
+		ToolDocsSearch:    false,
 		ToolReportBlocked: false,
 	}
 
@@ -155,6 +157,7 @@ func TestToolProviderAppCodingTools(t *testing.T) {
 		ToolTodoUpdate:      false,
 		ToolWebSearch:       false,
 		ToolWebFetch:        false,
+		ToolDocsSearch:      false,
 		ToolReportBlocked:   false,
 	}
 
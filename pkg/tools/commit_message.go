@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"orchestrator/pkg/config"
+	"orchestrator/pkg/proto"
+)
+
+// CommitInfo is the data a commit message template (git.commit_template) renders.
+type CommitInfo struct {
+	Type           string   // Conventional Commits type derived from the story and the touched paths
+	Scope          string   // Directory all touched paths share, if any
+	Summary        string   // The coder's summary, as given to the done tool
+	Subject        string   // First line of the summary
+	Body           string   // Rest of the summary
+	StoryID        string   // Story being implemented
+	StoryType      string   // "app" or "devops"
+	RequirementIDs []string // Spec requirements the story covers
+	Paths          []string // Staged paths
+}
+
+// genericRoots are top-level directories too broad to name a commit scope.
+//
+//nolint:gochecknoglobals // Intentional package-level lookup table
+var genericRoots = map[string]bool{"pkg": true, "internal": true, "src": true, "cmd": true, "lib": true, "app": true}
+
+// blankLinesRe matches runs of blank lines left by empty template sections.
+//
+//nolint:gochecknoglobals // Precompiled regex reused across commits
+var blankLinesRe = regexp.MustCompile(`\n{3,}`)
+
+// newCommitInfo derives the template data of a commit.
+func newCommitInfo(summary, storyID, storyType string, isHotfix bool, requirementIDs, paths []string) CommitInfo {
+	subject, body, _ := strings.Cut(strings.TrimSpace(summary), "\n")
+	return CommitInfo{
+		Type:           commitType(storyType, isHotfix, paths),
+		Scope:          commitScope(paths),
+		Summary:        summary,
+		Subject:        strings.TrimSpace(subject),
+		Body:           strings.TrimSpace(body),
+		StoryID:        storyID,
+		StoryType:      storyType,
+		RequirementIDs: requirementIDs,
+		Paths:          paths,
+	}
+}
+
+// commitType picks the Conventional Commits type: docs or test when only
+// documentation or tests changed, otherwise fix for hotfixes, build for
+// devops stories and feat for app stories.
+func commitType(storyType string, isHotfix bool, paths []string) string {
+	switch {
+	case len(paths) > 0 && allPaths(paths, isDocPath):
+		return "docs"
+	case len(paths) > 0 && allPaths(paths, isTestPath):
+		return "test"
+	case isHotfix:
+		return "fix"
+	case storyType == string(proto.StoryTypeDevOps):
+		return "build"
+	default:
+		return "feat"
+	}
+}
+
+// commitScope returns the directory all paths share below any generic root
+// (pkg/auth/login.go → auth), or "" when they share none.
+func commitScope(paths []string) string {
+	scope := ""
+	for _, p := range paths {
+		parts := strings.Split(p, "/")
+		if len(parts) > 1 && genericRoots[parts[0]] {
+			parts = parts[1:]
+		}
+		if len(parts) < 2 {
+			return "" // A file at the root touches no single area
+		}
+		if scope != "" && parts[0] != scope {
+			return ""
+		}
+		scope = parts[0]
+	}
+	return scope
+}
+
+func allPaths(paths []string, match func(string) bool) bool {
+	for _, p := range paths {
+		if !match(p) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDocPath(p string) bool {
+	ext := path.Ext(p)
+	return strings.HasPrefix(p, "docs/") || ext == ".md" || ext == ".rst" || ext == ".txt"
+}
+
+func isTestPath(p string) bool {
+	base := path.Base(p)
+	return strings.HasSuffix(base, "_test.go") || strings.Contains(base, ".test.") || strings.Contains(base, ".spec.") ||
+		strings.HasPrefix(base, "test_") || strings.HasPrefix(p, "test/") || strings.HasPrefix(p, "tests/") ||
+		strings.Contains(p, "/test/") || strings.Contains(p, "/tests/")
+}
+
+// renderCommitMessage renders a commit message with the configured template.
+func renderCommitMessage(info CommitInfo) (string, error) {
+	tmpl, err := config.ParseCommitTemplate(config.GetCommitTemplate())
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, info); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(blankLinesRe.ReplaceAllString(sb.String(), "\n\n")), nil
+}
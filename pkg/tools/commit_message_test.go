@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"testing"
+
+	"orchestrator/pkg/config"
+)
+
+func TestCommitTypeAndScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		storyType string
+		isHotfix  bool
+		paths     []string
+		wantType  string
+		wantScope string
+	}{
+		{"app feature", "app", false, []string{"pkg/auth/login.go", "pkg/auth/session.go"}, "feat", "auth"},
+		{"hotfix", "app", true, []string{"pkg/auth/login.go"}, "fix", "auth"},
+		{"devops", "devops", false, []string{"Dockerfile"}, "build", ""},
+		{"docs only", "app", false, []string{"docs/setup.md", "README.md"}, "docs", ""},
+		{"tests only", "app", true, []string{"pkg/auth/login_test.go"}, "test", "auth"},
+		{"several areas", "app", false, []string{"pkg/auth/login.go", "web/index.html"}, "feat", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commitType(tt.storyType, tt.isHotfix, tt.paths); got != tt.wantType {
+				t.Errorf("commitType() = %q, want %q", got, tt.wantType)
+			}
+			if got := commitScope(tt.paths); got != tt.wantScope {
+				t.Errorf("commitScope() = %q, want %q", got, tt.wantScope)
+			}
+		})
+	}
+}
+
+func TestRenderCommitMessage(t *testing.T) {
+	info := newCommitInfo("Add login form\n\nValidates email and password.", "story-7", "app", false,
+		[]string{"R-001", "R-003"}, []string{"pkg/auth/login.go"})
+
+	t.Run("default", func(t *testing.T) {
+		config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{}})
+		t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+		msg, err := renderCommitMessage(info)
+		if err != nil {
+			t.Fatalf("renderCommitMessage() error: %v", err)
+		}
+		want := "Story story-7: Add login form\n\nValidates email and password."
+		if msg != want {
+			t.Errorf("renderCommitMessage() = %q, want %q", msg, want)
+		}
+	})
+
+	t.Run("conventional", func(t *testing.T) {
+		config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{CommitTemplate: config.CommitTemplateConventional}})
+		t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+		msg, err := renderCommitMessage(info)
+		if err != nil {
+			t.Fatalf("renderCommitMessage() error: %v", err)
+		}
+		want := "feat(auth): Add login form\n\nValidates email and password.\n\nStory: story-7\nRefs: R-001, R-003"
+		if msg != want {
+			t.Errorf("renderCommitMessage() = %q, want %q", msg, want)
+		}
+	})
+
+	t.Run("conventional without body", func(t *testing.T) {
+		config.SetConfigForTesting(&config.Config{Git: &config.GitConfig{CommitTemplate: config.CommitTemplateConventional}})
+		t.Cleanup(func() { config.SetConfigForTesting(nil) })
+
+		msg, err := renderCommitMessage(newCommitInfo("Update docs", "story-8", "app", false, nil, []string{"README.md"}))
+		if err != nil {
+			t.Fatalf("renderCommitMessage() error: %v", err)
+		}
+		if want := "docs: Update docs\n\nStory: story-8"; msg != want {
+			t.Errorf("renderCommitMessage() = %q, want %q", msg, want)
+		}
+	})
+}
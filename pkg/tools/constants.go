@@ -48,6 +48,9 @@ const (
 	// Probing tools.
 	ToolSubmitProbing = "submit_probing"
 
+	// Conflict resolution tools.
+	ToolResolveConflicts = "resolve_conflicts"
+
 	// Architect read tools.
 	ToolReadFile       = "read_file"
 	ToolListFiles      = "list_files"
@@ -57,6 +60,9 @@ const (
 	ToolReviewComplete = "review_complete"
 	ToolStoryEdit      = "story_edit"
 
+	// ToolSubmitAmendment maps an approved spec amendment onto existing stories.
+	ToolSubmitAmendment = "submit_amendment"
+
 	// Architect maintenance tools.
 	ToolAddMaintenanceItem = "add_maintenance_item"
 
@@ -67,8 +73,11 @@ const (
 	ToolMaestroMdSubmit = "maestro_md_submit"
 	ToolIncidentAction  = "incident_action"
 
+	// ToolInterviewQuestionnaire tracks coverage of the team's interview questionnaires.
+	ToolInterviewQuestionnaire = "interview_questionnaire"
+
 	// Research tools.
-	// Note: ToolWebSearch is defined in web_search.go to keep tool name with its implementation.
+	// Note: ToolWebSearch and ToolDocsSearch are defined with their implementations.
 )
 
 // State-specific tool availability - defines which tools are available in each state.
@@ -89,6 +98,7 @@ var (
 		ToolChatRead,
 		ToolWebSearch,
 		ToolWebFetch,
+		ToolDocsSearch,
 	}
 
 	// DevOps planning tools - exploration and plan submission for infrastructure stories.
@@ -105,6 +115,7 @@ var (
 		ToolChatRead,
 		ToolWebSearch,
 		ToolWebFetch,
+		ToolDocsSearch,
 	}
 
 	// DevOpsCodingTools - identical to AppCodingTools.
@@ -132,6 +143,7 @@ var (
 		ToolTodoUpdate,
 		ToolWebSearch,
 		ToolWebFetch,
+		ToolDocsSearch,
 	}
 
 	// App coding tools - full development environment.
@@ -159,6 +171,7 @@ var (
 		ToolTodoUpdate,
 		ToolWebSearch,
 		ToolWebFetch,
+		ToolDocsSearch,
 	}
 
 	// Testing tools - validation and verification.
@@ -200,6 +213,7 @@ var (
 		ToolChatAskUser,
 		ToolBootstrap,
 		ToolSpecSubmit,
+		ToolInterviewQuestionnaire,
 		ToolWebSearch,
 		ToolWebFetch,
 		ToolReleaseHeldStories,
@@ -0,0 +1,425 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"orchestrator/pkg/config"
+	execpkg "orchestrator/pkg/exec"
+)
+
+// ToolDocsSearch is the constant name for the offline documentation search tool.
+const ToolDocsSearch = "docs_search"
+
+const (
+	// docsIndexDir holds the index inside the agent's container. It lives on
+	// the container's /tmp tmpfs, so it is never committed and is rebuilt
+	// after a container switch, when the toolchain it describes may differ.
+	docsIndexDir = "/tmp/maestro-docs"
+
+	// docsIndexMarker is written last by the build script; its absence means
+	// the index is missing or a build was interrupted.
+	docsIndexMarker = docsIndexDir + "/.built"
+
+	docsBuildTimeout   = 10 * time.Minute
+	docsSearchTimeout  = 60 * time.Second
+	docsDefaultResults = 8
+	docsMaxResults     = 25
+	docsMaxGrepLines   = 5000
+	docsSnippetLines   = 3
+	docsSnippetLength  = 300
+)
+
+// docsSources are the index's subdirectories, which the source filter names.
+//
+//nolint:gochecknoglobals // read-only list of index sections
+var docsSources = []string{"go", "npm", "python", "local"}
+
+// docsBuildScript builds the index from what is already installed in the
+// container; it never touches the network, so it works in airplane mode and
+// with NetworkDisabled executors. Each section is best effort: a container
+// without Go simply has no go/ section. The package caps keep the index
+// within the tmpfs and the build within docsBuildTimeout on large trees.
+//
+// $1 is the workspace; the remaining arguments are user doc directories,
+// which are searched in place rather than copied.
+const docsBuildScript = `
+set -u
+IDX=` + docsIndexDir + `
+WS="$1"
+rm -rf "$IDX" && mkdir -p "$IDX/go" "$IDX/npm" "$IDX/python" || exit 1
+
+if command -v go >/dev/null 2>&1 && [ -f "$WS/go.mod" ]; then
+  # Dependencies before the standard library, so the cap never crowds them out.
+  (cd "$WS" && GOPROXY=off GOTOOLCHAIN=local go list -deps -f '{{.Standard}} {{.ImportPath}}' ./... 2>/dev/null) |
+    sort -s -k1,1 | cut -d' ' -f2 |
+    grep -v -e '^internal/' -e '/internal/' -e '^vendor/' | head -n 400 |
+    while read -r pkg; do
+      out="$IDX/go/$(printf '%s' "$pkg" | tr '/' '_').txt"
+      (cd "$WS" && GOPROXY=off GOTOOLCHAIN=local go doc -all "$pkg" >"$out" 2>/dev/null) || rm -f "$out"
+    done
+fi
+
+if [ -d "$WS/node_modules" ]; then
+  find "$WS/node_modules" -mindepth 2 -maxdepth 3 -name package.json -not -path '*/node_modules/*/node_modules/*' 2>/dev/null |
+    head -n 500 | while read -r manifest; do
+      dir=$(dirname "$manifest")
+      name=${dir#"$WS/node_modules/"}
+      dest="$IDX/npm/$(printf '%s' "$name" | tr '/' '_')"
+      mkdir -p "$dest"
+      for f in "$dir"/README*; do [ -f "$f" ] && cp "$f" "$dest/" 2>/dev/null; done
+      find "$dir" -maxdepth 2 -name '*.d.ts' -not -path '*/node_modules/*/node_modules/*' 2>/dev/null | head -n 20 |
+        while read -r dts; do cp "$dts" "$dest/$(basename "$dts")" 2>/dev/null; done
+      rmdir "$dest" 2>/dev/null
+    done
+fi
+
+if command -v python3 >/dev/null 2>&1; then
+  python3 - "$IDX/python" <<'PY' >/dev/null 2>&1
+import importlib, importlib.metadata as md, pydoc, sys
+out, seen = sys.argv[1], set()
+for dist in md.distributions():
+    top = (dist.read_text("top_level.txt") or "").split()
+    for mod in top or [(dist.metadata["Name"] or "").replace("-", "_")]:
+        if not mod or mod.startswith("_") or mod in seen or len(seen) >= 200:
+            continue
+        seen.add(mod)
+        try:
+            text = pydoc.render_doc(importlib.import_module(mod), renderer=pydoc.plaintext)
+        except BaseException:
+            continue
+        with open(f"{out}/{mod}.txt", "w") as f:
+            f.write(text)
+PY
+fi
+
+{
+  echo "go $(ls "$IDX/go" | wc -l)"
+  echo "npm $(ls "$IDX/npm" | wc -l)"
+  echo "python $(ls "$IDX/python" | wc -l)"
+} >"$IDX/MANIFEST"
+shift
+for d in "$@"; do echo "local $d"; done >>"$IDX/MANIFEST"
+touch "$IDX/.built"
+`
+
+// DocsSearchTool searches a local full-text index of the documentation for
+// what is installed in the agent's container: Go packages (go doc), npm
+// package READMEs and typings, Python docstrings (pydoc), and the doc
+// directories listed in search.docs_dirs. It is the offline counterpart to
+// web_search: nothing it does needs the network.
+//
+// The "index" is plain text files searched with grep. That is fast enough
+// for a few hundred packages and needs nothing in the container beyond
+// coreutils, which every image maestro runs already has.
+type DocsSearchTool struct {
+	executor      execpkg.Executor
+	workspaceRoot string
+	docDirs       []string
+}
+
+// NewDocsSearchTool creates a docs_search tool over the container workspace
+// at workspaceRoot. User doc directories come from search.docs_dirs.
+func NewDocsSearchTool(executor execpkg.Executor, workspaceRoot string) *DocsSearchTool {
+	if workspaceRoot == "" {
+		workspaceRoot = DefaultWorkspaceDir
+	}
+	tool := &DocsSearchTool{executor: executor, workspaceRoot: workspaceRoot}
+	if cfg, err := config.GetConfig(); err == nil && cfg.Search != nil {
+		tool.docDirs = cfg.Search.DocsDirs
+	}
+	return tool
+}
+
+// Name returns the tool name.
+func (t *DocsSearchTool) Name() string {
+	return ToolDocsSearch
+}
+
+// PromptDocumentation returns formatted tool documentation for prompts.
+func (t *DocsSearchTool) PromptDocumentation() string {
+	return `- **docs_search** - Search offline documentation for installed dependencies
+  - Parameters: query (string, REQUIRED), source (optional: go, npm, python, local), max_results (optional), rebuild (optional boolean)
+  - Covers Go package docs (go doc), npm READMEs and .d.ts typings, Python docstrings, and project doc directories
+  - Works without network access; prefer it over web_search for API details of dependencies already installed
+  - The first call builds the index, which can take a minute; use rebuild=true after installing new dependencies
+  - Results name a file under ` + docsIndexDir + ` and matching lines; read more of it with shell (e.g. sed -n '120,200p' FILE)`
+}
+
+// Definition returns the tool definition for LLM.
+func (t *DocsSearchTool) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name:        ToolDocsSearch,
+		Description: `Search a local, offline index of documentation for the dependencies installed in this container: Go package docs, npm package READMEs and typings, Python docstrings, and project doc directories. Use it to look up library APIs without network access. The index is built on first use.`,
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"query": {
+					Type:        "string",
+					Description: "Words to search for, e.g. 'http.Client Timeout' or 'useEffect cleanup'. Files matching more of the words rank higher.",
+				},
+				"source": {
+					Type:        "string",
+					Description: "Restrict the search to one documentation set",
+					Enum:        docsSources,
+				},
+				"max_results": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Maximum number of documents to return (default %d, max %d)", docsDefaultResults, docsMaxResults),
+				},
+				"rebuild": {
+					Type:        "boolean",
+					Description: "Rebuild the index first, e.g. after installing dependencies",
+				},
+			},
+			Required: []string{"query"},
+		},
+	}
+}
+
+// docsHit is one document in a docs_search answer.
+type docsHit struct {
+	File    string   `json:"file"`
+	Source  string   `json:"source"`
+	Matches []string `json:"matches"`
+	Score   int      `json:"score"`
+	terms   map[string]struct{}
+	hits    int
+}
+
+// Exec executes the docs_search tool.
+func (t *DocsSearchTool) Exec(ctx context.Context, args map[string]any) (*ExecResult, error) {
+	query, ok := args["query"].(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("query is required and must be a string")
+	}
+	terms := docsQueryTerms(query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("query must contain at least one word of two or more characters")
+	}
+
+	source, _ := args["source"].(string)
+	if source != "" && !containsDocsSource(source) {
+		return t.errorResult(fmt.Sprintf("unknown source %q: use one of %s", source, strings.Join(docsSources, ", ")))
+	}
+	maxResults := intArgOrDefault(args, "max_results", docsDefaultResults)
+	if maxResults > docsMaxResults {
+		maxResults = docsMaxResults
+	}
+	rebuild, _ := args["rebuild"].(bool)
+
+	built, err := t.ensureIndex(ctx, rebuild)
+	if err != nil {
+		return t.errorResult(err.Error())
+	}
+
+	dirs := t.searchDirs(source)
+	if len(dirs) == 0 {
+		return t.errorResult("no documentation directories to search for source \"local\": set search.docs_dirs")
+	}
+	hits, truncated, err := t.search(ctx, terms, dirs)
+	if err != nil {
+		return t.errorResult(err.Error())
+	}
+	if len(hits) > maxResults {
+		hits = hits[:maxResults]
+	}
+
+	response := map[string]any{
+		"success":      true,
+		"query":        query,
+		"result_count": len(hits),
+		"results":      hits,
+	}
+	if built {
+		response["index_built"] = true
+	}
+	if truncated {
+		response["note"] = "Many lines matched; ranking used the first matches only. Add more specific words or a source to narrow the search."
+	} else if len(hits) == 0 {
+		response["note"] = "No documentation matched. Try other words, check the dependency is installed, or use rebuild=true after installing it."
+	}
+
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
+
+// ensureIndex builds the index if it is missing or a rebuild was asked for,
+// reporting whether it built one.
+func (t *DocsSearchTool) ensureIndex(ctx context.Context, rebuild bool) (bool, error) {
+	if !rebuild {
+		result, err := t.executor.Run(ctx, []string{"test", "-f", docsIndexMarker}, &execpkg.Opts{Timeout: docsSearchTimeout})
+		if err == nil && result.ExitCode == 0 {
+			return false, nil
+		}
+	}
+
+	cmd := append([]string{"sh", "-c", docsBuildScript, "docs_search", t.workspaceRoot}, t.localDirs()...)
+	result, err := t.executor.Run(ctx, cmd, &execpkg.Opts{Timeout: docsBuildTimeout})
+	if err != nil {
+		return false, fmt.Errorf("failed to build documentation index: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return false, fmt.Errorf("failed to build documentation index (exit code %d): %s",
+			result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+	return true, nil
+}
+
+// localDirs resolves search.docs_dirs against the container workspace.
+func (t *DocsSearchTool) localDirs() []string {
+	dirs := make([]string, 0, len(t.docDirs))
+	for _, dir := range t.docDirs {
+		if path.IsAbs(dir) {
+			dirs = append(dirs, path.Clean(dir))
+		} else {
+			dirs = append(dirs, path.Join(t.workspaceRoot, dir))
+		}
+	}
+	return dirs
+}
+
+// searchDirs returns the directories a search with the given source covers.
+func (t *DocsSearchTool) searchDirs(source string) []string {
+	switch source {
+	case "":
+		return append([]string{docsIndexDir + "/go", docsIndexDir + "/npm", docsIndexDir + "/python"}, t.localDirs()...)
+	case "local":
+		return t.localDirs()
+	default:
+		return []string{docsIndexDir + "/" + source}
+	}
+}
+
+// search greps dirs for any of the terms and ranks the matching files.
+// grep's output is capped at docsMaxGrepLines; truncated reports that the
+// cap was hit, so the ranking saw only part of the matches.
+func (t *DocsSearchTool) search(ctx context.Context, terms, dirs []string) ([]*docsHit, bool, error) {
+	script := fmt.Sprintf(`grep -r -n -i -I -F "$@" 2>/dev/null | head -n %d`, docsMaxGrepLines+1)
+	cmd := []string{"sh", "-c", script, "docs_search"}
+	for _, term := range terms {
+		cmd = append(cmd, "-e", term)
+	}
+	cmd = append(cmd, "--")
+	cmd = append(cmd, dirs...)
+
+	result, err := t.executor.Run(ctx, cmd, &execpkg.Opts{Timeout: docsSearchTimeout})
+	if err != nil {
+		return nil, false, fmt.Errorf("documentation search failed: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(result.Stdout, "\n"), "\n")
+	truncated := len(lines) > docsMaxGrepLines
+	if truncated {
+		lines = lines[:docsMaxGrepLines]
+	}
+	return rankDocsHits(lines, terms), truncated, nil
+}
+
+// rankDocsHits turns grep -n output into documents ranked by how many of the
+// terms they contain, then by how many lines matched. A term in the file
+// name counts as matched: a query naming a package should find its docs.
+func rankDocsHits(lines, terms []string) []*docsHit {
+	byFile := make(map[string]*docsHit)
+	var order []*docsHit
+	for _, line := range lines {
+		file, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lineNo, text, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		hit := byFile[file]
+		if hit == nil {
+			hit = &docsHit{File: file, Source: docsSourceOf(file), terms: make(map[string]struct{})}
+			lowerName := strings.ToLower(path.Base(file))
+			for _, term := range terms {
+				if strings.Contains(lowerName, term) {
+					hit.terms[term] = struct{}{}
+				}
+			}
+			byFile[file] = hit
+			order = append(order, hit)
+		}
+		hit.hits++
+		lowerText := strings.ToLower(text)
+		for _, term := range terms {
+			if strings.Contains(lowerText, term) {
+				hit.terms[term] = struct{}{}
+			}
+		}
+		if len(hit.Matches) < docsSnippetLines {
+			text = strings.TrimSpace(text)
+			if len(text) > docsSnippetLength {
+				text = text[:docsSnippetLength] + "…"
+			}
+			hit.Matches = append(hit.Matches, lineNo+": "+text)
+		}
+	}
+
+	for _, hit := range order {
+		hit.Score = len(hit.terms)*100 + min(hit.hits, 99)
+	}
+	sort.SliceStable(order, func(i, j int) bool { return order[i].Score > order[j].Score })
+	return order
+}
+
+// docsQueryTerms lower-cases the query's words, dropping one-letter words
+// and duplicates, which match nearly every line.
+func docsQueryTerms(query string) []string {
+	seen := make(map[string]struct{})
+	var terms []string
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if len(word) < 2 {
+			continue
+		}
+		if _, dup := seen[word]; dup {
+			continue
+		}
+		seen[word] = struct{}{}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// docsSourceOf names the documentation set a file came from.
+func docsSourceOf(file string) string {
+	for _, source := range docsSources[:3] {
+		if strings.HasPrefix(file, docsIndexDir+"/"+source+"/") {
+			return source
+		}
+	}
+	return "local"
+}
+
+func containsDocsSource(source string) bool {
+	for _, s := range docsSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// errorResult creates a JSON error response.
+func (t *DocsSearchTool) errorResult(msg string) (*ExecResult, error) {
+	response := map[string]any{
+		"success": false,
+		"error":   msg,
+	}
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal error response: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	execpkg "orchestrator/pkg/exec"
+)
+
+// docsExecutor plays the container for docs_search: it answers the index
+// check, the build and the grep, and records each command.
+type docsExecutor struct {
+	grepOutput string
+	commands   [][]string
+	indexBuilt bool
+}
+
+func (e *docsExecutor) Run(_ context.Context, cmd []string, _ *execpkg.Opts) (execpkg.Result, error) {
+	e.commands = append(e.commands, cmd)
+	switch {
+	case cmd[0] == "test":
+		if e.indexBuilt {
+			return execpkg.Result{ExitCode: 0}, nil
+		}
+		return execpkg.Result{ExitCode: 1}, nil
+	case cmd[2] == docsBuildScript:
+		e.indexBuilt = true
+		return execpkg.Result{ExitCode: 0}, nil
+	default:
+		return execpkg.Result{ExitCode: 0, Stdout: e.grepOutput}, nil
+	}
+}
+
+func (e *docsExecutor) Name() execpkg.ExecutorType { return "docs-test" }
+
+func (e *docsExecutor) Available() bool { return true }
+
+func (e *docsExecutor) builds() int {
+	n := 0
+	for _, cmd := range e.commands {
+		if len(cmd) > 2 && cmd[2] == docsBuildScript {
+			n++
+		}
+	}
+	return n
+}
+
+func execDocsSearch(t *testing.T, tool *DocsSearchTool, args map[string]any) map[string]any {
+	t.Helper()
+	result, err := tool.Exec(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	var response map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestDocsSearchTool_BuildsIndexOnce(t *testing.T) {
+	executor := &docsExecutor{grepOutput: docsIndexDir + "/go/net_http.txt:12:func (c *Client) Do(req *Request)\n"}
+	tool := NewDocsSearchTool(executor, "")
+	tool.docDirs = []string{"docs", "/usr/share/doc/lib"}
+
+	first := execDocsSearch(t, tool, map[string]any{"query": "client do"})
+	if first["success"] != true || first["index_built"] != true {
+		t.Fatalf("first search = %v, want success with index_built", first)
+	}
+	second := execDocsSearch(t, tool, map[string]any{"query": "client do"})
+	if second["index_built"] != nil {
+		t.Errorf("second search rebuilt the index: %v", second)
+	}
+	execDocsSearch(t, tool, map[string]any{"query": "client do", "rebuild": true})
+	if executor.builds() != 2 {
+		t.Errorf("index built %d times, want 2 (first use and rebuild)", executor.builds())
+	}
+
+	build := executor.commands[1]
+	want := []string{DefaultWorkspaceDir, DefaultWorkspaceDir + "/docs", "/usr/share/doc/lib"}
+	if got := build[4:]; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("build arguments = %v, want %v", got, want)
+	}
+}
+
+func TestDocsSearchTool_SourceFilter(t *testing.T) {
+	executor := &docsExecutor{indexBuilt: true}
+	tool := NewDocsSearchTool(executor, "")
+
+	execDocsSearch(t, tool, map[string]any{"query": "fetch", "source": "npm"})
+	grep := executor.commands[len(executor.commands)-1]
+	if dirs := grep[len(grep)-1]; dirs != docsIndexDir+"/npm" {
+		t.Errorf("searched %q, want only the npm section", dirs)
+	}
+
+	if response := execDocsSearch(t, tool, map[string]any{"query": "fetch", "source": "local"}); response["success"] != false {
+		t.Errorf("local search without docs_dirs succeeded: %v", response)
+	}
+	if response := execDocsSearch(t, tool, map[string]any{"query": "fetch", "source": "rust"}); response["success"] != false {
+		t.Errorf("unknown source accepted: %v", response)
+	}
+}
+
+func TestDocsSearchTool_MissingQuery(t *testing.T) {
+	tool := NewDocsSearchTool(&docsExecutor{}, "")
+	for _, args := range []map[string]any{{}, {"query": "  "}, {"query": "a"}} {
+		if _, err := tool.Exec(context.Background(), args); err == nil {
+			t.Errorf("Exec(%v) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestRankDocsHits(t *testing.T) {
+	lines := []string{
+		docsIndexDir + "/go/net_http.txt:10:The Client type handles redirects.",
+		docsIndexDir + "/go/net_http.txt:40:Timeout specifies a time limit for requests made by this Client.",
+		docsIndexDir + "/npm/axios/README.md:5:Set a timeout on the request.",
+		docsIndexDir + "/npm/axios/README.md:9:timeout: 1000",
+		docsIndexDir + "/npm/axios/README.md:11:timeout: 2000",
+		"/workspace/docs/http.md:3:Our client wrapper.",
+	}
+	hits := rankDocsHits(lines, []string{"client", "timeout", "http"})
+
+	if len(hits) != 3 {
+		t.Fatalf("got %d hits, want 3", len(hits))
+	}
+	// net_http.txt matches all three terms (http via its file name).
+	if hits[0].File != docsIndexDir+"/go/net_http.txt" || hits[0].Source != "go" {
+		t.Errorf("top hit = %+v, want the net/http docs", hits[0])
+	}
+	if len(hits[0].Matches) != 2 || !strings.HasPrefix(hits[0].Matches[1], "40: Timeout") {
+		t.Errorf("matches = %v", hits[0].Matches)
+	}
+	// docs/http.md matches two terms with one line; axios matches one with three.
+	if hits[1].Source != "local" || hits[2].Source != "npm" {
+		t.Errorf("ranking = %s, %s; want local before npm", hits[1].File, hits[2].File)
+	}
+}
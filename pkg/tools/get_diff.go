@@ -11,6 +11,10 @@ import (
 	"orchestrator/pkg/utils"
 )
 
+// DiffBaseConfigKey is the git config key under which a coder clone records the
+// branch its story branch started from, when that is not the target branch.
+const DiffBaseConfigKey = "maestro.baseBranch"
+
 // GetDiffTool allows getting git diff from coder workspaces.
 type GetDiffTool struct {
 	executor      execpkg.Executor
@@ -44,7 +48,7 @@ func (t *GetDiffTool) PromptDocumentation() string {
 	return `- **get_diff** - Get git diff showing changes on the current branch
   - Parameters:
     - path (string, optional): specific file path to diff
-    - base (string, optional): base ref to diff against (default: merge-base with origin/main)
+    - base (string, optional): base ref to diff against (default: merge-base with origin/main, or with the story's epic branch)
   - By default shows only changes made on this branch (excludes changes made on main by others)
   - Use base="origin/main" for direct comparison against current main
   - Returns head_sha, base_sha, baseline_ref, and diff_mode for traceability`
@@ -97,9 +101,10 @@ func (t *GetDiffTool) Exec(ctx context.Context, args map[string]any) (*ExecResul
 	var baseSHA, baselineRef, diffMode string
 
 	if base == "" {
-		// Default: merge-base with origin/main
-		baseSHA = t.resolveMergeBase(ctx)
-		baselineRef = "merge-base(origin/main, HEAD)"
+		// Default: merge-base with origin/main (or the story's epic branch)
+		baseRef := t.defaultBaseRef(ctx)
+		baseSHA = t.resolveMergeBase(ctx, baseRef)
+		baselineRef = fmt.Sprintf("merge-base(%s, HEAD)", baseRef)
 		diffMode = "merge_base"
 	} else {
 		baseSHA = t.resolveRef(ctx, base)
@@ -178,10 +183,21 @@ func (t *GetDiffTool) resolveRef(ctx context.Context, ref string) string {
 	return strings.TrimSpace(result.Stdout)
 }
 
-// resolveMergeBase resolves the merge-base of origin/main and HEAD.
+// defaultBaseRef returns the remote branch the workspace's story branch started
+// from: the branch recorded under DiffBaseConfigKey (an epic branch), else origin/main.
+func (t *GetDiffTool) defaultBaseRef(ctx context.Context) string {
+	cmd := []string{"git", "-C", t.workspaceRoot, "config", "--get", DiffBaseConfigKey}
+	result, err := t.executor.Run(ctx, cmd, nil)
+	if err != nil || result.ExitCode != 0 || strings.TrimSpace(result.Stdout) == "" {
+		return "origin/main"
+	}
+	return "origin/" + strings.TrimSpace(result.Stdout)
+}
+
+// resolveMergeBase resolves the merge-base of baseRef and HEAD.
 // Returns empty string on failure.
-func (t *GetDiffTool) resolveMergeBase(ctx context.Context) string {
-	cmd := []string{"git", "-C", t.workspaceRoot, "merge-base", "origin/main", "HEAD"}
+func (t *GetDiffTool) resolveMergeBase(ctx context.Context, baseRef string) string {
+	cmd := []string{"git", "-C", t.workspaceRoot, "merge-base", baseRef, "HEAD"}
 	result, err := t.executor.Run(ctx, cmd, nil)
 	if err != nil || result.ExitCode != 0 {
 		return ""
@@ -0,0 +1,519 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	execpkg "orchestrator/pkg/exec"
+)
+
+// ToolHTTPRequest is the constant name for the HTTP API probing tool.
+const ToolHTTPRequest = "http_request"
+
+const (
+	httpDefaultTimeout = 15 * time.Second
+	httpMaxTimeout     = 60 * time.Second
+
+	// httpMaxBodyShown caps the response body returned to the model;
+	// httpMaxBodyRecorded caps what each exchange keeps as evidence.
+	httpMaxBodyShown    = 8000
+	httpMaxBodyRecorded = 2000
+
+	// maxHTTPExchangesPerRun bounds the exchanges one run records, as
+	// maxScreenshotsPerRun does for the browser.
+	maxHTTPExchangesPerRun = 30
+
+	// httpStatusMarker separates the response from curl's write-out, which
+	// reports the final status even when the body has no trailing newline.
+	httpStatusMarker = "\n__MAESTRO_HTTP_STATUS__"
+)
+
+// httpMethods are the methods the tool sends. CONNECT and TRACE are left
+// out: neither has a place in testing an API contract.
+//
+//nolint:gochecknoglobals // read-only list of allowed methods
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+// HTTPAssertion is one expectation checked against a response.
+type HTTPAssertion struct {
+	Check    string `json:"check"` // "status", "body_contains" or "json:<path>"
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+}
+
+// HTTPExchange is a request/response pair recorded as evidence.
+//
+//nolint:govet // fieldalignment: Logical grouping preferred for readability
+type HTTPExchange struct {
+	Method          string          `json:"method"`
+	URL             string          `json:"url"`
+	RequestHeaders  []string        `json:"request_headers,omitempty"`
+	RequestBody     string          `json:"request_body,omitempty"`
+	Status          int             `json:"status"`
+	ResponseHeaders []string        `json:"response_headers,omitempty"`
+	ResponseBody    string          `json:"response_body,omitempty"`
+	DurationMS      int64           `json:"duration_ms"`
+	Error           string          `json:"error,omitempty"` // transport failure; Status is 0
+	Assertions      []HTTPAssertion `json:"assertions,omitempty"`
+}
+
+// HTTPRequestTool sends HTTP requests to the services on the story's compose
+// network and checks the responses against expectations, so API criteria
+// are exercised rather than read off the code.
+//
+// Requests are sent with curl from inside the agent's dev container, which
+// compose_up attaches to the compose network: they see the services exactly
+// as the application does. The target host must be one hosts returns (the
+// compose services and containers on the network) or the dev container
+// itself; anything else, the internet included, is refused. Redirects are
+// not followed, since a redirect could lead off the network.
+//
+// Every exchange is kept in the tool for the caller to attach to the run's
+// evidence.
+type HTTPRequestTool struct {
+	executor  execpkg.Executor
+	hosts     func(ctx context.Context) ([]string, error)
+	exchanges []HTTPExchange
+	mu        sync.Mutex
+}
+
+// NewHTTPRequestTool creates an http_request tool that runs curl through
+// executor. hosts lists the hostnames requests may go to besides localhost.
+func NewHTTPRequestTool(executor execpkg.Executor, hosts func(ctx context.Context) ([]string, error)) *HTTPRequestTool {
+	return &HTTPRequestTool{executor: executor, hosts: hosts}
+}
+
+// Name returns the tool name.
+func (t *HTTPRequestTool) Name() string {
+	return ToolHTTPRequest
+}
+
+// Exchanges returns the exchanges recorded so far.
+func (t *HTTPRequestTool) Exchanges() []HTTPExchange {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]HTTPExchange(nil), t.exchanges...)
+}
+
+// PromptDocumentation returns formatted tool documentation for prompts.
+func (t *HTTPRequestTool) PromptDocumentation() string {
+	return `- **http_request** - Send an HTTP request to a service on the story's compose network and check the response
+  - Parameters: url (REQUIRED), method (default GET), headers (["Name: value"]), body (string), timeout_seconds
+  - Expectations (optional): expect_status, expect_body_contains, expect_json ([{path: "data.items[0].id", equals: "42"}] or {path, exists: false})
+  - Hosts: compose service names and localhost (the dev container). Nothing else is reachable; redirects are not followed
+  - Every request and response is recorded as evidence; cite them in your findings`
+}
+
+// Definition returns the tool definition for LLM.
+func (t *HTTPRequestTool) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name: ToolHTTPRequest,
+		Description: `Send an HTTP request to the running application or another service on the story's compose network, and check the response against expectations (status, body text, JSON fields). ` +
+			`Use it to exercise API contracts. Only compose service hostnames and localhost (your dev container) are allowed. Requests and responses are recorded as evidence.`,
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"url": {
+					Type:        "string",
+					Description: "Absolute http(s) URL, e.g. http://api:8080/v1/items or http://localhost:3000/health",
+				},
+				"method": {
+					Type:        "string",
+					Description: "HTTP method (default GET)",
+					Enum:        httpMethods,
+				},
+				"headers": {
+					Type:        "array",
+					Description: `Request headers as "Name: value" strings`,
+					Items:       &Property{Type: "string"},
+				},
+				"body": {
+					Type:        "string",
+					Description: "Request body, sent as is. Set a Content-Type header to match",
+				},
+				"timeout_seconds": {
+					Type:        "integer",
+					Description: fmt.Sprintf("Request timeout (default %d, max %d)", int(httpDefaultTimeout.Seconds()), int(httpMaxTimeout.Seconds())),
+				},
+				"expect_status": {
+					Type:        "integer",
+					Description: "Expected status code",
+				},
+				"expect_body_contains": {
+					Type:        "string",
+					Description: "Text the response body must contain",
+				},
+				"expect_json": {
+					Type:        "array",
+					Description: "Expectations on fields of a JSON response body",
+					Items: &Property{
+						Type: "object",
+						Properties: map[string]*Property{
+							"path": {
+								Type:        "string",
+								Description: `Dot path into the body, e.g. "data.items[0].id"; "" or "$" is the whole body`,
+							},
+							"equals": {
+								Type:        "string",
+								Description: `Expected value: a string's text, or JSON for other types ("42", "true", "null", "[]")`,
+							},
+							"exists": {
+								Type:        "boolean",
+								Description: "Whether the field must be present (default true); use false to assert absence",
+							},
+						},
+						Required: []string{"path"},
+					},
+				},
+			},
+			Required: []string{"url"},
+		},
+	}
+}
+
+// httpRequestSpec is a validated request.
+type httpRequestSpec struct {
+	method  string
+	target  string
+	body    string
+	headers []string
+	timeout time.Duration
+}
+
+// Exec executes the http_request tool.
+func (t *HTTPRequestTool) Exec(ctx context.Context, args map[string]any) (*ExecResult, error) {
+	spec, err := parseHTTPRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.checkHost(ctx, spec.target); err != nil {
+		return t.errorResult(err.Error())
+	}
+
+	t.mu.Lock()
+	recorded := len(t.exchanges)
+	t.mu.Unlock()
+	if recorded >= maxHTTPExchangesPerRun {
+		return t.errorResult(fmt.Sprintf("request limit reached (%d); submit with the evidence you have", maxHTTPExchangesPerRun))
+	}
+
+	exchange := t.send(ctx, spec)
+	fullBody := exchange.ResponseBody
+	if exchange.Error == "" {
+		exchange.Assertions = checkHTTPExpectations(args, exchange.Status, fullBody)
+		exchange.ResponseBody = truncateHTTPBody(fullBody, httpMaxBodyRecorded)
+	}
+	t.mu.Lock()
+	t.exchanges = append(t.exchanges, exchange)
+	t.mu.Unlock()
+
+	if exchange.Error != "" {
+		return t.errorResult(exchange.Error)
+	}
+
+	response := map[string]any{
+		"success":          true,
+		"exchange":         recorded + 1,
+		"status":           exchange.Status,
+		"response_headers": exchange.ResponseHeaders,
+		"body":             truncateHTTPBody(fullBody, httpMaxBodyShown),
+		"duration_ms":      exchange.DurationMS,
+	}
+	if len(exchange.Assertions) > 0 {
+		passed := true
+		for i := range exchange.Assertions {
+			passed = passed && exchange.Assertions[i].Passed
+		}
+		response["assertions"] = exchange.Assertions
+		response["expectations_met"] = passed
+	}
+
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
+
+// parseHTTPRequestArgs validates the request half of the arguments.
+func parseHTTPRequestArgs(args map[string]any) (*httpRequestSpec, error) {
+	raw, ok := args["url"].(string)
+	if !ok || raw == "" {
+		return nil, fmt.Errorf("url is required and must be a string")
+	}
+	target, err := url.Parse(raw)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http(s) URL, got %q", raw)
+	}
+	if target.User != nil {
+		return nil, fmt.Errorf("url must not carry credentials; send an Authorization header instead")
+	}
+
+	spec := &httpRequestSpec{method: "GET", target: target.String(), timeout: httpDefaultTimeout}
+	if method, ok := args["method"].(string); ok && method != "" {
+		spec.method = strings.ToUpper(method)
+		if !slices.Contains(httpMethods, spec.method) {
+			return nil, fmt.Errorf("method must be one of %s", strings.Join(httpMethods, ", "))
+		}
+	}
+	if headers, ok := args["headers"].([]any); ok {
+		for _, h := range headers {
+			header, ok := h.(string)
+			if !ok || !strings.Contains(header, ":") || strings.ContainsAny(header, "\r\n") {
+				return nil, fmt.Errorf(`headers must be "Name: value" strings`)
+			}
+			spec.headers = append(spec.headers, header)
+		}
+	}
+	spec.body, _ = args["body"].(string)
+	if seconds := intArgOrDefault(args, "timeout_seconds", 0); seconds > 0 {
+		spec.timeout = min(time.Duration(seconds)*time.Second, httpMaxTimeout)
+	}
+	return spec, nil
+}
+
+// checkHost refuses targets that are not on the compose network.
+func (t *HTTPRequestTool) checkHost(ctx context.Context, target string) error {
+	parsed, err := url.Parse(target)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "localhost" || host == "127.0.0.1" || host == "::1" {
+		return nil
+	}
+
+	var allowed []string
+	if t.hosts != nil {
+		if allowed, err = t.hosts(ctx); err != nil {
+			return fmt.Errorf("failed to list compose services: %w", err)
+		}
+	}
+	for _, name := range allowed {
+		if strings.EqualFold(name, host) {
+			return nil
+		}
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("host %q is not reachable: only localhost is, as no compose services are running", host)
+	}
+	return fmt.Errorf("host %q is not on the story's compose network; use one of: localhost, %s", host, strings.Join(allowed, ", "))
+}
+
+// send runs the request with curl in the dev container and parses the response.
+func (t *HTTPRequestTool) send(ctx context.Context, spec *httpRequestSpec) HTTPExchange {
+	exchange := HTTPExchange{
+		Method:         spec.method,
+		URL:            spec.target,
+		RequestHeaders: spec.headers,
+		RequestBody:    truncateHTTPBody(spec.body, httpMaxBodyRecorded),
+	}
+
+	// --noproxy keeps a proxy configured in the container from carrying the
+	// request off the network; without -L, redirects are returned as is.
+	cmd := []string{
+		"curl", "-sS", "--noproxy", "*", "--max-time", strconv.Itoa(int(spec.timeout.Seconds())),
+		"-X", spec.method, "-D", "-", "-w", httpStatusMarker + "%{http_code}",
+	}
+	if spec.method == "HEAD" {
+		cmd = append(cmd, "-I")
+	}
+	for _, header := range spec.headers {
+		cmd = append(cmd, "-H", header)
+	}
+	if spec.body != "" {
+		cmd = append(cmd, "--data-binary", spec.body)
+	}
+	cmd = append(cmd, "--", spec.target)
+
+	start := time.Now()
+	result, err := t.executor.Run(ctx, cmd, &execpkg.Opts{Timeout: spec.timeout + 5*time.Second})
+	exchange.DurationMS = time.Since(start).Milliseconds()
+	switch {
+	case err != nil:
+		exchange.Error = fmt.Sprintf("request failed: %v", err)
+	case result.ExitCode == 127:
+		exchange.Error = "curl is not installed in the dev container; add it to the Dockerfile to use http_request"
+	case result.ExitCode != 0:
+		exchange.Error = fmt.Sprintf("request failed (curl exit code %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	default:
+		exchange.Status, exchange.ResponseHeaders, exchange.ResponseBody = parseCurlResponse(result.Stdout)
+	}
+	return exchange
+}
+
+// parseCurlResponse splits curl's -D - output into the final response's
+// status, headers and body. Interim 1xx responses are skipped.
+func parseCurlResponse(output string) (int, []string, string) {
+	status := 0
+	if i := strings.LastIndex(output, httpStatusMarker); i >= 0 {
+		status, _ = strconv.Atoi(strings.TrimSpace(output[i+len(httpStatusMarker):]))
+		output = output[:i]
+	}
+
+	var headers []string
+	for strings.HasPrefix(output, "HTTP/") {
+		end := strings.Index(output, "\r\n\r\n")
+		sep := 4
+		if end < 0 {
+			if end = strings.Index(output, "\n\n"); end < 0 {
+				end, sep = len(output), 0
+			} else {
+				sep = 2
+			}
+		}
+		block := strings.Split(strings.ReplaceAll(output[:end], "\r\n", "\n"), "\n")
+		output = output[end+sep:]
+		fields := strings.Fields(block[0])
+		if len(fields) >= 2 && strings.HasPrefix(fields[1], "1") && strings.HasPrefix(output, "HTTP/") {
+			continue
+		}
+		headers = block[1:]
+	}
+	return status, headers, output
+}
+
+// checkHTTPExpectations evaluates the expect_* arguments against a response.
+func checkHTTPExpectations(args map[string]any, status int, body string) []HTTPAssertion {
+	var assertions []HTTPAssertion
+	if _, ok := args["expect_status"]; ok {
+		want := intArgOrDefault(args, "expect_status", 0)
+		assertions = append(assertions, HTTPAssertion{
+			Check: "status", Expected: strconv.Itoa(want), Actual: strconv.Itoa(status), Passed: status == want,
+		})
+	}
+	if want, ok := args["expect_body_contains"].(string); ok && want != "" {
+		assertions = append(assertions, HTTPAssertion{
+			Check: "body_contains", Expected: want, Actual: truncateHTTPBody(body, 200), Passed: strings.Contains(body, want),
+		})
+	}
+
+	expectations, _ := args["expect_json"].([]any)
+	if len(expectations) == 0 {
+		return assertions
+	}
+	var doc any
+	decodeErr := json.Unmarshal([]byte(body), &doc)
+	for _, item := range expectations {
+		exp, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		path, _ := exp["path"].(string)
+		assertion := HTTPAssertion{Check: "json:" + path}
+		if decodeErr != nil {
+			assertion.Actual = "response body is not JSON"
+			assertions = append(assertions, assertion)
+			continue
+		}
+
+		value, found := lookupJSONPath(doc, path)
+		mustExist := true
+		if exists, ok := exp["exists"].(bool); ok {
+			mustExist = exists
+		}
+		switch want, hasEquals := exp["equals"].(string); {
+		case !mustExist:
+			assertion.Expected, assertion.Passed = "absent", !found
+			assertion.Actual = describeJSONValue(value, found)
+		case hasEquals:
+			assertion.Expected = want
+			assertion.Actual = describeJSONValue(value, found)
+			assertion.Passed = found && jsonValueEquals(value, want)
+		default:
+			assertion.Expected, assertion.Passed = "present", found
+			assertion.Actual = describeJSONValue(value, found)
+		}
+		assertions = append(assertions, assertion)
+	}
+	return assertions
+}
+
+// lookupJSONPath resolves a dot path with [n] indexes ("data.items[0].id")
+// in a decoded JSON document. "" and "$" name the whole document.
+func lookupJSONPath(doc any, path string) (any, bool) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return doc, true
+	}
+
+	current := doc
+	for _, segment := range strings.Split(path, ".") {
+		name, indexes, _ := strings.Cut(segment, "[")
+		if name != "" {
+			obj, ok := current.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if current, ok = obj[name]; !ok {
+				return nil, false
+			}
+		}
+		if indexes == "" {
+			continue
+		}
+		for _, part := range strings.Split(strings.TrimSuffix(indexes, "]"), "][") {
+			i, err := strconv.Atoi(part)
+			arr, ok := current.([]any)
+			if err != nil || !ok || i < 0 || i >= len(arr) {
+				return nil, false
+			}
+			current = arr[i]
+		}
+	}
+	return current, true
+}
+
+// jsonValueEquals compares a decoded value with the expected text: a
+// string's own text, or the JSON encoding of anything else.
+func jsonValueEquals(value any, want string) bool {
+	if s, ok := value.(string); ok && s == want {
+		return true
+	}
+	var wantValue any
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		return false
+	}
+	got, _ := json.Marshal(value)      //nolint:errchkjson // decoded JSON always re-encodes
+	norm, _ := json.Marshal(wantValue) //nolint:errchkjson // decoded JSON always re-encodes
+	return string(got) == string(norm)
+}
+
+func describeJSONValue(value any, found bool) string {
+	if !found {
+		return "absent"
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	encoded, _ := json.Marshal(value) //nolint:errchkjson // decoded JSON always re-encodes
+	return truncateHTTPBody(string(encoded), 200)
+}
+
+func truncateHTTPBody(body string, limit int) string {
+	if len(body) <= limit {
+		return body
+	}
+	return body[:limit] + fmt.Sprintf("... [truncated, %d bytes total]", len(body))
+}
+
+// errorResult creates a JSON error response.
+func (t *HTTPRequestTool) errorResult(msg string) (*ExecResult, error) {
+	response := map[string]any{
+		"success": false,
+		"error":   msg,
+	}
+	content, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal error response: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
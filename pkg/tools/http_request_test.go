@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	execpkg "orchestrator/pkg/exec"
+)
+
+// curlExecutor plays curl in the dev container: it records the command and
+// prints a canned response the way curl -D - -w does.
+type curlExecutor struct {
+	stdout   string
+	commands [][]string
+	exitCode int
+}
+
+func (e *curlExecutor) Run(_ context.Context, cmd []string, _ *execpkg.Opts) (execpkg.Result, error) {
+	e.commands = append(e.commands, cmd)
+	return execpkg.Result{ExitCode: e.exitCode, Stdout: e.stdout, Stderr: "curl: (7) Failed to connect"}, nil
+}
+
+func (e *curlExecutor) Name() execpkg.ExecutorType { return "curl-test" }
+
+func (e *curlExecutor) Available() bool { return true }
+
+func curlOutput(status, headers, body string) string {
+	return "HTTP/1.1 " + status + "\r\n" + headers + "\r\n" + body + httpStatusMarker + status[:3]
+}
+
+func newTestHTTPTool(executor *curlExecutor) *HTTPRequestTool {
+	return NewHTTPRequestTool(executor, func(_ context.Context) ([]string, error) {
+		return []string{"api", "db"}, nil
+	})
+}
+
+func execHTTPRequest(t *testing.T, tool *HTTPRequestTool, args map[string]any) map[string]any {
+	t.Helper()
+	result, err := tool.Exec(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Exec() error = %v", err)
+	}
+	var response map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestHTTPRequest_ExpectationsRecorded(t *testing.T) {
+	executor := &curlExecutor{stdout: curlOutput("201 Created", "Content-Type: application/json\r\n", `{"data":{"items":[{"id":42,"name":"x"}]}}`)}
+	tool := newTestHTTPTool(executor)
+
+	response := execHTTPRequest(t, tool, map[string]any{
+		"url":           "http://api:8080/v1/items",
+		"method":        "post",
+		"headers":       []any{"Content-Type: application/json"},
+		"body":          `{"name":"x"}`,
+		"expect_status": float64(201),
+		"expect_json": []any{
+			map[string]any{"path": "data.items[0].id", "equals": "42"},
+			map[string]any{"path": "data.items[0].name", "equals": "x"},
+			map[string]any{"path": "data.error", "exists": false},
+		},
+	})
+	if response["success"] != true || response["status"] != float64(201) {
+		t.Fatalf("unexpected response: %v", response)
+	}
+	if response["expectations_met"] != true {
+		t.Errorf("expected all expectations met: %v", response["assertions"])
+	}
+
+	cmd := executor.commands[0]
+	if cmd[0] != "curl" || !slices.Contains(cmd, "POST") || !slices.Contains(cmd, `{"name":"x"}`) || cmd[len(cmd)-1] != "http://api:8080/v1/items" {
+		t.Errorf("unexpected curl command: %v", cmd)
+	}
+	if slices.Contains(cmd, "-L") {
+		t.Error("redirects must not be followed")
+	}
+
+	exchanges := tool.Exchanges()
+	if len(exchanges) != 1 || len(exchanges[0].Assertions) != 4 || exchanges[0].ResponseHeaders[0] != "Content-Type: application/json" {
+		t.Errorf("unexpected exchange record: %+v", exchanges)
+	}
+}
+
+func TestHTTPRequest_FailedExpectation(t *testing.T) {
+	tool := newTestHTTPTool(&curlExecutor{stdout: curlOutput("500 Internal Server Error", "", "boom")})
+
+	response := execHTTPRequest(t, tool, map[string]any{"url": "http://localhost:3000/", "expect_status": float64(200)})
+	if response["expectations_met"] != false {
+		t.Errorf("expected a failed expectation, got %v", response)
+	}
+}
+
+func TestHTTPRequest_RefusesHostsOffTheNetwork(t *testing.T) {
+	executor := &curlExecutor{}
+	tool := newTestHTTPTool(executor)
+
+	for _, target := range []string{"https://example.com/", "http://169.254.169.254/latest/meta-data"} {
+		if response := execHTTPRequest(t, tool, map[string]any{"url": target}); response["success"] != false {
+			t.Errorf("%s: expected refusal, got %v", target, response)
+		}
+	}
+	if len(executor.commands) != 0 {
+		t.Errorf("no request should have been sent, got %v", executor.commands)
+	}
+	if _, err := tool.Exec(context.Background(), map[string]any{"url": "file:///etc/passwd"}); err == nil {
+		t.Error("expected an error for a non-http URL")
+	}
+}
+
+func TestHTTPRequest_TransportFailureRecorded(t *testing.T) {
+	tool := newTestHTTPTool(&curlExecutor{exitCode: 7})
+
+	response := execHTTPRequest(t, tool, map[string]any{"url": "http://api:8080/"})
+	if response["success"] != false {
+		t.Errorf("expected failure, got %v", response)
+	}
+	if exchanges := tool.Exchanges(); len(exchanges) != 1 || exchanges[0].Error == "" {
+		t.Errorf("expected the failed exchange to be recorded, got %+v", exchanges)
+	}
+}
+
+func TestParseCurlResponse_SkipsInterimResponses(t *testing.T) {
+	output := "HTTP/1.1 100 Continue\r\n\r\nHTTP/1.1 200 OK\r\nX-A: 1\r\n\r\nhello" + httpStatusMarker + "200"
+	status, headers, body := parseCurlResponse(output)
+	if status != 200 || body != "hello" || len(headers) != 1 || headers[0] != "X-A: 1" {
+		t.Errorf("got status=%d headers=%v body=%q", status, headers, body)
+	}
+}
+
+func TestLookupJSONPath(t *testing.T) {
+	var doc any
+	_ = json.Unmarshal([]byte(`{"a":{"b":[10,{"c":null}]},"top":[[1,2]]}`), &doc)
+
+	tests := []struct {
+		path  string
+		want  string
+		found bool
+	}{
+		{"a.b[0]", "10", true},
+		{"$.a.b[1].c", "null", true},
+		{"top[0][1]", "2", true},
+		{"a.b[5]", "", false},
+		{"a.missing", "", false},
+	}
+	for _, tt := range tests {
+		value, found := lookupJSONPath(doc, tt.path)
+		if found != tt.found || (found && !jsonValueEquals(value, tt.want)) {
+			t.Errorf("lookupJSONPath(%q) = %v, %v; want %s, %v", tt.path, value, found, tt.want, tt.found)
+		}
+	}
+}
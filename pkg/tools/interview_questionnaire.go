@@ -0,0 +1,178 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"orchestrator/pkg/interviews"
+	"orchestrator/pkg/utils"
+)
+
+// createInterviewQuestionnaireTool is the factory function for the tool registry.
+func createInterviewQuestionnaireTool(ctx *AgentContext) (Tool, error) {
+	return NewInterviewQuestionnaireTool(ctx.ProjectDir), nil
+}
+
+// getInterviewQuestionnaireSchema returns the input schema for registry metadata.
+func getInterviewQuestionnaireSchema() InputSchema {
+	return NewInterviewQuestionnaireTool("").Definition().InputSchema
+}
+
+// InterviewQuestionnaireTool lets the PM pick the questionnaires that match a
+// feature and record what the interview learned for each question. spec_submit
+// refuses full specs until the selected questionnaires are covered.
+type InterviewQuestionnaireTool struct {
+	projectDir string
+	coverage   *interviews.Coverage // Injected by PM from state data
+}
+
+// NewInterviewQuestionnaireTool creates a new interview_questionnaire tool.
+func NewInterviewQuestionnaireTool(projectDir string) *InterviewQuestionnaireTool {
+	return &InterviewQuestionnaireTool{projectDir: projectDir, coverage: &interviews.Coverage{}}
+}
+
+// SetCoverage injects the interview's coverage from PM state. The PM shares
+// the same value with spec_submit and persists it after each turn.
+func (t *InterviewQuestionnaireTool) SetCoverage(coverage *interviews.Coverage) {
+	t.coverage = coverage
+}
+
+// Name returns the tool name.
+func (t *InterviewQuestionnaireTool) Name() string {
+	return ToolInterviewQuestionnaire
+}
+
+// Definition returns the tool definition.
+func (t *InterviewQuestionnaireTool) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name:        ToolInterviewQuestionnaire,
+		Description: "Work through the team's interview questionnaires. Actions: list (available questionnaires and their questions), select (follow a questionnaire for this feature), record (save the answer to a question), decline (no questionnaire applies), status (what is still uncovered). spec_submit is refused until the selected questionnaires are covered.",
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"action": {
+					Type:        "string",
+					Description: "What to do",
+					Enum:        []string{"list", "select", "record", "decline", "status"},
+				},
+				"questionnaire_id": {
+					Type:        "string",
+					Description: "Questionnaire ID (required for select and record)",
+				},
+				"question_id": {
+					Type:        "string",
+					Description: "Question ID (required for record)",
+				},
+				"answer": {
+					Type:        "string",
+					Description: "For record: a concise summary of what the user answered, in your words",
+				},
+				"reason": {
+					Type:        "string",
+					Description: "For decline: why none of the questionnaires fits this feature",
+				},
+			},
+			Required: []string{"action"},
+		},
+	}
+}
+
+// PromptDocumentation returns prompt-friendly docs for the tool.
+func (t *InterviewQuestionnaireTool) PromptDocumentation() string {
+	return `- **interview_questionnaire** - Follow the team's requirement questionnaires
+  - Parameters: action (required: list|select|record|decline|status), questionnaire_id, question_id, answer, reason
+  - list: show the questionnaires and their questions; select: follow one for this feature (more than one may apply)
+  - record: after the user answers, save a concise summary under the question ID
+  - decline: when no questionnaire fits, with the reason (shown to the user at preview)
+  - spec_submit refuses full specs until every required question of the selected questionnaires is recorded`
+}
+
+// Exec executes the interview_questionnaire tool.
+func (t *InterviewQuestionnaireTool) Exec(_ context.Context, args map[string]any) (*ExecResult, error) {
+	action, _ := utils.SafeAssert[string](args["action"])
+	questionnaires, err := interviews.Load(t.projectDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load interview questionnaires: %w", err)
+	}
+	if len(questionnaires) == 0 && action != "status" {
+		return nil, fmt.Errorf("no interview questionnaires are defined in %s; interview as usual", interviews.Dir)
+	}
+
+	switch action {
+	case "list":
+		return t.result(map[string]any{"questionnaires": questionnaires})
+
+	case "select":
+		q, err := findQuestionnaire(questionnaires, args)
+		if err != nil {
+			return nil, err
+		}
+		t.coverage.Select(q.ID)
+		return t.result(map[string]any{
+			"selected":  q.ID,
+			"questions": q.Questions,
+			"next":      "Ask about each question during the interview and record the answers.",
+		})
+
+	case "record":
+		q, err := findQuestionnaire(questionnaires, args)
+		if err != nil {
+			return nil, err
+		}
+		questionID, _ := utils.SafeAssert[string](args["question_id"])
+		if q.Question(questionID) == nil {
+			return nil, fmt.Errorf("questionnaire %q has no question %q", q.ID, questionID)
+		}
+		answer, _ := utils.SafeAssert[string](args["answer"])
+		if strings.TrimSpace(answer) == "" {
+			return nil, fmt.Errorf("answer is required for record")
+		}
+		// Recording an answer implies the questionnaire applies.
+		t.coverage.Select(q.ID)
+		t.coverage.Record(q.ID, questionID, strings.TrimSpace(answer))
+		return t.result(map[string]any{"recorded": q.ID + "/" + questionID, "missing": t.coverage.Missing(questionnaires)})
+
+	case "decline":
+		reason, _ := utils.SafeAssert[string](args["reason"])
+		if strings.TrimSpace(reason) == "" {
+			return nil, fmt.Errorf("reason is required for decline")
+		}
+		if err := t.coverage.Decline(strings.TrimSpace(reason)); err != nil {
+			return nil, fmt.Errorf("cannot decline: %w", err)
+		}
+		return t.result(map[string]any{"declined": true})
+
+	case "status":
+		return t.result(t.coverage.Report(questionnaires))
+
+	default:
+		return nil, fmt.Errorf("unsupported action %q: valid actions are list, select, record, decline, status", action)
+	}
+}
+
+// findQuestionnaire resolves the questionnaire_id argument.
+func findQuestionnaire(questionnaires []*interviews.Questionnaire, args map[string]any) (*interviews.Questionnaire, error) {
+	id, _ := utils.SafeAssert[string](args["questionnaire_id"])
+	if id == "" {
+		return nil, fmt.Errorf("questionnaire_id is required")
+	}
+	ids := make([]string, 0, len(questionnaires))
+	for _, q := range questionnaires {
+		if q.ID == id {
+			return q, nil
+		}
+		ids = append(ids, q.ID)
+	}
+	return nil, fmt.Errorf("unknown questionnaire %q (available: %s)", id, strings.Join(ids, ", "))
+}
+
+// result marshals a successful response.
+func (t *InterviewQuestionnaireTool) result(payload any) (*ExecResult, error) {
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return &ExecResult{Content: string(content)}, nil
+}
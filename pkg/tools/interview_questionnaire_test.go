@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"orchestrator/pkg/interviews"
+)
+
+// newQuestionnaireProject creates a project directory with one questionnaire.
+func newQuestionnaireProject(t *testing.T) string {
+	t.Helper()
+	projectDir := t.TempDir()
+	dir := filepath.Join(projectDir, interviews.Dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "title: New REST endpoint\nquestions:\n  - id: auth\n    prompt: Who may call it?\n  - id: paging\n    prompt: Paginated?\n    optional: true\n"
+	if err := os.WriteFile(filepath.Join(dir, "rest-endpoint.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir
+}
+
+func TestInterviewQuestionnaire_SelectAndRecord(t *testing.T) {
+	tool := NewInterviewQuestionnaireTool(newQuestionnaireProject(t))
+	coverage := &interviews.Coverage{}
+	tool.SetCoverage(coverage)
+	ctx := context.Background()
+
+	if _, err := tool.Exec(ctx, map[string]any{"action": "select", "questionnaire_id": "graphql"}); err == nil {
+		t.Error("expected an error for an unknown questionnaire")
+	}
+	if _, err := tool.Exec(ctx, map[string]any{"action": "select", "questionnaire_id": "rest-endpoint"}); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if _, err := tool.Exec(ctx, map[string]any{"action": "record", "questionnaire_id": "rest-endpoint", "question_id": "nope", "answer": "x"}); err == nil {
+		t.Error("expected an error for an unknown question")
+	}
+
+	result, err := tool.Exec(ctx, map[string]any{"action": "record", "questionnaire_id": "rest-endpoint", "question_id": "auth", "answer": " Admins only "})
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	var response map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response["recorded"] != "rest-endpoint/auth" || response["missing"] != nil {
+		t.Errorf("unexpected record response: %v", response)
+	}
+	if coverage.Answers["rest-endpoint"]["auth"] != "Admins only" {
+		t.Errorf("answer not recorded in the injected coverage: %+v", coverage)
+	}
+
+	result, err = tool.Exec(ctx, map[string]any{"action": "status"})
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if !strings.Contains(result.Content, `"complete":true`) {
+		t.Errorf("expected complete status, got %s", result.Content)
+	}
+}
+
+func TestInterviewQuestionnaire_NoQuestionnaires(t *testing.T) {
+	tool := NewInterviewQuestionnaireTool(t.TempDir())
+	if _, err := tool.Exec(context.Background(), map[string]any{"action": "list"}); err == nil {
+		t.Error("expected an error when no questionnaires are defined")
+	}
+}
+
+func TestSpecSubmitTool_RequiresQuestionnaireCoverage(t *testing.T) {
+	tool := NewSpecSubmitTool(newQuestionnaireProject(t))
+	coverage := &interviews.Coverage{}
+	tool.SetInterviewCoverage(coverage)
+	ctx := context.Background()
+	args := map[string]any{"markdown": validSpecMarkdown, "summary": "Test specification"}
+
+	if _, err := tool.Exec(ctx, args); err == nil || !strings.Contains(err.Error(), "interview_questionnaire") {
+		t.Errorf("expected a coverage error before any selection, got %v", err)
+	}
+
+	coverage.Select("rest-endpoint")
+	if _, err := tool.Exec(ctx, args); err == nil || !strings.Contains(err.Error(), "rest-endpoint/auth") {
+		t.Errorf("expected the uncovered question to be named, got %v", err)
+	}
+
+	// Hotfixes are not gated.
+	if _, err := tool.Exec(ctx, map[string]any{"markdown": validSpecMarkdown, "summary": "fix", "hotfix": true}); err != nil {
+		t.Errorf("hotfix should not require coverage: %v", err)
+	}
+
+	coverage.Record("rest-endpoint", "auth", "Admins only")
+	if _, err := tool.Exec(ctx, args); err != nil {
+		t.Errorf("expected submission once covered, got %v", err)
+	}
+}
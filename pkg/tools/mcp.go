@@ -89,6 +89,7 @@ const (
 	SignalStoriesSubmitted     = "STORIES_SUBMITTED"     // submit_stories tool submitted story list
 	SignalMaintenanceSubmitted = "MAINTENANCE_SUBMITTED" // submit_stories tool submitted maintenance stories
 	SignalStoryEditComplete    = "STORY_EDIT_COMPLETE"   // story_edit tool annotated story before requeue
+	SignalAmendmentSubmitted   = "AMENDMENT_SUBMITTED"   // submit_amendment tool mapped a spec amendment onto stories
 
 	// Coder signals.
 	SignalPlanReview       = "PLAN_REVIEW"       // submit_plan tool ready for architect review
@@ -101,6 +102,10 @@ const (
 	SignalVerificationFail = "VERIFICATION_FAIL" // submit_verification tool: acceptance criteria gaps found
 	SignalProbingPass      = "PROBING_PASS"      // submit_probing tool: no critical robustness issues found
 	SignalProbingFail      = "PROBING_FAIL"      // submit_probing tool: critical robustness issues found
+
+	// Coder conflict-resolution signals.
+	SignalConflictsResolved   = "CONFLICTS_RESOLVED"   // resolve_conflicts tool: every conflicted hunk has a resolution
+	SignalConflictsUnresolved = "CONFLICTS_UNRESOLVED" // resolve_conflicts tool: conflicts need a decision from the architect
 )
 
 // ExecResult is the result of executing a tool.
@@ -28,6 +28,9 @@ type AgentContext struct {
 	ProjectDir      string                 // Project directory for bootstrap detection and config access
 	StoryID         string                 // Story ID for commit message prefix (used by done tool)
 	TargetBranch    string                 // Target branch for done tool merge-base check (defaults to "main")
+	StoryType       string                 // Story type for the done tool's commit message
+	IsHotfix        bool                   // Hotfix flag for the done tool's commit message
+	RequirementIDs  []string               // Requirement IDs for the done tool's commit message footer
 	ComposeRegistry *state.ComposeRegistry // Compose stack registry for cleanup tracking
 	MaintenanceLog  MaintenanceLog         // Maintenance log for architect review tools (nil for non-architect)
 }
@@ -295,7 +298,11 @@ func createLintTool(ctx *AgentContext) (Tool, error) {
 
 // createDoneTool creates a done tool instance.
 func createDoneTool(ctx *AgentContext) (Tool, error) {
-	return NewDoneTool(ctx.Agent, ctx.Executor, ctx.WorkDir, ctx.StoryID, ctx.TargetBranch), nil
+	tool := NewDoneTool(ctx.Agent, ctx.Executor, ctx.WorkDir, ctx.StoryID, ctx.TargetBranch)
+	tool.storyType = ctx.StoryType
+	tool.isHotfix = ctx.IsHotfix
+	tool.requirementIDs = ctx.RequirementIDs
+	return tool, nil
 }
 
 // createBackendInfoTool creates a backend info tool instance.
@@ -677,6 +684,14 @@ func getStoryEditSchema() InputSchema {
 	return NewStoryEditTool().Definition().InputSchema
 }
 
+func createSubmitAmendmentTool(_ *AgentContext) (Tool, error) {
+	return NewSubmitAmendmentTool(), nil
+}
+
+func getSubmitAmendmentSchema() InputSchema {
+	return NewSubmitAmendmentTool().Definition().InputSchema
+}
+
 func createAddMaintenanceItemTool(ctx *AgentContext) (Tool, error) {
 	return NewAddMaintenanceItemTool(ctx.MaintenanceLog, ctx.AgentID, ctx.StoryID), nil
 }
@@ -701,6 +716,19 @@ func getWebFetchSchema() InputSchema {
 	return NewWebFetchTool().Definition().InputSchema
 }
 
+func createDocsSearchTool(ctx *AgentContext) (Tool, error) {
+	if ctx.Executor == nil {
+		return nil, fmt.Errorf("docs_search tool requires an executor")
+	}
+	// The index is built inside the container, so the container workspace path is
+	// used, as for file_edit; ctx.WorkDir is the host path.
+	return NewDocsSearchTool(ctx.Executor, DefaultWorkspaceDir), nil
+}
+
+func getDocsSearchSchema() InputSchema {
+	return NewDocsSearchTool(nil, "").Definition().InputSchema
+}
+
 // init registers all tools in the global registry using the factory pattern.
 //
 //nolint:gochecknoinits // Factory pattern requires init() for tool registration
@@ -895,6 +923,12 @@ func init() {
 		InputSchema: getSpecSubmitSchema(),
 	})
 
+	Register(ToolInterviewQuestionnaire, createInterviewQuestionnaireTool, &ToolMeta{
+		Name:        ToolInterviewQuestionnaire,
+		Description: "Select the team's interview questionnaires for a feature and record answers to their questions",
+		InputSchema: getInterviewQuestionnaireSchema(),
+	})
+
 	Register(ToolChatAskUser, createChatAskUserTool, &ToolMeta{
 		Name:        ToolChatAskUser,
 		Description: "Post a question to chat and wait for user response. Use when you need user input before proceeding.",
@@ -921,6 +955,13 @@ func init() {
 		InputSchema: getStoryEditSchema(),
 	})
 
+	// Register submit_amendment tool for architect spec amendments
+	Register(ToolSubmitAmendment, createSubmitAmendmentTool, &ToolMeta{
+		Name:        ToolSubmitAmendment,
+		Description: "Apply an approved spec amendment to existing stories (edit, reopen, or add)",
+		InputSchema: getSubmitAmendmentSchema(),
+	})
+
 	// Register architect maintenance tools
 	Register(ToolAddMaintenanceItem, createAddMaintenanceItemTool, &ToolMeta{
 		Name:        ToolAddMaintenanceItem,
@@ -957,4 +998,9 @@ func init() {
 		Description: "Fetch and read the content of a web page from a URL",
 		InputSchema: getWebFetchSchema(),
 	})
+	Register(ToolDocsSearch, createDocsSearchTool, &ToolMeta{
+		Name:        ToolDocsSearch,
+		Description: "Search offline documentation for the dependencies installed in the container",
+		InputSchema: getDocsSearchSchema(),
+	})
 }
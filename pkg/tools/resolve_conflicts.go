@@ -0,0 +1,173 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResolveConflictsTool is the terminal tool for the PREPARE_MERGE conflict-resolution
+// toolloop. The LLM calls this to submit a resolution for every conflicted hunk, or to
+// report that the conflicts cannot be resolved without help.
+//
+// It is created per resolution run with the hunks to expect, so it is not registered
+// with the tool registry.
+type ResolveConflictsTool struct {
+	hunkCounts map[string]int // conflicted file -> number of hunks in it
+}
+
+// NewResolveConflictsTool creates a resolve conflicts tool expecting a resolution for
+// hunks 1..n of each file in hunkCounts.
+func NewResolveConflictsTool(hunkCounts map[string]int) *ResolveConflictsTool {
+	return &ResolveConflictsTool{hunkCounts: hunkCounts}
+}
+
+// Name returns the tool identifier.
+func (r *ResolveConflictsTool) Name() string {
+	return ToolResolveConflicts
+}
+
+// Definition returns the tool's definition in Claude API format.
+func (r *ResolveConflictsTool) Definition() ToolDefinition {
+	return ToolDefinition{
+		Name:        ToolResolveConflicts,
+		Description: "Submit the merged text for every conflicted hunk, or explain why the conflicts cannot be resolved safely.",
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"resolutions": {
+					Type:        "array",
+					Description: "One resolution per conflicted hunk",
+					Items: &Property{
+						Type: "object",
+						Properties: map[string]*Property{
+							"file": {
+								Type:        "string",
+								Description: "Path of the conflicted file, as listed in the conflict",
+							},
+							"hunk": {
+								Type:        "integer",
+								Description: "Number of the hunk within the file, starting at 1",
+							},
+							"resolution": {
+								Type:        "string",
+								Description: "Text replacing the whole hunk, conflict markers included. Empty removes the hunk.",
+							},
+						},
+						Required: []string{"file", "hunk", "resolution"},
+					},
+				},
+				"summary": {
+					Type:        "string",
+					Description: "How the two sides were combined, or what was tried",
+				},
+				"unresolvable_reason": {
+					Type:        "string",
+					Description: "Set only when the conflicts cannot be resolved safely: why, and what decision is needed",
+				},
+			},
+			Required: []string{"summary"},
+		},
+	}
+}
+
+// PromptDocumentation returns markdown documentation for LLM prompts.
+func (r *ResolveConflictsTool) PromptDocumentation() string {
+	return `- **resolve_conflicts** - Submit merged text for each conflicted hunk
+  - Parameters: summary (required), resolutions (array of {file, hunk, resolution}), unresolvable_reason (optional)
+  - Every hunk of every conflicted file needs exactly one resolution; the resolution replaces the hunk including its markers
+  - Set unresolvable_reason instead when the two sides cannot be combined without a decision from the architect`
+}
+
+// Exec validates the resolutions against the expected hunks.
+func (r *ResolveConflictsTool) Exec(_ context.Context, args map[string]any) (*ExecResult, error) {
+	summary, err := extractRequiredString(args, "summary")
+	if err != nil {
+		return nil, err
+	}
+
+	if reason, _ := args["unresolvable_reason"].(string); strings.TrimSpace(reason) != "" {
+		return &ExecResult{
+			Content: "Conflicts reported as unresolvable",
+			ProcessEffect: &ProcessEffect{
+				Signal: SignalConflictsUnresolved,
+				Data: map[string]any{
+					"summary":             summary,
+					"unresolvable_reason": reason,
+				},
+			},
+		}, nil
+	}
+
+	resolutionsRaw, ok := args["resolutions"].([]any)
+	if !ok || len(resolutionsRaw) == 0 {
+		return nil, fmt.Errorf("resolutions must be a non-empty array unless unresolvable_reason is set")
+	}
+
+	seen := make(map[string]bool, len(resolutionsRaw))
+	resolutions := make([]any, 0, len(resolutionsRaw))
+	for i, item := range resolutionsRaw {
+		resolution, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("resolutions item %d must be an object", i)
+		}
+		file, _ := resolution["file"].(string)
+		count, known := r.hunkCounts[file]
+		if !known {
+			return nil, fmt.Errorf("resolutions item %d: %q is not a conflicted file", i, file)
+		}
+		hunk, ok := hunkNumber(resolution["hunk"])
+		if !ok || hunk < 1 || hunk > count {
+			return nil, fmt.Errorf("resolutions item %d: hunk must be between 1 and %d for %s", i, count, file)
+		}
+		text, ok := resolution["resolution"].(string)
+		if !ok {
+			return nil, fmt.Errorf("resolutions item %d: resolution must be a string", i)
+		}
+		key := fmt.Sprintf("%s#%d", file, hunk)
+		if seen[key] {
+			return nil, fmt.Errorf("resolutions item %d: hunk %d of %s is resolved twice", i, hunk, file)
+		}
+		seen[key] = true
+		resolutions = append(resolutions, map[string]any{"file": file, "hunk": hunk, "resolution": text})
+	}
+
+	var missing []string
+	for file, count := range r.hunkCounts {
+		for hunk := 1; hunk <= count; hunk++ {
+			if !seen[fmt.Sprintf("%s#%d", file, hunk)] {
+				missing = append(missing, fmt.Sprintf("%s hunk %d", file, hunk))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("missing resolutions for: %s", strings.Join(missing, ", "))
+	}
+
+	return &ExecResult{
+		Content: "Conflict resolutions submitted",
+		ProcessEffect: &ProcessEffect{
+			Signal: SignalConflictsResolved,
+			Data: map[string]any{
+				"resolutions": resolutions,
+				"summary":     summary,
+			},
+		},
+	}, nil
+}
+
+// hunkNumber converts a hunk number decoded from JSON (float64) or passed directly (int).
+func hunkNumber(raw any) (int, bool) {
+	switch n := raw.(type) {
+	case float64:
+		if n != float64(int(n)) {
+			return 0, false
+		}
+		return int(n), true
+	case int:
+		return n, true
+	}
+	return 0, false
+}
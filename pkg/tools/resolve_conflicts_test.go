@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveConflicts_Resolved(t *testing.T) {
+	tool := NewResolveConflictsTool(map[string]int{"main.go": 2, "util.go": 1})
+
+	args := map[string]any{
+		"resolutions": []any{
+			map[string]any{"file": "main.go", "hunk": float64(1), "resolution": "a := 1\n"},
+			map[string]any{"file": "main.go", "hunk": float64(2), "resolution": ""},
+			map[string]any{"file": "util.go", "hunk": float64(1), "resolution": "b := 2\n"},
+		},
+		"summary": "Kept both changes",
+	}
+
+	result, err := tool.Exec(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.ProcessEffect == nil || result.ProcessEffect.Signal != SignalConflictsResolved {
+		t.Fatalf("Expected %s effect, got %+v", SignalConflictsResolved, result.ProcessEffect)
+	}
+
+	data, ok := result.ProcessEffect.Data.(map[string]any)
+	if !ok {
+		t.Fatal("Expected map[string]any data")
+	}
+	resolutions, ok := data["resolutions"].([]any)
+	if !ok || len(resolutions) != 3 {
+		t.Fatalf("Expected 3 resolutions, got %v", data["resolutions"])
+	}
+	first, ok := resolutions[0].(map[string]any)
+	if !ok {
+		t.Fatal("Expected resolution item to be map[string]any")
+	}
+	if first["hunk"] != 1 || first["resolution"] != "a := 1\n" {
+		t.Errorf("Unexpected first resolution: %v", first)
+	}
+}
+
+func TestResolveConflicts_Unresolvable(t *testing.T) {
+	tool := NewResolveConflictsTool(map[string]int{"main.go": 1})
+
+	result, err := tool.Exec(context.Background(), map[string]any{
+		"summary":             "Both sides rename the handler differently",
+		"unresolvable_reason": "Which name should the API use?",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.ProcessEffect == nil || result.ProcessEffect.Signal != SignalConflictsUnresolved {
+		t.Fatalf("Expected %s effect, got %+v", SignalConflictsUnresolved, result.ProcessEffect)
+	}
+}
+
+func TestResolveConflicts_Invalid(t *testing.T) {
+	tool := NewResolveConflictsTool(map[string]int{"main.go": 2})
+
+	testCases := []struct {
+		name        string
+		resolutions []any
+		wantErr     string
+	}{
+		{
+			name:        "Empty",
+			resolutions: []any{},
+			wantErr:     "non-empty array",
+		},
+		{
+			name: "UnknownFile",
+			resolutions: []any{
+				map[string]any{"file": "other.go", "hunk": float64(1), "resolution": "x"},
+			},
+			wantErr: "not a conflicted file",
+		},
+		{
+			name: "HunkOutOfRange",
+			resolutions: []any{
+				map[string]any{"file": "main.go", "hunk": float64(3), "resolution": "x"},
+			},
+			wantErr: "between 1 and 2",
+		},
+		{
+			name: "Duplicate",
+			resolutions: []any{
+				map[string]any{"file": "main.go", "hunk": float64(1), "resolution": "x"},
+				map[string]any{"file": "main.go", "hunk": float64(1), "resolution": "y"},
+			},
+			wantErr: "resolved twice",
+		},
+		{
+			name: "Missing",
+			resolutions: []any{
+				map[string]any{"file": "main.go", "hunk": float64(1), "resolution": "x"},
+			},
+			wantErr: "main.go hunk 2",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tool.Exec(context.Background(), map[string]any{
+				"resolutions": tc.resolutions,
+				"summary":     "attempt",
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"orchestrator/pkg/interviews"
 	"orchestrator/pkg/mirror"
 	"orchestrator/pkg/specs"
 	"orchestrator/pkg/utils"
@@ -16,6 +17,8 @@ type SpecSubmitTool struct {
 	projectDir            string
 	bootstrapRequirements []workspace.BootstrapRequirementID // Injected bootstrap requirements (rendered by architect)
 	inFlight              bool                               // True when development is in progress (only hotfixes allowed)
+	interviewCoverage     *interviews.Coverage               // Injected questionnaire coverage (full specs need it complete)
+	approvedSpec          string                             // Injected last approved spec (amendments are diffed against it)
 }
 
 // NewSpecSubmitTool creates a new spec submit tool instance.
@@ -40,6 +43,19 @@ func (s *SpecSubmitTool) SetInFlight(inFlight bool) {
 	s.inFlight = inFlight
 }
 
+// SetInterviewCoverage injects the interview's questionnaire coverage from PM state.
+// Full specs are refused while questionnaires in .maestro/interviews/ are
+// unaddressed or have required questions left uncovered.
+func (s *SpecSubmitTool) SetInterviewCoverage(coverage *interviews.Coverage) {
+	s.interviewCoverage = coverage
+}
+
+// SetApprovedSpec injects the last approved spec from PM state.
+// Amendments are submitted as a full revised spec and diffed against it.
+func (s *SpecSubmitTool) SetApprovedSpec(markdown string) {
+	s.approvedSpec = markdown
+}
+
 // Definition returns the tool's definition in Claude API format.
 func (s *SpecSubmitTool) Definition() ToolDefinition {
 	return ToolDefinition{
@@ -60,6 +76,10 @@ func (s *SpecSubmitTool) Definition() ToolDefinition {
 					Type:        "boolean",
 					Description: "Set to true if this is a hotfix (small, scoped change) rather than a full specification. Hotfixes are allowed while development is in progress.",
 				},
+				"amendment": {
+					Type:        "boolean",
+					Description: "Set to true to amend the approved specification. Submit the complete revised spec (same R-xxx requirement IDs for unchanged requirements); only the requirement-level changes are reviewed. Amendments are allowed while development is in progress.",
+				},
 				"maestro_md": {
 					Type:        "string",
 					Description: "Optional: Updated MAESTRO.md content if the project scope or architecture has changed. Will be committed to the repository.",
@@ -78,12 +98,16 @@ func (s *SpecSubmitTool) Name() string {
 // PromptDocumentation returns markdown documentation for LLM prompts.
 func (s *SpecSubmitTool) PromptDocumentation() string {
 	return `- **spec_submit** - Submit finalized specification for user review
-  - Parameters: markdown (required), summary (required), hotfix (optional, boolean), maestro_md (optional, string)
+  - Parameters: markdown (required), summary (required), hotfix (optional, boolean), amendment (optional, boolean), maestro_md (optional, string)
   - Accepts flexible markdown format - architect will review and provide feedback
   - Use when you have completed the specification interview and drafted the full spec
   - Bootstrap requirements are handled automatically by the system (transparent to PM)
   - When development is in progress (in_flight=true), set hotfix=true for small changes
   - Full specs (hotfix=false) are rejected while development is in progress
+  - To change the approved spec's requirements, set amendment=true and submit the complete revised spec:
+    keep the R-xxx IDs of existing requirements, add new IDs for new ones, and drop removed ones.
+    The architect reviews only the added/changed/removed requirements and updates the affected stories
+  - Full specs are rejected until the selected interview questionnaires are covered (see interview_questionnaire)
   - Use maestro_md parameter when project scope/architecture changes significantly`
 }
 
@@ -97,9 +121,34 @@ func (s *SpecSubmitTool) Exec(ctx context.Context, args map[string]any) (*ExecRe
 		isHotfix = hotfix
 	}
 
-	// Enforce in_flight restriction: when development is in progress, only hotfixes allowed
-	if s.inFlight && !isHotfix {
-		return nil, fmt.Errorf("cannot submit new full spec while development is in progress. Wait for completion or scope down to the hotfix level for immediate processing")
+	// Extract amendment parameter (optional, defaults to false).
+	isAmendment := false
+	if amendment, ok := args["amendment"].(bool); ok {
+		isAmendment = amendment
+	}
+	if isHotfix && isAmendment {
+		return nil, fmt.Errorf("a submission cannot be both a hotfix and an amendment")
+	}
+
+	// Enforce in_flight restriction: when development is in progress, only hotfixes and amendments allowed
+	if s.inFlight && !isHotfix && !isAmendment {
+		return nil, fmt.Errorf("cannot submit new full spec while development is in progress. Wait for completion, scope down to the hotfix level for immediate processing, or amend the approved spec (amendment=true)")
+	}
+
+	// Enforce questionnaire coverage for full specs (hotfixes are too small to warrant it,
+	// and amendments revise a spec whose interview was already covered)
+	if !isHotfix && !isAmendment && s.projectDir != "" {
+		questionnaires, err := interviews.Load(s.projectDir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot check interview questionnaires: %w (ask the user to fix the file)", err)
+		}
+		coverage := s.interviewCoverage
+		if coverage == nil {
+			coverage = &interviews.Coverage{}
+		}
+		if err := coverage.CheckComplete(questionnaires); err != nil {
+			return nil, fmt.Errorf("cannot submit spec yet: %w. Use interview_questionnaire to continue", err)
+		}
 	}
 
 	// Extract markdown parameter.
@@ -149,6 +198,16 @@ func (s *SpecSubmitTool) Exec(ctx context.Context, args map[string]any) (*ExecRe
 		}
 	}
 
+	// Amendments must be diffable against the approved spec
+	var amendmentDiff string
+	if isAmendment {
+		diff, err := s.amendmentDiff(markdownStr)
+		if err != nil {
+			return nil, err
+		}
+		amendmentDiff = diff
+	}
+
 	// Parse the user specification to extract basic metadata (but don't enforce strict validation).
 	// The architect will review the spec and provide feedback if needed.
 	spec, err := specs.Parse(markdownStr)
@@ -186,7 +245,33 @@ func (s *SpecSubmitTool) Exec(ctx context.Context, args map[string]any) (*ExecRe
 				"summary":                summaryStr,
 				"metadata":               metadata,
 				"is_hotfix":              isHotfix, // Pass hotfix flag to state machine
+				"is_amendment":           isAmendment,
+				"amendment_diff":         amendmentDiff, // Requirement-level diff against the approved spec
 			},
 		},
 	}, nil
 }
+
+// amendmentDiff renders the requirement-level diff between the approved spec
+// and an amended version of it.
+func (s *SpecSubmitTool) amendmentDiff(markdown string) (string, error) {
+	if s.approvedSpec == "" {
+		return "", fmt.Errorf("there is no approved spec to amend - submit a full spec (amendment=false) instead")
+	}
+	base, err := specs.Parse(s.approvedSpec)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse the approved spec for diffing: %w", err)
+	}
+	updated, err := specs.Parse(markdown)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse the amended spec: %w", err)
+	}
+	if len(updated.Requirements) == 0 {
+		return "", fmt.Errorf("the amended spec has no requirements in the '### R-001: Title' format, so it cannot be diffed - keep the approved spec's requirement headings")
+	}
+	changes := specs.Diff(base, updated)
+	if len(changes) == 0 {
+		return "", fmt.Errorf("the amendment does not add, change, or remove any requirement - change the requirements or submit a hotfix instead")
+	}
+	return specs.FormatDiff(changes), nil
+}
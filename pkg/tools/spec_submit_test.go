@@ -2,6 +2,7 @@ package tools
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -226,3 +227,53 @@ func TestSpecSubmitTool_Definition(t *testing.T) {
 		t.Errorf("Expected 2 required fields, got: %d", len(def.InputSchema.Required))
 	}
 }
+
+func TestSpecSubmitTool_Amendment(t *testing.T) {
+	ctx := context.Background()
+	amended := strings.Replace(validSpecMarkdown, "- [ ] Criterion 2", "- [ ] Criterion 2 (revised)", 1)
+
+	t.Run("requires approved spec", func(t *testing.T) {
+		tool := NewSpecSubmitTool("")
+		_, err := tool.Exec(ctx, map[string]any{"markdown": amended, "summary": "Revise criterion", "amendment": true})
+		if err == nil || !strings.Contains(err.Error(), "no approved spec") {
+			t.Fatalf("Expected no-approved-spec error, got: %v", err)
+		}
+	})
+
+	t.Run("allowed in flight with requirement diff", func(t *testing.T) {
+		tool := NewSpecSubmitTool("")
+		tool.SetInFlight(true)
+		tool.SetApprovedSpec(validSpecMarkdown)
+
+		result, err := tool.Exec(ctx, map[string]any{"markdown": amended, "summary": "Revise criterion", "amendment": true})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		data := result.ProcessEffect.Data.(map[string]any)
+		if data["is_amendment"] != true {
+			t.Errorf("Expected is_amendment=true, got %v", data["is_amendment"])
+		}
+		diff, _ := data["amendment_diff"].(string)
+		if !strings.Contains(diff, "### Changed R-001: Test Requirement") || !strings.Contains(diff, "Criterion 2 (revised)") {
+			t.Errorf("Unexpected amendment diff:\n%s", diff)
+		}
+	})
+
+	t.Run("rejects unchanged requirements", func(t *testing.T) {
+		tool := NewSpecSubmitTool("")
+		tool.SetApprovedSpec(validSpecMarkdown)
+		_, err := tool.Exec(ctx, map[string]any{"markdown": validSpecMarkdown, "summary": "No change", "amendment": true})
+		if err == nil || !strings.Contains(err.Error(), "does not add, change, or remove") {
+			t.Fatalf("Expected empty-diff error, got: %v", err)
+		}
+	})
+
+	t.Run("rejects hotfix amendment", func(t *testing.T) {
+		tool := NewSpecSubmitTool("")
+		tool.SetApprovedSpec(validSpecMarkdown)
+		_, err := tool.Exec(ctx, map[string]any{"markdown": amended, "summary": "Both", "amendment": true, "hotfix": true})
+		if err == nil {
+			t.Fatal("Expected error for hotfix amendment")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"orchestrator/pkg/utils"
+)
+
+// Story update actions for submit_amendment.
+const (
+	// AmendmentActionEdit edits a story that has not started, with story_edit semantics.
+	AmendmentActionEdit = "edit"
+	// AmendmentActionReopen adds a follow-up story for a story that is done or in progress.
+	AmendmentActionReopen = "reopen"
+)
+
+// SubmitAmendmentTool lets the architect map an approved spec amendment onto
+// the spec's existing stories: edit stories that have not started, reopen
+// stories whose work is done or underway, and add stories for new requirements.
+type SubmitAmendmentTool struct {
+	// No executor needed - this is a control flow tool
+}
+
+// NewSubmitAmendmentTool creates a new submit_amendment tool.
+func NewSubmitAmendmentTool() *SubmitAmendmentTool {
+	return &SubmitAmendmentTool{}
+}
+
+// Name returns the tool name.
+func (t *SubmitAmendmentTool) Name() string {
+	return ToolSubmitAmendment
+}
+
+// PromptDocumentation returns formatted tool documentation for prompts.
+func (t *SubmitAmendmentTool) PromptDocumentation() string {
+	return `- **submit_amendment** - Apply an approved spec amendment to the existing stories
+  - Parameters:
+    - summary (string, REQUIRED) - How the requirement changes map onto stories
+    - story_updates (array, optional) - Changes to existing stories, each with:
+      - story_id (string, REQUIRED), action ("edit" or "reopen", REQUIRED), requirement_ids (array of R-xxx IDs)
+      - implementation_notes (string) and/or revised_content (string), as for story_edit
+      - "edit" only applies to stories that have not started; "reopen" adds a follow-up story for done or in-progress stories
+    - new_requirements (array, optional) - Stories for work no existing story covers, in the submit_stories requirement format
+  - At least one story update or new requirement is required`
+}
+
+// Definition returns the tool definition for LLM.
+func (t *SubmitAmendmentTool) Definition() ToolDefinition {
+	requirements := NewSubmitStoriesTool().Definition().InputSchema.Properties["requirements"]
+	requirements.Description = "Stories for added requirements (or changed ones no existing story covers), in the submit_stories format. " +
+		"Dependencies may reference ordinal IDs in this array or existing story IDs."
+
+	return ToolDefinition{
+		Name: ToolSubmitAmendment,
+		Description: "Apply an approved spec amendment to the spec's existing stories. " +
+			"Edit stories that have not started, reopen stories that are done or in progress (a follow-up story is added), " +
+			"and add stories for requirements no existing story covers.",
+		InputSchema: InputSchema{
+			Type: "object",
+			Properties: map[string]Property{
+				"summary": {
+					Type:        "string",
+					Description: "Brief summary of how the requirement changes map onto stories",
+				},
+				"story_updates": {
+					Type:        "array",
+					Description: "Changes to existing stories affected by the amendment",
+					Items: &Property{
+						Type: "object",
+						Properties: map[string]*Property{
+							"story_id": {
+								Type:        "string",
+								Description: "ID of the existing story",
+							},
+							"action": {
+								Type:        "string",
+								Description: "'edit' for a story that has not started; 'reopen' for a story that is done or in progress",
+								Enum:        []string{AmendmentActionEdit, AmendmentActionReopen},
+							},
+							"requirement_ids": {
+								Type:        "array",
+								Description: "The changed requirement IDs (R-xxx) this update addresses",
+								Items:       &Property{Type: "string"},
+							},
+							"implementation_notes": {
+								Type:        "string",
+								Description: "What must change, appended to the story (edit) or used as the follow-up story's task (reopen)",
+							},
+							"revised_content": {
+								Type:        "string",
+								Description: "Complete replacement story content; takes precedence over implementation_notes",
+							},
+						},
+					},
+				},
+				"new_requirements": requirements,
+			},
+			Required: []string{"summary"},
+		},
+	}
+}
+
+// Exec executes the tool with the given arguments.
+//
+//nolint:cyclop // Per-entry validation of two arrays is inherently branchy
+func (t *SubmitAmendmentTool) Exec(_ context.Context, args map[string]any) (*ExecResult, error) {
+	summary, ok := utils.SafeAssert[string](args["summary"])
+	if !ok || summary == "" {
+		return nil, fmt.Errorf("summary is required and must be a non-empty string")
+	}
+
+	updates, _ := utils.SafeAssert[[]any](args["story_updates"])
+	for i, raw := range updates {
+		update, ok := utils.SafeAssert[map[string]any](raw)
+		if !ok {
+			return nil, fmt.Errorf("story_updates[%d] must be an object", i)
+		}
+		if id, _ := utils.SafeAssert[string](update["story_id"]); id == "" {
+			return nil, fmt.Errorf("story_updates[%d]: story_id is required", i)
+		}
+		action, _ := utils.SafeAssert[string](update["action"])
+		if action != AmendmentActionEdit && action != AmendmentActionReopen {
+			return nil, fmt.Errorf("story_updates[%d]: action must be '%s' or '%s'", i, AmendmentActionEdit, AmendmentActionReopen)
+		}
+		notes, _ := utils.SafeAssert[string](update["implementation_notes"])
+		revised, _ := utils.SafeAssert[string](update["revised_content"])
+		if notes == "" && revised == "" {
+			return nil, fmt.Errorf("story_updates[%d]: implementation_notes or revised_content is required", i)
+		}
+	}
+
+	requirements, _ := utils.SafeAssert[[]any](args["new_requirements"])
+	for i, raw := range requirements {
+		req, ok := utils.SafeAssert[map[string]any](raw)
+		if !ok {
+			return nil, fmt.Errorf("new_requirements[%d] must be an object", i)
+		}
+		if id, _ := utils.SafeAssert[string](req["id"]); id == "" {
+			return nil, fmt.Errorf("new_requirements[%d]: id is required (e.g., req_001)", i)
+		}
+		if title, _ := utils.SafeAssert[string](req["title"]); title == "" {
+			return nil, fmt.Errorf("new_requirements[%d]: title is required", i)
+		}
+	}
+
+	if len(updates) == 0 && len(requirements) == 0 {
+		return nil, fmt.Errorf("provide at least one story update or new requirement")
+	}
+
+	return &ExecResult{
+		Content: fmt.Sprintf("Amendment submitted (%d story updates, %d new stories)", len(updates), len(requirements)),
+		ProcessEffect: &ProcessEffect{
+			Signal: SignalAmendmentSubmitted,
+			Data: map[string]any{
+				"summary":       summary,
+				"story_updates": updates,
+				"requirements":  requirements,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestSubmitAmendmentTool_Definition(t *testing.T) {
+	def := NewSubmitAmendmentTool().Definition()
+
+	if def.Name != ToolSubmitAmendment {
+		t.Errorf("expected definition name %q, got %q", ToolSubmitAmendment, def.Name)
+	}
+	for _, prop := range []string{"summary", "story_updates", "new_requirements"} {
+		if _, exists := def.InputSchema.Properties[prop]; !exists {
+			t.Errorf("expected %q property in schema", prop)
+		}
+	}
+	if def.InputSchema.Properties["new_requirements"].Items == nil {
+		t.Error("expected new_requirements to reuse the submit_stories requirement schema")
+	}
+}
+
+func TestSubmitAmendmentTool_Exec(t *testing.T) {
+	tool := NewSubmitAmendmentTool()
+	result, err := tool.Exec(context.Background(), map[string]any{
+		"summary": "R-001 changes the done registration story",
+		"story_updates": []any{
+			map[string]any{
+				"story_id":             "abc123",
+				"action":               AmendmentActionReopen,
+				"requirement_ids":      []any{"R-001"},
+				"implementation_notes": "Return HTTP 409 for duplicate emails",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.ProcessEffect == nil || result.ProcessEffect.Signal != SignalAmendmentSubmitted {
+		t.Fatalf("expected %s signal, got %+v", SignalAmendmentSubmitted, result.ProcessEffect)
+	}
+	data, ok := result.ProcessEffect.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("expected map data, got %T", result.ProcessEffect.Data)
+	}
+	if updates, _ := data["story_updates"].([]any); len(updates) != 1 {
+		t.Errorf("expected 1 story update, got %v", data["story_updates"])
+	}
+}
+
+func TestSubmitAmendmentTool_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{"missing summary", map[string]any{"story_updates": []any{}}, "summary is required"},
+		{"nothing to apply", map[string]any{"summary": "x"}, "at least one"},
+		{"bad action", map[string]any{"summary": "x", "story_updates": []any{
+			map[string]any{"story_id": "s1", "action": "delete", "implementation_notes": "n"},
+		}}, "action must be"},
+		{"no change given", map[string]any{"summary": "x", "story_updates": []any{
+			map[string]any{"story_id": "s1", "action": AmendmentActionEdit},
+		}}, "implementation_notes or revised_content"},
+		{"requirement without title", map[string]any{"summary": "x", "new_requirements": []any{
+			map[string]any{"id": "req_001"},
+		}}, "title is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSubmitAmendmentTool().Exec(context.Background(), tt.args)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
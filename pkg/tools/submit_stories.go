@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"fmt"
+
+	"orchestrator/pkg/config"
 )
 
 // SubmitStoriesTool signals completion of spec analysis with structured story data.
@@ -29,6 +31,8 @@ func (t *SubmitStoriesTool) PromptDocumentation() string {
     - requirements (array, REQUIRED) - Array of requirement objects with id, title, description, acceptance_criteria, dependencies, and story_type
       - id (string, REQUIRED) - Ordinal identifier for this requirement (e.g., req_001, req_002)
       - dependencies reference ordinal IDs (e.g., ["req_001"]), NOT titles
+      - covers (array, optional) - Spec requirement IDs this story implements (e.g., ["R-001"]); every spec requirement should be covered by at least one story
+      - repo (string, optional) - Repository the story changes in a multi-repo product; omit for the primary repository
     - maintenance (boolean, OPTIONAL) - If true, routes stories to the maintenance queue with auto-merge enabled
   - Call this when you have completed spec analysis and extracted all requirements`
 }
@@ -86,6 +90,17 @@ func (t *SubmitStoriesTool) Definition() ToolDefinition {
 								Description: "Either 'app' (application code) or 'devops' (infrastructure)",
 								Enum:        []string{"app", "devops"},
 							},
+							"covers": {
+								Type:        "array",
+								Description: "Spec requirement IDs this story implements (e.g., [\"R-001\", \"R-003\"]), for traceability. Every spec requirement should be covered by at least one story.",
+								Items: &Property{
+									Type: "string",
+								},
+							},
+							"repo": {
+								Type:        "string",
+								Description: "Name of the repository the story changes, in a multi-repo product. Omit for the primary repository. A story changes exactly one repository.",
+							},
 						},
 					},
 				},
@@ -147,6 +162,20 @@ func (t *SubmitStoriesTool) Exec(_ context.Context, args map[string]any) (*ExecR
 		if _, ok := reqMap["story_type"].(string); !ok {
 			return nil, fmt.Errorf("requirement %d: story_type is required", i)
 		}
+		if covers, exists := reqMap["covers"]; exists && covers != nil {
+			if _, ok := covers.([]any); !ok {
+				return nil, fmt.Errorf("requirement %d: covers must be an array of spec requirement IDs", i)
+			}
+		}
+		if repo, exists := reqMap["repo"]; exists && repo != nil {
+			name, ok := repo.(string)
+			if !ok {
+				return nil, fmt.Errorf("requirement %d: repo must be a repository name", i)
+			}
+			if _, err := config.ResolveRepo(name); err != nil {
+				return nil, fmt.Errorf("requirement %d: %w", i, err)
+			}
+		}
 	}
 
 	// Check for maintenance flag (optional, defaults to false)
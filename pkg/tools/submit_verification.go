@@ -41,7 +41,7 @@ func (s *SubmitVerificationTool) Definition() ToolDefinition {
 							"method": {
 								Type:        "string",
 								Description: "How the criterion was verified",
-								Enum:        []string{"command", "inspection"},
+								Enum:        []string{"command", "inspection", "browser"},
 							},
 							"result": {
 								Type:        "string",
@@ -52,6 +52,15 @@ func (s *SubmitVerificationTool) Definition() ToolDefinition {
 								Type:        "string",
 								Description: "Specific evidence supporting the result (file paths, command output, etc.)",
 							},
+							"screenshots": {
+								Type:        "array",
+								Description: "Names of browser screenshots showing this criterion (e.g. shot-01.png)",
+								Items:       &Property{Type: "string"},
+							},
+							"requirement_id": {
+								Type:        "string",
+								Description: "Spec requirement ID (e.g. R-001) this criterion belongs to, from the story's Spec Requirements",
+							},
 						},
 						Required: []string{"criterion", "method", "result", "evidence"},
 					},
@@ -83,7 +92,9 @@ func (s *SubmitVerificationTool) Definition() ToolDefinition {
 func (s *SubmitVerificationTool) PromptDocumentation() string {
 	return `- **submit_verification** - Submit acceptance-criteria verification evidence
   - Parameters: acceptance_criteria_checked (required array), confidence (required), summary (required), gaps (optional)
-  - Each criterion needs: criterion, method (command|inspection), result (pass|fail|partial|unverified), evidence
+  - Each criterion needs: criterion, method (command|inspection|browser), result (pass|fail|partial|unverified), evidence
+  - Criteria checked in the browser can cite screenshots by name (screenshots: ["shot-01.png"])
+  - When the story lists Spec Requirements, tag each criterion with the requirement it belongs to (requirement_id: "R-001")
   - Call this after inspecting the implementation against each acceptance criterion`
 }
 
@@ -140,10 +151,18 @@ func (s *SubmitVerificationTool) Exec(_ context.Context, args map[string]any) (*
 		}
 
 		// Convert map[string]string → map[string]any for consumer compatibility
-		criterionMap := make(map[string]any, len(validated))
+		criterionMap := make(map[string]any, len(validated)+1)
 		for k, v := range validated {
 			criterionMap[k] = v
 		}
+		if shots, err := extractScreenshotRefs(i, criterion); err != nil {
+			return nil, err
+		} else if len(shots) > 0 {
+			criterionMap["screenshots"] = shots
+		}
+		if reqID, ok := criterion["requirement_id"].(string); ok && reqID != "" {
+			criterionMap["requirement_id"] = reqID
+		}
 		validatedCriteria = append(validatedCriteria, criterionMap)
 	}
 
@@ -186,7 +205,29 @@ func (s *SubmitVerificationTool) Exec(_ context.Context, args map[string]any) (*
 }
 
 func isValidMethod(s string) bool {
-	return s == "command" || s == "inspection"
+	return s == "command" || s == "inspection" || s == "browser"
+}
+
+// extractScreenshotRefs returns a criterion's optional screenshot names as
+// []any, the shape consumers see after a JSON round-trip.
+func extractScreenshotRefs(index int, criterion map[string]any) ([]any, error) {
+	raw, ok := criterion["screenshots"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	arr, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("acceptance_criteria_checked item %d: screenshots must be an array of names", index)
+	}
+	refs := make([]any, 0, len(arr))
+	for _, ref := range arr {
+		name, ok := ref.(string)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("acceptance_criteria_checked item %d: screenshots must be an array of names", index)
+		}
+		refs = append(refs, name)
+	}
+	return refs, nil
 }
 
 func isValidResult(s string) bool {
@@ -208,7 +249,7 @@ func validateCriterion(index int, criterion map[string]any) (map[string]string,
 
 	method, ok := criterion["method"].(string)
 	if !ok || !isValidMethod(method) {
-		return nil, fmt.Errorf("%s: method must be 'command' or 'inspection'", prefix)
+		return nil, fmt.Errorf("%s: method must be 'command', 'inspection' or 'browser'", prefix)
 	}
 
 	result, ok := criterion["result"].(string)
@@ -225,6 +225,71 @@ func TestSubmitVerification_InvalidMethod(t *testing.T) {
 	}
 }
 
+func TestSubmitVerification_BrowserScreenshots(t *testing.T) {
+	tool := NewSubmitVerificationTool()
+
+	args := map[string]any{
+		"acceptance_criteria_checked": []any{
+			map[string]any{
+				"criterion":   "Login form shows an error for a wrong password",
+				"method":      "browser",
+				"result":      "pass",
+				"evidence":    "Submitted a wrong password; the banner read 'Invalid credentials'",
+				"screenshots": []any{"shot-01.png"},
+			},
+		},
+		"confidence": "high",
+		"summary":    "done",
+	}
+
+	result, err := tool.Exec(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	data, _ := result.ProcessEffect.Data.(map[string]any)
+	criteria, _ := data["acceptance_criteria_checked"].([]any)
+	if len(criteria) != 1 {
+		t.Fatalf("Expected 1 criterion, got %d", len(criteria))
+	}
+	shots, _ := criteria[0].(map[string]any)["screenshots"].([]any)
+	if len(shots) != 1 || shots[0] != "shot-01.png" {
+		t.Errorf("Expected screenshots [shot-01.png], got %v", shots)
+	}
+
+	args["acceptance_criteria_checked"] = []any{
+		map[string]any{"criterion": "A", "method": "browser", "result": "pass", "evidence": "ok", "screenshots": "shot-01.png"},
+	}
+	if _, err := tool.Exec(context.Background(), args); err == nil {
+		t.Fatal("Expected error for screenshots that are not an array")
+	}
+}
+
+func TestSubmitVerification_RequirementID(t *testing.T) {
+	tool := NewSubmitVerificationTool()
+
+	args := map[string]any{
+		"acceptance_criteria_checked": []any{
+			map[string]any{"criterion": "A", "method": "command", "result": "pass", "evidence": "ok", "requirement_id": "R-002"},
+			map[string]any{"criterion": "B", "method": "command", "result": "pass", "evidence": "ok"},
+		},
+		"confidence": "high",
+		"summary":    "done",
+	}
+
+	result, err := tool.Exec(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	data, _ := result.ProcessEffect.Data.(map[string]any)
+	criteria, _ := data["acceptance_criteria_checked"].([]any)
+	if got := criteria[0].(map[string]any)["requirement_id"]; got != "R-002" {
+		t.Errorf("Expected requirement_id R-002, got %v", got)
+	}
+	if _, ok := criteria[1].(map[string]any)["requirement_id"]; ok {
+		t.Error("Expected no requirement_id for an untagged criterion")
+	}
+}
+
 func TestSubmitVerification_InvalidResult(t *testing.T) {
 	tool := NewSubmitVerificationTool()
 
@@ -7,9 +7,13 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"orchestrator/pkg/config"
+	"orchestrator/pkg/logx"
+	"orchestrator/pkg/persistence"
 )
 
 // ToolWebSearch is the constant name for the web search tool.
@@ -23,7 +27,7 @@ type SearchResult struct {
 }
 
 // SearchProvider defines the interface for web search backends.
-// Implementations can use Google Custom Search, Brave Search, Bing, etc.
+// Implementations cover Google Custom Search, Brave Search, Bing and SearXNG.
 type SearchProvider interface {
 	// Name returns a human-readable name for the provider.
 	Name() string
@@ -31,45 +35,87 @@ type SearchProvider interface {
 	Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error)
 }
 
+// SearchCache stores a session's search answers. Answers are kept as the
+// JSON of the provider's unfiltered results, so a change to the domain
+// lists applies to cached answers too.
+type SearchCache interface {
+	GetCachedSearch(provider, query string, maxResults int) (string, bool, error)
+	StoreSearchResults(provider, query string, maxResults int, resultsJSON string) error
+	// CountSearchQueries returns how many queries a provider has answered
+	// this session, which is what search.max_queries_per_session bounds.
+	CountSearchQueries() (int, error)
+}
+
 // WebSearchTool allows agents to search the web for information.
 // Useful for finding current information about APIs, libraries, versions,
 // and other technical details that may be beyond the LLM's training cutoff.
 type WebSearchTool struct {
-	provider   SearchProvider
-	maxResults int
+	provider     SearchProvider
+	cache        SearchCache // nil: the session database, or memCache without one
+	memCache     *memorySearchCache
+	allowDomains []string
+	denyDomains  []string
+	maxQueries   int
+	maxResults   int
 }
 
 // NewWebSearchTool creates a new web search tool.
 // It automatically selects the best available provider based on environment configuration.
 func NewWebSearchTool() *WebSearchTool {
-	return &WebSearchTool{
-		provider:   selectProvider(),
-		maxResults: 5,
-	}
+	return NewWebSearchToolWithProvider(selectProvider())
 }
 
 // NewWebSearchToolWithProvider creates a web search tool with a specific provider.
 // Useful for testing or when you want to override the default provider selection.
 func NewWebSearchToolWithProvider(provider SearchProvider) *WebSearchTool {
-	return &WebSearchTool{
+	tool := &WebSearchTool{
 		provider:   provider,
 		maxResults: 5,
+		memCache:   newMemorySearchCache(),
 	}
+	if cfg, err := config.GetConfig(); err == nil && cfg.Search != nil {
+		tool.maxQueries = cfg.Search.MaxQueriesPerSession
+		tool.allowDomains = normalizeDomains(cfg.Search.AllowDomains)
+		tool.denyDomains = normalizeDomains(cfg.Search.DenyDomains)
+	}
+	return tool
 }
 
 // selectProvider chooses the best available search provider.
-// Priority: Google Custom Search > DuckDuckGo (fallback).
+// Priority: whatever config.DetectSearchAPIs settles on > DuckDuckGo (fallback).
 func selectProvider() SearchProvider {
 	// Use config package to detect available search APIs
 	status := config.DetectSearchAPIs()
-	if status.Available && status.Provider == config.SearchProviderGoogle {
-		return NewGoogleSearchProvider(status.GoogleAPIKey, status.GoogleCX)
+	if status.Available {
+		switch status.Provider {
+		case config.SearchProviderGoogle:
+			return NewGoogleSearchProvider(status.GoogleAPIKey, status.GoogleCX)
+		case config.SearchProviderBrave:
+			return NewBraveSearchProvider(status.BraveAPIKey)
+		case config.SearchProviderBing:
+			return NewBingSearchProvider(status.BingAPIKey)
+		case config.SearchProviderSearXNG:
+			return NewSearXNGProvider(status.SearXNGURL)
+		}
 	}
 
 	// Fall back to DuckDuckGo (limited functionality)
 	return NewDuckDuckGoProvider()
 }
 
+// searchCache returns where this call's answers are cached. The session
+// database is shared by every agent, so one agent's search answers the
+// same query from another; without a database each tool keeps its own.
+func (t *WebSearchTool) searchCache() SearchCache {
+	if t.cache != nil {
+		return t.cache
+	}
+	if persistence.IsInitialized() {
+		return persistence.Ops()
+	}
+	return t.memCache
+}
+
 // Name returns the tool name.
 func (t *WebSearchTool) Name() string {
 	return ToolWebSearch
@@ -114,12 +160,17 @@ func (t *WebSearchTool) Exec(ctx context.Context, args map[string]any) (*ExecRes
 	if !ok || query == "" {
 		return nil, fmt.Errorf("query is required and must be a string")
 	}
+	// Collapse whitespace so trivially different spellings share a cache entry
+	query = strings.Join(strings.Fields(query), " ")
+	if query == "" {
+		return nil, fmt.Errorf("query is required and must be a string")
+	}
 
-	// Perform search using the configured provider
-	results, err := t.provider.Search(ctx, query, t.maxResults)
+	results, cached, err := t.search(ctx, query)
 	if err != nil {
-		return t.errorResult(fmt.Sprintf("search failed: %v", err))
+		return t.errorResult(err.Error())
 	}
+	results, filtered := t.filterDomains(results)
 
 	// Build response
 	response := map[string]any{
@@ -128,11 +179,19 @@ func (t *WebSearchTool) Exec(ctx context.Context, args map[string]any) (*ExecRes
 		"provider":     t.provider.Name(),
 		"result_count": len(results),
 		"results":      results,
+		"cached":       cached,
+	}
+	if filtered > 0 {
+		response["filtered_count"] = filtered
 	}
 
 	// Add note if no results found
 	if len(results) == 0 {
-		response["note"] = "No results found. Try a different search query or rephrase your question."
+		if filtered > 0 {
+			response["note"] = "All results were on domains excluded by the search configuration. Try a different search query."
+		} else {
+			response["note"] = "No results found. Try a different search query or rephrase your question."
+		}
 	}
 
 	content, err := json.Marshal(response)
@@ -143,6 +202,133 @@ func (t *WebSearchTool) Exec(ctx context.Context, args map[string]any) (*ExecRes
 	return &ExecResult{Content: string(content)}, nil
 }
 
+// search answers query from the session cache, or from the provider while
+// the session's quota lasts. Cache failures are logged and searched past:
+// the cache saves money, and losing it should not cost the agent its search.
+func (t *WebSearchTool) search(ctx context.Context, query string) ([]SearchResult, bool, error) {
+	cache := t.searchCache()
+	name := t.provider.Name()
+
+	raw, hit, err := cache.GetCachedSearch(name, query, t.maxResults)
+	if err != nil {
+		logx.Warnf("web_search: cache lookup failed, searching anyway: %v", err)
+	}
+	if hit {
+		var results []SearchResult
+		if err := json.Unmarshal([]byte(raw), &results); err == nil {
+			return results, true, nil
+		}
+		logx.Warnf("web_search: discarding unreadable cache entry for %q", query)
+	}
+
+	if t.maxQueries > 0 {
+		used, countErr := cache.CountSearchQueries()
+		if countErr != nil {
+			logx.Warnf("web_search: cannot count this session's searches, not enforcing the quota: %v", countErr)
+		} else if used >= t.maxQueries {
+			return nil, false, fmt.Errorf("search quota exhausted: %d of %d queries used this session "+
+				"(search.max_queries_per_session); work with the results already gathered", used, t.maxQueries)
+		}
+	}
+
+	// Perform search using the configured provider
+	results, err := t.provider.Search(ctx, query, t.maxResults)
+	if err != nil {
+		return nil, false, fmt.Errorf("search failed: %w", err)
+	}
+
+	if encoded, err := json.Marshal(results); err == nil {
+		if err := cache.StoreSearchResults(name, query, t.maxResults, string(encoded)); err != nil {
+			logx.Warnf("web_search: failed to cache results: %v", err)
+		}
+	}
+	return results, false, nil
+}
+
+// filterDomains applies the search configuration's domain lists, returning
+// the results kept and how many were dropped. With an allow list, a result
+// without a parseable host is dropped: it cannot be shown to be allowed.
+func (t *WebSearchTool) filterDomains(results []SearchResult) ([]SearchResult, int) {
+	if len(t.allowDomains) == 0 && len(t.denyDomains) == 0 {
+		return results, 0
+	}
+	kept := make([]SearchResult, 0, len(results))
+	for i := range results {
+		host := ""
+		if parsed, err := url.Parse(results[i].URL); err == nil {
+			host = strings.ToLower(parsed.Hostname())
+		}
+		if host != "" && matchesDomain(host, t.denyDomains) {
+			continue
+		}
+		if len(t.allowDomains) > 0 && (host == "" || !matchesDomain(host, t.allowDomains)) {
+			continue
+		}
+		kept = append(kept, results[i])
+	}
+	return kept, len(results) - len(kept)
+}
+
+// matchesDomain reports whether host is one of domains or a subdomain of one.
+func matchesDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDomains lower-cases the configured domains and drops a leading
+// dot, so ".example.com" and "Example.com" both mean example.com.
+func normalizeDomains(domains []string) []string {
+	if len(domains) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		if domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "."); domain != "" {
+			out = append(out, domain)
+		}
+	}
+	return out
+}
+
+// memorySearchCache is the SearchCache for a tool running without a session
+// database, e.g. in tests and one-off tool runs.
+type memorySearchCache struct {
+	entries map[string]string
+	mu      sync.Mutex
+}
+
+func newMemorySearchCache() *memorySearchCache {
+	return &memorySearchCache{entries: make(map[string]string)}
+}
+
+func memorySearchKey(provider, query string, maxResults int) string {
+	return fmt.Sprintf("%s\x00%s\x00%d", provider, query, maxResults)
+}
+
+func (c *memorySearchCache) GetCachedSearch(provider, query string, maxResults int) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resultsJSON, ok := c.entries[memorySearchKey(provider, query, maxResults)]
+	return resultsJSON, ok, nil
+}
+
+func (c *memorySearchCache) StoreSearchResults(provider, query string, maxResults int, resultsJSON string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[memorySearchKey(provider, query, maxResults)] = resultsJSON
+	return nil
+}
+
+func (c *memorySearchCache) CountSearchQueries() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries), nil
+}
+
 // errorResult creates an error result response.
 func (t *WebSearchTool) errorResult(errMsg string) (*ExecResult, error) {
 	response := map[string]any{
@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The providers below use http.Client's default transport, which honours
+// HTTPS_PROXY/HTTP_PROXY/NO_PROXY, so they work behind a corporate proxy
+// with nothing configured here.
+
+// getSearchJSON performs a GET and decodes a JSON answer into out. A non-2xx
+// status is an error carrying the start of the body, which is where every
+// provider below puts its reason.
+func getSearchJSON(ctx context.Context, client *http.Client, searchURL string, header http.Header, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, searchURL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		snippet := strings.TrimSpace(string(body))
+		if len(snippet) > 300 {
+			snippet = snippet[:300] + "…"
+		}
+		return fmt.Errorf("API error %d: %s", resp.StatusCode, snippet)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// =============================================================================
+// Brave Search Provider
+// =============================================================================
+
+// BraveSearchProvider implements SearchProvider using the Brave Search API.
+type BraveSearchProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	endpoint   string
+}
+
+// NewBraveSearchProvider creates a new Brave Search provider.
+func NewBraveSearchProvider(apiKey string) *BraveSearchProvider {
+	return &BraveSearchProvider{
+		apiKey:     apiKey,
+		endpoint:   "https://api.search.brave.com/res/v1/web/search",
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the provider name.
+func (p *BraveSearchProvider) Name() string {
+	return "brave"
+}
+
+// braveSearchResponse represents the part of a Brave Search response we use.
+type braveSearchResponse struct {
+	Web struct {
+		Results []struct {
+			Title       string `json:"title"`
+			URL         string `json:"url"`
+			Description string `json:"description"`
+		} `json:"results"`
+	} `json:"web"`
+}
+
+// Search performs a web search using the Brave Search API.
+func (p *BraveSearchProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	// API docs: https://api-dashboard.search.brave.com/app/documentation/web-search/get-started
+	searchURL := p.endpoint + "?" + url.Values{"q": {query}, "count": {strconv.Itoa(maxResults)}}.Encode()
+	header := http.Header{"Accept": {"application/json"}, "X-Subscription-Token": {p.apiKey}}
+
+	var braveResp braveSearchResponse
+	if err := getSearchJSON(ctx, p.httpClient, searchURL, header, &braveResp); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(braveResp.Web.Results))
+	for i := range braveResp.Web.Results {
+		item := &braveResp.Web.Results[i]
+		results = append(results, SearchResult{Title: item.Title, Description: item.Description, URL: item.URL})
+	}
+	return capResults(results, maxResults), nil
+}
+
+// =============================================================================
+// Bing Web Search Provider
+// =============================================================================
+
+// BingSearchProvider implements SearchProvider using the Bing Web Search API.
+type BingSearchProvider struct {
+	httpClient *http.Client
+	apiKey     string
+	endpoint   string
+}
+
+// NewBingSearchProvider creates a new Bing Web Search provider.
+func NewBingSearchProvider(apiKey string) *BingSearchProvider {
+	return &BingSearchProvider{
+		apiKey:     apiKey,
+		endpoint:   "https://api.bing.microsoft.com/v7.0/search",
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the provider name.
+func (p *BingSearchProvider) Name() string {
+	return "bing"
+}
+
+// bingSearchResponse represents the part of a Bing Web Search response we use.
+type bingSearchResponse struct {
+	WebPages struct {
+		Value []struct {
+			Name    string `json:"name"`
+			URL     string `json:"url"`
+			Snippet string `json:"snippet"`
+		} `json:"value"`
+	} `json:"webPages"`
+}
+
+// Search performs a web search using the Bing Web Search API.
+func (p *BingSearchProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	// API docs: https://learn.microsoft.com/en-us/bing/search-apis/bing-web-search/reference/endpoints
+	searchURL := p.endpoint + "?" + url.Values{
+		"q": {query}, "count": {strconv.Itoa(maxResults)}, "responseFilter": {"Webpages"},
+	}.Encode()
+	header := http.Header{"Ocp-Apim-Subscription-Key": {p.apiKey}}
+
+	var bingResp bingSearchResponse
+	if err := getSearchJSON(ctx, p.httpClient, searchURL, header, &bingResp); err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(bingResp.WebPages.Value))
+	for i := range bingResp.WebPages.Value {
+		item := &bingResp.WebPages.Value[i]
+		results = append(results, SearchResult{Title: item.Name, Description: item.Snippet, URL: item.URL})
+	}
+	return capResults(results, maxResults), nil
+}
+
+// =============================================================================
+// SearXNG Provider (self-hosted)
+// =============================================================================
+
+// SearXNGProvider implements SearchProvider using a SearXNG instance's JSON
+// API. It is the provider for airplane mode and for networks that cannot
+// reach a hosted search API: the instance is whatever the team runs.
+//
+// The instance must have "json" in search.formats in its settings.yml; a
+// default install answers format=json with 403.
+type SearXNGProvider struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewSearXNGProvider creates a provider for the SearXNG instance at baseURL.
+func NewSearXNGProvider(baseURL string) *SearXNGProvider {
+	return &SearXNGProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Name returns the provider name.
+func (p *SearXNGProvider) Name() string {
+	return "searxng"
+}
+
+// searXNGResponse represents the part of a SearXNG JSON response we use.
+type searXNGResponse struct {
+	Results []struct {
+		Title   string `json:"title"`
+		URL     string `json:"url"`
+		Content string `json:"content"`
+	} `json:"results"`
+}
+
+// Search performs a web search through the SearXNG instance.
+func (p *SearXNGProvider) Search(ctx context.Context, query string, maxResults int) ([]SearchResult, error) {
+	searchURL := p.baseURL + "/search?" + url.Values{"q": {query}, "format": {"json"}}.Encode()
+
+	var searxResp searXNGResponse
+	err := getSearchJSON(ctx, p.httpClient, searchURL, http.Header{"Accept": {"application/json"}}, &searxResp)
+	if err != nil {
+		if strings.Contains(err.Error(), "API error 403") {
+			return nil, fmt.Errorf("%w (enable the json format in the instance's search.formats)", err)
+		}
+		return nil, err
+	}
+
+	results := make([]SearchResult, 0, len(searxResp.Results))
+	for i := range searxResp.Results {
+		item := &searxResp.Results[i]
+		results = append(results, SearchResult{Title: item.Title, Description: item.Content, URL: item.URL})
+	}
+	return capResults(results, maxResults), nil
+}
+
+// capResults trims results to maxResults; SearXNG has no count parameter,
+// and the others treat theirs as a hint.
+func capResults(results []SearchResult, maxResults int) []SearchResult {
+	if maxResults > 0 && len(results) > maxResults {
+		return results[:maxResults]
+	}
+	return results
+}
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// searchServer answers every request with body and records the last one.
+func searchServer(t *testing.T, status int, body string, last **http.Request) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*last = r
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSearXNGProvider_Search(t *testing.T) {
+	var req *http.Request
+	server := searchServer(t, http.StatusOK, `{"results":[
+		{"title":"Go","url":"https://go.dev/","content":"The Go language"},
+		{"title":"Tour","url":"https://go.dev/tour","content":"A tour"},
+		{"title":"Blog","url":"https://go.dev/blog","content":"News"}]}`, &req)
+
+	results, err := NewSearXNGProvider(server.URL+"/").Search(context.Background(), "golang generics", 2)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if req.URL.Path != "/search" || req.URL.Query().Get("format") != "json" || req.URL.Query().Get("q") != "golang generics" {
+		t.Errorf("request = %s, want /search?format=json&q=...", req.URL)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2 (capped)", len(results))
+	}
+	if results[0] != (SearchResult{Title: "Go", Description: "The Go language", URL: "https://go.dev/"}) {
+		t.Errorf("results[0] = %+v", results[0])
+	}
+}
+
+func TestSearXNGProvider_JSONFormatDisabled(t *testing.T) {
+	var req *http.Request
+	server := searchServer(t, http.StatusForbidden, "Forbidden", &req)
+
+	_, err := NewSearXNGProvider(server.URL).Search(context.Background(), "q", 5)
+	if err == nil || !strings.Contains(err.Error(), "search.formats") {
+		t.Errorf("error = %v, want a hint about search.formats", err)
+	}
+}
+
+func TestBraveSearchProvider_Search(t *testing.T) {
+	var req *http.Request
+	server := searchServer(t, http.StatusOK,
+		`{"web":{"results":[{"title":"Go","url":"https://go.dev/","description":"The Go language"}]}}`, &req)
+
+	provider := NewBraveSearchProvider("brave-token")
+	provider.endpoint = server.URL
+	results, err := provider.Search(context.Background(), "golang", 5)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := req.Header.Get("X-Subscription-Token"); got != "brave-token" {
+		t.Errorf("X-Subscription-Token = %q", got)
+	}
+	if req.URL.Query().Get("count") != "5" {
+		t.Errorf("count = %q, want 5", req.URL.Query().Get("count"))
+	}
+	if len(results) != 1 || results[0].Description != "The Go language" {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestBingSearchProvider_Search(t *testing.T) {
+	var req *http.Request
+	server := searchServer(t, http.StatusOK,
+		`{"webPages":{"value":[{"name":"Go","url":"https://go.dev/","snippet":"The Go language"}]}}`, &req)
+
+	provider := NewBingSearchProvider("bing-key")
+	provider.endpoint = server.URL
+	results, err := provider.Search(context.Background(), "golang", 5)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := req.Header.Get("Ocp-Apim-Subscription-Key"); got != "bing-key" {
+		t.Errorf("Ocp-Apim-Subscription-Key = %q", got)
+	}
+	if len(results) != 1 || results[0] != (SearchResult{Title: "Go", Description: "The Go language", URL: "https://go.dev/"}) {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestBingSearchProvider_APIError(t *testing.T) {
+	var req *http.Request
+	server := searchServer(t, http.StatusUnauthorized, `{"error":{"code":"401","message":"bad key"}}`, &req)
+
+	provider := NewBingSearchProvider("bad")
+	provider.endpoint = server.URL
+	if _, err := provider.Search(context.Background(), "golang", 5); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("error = %v, want the status", err)
+	}
+}
@@ -170,6 +170,9 @@ func TestSearchProviderInterface(_ *testing.T) {
 	// Test that our providers implement the interface
 	var _ SearchProvider = &GoogleSearchProvider{}
 	var _ SearchProvider = &DuckDuckGoProvider{}
+	var _ SearchProvider = &BraveSearchProvider{}
+	var _ SearchProvider = &BingSearchProvider{}
+	var _ SearchProvider = &SearXNGProvider{}
 	var _ SearchProvider = &MockSearchProvider{}
 }
 
@@ -201,3 +204,121 @@ func containsSubstring(str, substr string) bool {
 	}
 	return false
 }
+
+// countingProvider records how many searches reached it.
+type countingProvider struct {
+	results []SearchResult
+	calls   int
+}
+
+func (p *countingProvider) Name() string { return "counting" }
+
+func (p *countingProvider) Search(_ context.Context, _ string, _ int) ([]SearchResult, error) {
+	p.calls++
+	return p.results, nil
+}
+
+func execSearch(t *testing.T, tool *WebSearchTool, query string) map[string]any {
+	t.Helper()
+	result, err := tool.Exec(context.Background(), map[string]any{"query": query})
+	if err != nil {
+		t.Fatalf("Exec(%q) error = %v", query, err)
+	}
+	var response map[string]any
+	if err := json.Unmarshal([]byte(result.Content), &response); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	return response
+}
+
+func TestWebSearchTool_CachesPerQuery(t *testing.T) {
+	provider := &countingProvider{results: []SearchResult{{Title: "Go", URL: "https://go.dev/"}}}
+	tool := NewWebSearchToolWithProvider(provider)
+	tool.cache = newMemorySearchCache()
+
+	if first := execSearch(t, tool, "go release notes"); first["cached"] != false {
+		t.Errorf("first search cached = %v, want false", first["cached"])
+	}
+	second := execSearch(t, tool, "  go   release notes ")
+	if second["cached"] != true {
+		t.Errorf("repeated search cached = %v, want true", second["cached"])
+	}
+	if count, _ := second["result_count"].(float64); count != 1 {
+		t.Errorf("cached result_count = %v, want 1", second["result_count"])
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+}
+
+func TestWebSearchTool_QuotaCountsProviderQueriesOnly(t *testing.T) {
+	provider := &countingProvider{results: []SearchResult{{Title: "Go", URL: "https://go.dev/"}}}
+	tool := NewWebSearchToolWithProvider(provider)
+	tool.cache = newMemorySearchCache()
+	tool.maxQueries = 1
+
+	execSearch(t, tool, "first")
+	if again := execSearch(t, tool, "first"); again["success"] != true {
+		t.Errorf("cached query refused under quota: %v", again)
+	}
+	refused := execSearch(t, tool, "second")
+	if refused["success"] != false {
+		t.Fatalf("query over quota succeeded: %v", refused)
+	}
+	if msg, _ := refused["error"].(string); !containsString(msg, "quota exhausted") {
+		t.Errorf("error = %q, want a quota message", msg)
+	}
+	if provider.calls != 1 {
+		t.Errorf("provider called %d times, want 1", provider.calls)
+	}
+}
+
+func TestWebSearchTool_DomainLists(t *testing.T) {
+	results := []SearchResult{
+		{Title: "docs", URL: "https://pkg.go.dev/net/http"},
+		{Title: "blog", URL: "https://go.dev/blog"},
+		{Title: "spam", URL: "https://ads.go.dev/x"},
+		{Title: "other", URL: "https://example.com/"},
+		{Title: "answer", URL: ""},
+	}
+
+	tests := []struct {
+		name  string
+		allow []string
+		deny  []string
+		want  []string
+	}{
+		{"no lists", nil, nil, []string{"docs", "blog", "spam", "other", "answer"}},
+		{"deny subdomain", nil, []string{"ads.go.dev"}, []string{"docs", "blog", "other", "answer"}},
+		{"allow with subdomains", []string{"go.dev"}, nil, []string{"docs", "blog", "spam"}},
+		{"deny wins over allow", []string{"go.dev"}, []string{"ads.go.dev"}, []string{"docs", "blog"}},
+		{"normalized entries", []string{".GO.dev"}, nil, []string{"docs", "blog", "spam"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool := NewWebSearchToolWithProvider(&MockSearchProvider{name: "mock", results: results})
+			tool.cache = newMemorySearchCache()
+			tool.allowDomains = normalizeDomains(tt.allow)
+			tool.denyDomains = normalizeDomains(tt.deny)
+
+			response := execSearch(t, tool, "query")
+			got, _ := response["results"].([]any)
+			var titles []string
+			for _, r := range got {
+				titles = append(titles, r.(map[string]any)["title"].(string))
+			}
+			if len(titles) != len(tt.want) {
+				t.Fatalf("results = %v, want %v", titles, tt.want)
+			}
+			for i := range titles {
+				if titles[i] != tt.want[i] {
+					t.Fatalf("results = %v, want %v", titles, tt.want)
+				}
+			}
+			dropped := len(results) - len(tt.want)
+			if filtered, _ := response["filtered_count"].(float64); int(filtered) != dropped {
+				t.Errorf("filtered_count = %v, want %d", response["filtered_count"], dropped)
+			}
+		})
+	}
+}
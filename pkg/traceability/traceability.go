@@ -0,0 +1,326 @@
+// Package traceability links spec requirements to the stories that implement
+// them, the pull requests and merge commits those stories produced, and the
+// acceptance-criteria verification recorded when each story merged.
+package traceability
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/specs"
+)
+
+// Status is the traceability status of a requirement.
+type Status string
+
+const (
+	// StatusVerified means every covering story merged and its verification passed.
+	StatusVerified Status = "verified"
+	// StatusFailed means a covering story failed or a criterion failed verification.
+	StatusFailed Status = "failed"
+	// StatusUnverified means the covering stories are done but verification is missing or partial.
+	StatusUnverified Status = "unverified"
+	// StatusInProgress means at least one covering story is not done yet.
+	StatusInProgress Status = "in_progress"
+	// StatusUncovered means no story covers the requirement.
+	StatusUncovered Status = "uncovered"
+)
+
+// Criterion result values reported by submit_verification.
+const (
+	ResultPass       = "pass"
+	ResultFail       = "fail"
+	ResultPartial    = "partial"
+	ResultUnverified = "unverified"
+)
+
+// Verification is a story's acceptance-criteria verification, recorded on the
+// story when it merges. persistence.Story.Verification holds it as JSON.
+type Verification struct {
+	Status   string            `json:"status"` // pass, fail, or unavailable
+	Criteria []CriterionResult `json:"criteria,omitempty"`
+}
+
+// CriterionResult is the verification result of one acceptance criterion.
+type CriterionResult struct {
+	Criterion     string `json:"criterion"`
+	RequirementID string `json:"requirement_id,omitempty"` // Spec requirement (R-001) the criterion belongs to
+	Result        string `json:"result"`                   // pass, fail, partial, or unverified
+	Method        string `json:"method,omitempty"`         // command, inspection, or browser
+}
+
+// ParseVerification decodes a story's verification JSON.
+// Returns nil when the story has no (or unreadable) verification.
+func ParseVerification(raw string) *Verification {
+	if raw == "" {
+		return nil
+	}
+	var v Verification
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return nil
+	}
+	return &v
+}
+
+// StoryLink is a story covering a requirement, with the PR it merged through.
+type StoryLink struct {
+	ID           string `json:"id"`
+	Title        string `json:"title"`
+	Status       string `json:"status"`
+	PRID         string `json:"pr_id,omitempty"`
+	CommitHash   string `json:"commit_hash,omitempty"`
+	Verification string `json:"verification,omitempty"` // Overall verification status of the story
+}
+
+// RequirementTrace is one row of the traceability matrix.
+type RequirementTrace struct {
+	ID       string            `json:"id"`
+	Title    string            `json:"title"`
+	Status   Status            `json:"status"`
+	Stories  []StoryLink       `json:"stories"`
+	Criteria []CriterionResult `json:"criteria,omitempty"` // Verified criteria attributed to this requirement
+}
+
+// Report is the traceability matrix of one spec.
+type Report struct {
+	SpecID       string             `json:"spec_id"`
+	Version      int                `json:"version,omitempty"`
+	Requirements []RequirementTrace `json:"requirements"`
+	Uncovered    []string           `json:"uncovered,omitempty"` // Requirement IDs no story covers
+	Untraced     []StoryLink        `json:"untraced,omitempty"`  // Spec stories that cover no known requirement
+}
+
+// Build assembles the traceability matrix of a spec from its requirements and stories.
+// Stories of other specs are ignored.
+func Build(specID string, requirements []specs.Requirement, stories []*persistence.Story) *Report {
+	report := &Report{SpecID: specID, Requirements: make([]RequirementTrace, 0, len(requirements))}
+
+	known := make(map[string]bool, len(requirements))
+	for i := range requirements {
+		known[requirements[i].ID] = true
+	}
+
+	for i := range requirements {
+		req := &requirements[i]
+		trace := RequirementTrace{ID: req.ID, Title: req.Title, Stories: []StoryLink{}}
+		var verifications []*Verification
+		for _, story := range stories {
+			if story.SpecID != specID || !covers(story, req.ID) {
+				continue
+			}
+			verification := ParseVerification(story.Verification)
+			trace.Stories = append(trace.Stories, link(story, verification))
+			verifications = append(verifications, verification)
+			if verification != nil {
+				for _, c := range verification.Criteria {
+					if c.RequirementID == req.ID {
+						trace.Criteria = append(trace.Criteria, c)
+					}
+				}
+			}
+		}
+		trace.Status = requirementStatus(&trace, verifications)
+		if trace.Status == StatusUncovered {
+			report.Uncovered = append(report.Uncovered, req.ID)
+		}
+		report.Requirements = append(report.Requirements, trace)
+	}
+
+	for _, story := range stories {
+		if story.SpecID != specID {
+			continue
+		}
+		traced := false
+		for _, id := range story.RequirementIDs {
+			traced = traced || known[id]
+		}
+		if !traced {
+			report.Untraced = append(report.Untraced, link(story, ParseVerification(story.Verification)))
+		}
+	}
+
+	return report
+}
+
+// Complete reports whether every requirement is covered by at least one story.
+func (r *Report) Complete() bool {
+	return len(r.Uncovered) == 0
+}
+
+func covers(story *persistence.Story, requirementID string) bool {
+	for _, id := range story.RequirementIDs {
+		if id == requirementID {
+			return true
+		}
+	}
+	return false
+}
+
+func link(story *persistence.Story, verification *Verification) StoryLink {
+	l := StoryLink{ID: story.ID, Title: story.Title, Status: story.Status, PRID: story.PRID, CommitHash: story.CommitHash}
+	if verification != nil {
+		l.Verification = verification.Status
+	}
+	return l
+}
+
+// requirementStatus derives a requirement's status from its covering stories.
+// Criteria attributed to the requirement decide verification when present;
+// otherwise the stories' overall verification does.
+func requirementStatus(trace *RequirementTrace, verifications []*Verification) Status {
+	if len(trace.Stories) == 0 {
+		return StatusUncovered
+	}
+	for _, c := range trace.Criteria {
+		if c.Result == ResultFail {
+			return StatusFailed
+		}
+	}
+	for i := range trace.Stories {
+		switch trace.Stories[i].Status {
+		case persistence.StatusFailed:
+			return StatusFailed
+		case persistence.StatusDone, persistence.StatusSkipped:
+		default:
+			return StatusInProgress
+		}
+	}
+
+	if len(trace.Criteria) > 0 {
+		for _, c := range trace.Criteria {
+			if c.Result != ResultPass {
+				return StatusUnverified
+			}
+		}
+		return StatusVerified
+	}
+	for _, v := range verifications {
+		if v == nil || v.Status != ResultPass {
+			return StatusUnverified
+		}
+	}
+	return StatusVerified
+}
+
+// Markdown renders the report as a markdown document for export.
+func (r *Report) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Traceability: spec %s", r.SpecID)
+	if r.Version > 0 {
+		fmt.Fprintf(&b, " (v%d)", r.Version)
+	}
+	b.WriteString("\n\n")
+
+	if len(r.Uncovered) > 0 {
+		fmt.Fprintf(&b, "> ⚠️ %d requirement(s) are not covered by any story: %s\n\n", len(r.Uncovered), strings.Join(r.Uncovered, ", "))
+	}
+
+	b.WriteString("| Requirement | Status | Stories | PRs | Merge commits | Criteria |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for i := range r.Requirements {
+		req := &r.Requirements[i]
+		var storyCells, prCells, commitCells []string
+		for _, s := range req.Stories {
+			storyCells = append(storyCells, fmt.Sprintf("%s (%s)", s.ID, s.Status))
+			if s.PRID != "" {
+				prCells = append(prCells, s.PRID)
+			}
+			if s.CommitHash != "" {
+				commitCells = append(commitCells, shortSHA(s.CommitHash))
+			}
+		}
+		fmt.Fprintf(&b, "| %s: %s | %s | %s | %s | %s | %s |\n",
+			req.ID, escapeCell(req.Title), statusLabel(req.Status),
+			cell(storyCells), cell(prCells), cell(commitCells), criteriaSummary(req.Criteria))
+	}
+
+	var details strings.Builder
+	for i := range r.Requirements {
+		req := &r.Requirements[i]
+		if len(req.Criteria) == 0 {
+			continue
+		}
+		fmt.Fprintf(&details, "\n### %s: %s\n\n", req.ID, req.Title)
+		for _, c := range req.Criteria {
+			fmt.Fprintf(&details, "- %s %s", resultIcon(c.Result), c.Criterion)
+			if c.Method != "" {
+				fmt.Fprintf(&details, " _(%s)_", c.Method)
+			}
+			details.WriteString("\n")
+		}
+	}
+	if details.Len() > 0 {
+		b.WriteString("\n## Verification by criterion\n")
+		b.WriteString(details.String())
+	}
+
+	if len(r.Untraced) > 0 {
+		b.WriteString("\n## Stories not linked to a requirement\n\n")
+		for _, s := range r.Untraced {
+			fmt.Fprintf(&b, "- %s: %s (%s)\n", s.ID, s.Title, s.Status)
+		}
+	}
+
+	return b.String()
+}
+
+func statusLabel(status Status) string {
+	switch status {
+	case StatusVerified:
+		return "✅ verified"
+	case StatusFailed:
+		return "❌ failed"
+	case StatusUnverified:
+		return "⚠️ unverified"
+	case StatusInProgress:
+		return "🔄 in progress"
+	default:
+		return "⛔ uncovered"
+	}
+}
+
+func resultIcon(result string) string {
+	switch result {
+	case ResultPass:
+		return "✅"
+	case ResultFail:
+		return "❌"
+	case ResultPartial:
+		return "◐"
+	default:
+		return "❔"
+	}
+}
+
+func criteriaSummary(criteria []CriterionResult) string {
+	if len(criteria) == 0 {
+		return "—"
+	}
+	passed := 0
+	for _, c := range criteria {
+		if c.Result == ResultPass {
+			passed++
+		}
+	}
+	return fmt.Sprintf("%d/%d pass", passed, len(criteria))
+}
+
+func cell(values []string) string {
+	if len(values) == 0 {
+		return "—"
+	}
+	return escapeCell(strings.Join(values, ", "))
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
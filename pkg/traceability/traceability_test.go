@@ -0,0 +1,101 @@
+package traceability
+
+import (
+	"strings"
+	"testing"
+
+	"orchestrator/pkg/persistence"
+	"orchestrator/pkg/specs"
+)
+
+var testRequirements = []specs.Requirement{
+	{ID: "R-001", Title: "User Registration"},
+	{ID: "R-002", Title: "User Login"},
+	{ID: "R-003", Title: "Password Reset"},
+	{ID: "R-004", Title: "Audit Log"},
+}
+
+func TestBuild(t *testing.T) {
+	stories := []*persistence.Story{
+		{
+			ID: "s1", SpecID: "spec-1", Title: "Registration API", Status: persistence.StatusDone,
+			PRID: "12", CommitHash: "0123456789abcdef", RequirementIDs: []string{"R-001"},
+			Verification: `{"status":"pass","criteria":[
+				{"criterion":"Email validated","requirement_id":"R-001","result":"pass","method":"command"},
+				{"criterion":"Duplicate email rejected","requirement_id":"R-001","result":"pass","method":"command"}]}`,
+		},
+		{
+			ID: "s2", SpecID: "spec-1", Title: "Login form", Status: persistence.StatusDone,
+			RequirementIDs: []string{"R-002"},
+			Verification:   `{"status":"pass","criteria":[{"criterion":"Lockout after 5 attempts","requirement_id":"R-002","result":"partial"}]}`,
+		},
+		{ID: "s3", SpecID: "spec-1", Title: "Reset email", Status: persistence.StatusCoding, RequirementIDs: []string{"R-003"}},
+		{ID: "s4", SpecID: "spec-1", Title: "Refactor", Status: persistence.StatusDone},
+		{ID: "s5", SpecID: "spec-0", Title: "Bootstrap", Status: persistence.StatusDone, RequirementIDs: []string{"R-004"}},
+	}
+
+	report := Build("spec-1", testRequirements, stories)
+
+	want := map[string]Status{
+		"R-001": StatusVerified,
+		"R-002": StatusUnverified,
+		"R-003": StatusInProgress,
+		"R-004": StatusUncovered, // Covered only by a story of another spec
+	}
+	for _, req := range report.Requirements {
+		if req.Status != want[req.ID] {
+			t.Errorf("%s status = %s, want %s", req.ID, req.Status, want[req.ID])
+		}
+	}
+	if report.Complete() {
+		t.Error("Expected report with an uncovered requirement to be incomplete")
+	}
+	if len(report.Uncovered) != 1 || report.Uncovered[0] != "R-004" {
+		t.Errorf("Expected R-004 uncovered, got %v", report.Uncovered)
+	}
+	if len(report.Untraced) != 1 || report.Untraced[0].ID != "s4" {
+		t.Errorf("Expected s4 untraced, got %+v", report.Untraced)
+	}
+
+	md := report.Markdown()
+	for _, s := range []string{
+		"| R-001: User Registration | ✅ verified | s1 (done) | 12 | 0123456 | 2/2 pass |",
+		"not covered by any story: R-004",
+		"- ◐ Lockout after 5 attempts",
+		"- s4: Refactor (done)",
+	} {
+		if !strings.Contains(md, s) {
+			t.Errorf("Markdown() missing %q:\n%s", s, md)
+		}
+	}
+}
+
+func TestBuild_FailedVerification(t *testing.T) {
+	stories := []*persistence.Story{
+		{ID: "s1", SpecID: "spec-1", Status: persistence.StatusDone, RequirementIDs: []string{"R-001", "R-002"},
+			Verification: `{"status":"fail","criteria":[{"criterion":"Login works","requirement_id":"R-002","result":"fail"}]}`},
+	}
+
+	report := Build("spec-1", testRequirements[:2], stories)
+
+	// R-001 has no criteria of its own, so the story's overall verification decides
+	if got := report.Requirements[0].Status; got != StatusUnverified {
+		t.Errorf("R-001 status = %s, want %s", got, StatusUnverified)
+	}
+	if got := report.Requirements[1].Status; got != StatusFailed {
+		t.Errorf("R-002 status = %s, want %s", got, StatusFailed)
+	}
+	if !report.Complete() {
+		t.Errorf("Expected all requirements covered, uncovered: %v", report.Uncovered)
+	}
+}
+
+func TestParseVerification(t *testing.T) {
+	if ParseVerification("") != nil || ParseVerification("not json") != nil {
+		t.Error("Expected nil for empty or invalid verification")
+	}
+	v := ParseVerification(`{"status":"pass","criteria":[{"criterion":"c","result":"pass"}]}`)
+	if v == nil || v.Status != ResultPass || len(v.Criteria) != 1 {
+		t.Errorf("Unexpected verification: %+v", v)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"orchestrator/pkg/agent"
 	"orchestrator/pkg/chat"
+	"orchestrator/pkg/interviews"
 )
 
 const errDispatcherNotAvailable = "dispatcher not available"
@@ -26,6 +27,13 @@ type PMStartResponse struct {
 	Message   string `json:"message"`
 }
 
+// PMImportIssueRequest represents a request to import a forge issue as PM input.
+type PMImportIssueRequest struct {
+	Issue     string `json:"issue"`               // Issue number, #number, or issue URL
+	Mode      string `json:"mode"`                // "interview" (default) or "draft"
+	Expertise string `json:"expertise,omitempty"` // Used when the import starts the interview
+}
+
 // PMChatRequest represents a message sent to the PM agent.
 // Supports optional file attachment (inline spec upload via paperclip button).
 type PMChatRequest struct {
@@ -43,8 +51,10 @@ type PMChatResponse struct {
 
 // PMPreviewResponse represents a generated spec preview.
 type PMPreviewResponse struct {
-	Markdown string `json:"markdown"`
-	Message  string `json:"message"`
+	Markdown  string             `json:"markdown"`
+	Message   string             `json:"message"`
+	Interview *interviews.Report `json:"interview,omitempty"` // Questionnaire coverage, when the project defines questionnaires
+	Amendment string             `json:"amendment,omitempty"` // Requirement-level diff, when the spec amends the approved spec
 }
 
 // PMStatusResponse represents the current PM agent status.
@@ -147,6 +157,77 @@ func (s *Server) handlePMStart(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handlePMImportIssue implements POST /api/pm/import-issue - Import a forge issue
+// as the starting point of an interview or directly as a spec draft in PREVIEW.
+func (s *Server) handlePMImportIssue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req PMImportIssueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.logger.Warn("Failed to parse PM import issue request: %v", err)
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Issue == "" {
+		http.Error(w, "issue is required", http.StatusBadRequest)
+		return
+	}
+
+	if errCheck := s.checkPMAvailability(); errCheck != nil {
+		s.logger.Warn("PM availability check failed: %v", errCheck)
+		if errCheck.Error() == errDispatcherNotAvailable {
+			http.Error(w, "Dispatcher not available", http.StatusServiceUnavailable)
+		} else {
+			http.Error(w, "PM agent not available", http.StatusConflict)
+		}
+		return
+	}
+
+	pmAgent := s.dispatcher.GetAgent("pm-001")
+	if pmAgent == nil {
+		http.Error(w, "PM agent not found", http.StatusNotFound)
+		return
+	}
+
+	// Type assert to PM driver to access ImportIssue method
+	type IssueImporter interface {
+		ImportIssue(ctx context.Context, ref, mode, expertise string) error
+	}
+
+	pmDriver, ok := pmAgent.(IssueImporter)
+	if !ok {
+		s.logger.Error("PM agent does not implement IssueImporter interface")
+		http.Error(w, "PM agent does not support issue import", http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.Info("Importing issue %s into PM (mode: %s)", req.Issue, req.Mode)
+	ctx, cancel := context.WithTimeout(r.Context(), time.Minute)
+	defer cancel()
+	if err := pmDriver.ImportIssue(ctx, req.Issue, req.Mode, req.Expertise); err != nil {
+		s.logger.Error("Failed to import issue into PM: %v", err)
+		http.Error(w, fmt.Sprintf("Failed to import issue: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	message := "I've read the issue. Let me look at what's still missing before we write the spec."
+	if req.Mode == "draft" {
+		message = "The issue has been imported as a specification draft. Review it in the Preview tab."
+	}
+	response := PMStartResponse{
+		SessionID: fmt.Sprintf("pm_session_%d", time.Now().UnixNano()),
+		Message:   message,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Error("Failed to encode PM import issue response: %v", err)
+	}
+}
+
 // handlePMChat implements POST /api/pm/chat - Send a message to the PM agent.
 // Supports optional file attachment (file_content + file_name fields).
 //
@@ -402,6 +483,22 @@ func (s *Server) handlePMPreviewGet(w http.ResponseWriter, r *http.Request) {
 		Message:  "Specification ready for review",
 	}
 
+	// Include questionnaire coverage so the user can see what the interview covered
+	type InterviewCoverageGetter interface {
+		GetInterviewCoverage() *interviews.Report
+	}
+	if coverageGetter, ok := pmAgent.(InterviewCoverageGetter); ok {
+		response.Interview = coverageGetter.GetInterviewCoverage()
+	}
+
+	// Amendments show what changed against the approved spec
+	type AmendmentDiffGetter interface {
+		GetAmendmentDiff() string
+	}
+	if diffGetter, ok := pmAgent.(AmendmentDiffGetter); ok {
+		response.Amendment = diffGetter.GetAmendmentDiff()
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		s.logger.Error("Failed to encode PM preview spec response: %v", err)
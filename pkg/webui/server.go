@@ -31,6 +31,7 @@ import (
 	"orchestrator/pkg/logx"
 	"orchestrator/pkg/persistence"
 	"orchestrator/pkg/preflight"
+	"orchestrator/pkg/traceability"
 	"orchestrator/pkg/version"
 )
 
@@ -45,6 +46,17 @@ type StoryProvider interface {
 	GetStoryList() []*architect.QueuedStory
 }
 
+// TraceabilityProvider interface for agents that can build a spec's traceability matrix.
+type TraceabilityProvider interface {
+	GetTraceabilityReport(specID string) (*traceability.Report, error)
+}
+
+// EpicProvider interface for agents that manage epic branches.
+type EpicProvider interface {
+	GetEpics() []architect.EpicStatus
+	AcceptEpic(ctx context.Context, specID string) error
+}
+
 // DemoAvailabilityChecker interface for checking demo availability.
 // PM implements this to indicate when bootstrap is complete.
 type DemoAvailabilityChecker interface {
@@ -395,6 +407,9 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/api/agent/", s.requireAuth(s.handleAgent))
 	mux.HandleFunc("/api/queues", s.requireAuth(s.handleQueues))
 	mux.HandleFunc("/api/stories", s.requireAuth(s.handleStories))
+	mux.HandleFunc("/api/traceability", s.requireAuth(s.handleTraceability))
+	mux.HandleFunc("/api/epics", s.requireAuth(s.handleEpics))
+	mux.HandleFunc("/api/epics/accept", s.requireAuth(s.handleEpicAccept))
 	// NOTE: /api/upload removed - all specs must go through PM for validation
 	// Specs are uploaded inline via /api/pm/chat with file_content field
 	mux.HandleFunc("/api/answer", s.requireAuth(s.handleAnswer))
@@ -409,6 +424,7 @@ func (s *Server) RegisterRoutes(mux *http.ServeMux) {
 	// PM agent endpoints - specification development
 	mux.HandleFunc("/api/pm/start", s.requireAuth(s.handlePMStart))
 	mux.HandleFunc("/api/pm/chat", s.requireAuth(s.handlePMChat))
+	mux.HandleFunc("/api/pm/import-issue", s.requireAuth(s.handlePMImportIssue))
 	mux.HandleFunc("/api/pm/preview", s.requireAuth(s.handlePMPreview))
 	mux.HandleFunc("/api/pm/preview/action", s.requireAuth(s.handlePMPreviewAction))
 	mux.HandleFunc("/api/pm/preview/spec", s.requireAuth(s.handlePMPreviewGet))
@@ -749,6 +765,124 @@ func (s *Server) handleStories(w http.ResponseWriter, r *http.Request) {
 	s.logger.Debug("Served story information: %d stories", len(stories))
 }
 
+// handleTraceability implements GET /api/traceability.
+// Returns the requirement → story → PR matrix of a spec (spec_id, default: the spec in development)
+// as JSON, or as a markdown download with format=markdown.
+func (s *Server) handleTraceability(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var provider TraceabilityProvider
+	registeredAgents := s.dispatcher.GetRegisteredAgents()
+	for i := range registeredAgents {
+		agentInfo := &registeredAgents[i]
+		if agentInfo.Type == agent.TypeArchitect {
+			if p, ok := agentInfo.Driver.(TraceabilityProvider); ok {
+				provider = p
+				break
+			}
+		}
+	}
+	if provider == nil {
+		http.Error(w, "Architect not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	report, err := provider.GetTraceabilityReport(r.URL.Query().Get("spec_id"))
+	if err != nil {
+		s.logger.Debug("Traceability report unavailable: %v", err)
+		http.Error(w, fmt.Sprintf("Traceability report unavailable: %v", err), http.StatusNotFound)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "markdown" {
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"traceability-%s.md\"", report.SpecID))
+		if _, err := w.Write([]byte(report.Markdown())); err != nil {
+			s.logger.Error("Failed to write traceability markdown: %v", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		s.logger.Error("Failed to encode traceability response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// findEpicProvider returns the architect that manages epic branches, or nil.
+func (s *Server) findEpicProvider() EpicProvider {
+	registeredAgents := s.dispatcher.GetRegisteredAgents()
+	for i := range registeredAgents {
+		agentInfo := &registeredAgents[i]
+		if agentInfo.Type == agent.TypeArchitect {
+			if p, ok := agentInfo.Driver.(EpicProvider); ok {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// handleEpics implements GET /api/epics.
+// Returns the epic branches of the specs in the story queue.
+func (s *Server) handleEpics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	provider := s.findEpicProvider()
+	if provider == nil {
+		http.Error(w, "Architect not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(provider.GetEpics()); err != nil {
+		s.logger.Error("Failed to encode epics response: %v", err)
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// handleEpicAccept implements POST /api/epics/accept.
+// Merges a completed spec's epic branch into the target branch: the human Accept of ADR 0023.
+func (s *Server) handleEpicAccept(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var reqBody struct {
+		SpecID string `json:"spec_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil || reqBody.SpecID == "" {
+		http.Error(w, "Invalid JSON: spec_id is required", http.StatusBadRequest)
+		return
+	}
+
+	provider := s.findEpicProvider()
+	if provider == nil {
+		http.Error(w, "Architect not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := provider.AcceptEpic(r.Context(), reqBody.SpecID); err != nil {
+		s.logger.Warn("Failed to accept epic of spec %s: %v", reqBody.SpecID, err)
+		http.Error(w, fmt.Sprintf("Failed to accept epic: %v", err), http.StatusConflict)
+		return
+	}
+
+	s.logger.Info("Epic of spec %s accepted via web UI", reqBody.SpecID)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]string{"status": "accepted", "spec_id": reqBody.SpecID}); err != nil {
+		s.logger.Error("Failed to encode epic accept response: %v", err)
+	}
+}
+
 // handleDashboard serves the main dashboard page.
 // This also acts as a catch-all for client-side routes (SPA routing).
 func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
@@ -3,6 +3,7 @@ package webui
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -10,9 +11,11 @@ import (
 	"testing"
 
 	"orchestrator/pkg/agent"
+	"orchestrator/pkg/architect"
 	"orchestrator/pkg/config"
 	"orchestrator/pkg/dispatch"
 	"orchestrator/pkg/proto"
+	"orchestrator/pkg/traceability"
 )
 
 type mockAgent struct {
@@ -661,3 +664,146 @@ func TestEmbeddedTemplates(t *testing.T) {
 		t.Error("Templates should be loaded from embedded filesystem")
 	}
 }
+
+// mockTraceabilityArchitect is an architect that serves a fixed traceability report.
+type mockTraceabilityArchitect struct {
+	mockAgent
+	report *traceability.Report
+}
+
+func (m *mockTraceabilityArchitect) GetTraceabilityReport(specID string) (*traceability.Report, error) {
+	if specID != "" && specID != m.report.SpecID {
+		return nil, fmt.Errorf("spec %s not found", specID)
+	}
+	return m.report, nil
+}
+
+func TestHandleTraceability(t *testing.T) {
+	cfg := createTestConfig()
+	dispatcher, err := dispatch.NewDispatcher(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create dispatcher: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := dispatcher.Start(ctx); err != nil {
+		t.Fatalf("Failed to start dispatcher: %v", err)
+	}
+	defer dispatcher.Stop(ctx)
+
+	architectAgent := &mockTraceabilityArchitect{
+		mockAgent: mockAgent{id: "architect-001", typ: agent.TypeArchitect, state: "MONITORING"},
+		report: &traceability.Report{
+			SpecID: "spec-1",
+			Requirements: []traceability.RequirementTrace{
+				{ID: "R-001", Title: "Login", Status: traceability.StatusVerified},
+			},
+		},
+	}
+	if err := dispatcher.RegisterAgent(architectAgent); err != nil {
+		t.Fatalf("Failed to register architect: %v", err)
+	}
+
+	llmFactory := createTestLLMFactory(t)
+	defer llmFactory.Stop()
+
+	server := NewServer(dispatcher, "/tmp/test", nil, llmFactory)
+
+	w := httptest.NewRecorder()
+	server.handleTraceability(w, httptest.NewRequest("GET", "/api/traceability", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report traceability.Report
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if report.SpecID != "spec-1" || len(report.Requirements) != 1 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleTraceability(w, httptest.NewRequest("GET", "/api/traceability?format=markdown", nil))
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/markdown") {
+		t.Errorf("Expected markdown content type, got %q", w.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(w.Body.String(), "| R-001: Login | ✅ verified |") {
+		t.Errorf("Unexpected markdown:\n%s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	server.handleTraceability(w, httptest.NewRequest("GET", "/api/traceability?spec_id=spec-9", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown spec, got %d", w.Code)
+	}
+}
+
+// mockEpicArchitect is an architect with one epic awaiting acceptance.
+type mockEpicArchitect struct {
+	mockAgent
+	accepted []string
+}
+
+func (m *mockEpicArchitect) GetEpics() []architect.EpicStatus {
+	return []architect.EpicStatus{{SpecID: "spec-1", Branch: "maestro/epic/spec-1", TargetBranch: "main", State: architect.EpicStateAwaitingAccept}}
+}
+
+func (m *mockEpicArchitect) AcceptEpic(_ context.Context, specID string) error {
+	if specID != "spec-1" {
+		return fmt.Errorf("spec %s has no epic branch", specID)
+	}
+	m.accepted = append(m.accepted, specID)
+	return nil
+}
+
+func TestHandleEpics(t *testing.T) {
+	cfg := createTestConfig()
+	dispatcher, err := dispatch.NewDispatcher(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create dispatcher: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := dispatcher.Start(ctx); err != nil {
+		t.Fatalf("Failed to start dispatcher: %v", err)
+	}
+	defer dispatcher.Stop(ctx)
+
+	architectAgent := &mockEpicArchitect{mockAgent: mockAgent{id: "architect-001", typ: agent.TypeArchitect, state: "MONITORING"}}
+	if err := dispatcher.RegisterAgent(architectAgent); err != nil {
+		t.Fatalf("Failed to register architect: %v", err)
+	}
+
+	llmFactory := createTestLLMFactory(t)
+	defer llmFactory.Stop()
+
+	server := NewServer(dispatcher, "/tmp/test", nil, llmFactory)
+
+	w := httptest.NewRecorder()
+	server.handleEpics(w, httptest.NewRequest("GET", "/api/epics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var epics []architect.EpicStatus
+	if err := json.NewDecoder(w.Body).Decode(&epics); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(epics) != 1 || epics[0].State != architect.EpicStateAwaitingAccept {
+		t.Errorf("Unexpected epics: %+v", epics)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleEpicAccept(w, httptest.NewRequest("POST", "/api/epics/accept", strings.NewReader(`{"spec_id":"spec-1"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(architectAgent.accepted) != 1 {
+		t.Errorf("Expected the epic to be accepted, got %v", architectAgent.accepted)
+	}
+
+	w = httptest.NewRecorder()
+	server.handleEpicAccept(w, httptest.NewRequest("POST", "/api/epics/accept", strings.NewReader(`{"spec_id":"spec-9"}`)))
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for spec without epic, got %d", w.Code)
+	}
+}
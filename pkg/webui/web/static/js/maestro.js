@@ -95,12 +95,14 @@ class MaestroUI {
         this.pollServicesStatus();
         this.pollAgents();
         this.pollStories();
+        this.pollEpics();
         this.pollLogs();
         this.pollMessages();
         this.pollChat();
         setInterval(() => this.pollServicesStatus(), 5000); // Poll services every 5 seconds
         setInterval(() => this.pollAgents(), this.pollingInterval);
         setInterval(() => this.pollStories(), this.pollingInterval);
+        setInterval(() => this.pollEpics(), this.pollingInterval);
         setInterval(() => this.pollLogs(), this.pollingInterval);
         setInterval(() => this.pollMessages(), this.pollingInterval);
         setInterval(() => this.pollChat(), 2000); // Poll chat every 2 seconds (handles all channels)
@@ -293,6 +295,7 @@ class MaestroUI {
             if (!response.ok) throw new Error('Failed to fetch agents');
 
             const agents = await response.json();
+            this.agents = agents;
             this.updateAgentGrid(agents);
             this.checkEscalations(agents);
             this.updatePMStatus(agents);
@@ -319,6 +322,19 @@ class MaestroUI {
         }
     }
 
+    async pollEpics() {
+        try {
+            const response = await fetch('/api/epics');
+            if (!response.ok) throw new Error('Failed to fetch epics');
+
+            const epics = await response.json();
+            this.updateEpics(epics);
+
+        } catch (error) {
+            console.error('Error polling epics:', error);
+        }
+    }
+
     async pollLogs() {
         try {
             const domain = document.getElementById('log-domain').value;
@@ -620,6 +636,65 @@ class MaestroUI {
         }
     }
 
+    updateEpics(epics) {
+        const section = document.getElementById('epics-section');
+        const list = document.getElementById('epics-list');
+        if (!section || !list) return;
+
+        // Epic branches are opt-in (git.epic_branches); hide the section when there are none
+        if (!epics || epics.length === 0) {
+            section.classList.add('hidden');
+            return;
+        }
+        section.classList.remove('hidden');
+
+        const stateLabels = {
+            in_progress: ['In progress', 'bg-blue-100 text-blue-800'],
+            awaiting_accept: ['Awaiting accept', 'bg-yellow-100 text-yellow-800'],
+            accepted: ['Accepted', 'bg-green-100 text-green-800'],
+        };
+        list.innerHTML = epics.map(epic => {
+            const [label, badgeClass] = stateLabels[epic.state] || [epic.state, 'bg-gray-100 text-gray-800'];
+            const specId = this.escapeHtml(epic.spec_id);
+            const prLink = epic.pr_url && epic.pr_url.startsWith('http')
+                ? `<a href="${this.escapeHtml(epic.pr_url)}" target="_blank" class="text-sm text-maestro-blue hover:underline">Epic PR</a>`
+                : '';
+            const acceptButton = epic.state === 'awaiting_accept'
+                ? `<button class="px-3 py-1 text-sm bg-maestro-blue text-white rounded hover:bg-blue-700" onclick="window.maestroUI.acceptEpic('${specId}', this)">Accept</button>`
+                : '';
+            return `
+                <div class="border border-gray-200 rounded-lg p-4 mb-3 flex items-center justify-between">
+                    <div>
+                        <div class="font-mono text-sm text-gray-900">${this.escapeHtml(epic.branch)} → ${this.escapeHtml(epic.target_branch)}</div>
+                        <div class="text-sm text-gray-500">${epic.stories_done}/${epic.stories_total} stories merged</div>
+                    </div>
+                    <div class="flex items-center space-x-3">
+                        ${prLink}
+                        <span class="px-2 py-1 text-xs font-medium rounded-full ${badgeClass}">${label}</span>
+                        ${acceptButton}
+                    </div>
+                </div>
+            `;
+        }).join('');
+    }
+
+    async acceptEpic(specId, button) {
+        button.disabled = true;
+        try {
+            const response = await fetch('/api/epics/accept', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ spec_id: specId }),
+            });
+            if (!response.ok) throw new Error((await response.text()).trim());
+            this.showToast(`Epic of spec ${specId} merged`, 'success');
+            this.pollEpics();
+        } catch (error) {
+            this.showToast(`Failed to accept epic: ${error.message}`, 'error', 8000);
+            button.disabled = false;
+        }
+    }
+
     escapeHtml(text) {
         const div = document.createElement('div');
         div.textContent = text;
@@ -1752,10 +1827,22 @@ class MaestroUI {
             userInfo.classList.add('hidden');
             dropdownInput.classList.remove('hidden');
             freeInput.classList.add('hidden');
+            this.addSigningKeyOptions();
         }
         this.loadSecrets();
     }
 
+    // Offer a per-coder commit signing key (GIT_SIGNING_KEY_<AGENT_ID>) for each coder
+    addSigningKeyOptions() {
+        const select = document.getElementById('secrets-name-select');
+        (this.agents || []).filter(agent => agent.role === 'coder').forEach(agent => {
+            const name = 'GIT_SIGNING_KEY_' + agent.id.toUpperCase().replace(/[-.]/g, '_');
+            if (!select.querySelector(`option[value="${name}"]`)) {
+                select.add(new Option(name, name));
+            }
+        });
+    }
+
     async loadSecrets() {
         const list = document.getElementById('secrets-list');
         const warningEl = document.getElementById('secrets-password-warning');
@@ -24,6 +24,8 @@ class PMController {
 
         // Interview tab
         document.getElementById('start-interview-btn').addEventListener('click', () => this.startInterview());
+        document.getElementById('import-issue-interview-btn').addEventListener('click', () => this.importIssue('interview'));
+        document.getElementById('import-issue-draft-btn').addEventListener('click', () => this.importIssue('draft'));
         document.getElementById('interview-send-btn').addEventListener('click', () => this.sendInterviewMessage());
 
         // Textarea: Enter sends, Shift+Enter inserts newline
@@ -298,6 +300,41 @@ class PMController {
         }
     }
 
+    async importIssue(mode) {
+        const issue = document.getElementById('import-issue-ref').value.trim();
+        if (!issue) return;
+        const expertise = document.getElementById('expertise-level').value;
+        const buttons = ['import-issue-interview-btn', 'import-issue-draft-btn'].map(id => document.getElementById(id));
+
+        try {
+            buttons.forEach(btn => { btn.disabled = true; });
+
+            const response = await fetch('/api/pm/import-issue', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ issue, mode, expertise })
+            });
+
+            if (!response.ok) {
+                const error = await response.text();
+                throw new Error(error);
+            }
+
+            const result = await response.json();
+            this.sessionID = result.session_id;
+
+            // Show chat interface (status polling switches to the preview tab for drafts)
+            document.getElementById('interview-start-section').classList.add('hidden');
+            document.getElementById('interview-chat-section').classList.remove('hidden');
+
+            this.addInterviewMessage('system', result.message);
+        } catch (error) {
+            alert(`Failed to import issue: ${error.message}`);
+        } finally {
+            buttons.forEach(btn => { btn.disabled = false; });
+        }
+    }
+
     async sendInterviewMessage() {
         const textarea = document.getElementById('interview-input');
         const message = textarea.value.trim();
@@ -410,7 +447,9 @@ class PMController {
             // TODO: Use a proper markdown renderer library for better formatting
             if (result.markdown && result.markdown.length > 0) {
                 // Wrap in a pre tag to preserve formatting
-                previewDiv.innerHTML = `<pre class="whitespace-pre-wrap text-sm">${this.escapeHtml(result.markdown)}</pre>`;
+                previewDiv.innerHTML = this.renderAmendment(result.amendment) +
+                    this.renderInterviewCoverage(result.interview) +
+                    `<pre class="whitespace-pre-wrap text-sm">${this.escapeHtml(result.markdown)}</pre>`;
             } else {
                 // No spec available
                 previewDiv.innerHTML = '<div class="bg-yellow-50 border border-yellow-200 rounded-lg p-4"><p class="text-yellow-800"><strong>⏳ Specification Not Ready</strong></p><p class="text-sm text-yellow-600 mt-2">The specification is still being generated. Please wait...</p></div>';
@@ -426,6 +465,39 @@ class PMController {
         }
     }
 
+    // Render the requirement-level diff of an amendment above the spec (empty for new specs)
+    renderAmendment(amendment) {
+        if (!amendment) {
+            return '';
+        }
+        return `<div class="mb-4 bg-blue-50 border border-blue-200 rounded-lg p-3 text-sm"><strong>Amendment to the approved spec</strong>` +
+            `<pre class="whitespace-pre-wrap mt-2">${this.escapeHtml(amendment)}</pre></div>`;
+    }
+
+    // Render questionnaire coverage above the spec (empty when the project has no questionnaires)
+    renderInterviewCoverage(interview) {
+        if (!interview) {
+            return '';
+        }
+
+        if (interview.declined_reason) {
+            return `<div class="mb-4 bg-gray-50 border border-gray-200 rounded-lg p-3 text-sm text-gray-700"><strong>Interview questionnaires:</strong> none applied &mdash; ${this.escapeHtml(interview.declined_reason)}</div>`;
+        }
+
+        const sections = (interview.questionnaires || []).map(q => {
+            const items = q.questions.map(question => {
+                const icon = question.answer ? '✅' : (question.optional ? '➖' : '⬜');
+                const answer = question.answer ? `<div class="ml-6 text-gray-600">${this.escapeHtml(question.answer)}</div>` : '';
+                return `<li>${icon} ${this.escapeHtml(question.prompt)}${question.optional ? ' <span class="text-gray-400">(optional)</span>' : ''}${answer}</li>`;
+            }).join('');
+            return `<div class="mt-2"><p class="font-medium">${this.escapeHtml(q.title)} <span class="text-gray-500">(${q.covered}/${q.required} required covered)</span></p><ul class="space-y-1 mt-1">${items}</ul></div>`;
+        }).join('');
+
+        const color = interview.complete ? 'green' : 'yellow';
+        const heading = sections ? 'Interview questionnaire coverage' : 'No interview questionnaire selected yet';
+        return `<div class="mb-4 bg-${color}-50 border border-${color}-200 rounded-lg p-3 text-sm"><strong>${heading}</strong>${sections}</div>`;
+    }
+
     async continueInterview() {
         try {
             const sessionParam = this.sessionID || 'current';